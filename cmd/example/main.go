@@ -20,84 +20,114 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/golang/glog"
-	"golang.org/x/oauth2/google"
 
-	alpha "google.golang.org/api/compute/v0.alpha"
-	beta "google.golang.org/api/compute/v0.beta"
 	ga "google.golang.org/api/compute/v1"
 
 	"github.com/bowei/gce-gen/pkg/cloud"
 	"github.com/bowei/gce-gen/pkg/cloud/filter"
 	"github.com/bowei/gce-gen/pkg/cloud/meta"
+	"github.com/bowei/gce-gen/pkg/cloud/mock"
 )
 
 var flags = struct {
-	usemock bool
+	usemock   bool
+	mockstate string
+	record    string
+	replay    string
 }{}
 
 func init() {
 	flag.BoolVar(&flags.usemock, "usemock", false, "usemock")
+	flag.StringVar(&flags.mockstate, "mockstate", "", "JSON file of the objects of the mock with -usemock (see mock.MockGCE.Save), e.g. cmd/example/mockstate.json")
+	flag.StringVar(&flags.record, "record", "", "JSON file to save the calls made by the example to (see cloud.RecordingCloud)")
+	flag.StringVar(&flags.replay, "replay", "", "JSON file of the calls saved with -record to serve the calls from, without calling GCE (see cloud.ReplayCloud)")
 }
 
 func mockCloud() cloud.Cloud {
-	mock := cloud.NewMockGCE()
-	mock.MockZones.Objects[*meta.ZonalKey("abc", "us-central1-b")] = &cloud.MockZonesObj{
-		ga.Zone{Name: "us-central1-b"},
+	m := mock.NewMockGCE()
+	m.MockZones.Objects[*meta.ZonalKey("abc", "us-central1-b")] = &mock.MockZonesObj{
+		Obj: &ga.Zone{Name: "us-central1-b"},
+	}
+	if flags.mockstate != "" {
+		f, err := os.Open(flags.mockstate)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := m.Load(f); err != nil {
+			log.Fatal(err)
+		}
 	}
-	return mock
+	return m
 }
 
-func realCloud() cloud.Cloud {
-	var g *ga.Service
-	var a *alpha.Service
-	var b *beta.Service
-
-	c, err := google.DefaultClient(context.Background(), ga.CloudPlatformScope)
+func replayCloud() cloud.Cloud {
+	f, err := os.Open(flags.replay)
 	if err != nil {
 		log.Fatal(err)
 	}
-	g, err = ga.New(c)
+	defer f.Close()
+	cassette, err := cloud.LoadCassette(f)
 	if err != nil {
 		log.Fatal(err)
 	}
-	c, err = google.DefaultClient(context.Background(), alpha.CloudPlatformScope)
+	return cloud.NewReplayCloud(cassette)
+}
+
+// saveCassette saves the calls recorded by c to the file of -record.
+func saveCassette(c *cloud.RecordingCloud) {
+	f, err := os.Create(flags.record)
 	if err != nil {
 		log.Fatal(err)
 	}
-	a, err = alpha.New(c)
-	if err != nil {
+	defer f.Close()
+	if err := c.Cassette().Save(f); err != nil {
 		log.Fatal(err)
 	}
-	c, err = google.DefaultClient(context.Background(), beta.CloudPlatformScope)
+}
+
+// scopes are the OAuth scopes needed by the services used by the example.
+var scopes = meta.RequiredScopes(
+	meta.AllServicesByGroup["Addresses"].GA,
+	meta.AllServicesByGroup["Firewalls"].GA,
+	meta.AllServicesByGroup["Projects"].GA,
+)
+
+func realCloud() cloud.Cloud {
+	s, err := cloud.NewService(context.Background(),
+		cloud.WithScopes(scopes...),
+		cloud.WithDefaultProject("bowei-gke"),
+		cloud.WithRateLimiter(&cloud.NopRateLimiter{}),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
-	b, err = beta.New(c)
-	if err != nil {
+	if err := s.HealthCheck(context.Background()).Err(); err != nil {
 		log.Fatal(err)
 	}
-
-	gce := cloud.NewGCE(&cloud.Service{
-		GA:            g,
-		Alpha:         a,
-		Beta:          b,
-		ProjectRouter: &cloud.SingleProjectRouter{ID: "bowei-gke"},
-		RateLimiter:   &cloud.NopRateLimiter{},
-	})
-	return gce
+	return cloud.NewGCE(s)
 }
 
 func main() {
 	flag.Parse()
 
 	var c cloud.Cloud
-	if flags.usemock {
+	switch {
+	case flags.replay != "":
+		c = replayCloud()
+	case flags.usemock:
 		c = mockCloud()
-	} else {
+	default:
 		c = realCloud()
 	}
+	if flags.record != "" {
+		rc := cloud.NewRecordingCloud(c)
+		defer saveCassette(rc)
+		c = rc
+	}
 
 	glog.Infof("List addresses")
 	addrs, err := c.Addresses().List(context.Background(), "us-central1", filter.None)
@@ -0,0 +1,108 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff is a policy of delays between the attempts of an action: the
+// retries of a call (see RetryPolicy), the polls of an operation (see
+// PollPolicy) and the calls throttled after a quota error (see
+// AdaptiveRateLimiter.QuotaBackoff). The zero value waits no time, e.g. for
+// the tests:
+//
+//	s.Backoff = &cloud.Backoff{}
+type Backoff struct {
+	// Initial is the delay before the first attempt that waits.
+	Initial time.Duration
+	// Multiplier is the factor applied to the delay after each attempt. A
+	// value of 1 or less keeps the delay constant.
+	Multiplier float64
+	// Max is the longest delay, if non-zero.
+	Max time.Duration
+	// Jitter is the fraction of each delay that is randomized, between 0
+	// and 1: the delay d is picked uniformly in [d-Jitter*d, d+Jitter*d]
+	// so that clients started together do not retry in lockstep.
+	Jitter float64
+}
+
+// Delay returns the delay before the given attempt, 1 for the first one
+// that waits: Initial, multiplied by Multiplier for each following attempt
+// up to Max, and randomized by Jitter.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 1; i < attempt && b.Multiplier > 1 && (b.Max <= 0 || d < b.Max); i++ {
+		d = b.next(d)
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return b.jitter(d)
+}
+
+// next returns the delay following a delay of d, without jitter.
+func (b *Backoff) next(d time.Duration) time.Duration {
+	if b.Multiplier > 1 {
+		if f := float64(d) * b.Multiplier; f < math.MaxInt64 {
+			d = time.Duration(f)
+		} else {
+			d = math.MaxInt64
+		}
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// jitter randomizes d by Jitter.
+func (b *Backoff) jitter(d time.Duration) time.Duration {
+	if b.Jitter <= 0 || d <= 0 {
+		return d
+	}
+	j := math.Min(b.Jitter, 1)
+	return time.Duration(float64(d) * (1 + j*(2*rand.Float64()-1)))
+}
+
+// retryPolicy returns the RetryPolicy of the calls, with the Backoff of the
+// Service if it has none of its own.
+func (g *Service) retryPolicy() *RetryPolicy {
+	p := g.RetryPolicy
+	if p == nil || p.Backoff != nil || g.Backoff == nil {
+		return p
+	}
+	c := *p
+	c.Backoff = g.Backoff
+	return &c
+}
+
+// withBackoff returns p with the Backoff of the Service if it has none of
+// its own.
+func (g *Service) withBackoff(p *PollPolicy) *PollPolicy {
+	if p != nil && p.Backoff != nil || g.Backoff == nil {
+		return p
+	}
+	c := PollPolicy{}
+	if p != nil {
+		c = *p
+	}
+	c.Backoff = g.Backoff
+	return &c
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		b       Backoff
+		attempt int
+		want    time.Duration
+	}{
+		{Backoff{}, 1, 0},
+		{Backoff{}, 10, 0},
+		{Backoff{Initial: time.Second}, 1, time.Second},
+		{Backoff{Initial: time.Second}, 5, time.Second},
+		{Backoff{Initial: time.Second, Multiplier: 2}, 1, time.Second},
+		{Backoff{Initial: time.Second, Multiplier: 2}, 4, 8 * time.Second},
+		{Backoff{Initial: time.Second, Multiplier: 1.5}, 3, 2250 * time.Millisecond},
+		{Backoff{Initial: time.Second, Multiplier: 2, Max: 5 * time.Second}, 3, 4 * time.Second},
+		{Backoff{Initial: time.Second, Multiplier: 2, Max: 5 * time.Second}, 4, 5 * time.Second},
+		{Backoff{Initial: time.Second, Multiplier: 2, Max: 5 * time.Second}, 1000, 5 * time.Second},
+		{Backoff{Initial: time.Second, Multiplier: 2}, 1000, math.MaxInt64},
+		{Backoff{Initial: 10 * time.Second, Max: 5 * time.Second}, 1, 5 * time.Second},
+	} {
+		if got := tc.b.Delay(tc.attempt); got != tc.want {
+			t.Errorf("%+v.Delay(%d) = %v; want %v", tc.b, tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	t.Parallel()
+
+	b := &Backoff{Initial: time.Second, Jitter: 0.5}
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 100; i++ {
+		d := b.Delay(1)
+		if d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Delay(1) = %v; want within [500ms, 1.5s]", d)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Delay(1) = %v 100 times; want randomized delays", seen)
+	}
+	b.Jitter = 5
+	for i := 0; i < 100; i++ {
+		if d := b.Delay(1); d < 0 || d > 2*time.Second {
+			t.Fatalf("Delay(1) = %v with Jitter 5; want within [0, 2s]", d)
+		}
+	}
+}
+
+func TestServiceBackoff(t *testing.T) {
+	t.Parallel()
+
+	// The delays of the policies would time the test out if Service.Backoff
+	// did not replace them.
+	slow := &Backoff{Initial: time.Hour}
+	for _, tc := range []struct {
+		desc    string
+		backoff *Backoff
+		retry   *RetryPolicy
+		poll    *PollPolicy
+	}{
+		{
+			desc:    "service backoff",
+			backoff: &Backoff{},
+			retry:   &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, Mutations: true},
+			poll:    &PollPolicy{Interval: time.Hour},
+		},
+		{
+			desc:    "no poll policy",
+			backoff: &Backoff{},
+			retry:   &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, Mutations: true},
+		},
+		{
+			desc:    "policy backoffs",
+			backoff: slow,
+			retry:   &RetryPolicy{MaxAttempts: 3, Backoff: &Backoff{}, Mutations: true},
+			poll:    &PollPolicy{Backoff: &Backoff{}},
+		},
+	} {
+		calls := 0
+		s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			switch {
+			case r.Method == http.MethodPost && calls == 1:
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			case r.Method == http.MethodPost:
+				writeJSON(t, w, &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"})
+			case calls < 5:
+				writeJSON(t, w, &ga.Operation{Name: "op", Status: "RUNNING"})
+			default:
+				writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+			}
+		})
+		s.RateLimiter = NewMinimumRateLimiter(0)
+		s.Backoff = tc.backoff
+		s.RetryPolicy = tc.retry
+		s.PollPolicy = tc.poll
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := NewGCE(s).Firewalls().Insert(ctx, *meta.GlobalKey("fw"), &ga.Firewall{})
+		cancel()
+		if err != nil || calls != 5 {
+			t.Errorf("%s: Insert() = %v after %d calls; want nil after 5 calls", tc.desc, err, calls)
+		}
+	}
+}
+
+func TestServicePolicies(t *testing.T) {
+	t.Parallel()
+
+	b := &Backoff{Initial: time.Second}
+	s := &Service{Backoff: b}
+	if got := s.retryPolicy(); got != nil {
+		t.Errorf("retryPolicy() = %+v; want nil without a RetryPolicy", got)
+	}
+	s.RetryPolicy = &RetryPolicy{MaxAttempts: 2}
+	if got := s.retryPolicy(); got.Backoff != b || got.MaxAttempts != 2 || s.RetryPolicy.Backoff != nil {
+		t.Errorf("retryPolicy() = %+v; want the RetryPolicy with the Backoff of the Service", got)
+	}
+	if got := s.withBackoff(nil); got == nil || got.Backoff != b {
+		t.Errorf("withBackoff(nil) = %+v; want a PollPolicy with the Backoff of the Service", got)
+	}
+	own := &PollPolicy{Backoff: &Backoff{}}
+	if got := s.withBackoff(own); got != own {
+		t.Errorf("withBackoff(%+v) = %+v; want it unchanged", own, got)
+	}
+	s.Backoff = nil
+	if got := s.withBackoff(nil); got != nil {
+		t.Errorf("withBackoff(nil) = %+v; want nil without a Backoff", got)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// DefaultBatchConcurrency is the number of calls in flight of a batch method
+// (e.g. BatchGet) when Service.BatchConcurrency is 0.
+const DefaultBatchConcurrency = 16
+
+// BatchError is returned by the batch methods (e.g. BatchGet) when some of
+// the calls failed.
+type BatchError struct {
+	// Errors are the errors of the calls in the order of the keys, nil for
+	// the calls that succeeded.
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	var first error
+	n := 0
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			n++
+		}
+	}
+	return fmt.Sprintf("%d of %d calls failed, first error: %v", n, len(e.Errors), first)
+}
+
+// Unwrap returns the errors of the calls that failed, for errors.Is() and
+// errors.As().
+func (e *BatchError) Unwrap() []error {
+	var ret []error
+	for _, err := range e.Errors {
+		if err != nil {
+			ret = append(ret, err)
+		}
+	}
+	return ret
+}
+
+// batch calls call for each of keys with at most concurrency calls in flight
+// (DefaultBatchConcurrency if 0). It returns a *BatchError if any of the
+// calls failed. The keys that are not called once ctx is done fail with the
+// error of ctx.
+func batch(ctx context.Context, keys []meta.Key, concurrency int, call func(ctx context.Context, i int, key meta.Key) error) error {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, key meta.Key) {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = call(ctx, i, key)
+		}(i, key)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return &BatchError{Errors: errs}
+		}
+	}
+	return nil
+}
+
+// BatchGet calls get for each of keys with at most concurrency calls in
+// flight and returns the objects in the order of the keys, nil for the calls
+// that failed. The error is a *BatchError if any of the calls failed. This
+// implements the generated BatchGet() methods.
+func BatchGet[T any](ctx context.Context, keys []meta.Key, concurrency int, get func(context.Context, meta.Key, ...Option) (*T, error)) ([]*T, error) {
+	objs := make([]*T, len(keys))
+	err := batch(ctx, keys, concurrency, func(ctx context.Context, i int, key meta.Key) error {
+		obj, err := get(ctx, key)
+		objs[i] = obj
+		return err
+	})
+	return objs, err
+}
+
+// BatchDelete calls del for each of keys with at most concurrency calls in
+// flight. The error is a *BatchError if any of the calls failed. This
+// implements the generated BatchDelete() methods.
+func BatchDelete(ctx context.Context, keys []meta.Key, concurrency int, del func(context.Context, meta.Key, ...Option) error) error {
+	return batch(ctx, keys, concurrency, func(ctx context.Context, _ int, key meta.Key) error {
+		return del(ctx, key)
+	})
+}
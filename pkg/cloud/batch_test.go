@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestBatchGet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/compute/v1/projects/proj/global/firewalls/")
+		if !strings.HasPrefix(name, "fw-") {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(t, w, &ga.Firewall{Name: name})
+	})
+	s.BatchConcurrency = 2
+	keys := []meta.Key{*meta.GlobalKey("fw-1"), *meta.GlobalKey("other"), *meta.GlobalKey("fw-2")}
+
+	objs, err := NewGCE(s).Firewalls().BatchGet(ctx, keys)
+	if len(objs) != 3 || objs[0].Name != "fw-1" || objs[1] != nil || objs[2].Name != "fw-2" {
+		t.Errorf("Firewalls().BatchGet(%v) = %v, _; want [fw-1 nil fw-2]", keys, objs)
+	}
+	var be *BatchError
+	if !errors.As(err, &be) || len(be.Errors) != 3 || be.Errors[0] != nil || !IsNotFound(be.Errors[1]) || be.Errors[2] != nil {
+		t.Fatalf("Firewalls().BatchGet(%v) = _, %v; want a BatchError with http.StatusNotFound for other", keys, err)
+	}
+	if got, want := err.Error(), "1 of 3 calls failed, first error: "; !strings.HasPrefix(got, want) {
+		t.Errorf("Error() = %q; want prefix %q", got, want)
+	}
+	if _, err := NewGCE(s).Firewalls().BatchGet(ctx, keys[:1]); err != nil {
+		t.Errorf("Firewalls().BatchGet(%v) = _, %v; want nil", keys[:1], err)
+	}
+}
+
+func TestBatchConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lock             sync.Mutex
+		inFlight, maxOut int
+	)
+	var keys []meta.Key
+	for i := 0; i < 10; i++ {
+		keys = append(keys, *meta.GlobalKey("fw"))
+	}
+	err := BatchDelete(context.Background(), keys, 3, func(ctx context.Context, key meta.Key, _ ...Option) error {
+		lock.Lock()
+		inFlight++
+		if inFlight > maxOut {
+			maxOut = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(time.Millisecond)
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Errorf("BatchDelete() = %v; want nil", err)
+	}
+	if maxOut > 3 {
+		t.Errorf("%d calls in flight; want at most 3", maxOut)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = BatchDelete(ctx, keys, 1, func(ctx context.Context, key meta.Key, _ ...Option) error { return nil })
+	var be *BatchError
+	if !errors.As(err, &be) || !errors.Is(err, context.Canceled) {
+		t.Errorf("BatchDelete() with a canceled context = %v; want a BatchError with context.Canceled", err)
+	}
+}
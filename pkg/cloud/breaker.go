@@ -0,0 +1,261 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// CircuitBreakerPolicy is the policy of the circuit breakers of a
+// CircuitBreakerCloud.
+type CircuitBreakerPolicy struct {
+	// Window is the period over which the ratio of failed calls is
+	// measured.
+	Window time.Duration
+	// MinCalls is the number of calls in a Window below which the breaker
+	// does not trip.
+	MinCalls int
+	// FailureRatio is the ratio of failed calls in a Window that trips the
+	// breaker.
+	FailureRatio float64
+	// OpenDuration is how long the tripped breaker fails the calls before
+	// letting a probe call through.
+	OpenDuration time.Duration
+	// Failure, if set, classifies the errors counted as failures instead of
+	// IsBreakerFailure.
+	Failure func(err error) bool
+}
+
+// DefaultCircuitBreakerPolicy returns a CircuitBreakerPolicy tripping when
+// half of at least 20 calls in a minute fail, and probing the service again
+// after 30s.
+func DefaultCircuitBreakerPolicy() *CircuitBreakerPolicy {
+	return &CircuitBreakerPolicy{
+		Window:       time.Minute,
+		MinCalls:     20,
+		FailureRatio: 0.5,
+		OpenDuration: 30 * time.Second,
+	}
+}
+
+// IsBreakerFailure is true if err is a sign of a degraded API: a
+// googleapi.Error with a 5xx status code or a timeout.
+func IsBreakerFailure(err error) bool {
+	if apiErr, ok := apiError(err); ok && apiErr.Code >= http.StatusInternalServerError {
+		return true
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// CircuitState is the state of the circuit breaker of a service.
+type CircuitState string
+
+const (
+	// CircuitClosed lets the calls through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails the calls with a *CircuitOpenError.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe call through, which closes the
+	// breaker if it succeeds and opens it again otherwise.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitOpenError is the error of the calls failed by an open circuit
+// breaker, without calling the API.
+type CircuitOpenError struct {
+	Version meta.Version
+	Service string
+	// Until is when the breaker lets a probe call through.
+	Until time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker of %s %s is open until %s", e.Version, e.Service, e.Until.Format(time.RFC3339))
+}
+
+// IsCircuitOpen is true if err is (or wraps) a *CircuitOpenError.
+func IsCircuitOpen(err error) bool {
+	var e *CircuitOpenError
+	return errors.As(err, &e)
+}
+
+// CircuitBreakerCloud is a Cloud that guards the calls of each service of
+// another Cloud with a circuit breaker: once the ratio of the calls failing
+// with a 5xx or a timeout trips the breaker, the calls fail fast with a
+// *CircuitOpenError (see IsCircuitOpen()) instead of hammering the degraded
+// API and spending the quota on retries. After OpenDuration a probe call is
+// let through, which closes the breaker if it succeeds:
+//
+//	c := cloud.NewCircuitBreakerCloud(cloud.NewGCE(svc), cloud.DefaultCircuitBreakerPolicy())
+//	obj, err := c.Firewalls().Get(ctx, key)
+//	if cloud.IsCircuitOpen(err) {
+//		// Requeue without calling the API.
+//	}
+//
+// Each method is one call, whatever the number of API calls it makes (e.g.
+// BatchGet). The hand written methods of the services with CustomOps are not
+// guarded.
+type CircuitBreakerCloud struct {
+	c      Cloud
+	policy *CircuitBreakerPolicy
+	// now is time.Now, replaced by the tests.
+	now func() time.Time
+
+	lock     sync.Mutex
+	breakers map[breakerKey]*breaker
+}
+
+// CircuitBreakerCloud implements Cloud.
+var _ Cloud = (*CircuitBreakerCloud)(nil)
+
+// NewCircuitBreakerCloud returns a CircuitBreakerCloud guarding the calls of
+// c according to policy.
+func NewCircuitBreakerCloud(c Cloud, policy *CircuitBreakerPolicy) *CircuitBreakerCloud {
+	return &CircuitBreakerCloud{
+		c:        c,
+		policy:   policy,
+		now:      time.Now,
+		breakers: map[breakerKey]*breaker{},
+	}
+}
+
+type breakerKey struct {
+	version meta.Version
+	service string
+}
+
+// breaker is the circuit breaker of a service.
+type breaker struct {
+	state CircuitState
+	// generation is incremented on each change of state, so that the
+	// outcome of a call let through in a previous state is ignored.
+	generation uint64
+	// windowStart, calls and failures count the calls of the current
+	// Window while closed.
+	windowStart     time.Time
+	calls, failures int
+	// openedAt is when the breaker was last opened.
+	openedAt time.Time
+}
+
+// setState changes the state of b.
+func (b *breaker) setState(state CircuitState) {
+	b.state = state
+	b.generation++
+}
+
+// breakerCall is a call let through by a breaker, whose outcome is recorded
+// by record().
+type breakerCall struct {
+	b *breaker
+	// generation is the generation of b when the call was let through.
+	generation uint64
+	// probe is true for the probe call of a half-open breaker.
+	probe bool
+}
+
+// State returns the state of the circuit breaker of service.
+func (c *CircuitBreakerCloud) State(version meta.Version, service string) CircuitState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if b, ok := c.breakers[breakerKey{version, service}]; ok {
+		return b.state
+	}
+	return CircuitClosed
+}
+
+// allow returns the call to record() if it can be made, a *CircuitOpenError
+// otherwise.
+func (c *CircuitBreakerCloud) allow(version meta.Version, service string) (*breakerCall, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	k := breakerKey{version, service}
+	b, ok := c.breakers[k]
+	if !ok {
+		b = &breaker{state: CircuitClosed, windowStart: now}
+		c.breakers[k] = b
+	}
+	switch b.state {
+	case CircuitOpen:
+		until := b.openedAt.Add(c.policy.OpenDuration)
+		if now.Before(until) {
+			return nil, &CircuitOpenError{version, service, until}
+		}
+		// This call is the probe.
+		b.setState(CircuitHalfOpen)
+		return &breakerCall{b, b.generation, true}, nil
+	case CircuitHalfOpen:
+		// The probe is in flight.
+		return nil, &CircuitOpenError{version, service, b.openedAt.Add(c.policy.OpenDuration)}
+	default:
+		if now.Sub(b.windowStart) >= c.policy.Window {
+			b.windowStart, b.calls, b.failures = now, 0, 0
+		}
+	}
+	return &breakerCall{b, b.generation, false}, nil
+}
+
+// record updates the breaker of call, returned by allow(), with the outcome
+// err of the call. The outcome of a call let through before the last change
+// of state is ignored: only the probe decides whether a half-open breaker
+// closes.
+func (c *CircuitBreakerCloud) record(call *breakerCall, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	b := call.b
+	if call.generation != b.generation {
+		return
+	}
+	failure := c.policy.Failure
+	if failure == nil {
+		failure = IsBreakerFailure
+	}
+	now := c.now()
+	switch {
+	case call.probe && errors.Is(err, context.Canceled):
+		// The probe tells nothing; the next call probes again.
+		b.setState(CircuitOpen)
+	case call.probe && failure(err):
+		b.setState(CircuitOpen)
+		b.openedAt = now
+	case call.probe:
+		b.setState(CircuitClosed)
+		b.windowStart, b.calls, b.failures = now, 0, 0
+	case !errors.Is(err, context.Canceled):
+		b.calls++
+		if failure(err) {
+			b.failures++
+		}
+		if b.calls >= c.policy.MinCalls && float64(b.failures) >= c.policy.FailureRatio*float64(b.calls) {
+			b.setState(CircuitOpen)
+			b.openedAt = now
+		}
+	}
+}
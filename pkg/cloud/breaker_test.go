@@ -0,0 +1,177 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestIsBreakerFailure(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{&googleapi.Error{Code: http.StatusServiceUnavailable}, true},
+		{&googleapi.Error{Code: http.StatusNotFound}, false},
+		{&Error{Err: &googleapi.Error{Code: http.StatusBadGateway}}, true},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), true},
+		{context.Canceled, false},
+		{errors.New("other"), false},
+	} {
+		if got := IsBreakerFailure(tc.err); got != tc.want {
+			t.Errorf("IsBreakerFailure(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestCircuitBreakerCloud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	status := http.StatusServiceUnavailable
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/compute/v1/projects/proj/global/firewalls/fw" && status != http.StatusOK {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	})
+	now := time.Unix(1000, 0)
+	c := NewCircuitBreakerCloud(NewGCE(s), &CircuitBreakerPolicy{
+		Window:       time.Minute,
+		MinCalls:     3,
+		FailureRatio: 0.5,
+		OpenDuration: time.Minute,
+	})
+	c.now = func() time.Time { return now }
+	key := *meta.GlobalKey("fw")
+	get := func() error {
+		_, err := c.Firewalls().Get(ctx, key)
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := get(); IsCircuitOpen(err) || err == nil {
+			t.Fatalf("Get() #%d = _, %v; want a 503", i, err)
+		}
+	}
+	if got := c.State(meta.VersionGA, "Firewalls"); got != CircuitOpen {
+		t.Fatalf("State() = %q after 3 failures; want %q", got, CircuitOpen)
+	}
+	if err := get(); !IsCircuitOpen(err) || calls != 3 {
+		t.Errorf("Get() = _, %v with %d calls to the API; want a CircuitOpenError with 3 calls", err, calls)
+	}
+	// The other services are not affected.
+	if _, err := c.Zones().Get(ctx, *meta.GlobalKey("us-central1-b")); err != nil {
+		t.Errorf("Zones().Get() = _, %v; want nil", err)
+	}
+
+	// The probe fails and the breaker opens again.
+	now = now.Add(time.Minute)
+	if err := get(); IsCircuitOpen(err) || err == nil {
+		t.Errorf("Get() probe = _, %v; want a 503", err)
+	}
+	if got := c.State(meta.VersionGA, "Firewalls"); got != CircuitOpen {
+		t.Errorf("State() = %q after a failed probe; want %q", got, CircuitOpen)
+	}
+
+	// The probe succeeds and closes the breaker.
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	if err := get(); err != nil {
+		t.Errorf("Get() probe = _, %v; want nil", err)
+	}
+	if got := c.State(meta.VersionGA, "Firewalls"); got != CircuitClosed {
+		t.Errorf("State() = %q after a successful probe; want %q", got, CircuitClosed)
+	}
+}
+
+func TestCircuitBreakerStaleOutcomes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	c := NewCircuitBreakerCloud(nil, &CircuitBreakerPolicy{
+		Window:       time.Minute,
+		MinCalls:     2,
+		FailureRatio: 0.5,
+		OpenDuration: time.Minute,
+	})
+	c.now = func() time.Time { return now }
+	unavailable := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	allow := func() *breakerCall {
+		t.Helper()
+		call, err := c.allow(meta.VersionGA, "Firewalls")
+		if err != nil {
+			t.Fatalf("allow() = _, %v; want nil", err)
+		}
+		return call
+	}
+	state := func() CircuitState { return c.State(meta.VersionGA, "Firewalls") }
+
+	// Two slow calls are let through while the breaker is closed, then two
+	// failures open it.
+	slowOK, slowFailed := allow(), allow()
+	c.record(allow(), unavailable)
+	c.record(allow(), unavailable)
+	if got := state(); got != CircuitOpen {
+		t.Fatalf("State() = %q after 2 failures; want %q", got, CircuitOpen)
+	}
+
+	now = now.Add(time.Minute)
+	probe := allow()
+	// The slow calls return while the probe is in flight: they do not
+	// decide the state of the breaker.
+	c.record(slowOK, nil)
+	if got := state(); got != CircuitHalfOpen {
+		t.Errorf("State() = %q after a stale success; want %q", got, CircuitHalfOpen)
+	}
+	c.record(slowFailed, unavailable)
+	if got := state(); got != CircuitHalfOpen {
+		t.Errorf("State() = %q after a stale failure; want %q", got, CircuitHalfOpen)
+	}
+	if _, err := c.allow(meta.VersionGA, "Firewalls"); !IsCircuitOpen(err) {
+		t.Errorf("allow() = _, %v while the probe is in flight; want a CircuitOpenError", err)
+	}
+
+	c.record(probe, nil)
+	if got := state(); got != CircuitClosed {
+		t.Errorf("State() = %q after a successful probe; want %q", got, CircuitClosed)
+	}
+	// A call let through before the breaker closed does not count in the
+	// new window.
+	c.record(slowFailed, unavailable)
+	c.record(allow(), unavailable)
+	if got := state(); got != CircuitClosed {
+		t.Errorf("State() = %q after a stale and a current failure; want %q", got, CircuitClosed)
+	}
+}
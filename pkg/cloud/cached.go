@@ -0,0 +1,261 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// CachedCloud is a Cloud that caches the results of the Get and list calls
+// (List, AggregatedList, ...) of another Cloud for a TTL:
+//
+//	c := cloud.NewCachedCloud(cloud.NewGCE(svc), 30*time.Second)
+//	obj, err := c.Firewalls().Get(ctx, *meta.GlobalKey("fw"))
+//
+// The mutations made through the CachedCloud (Insert, Delete, SetTarget, ...)
+// invalidate the object of their key and the lists of its service at every
+// API version. Changes made by others are seen once the TTL expires or after
+// Invalidate(). Errors are not cached. The hand written methods of the
+// services with CustomOps are not cached and do not invalidate anything.
+//
+// The cached objects are shared between the callers and must not be
+// modified (see the Copy<Version><Object> functions).
+type CachedCloud struct {
+	c   Cloud
+	ttl time.Duration
+	// now is time.Now, replaced by the tests.
+	now func() time.Time
+
+	lock      sync.Mutex
+	entries   map[cacheKey]*cacheEntry
+	lastSweep time.Time
+}
+
+// CachedCloud implements Cloud.
+var _ Cloud = (*CachedCloud)(nil)
+
+// NewCachedCloud returns a CachedCloud caching the reads of c for ttl.
+func NewCachedCloud(c Cloud, ttl time.Duration) *CachedCloud {
+	return &CachedCloud{
+		c:       c,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[cacheKey]*cacheEntry{},
+	}
+}
+
+// cacheKey identifies the result of a call.
+type cacheKey struct {
+	version meta.Version
+	service string
+	method  string
+	// key is the key of the object, empty for the lists of a collection.
+	key string
+	// args are the other arguments of the call (see cacheArgs()).
+	args string
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// cacheArgs formats the arguments of a call for its cacheKey.
+func cacheArgs(args ...interface{}) string {
+	var ret []string
+	for _, a := range args {
+		if fl, ok := a.(*filter.F); ok && fl == nil {
+			a = "<nil>"
+		}
+		ret = append(ret, fmt.Sprint(a))
+	}
+	return strings.Join(ret, ", ")
+}
+
+// cachedRead returns the cached result of the call k, calling read if it is
+// not cached.
+func cachedRead[T any](c *CachedCloud, k cacheKey, read func() (T, error)) (T, error) {
+	if v, ok := c.get(k); ok {
+		return v.(T), nil
+	}
+	v, err := read()
+	if err != nil {
+		return v, err
+	}
+	c.put(k, v)
+	return v, nil
+}
+
+// cachedBatchGet returns the cached objects of keys, calling batchGet for the
+// keys that are not cached. It shares the cache of Get.
+func cachedBatchGet[T any](c *CachedCloud, version meta.Version, service string, keys []meta.Key, batchGet func([]meta.Key) ([]*T, error)) ([]*T, error) {
+	objs := make([]*T, len(keys))
+	var missing []meta.Key
+	// idx are the indices in keys of the missing keys.
+	var idx []int
+	for i, key := range keys {
+		if v, ok := c.get(cacheKey{version, service, "Get", key.String(), ""}); ok {
+			objs[i] = v.(*T)
+			continue
+		}
+		missing = append(missing, key)
+		idx = append(idx, i)
+	}
+	if len(missing) == 0 {
+		return objs, nil
+	}
+
+	got, err := batchGet(missing)
+	var errs []error
+	if err != nil {
+		// Map the errors of the missing keys back to keys.
+		errs = make([]error, len(keys))
+		be, ok := err.(*BatchError)
+		for j, i := range idx {
+			switch {
+			case !ok:
+				errs[i] = err
+			case j < len(be.Errors):
+				errs[i] = be.Errors[j]
+			}
+		}
+	}
+	for j, i := range idx {
+		if j >= len(got) || got[j] == nil || (errs != nil && errs[i] != nil) {
+			continue
+		}
+		objs[i] = got[j]
+		c.put(cacheKey{version, service, "Get", keys[i].String(), ""}, got[j])
+	}
+	if errs != nil {
+		return objs, &BatchError{Errors: errs}
+	}
+	return objs, nil
+}
+
+func (c *CachedCloud) get(k cacheKey) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, k)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *CachedCloud) put(k cacheKey, v interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	// Drop the expired entries once per TTL so that the results of calls
+	// that are not repeated do not accumulate.
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[k] = &cacheEntry{value: v, expires: now.Add(c.ttl)}
+}
+
+// remove removes the entries for which match is true.
+func (c *CachedCloud) remove(match func(k cacheKey) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for k := range c.entries {
+		if match(k) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// invalidate removes the object of key and the lists of the collection of
+// service, at every version.
+func (c *CachedCloud) invalidate(service string, key meta.Key) {
+	ks := key.String()
+	c.remove(func(k cacheKey) bool {
+		return k.service == service && (k.key == ks || k.key == "")
+	})
+}
+
+// invalidateKeys removes the objects of keys and the lists of the collection
+// of service (see invalidate()).
+func (c *CachedCloud) invalidateKeys(service string, keys []meta.Key) {
+	for _, key := range keys {
+		c.invalidate(service, key)
+	}
+}
+
+// invalidateOnDone returns op, invalidating the object of key (see
+// invalidate()) when it is done.
+func (c *CachedCloud) invalidateOnDone(service string, key meta.Key, op Op) Op {
+	if op == nil {
+		return nil
+	}
+	c.invalidate(service, key)
+	return &cachedOp{op, func() { c.invalidate(service, key) }}
+}
+
+// Invalidate removes the object of key from the cache of every service, along
+// with the lists of the collections, which could contain it.
+func (c *CachedCloud) Invalidate(key meta.Key) {
+	ks := key.String()
+	c.remove(func(k cacheKey) bool {
+		return k.key == ks || k.key == ""
+	})
+}
+
+// InvalidateAll empties the cache.
+func (c *CachedCloud) InvalidateAll() {
+	c.remove(func(cacheKey) bool { return true })
+}
+
+// cachedOp is an Op that calls invalidate once done.
+type cachedOp struct {
+	Op
+	invalidate func()
+}
+
+func (o *cachedOp) Done(ctx context.Context) (bool, error) {
+	done, err := o.Op.Done(ctx)
+	if done {
+		o.invalidate()
+	}
+	return done, err
+}
+
+func (o *cachedOp) Wait(ctx context.Context) error {
+	err := o.Op.Wait(ctx)
+	o.invalidate()
+	return err
+}
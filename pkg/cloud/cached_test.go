@@ -0,0 +1,227 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestCachedCloud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := map[string]int{}
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method+" "+r.URL.Path]++
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "GET /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw"}}})
+		case "POST /compute/v1/projects/proj/global/firewalls", "DELETE /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	s.RateLimiter = NewDefaultRateLimiter()
+	now := time.Unix(1000, 0)
+	c := NewCachedCloud(NewGCE(s), time.Minute)
+	c.now = func() time.Time { return now }
+	key := meta.GlobalKey("fw")
+	const (
+		get  = "GET /compute/v1/projects/proj/global/firewalls/fw"
+		list = "GET /compute/v1/projects/proj/global/firewalls"
+	)
+
+	for _, tc := range []struct {
+		desc string
+		do   func()
+		want map[string]int
+	}{
+		{
+			desc: "Get and List",
+			do: func() {
+				c.Firewalls().Get(ctx, *key)
+				c.Firewalls().List(ctx, filter.None)
+			},
+			want: map[string]int{get: 1, list: 1},
+		},
+		{
+			desc: "cached Get, Exists and List",
+			do: func() {
+				c.Firewalls().Get(ctx, *key)
+				if ok, err := c.Firewalls().Exists(ctx, *key); !ok || err != nil {
+					t.Errorf("Firewalls().Exists(%v) = %t, %v; want true, nil", key, ok, err)
+				}
+				c.Firewalls().List(ctx, filter.None)
+			},
+			want: map[string]int{get: 1, list: 1},
+		},
+		{
+			desc: "List with another filter",
+			do:   func() { c.Firewalls().List(ctx, filter.Regexp("name", "fw")) },
+			want: map[string]int{get: 1, list: 2},
+		},
+		{
+			desc: "errors are not cached",
+			do: func() {
+				for i := 0; i < 2; i++ {
+					if ok, err := c.Firewalls().Exists(ctx, *meta.GlobalKey("other")); ok || err != nil {
+						t.Errorf("Firewalls().Exists(other) = %t, %v; want false, nil", ok, err)
+					}
+				}
+			},
+			want: map[string]int{get: 1, list: 2, "GET /compute/v1/projects/proj/global/firewalls/other": 2},
+		},
+		{
+			desc: "Insert invalidates",
+			do: func() {
+				if err := c.Firewalls().Insert(ctx, *key, &ga.Firewall{Name: "fw"}); err != nil {
+					t.Errorf("Firewalls().Insert(%v) = %v; want nil", key, err)
+				}
+				c.Firewalls().Get(ctx, *key)
+				c.Firewalls().List(ctx, filter.None)
+			},
+			want: map[string]int{get: 2, list: 3},
+		},
+		{
+			desc: "DeleteOp invalidates when done",
+			do: func() {
+				op, err := c.Firewalls().DeleteOp(ctx, *key)
+				if err != nil {
+					t.Fatalf("Firewalls().DeleteOp(%v) = _, %v; want nil", key, err)
+				}
+				c.Firewalls().Get(ctx, *key)
+				if err := op.Wait(ctx); err != nil {
+					t.Errorf("Wait() = %v; want nil", err)
+				}
+				c.Firewalls().Get(ctx, *key)
+			},
+			want: map[string]int{get: 4, list: 3},
+		},
+		{
+			desc: "Invalidate",
+			do: func() {
+				c.Invalidate(*key)
+				c.Firewalls().Get(ctx, *key)
+				c.InvalidateAll()
+				c.Firewalls().Get(ctx, *key)
+			},
+			want: map[string]int{get: 6, list: 3},
+		},
+		{
+			desc: "expiry",
+			do: func() {
+				now = now.Add(time.Minute)
+				c.Firewalls().Get(ctx, *key)
+				c.Firewalls().List(ctx, filter.None)
+			},
+			want: map[string]int{get: 7, list: 4},
+		},
+		{
+			desc: "calls with Options are not cached",
+			do: func() {
+				c.Firewalls().Get(ctx, *key, FieldMask("name"))
+				c.Firewalls().List(ctx, filter.None, FieldMask("items(name)"))
+			},
+			want: map[string]int{get: 8, list: 5},
+		},
+	} {
+		tc.do()
+		for call, n := range tc.want {
+			if calls[call] != n {
+				t.Errorf("%s: %d calls to %q; want %d", tc.desc, calls[call], call, n)
+			}
+		}
+	}
+}
+
+func TestCacheArgs(t *testing.T) {
+	t.Parallel()
+
+	var nilFilter *filter.F
+	for _, tc := range []struct {
+		args []interface{}
+		want string
+	}{
+		{nil, ""},
+		{[]interface{}{nilFilter}, "<nil>"},
+		{[]interface{}{"us-central1", filter.Regexp("name", "fw")}, "us-central1, name eq fw"},
+		{[]interface{}{*meta.GlobalKey("fw"), filter.None}, `Key{"fw"}, <nil>`},
+	} {
+		if got := cacheArgs(tc.args...); got != tc.want {
+			t.Errorf("cacheArgs(%v) = %q; want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestCachedBatchGet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := map[string]int{}
+	var lock sync.Mutex
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls[r.URL.Path]++
+		lock.Unlock()
+		switch r.URL.Path {
+		case "/compute/v1/projects/proj/global/firewalls/a", "/compute/v1/projects/proj/global/firewalls/b":
+			writeJSON(t, w, &ga.Firewall{Name: path.Base(r.URL.Path)})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	c := NewCachedCloud(NewGCE(s), time.Minute)
+	a, b, x := *meta.GlobalKey("a"), *meta.GlobalKey("b"), *meta.GlobalKey("x")
+
+	if _, err := c.Firewalls().Get(ctx, a); err != nil {
+		t.Fatalf("Firewalls().Get(%v) = _, %v; want nil", a, err)
+	}
+	objs, err := c.Firewalls().BatchGet(ctx, []meta.Key{a, x, b})
+	if len(objs) != 3 || objs[0].Name != "a" || objs[1] != nil || objs[2].Name != "b" {
+		t.Errorf("Firewalls().BatchGet() = %v, _; want [a nil b]", objs)
+	}
+	if be, ok := err.(*BatchError); !ok || be.Errors[0] != nil || !IsNotFound(be.Errors[1]) || be.Errors[2] != nil {
+		t.Errorf("Firewalls().BatchGet() = _, %v; want a BatchError with http.StatusNotFound for x", err)
+	}
+	if _, err := c.Firewalls().Get(ctx, b); err != nil {
+		t.Fatalf("Firewalls().Get(%v) = _, %v; want nil", b, err)
+	}
+	want := map[string]int{
+		"/compute/v1/projects/proj/global/firewalls/a": 1,
+		"/compute/v1/projects/proj/global/firewalls/b": 1,
+		"/compute/v1/projects/proj/global/firewalls/x": 1,
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v; want %v", calls, want)
+	}
+}
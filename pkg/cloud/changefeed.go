@@ -0,0 +1,208 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Change is a record of a successful mutation made through the GCE adapters.
+type Change struct {
+	// Time the mutation completed.
+	Time time.Time `json:"time"`
+	// ProjectID the mutation was made in.
+	ProjectID string `json:"projectId"`
+	// Version of the API used.
+	Version meta.Version `json:"version"`
+	// Service is the service mutated (e.g. "Firewalls").
+	Service string `json:"service"`
+	// Action is the method invoked (e.g. "Insert", "Delete", "SetTarget").
+	Action string `json:"action"`
+	// Key of the resource.
+	Key meta.Key `json:"key"`
+	// ResourceURL is the URL of the resource (the target of the operation).
+	ResourceURL string `json:"resourceUrl,omitempty"`
+	// Operation is the name of the GCE operation.
+	Operation string `json:"operation,omitempty"`
+	// Object is the serialized request: the object for Insert, the
+	// arguments of the call for other methods and empty for Delete.
+	Object json.RawMessage `json:"object,omitempty"`
+}
+
+// ChangeSink receives a Change for every successful mutation when configured
+// as Service.ChangeSink. Errors returned by the sink are logged but do not
+// fail the mutation.
+type ChangeSink interface {
+	Publish(ctx context.Context, c *Change) error
+}
+
+// recordChange publishes the change for the completed operation op to the
+// configured ChangeSink, if any.
+func (g *Service) recordChange(ctx context.Context, rk *RateLimitKey, key meta.Key, op, req interface{}) {
+	if g.ChangeSink == nil {
+		return
+	}
+	c := &Change{
+		Time:      time.Now(),
+		ProjectID: rk.ProjectID,
+		Version:   rk.Version,
+		Service:   rk.Service,
+		Action:    rk.Operation,
+		Key:       key,
+	}
+	switch o := op.(type) {
+	case *ga.Operation:
+		c.Operation, c.ResourceURL = o.Name, o.TargetLink
+	case *alpha.Operation:
+		c.Operation, c.ResourceURL = o.Name, o.TargetLink
+	case *beta.Operation:
+		c.Operation, c.ResourceURL = o.Name, o.TargetLink
+	}
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			glog.Errorf("Could not serialize %T for change %+v: %v", req, c, err)
+		}
+		c.Object = b
+	}
+	if err := g.ChangeSink.Publish(ctx, c); err != nil {
+		glog.Errorf("ChangeSink.Publish(%+v) = %v", c, err)
+	}
+}
+
+// NewFileChangeSink returns a sink appending changes to the file at path.
+func NewFileChangeSink(path string) (*WriterChangeSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &WriterChangeSink{W: f}, nil
+}
+
+// WriterChangeSink writes each change as a line of JSON to W.
+type WriterChangeSink struct {
+	W io.Writer
+
+	lock sync.Mutex
+}
+
+// Publish implements ChangeSink.
+func (s *WriterChangeSink) Publish(ctx context.Context, c *Change) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err = s.W.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes W if it is an io.Closer.
+func (s *WriterChangeSink) Close() error {
+	if c, ok := s.W.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+const pubSubEndpoint = "https://pubsub.googleapis.com/v1/"
+
+// PubSubChangeSink publishes each change as a message to a Cloud Pub/Sub
+// topic using the Pub/Sub REST API. The message data is the JSON encoded
+// Change; the service, action and version are set as message attributes.
+type PubSubChangeSink struct {
+	// Client is an authenticated client with the Pub/Sub scope, e.g. from
+	// google.DefaultClient(ctx, "https://www.googleapis.com/auth/pubsub").
+	Client *http.Client
+	// ProjectID of the topic.
+	ProjectID string
+	// Topic name.
+	Topic string
+	// Endpoint, if set, overrides the Pub/Sub API endpoint.
+	Endpoint string
+}
+
+type pubSubMessage struct {
+	Data       []byte            `json:"data"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+type pubSubPublishRequest struct {
+	Messages []*pubSubMessage `json:"messages"`
+}
+
+// Publish implements ChangeSink.
+func (s *PubSubChangeSink) Publish(ctx context.Context, c *Change) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&pubSubPublishRequest{
+		Messages: []*pubSubMessage{{
+			Data: data,
+			Attributes: map[string]string{
+				"service": c.Service,
+				"action":  c.Action,
+				"version": string(c.Version),
+			},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = pubSubEndpoint
+	}
+	url := fmt.Sprintf("%sprojects/%s/topics/%s:publish", endpoint, s.ProjectID, s.Topic)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("pubsub publish to %q: %s: %s", url, resp.Status, msg)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestChangeSink(t *testing.T) {
+	t.Parallel()
+
+	const targetLink = "https://www.googleapis.com/compute/v1/projects/proj/global/firewalls/fw"
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op-insert", SelfLink: "projects/proj/global/operations/op-insert", TargetLink: targetLink})
+		case "GET /compute/v1/projects/proj/global/operations/op-insert":
+			writeJSON(t, w, &ga.Operation{Name: "op-insert", Status: "DONE"})
+		case "DELETE /compute/v1/projects/proj/global/firewalls/fw":
+			http.Error(w, "injected error", http.StatusInternalServerError)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	buf := &bytes.Buffer{}
+	s.ChangeSink = &WriterChangeSink{W: buf}
+	gce := NewGCE(s)
+
+	key := meta.GlobalKey("fw")
+	if err := gce.Firewalls().Insert(ctx, *key, &ga.Firewall{Network: "net"}); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	// Failed mutations are not recorded.
+	if err := gce.Firewalls().Delete(ctx, *key); err == nil {
+		t.Fatalf("Firewalls().Delete(%v) = nil; want error", key)
+	}
+
+	var c Change
+	dec := json.NewDecoder(buf)
+	if err := dec.Decode(&c); err != nil {
+		t.Fatalf("Decode() = %v; want nil", err)
+	}
+	if c.ProjectID != "proj" || c.Service != "Firewalls" || c.Action != "Insert" || c.Version != meta.VersionGA ||
+		c.Key != *key || c.Operation != "op-insert" || c.ResourceURL != targetLink || c.Time.IsZero() {
+		t.Errorf("change = %+v, want Insert of %v", c, key)
+	}
+	var fw ga.Firewall
+	if err := json.Unmarshal(c.Object, &fw); err != nil || fw.Name != "fw" || fw.Network != "net" {
+		t.Errorf("change.Object = %s; want serialized firewall", c.Object)
+	}
+	if dec.More() {
+		t.Errorf("got more than one change recorded")
+	}
+}
+
+func TestPubSubChangeSink(t *testing.T) {
+	t.Parallel()
+
+	var got pubSubPublishRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/projects/proj/topics/changes:publish" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Decode() = %v", err)
+		}
+		w.Write([]byte(`{"messageIds": ["1"]}`))
+	}))
+	defer srv.Close()
+
+	sink := &PubSubChangeSink{Client: srv.Client(), ProjectID: "proj", Topic: "changes", Endpoint: srv.URL + "/"}
+	c := &Change{Service: "Firewalls", Action: "Delete", Version: meta.VersionGA, Key: *meta.GlobalKey("fw")}
+	if err := sink.Publish(context.Background(), c); err != nil {
+		t.Fatalf("sink.Publish(%+v) = %v; want nil", c, err)
+	}
+	if len(got.Messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(got.Messages))
+	}
+	msg := got.Messages[0]
+	var gotChange Change
+	if err := json.Unmarshal(msg.Data, &gotChange); err != nil || gotChange.Key != c.Key {
+		t.Errorf("message data = %s, want %+v", msg.Data, c)
+	}
+	if msg.Attributes["service"] != "Firewalls" || msg.Attributes["action"] != "Delete" {
+		t.Errorf("message attributes = %v, want service and action", msg.Attributes)
+	}
+
+	sink.Topic = "missing"
+	if err := sink.Publish(context.Background(), c); err == nil {
+		t.Errorf("sink.Publish() to missing topic = nil; want error")
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// ServiceOption configures the Service built by NewService().
+type ServiceOption func(*serviceConfig)
+
+// serviceConfig is the configuration of NewService().
+type serviceConfig struct {
+	credentialsFile  string
+	tokenSource      oauth2.TokenSource
+	workloadIdentity bool
+	impersonate      string
+	delegates        []string
+	scopes           []string
+	projectID        string
+	httpClient       *http.Client
+	projectRouter    ProjectRouter
+	rateLimiter      RateLimiter
+}
+
+// WithCredentialsFile authenticates the calls with the JSON key file of a
+// service account, or the credentials of a user written by "gcloud auth
+// application-default login", instead of the Application Default
+// Credentials. The project of a service account key is the default project.
+func WithCredentialsFile(path string) ServiceOption {
+	return func(c *serviceConfig) { c.credentialsFile = path }
+}
+
+// WithTokenSource authenticates the calls with the tokens of ts instead of
+// the Application Default Credentials.
+func WithTokenSource(ts oauth2.TokenSource) ServiceOption {
+	return func(c *serviceConfig) { c.tokenSource = ts }
+}
+
+// WithWorkloadIdentity authenticates the calls as the service account of the
+// metadata server, which is the Google service account bound to the
+// Kubernetes service account of the pod with GKE Workload Identity. Its
+// tokens are refreshed DefaultTokenRefreshMargin before they expire. The
+// project of the metadata server is the default project.
+func WithWorkloadIdentity() ServiceOption {
+	return func(c *serviceConfig) { c.workloadIdentity = true }
+}
+
+// WithImpersonation authenticates the calls as the service account target
+// (e.g. "deployer@my-project.iam.gserviceaccount.com"), impersonated by the
+// credentials of the other options through the chain of service accounts
+// delegates, if any. The short-lived tokens of target are issued by the IAM
+// Service Account Credentials API and refreshed DefaultTokenRefreshMargin
+// before they expire. The credentials need the cloud-platform scope, which
+// is requested instead of the scopes of WithScopes(); the latter are the
+// scopes of the tokens of target.
+func WithImpersonation(target string, delegates ...string) ServiceOption {
+	return func(c *serviceConfig) { c.impersonate, c.delegates = target, delegates }
+}
+
+// WithScopes requests scopes for the credentials instead of the scopes
+// required by all of the services (see meta.RequiredScopes()).
+func WithScopes(scopes ...string) ServiceOption {
+	return func(c *serviceConfig) { c.scopes = scopes }
+}
+
+// WithDefaultProject routes the calls to the project id (see
+// SingleProjectRouter) instead of the project of the credentials.
+func WithDefaultProject(id string) ServiceOption {
+	return func(c *serviceConfig) { c.projectID = id }
+}
+
+// WithHTTPClient makes the calls with client, which handles the
+// authentication, instead of building one from the credentials.
+func WithHTTPClient(client *http.Client) ServiceOption {
+	return func(c *serviceConfig) { c.httpClient = client }
+}
+
+// WithProjectRouter routes the calls with r instead of a SingleProjectRouter
+// to the default project.
+func WithProjectRouter(r ProjectRouter) ServiceOption {
+	return func(c *serviceConfig) { c.projectRouter = r }
+}
+
+// WithRateLimiter rate limits the calls with rl instead of
+// NewDefaultRateLimiter().
+func WithRateLimiter(rl RateLimiter) ServiceOption {
+	return func(c *serviceConfig) { c.rateLimiter = rl }
+}
+
+// NewService returns a Service ready to be used with NewGCE(): it builds the
+// authenticated http.Client, the GA, Alpha and Beta clients sharing it, the
+// ProjectRouter and the RateLimiter from opts. By default, the calls are
+// authenticated with the Application Default Credentials for the scopes of
+// all of the services (see WithTokenSource(), WithImpersonation() and
+// WithWorkloadIdentity() for the alternatives), routed to the project of the
+// credentials and rate limited by NewDefaultRateLimiter(). The other fields
+// of the Service (e.g. RetryPolicy) can be set on the result before it is
+// used.
+//
+// ctx is used by the credentials to refresh their tokens, not for the calls,
+// so it must not be canceled while the Service is in use.
+func NewService(ctx context.Context, opts ...ServiceOption) (*Service, error) {
+	cfg := &serviceConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	client := cfg.httpClient
+	if client == nil {
+		ts, err := cfg.credentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		client = &http.Client{Transport: &oauth2.Transport{Source: ts}}
+	}
+
+	router := cfg.projectRouter
+	if router == nil {
+		if cfg.projectID == "" {
+			return nil, fmt.Errorf("no project to route the calls to: the credentials have none, use WithDefaultProject() or WithProjectRouter()")
+		}
+		router = &SingleProjectRouter{ID: cfg.projectID}
+	}
+	rateLimiter := cfg.rateLimiter
+	if rateLimiter == nil {
+		rateLimiter = NewDefaultRateLimiter()
+	}
+
+	s := &Service{ProjectRouter: router, RateLimiter: rateLimiter}
+	var err error
+	if s.GA, err = ga.New(client); err != nil {
+		return nil, fmt.Errorf("error creating client for API version %q: %v", meta.VersionGA, err)
+	}
+	if s.Alpha, err = alpha.New(client); err != nil {
+		return nil, fmt.Errorf("error creating client for API version %q: %v", meta.VersionAlpha, err)
+	}
+	if s.Beta, err = beta.New(client); err != nil {
+		return nil, fmt.Errorf("error creating client for API version %q: %v", meta.VersionBeta, err)
+	}
+	return s, nil
+}
+
+// credentials returns the token source of the calls, setting the default
+// project to the project of the credentials if none was given.
+func (cfg *serviceConfig) credentials(ctx context.Context) (oauth2.TokenSource, error) {
+	scopes := cfg.scopes
+	if len(scopes) == 0 {
+		scopes = meta.RequiredScopes(meta.AllServices...)
+	}
+	credScopes := scopes
+	if cfg.impersonate != "" {
+		credScopes = []string{meta.CloudPlatformScope}
+	}
+
+	var (
+		ts        oauth2.TokenSource
+		projectID string
+		err       error
+	)
+	switch {
+	case cfg.tokenSource != nil:
+		ts = oauth2.ReuseTokenSource(nil, cfg.tokenSource)
+	case cfg.workloadIdentity:
+		ts, projectID, err = workloadIdentityCredentials(cfg.projectID)
+		ts = NewRefreshingTokenSource(ts, DefaultTokenRefreshMargin)
+	default:
+		ts, projectID, err = findCredentials(ctx, cfg.credentialsFile, credScopes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.projectID == "" {
+		cfg.projectID = projectID
+	}
+	if cfg.impersonate != "" {
+		ts = NewRefreshingTokenSource(&impersonatedTokenSource{
+			ctx:       ctx,
+			client:    &http.Client{Transport: &oauth2.Transport{Source: ts}},
+			endpoint:  iamCredentialsEndpoint,
+			target:    cfg.impersonate,
+			delegates: cfg.delegates,
+			scopes:    scopes,
+		}, DefaultTokenRefreshMargin)
+	}
+	return ts, nil
+}
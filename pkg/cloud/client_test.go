@@ -0,0 +1,139 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile(%q) = %v", path, err)
+		}
+		return path
+	}
+	serviceAccount := writeFile("sa.json", `{"type": "service_account", "client_email": "sa@file-proj.iam.gserviceaccount.com", "private_key": "key", "project_id": "file-proj"}`)
+	user := writeFile("user.json", `{"type": "authorized_user", "client_id": "id", "client_secret": "secret", "refresh_token": "token"}`)
+	unknown := writeFile("unknown.json", `{"type": "external_account"}`)
+	router := &SingleProjectRouter{ID: "routed"}
+	rl := NewMinimumRateLimiter(0)
+
+	for _, tc := range []struct {
+		desc        string
+		opts        []ServiceOption
+		wantProject string
+		wantRL      RateLimiter
+		wantErr     bool
+	}{
+		{
+			desc:        "http client",
+			opts:        []ServiceOption{WithHTTPClient(http.DefaultClient), WithDefaultProject("proj")},
+			wantProject: "proj",
+		},
+		{
+			desc:    "http client without a project",
+			opts:    []ServiceOption{WithHTTPClient(http.DefaultClient)},
+			wantErr: true,
+		},
+		{
+			desc:        "service account key",
+			opts:        []ServiceOption{WithCredentialsFile(serviceAccount)},
+			wantProject: "file-proj",
+		},
+		{
+			desc:        "default project over the project of the key",
+			opts:        []ServiceOption{WithCredentialsFile(serviceAccount), WithScopes(meta.CloudPlatformScope), WithDefaultProject("proj")},
+			wantProject: "proj",
+		},
+		{
+			desc:    "user credentials without a project",
+			opts:    []ServiceOption{WithCredentialsFile(user)},
+			wantErr: true,
+		},
+		{
+			desc:        "router and rate limiter",
+			opts:        []ServiceOption{WithCredentialsFile(user), WithProjectRouter(router), WithRateLimiter(rl)},
+			wantProject: "routed",
+			wantRL:      rl,
+		},
+		{
+			desc:    "unsupported credentials",
+			opts:    []ServiceOption{WithCredentialsFile(unknown), WithDefaultProject("proj")},
+			wantErr: true,
+		},
+		{
+			desc:    "missing credentials file",
+			opts:    []ServiceOption{WithCredentialsFile(filepath.Join(dir, "missing.json")), WithDefaultProject("proj")},
+			wantErr: true,
+		},
+	} {
+		s, err := NewService(ctx, tc.opts...)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: NewService() = _, %v; want error %t", tc.desc, err, tc.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if s.GA == nil || s.Alpha == nil || s.Beta == nil {
+			t.Errorf("%s: NewService() = %+v; want the GA, Alpha and Beta clients", tc.desc, s)
+		}
+		if got := s.ProjectRouter.ProjectID(ctx, meta.VersionGA, "Firewalls"); got != tc.wantProject {
+			t.Errorf("%s: ProjectID() = %q; want %q", tc.desc, got, tc.wantProject)
+		}
+		if tc.wantRL != nil && s.RateLimiter != tc.wantRL {
+			t.Errorf("%s: RateLimiter = %v; want %v", tc.desc, s.RateLimiter, tc.wantRL)
+		}
+		if _, ok := s.RateLimiter.(*DefaultRateLimiter); tc.wantRL == nil && !ok {
+			t.Errorf("%s: RateLimiter = %T; want a *DefaultRateLimiter", tc.desc, s.RateLimiter)
+		}
+	}
+}
+
+func TestNewServiceCalls(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	s, err := NewService(ctx, WithHTTPClient(srv.Client()), WithDefaultProject("proj"))
+	if err != nil {
+		t.Fatalf("NewService() = _, %v; want nil", err)
+	}
+	s.Endpoints = map[meta.Version]string{meta.VersionGA: srv.URL + "/compute/v1"}
+	key := meta.GlobalKey("fw")
+	if fw, err := NewGCE(s).Firewalls().Get(ctx, *key); err != nil || fw.Name != "fw" {
+		t.Errorf("Firewalls().Get(%v) = %+v, %v; want the firewall", key, fw, err)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestVersionClouds(t *testing.T) {
+	t.Parallel()
+
+	var total int
+	for _, tc := range []struct {
+		cloud  reflect.Type
+		prefix string
+	}{
+		{reflect.TypeOf((*GACloud)(nil)).Elem(), ""},
+		{reflect.TypeOf((*AlphaCloud)(nil)).Elem(), "Alpha"},
+		{reflect.TypeOf((*BetaCloud)(nil)).Elem(), "Beta"},
+	} {
+		total += tc.cloud.NumMethod()
+		for i := 0; i < tc.cloud.NumMethod(); i++ {
+			name := tc.cloud.Method(i).Name
+			isAlpha, isBeta := strings.HasPrefix(name, "Alpha"), strings.HasPrefix(name, "Beta")
+			if (tc.prefix == "" && (isAlpha || isBeta)) || !strings.HasPrefix(name, tc.prefix) {
+				t.Errorf("%v has method %s(); want only the services of its version", tc.cloud.Name(), name)
+			}
+		}
+	}
+	if n := reflect.TypeOf((*Cloud)(nil)).Elem().NumMethod(); n != total {
+		t.Errorf("Cloud has %d methods; want %d (the methods of GACloud, AlphaCloud and BetaCloud)", n, total)
+	}
+
+	// A GCE can be used where a single version is required.
+	var ga GACloud = NewGCE(&Service{})
+	if ga.Firewalls() == nil {
+		t.Errorf("GACloud.Firewalls() = nil; want non-nil")
+	}
+}
+
+func TestTagClouds(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		cloud reflect.Type
+		tag   meta.Tag
+	}{
+		{reflect.TypeOf((*InstancesCloud)(nil)).Elem(), meta.TagInstances},
+		{reflect.TypeOf((*LoadBalancingCloud)(nil)).Elem(), meta.TagLoadBalancing},
+		{reflect.TypeOf((*NetworkingCloud)(nil)).Elem(), meta.TagNetworking},
+		{reflect.TypeOf((*StorageCloud)(nil)).Elem(), meta.TagStorage},
+	} {
+		want := map[string]bool{}
+		for _, s := range meta.AllServices {
+			if s.HasTag(tc.tag) {
+				want[s.WrapType()] = true
+			}
+		}
+		if n := tc.cloud.NumMethod(); n != len(want) {
+			t.Errorf("%v has %d methods; want %d (the services tagged %q)", tc.cloud.Name(), n, len(want), tc.tag)
+		}
+		for i := 0; i < tc.cloud.NumMethod(); i++ {
+			if name := tc.cloud.Method(i).Name; !want[name] {
+				t.Errorf("%v has method %s(); want only the services tagged %q", tc.cloud.Name(), name, tc.tag)
+			}
+		}
+	}
+
+	// A GCE can be used where the services of a tag are required.
+	var lb LoadBalancingCloud = NewGCE(&Service{})
+	if lb.UrlMaps() == nil {
+		t.Errorf("LoadBalancingCloud.UrlMaps() = nil; want non-nil")
+	}
+}
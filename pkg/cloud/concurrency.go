@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+)
+
+// ConcurrencyLimiter bounds the number of calls in flight, overall and per
+// service, so that a misbehaving caller cannot open many simultaneous
+// connections to the API and exhaust the sockets or the per-user quotas. The
+// calls over the limits wait for a call to complete. Unlike a RateLimiter, it
+// does not bound the rate of the calls.
+type ConcurrencyLimiter struct {
+	// all bounds the calls of all of the services, nil if unlimited.
+	all chan struct{}
+	// services bounds the calls of a service.
+	services map[string]chan struct{}
+}
+
+// NewConcurrencyLimiter returns a ConcurrencyLimiter allowing max calls in
+// flight (0 for no overall limit) and perService[service] calls in flight
+// for a service (e.g. "Instances"; "Operations" for the polls of the
+// operations).
+func NewConcurrencyLimiter(max int, perService map[string]int) *ConcurrencyLimiter {
+	l := &ConcurrencyLimiter{services: map[string]chan struct{}{}}
+	if max > 0 {
+		l.all = make(chan struct{}, max)
+	}
+	for service, n := range perService {
+		if n > 0 {
+			l.services[service] = make(chan struct{}, n)
+		}
+	}
+	return l
+}
+
+// Acquire blocks until the call for key can be made and returns the function
+// to call once it has completed. It returns an error if ctx is done first.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, key *RateLimitKey) (release func(), err error) {
+	// The slot of the service is acquired first so that a call waiting for
+	// its service does not hold one of the overall slots.
+	var held []chan struct{}
+	release = func() {
+		for _, sem := range held {
+			<-sem
+		}
+	}
+	for _, sem := range []chan struct{}{l.services[key.Service], l.all} {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			held = append(held, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// InFlight returns the number of calls in flight, overall if service is "",
+// for service otherwise. It is 0 if the calls are not limited.
+func (l *ConcurrencyLimiter) InFlight(service string) int {
+	if service == "" {
+		return len(l.all)
+	}
+	return len(l.services[service])
+}
+
+// acquireCall acquires the slot of the ConcurrencyLimiter, if any, for the
+// call for rk.
+func (g *Service) acquireCall(ctx context.Context, rk *RateLimitKey) (func(), error) {
+	if g.ConcurrencyLimiter == nil {
+		return func() {}, nil
+	}
+	return g.ConcurrencyLimiter.Acquire(ctx, rk)
+}
+
+// isDone polls op once, within the limits of the ConcurrencyLimiter.
+func (g *Service) isDone(ctx context.Context, op operation) (bool, error) {
+	release, err := g.acquireCall(ctx, op.rateLimitKey())
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return op.isDone(ctx)
+}
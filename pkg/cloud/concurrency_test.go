@@ -0,0 +1,117 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := NewConcurrencyLimiter(2, map[string]int{"Instances": 1})
+	instances := &RateLimitKey{Service: "Instances"}
+	firewalls := &RateLimitKey{Service: "Firewalls"}
+	blocked := func(key *RateLimitKey) bool {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		release, err := l.Acquire(ctx, key)
+		if err == nil {
+			release()
+			return false
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Acquire(%+v) = _, %v; want %v", key, err, context.DeadlineExceeded)
+		}
+		return true
+	}
+
+	releaseInstances, err := l.Acquire(ctx, instances)
+	if err != nil {
+		t.Fatalf("Acquire(%+v) = _, %v; want nil", instances, err)
+	}
+	if !blocked(instances) {
+		t.Errorf("Acquire(%+v) with 1 Instances call in flight succeeded; want blocked", instances)
+	}
+	releaseFirewalls, err := l.Acquire(ctx, firewalls)
+	if err != nil {
+		t.Fatalf("Acquire(%+v) = _, %v; want nil", firewalls, err)
+	}
+	if !blocked(firewalls) {
+		t.Errorf("Acquire(%+v) with 2 calls in flight succeeded; want blocked", firewalls)
+	}
+	if got, got2 := l.InFlight(""), l.InFlight("Instances"); got != 2 || got2 != 1 {
+		t.Errorf("InFlight() = %d, InFlight(Instances) = %d; want 2, 1", got, got2)
+	}
+	releaseInstances()
+	if blocked(firewalls) {
+		t.Errorf("Acquire(%+v) after a release blocked; want success", firewalls)
+	}
+	releaseFirewalls()
+	if got := l.InFlight(""); got != 0 {
+		t.Errorf("InFlight() = %d; want 0", got)
+	}
+}
+
+func TestServiceConcurrencyLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var (
+		lock                  sync.Mutex
+		inFlight, maxInFlight int
+	)
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	})
+	s.ConcurrencyLimiter = NewConcurrencyLimiter(0, map[string]int{"Firewalls": 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := NewGCE(s).Firewalls().Get(ctx, *meta.GlobalKey("fw")); err != nil {
+				t.Errorf("Firewalls().Get() = _, %v; want nil", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight > 2 {
+		t.Errorf("%d calls in flight; want at most 2", maxInFlight)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/compute/metadata"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// findCredentials returns the token source for scopes and the project of the
+// credentials in the file at path, or of the Application Default Credentials
+// if path is "".
+func findCredentials(ctx context.Context, path string, scopes []string) (oauth2.TokenSource, string, error) {
+	if path == "" {
+		creds, err := google.FindDefaultCredentials(ctx, scopes...)
+		if err != nil {
+			return nil, "", err
+		}
+		return creds.TokenSource, creds.ProjectID, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading credentials file: %v", err)
+	}
+	var f struct {
+		Type         string `json:"type"`
+		ProjectID    string `json:"project_id"`
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, "", fmt.Errorf("error parsing credentials file %q: %v", path, err)
+	}
+	switch f.Type {
+	case "service_account":
+		jwt, err := google.JWTConfigFromJSON(b, scopes...)
+		if err != nil {
+			return nil, "", err
+		}
+		return jwt.TokenSource(ctx), f.ProjectID, nil
+	case "authorized_user":
+		conf := &oauth2.Config{
+			ClientID:     f.ClientID,
+			ClientSecret: f.ClientSecret,
+			Scopes:       scopes,
+			Endpoint:     google.Endpoint,
+		}
+		return conf.TokenSource(ctx, &oauth2.Token{RefreshToken: f.RefreshToken}), "", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported credentials type %q in %q", f.Type, path)
+	}
+}
+
+// workloadIdentityCredentials returns the token source of the service
+// account of the metadata server, which is the identity of the Kubernetes
+// service account with GKE Workload Identity, and the project of the
+// metadata server if projectID is "".
+func workloadIdentityCredentials(projectID string) (oauth2.TokenSource, string, error) {
+	if projectID == "" {
+		id, err := metadata.ProjectID()
+		if err != nil {
+			return nil, "", fmt.Errorf("error getting the project from the metadata server: %v", err)
+		}
+		projectID = id
+	}
+	return metadataTokenSource{}, projectID, nil
+}
+
+// metadataTokenSource gets a token from the metadata server on each call.
+// Unlike google.ComputeTokenSource(), it does not cache the token, which is
+// left to NewRefreshingTokenSource().
+type metadataTokenSource struct{}
+
+func (metadataTokenSource) Token() (*oauth2.Token, error) {
+	s, err := metadata.Get("instance/service-accounts/default/token")
+	if err != nil {
+		return nil, err
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.Unmarshal([]byte(s), &token); err != nil {
+		return nil, fmt.Errorf("error parsing the token of the metadata server: %v", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("the metadata server returned no token")
+	}
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// iamCredentialsEndpoint is the endpoint of the IAM Service Account
+// Credentials API, which issues the tokens of the impersonated accounts.
+const iamCredentialsEndpoint = "https://iamcredentials.googleapis.com/"
+
+// impersonatedTokenSource returns the access tokens of a service account
+// impersonated by the credentials of client, from the generateAccessToken
+// method of the IAM Service Account Credentials API. The credentials must
+// have the roles/iam.serviceAccountTokenCreator role on the account, or on
+// the first of the delegates, each of which has it on the next one.
+type impersonatedTokenSource struct {
+	ctx       context.Context
+	client    *http.Client
+	endpoint  string
+	target    string
+	delegates []string
+	scopes    []string
+}
+
+// Token generates a new access token for the impersonated account.
+func (ts *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	req := struct {
+		Delegates []string `json:"delegates,omitempty"`
+		Scope     []string `json:"scope"`
+	}{Scope: ts.scopes}
+	for _, d := range ts.delegates {
+		req.Delegates = append(req.Delegates, "projects/-/serviceAccounts/"+d)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	u := ts.endpoint + "v1/projects/-/serviceAccounts/" + url.PathEscape(ts.target) + ":generateAccessToken"
+	httpReq, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := ts.client.Do(httpReq.WithContext(ts.ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error impersonating %q: %v", ts.target, err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error impersonating %q: %v", ts.target, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error impersonating %q: %s: %s", ts.target, resp.Status, bytes.TrimSpace(b))
+	}
+	var token struct {
+		AccessToken string    `json:"accessToken"`
+		ExpireTime  time.Time `json:"expireTime"`
+	}
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, fmt.Errorf("error parsing the token of %q: %v", ts.target, err)
+	}
+	return &oauth2.Token{AccessToken: token.AccessToken, TokenType: "Bearer", Expiry: token.ExpireTime}, nil
+}
+
+// DefaultTokenRefreshMargin is how long before their expiry the tokens of the
+// impersonated and Workload Identity accounts are refreshed.
+const DefaultTokenRefreshMargin = 5 * time.Minute
+
+// NewRefreshingTokenSource returns a TokenSource caching the tokens of ts
+// and refreshing them margin before they expire, rather than the few seconds
+// of oauth2.ReuseTokenSource(), so that a call made by a long-lived
+// controller is not rejected because its token expired in flight. If a
+// refresh fails, the cached token is returned until it actually expires.
+func NewRefreshingTokenSource(ts oauth2.TokenSource, margin time.Duration) oauth2.TokenSource {
+	return &refreshingTokenSource{src: ts, margin: margin, now: time.Now}
+}
+
+// refreshingTokenSource implements NewRefreshingTokenSource().
+type refreshingTokenSource struct {
+	src    oauth2.TokenSource
+	margin time.Duration
+	now    func() time.Time
+
+	lock sync.Mutex
+	t    *oauth2.Token
+}
+
+// Token returns the cached token unless it is within margin of its expiry.
+func (ts *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	now := ts.now()
+	if ts.t != nil && (ts.t.Expiry.IsZero() || now.Add(ts.margin).Before(ts.t.Expiry)) {
+		return ts.t, nil
+	}
+	t, err := ts.src.Token()
+	if err != nil {
+		if ts.t != nil && now.Before(ts.t.Expiry) {
+			return ts.t, nil
+		}
+		return nil, err
+	}
+	ts.t = t
+	return t, nil
+}
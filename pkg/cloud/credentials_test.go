@@ -0,0 +1,164 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestImpersonatedTokenSource(t *testing.T) {
+	t.Parallel()
+
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/-/serviceAccounts/denied@proj.iam.gserviceaccount.com:generateAccessToken" {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+		if got, want := r.URL.Path, "/v1/projects/-/serviceAccounts/target@proj.iam.gserviceaccount.com:generateAccessToken"; got != want {
+			t.Errorf("path = %q; want %q", got, want)
+		}
+		if got, want := r.Header.Get("Authorization"), "Bearer base-token"; got != want {
+			t.Errorf("Authorization = %q; want %q", got, want)
+		}
+		var req struct {
+			Delegates []string `json:"delegates"`
+			Scope     []string `json:"scope"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Decode() = %v", err)
+		}
+		if want := []string{"projects/-/serviceAccounts/delegate@proj.iam.gserviceaccount.com"}; !reflect.DeepEqual(req.Delegates, want) {
+			t.Errorf("delegates = %v; want %v", req.Delegates, want)
+		}
+		if want := []string{meta.CloudPlatformScope}; !reflect.DeepEqual(req.Scope, want) {
+			t.Errorf("scope = %v; want %v", req.Scope, want)
+		}
+		writeJSON(t, w, map[string]interface{}{"accessToken": "target-token", "expireTime": expiry})
+	}))
+	t.Cleanup(srv.Close)
+
+	base := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "base-token"})
+	ts := &impersonatedTokenSource{
+		ctx:       context.Background(),
+		client:    &http.Client{Transport: &oauth2.Transport{Source: base}},
+		endpoint:  srv.URL + "/",
+		target:    "target@proj.iam.gserviceaccount.com",
+		delegates: []string{"delegate@proj.iam.gserviceaccount.com"},
+		scopes:    []string{meta.CloudPlatformScope},
+	}
+	tok, err := ts.Token()
+	if err != nil || tok.AccessToken != "target-token" || !tok.Expiry.Equal(expiry) {
+		t.Errorf("Token() = %+v, %v; want the token of the target expiring at %v", tok, err, expiry)
+	}
+
+	ts.target = "denied@proj.iam.gserviceaccount.com"
+	if _, err := ts.Token(); err == nil {
+		t.Errorf("Token() = _, nil; want an error for a 403")
+	}
+}
+
+// countingTokenSource returns a new token expiring after lifetime on each
+// call, or err if set.
+type countingTokenSource struct {
+	now      *time.Time
+	lifetime time.Duration
+	calls    int
+	err      error
+}
+
+func (ts *countingTokenSource) Token() (*oauth2.Token, error) {
+	ts.calls++
+	if ts.err != nil {
+		return nil, ts.err
+	}
+	return &oauth2.Token{AccessToken: "token", Expiry: ts.now.Add(ts.lifetime)}, nil
+}
+
+func TestRefreshingTokenSource(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	src := &countingTokenSource{now: &now, lifetime: time.Hour}
+	ts := NewRefreshingTokenSource(src, 5*time.Minute).(*refreshingTokenSource)
+	ts.now = func() time.Time { return now }
+
+	for i, tc := range []struct {
+		after     time.Duration
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		{0, nil, 1, false},
+		// The token is cached until the margin before its expiry.
+		{50 * time.Minute, nil, 1, false},
+		{5 * time.Minute, nil, 2, false},
+		{time.Minute, nil, 2, false},
+		// A failed refresh returns the cached token until it expires.
+		{55 * time.Minute, errors.New("unavailable"), 3, false},
+		{4 * time.Minute, errors.New("unavailable"), 4, true},
+		{0, nil, 5, false},
+	} {
+		now = now.Add(tc.after)
+		src.err = tc.err
+		_, err := ts.Token()
+		if gotErr := err != nil; gotErr != tc.wantErr || src.calls != tc.wantCalls {
+			t.Errorf("Token() #%d = _, %v with %d calls; want error %t with %d calls", i, err, src.calls, tc.wantErr, tc.wantCalls)
+		}
+	}
+}
+
+func TestNewServiceTokenSource(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer token"; got != want {
+			t.Errorf("Authorization = %q; want %q", got, want)
+		}
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	now := time.Now()
+	s, err := NewService(ctx, WithTokenSource(&countingTokenSource{now: &now, lifetime: time.Hour}), WithDefaultProject("proj"))
+	if err != nil {
+		t.Fatalf("NewService() = _, %v; want nil", err)
+	}
+	s.Endpoints = map[meta.Version]string{meta.VersionGA: srv.URL + "/compute/v1"}
+	key := meta.GlobalKey("fw")
+	if _, err := NewGCE(s).Firewalls().Get(ctx, *key); err != nil {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want nil", key, err)
+	}
+
+	// Without a project, the token source does not provide one.
+	if _, err := NewService(ctx, WithTokenSource(&countingTokenSource{now: &now})); err == nil {
+		t.Errorf("NewService() without a project = _, nil; want an error")
+	}
+}
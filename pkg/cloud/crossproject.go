@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ProjectsError is returned by ListAcrossProjects when the calls of some of
+// the projects failed.
+type ProjectsError struct {
+	// Errors are the errors of the projects whose call failed.
+	Errors map[string]error
+}
+
+func (e *ProjectsError) Error() string {
+	projects := make([]string, 0, len(e.Errors))
+	for p := range e.Errors {
+		projects = append(projects, p)
+	}
+	sort.Strings(projects)
+	return fmt.Sprintf("%d projects failed (%v), first error: %v", len(projects), projects, e.Errors[projects[0]])
+}
+
+// Unwrap returns the errors of the projects, for errors.Is() and errors.As().
+func (e *ProjectsError) Unwrap() []error {
+	var ret []error
+	for _, err := range e.Errors {
+		ret = append(ret, err)
+	}
+	return ret
+}
+
+// ListAcrossProjects calls list for each of projects with at most
+// concurrency calls in flight (DefaultBatchConcurrency if 0) and returns the
+// items by project. The projects whose call failed are missing from the
+// result and the error is a *ProjectsError giving the error of each of them.
+// This implements the generated ListAcrossProjects() methods.
+func ListAcrossProjects[T any](ctx context.Context, projects []string, concurrency int, list func(ctx context.Context, projectID string) ([]*T, error)) (map[string][]*T, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		ret  = map[string][]*T{}
+		errs = map[string]error{}
+	)
+	set := func(projectID string, items []*T, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[projectID] = err
+			return
+		}
+		ret[projectID] = items
+	}
+	sem := make(chan struct{}, concurrency)
+	for _, p := range projects {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			set(p, nil, ctx.Err())
+			continue
+		}
+		wg.Add(1)
+		go func(p string) {
+			defer func() { <-sem; wg.Done() }()
+			items, err := list(ctx, p)
+			set(p, items, err)
+		}(p)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return ret, &ProjectsError{Errors: errs}
+	}
+	return ret, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+)
+
+func TestListAcrossProjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		project := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/compute/v1/projects/"), "/global/firewalls")
+		if project == "denied" {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+		writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw-" + project}}})
+	})
+	s.BatchConcurrency = 2
+	projects := []string{"host", "service", "denied"}
+
+	got, err := NewGCE(s).Firewalls().ListAcrossProjects(ctx, projects, filter.None)
+	names := map[string][]string{}
+	for p, objs := range got {
+		for _, obj := range objs {
+			names[p] = append(names[p], obj.Name)
+		}
+	}
+	if want := map[string][]string{"host": {"fw-host"}, "service": {"fw-service"}}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Firewalls().ListAcrossProjects(%v) = %v, _; want %v", projects, names, want)
+	}
+	var pe *ProjectsError
+	if !errors.As(err, &pe) || len(pe.Errors) != 1 || !IsForbidden(pe.Errors["denied"]) {
+		t.Fatalf("Firewalls().ListAcrossProjects(%v) = _, %v; want a ProjectsError with http.StatusForbidden for denied", projects, err)
+	}
+	if got, want := err.Error(), "1 projects failed ([denied]), first error: "; !strings.HasPrefix(got, want) {
+		t.Errorf("Error() = %q; want prefix %q", got, want)
+	}
+	if _, err := NewGCE(s).Firewalls().ListAcrossProjects(ctx, projects[:2], filter.None); err != nil {
+		t.Errorf("Firewalls().ListAcrossProjects(%v) = _, %v; want nil", projects[:2], err)
+	}
+}
+
+func TestListAcrossProjectsCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	projects := []string{"a", "b", "c"}
+	_, err := ListAcrossProjects(ctx, projects, 1, func(ctx context.Context, projectID string) ([]*ga.Firewall, error) {
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ListAcrossProjects() = _, %v; want context.Canceled", err)
+	}
+}
@@ -24,7 +24,8 @@ limitations under the License.
 // Usage
 //
 // The root of the GCE compute API is the interface "Cloud". Code written using
-// Cloud can be used against the actual implementation "GCE" or "MockGCE".
+// Cloud can be used against the actual implementation "GCE" or "MockGCE" from
+// package "mock".
 //
 //  func foo(cloud Cloud) {
 //    igs, err := cloud.InstanceGroups().List(ctx, "us-central1-b", filter.None)
@@ -33,25 +34,512 @@ limitations under the License.
 //  // Run foo against the actual cloud.
 //  foo(NewGCE(&Service{...}))
 //  // Run foo with a mock.
-//  foo(NewMockGCE())
+//  foo(mock.NewMockGCE())
+//
+// NewService() builds a ready Service in one call: the authenticated
+// http.Client, the GA, Alpha and Beta clients sharing it, the ProjectRouter
+// and the RateLimiter. By default it uses the Application Default Credentials
+// for the scopes of all of the services, routes the calls to the project of the
+// credentials and rate limits them with NewDefaultRateLimiter(); the options
+// WithCredentialsFile(), WithScopes(), WithDefaultProject(), WithHTTPClient(),
+// WithProjectRouter() and WithRateLimiter() override these.
+//
+//  svc, err := cloud.NewService(ctx, cloud.WithCredentialsFile("key.json"), cloud.WithDefaultProject("my-project"))
+//  if err != nil {
+//  	return err
+//  }
+//  c := cloud.NewGCE(svc)
+//
+// The credentials are pluggable: WithTokenSource() takes any oauth2.TokenSource,
+// WithImpersonation() calls as a service account impersonated by the
+// credentials (optionally through a chain of delegates) with the short-lived
+// tokens of the IAM Service Account Credentials API, and WithWorkloadIdentity()
+// calls as the account of the metadata server, e.g. the Google service account
+// of the pod with GKE Workload Identity. The tokens of the latter two are
+// refreshed DefaultTokenRefreshMargin before they expire (see
+// NewRefreshingTokenSource()), so that a long-lived controller does not manage
+// the http.Clients of the three API versions itself.
+//
+//  svc, err := cloud.NewService(ctx,
+//  	cloud.WithWorkloadIdentity(),
+//  	cloud.WithImpersonation("deployer@my-project.iam.gserviceaccount.com"),
+//  )
+//
+// Service.HealthCheck() validates the credentials, scopes and connectivity of
+// each API version with a client by reading the project the calls are routed to,
+// one cheap call per version. The HealthReport tells which versions are usable
+// and gives an actionable hint for the others (e.g. enable the Compute Engine
+// API, request the missing scope, grant compute.projects.get), so that a
+// controller fails fast at startup instead of with a 403 later.
+//
+//  if err := svc.HealthCheck(ctx).Err(); err != nil {
+//  	glog.Exitf("Compute API unusable: %v", err)
+//  }
 //
 // Rate limiting and routing
 //
 // The generated code allows for custom policies for operation rate limiting
 // and GCE project routing. See RateLimiter and ProjectRouter for more details.
 //
+// Each service declares rate limit hints per operation (meta.RateLimit, a QPS
+// and a burst): ServiceInfo.RateLimit(op) returns the one declared by the
+// service, the one for "*", or meta.DefaultRateLimits. The hints are emitted in
+// the registry (Resource.RateLimits) and in a -config file as "rateLimits", e.g.
+// "rateLimits": {"Get": {"qps": 50, "burst": 100}}. NewDefaultRateLimiter()
+// returns a token bucket RateLimiter per project, service, version and operation
+// sized by the hints (see RateLimitHint()).
+//
+// NewConfiguredRateLimiter() takes the QPS and burst per version, service and
+// operation instead (a RateLimitSelector, where an empty field matches any
+// value): the most specific selector that matches a call wins, and the empty
+// selector is the default bucket. The calls that match no selector keep the
+// hints. The buckets are implemented in the package rather than with
+// "golang.org/x/time/rate", which is not part of the vendored dependencies.
+//
+// AdaptiveRateLimiter starts from the hints and adapts to the quota errors
+// (IsQuotaExceeded()) returned by GCE: each error backs off the QPS of the
+// RateLimitKey and the QPS recovers after a period without errors. The errors
+// are fed back through the RateLimitObserver interface: after each call, the
+// generated code passes the result to Observe(ctx, key, err) if the
+// RateLimiter implements it.
+//
+// The policies compose instead of being written as one limiter:
+// NewCompositeRateLimiter() accepts a call once each of its limiters accepted it,
+// NewScopedRateLimiter() applies a limiter to a coarser key (GlobalScope,
+// ProjectScope, ServiceScope, OperationScope), so that e.g. all of the calls of
+// a project share a bucket, and NewMinimumRateLimiter() spaces the calls of the
+// same key by a minimum interval.
+//
+//  svc.RateLimiter = cloud.NewCompositeRateLimiter(
+//  	cloud.NewScopedRateLimiter(cloud.GlobalScope, global),
+//  	cloud.NewScopedRateLimiter(cloud.ProjectScope, perProject),
+//  	cloud.NewDefaultRateLimiter(),
+//  	cloud.NewMinimumRateLimiter(100*time.Millisecond),
+//  )
+//
+// Service.RetryPolicy retries the calls that fail with a transient error (by
+// default IsRetryable(): a 5xx, a quota error or a connection reset) with an
+// exponential backoff, e.g. DefaultRetryPolicy(). Each attempt goes through the
+// RateLimiter. Only the calls that read are retried by default, as a mutation
+// applied by a failed attempt makes its retry fail (e.g. a 409 for an Insert):
+// RetryPolicy.Mutations also retries the mutations. For a mutation, only the
+// call returning the operation is retried; an error of the operation itself is
+// returned as-is.
+//
+// Service.PollPolicy sets the cadence of WaitForCompletion(): the interval
+// between the polls of an operation, its growth (Multiplier, MaxInterval) and a
+// Timeout. Service.PollPolicies overrides it for the mutations matching a
+// RateLimitSelector, e.g. {Service: "Instances", Operation: "Insert"} to poll
+// the creation of instances less often than the insertion of firewalls, and
+// WaitForCompletionWithPolicy() takes the policy of a single call. The polls
+// remain subject to the RateLimiter.
+//
+// The wait stops as soon as its context is done, whether it is between two
+// polls, waiting for the RateLimiter or in the middle of a poll, and returns
+// the error of the context (ctx.Err()) rather than the error of the interrupted
+// request. With PollPolicy.DeleteOnCancel, an abandoned operation (the context
+// is done or the Timeout expired) is deleted on a best-effort basis, within a
+// few seconds, before the wait returns.
+//
+// The delays of the retries and of the polls share one Backoff (Initial,
+// Multiplier, Max and a Jitter randomizing each delay). Service.Backoff is the
+// Backoff of the RetryPolicy and the PollPolicies that have none of their own,
+// replacing their other delays, and AdaptiveRateLimiter.QuotaBackoff holds the
+// calls of a key after consecutive quota errors. A zero Backoff makes the tests
+// wait no time.
+//
+//  s.Backoff = &cloud.Backoff{Initial: time.Second, Multiplier: 2, Max: 30 * time.Second, Jitter: 0.2}
+//
+//  // In the tests.
+//  s.Backoff = &cloud.Backoff{}
+//
+// WaitForCompletion() polls the operations collection of the scope of the
+// operation: GlobalOperations, RegionOperations or ZoneOperations, in the
+// project of its selfLink. An operation whose selfLink was left out by a
+// Fields() projection is located by its name and the URL of its region or zone.
+//
+// Service.ConcurrencyLimiter bounds the number of calls in flight, overall and
+// per service (NewConcurrencyLimiter()), so that a runaway loop cannot open
+// hundreds of connections to the API at once. The calls over the limits wait
+// for a slot; the polls of the operations count as calls of "Operations".
+//
+//  svc.ConcurrencyLimiter = cloud.NewConcurrencyLimiter(64, map[string]int{"Instances": 16})
+//
+// SingleProjectRouter routes every call to one project. ServiceProjectRouter
+// routes by service name or by tag (Resource.Tags), e.g. the networking
+// services to the host project of a Shared VPC and the instances to a service
+// project. OverrideProjectRouter routes a single call to the project of its
+// context (WithProjectID()) and the other calls with another router.
+//
+//  svc.ProjectRouter = &cloud.OverrideProjectRouter{
+//  	Router: &cloud.ServiceProjectRouter{
+//  		Default: "service-project",
+//  		Tags:    map[meta.Tag]string{meta.TagNetworking: "host-project"},
+//  	},
+//  }
+//  err := c.Instances().Insert(cloud.WithProjectID(ctx, "other-project"), key, obj)
+//
+// The Project() option makes a single call in a project whatever the router.
+// The networking services (Addresses, GlobalAddresses, Firewalls and Routes)
+// have ListAcrossProjects(ctx, projects, ...), listing the objects of each of
+// the projects of a Shared VPC with Service.BatchConcurrency calls in flight.
+// It returns the objects by project and a *ProjectsError giving the error of
+// each project whose call failed; the objects of the other projects are still
+// returned. The mocks, not being project aware, return their objects for every
+// project.
+//
+//  byProject, err := c.Firewalls().ListAcrossProjects(ctx, []string{"host-project", "service-project"}, filter.None)
+//  var pe *cloud.ProjectsError
+//  if errors.As(err, &pe) {
+//  	for project, err := range pe.Errors {
+//  		log.Printf("listing the firewalls of %s: %v", project, err)
+//  	}
+//  }
+//
+// ServiceInfo.Scopes() gives the OAuth scopes needed by a service: the
+// compute scope if it has methods that modify resources, compute.readonly
+// otherwise. meta.RequiredScopes() combines the scopes of several services into
+// the minimal set to request when building the clients (see cmd/example). The
+// cloud-platform scope (meta.CloudPlatformScope) covers all of them.
+//
+// Service.Endpoints sets the base URL of an API version, e.g. to call it
+// through Private Google Access, a restricted VIP, a staging endpoint or a local
+// fake server. It is set as the BasePath of the client of the version, whether the
+// client is given or constructed by NewGA/NewAlpha/NewBeta. ParseResourceURL
+// accepts the self-links returned by these endpoints.
+//
+//  s := &cloud.Service{
+//  	NewGA: newGAClient,
+//  	Endpoints: map[meta.Version]string{
+//  		meta.VersionGA: "https://compute.private.googleapis.com/compute/v1/",
+//  	},
+//  }
+//
+// Service.RegionalEndpoints routes the calls on the resources of a region and of
+// its zones to the regional endpoint of the region, for data residency or
+// latency, along with the polls of their operations. The calls on the global
+// resources and in the other regions use the global endpoint. The clients of the
+// regional endpoints are copies of the client of each version sharing its
+// http.Client.
+//
+//  s.RegionalEndpoints = map[string]string{
+//  	"me-central2": "https://compute.me-central2.rep.googleapis.com/",
+//  }
+//
+// Service.UserAgent is appended to the User-Agent of the calls to attribute them
+// to the application. Service.QuotaProject sends the X-Goog-User-Project header
+// so the quota and billing of another project are used. Service.CallOptions are
+// added to every call, including each page of the lists, e.g.
+// googleapi.QuotaUser() or a fields projection with Fields().
+//
+//  s.UserAgent = "my-controller/1.2"
+//  s.QuotaProject = "billing-project"
+//  s.CallOptions = []googleapi.CallOption{cloud.Fields("items/name", "nextPageToken")}
+//
+// The methods making a single API call (Get, the list methods, Insert, Delete,
+// Update, Patch and the custom methods) take Options tuning that call alone:
+// Timeout() bounds it, including waiting for the operation, FieldMask()
+// restricts the fields of the response of a read, RequestID() sets the
+// idempotency token of a mutation and SkipWait() returns as soon as the
+// operation of a mutation is started. CachedCloud does not cache the calls with
+// Options and the mocks ignore them.
+//
+//  err := c.Firewalls().Insert(ctx, key, fw, cloud.RequestID(id), cloud.Timeout(time.Minute))
+//  fws, err := c.Firewalls().List(ctx, filter.None, cloud.FieldMask("items(name,selfLink),nextPageToken"))
+//
+// Metrics
+//
+// The GCE adapters record every call to Service.MetricsRecorder, if set, with
+// its project, version, service, operation, latency and error, so latency and
+// error rates can be exported without decorating each method by hand. The
+// latency of a mutation includes waiting for the operation to complete.
+//
+// CallMetrics.Code is the HTTP status code of the call (0 if it failed without
+// a response). A MetricsRecorder that is also a CallStartRecorder is told of
+// the start of each call as well. PrometheusRecorder is a ready-made
+// implementation that serves the calls in flight, the calls by status code and
+// a latency histogram per version, service and operation in the Prometheus text
+// format. It does not use the Prometheus client library, which is not vendored.
+//
+//  rec := cloud.NewPrometheusRecorder(nil)
+//  svc.MetricsRecorder = rec
+//  http.Handle("/metrics", rec)
+//
+// Service.Tracer starts a span around every call of the GCE adapters,
+// regardless of MetricsRecorder. The span is named after the service and operation
+// (e.g. "gce.Firewalls.Insert") and has the project, version, operation and
+// key (for the calls on an object) as attributes. The call, including its
+// retries and the wait for its operation, is made with the context returned by
+// Tracer.Start(), so the span propagates to the HTTP requests. The Tracer and
+// Span interfaces are modeled on OpenCensus and OpenTelemetry.
+//
+// Service.CallLogger receives a CallLog for every call of the GCE adapters,
+// regardless of MetricsRecorder, with the method, key, duration, error, and the request
+// of the mutations or the response of the other calls. GlogCallLogger logs a
+// line per call at its Verbosity, and the payloads as JSON at its
+// PayloadVerbosity, with the fields in RedactedFields (by default the metadata,
+// private keys and encryption keys) replaced by "REDACTED".
+//
+//  svc.CallLogger = &cloud.GlogCallLogger{Verbosity: 4, PayloadVerbosity: 6}
+//
+// Service.Interceptors are called around every call of the GCE adapters, to
+// layer cross-cutting features such as auditing, fault injection or header
+// stamping without wrapping each interface. Before is called in order with a
+// CallInfo (project, version, service, operation, key and request); an error
+// fails the call without making it, and the headers it sets in CallInfo.Header
+// are added to the HTTP requests of the call. After is called in reverse order
+// with the error of the call and, for a call that is not a mutation, its
+// Response. The interceptors see each call once, not each retry.
+//
+//  svc.Interceptors = append(svc.Interceptors, cloud.Interceptor{
+//  	Before: func(ctx context.Context, call *cloud.CallInfo) error {
+//  		call.Header = http.Header{"X-Audit-Tag": {auditTag(ctx)}}
+//  		return nil
+//  	},
+//  	After: func(ctx context.Context, call *cloud.CallInfo, err error) {
+//  		audit.Record(call.Operation, call.Key, err)
+//  	},
+//  })
+//
+// Interfaces
+//
+// The Cloud interface and the service interfaces are generated into the
+// separate package "interfaces" ("interfaces/gen.go"), which does not depend on
+// the GCE adapters or the mocks. Libraries that only accept a Cloud can depend
+// on package interfaces alone; package cloud defines aliases for them (e.g.
+// "cloud.Firewalls" is "interfaces.Firewalls").
+//
+// Cloud is composed of an interface for each API version: GACloud, AlphaCloud and
+// BetaCloud. A component that must only use the GA API can accept a GACloud and
+// is prevented at compile time from calling the alpha and beta services. GCE and
+// MockGCE can be passed as any of them.
+//
+//  func NewController(c cloud.GACloud) *Controller { ... }
+//
+// Services are also tagged by area in meta (meta.Tag, e.g. "loadbalancing" or
+// "networking"; "tags" in a -config file), and an interface is generated for
+// each tag with the services that have it at all of their versions (e.g.
+// LoadBalancingCloud). A component that depends on a tag interface is easier to
+// fake in tests, and a dependency on another area is a compile error.
+//
+//  func NewL7Controller(c cloud.LoadBalancingCloud) *L7Controller { ... }
+//
+// A service generated at more than one API version (e.g. BackendServices at
+// alpha and GA) also has a single accessor on Versioned(c), which returns the
+// service at each of its versions. It works with any Cloud, including the mocks.
+// In a -config file, "versions" declares a service at several versions with the
+// same configuration.
+//
+//  bs := cloud.Versioned(c).BackendServices()
+//  obj, err := bs.Alpha().Get(ctx, key)
+//
+// A resource that exists in two scopes as two services with the same object
+// (e.g. Disks and RegionDisks) is declared as a meta.ScopedPair. Scoped(c) has a
+// single accessor for it, whose methods that take a key call the service for
+// the scope of the key; a key of another scope is an error.
+//
+//  disks := cloud.Scoped(c).AlphaDisks()
+//  err := disks.Delete(ctx, *meta.RegionalKey("disk-1", "us-central1"))
+//
+// NewCachedCloud(c, ttl) wraps any Cloud in a read-through cache: the results
+// of Get, Exists and the list calls are cached for the TTL, and the mutations
+// made through it (including the operations of InsertOp and DeleteOp, once done)
+// invalidate the object of their key and the lists of its service.
+// Invalidate(key) and InvalidateAll() drop the changes made by others. The
+// cached objects are shared and must not be modified. The wrappers are generated
+// from the methods of each service; the hand written methods of CustomOps pass
+// through uncached.
+//
+//  c := cloud.NewCachedCloud(cloud.NewGCE(svc), 30*time.Second)
+//  fws, err := c.Firewalls().List(ctx, filter.None)
+//
+// NewDryRunCloud(c) passes the reads through to c and records the mutations
+// (including the methods such as SetTarget and Projects'
+// SetCommonInstanceMetadata) as PlannedChanges instead of making them, to show
+// what a tool would change before it is run for real. The recorded mutations
+// succeed without changing what the reads return.
+//
+//  c := cloud.NewDryRunCloud(cloud.NewGCE(svc))
+//  err := migrate(ctx, c)
+//  for _, ch := range c.Changes() {
+//  	fmt.Println(ch)
+//  }
+//
+// NewCircuitBreakerCloud(c, policy) guards the calls of each service with a
+// circuit breaker. Once FailureRatio of at least MinCalls calls in a Window
+// failed with a 5xx or a timeout (IsBreakerFailure()), the calls of the service
+// fail fast with a *CircuitOpenError (IsCircuitOpen()) instead of hammering the
+// degraded API and spending the quota on retries. After OpenDuration a single
+// probe call is let through, which closes the breaker if it succeeds.
+//
+//  c := cloud.NewCircuitBreakerCloud(cloud.NewGCE(svc), cloud.DefaultCircuitBreakerPolicy())
+//  if _, err := c.Instances().Get(ctx, key); cloud.IsCircuitOpen(err) {
+//  	// Requeue without calling the API.
+//  }
+//
+// NewReadOnlyCloud(c) passes the reads (Get, List, ...) through to c and fails
+// every mutation, including the methods such as SetTarget and Projects'
+// SetCommonInstanceMetadata, with a *ReadOnlyError wrapping ErrReadOnly without
+// calling c. Audit and reporting tools use it to be guaranteed never to modify
+// the project, even if they are miswired. GetOrCreate returns the object if it
+// exists.
+//
+//  c := cloud.NewReadOnlyCloud(cloud.NewGCE(svc))
+//  if err := c.Firewalls().Delete(ctx, key); errors.Is(err, cloud.ErrReadOnly) {
+//  	// Nothing was deleted.
+//  }
+//
+// NewRecordingCloud(c) passes the calls through to c and records each of them,
+// with its results and its error, in a Cassette saved as JSON.
+// NewReplayCloud(cassette) then serves the calls from the cassette without
+// calling GCE, so that the tests of a controller run against the recorded
+// responses of the real API (VCR style). Each call is answered by the first
+// recorded call of the same method with the same key and arguments that has not
+// been served yet (see ReplayCloud.Match), so that a Get failing with 404, an
+// Insert and a Get returning the object are replayed in order. A call that was
+// not recorded fails with ErrNotRecorded, and Unused() returns the recorded
+// calls that were not made. MockGCE.LoadCassette() stores the objects returned
+// in a cassette in the mocks, for the tests that need to modify them (see also
+// -record and -replay in cmd/example).
+//
+//  // Once, against a test project:
+//  rc := cloud.NewRecordingCloud(cloud.NewGCE(svc))
+//  err := reconcile(ctx, rc)
+//  err = rc.Cassette().Save(f)
+//
+//  // In the test:
+//  cassette, err := cloud.LoadCassette(f)
+//  err = reconcile(ctx, cloud.NewReplayCloud(cassette))
+//
 // Mocks
 //
 // Mocks are automatically generated for each type implementing basic logic for
 // resource manipulation.  This eliminates the boilerplate required to mock GCE
 // functionality.  Each method will also have a corresponding "xxxHook"
 // function generated in the mock structure where unit test code can hook the
-// execution of the method.
+// execution of the method. Sequences of outcomes (e.g. fail twice, then
+// succeed, or fail with a list of errors in order) and failures at random
+// with a given probability can be scripted without hooks using a Scenario.
+//
+// The mocks are generated into the separate package "mock" ("mock/gen.go") so
+// that production binaries importing package cloud do not link them in. Only
+// test code needs to import "mock".
+//
+// Like the compute API, the mocks reject an Insert of an object without one of
+// its required fields with 400 Bad Request (cloud.IsBadRequest), so that an
+// incomplete object is caught by the unit tests of a controller. The required
+// fields are declared per service in meta (e.g. Network for Firewalls,
+// MachineType for Instances), or with "requiredFields" in a -config file.
 //
 // Mocks for different versions of the same service will share the same set of
 // objects, i.e. an alpha object will be visible with beta and GA methods.
 // Note that translation is done with JSON serialization between the API versions.
 //
+// Like the GCE adapters, the mocks name an inserted object after its key (the
+// Name, or the identity field of the service). They also set the output only
+// fields that the compute API sets on an inserted object, unless the test has
+// set them: the SelfLink (for the version of the mock, in mock.DefaultProjectID
+// or the project given to MockGCE.SetProjectID()), a unique Id and the
+// CreationTimestamp. The object passed to Insert is not modified.
+//
+// Like the API, the mocks have value semantics: they store a deep copy of the
+// objects passed to Insert and Update and return deep copies from Get and List
+// (with the generated cloud.CopyXxx functions), so a test modifying an object
+// does not change the state of the mock.
+//
+// To assert on the calls made without hooks, set a mock.Recorder on all of the
+// mocks with MockGCE.UseRecorder() (or on a single mock with its Recorder
+// field). It records the service, API version, operation, key, arguments, time
+// and returned error of each call:
+//
+//  rec := mock.NewRecorder()
+//  m.UseRecorder(rec)
+//  ...
+//  if calls := rec.CallsMatching("Firewalls", "Insert", &key); len(calls) != 1 {
+//  	t.Errorf("got %v; want exactly one Insert of %v", calls, key)
+//  }
+//
+// To test the handling of exhausted quotas, set limits on MockGCE.MockQuotas for
+// the quota metrics of the compute API (see cloud.QuotaServices()), for the
+// project or for a region. An Insert beyond the limit fails with 403
+// quotaExceeded (cloud.IsQuotaExceeded) and the message and body of the API
+// error. The usage is the number of objects in the mocks:
+//
+//  m.MockQuotas.SetRegionLimit("us-central1", "STATIC_ADDRESSES", 8)
+//  m.MockQuotas.SetProjectLimit("FIREWALLS", 100)
+//
+// The objects of the mocks can be saved as JSON by service and key with
+// MockGCE.Save() and loaded with MockGCE.Load(), so that realistic fixtures,
+// captured from a MockGCE or written by hand, can be checked in and shared by
+// tests (see also -mockstate in cmd/example):
+//
+//  // testdata/state.json: {"Firewalls": {"global/fw": {"name": "fw", "network": "default"}}}
+//  f, err := os.Open("testdata/state.json")
+//  ...
+//  err = m.Load(f)
+//
+// Larger fixtures can be declared as a manifest of resources, each with its
+// kind, key and fields, and stored with MockGCE.Seed(). The kind is the object
+// or the service of the resource; objects such as Address are told apart by the
+// type of the key. Seed also stores the objects of the read-only resources
+// (e.g. Zone) and sets their SelfLink, Id and CreationTimestamp. The mocks are
+// not modified if the manifest is invalid:
+//
+//  err := m.Seed(strings.NewReader(`[
+//    {"kind": "Zone", "key": "global/us-central1-b"},
+//    {"kind": "Instance", "key": "zones/us-central1-b/vm-1", "object": {"machineType": "n1-standard-1"}},
+//    {"kind": "Address", "key": "global/lb-ip"}
+//  ]`))
+//
+// The mutations of the mocks complete at once by default. To test the handling
+// of pending operations, set MockGCE.MockOperations.Async: a mutation then
+// starts an operation, stored as "RUNNING" in the GlobalOperations,
+// RegionOperations or ZoneOperations mock, and the object only changes when the
+// operation completes, after MockOperations.Delay or when the test calls
+// MockOperations.Step(). Insert and the other mutations wait for their
+// operation, InsertOp and DeleteOp return it pending, and mock.NewHTTPHandler
+// serves it as pending to Service.WaitForCompletion().
+//
+//  m := mock.NewMockGCE()
+//  m.MockOperations.Async = true
+//  op, err := m.Firewalls().InsertOp(ctx, key, fw)
+//  // The firewall does not exist yet.
+//  m.MockOperations.Step()
+//  err = op.Wait(ctx)
+//
+// Code that uses the compute API clients directly, or tools not written in Go,
+// can be tested against the same state as the mocks with mock.NewHTTPHandler.
+// It serves the GET, POST and DELETE calls of the REST API and the operations
+// endpoints from a MockGCE. The aggregated lists of the zonal and regional
+// resources are keyed by "zones/<zone>" or "regions/<region>" as in the API, with
+// an empty scope carrying a NO_RESULTS_ON_PAGE warning for each zone or region
+// of MockZones or MockRegions without objects:
+//
+//  srv := httptest.NewServer(mock.NewHTTPHandler(m))
+//  svc, err := ga.New(srv.Client())
+//  svc.BasePath = srv.URL + "/compute/v1/projects/"
+//
+// Teams that use gomock for expectations and call verification can also
+// generate mockgen style mocks (NewMockXxx(ctrl), EXPECT()) for Cloud and each
+// service interface into package "gomocks" with -gomock. The generated code
+// requires "github.com/golang/mock", which is not vendored by this repository.
+// Services with the CustomOps option are not mocked as their Ops interface is
+// written by hand.
+//
+//  $ go run gen/main.go -dir . -gomock
+//
+// Generated code structure
+//
+// The generated wrappers are a thin layer per service over a common generic
+// core: resourceClient ("gce_core.go") for the GCE adapters and mockStore
+// ("mock/mock_core.go") for the mocks. Behavior shared by all services should be
+// changed in the core rather than in the generator templates.
+//
+// The interfaces and the GCE adapters are commented with the descriptions of the
+// resources, methods and parameters from the discovery document vendored with
+// each compute client ("compute-api.json"). The descriptions change with the
+// vendored clients; use -docs=false to generate the code without them.
+//
 // Changing service code generation
 //
 // The list of services to generate is contained in "meta/meta.go". To add a
@@ -69,10 +557,413 @@ limitations under the License.
 //    options: <options>              // Or'd ("|") together.
 //  }
 //
+// The generator also writes unit tests ("mock/gen_test.go") that exercise the
+// mock of every service, so new entries are tested automatically. Regenerate all
+// of the files after changing the list:
+//
+//  $ go run gen/main.go -dir .
+//
+// Before generating, the generator checks each service against the golang
+// client of its version: the service, the object type and the generated methods
+// must exist. An invalid entry fails with a message naming the service instead of
+// producing code that does not compile.
+//
+// The generator also writes fuzz targets ("mock/gen_fuzz_test.go") for
+// ParseResourceURL and for the typed keys and mock CRUD paths of each service.
+// The seed corpus runs as part of "go test"; to fuzz a target continuously:
+//
+//  $ go test ./mock -run NONE -fuzz FuzzParseResourceURL
+//
+// Benchmarks of the mock Get, List and Insert calls at various object counts and
+// of the conversions between API versions are generated into
+// "mock/gen_bench_test.go":
+//
+//  $ go test ./mock -run NONE -bench .
+//
+// Use -check to verify that the generated files are up to date, e.g. in a
+// presubmit. It exits with a non-zero status if they need to be regenerated:
+//
+//  $ go run gen/main.go -check -dir .
+//
+// The services can also be derived from the compute API discovery documents
+// ("compute-api.json") instead of "meta.AllServices". The service names,
+// object types, key types and methods are taken from the document for each
+// resource in the allowlist given by -resources. CustomOps cannot be derived
+// and is taken from the matching "meta.AllServices" entry.
+//
+//  $ go run gen/main.go -services discovery -resources addresses,instances
+//
+// The services can also be read from a JSON configuration file given by -config
+// instead of "meta.AllServices". This allows a team to maintain its own set of
+// resources without forking "meta/meta.go". See "meta.Config" for the format.
+// YAML is not supported as it would require an additional dependency.
+//
+//  $ go run gen/main.go -config services.json
+//
+// The client packages of the API are given by the API group of the services
+// ("meta.APIGroup"). Services default to "meta.ComputeAPI"; other Google APIs
+// can be described by adding an entry to "meta.APIGroups" and selecting it with
+// "apiGroup" in the configuration file or -api-group with -services=discovery.
+// All of the services generated together must be in the same API group. Note
+// that the generated GCE adapters use the compute Service and operations, so
+// only the interfaces and mocks can be generated for other API groups today.
+//
+// Consumers that only need a handful of resources can generate a slim wrapper
+// with -only and -exclude, which select services by name (e.g. "Firewalls"). All
+// of the versions of a selected service are generated, as are Projects, Regions,
+// Zones and the operations, which the hand-written code of the package uses
+// and which cannot be excluded. Note that services with the CustomOps option
+// need their hand-written code alongside the output.
+//
+//  $ go run gen/main.go -only Firewalls,Addresses
+//
+// The generated code is produced from the templates in "gen/templates". A
+// project can override or extend individual templates without forking the
+// generator by placing files with the same name (e.g. "types.tmpl") in a
+// directory given by -template-dir.
+//
 // Read-only objects
 //
-// Services such as Regions and Zones do not allow for mutations. Specify
-// "ReadOnly" in ServiceInfo.options to omit the mutation methods.
+// Services such as Regions, Zones, MachineTypes and DiskTypes do not allow for
+// mutations. Specify "ReadOnly" in ServiceInfo.options to generate only Get() and
+// List(). ReadOnly cannot be combined with Update, Patch or additional methods
+// that return an operation. With -services=discovery, resources without any call
+// returning an operation are read-only. mock.NewHTTPHandler rejects mutations of
+// read-only resources with 405 Method Not Allowed.
+//
+// Update and Patch
+//
+// Specify "Update" and/or "Patch" in ServiceInfo.options to generate the
+// corresponding methods for resources that support them. Patch only modifies
+// the fields set in the object; the mock emulates this by merging the
+// non-empty fields into the stored object.
+//
+// For resources with a fingerprint (e.g. BackendServices, UrlMaps), Update and
+// Patch are guarded by it: the call fails with 412 Precondition Failed
+// (cloud.IsPreconditionFailed) if the fingerprint in the object is set and is
+// not the one of the current resource. The mocks emulate this and give the
+// resource a new fingerprint on each modification.
+//
+// These services also have UpdateWithRetry(), which reads the current resource,
+// modifies it with a function and updates it. If the resource is modified
+// concurrently, the update fails on the fingerprint and is retried with the
+// resource read again, up to cloud.MaxUpdateAttempts times.
+//
+//  err := cloud.UrlMaps().UpdateWithRetry(ctx, key, func(um *ga.UrlMap) error {
+//  	um.DefaultService = backend
+//  	return nil
+//  })
+//
+// Labels
+//
+// Resources with labels that are set with a SetLabels call (e.g. Disks,
+// Instances, alpha Addresses) have UpdateLabels(), which reads the label
+// fingerprint of the current resource and sends it with the new labels. These
+// services are found with ServiceInfo.SupportsLabels(); nothing needs to be
+// configured.
+//
+//  err := cloud.Disks().UpdateLabels(ctx, key, map[string]string{"env": "prod"})
+//
+// List filters
+//
+// The list calls take a *filter.F, which is passed to the API as the filter of
+// the call so that only the matching resources are returned. Build it with the
+// helpers (filter.Regexp("name", "abc.*"), filter.Label("env", "prod"), ...) or
+// parse a filter expression of the API with filter.Parse(), which supports the
+// eq, ne, =, != and <, <=, >, >= comparisons joined by AND, OR and NOT (as in
+// the API, the eq and ne regexps cannot be mixed with the rest). The
+// mocks evaluate the same filters on their objects, including labels and integer
+// fields, as does the HTTP handler of the mocks for the filter parameter of the
+// list calls, so that filtered lists can be tested offline.
+//
+//  fl := filter.MustParse("(labels.env eq prod) (name ne .*-canary)")
+//  vms, err := c.Instances().List(ctx, "us-central1-b", fl)
+//
+// Paged lists
+//
+// List() accumulates all of the pages of the collection in memory. The services
+// whose List() call is paged also have ListPage(), which returns a single page
+// of at most maxResults objects starting at a page token, and the token of the
+// next page. NewIterator() walks the pages of a ListPage one page (NextPage()) or
+// one object (Next()) at a time. The mocks return their objects in the order of
+// their keys, with the offset of the page as its token.
+//
+//  it := cloud.NewIterator(func(ctx context.Context, token string) ([]*ga.Instance, string, error) {
+//  	return c.Instances().ListPage(ctx, "us-central1-b", filter.None, token, 500)
+//  })
+//  for {
+//  	obj, err := it.Next(ctx)
+//  	if err == cloud.IteratorDone {
+//  		break
+//  	}
+//  	...
+//  }
+//
+// Aggregated lists
+//
+// Specify "AggregatedList" in ServiceInfo.options to generate AggregatedList(),
+// which lists the resources across all zones or regions in a single call. The
+// result is keyed by location (e.g. "us-central1-b").
+//
+// Additional list calls
+//
+// Some resources have List style calls besides List() (e.g.
+// Subnetworks.ListUsable, InstanceGroupManagers.ListManagedInstances). Name
+// them in ServiceInfo.listMethods (or "listMethods" in a -config file) to
+// generate them. The shape of the call is derived from the golang client: the
+// parameters (project, location or the key of a resource), the response type
+// and the field of the response containing the items. The mocks of these calls must be set with the
+// corresponding "xxxHook".
+//
+// Exists and GetOrCreate
+//
+// Services with Get() also have Exists(), which returns false instead of an
+// error if the resource is not found. Services with Get() and Insert() have
+// GetOrCreate(), which returns the existing resource or inserts the desired one.
+// The 404 handling is implemented once by the cloud.Exists and cloud.GetOrCreate
+// helpers used by both the GCE adapters and the mocks.
+//
+//  fw, err := cloud.Firewalls().GetOrCreate(ctx, key, &ga.Firewall{...})
+//
+// Services with Insert() and Delete() also have InsertOp() and DeleteOp(), which
+// return a pending Op as soon as GCE has accepted the mutation instead of
+// waiting for the operation. Op.Wait() polls the operation according to the
+// PollPolicy, Op.Done() polls it once and Op.Error() is the error of the
+// completed operation. The mutations can be started concurrently and waited for
+// afterwards. The ChangeSink receives the change when the operation completes.
+// In the mocks, the returned Op has already completed unless the mock
+// operations are asynchronous (see MockOperations in Mocks).
+//
+//  ops := make([]cloud.Op, len(keys))
+//  for i, key := range keys {
+//    ops[i], err = cloud.Firewalls().InsertOp(ctx, key, fws[i])
+//  }
+//  for _, op := range ops {
+//    err := op.Wait(ctx)
+//  }
+//
+// The operations themselves are services: GlobalOperations, RegionOperations
+// and ZoneOperations (GA) have Get, List and Delete, and a hand written
+// Wait(ctx, key) that polls the operation according to the PollPolicy. Together
+// with Op.Key(), this allows resuming the wait for an operation by name, e.g.
+// after a restart. The Delete of an operation does not return an operation
+// (meta.ServiceInfo.DeleteReturnsOperation()). The mock Wait() returns once the
+// stored operation has the status "DONE".
+//
+// Errors
+//
+// The errors of the calls are the *googleapi.Error returned by the golang
+// client, or made up by the mocks. Instead of checking the status code, use the
+// helpers cloud.IsNotFound, IsConflict, IsForbidden, IsPreconditionFailed,
+// IsBadRequest, IsQuotaExceeded and IsOperationInProgress. They find the
+// googleapi.Error with errors.As(), so they work on errors wrapped with
+// fmt.Errorf("%w") and on a *BatchError, for which they are true if any of the
+// calls failed with such an error.
+//
+//  if err := c.Firewalls().Insert(ctx, key, fw); cloud.IsConflict(err) {
+//  	// The firewall already exists.
+//  }
+//
+// The errors of the generated methods are returned as a *cloud.Error, which
+// describes the call that failed: the Resource (project, collection and key),
+// Version, Service, Operation and HTTP Code. It unwraps to the error of the
+// call, so the helpers and errors.As() work as if it was returned as-is. The
+// mocks return the same shape for the errors they make up (e.g. not found);
+// injected errors are returned unchanged.
+//
+//  var e *cloud.Error
+//  if errors.As(err, &e) {
+//  	log.Printf("%s of %s failed with %d", e.Operation, e.Resource.RelativeResourceName(), e.Code)
+//  }
+//
+// An operation that completes with errors (the Error field of the Operation) is
+// returned as a *cloud.OperationError by WaitForCompletion(), the Wait() of the
+// operations services and of an Op, and by the mutations (wrapped in the
+// *cloud.Error). It has the SelfLink of the operation, its HTTPStatusCode and
+// the Code, Location and Message of each error, so that a controller can e.g.
+// retry RESOURCE_NOT_READY later and report QUOTA_EXCEEDED.
+// CheckOperation() returns it for an Operation that is already done.
+//
+//  err := c.Instances().Insert(ctx, key, vm)
+//  switch {
+//  case cloud.IsOperationError(err, "RESOURCE_NOT_READY"):
+//  	// Requeue.
+//  case cloud.IsOperationError(err, "QUOTA_EXCEEDED", "ZONE_RESOURCE_POOL_EXHAUSTED"):
+//  	// Try another zone.
+//  }
+//
+// Quotas
+//
+// cloud.ProjectQuotas() and cloud.RegionQuotas() return the quotas of a project
+// (global resources) or a region (regional and zonal resources) from the Get of
+// the project or the region. cloud.QuotaServices() maps a quota metric (e.g.
+// IN_USE_ADDRESSES) to the services whose Insert counts against it, and
+// Quotas.Check() returns an error classified by IsQuotaExceeded if one of the
+// quotas of a service does not have enough left, so that an Insert that would
+// certainly fail can be skipped.
+//
+//  quotas, err := cloud.RegionQuotas(ctx, c.Regions(), "us-central1")
+//  if err == nil && quotas.Check("Addresses", 1) != nil {
+//  	// Wait for addresses to be released.
+//  }
+//
+// Batch calls
+//
+// BatchGet(ctx, keys) and BatchDelete(ctx, keys) get or delete many objects of a
+// service at once. The golang client does not support the batch HTTP requests
+// of the compute API, so the calls fan out concurrently with at most
+// Service.BatchConcurrency (DefaultBatchConcurrency) calls in flight, each
+// subject to the RateLimiter and RetryPolicy. The results are in the order of
+// the keys; if any call fails, the error is a *BatchError with the error of each
+// key. CachedCloud answers BatchGet from the cache of Get for the keys it has.
+//
+//  objs, err := c.Instances().BatchGet(ctx, keys)
+//  if be, ok := err.(*cloud.BatchError); ok {
+//  	// be.Errors[i] is the error for keys[i].
+//  }
+//
+// Typed keys
+//
+// meta.Key does not carry the scope of the resource, so a zonal key can be
+// passed to a global resource by mistake. The generator emits a typed key for
+// each service (e.g. "FirewallKey", "InstanceKey") whose constructor requires
+// the location of the resource. Key() converts it to the meta.Key used by the
+// service methods and <Type>From() converts back, returning an error if the
+// scope does not match.
+//
+//  key := NewInstanceKey("my-vm", "us-central1-b")
+//  inst, err := cloud.Instances().Get(ctx, key.Key())
+//
+// The GCE adapters check the key of every call with meta.Key.Validate() before
+// calling the API: the name must be a RFC1035 label (or a numeric resource id),
+// the zone and region must be well formed, and the scope of the key must match
+// the service. A bad key is returned as a descriptive error instead of the
+// HTTP 400 from the API. meta.NewGlobalKey(), meta.NewRegionalKey() and
+// meta.NewZonalKey() apply the same rules when the key is built. The mocks do
+// not validate keys.
+//
+// Insert sets the name of the object from the key. For a resource identified by
+// another field, the service declares its identity field in meta
+// ("identityField" in a -config file): a string field is set from the name of
+// the key, a uint64 field (e.g. "Id") from the name parsed as a numeric ID, and
+// "-" (meta.NoIdentityField) sets nothing, for objects whose identity is
+// assigned by the API.
+//
+// meta.Key.Path() renders the path of the resource named by a key (e.g.
+// "projects/my-project/zones/us-central1-b/instances/my-vm") or, given an API
+// version, its full URL, which ParseResourceURL() parses back into the key.
+// KeyFromResourceURL() goes the other way: it turns a SelfLink or reference
+// from an API response into the resource collection and key for another call.
+//
+// meta.ServiceForResource() finds the service of a REST collection (e.g. the
+// Resource of a ResourceID) at a version. Collections shared by several services
+// (e.g. "addresses") are resolved by key type with meta.ServiceForResourceKey(),
+// or directly from a parsed URL with ResourceID.Service().
+//
+// meta.Key and ResourceID can be stored in JSON (e.g. in a custom resource, a
+// cache file or a mock seed file) and read back unchanged. A key is encoded as
+// {"name": ..., "zone": ...} and, as a map key or with MarshalText(), as
+// "zones/<zone>/<name>" (or "regions/<region>/<name>", "global/<name>"). A
+// ResourceID is encoded as its relative resource name
+// ("projects/<proj>/zones/<zone>/instances/<name>"); full URLs are accepted when
+// decoding.
+//
+// Resource registry
+//
+// The generator emits a registry of the services, which allows generic tooling
+// (e.g. CLIs, garbage collectors, auditors) to operate over all of the resources
+// without a hand-maintained switch statement. Resources() lists them and
+// LookupResource() finds one by service name and version. Each entry has the
+// REST collection name, the key type, the object type and an accessor for the
+// service on a Cloud, which works with both GCE and MockGCE.
+//
+//  r, ok := cloud.LookupResource("Firewalls", meta.VersionGA)
+//  fws := r.Accessor(c).(cloud.Firewalls)
+//
+// The entries also list the operations of the service (Resource.Operations,
+// Resource.Supports()) and whether it can modify resources, so tooling knows
+// which calls are legal without reflecting on the interfaces. The same
+// information is available from meta.ServiceInfo: SupportsUpdate(),
+// SupportsPatch(), SupportsAggregatedList(), MutationsEnabled(), ExtraMethods()
+// and Operations().
+//
+// Version conversions
+//
+// For objects that are available at more than one API version, the generator
+// emits conversion functions named <From><Object>To<To> (e.g.
+// "AlphaBackendServiceToGA"). Fields of basic types are copied directly; fields
+// whose types differ between the versions are converted via JSON. Fields that do
+// not exist in the destination version are dropped and listed in the function
+// comment. The generated tests check that no other fields are dropped.
+//
+// The generator also emits a deep copy function for each object at each version,
+// named Copy<Version><Object> (e.g. "CopyGABackendService"), for callers such as
+// controllers that copy cached objects in hot paths. Unlike a copy via JSON, the
+// copies are field by field and do not serialize the object. The struct types
+// nested in the objects are copied by unexported functions. ServerResponse is not
+// copied.
+//
+//  copy := cloud.CopyGAInstance(cached)
+//
+// Custom code in the generated methods
+//
+// Small pieces of custom logic for a resource (e.g. validation, normalization or
+// extra logging) can be merged into the generated methods instead of being
+// written in hand-written companion files. Set ServiceInfo.snippets (or
+// "snippets" in a -config file) to the code for each injection point:
+// "gce.<Method>" and "mock.<Method>" are the start of the body of the method of
+// the GCE adapter and of the mock. The code can use the parameters of the method
+// (e.g. ctx, key and obj) and may return early. Exists and GetOrCreate call the
+// other methods and have no injection points. Snippets can only use the packages
+// imported by the generated file; call a hand-written function of the package
+// for anything else.
+//
+//  "snippets": {
+//    "gce.Insert": "if obj.Network == \"\" { return fmt.Errorf(\"network must be set\") }"
+//  }
+//
+// Per-method versions
+//
+// An additional method that only exists (or behaves as needed) at another API
+// version can be called at that version without moving the whole service. In a
+// -config file, "methodVersions" maps the name of an additional method to its
+// version. The method then takes and returns the types of that version and its
+// calls use the compute client of that version; the other methods of the
+// service are unchanged.
+//
+//  "additionalMethods": ["AttachDisk", "SetLabels"],
+//  "methodVersions": {"SetLabels": "alpha"}
+//
+// Deprecated services and methods
+//
+// A service that is being sunset (e.g. HttpHealthChecks in favor of
+// HealthChecks) has a deprecation notice in meta ("deprecated" in a -config
+// file), and so can a single method ("deprecatedMethods", keyed by method name).
+// The generator adds the notice to the comments of the generated interfaces, GCE
+// adapters and accessors as a "Deprecated:" paragraph, so that linters (e.g.
+// staticcheck) flag the code that still uses them.
+//
+//  "deprecated": "Use HealthChecks instead.",
+//  "deprecatedMethods": {"SetTarget": "Use Patch instead."}
+//
+// Adding an API version
+//
+// The API versions are declared in meta.Versions: the name of the version, the
+// golang CamelCase title and prefix used in the generated names, the client
+// package and its root Service type, and the prefix of the resource URLs. The
+// generator, the resource URL parsing (ParseResourceURL, Key.Path) and the mock
+// server are driven by this list. A new version (e.g. a staging endpoint) also
+// needs a Version constant and, in package cloud, a client in Service with its
+// <version>Service() accessor and an operation type in op.go.
+//
+//  {
+//    Version:     VersionStaging,
+//    Title:       "Staging",
+//    WrapPrefix:  "Staging",
+//    Package:     "google.golang.org/api/compute/v0.alpha",
+//    ServiceType: reflect.TypeOf(alpha.Service{}),
+//    URLPrefix:   "https://www.googleapis.com/compute/staging_alpha/",
+//  },
 //
 // Adding custom methods
 //
@@ -80,8 +971,9 @@ limitations under the License.
 // addition of custom code to the generated mocks, set the "CustomOps" option
 // in "meta.ServiceInfo" entry. This will make the generated service interface
 // embed a "<ServiceName>Ops" interface. This interface MUST be written by hand
-// and contain the custom method logic. Corresponding methods must be added to
-// the corresponding Mockxxx and GCExxx struct types.
+// in package "interfaces" and contain the custom method logic. Corresponding
+// methods must be added to the corresponding Mockxxx (in package "mock") and
+// GCExxx struct types.
 //
 //  // In "meta/meta.go":
 //  &ServiceInfo{
@@ -96,11 +988,12 @@ limitations under the License.
 //    ...
 //  }
 //
-//  // In hand written file:
+//  // In hand written files in package "interfaces":
 //  type InstanceGroupsOps interface {
 //    MyMethod()
 //  }
 //
+//  // In package "mock":
 //  func (mock *MockInstanceGroups) MyMethod() {
 //    // Custom mock implementation.
 //  }
@@ -0,0 +1,108 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// DryRunCloud is a Cloud that passes the reads through to another Cloud and
+// records the mutations (Insert, Delete, SetTarget, ...) as PlannedChanges
+// instead of making them, to show what a change would do before applying it:
+//
+//	c := cloud.NewDryRunCloud(cloud.NewGCE(svc))
+//	err := migrate(ctx, c)
+//	for _, ch := range c.Changes() {
+//		fmt.Println(ch)
+//	}
+//
+// The recorded mutations succeed: InsertOp and DeleteOp return a done Op and
+// GetOrCreate returns the object it would create. The reads do not see the
+// recorded changes.
+type DryRunCloud struct {
+	c Cloud
+
+	lock    sync.Mutex
+	changes []*PlannedChange
+}
+
+// DryRunCloud implements Cloud.
+var _ Cloud = (*DryRunCloud)(nil)
+
+// NewDryRunCloud returns a DryRunCloud reading from c.
+func NewDryRunCloud(c Cloud) *DryRunCloud {
+	return &DryRunCloud{c: c}
+}
+
+// PlannedChange is a mutation recorded by a DryRunCloud.
+type PlannedChange struct {
+	// Version of the service.
+	Version meta.Version
+	// Service is the name of the service (e.g. "Firewalls").
+	Service string
+	// Operation is the method called (e.g. "Insert", "SetTarget").
+	Operation string
+	// Key of the object, nil for the changes to a project.
+	Key *meta.Key
+	// Args are the arguments of the call after the key, e.g. the object
+	// inserted.
+	Args []interface{}
+}
+
+// String returns a summary of the change, e.g. "Insert ga Firewalls
+// Key{"fw"}".
+func (ch *PlannedChange) String() string {
+	key := "-"
+	if ch.Key != nil {
+		key = ch.Key.String()
+	}
+	return fmt.Sprintf("%s %s %s %s", ch.Operation, ch.Version, ch.Service, key)
+}
+
+// record adds ch to the changes.
+func (c *DryRunCloud) record(ch *PlannedChange) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.changes = append(c.changes, ch)
+}
+
+// Changes returns the changes recorded so far, in order.
+func (c *DryRunCloud) Changes() []*PlannedChange {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]*PlannedChange(nil), c.changes...)
+}
+
+// Reset forgets the recorded changes.
+func (c *DryRunCloud) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.changes = nil
+}
+
+// SetCommonInstanceMetadata records the change instead of making it. The
+// other methods of ProjectsOps are reads.
+func (s *dryRunProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, m *compute.Metadata) error {
+	s.c.record(&PlannedChange{meta.VersionGA, "Projects", "SetCommonInstanceMetadata", nil, []interface{}{projectID, m}})
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestDryRunCloud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("%s %s made by a DryRunCloud", r.Method, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "/compute/v1/projects/proj/global/urlMaps/um":
+			writeJSON(t, w, &ga.UrlMap{Name: "um", Fingerprint: "fp"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	c := NewDryRunCloud(NewGCE(s))
+	key, newKey := meta.GlobalKey("fw"), meta.GlobalKey("new")
+	obj, newObj := &ga.Firewall{Name: "fw"}, &ga.Firewall{Name: "new"}
+	tp := meta.RegionalKey("tp", "us-central1")
+	req := &ga.TargetPoolsAddInstanceRequest{}
+	md := &ga.Metadata{}
+	um := meta.GlobalKey("um")
+
+	if got, err := c.Firewalls().Get(ctx, *key); err != nil || got.Name != "fw" {
+		t.Errorf("Firewalls().Get(%v) = %v, %v; want fw, nil", key, got, err)
+	}
+	if err := c.Firewalls().Insert(ctx, *key, obj); err != nil {
+		t.Errorf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	if got, err := c.Firewalls().GetOrCreate(ctx, *key, obj); err != nil || got.Name != "fw" {
+		t.Errorf("Firewalls().GetOrCreate(%v) = %v, %v; want fw, nil", key, got, err)
+	}
+	if got, err := c.Firewalls().GetOrCreate(ctx, *newKey, newObj); err != nil || got != newObj {
+		t.Errorf("Firewalls().GetOrCreate(%v) = %v, %v; want %v, nil", newKey, got, err, newObj)
+	}
+	op, err := c.Firewalls().DeleteOp(ctx, *key)
+	if err != nil {
+		t.Fatalf("Firewalls().DeleteOp(%v) = _, %v; want nil", key, err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Errorf("Wait() = %v; want nil", err)
+	}
+	if err := c.TargetPools().AddInstance(ctx, *tp, req); err != nil {
+		t.Errorf("TargetPools().AddInstance(%v) = %v; want nil", tp, err)
+	}
+	if err := c.Firewalls().BatchDelete(ctx, []meta.Key{*key, *newKey}); err != nil {
+		t.Errorf("Firewalls().BatchDelete() = %v; want nil", err)
+	}
+	if err := c.Projects().SetCommonInstanceMetadata(ctx, "proj", md); err != nil {
+		t.Errorf("Projects().SetCommonInstanceMetadata() = %v; want nil", err)
+	}
+	setDefault := func(obj *ga.UrlMap) error {
+		obj.DefaultService = "bs"
+		return nil
+	}
+	if err := c.UrlMaps().UpdateWithRetry(ctx, *um, setDefault); err != nil {
+		t.Errorf("UrlMaps().UpdateWithRetry(%v) = %v; want nil", um, err)
+	}
+
+	want := []*PlannedChange{
+		{meta.VersionGA, "Firewalls", "Insert", key, []interface{}{obj}},
+		{meta.VersionGA, "Firewalls", "GetOrCreate", newKey, []interface{}{newObj}},
+		{meta.VersionGA, "Firewalls", "DeleteOp", key, nil},
+		{meta.VersionGA, "TargetPools", "AddInstance", tp, []interface{}{req}},
+		{meta.VersionGA, "Firewalls", "BatchDelete", key, nil},
+		{meta.VersionGA, "Firewalls", "BatchDelete", newKey, nil},
+		{meta.VersionGA, "Projects", "SetCommonInstanceMetadata", nil, []interface{}{"proj", md}},
+		{meta.VersionGA, "UrlMaps", "Update", um, nil},
+	}
+	got := c.Changes()
+	// The object of the update is the current one, modified by setDefault.
+	if n := len(got); n == len(want) && len(got[n-1].Args) == 1 {
+		if obj, ok := got[n-1].Args[0].(*ga.UrlMap); !ok || obj.Fingerprint != "fp" || obj.DefaultService != "bs" {
+			t.Errorf("UrlMaps().UpdateWithRetry() recorded %+v; want the current object with DefaultService bs", got[n-1].Args[0])
+		}
+		got[n-1].Args = nil
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Changes() = %v; want %v", got, want)
+	}
+	if got, want := want[0].String(), `Insert ga Firewalls Key{"fw"}`; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+	c.Reset()
+	if got := c.Changes(); len(got) != 0 {
+		t.Errorf("Changes() = %v after Reset(); want none", got)
+	}
+}
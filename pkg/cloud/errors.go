@@ -0,0 +1,191 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Error is the error of a call made by the generated services and their
+// mocks. It describes the call that failed and wraps its error, usually a
+// *googleapi.Error, which is returned by Unwrap() so that errors.As() and the
+// Is* helpers (e.g. IsNotFound) apply as if it was returned as-is.
+type Error struct {
+	// Resource is the resource of the call. Its Key is nil for the calls on
+	// a collection (e.g. List) and its ProjectID is empty in the mocks.
+	Resource *ResourceID
+	// Version is the API version of the call.
+	Version meta.Version
+	// Service is the name of the service (e.g. "Firewalls").
+	Service string
+	// Operation is the name of the call (e.g. "Insert", "SetLabels").
+	Operation string
+	// Code is the HTTP status code of the error, or 0 if the call did not
+	// fail with a googleapi.Error (e.g. the context was cancelled).
+	Code int
+	// Err is the error of the call.
+	Err error
+}
+
+// Error returns the call followed by its error, e.g. `Insert ga Firewalls
+// Key{"fw"}: googleapi: Error 409: ...`.
+func (e *Error) Error() string {
+	what := "-"
+	if e.Resource != nil && e.Resource.Key != nil {
+		what = e.Resource.Key.String()
+	}
+	return fmt.Sprintf("%s %s %s %s: %v", e.Operation, e.Version, e.Service, what, e.Err)
+}
+
+// Unwrap returns the error of the call.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// wrapError returns err, if any, as an *Error for the call described by rk on
+// the object referenced by key (nil for the calls on a collection). An err
+// that is already an *Error is returned as-is.
+func wrapError(rk *RateLimitKey, key *meta.Key, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	e := &Error{
+		Resource:  &ResourceID{ProjectID: rk.ProjectID, Key: key},
+		Version:   rk.Version,
+		Service:   rk.Service,
+		Operation: rk.Operation,
+		Err:       err,
+	}
+	if r, ok := LookupResource(rk.Service, rk.Version); ok {
+		e.Resource.Resource = r.Resource
+	}
+	if apiErr, ok := apiError(err); ok {
+		e.Code = apiErr.Code
+	}
+	return e
+}
+
+// OperationError is the error of an operation that completed with errors
+// (the Error field of the Operation). It is returned by WaitForCompletion()
+// and by the Wait() of the operations services, and by the mutations wrapped
+// in an *Error, so that the caller can tell e.g. RESOURCE_NOT_READY from
+// QUOTA_EXCEEDED with IsOperationError().
+type OperationError struct {
+	// SelfLink is the self-link of the operation.
+	SelfLink string
+	// OperationType is the type of the operation (e.g. "insert").
+	OperationType string
+	// TargetLink is the self-link of the resource of the operation.
+	TargetLink string
+	// HTTPStatusCode is the HTTP status code of the error, e.g. 404 if the
+	// resource was not found, or 0 if none was reported.
+	HTTPStatusCode int
+	// Errors are the errors reported by the operation.
+	Errors []OperationErrorItem
+}
+
+// OperationErrorItem is one of the errors of an operation.
+type OperationErrorItem struct {
+	// Code is the error type identifier (e.g. "QUOTA_EXCEEDED").
+	Code string
+	// Location is the field in the request that caused the error, if any.
+	Location string
+	// Message is the human readable description of the error.
+	Message string
+}
+
+// Error returns the operation followed by its errors, e.g. `operation
+// https://.../operations/op failed: QUOTA_EXCEEDED: Quota 'CPUS' exceeded`.
+func (e *OperationError) Error() string {
+	var msgs []string
+	for _, item := range e.Errors {
+		msg := item.Code + ": " + item.Message
+		if item.Location != "" {
+			msg += " (" + item.Location + ")"
+		}
+		msgs = append(msgs, msg)
+	}
+	return fmt.Sprintf("operation %s failed: %s", e.SelfLink, strings.Join(msgs, "; "))
+}
+
+// HasCode is true if one of the errors of the operation has one of codes.
+func (e *OperationError) HasCode(codes ...string) bool {
+	for _, item := range e.Errors {
+		for _, code := range codes {
+			if item.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsOperationError is true if there is an *OperationError in the chain of err
+// with an error of one of codes, or with any error if no code is given.
+func IsOperationError(err error, codes ...string) bool {
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	return len(codes) == 0 || opErr.HasCode(codes...)
+}
+
+// CheckOperation returns an *OperationError if genericOp, one of the alpha,
+// beta and ga Operation types, reports errors, nil otherwise.
+func CheckOperation(genericOp interface{}) error {
+	e := &OperationError{}
+	switch op := genericOp.(type) {
+	case *ga.Operation:
+		if op.Error == nil || len(op.Error.Errors) == 0 {
+			return nil
+		}
+		e.SelfLink, e.OperationType, e.TargetLink, e.HTTPStatusCode = op.SelfLink, op.OperationType, op.TargetLink, int(op.HttpErrorStatusCode)
+		for _, item := range op.Error.Errors {
+			e.Errors = append(e.Errors, OperationErrorItem{Code: item.Code, Location: item.Location, Message: item.Message})
+		}
+	case *alpha.Operation:
+		if op.Error == nil || len(op.Error.Errors) == 0 {
+			return nil
+		}
+		e.SelfLink, e.OperationType, e.TargetLink, e.HTTPStatusCode = op.SelfLink, op.OperationType, op.TargetLink, int(op.HttpErrorStatusCode)
+		for _, item := range op.Error.Errors {
+			e.Errors = append(e.Errors, OperationErrorItem{Code: item.Code, Location: item.Location, Message: item.Message})
+		}
+	case *beta.Operation:
+		if op.Error == nil || len(op.Error.Errors) == 0 {
+			return nil
+		}
+		e.SelfLink, e.OperationType, e.TargetLink, e.HTTPStatusCode = op.SelfLink, op.OperationType, op.TargetLink, int(op.HttpErrorStatusCode)
+		for _, item := range op.Error.Errors {
+			e.Errors = append(e.Errors, OperationErrorItem{Code: item.Code, Location: item.Location, Message: item.Message})
+		}
+	default:
+		return nil
+	}
+	return e
+}
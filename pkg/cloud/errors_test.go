@@ -0,0 +1,247 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": 404, "message": "not found"}}`))
+		default:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": {"code": 409, "message": "exists"}}`))
+		}
+	}))
+	key := meta.GlobalKey("fw")
+
+	for _, tc := range []struct {
+		desc          string
+		call          func() error
+		wantOperation string
+		wantKey       *meta.Key
+		wantCode      int
+		wantString    string
+	}{
+		{
+			desc:          "get",
+			call:          func() error { _, err := gce.Firewalls().Get(ctx, *key); return err },
+			wantOperation: "Get",
+			wantKey:       key,
+			wantCode:      http.StatusNotFound,
+			wantString:    `Get ga Firewalls Key{"fw"}: googleapi: Error 404: not found`,
+		},
+		{
+			desc:          "list",
+			call:          func() error { _, err := gce.Firewalls().List(ctx, nil); return err },
+			wantOperation: "List",
+			wantCode:      http.StatusNotFound,
+			wantString:    `List ga Firewalls -: googleapi: Error 404: not found`,
+		},
+		{
+			desc:          "insert",
+			call:          func() error { return gce.Firewalls().Insert(ctx, *key, &ga.Firewall{Name: "fw"}) },
+			wantOperation: "Insert",
+			wantKey:       key,
+			wantCode:      http.StatusConflict,
+			wantString:    `Insert ga Firewalls Key{"fw"}: googleapi: Error 409: exists`,
+		},
+	} {
+		err := tc.call()
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s: error %v is not an *Error", tc.desc, err)
+			continue
+		}
+		if e.Operation != tc.wantOperation || e.Version != meta.VersionGA || e.Service != "Firewalls" || e.Code != tc.wantCode {
+			t.Errorf("%s: got %+v; want a %s of ga Firewalls with code %d", tc.desc, e, tc.wantOperation, tc.wantCode)
+		}
+		want := &ResourceID{ProjectID: "proj", Resource: "firewalls", Key: tc.wantKey}
+		if !e.Resource.Equal(want) {
+			t.Errorf("%s: Resource = %+v; want %+v", tc.desc, e.Resource, want)
+		}
+		if got := e.Error(); got != tc.wantString {
+			t.Errorf("%s: Error() = %q; want %q", tc.desc, got, tc.wantString)
+		}
+		// The wrapped error is still classified by the helpers.
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != tc.wantCode {
+			t.Errorf("%s: errors.As(%v, *googleapi.Error) = %v; want code %d", tc.desc, err, apiErr, tc.wantCode)
+		}
+		if got, want := IsNotFound(err), tc.wantCode == http.StatusNotFound; got != want {
+			t.Errorf("%s: IsNotFound(%v) = %t; want %t", tc.desc, err, got, want)
+		}
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	t.Parallel()
+
+	rk := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	if err := wrapError(rk, nil, nil); err != nil {
+		t.Errorf("wrapError(nil) = %v; want nil", err)
+	}
+	err := wrapError(rk, nil, context.Canceled)
+	if e, ok := err.(*Error); !ok || e.Code != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("wrapError(%v) = %v; want an *Error with no code wrapping it", context.Canceled, err)
+	}
+	// An *Error is not wrapped again.
+	if again := wrapError(rk, nil, err); again != err {
+		t.Errorf("wrapError(%v) = %v; want it unchanged", err, again)
+	}
+}
+
+func TestCheckOperation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc string
+		op   interface{}
+		want error
+	}{
+		{desc: "no error", op: &ga.Operation{Status: "DONE"}},
+		{desc: "no errors", op: &ga.Operation{Status: "DONE", Error: &ga.OperationError{}}},
+		{desc: "not an operation", op: &ga.Firewall{}},
+		{
+			desc: "ga",
+			op: &ga.Operation{
+				SelfLink:            "projects/proj/global/operations/op",
+				OperationType:       "insert",
+				TargetLink:          "projects/proj/global/firewalls/fw",
+				HttpErrorStatusCode: http.StatusForbidden,
+				Error: &ga.OperationError{Errors: []*ga.OperationErrorErrors{
+					{Code: "QUOTA_EXCEEDED", Message: "Quota 'FIREWALLS' exceeded"},
+					{Code: "INVALID_FIELD", Location: "sourceRanges", Message: "invalid"},
+				}},
+			},
+			want: &OperationError{
+				SelfLink:       "projects/proj/global/operations/op",
+				OperationType:  "insert",
+				TargetLink:     "projects/proj/global/firewalls/fw",
+				HTTPStatusCode: http.StatusForbidden,
+				Errors: []OperationErrorItem{
+					{Code: "QUOTA_EXCEEDED", Message: "Quota 'FIREWALLS' exceeded"},
+					{Code: "INVALID_FIELD", Location: "sourceRanges", Message: "invalid"},
+				},
+			},
+		},
+		{
+			desc: "alpha",
+			op: &alpha.Operation{SelfLink: "op", Error: &alpha.OperationError{Errors: []*alpha.OperationErrorErrors{
+				{Code: "RESOURCE_NOT_READY", Message: "not ready"},
+			}}},
+			want: &OperationError{SelfLink: "op", Errors: []OperationErrorItem{{Code: "RESOURCE_NOT_READY", Message: "not ready"}}},
+		},
+		{
+			desc: "beta",
+			op: &beta.Operation{SelfLink: "op", Error: &beta.OperationError{Errors: []*beta.OperationErrorErrors{
+				{Code: "RESOURCE_NOT_READY", Message: "not ready"},
+			}}},
+			want: &OperationError{SelfLink: "op", Errors: []OperationErrorItem{{Code: "RESOURCE_NOT_READY", Message: "not ready"}}},
+		},
+	} {
+		got := CheckOperation(tc.op)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: CheckOperation() = %#v; want %#v", tc.desc, got, tc.want)
+		}
+	}
+
+	err := CheckOperation(&ga.Operation{SelfLink: "op", Error: &ga.OperationError{Errors: []*ga.OperationErrorErrors{
+		{Code: "QUOTA_EXCEEDED", Message: "quota"},
+		{Code: "INVALID_FIELD", Location: "name", Message: "invalid"},
+	}}})
+	if got, want := err.Error(), "operation op failed: QUOTA_EXCEEDED: quota; INVALID_FIELD: invalid (name)"; got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+	for _, tc := range []struct {
+		err   error
+		codes []string
+		want  bool
+	}{
+		{err, nil, true},
+		{err, []string{"QUOTA_EXCEEDED"}, true},
+		{err, []string{"RESOURCE_NOT_READY", "INVALID_FIELD"}, true},
+		{err, []string{"RESOURCE_NOT_READY"}, false},
+		{fmt.Errorf("wrapped: %w", err), []string{"QUOTA_EXCEEDED"}, true},
+		{errors.New("error"), nil, false},
+		{nil, nil, false},
+	} {
+		if got := IsOperationError(tc.err, tc.codes...); got != tc.want {
+			t.Errorf("IsOperationError(%v, %v) = %t; want %t", tc.err, tc.codes, got, tc.want)
+		}
+	}
+}
+
+func TestOperationErrorOfMutation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opErr := &ga.OperationError{Errors: []*ga.OperationErrorErrors{{Code: "RESOURCE_NOT_READY", Message: "not ready"}}}
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "RUNNING", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE", SelfLink: "projects/proj/global/operations/op", Error: opErr})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	gce := NewGCE(s)
+	key := meta.GlobalKey("fw")
+
+	err := gce.Firewalls().Insert(ctx, *key, &ga.Firewall{Name: "fw"})
+	var e *Error
+	if !errors.As(err, &e) || e.Operation != "Insert" || !IsOperationError(err, "RESOURCE_NOT_READY") {
+		t.Errorf("Firewalls().Insert(%v) = %v; want an *Error of the Insert wrapping the RESOURCE_NOT_READY operation error", key, err)
+	}
+
+	op, err := gce.Firewalls().InsertOp(ctx, *key, &ga.Firewall{Name: "fw"})
+	if err != nil {
+		t.Fatalf("Firewalls().InsertOp(%v) = _, %v; want nil", key, err)
+	}
+	if done, err := op.Done(ctx); !done || err != nil || !IsOperationError(op.Error(), "RESOURCE_NOT_READY") {
+		t.Errorf("Done() = %t, %v, Error() = %v; want true, nil and the operation error", done, err, op.Error())
+	}
+	if err := op.Wait(ctx); !IsOperationError(err, "RESOURCE_NOT_READY") {
+		t.Errorf("Wait() = %v; want the operation error", err)
+	}
+
+	if err := gce.GlobalOperations().Wait(ctx, *meta.GlobalKey("op")); !IsOperationError(err) {
+		t.Errorf("GlobalOperations().Wait() = %v; want the operation error", err)
+	}
+}
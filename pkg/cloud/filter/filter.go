@@ -24,6 +24,12 @@ limitations under the License.
 //
 //  // List using multiple predicates.
 //  c.GlobalAddresses().List(ctx, filter.Regexp("name", "abc.*").NotRegexp("name", "abcdef"))
+//
+//  // List using a filter expression of the compute API.
+//  c.GlobalAddresses().List(ctx, filter.MustParse("(labels.env eq prod) (name ne .*-canary)"))
+//
+//  // List using OR, NOT and comparisons.
+//  c.GlobalAddresses().List(ctx, filter.MustParse("(name = a) OR (name = b) AND NOT (labels.env = dev)"))
 package filter
 
 import (
@@ -31,6 +37,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/golang/glog"
@@ -72,6 +79,226 @@ func NotEqualBool(fieldName string, v bool) *F {
 	return (&F{}).AndNotEqualBool(fieldName, v)
 }
 
+// Label returns a filter for the value of the label key matches regexp v.
+func Label(key, v string) *F {
+	return (&F{}).AndLabel(key, v)
+}
+
+// Parse returns the filter of a filter expression of the compute API (see F),
+// e.g. "name eq abc.*", "(labels.env eq prod) (zone ne .*-f)" or
+// "(cpu_platform = Skylake) OR (cpu_platform = Broadwell) AND (id > 100)".
+// The operators are eq and ne, which match the literal as a regular
+// expression, = and !=, which match it exactly, and <, <=, > and >=, which
+// compare numbers numerically and strings lexically. A literal can be double
+// quoted. The String() of the filter is the expression as parsed, with the
+// same operators and quoting. The expressions in parentheses are joined with AND, which is
+// implied between them, and OR, which takes precedence over AND as in the
+// API. NOT or - negates an expression. As in the API, the eq and ne regexps
+// cannot be used in the same expression as the other operators, AND, OR and
+// NOT.
+func Parse(expr string) (*F, error) {
+	var syn syntax
+	fl, err := parseExpr(strings.TrimSpace(expr), &syn)
+	if err == nil && syn.regexp != "" && syn.standard != "" {
+		err = fmt.Errorf("%q cannot be used with %q: eq and ne cannot be mixed with =, !=, <, <=, >, >=, AND, OR and NOT", syn.regexp, syn.standard)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("filter %q: %v", expr, err)
+	}
+	return fl, nil
+}
+
+// MustParse is Parse for expressions that are known to be valid. It panics if
+// expr is invalid.
+func MustParse(expr string) *F {
+	fl, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return fl
+}
+
+// syntax records the first expression of each of the two syntaxes of the
+// filters of the API seen by the parser, which cannot be mixed.
+type syntax struct {
+	// regexp is the first eq or ne predicate.
+	regexp string
+	// standard is the first =, !=, <, <=, > or >= predicate, AND, OR or NOT.
+	standard string
+}
+
+// saw records the expression expr of the regexp or the standard syntax.
+func (syn *syntax) saw(expr string, regexp bool) {
+	switch {
+	case regexp && syn.regexp == "":
+		syn.regexp = expr
+	case !regexp && syn.standard == "":
+		syn.standard = expr
+	}
+}
+
+// parseExpr parses the expressions of expr joined by AND and OR.
+func parseExpr(expr string, syn *syntax) (*F, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+	var (
+		and []*F
+		or  []*F
+	)
+	for i := 0; i < len(tokens); {
+		if i > 0 {
+			switch tok := tokens[i]; {
+			case tok == "AND" || tok == "OR":
+				if i+1 == len(tokens) {
+					return nil, fmt.Errorf("expected an expression after %s", tok)
+				}
+				syn.saw(tok, false)
+				if tok == "AND" {
+					and, or = append(and, Or(or...)), nil
+				}
+				i++
+			case tok[0] == '(':
+				and, or = append(and, Or(or...)), nil
+			default:
+				return nil, fmt.Errorf("expected AND, OR or '(' at %q", strings.Join(tokens[i:], " "))
+			}
+		}
+		term, n, err := parseTerm(tokens[i:], syn)
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, term)
+		i += n
+	}
+	return And(append(and, Or(or...))...), nil
+}
+
+// parseTerm parses the expression at the start of tokens: an expression in
+// parentheses, a negated expression or a "field_name comparison_string
+// literal_string" predicate. It returns the number of tokens of the
+// expression.
+func parseTerm(tokens []string, syn *syntax) (*F, int, error) {
+	tok := tokens[0]
+	switch {
+	case tok == "NOT" && len(tokens) > 1:
+		syn.saw(tok, false)
+		fl, n, err := parseTerm(tokens[1:], syn)
+		return Not(fl), n + 1, err
+	case len(tok) > 1 && tok[0] == '-':
+		syn.saw("-", false)
+		fl, n, err := parseTerm(append([]string{tok[1:]}, tokens[1:]...), syn)
+		return Not(fl), n, err
+	case tok[0] == '(':
+		fl, err := parseExpr(tok[1:len(tok)-1], syn)
+		return fl, 1, err
+	}
+	// The literal is made of the tokens up to the next AND or OR.
+	n := 0
+	for n < len(tokens) && tokens[n] != "AND" && tokens[n] != "OR" {
+		n++
+	}
+	p, err := parsePredicate(tokens[:n])
+	if err == nil {
+		syn.saw(p.format(false), p.op == equals || p.op == notEquals)
+	}
+	return &F{predicates: []filterPredicate{p}}, n, err
+}
+
+// parsePredicate parses a single "field_name comparison_string
+// literal_string" expression.
+func parsePredicate(tokens []string) (filterPredicate, error) {
+	if len(tokens) < 3 {
+		return filterPredicate{}, fmt.Errorf("%q is not of the form \"field op literal\"", strings.Join(tokens, " "))
+	}
+	field, op := tokens[0], tokens[1]
+	lit := strings.Join(tokens[2:], " ")
+	p := filterPredicate{fieldName: field}
+	if len(lit) >= 2 && lit[0] == '"' && lit[len(lit)-1] == '"' {
+		unquoted, err := strconv.Unquote(lit)
+		if err != nil {
+			return filterPredicate{}, fmt.Errorf("invalid literal %s: %v", lit, err)
+		}
+		lit, p.quoted = unquoted, true
+	}
+	switch op {
+	case "eq":
+		p.op = equals
+	case "ne":
+		p.op = notEquals
+	case "=":
+		p.op = exactlyEquals
+	case "!=":
+		p.op = exactlyNotEquals
+	case "<":
+		p.op = less
+	case "<=":
+		p.op = lessOrEqual
+	case ">":
+		p.op = greater
+	case ">=":
+		p.op = greaterOrEqual
+	default:
+		return filterPredicate{}, fmt.Errorf("invalid operator %q in %q", op, strings.Join(tokens, " "))
+	}
+	p.s = &lit
+	return p, nil
+}
+
+// tokenize splits expr at the spaces that are not in a double quoted string
+// or in parentheses, e.g. "(a eq b) OR c eq "d e"" in "(a eq b)", "OR", "c",
+// "eq" and ""d e"".
+func tokenize(expr string) ([]string, error) {
+	var (
+		tokens []string
+		depth  int
+		quote  = -1
+		start  = -1
+	)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote >= 0 && c == '\\':
+			i++
+		case c == '"' && quote < 0:
+			quote = i
+		case c == '"':
+			quote = -1
+		case quote >= 0:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses at %q", expr[i:])
+			}
+		case c == ' ' || c == '\t' || c == '\n':
+			if depth == 0 && start >= 0 {
+				tokens = append(tokens, expr[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if quote >= 0 {
+		return nil, fmt.Errorf("unterminated quoted string at %q", expr[quote:])
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("unbalanced parentheses at %q", expr[start:])
+	}
+	if start >= 0 {
+		tokens = append(tokens, expr[start:])
+	}
+	return tokens, nil
+}
+
 // F is a filter to be used with List() operations.
 //
 // From the compute API description:
@@ -103,6 +330,31 @@ type F struct {
 	predicates []filterPredicate
 }
 
+// And returns a filter matching the objects matched by all of fls.
+func And(fls ...*F) *F {
+	ret := &F{}
+	for _, fl := range fls {
+		ret.predicates = append(ret.predicates, fl.predicates...)
+	}
+	return ret
+}
+
+// Or returns a filter matching the objects matched by any of fls. As the API
+// has no regexps in an OR, the Regexp and Label predicates of the filter are
+// sent to the API as = and != predicates: their regexps must be literals.
+func Or(fls ...*F) *F {
+	if len(fls) == 1 {
+		return fls[0]
+	}
+	return &F{predicates: []filterPredicate{{op: equals, or: fls}}}
+}
+
+// Not returns a filter matching the objects not matched by fl. As for Or,
+// the regexps of the Regexp and Label predicates of fl must be literals.
+func Not(fl *F) *F {
+	return &F{predicates: []filterPredicate{{op: notEquals, or: []*F{fl}}}}
+}
+
 // And joins two filters together.
 func (fl *F) And(rest *F) *F {
 	fl.predicates = append(fl.predicates, rest.predicates...)
@@ -121,6 +373,11 @@ func (fl *F) AndNotRegexp(fieldName, v string) *F {
 	return fl
 }
 
+// AndLabel adds a label value match string predicate.
+func (fl *F) AndLabel(key, v string) *F {
+	return fl.AndRegexp("labels."+key, v)
+}
+
 // AndEqualInt adds a field == int predicate.
 func (fl *F) AndEqualInt(fieldName string, v int) *F {
 	fl.predicates = append(fl.predicates, filterPredicate{fieldName: fieldName, op: equals, i: &v})
@@ -146,13 +403,31 @@ func (fl *F) AndNotEqualBool(fieldName string, v bool) *F {
 }
 
 func (fl *F) String() string {
+	return fl.format(fl.standard())
+}
+
+// standard returns true if fl is an expression of the standard syntax of the
+// API, i.e. if it has an OR or NOT group or an =, !=, <, <=, > or >=
+// predicate.
+func (fl *F) standard() bool {
+	for _, p := range fl.predicates {
+		if p.or != nil || (p.op != equals && p.op != notEquals) {
+			return true
+		}
+	}
+	return false
+}
+
+// format returns the expression of fl. The eq and ne predicates are
+// formatted as = and != if standard is true (see filterPredicate.format).
+func (fl *F) format(standard bool) string {
 	if len(fl.predicates) == 1 {
-		return fl.predicates[0].String()
+		return fl.predicates[0].format(standard)
 	}
 
 	var pl []string
 	for _, p := range fl.predicates {
-		pl = append(pl, "("+p.String()+")")
+		pl = append(pl, "("+p.format(standard)+")")
 	}
 	return strings.Join(pl, " ")
 }
@@ -160,7 +435,9 @@ func (fl *F) String() string {
 // Match returns true if the F as specifies matches the given object. This
 // is used by the Mock implementations to perform filtering and SHOULD NOT be
 // used in production code as it is not well-tested to be equivalent to the
-// actual compute API.
+// actual compute API. As in the API, a regexp must match the entire value and
+// a string literal (e.g. from Parse()) is compared to the value of an int or
+// bool field.
 func (fl *F) Match(obj interface{}) bool {
 	if fl == nil {
 		return true
@@ -176,11 +453,21 @@ func (fl *F) Match(obj interface{}) bool {
 type filterOp int
 
 const (
-	equals    filterOp = iota
-	notEquals filterOp = iota
+	// equals and notEquals match the value with a regexp (eq and ne).
+	equals filterOp = iota
+	notEquals
+	// exactlyEquals and exactlyNotEquals compare the value to a string (=
+	// and !=).
+	exactlyEquals
+	exactlyNotEquals
+	less
+	lessOrEqual
+	greater
+	greaterOrEqual
 )
 
-// filterPredicate is an individual predicate for a fieldName and value.
+// filterPredicate is an individual predicate for a fieldName and value, or a
+// group of filters.
 type filterPredicate struct {
 	fieldName string
 
@@ -188,15 +475,51 @@ type filterPredicate struct {
 	s  *string
 	i  *int
 	b  *bool
+	// quoted is true if the string literal was double quoted in the parsed
+	// expression.
+	quoted bool
+
+	// or, if set, makes the predicate a group that matches if any of the
+	// filters matches (or none of them for notEquals).
+	or []*F
 }
 
-func (fp *filterPredicate) String() string {
+// format returns the expression of fp. The API does not allow the eq and ne
+// regexps to be mixed with the standard syntax: if standard is true, an eq or
+// ne predicate is formatted as = or != if its regexp is a literal.
+func (fp *filterPredicate) format(standard bool) string {
+	if fp.or != nil {
+		var terms []string
+		for _, fl := range fp.or {
+			terms = append(terms, "("+fl.format(true)+")")
+		}
+		if fp.op == notEquals {
+			return "NOT " + strings.Join(terms, " OR ")
+		}
+		return strings.Join(terms, " OR ")
+	}
+	if standard {
+		fp = fp.exact()
+	}
+
 	var op string
 	switch fp.op {
 	case equals:
 		op = "eq"
 	case notEquals:
 		op = "ne"
+	case exactlyEquals:
+		op = "="
+	case exactlyNotEquals:
+		op = "!="
+	case less:
+		op = "<"
+	case lessOrEqual:
+		op = "<="
+	case greater:
+		op = ">"
+	case greaterOrEqual:
+		op = ">="
 	default:
 		op = "invalidOp"
 	}
@@ -204,9 +527,12 @@ func (fp *filterPredicate) String() string {
 	var value string
 	switch {
 	case fp.s != nil:
-		// There does not seem to be any sort of escaping as specified in the
-		// document. This means it's possible to create malformed expressions.
+		// The literal is quoted as it was parsed, or if it would not be parsed
+		// back as a single literal otherwise.
 		value = *fp.s
+		if fp.quoted || value == "" || strings.ContainsAny(value, " \t\n\"") {
+			value = strconv.Quote(value)
+		}
 	case fp.i != nil:
 		value = fmt.Sprintf("%d", *fp.i)
 	case fp.b != nil:
@@ -218,12 +544,50 @@ func (fp *filterPredicate) String() string {
 	return fmt.Sprintf("%s %s %s", fp.fieldName, op, value)
 }
 
+// exact returns fp with its eq or ne operator as = or != if its value is an
+// int, a bool or a regexp that matches a single string.
+func (fp *filterPredicate) exact() *filterPredicate {
+	op, ok := map[filterOp]filterOp{equals: exactlyEquals, notEquals: exactlyNotEquals}[fp.op]
+	if !ok {
+		return fp
+	}
+	ret := *fp
+	ret.op = op
+	if fp.s != nil {
+		re, err := regexp.Compile(*fp.s)
+		if err != nil {
+			return fp
+		}
+		lit, complete := re.LiteralPrefix()
+		if !complete {
+			return fp
+		}
+		ret.s = &lit
+	}
+	return &ret
+}
+
 func (fp *filterPredicate) match(o interface{}) bool {
+	if fp.or != nil {
+		match := false
+		for _, fl := range fp.or {
+			if fl.Match(o) {
+				match = true
+				break
+			}
+		}
+		return match == (fp.op == equals)
+	}
+
 	v, err := extractValue(fp.fieldName, o)
 	glog.V(5).Infof("extractValue(%q, %#v) = %v, %v", fp.fieldName, o, v, err)
 	if err != nil {
 		return false
 	}
+	switch fp.op {
+	case less, lessOrEqual, greater, greaterOrEqual:
+		return fp.compare(v)
+	}
 
 	var match bool
 	switch x := v.(type) {
@@ -231,34 +595,88 @@ func (fp *filterPredicate) match(o interface{}) bool {
 		if fp.s == nil {
 			return false
 		}
-		re, err := regexp.Compile(*fp.s)
-		if err != nil {
-			glog.Errorf("Match regexp %q is invalid: %v", *fp.s, err)
-			return false
-		}
-		match = re.Match([]byte(x))
+		match = fp.matchString(x)
 	case int:
-		if fp.i == nil {
+		switch {
+		case fp.i != nil:
+			match = x == *fp.i
+		case fp.s != nil:
+			match = fp.matchString(strconv.Itoa(x))
+		default:
 			return false
 		}
-		match = x == *fp.i
 	case bool:
-		if fp.b == nil {
+		switch {
+		case fp.b != nil:
+			match = x == *fp.b
+		case fp.s != nil:
+			match = fp.matchString(strconv.FormatBool(x))
+		default:
 			return false
 		}
-		match = x == *fp.b
 	}
 
 	switch fp.op {
-	case equals:
+	case equals, exactlyEquals:
 		return match
-	case notEquals:
+	case notEquals, exactlyNotEquals:
 		return !match
 	}
 
 	return false
 }
 
+// compare returns true if the value v compares to the literal of fp as
+// given by the operator: numerically for an int, lexically for a string.
+func (fp *filterPredicate) compare(v interface{}) bool {
+	if fp.s == nil {
+		return false
+	}
+	var cmp int
+	switch x := v.(type) {
+	case int:
+		lit, err := strconv.Atoi(*fp.s)
+		if err != nil {
+			return false
+		}
+		switch {
+		case x < lit:
+			cmp = -1
+		case x > lit:
+			cmp = 1
+		}
+	case string:
+		cmp = strings.Compare(x, *fp.s)
+	default:
+		return false
+	}
+	switch fp.op {
+	case less:
+		return cmp < 0
+	case lessOrEqual:
+		return cmp <= 0
+	case greater:
+		return cmp > 0
+	case greaterOrEqual:
+		return cmp >= 0
+	}
+	return false
+}
+
+// matchString returns true if the regexp of fp matches the entire string x,
+// or if x is the string of fp for = and !=.
+func (fp *filterPredicate) matchString(x string) bool {
+	if fp.op == exactlyEquals || fp.op == exactlyNotEquals {
+		return x == *fp.s
+	}
+	re, err := regexp.Compile("^(?:" + *fp.s + ")$")
+	if err != nil {
+		glog.Errorf("Match regexp %q is invalid: %v", *fp.s, err)
+		return false
+	}
+	return re.MatchString(x)
+}
+
 // snakeToCamelCase converts from "names_like_this" to "NamesLikeThis" to
 // interoperate between proto and Golang naming conventions.
 func snakeToCamelCase(s string) string {
@@ -282,6 +700,15 @@ func extractValue(path string, o interface{}) (interface{}, error) {
 			}
 			v = v.Elem()
 		}
+		// Labels are looked up by key, e.g. "labels.env".
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			v = v.MapIndex(reflect.ValueOf(f).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return nil, fmt.Errorf("cannot get key %q as it is not in %T", f, o)
+			}
+			o = v.Interface()
+			continue
+		}
 		if v.Kind() != reflect.Struct {
 			return nil, fmt.Errorf("cannot get field from non-struct (%T)", o)
 		}
@@ -294,9 +721,14 @@ func extractValue(path string, o interface{}) (interface{}, error) {
 		}
 		o = v.Interface()
 	}
-	switch o.(type) {
+	switch x := o.(type) {
 	case string, int, bool:
 		return o, nil
+	// The integers of the API objects are int64 or uint64.
+	case int64:
+		return int(x), nil
+	case uint64:
+		return int(x), nil
 	}
 	return nil, fmt.Errorf("unhandled object of type %T", o)
 }
@@ -37,6 +37,12 @@ func TestFilterToString(t *testing.T) {
 		{Regexp("field1", "abc").AndRegexp("field2", "def"), `(field1 eq abc) (field2 eq def)`},
 		{Regexp("field1", "abc").AndNotEqualInt("field2", 17), `(field1 eq abc) (field2 ne 17)`},
 		{Regexp("field1", "abc").And(EqualInt("field2", 17)), `(field1 eq abc) (field2 eq 17)`},
+		// The regexps are sent as = and != with OR, NOT and comparisons.
+		{Or(Regexp("name", "a"), Regexp("name", "b")), `(name = a) OR (name = b)`},
+		{Not(Label("env", "dev")), `NOT (labels.env = dev)`},
+		{Or(Label("env", "prod"), EqualInt("port", 80)).And(Not(NotEqualBool("b", true))), `((labels.env = prod) OR (port = 80)) (NOT (b != true))`},
+		{Regexp("name", `a\.b`).And(MustParse("port > 80")), `(name = a.b) (port > 80)`},
+		{Regexp("name", "a b").And(MustParse(`zone != "us-central1-f"`)), `(name = "a b") (zone != "us-central1-f")`},
 	} {
 		if tc.f.String() != tc.want {
 			t.Errorf("filter %#v String() = %q, want %q", tc.f, tc.f.String(), tc.want)
@@ -56,6 +62,9 @@ func TestFilterMatch(t *testing.T) {
 		B           bool
 		Unhandled   struct{}
 		NestedField *inner
+		Labels      map[string]string
+		Id          uint64
+		Port        int64
 	}
 
 	for _, tc := range []struct {
@@ -87,6 +96,35 @@ func TestFilterMatch(t *testing.T) {
 		{f: NotRegexp("nested_field.x", "xyz"), o: &S{NestedField: &inner{"xyz"}}},
 		{f: Regexp("nested_field.y", "xyz"), o: &S{NestedField: &inner{"xyz"}}},
 		{f: Regexp("nested_field", "xyz"), o: &S{NestedField: &inner{"xyz"}}},
+		{f: Regexp("s", "b"), o: &S{S: "abc"}},
+		{f: Regexp("i", "1."), o: &S{I: 10}, want: true},
+		{f: Regexp("b", "true"), o: &S{B: true}, want: true},
+		{f: Label("env", "prod"), o: &S{Labels: map[string]string{"env": "prod"}}, want: true},
+		{f: Label("env", "prod"), o: &S{Labels: map[string]string{"env": "dev"}}},
+		{f: Label("env", "prod"), o: &S{}},
+		{f: EqualInt("id", 7), o: &S{Id: 7}, want: true},
+		{f: EqualInt("port", 80), o: &S{Port: 80}, want: true},
+		{f: MustParse("(s = a.c) (i != 3)"), o: &S{S: "abc"}},
+		{f: MustParse("(s = a.c) (i != 3)"), o: &S{S: "a.c"}, want: true},
+		{f: MustParse("(s = x) OR (i = 3)"), o: &S{I: 3}, want: true},
+		{f: MustParse("(s = x) OR (i = 3)"), o: &S{S: "x"}, want: true},
+		{f: MustParse("(s = x) OR (i = 3)"), o: &S{S: "y"}},
+		{f: MustParse("(s = x) OR (i = 3) (b = true)"), o: &S{S: "x"}},
+		{f: MustParse("(s = x) OR (i = 3) (b = true)"), o: &S{S: "x", B: true}, want: true},
+		{f: MustParse("NOT (s = x)"), o: &S{S: "x"}},
+		{f: MustParse("NOT (s = x)"), o: &S{S: "y"}, want: true},
+		{f: MustParse("i > 9"), o: &S{I: 10}, want: true},
+		{f: MustParse("i > 10"), o: &S{I: 10}},
+		{f: MustParse("i >= 10"), o: &S{I: 10}, want: true},
+		{f: MustParse("i < 9"), o: &S{I: 10}},
+		{f: MustParse("i <= 10"), o: &S{I: 10}, want: true},
+		{f: MustParse("i < x"), o: &S{I: 10}},
+		{f: MustParse("id > 5"), o: &S{Id: 7}, want: true},
+		{f: MustParse("s < b"), o: &S{S: "abc"}, want: true},
+		{f: MustParse("s >= b"), o: &S{S: "abc"}},
+		{f: MustParse("b > false"), o: &S{B: true}},
+		{f: Or(Regexp("s", "x"), Label("env", "prod")), o: &S{Labels: map[string]string{"env": "prod"}}, want: true},
+		{f: Not(Label("env", "prod")), o: &S{}, want: true},
 	} {
 		got := tc.f.Match(tc.o)
 		if got != tc.want {
@@ -132,6 +170,8 @@ func TestFilterExtractValue(t *testing.T) {
 		F       bool
 		Nest    nest
 		NestPtr *nest
+		Labels  map[string]string
+		I64     int64
 
 		Unhandled float64
 	}{
@@ -140,6 +180,8 @@ func TestFilterExtractValue(t *testing.T) {
 		true,
 		nest{"xyz", nest2{"zzz"}},
 		&nest{"yyy", nest2{}},
+		map[string]string{"env": "prod"},
+		64,
 		0.0,
 	}
 
@@ -154,6 +196,9 @@ func TestFilterExtractValue(t *testing.T) {
 		{path: "f", o: st, want: true},
 		{path: "nest.x", o: st, want: "xyz"},
 		{path: "nest_ptr.x", o: st, want: "yyy"},
+		{path: "labels.env", o: st, want: "prod"},
+		{path: "i64", o: st, want: 64},
+		{path: "labels.none", o: st, wantErr: true},
 		// Error cases.
 		{path: "", o: st, wantErr: true},
 		{path: "no_such_field", o: st, wantErr: true},
@@ -174,3 +219,87 @@ func TestFilterExtractValue(t *testing.T) {
 		}
 	}
 }
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{expr: "name eq abc.*", want: "name eq abc.*"},
+		{expr: "  name ne abc  ", want: "name ne abc"},
+		{expr: "name = a.b", want: "name = a.b"},
+		{expr: "name != a.b", want: "name != a.b"},
+		{expr: `name = "my-fw.1"`, want: `name = "my-fw.1"`},
+		{expr: `name eq "a b"`, want: `name eq "a b"`},
+		{expr: "(labels.env eq prod) (zone ne .*-f)", want: "(labels.env eq prod) (zone ne .*-f)"},
+		{expr: "(name eq (a|b)) (port eq 80)", want: "(name eq (a|b)) (port eq 80)"},
+		{expr: "(name = a) AND (port = 80)", want: "(name = a) (port = 80)"},
+		{expr: "name = a OR name = b", want: "(name = a) OR (name = b)"},
+		{expr: "(name = a) OR (name = b) AND (port > 80)", want: "((name = a) OR (name = b)) (port > 80)"},
+		{expr: "(port >= 80) (port <= 90) (name < b)", want: "(port >= 80) (port <= 90) (name < b)"},
+		{expr: "NOT (name = a)", want: "NOT (name = a)"},
+		{expr: "-name = a", want: "NOT (name = a)"},
+		{expr: "NOT ((name = a) OR (name = b))", want: "NOT ((name = a) OR (name = b))"},
+		{expr: `name = "a)b"`, want: `name = "a)b"`},
+		// Error cases.
+		{expr: "", wantErr: true},
+		{expr: "name", wantErr: true},
+		{expr: "name eq", wantErr: true},
+		{expr: "name lt 3", wantErr: true},
+		{expr: "(name eq a", wantErr: true},
+		{expr: "(name eq a) name eq b", wantErr: true},
+		{expr: `name eq "a`, wantErr: true},
+		{expr: `name eq "a\"`, wantErr: true},
+		{expr: `(name eq "a)`, wantErr: true},
+		{expr: "name eq a OR", wantErr: true},
+		{expr: "OR name eq a", wantErr: true},
+		{expr: "(name eq a) OR", wantErr: true},
+		{expr: "name eq a)", wantErr: true},
+		{expr: "NOT", wantErr: true},
+		// eq and ne cannot be mixed with the other operators, AND, OR and NOT.
+		{expr: "(labels.env eq prod) (address_type = EXTERNAL)", wantErr: true},
+		{expr: "(name eq a) AND (port eq 80)", wantErr: true},
+		{expr: "(name eq a) OR (name eq b)", wantErr: true},
+		{expr: "NOT (name eq a)", wantErr: true},
+		{expr: "-name eq a", wantErr: true},
+		{expr: "(name ne a.*) (port > 80)", wantErr: true},
+	} {
+		fl, err := Parse(tc.expr)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("Parse(%q) = %v, %v; gotErr = %v, want %v", tc.expr, fl, err, gotErr, tc.wantErr)
+			continue
+		}
+		if err == nil && fl.String() != tc.want {
+			t.Errorf("Parse(%q).String() = %q; want %q", tc.expr, fl.String(), tc.want)
+		}
+	}
+}
+
+func TestParseStringRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		"name eq abc.*",
+		"name = a.b",
+		`name = "my-fw.1"`,
+		`name != "a b"`,
+		`name eq "a\"b"`,
+		"(labels.env eq prod) (zone ne .*-f)",
+		"(network = default) (priority > 100)",
+		"(name = a) OR (name = b)",
+		"NOT (name = a)",
+	} {
+		fl := MustParse(expr)
+		got, err := Parse(fl.String())
+		if err != nil {
+			t.Errorf("Parse(%q) = _, %v; want nil", fl.String(), err)
+			continue
+		}
+		if got.String() != expr || !reflect.DeepEqual(got, fl) {
+			t.Errorf("Parse(Parse(%q).String()) = %q (%+v); want %q (%+v)", expr, got, got, expr, fl)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// resourceClient is the common core shared by the generated GCE adapters.
+// The generated code only builds the version specific call; the project
+// routing, rate limiting and operation handling is done here.
+//
+// T is the type of the resource object (e.g. ga.Address) and C is the type of
+// the compute client for the API version (e.g. *ga.Service).
+type resourceClient[T, C any] struct {
+	s       *Service
+	version meta.Version
+	service string
+	// keyType is the type of the keys of the objects of the service.
+	keyType meta.KeyType
+	// client returns the compute client for the API version calling the
+	// endpoint of a location (see Service.RegionalEndpoints).
+	client func(location string) (C, error)
+	// location is the region or zone of the calls, empty for the global
+	// ones (see withLocation()).
+	location string
+	// started, if set, receives the operations of the mutations, which
+	// then return without waiting for them (see startOp()).
+	started func(Op)
+	// opts is the configuration of the call (see withOptions()).
+	opts CallConfig
+}
+
+// callFunc performs the API call using the given client and project.
+type callFunc[C, R any] func(ctx context.Context, c C, projectID string) (R, error)
+
+// newResourceClient returns a resourceClient for the given service.
+func newResourceClient[T, C any](s *Service, version meta.Version, service string, keyType meta.KeyType, client func(string) (C, error)) *resourceClient[T, C] {
+	return &resourceClient[T, C]{
+		s:       s,
+		version: version,
+		service: service,
+		keyType: keyType,
+		client:  client,
+	}
+}
+
+// withVersion returns a copy of rc that makes its calls with the compute
+// client of another API version. It is used for the methods that are
+// configured to be called at a different version than the rest of the
+// service (see meta.ServiceConfig.MethodVersions).
+func withVersion[T, C, V any](rc *resourceClient[T, C], version meta.Version, client func(string) (V, error)) *resourceClient[T, V] {
+	return &resourceClient[T, V]{
+		s:        rc.s,
+		version:  version,
+		service:  rc.service,
+		keyType:  rc.keyType,
+		client:   client,
+		location: rc.location,
+		started:  rc.started,
+		opts:     rc.opts,
+	}
+}
+
+// withOptions returns a copy of rc that makes its call with the Options of
+// a method, or rc if there are none.
+func (rc *resourceClient[T, C]) withOptions(opts []Option) *resourceClient[T, C] {
+	if len(opts) == 0 {
+		return rc
+	}
+	c := *rc
+	c.opts = interfaces.NewCallConfig(opts...)
+	return &c
+}
+
+// withLocation returns a copy of rc that makes its calls in location (a
+// region or a zone), or rc if it already does. The location selects the
+// regional endpoint of the calls (see Service.RegionalEndpoints).
+func (rc *resourceClient[T, C]) withLocation(location string) *resourceClient[T, C] {
+	if rc.location == location {
+		return rc
+	}
+	c := *rc
+	c.location = location
+	return &c
+}
+
+// readOptions returns the CallOptions of the call of ctx for a read.
+func (rc *resourceClient[T, C]) readOptions(ctx context.Context) []googleapi.CallOption {
+	return headerOptions(ctx, readOptions(rc.opts))
+}
+
+// mutationOptions returns the CallOptions of the call of ctx for a mutation.
+func (rc *resourceClient[T, C]) mutationOptions(ctx context.Context) []googleapi.CallOption {
+	return headerOptions(ctx, mutationOptions(rc.opts))
+}
+
+// checkKey returns an error if key is not valid (see meta.Key.Validate()) or is
+// not of the type used by the service, so that a bad key is reported before
+// the API call instead of as an error from the API.
+func (rc *resourceClient[T, C]) checkKey(key meta.Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	if key.Type() != rc.keyType {
+		return fmt.Errorf("%s is a %s key; %s %s requires a %s key", key, key.Type(), rc.version, rc.service, rc.keyType)
+	}
+	return nil
+}
+
+// keyID returns the name of key as the numeric ID of an object, for the
+// services whose objects are identified by ID (see
+// meta.ServiceInfo.IdentityField()).
+func keyID(key meta.Key) (uint64, error) {
+	id, err := strconv.ParseUint(key.Name, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: name %q is not a numeric ID", key, key.Name)
+	}
+	return id, nil
+}
+
+// rateLimitKey returns the key for operation, routing the call to the
+// appropriate project: the one of the Project() option, if any.
+func (rc *resourceClient[T, C]) rateLimitKey(ctx context.Context, operation string) *RateLimitKey {
+	projectID := rc.opts.ProjectID
+	if projectID == "" {
+		projectID = rc.s.ProjectRouter.ProjectID(ctx, rc.version, rc.service)
+	}
+	return &RateLimitKey{
+		ProjectID: projectID,
+		Operation: operation,
+		Version:   rc.version,
+		Service:   rc.service,
+	}
+}
+
+// invoke performs the read operation on the object referenced by key (nil
+// for the calls on a collection, e.g. List), subject to routing and rate
+// limiting. An error is returned as an *Error.
+func invoke[R, T, C any](ctx context.Context, rc *resourceClient[T, C], operation string, key *meta.Key, call callFunc[C, R]) (R, error) {
+	return invokeCall(ctx, rc, operation, key, false, call)
+}
+
+// invokeCall is invoke for a read or, if mutation is true, a call that
+// mutates a resource without returning an operation.
+func invokeCall[R, T, C any](ctx context.Context, rc *resourceClient[T, C], operation string, key *meta.Key, mutation bool, call callFunc[C, R]) (R, error) {
+	if key != nil {
+		rc = rc.withLocation(key.Location())
+	}
+	ctx, cancel := callContext(ctx, rc.opts)
+	defer cancel()
+	rk := rc.rateLimitKey(ctx, operation)
+	ctx, span := rc.s.startSpan(ctx, rk, key)
+	rc.s.recordCallStart(ctx, rk)
+	start := time.Now()
+	info := newCallInfo(rk, key, nil)
+	ctx, n, err := rc.s.beforeCall(ctx, info)
+	var r R
+	if err == nil {
+		r, err = invokeWithKey(ctx, rc, rk, mutation, call)
+	}
+	var resp interface{}
+	if err == nil {
+		resp = r
+		info.Response = r
+	}
+	rc.s.afterCall(ctx, info, n, err)
+	rc.s.recordCall(ctx, rk, start, err)
+	rc.s.logCall(ctx, rk, key, nil, resp, start, err)
+	span.End(err)
+	return r, wrapError(rk, key, err)
+}
+
+// invokeWithKey performs the call described by rk, retrying it according
+// to the Service.RetryPolicy. mutation is true if the call mutates a
+// resource.
+func invokeWithKey[R, T, C any](ctx context.Context, rc *resourceClient[T, C], rk *RateLimitKey, mutation bool, call callFunc[C, R]) (R, error) {
+	return withRetries(ctx, rc.s.retryPolicy(), rk, mutation, func() (R, error) {
+		return invokeOnce(ctx, rc, rk, call)
+	})
+}
+
+// invokeOnce performs a single attempt of the call described by rk.
+func invokeOnce[R, T, C any](ctx context.Context, rc *resourceClient[T, C], rk *RateLimitKey, call callFunc[C, R]) (R, error) {
+	var zero R
+
+	if err := rc.s.RateLimiter.Accept(ctx, rk); err != nil {
+		return zero, err
+	}
+	c, err := rc.client(rc.location)
+	if err != nil {
+		return zero, err
+	}
+	release, err := rc.s.acquireCall(ctx, rk)
+	if err != nil {
+		return zero, err
+	}
+	r, err := call(ctx, c, rk.ProjectID)
+	release()
+	rc.s.observeRateLimit(ctx, rk, err)
+	return r, err
+}
+
+// get performs a call returning a single object.
+func (rc *resourceClient[T, C]) get(ctx context.Context, key meta.Key, call callFunc[C, *T]) (*T, error) {
+	return invoke(ctx, rc, "Get", &key, call)
+}
+
+// list performs a call returning a list of objects.
+func (rc *resourceClient[T, C]) list(ctx context.Context, call callFunc[C, []*T]) ([]*T, error) {
+	return invoke(ctx, rc, "List", nil, call)
+}
+
+// do performs a call that returns neither a result nor an operation (e.g.
+// the Delete of an Operation).
+func (rc *resourceClient[T, C]) do(ctx context.Context, operation string, key meta.Key, call func(ctx context.Context, c C, projectID string) error) error {
+	_, err := invokeCall(ctx, rc, operation, &key, true, func(ctx context.Context, c C, projectID string) (struct{}, error) {
+		return struct{}{}, call(ctx, c, projectID)
+	})
+	return err
+}
+
+// aggregatedListLocation returns the location for a key of the Items of an
+// aggregated list response, e.g. "zones/us-central1-b" => "us-central1-b".
+func aggregatedListLocation(scope string) string {
+	for _, prefix := range []string{"zones/", "regions/"} {
+		if strings.HasPrefix(scope, prefix) {
+			return strings.TrimPrefix(scope, prefix)
+		}
+	}
+	return scope
+}
+
+// aggregatedList performs a call returning lists of objects by location.
+func (rc *resourceClient[T, C]) aggregatedList(ctx context.Context, call callFunc[C, map[string][]*T]) (map[string][]*T, error) {
+	return invoke(ctx, rc, "AggregatedList", nil, call)
+}
+
+// mutate performs a call returning an operation (e.g. *ga.Operation) and
+// waits for the operation to complete. req is the request payload of the
+// call (e.g. the object being inserted); it is only used to record the change
+// to the Service.ChangeSink. An error is returned as an *Error.
+func (rc *resourceClient[T, C]) mutate(ctx context.Context, operation string, key meta.Key, req interface{}, call callFunc[C, interface{}]) error {
+	rc = rc.withLocation(key.Location())
+	ctx, cancel := callContext(ctx, rc.opts)
+	defer cancel()
+	rk := rc.rateLimitKey(ctx, operation)
+	ctx, span := rc.s.startSpan(ctx, rk, &key)
+	rc.s.recordCallStart(ctx, rk)
+	start := time.Now()
+	info := newCallInfo(rk, &key, req)
+	ctx, n, err := rc.s.beforeCall(ctx, info)
+	if err == nil {
+		err = rc.mutateWithKey(ctx, rk, key, req, call)
+	}
+	rc.s.afterCall(ctx, info, n, err)
+	rc.s.recordCall(ctx, rk, start, err)
+	rc.s.logCall(ctx, rk, &key, req, nil, start, err)
+	span.End(err)
+	return wrapError(rk, &key, err)
+}
+
+// mutateWithKey performs the mutation described by rk.
+func (rc *resourceClient[T, C]) mutateWithKey(ctx context.Context, rk *RateLimitKey, key meta.Key, req interface{}, call callFunc[C, interface{}]) error {
+	op, err := invokeWithKey(ctx, rc, rk, true, call)
+	if err != nil {
+		return err
+	}
+	if rc.started != nil {
+		o, err := rc.s.wrapOperation(op)
+		if err != nil {
+			return err
+		}
+		rc.started(&pendingOp{
+			s:      rc.s,
+			op:     o,
+			policy: rc.s.pollPolicy(rk),
+			onDone: func(ctx context.Context) { rc.s.recordChange(ctx, rk, key, op, req) },
+		})
+		return nil
+	}
+	if rc.opts.SkipWait {
+		return nil
+	}
+	if err := rc.s.WaitForCompletionWithPolicy(ctx, op, rc.s.pollPolicy(rk)); err != nil {
+		return err
+	}
+	rc.s.recordChange(ctx, rk, key, op, req)
+	return nil
+}
+
+// startOp performs mutation with a copy of rc that returns as soon as the
+// operation is started, and returns the pending operation. This implements
+// the generated InsertOp() and DeleteOp() methods on top of Insert() and
+// Delete().
+func startOp[T, C any](rc *resourceClient[T, C], mutation func(rc *resourceClient[T, C]) error) (Op, error) {
+	var op Op
+	async := *rc
+	async.started = func(o Op) { op = o }
+	if err := mutation(&async); err != nil {
+		return nil, err
+	}
+	if op == nil {
+		// The mutation completed without an operation.
+		return DoneOp(nil), nil
+	}
+	return op, nil
+}
+
+// apiCall is implemented by the calls of the compute clients returning R
+// (e.g. *ga.FirewallsGetCall).
+type apiCall[R any] interface {
+	Header() http.Header
+	Do(opts ...googleapi.CallOption) (R, error)
+}
+
+// do makes call with the headers and the CallOptions configured on s (see
+// Service.QuotaProject), followed by opts. All of the calls of the GCE
+// adapters are made with do() or doNoResult().
+func do[R any](s *Service, call apiCall[R], opts ...googleapi.CallOption) (R, error) {
+	return call.Do(s.callOptions(call.Header(), opts)...)
+}
+
+// doNoResult is do() for the calls that return no result (e.g. the Delete of
+// an Operation).
+func doNoResult(s *Service, call interface {
+	Header() http.Header
+	Do(opts ...googleapi.CallOption) error
+}, opts ...googleapi.CallOption) error {
+	return call.Do(s.callOptions(call.Header(), opts)...)
+}
+
+// pagedCall is implemented by the list calls of the compute clients (e.g.
+// *ga.FirewallsListCall) returning pages of type L.
+type pagedCall[C, L any] interface {
+	apiCall[*L]
+	PageToken(pageToken string) C
+}
+
+// pages calls f with each of the pages returned by call, requesting the
+// page of the token returned by next until it is "". Unlike the Pages()
+// method of the calls, it makes the calls with do() and opts.
+func pages[C pagedCall[C, L], L any](s *Service, call C, next func(*L) string, f func(*L) error, opts ...googleapi.CallOption) error {
+	for {
+		l, err := do[*L](s, call, opts...)
+		if err != nil {
+			return err
+		}
+		if err := f(l); err != nil {
+			return err
+		}
+		token := next(l)
+		if token == "" {
+			return nil
+		}
+		call = call.PageToken(token)
+	}
+}
+
+// listPages accumulates the items from all of the pages of a List call.
+func listPages[C pagedCall[C, L], T, L any](s *Service, call C, items func(*L) []*T, next func(*L) string, opts ...googleapi.CallOption) ([]*T, error) {
+	var all []*T
+	f := func(l *L) error {
+		all = append(all, items(l)...)
+		return nil
+	}
+	if err := pages(s, call, next, f, opts...); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// newTestService returns a Service backed by a fake HTTP server using
+// handler.
+func newTestService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	svc, err := ga.New(srv.Client())
+	if err != nil {
+		t.Fatalf("ga.New() = _, %v", err)
+	}
+	svc.BasePath = srv.URL + "/compute/v1/projects/"
+	return &Service{
+		GA:            svc,
+		ProjectRouter: &SingleProjectRouter{ID: "proj"},
+		RateLimiter:   &NopRateLimiter{},
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, obj interface{}) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("json.Encode(%+v) = %v", obj, err)
+	}
+}
+
+func TestResourceClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var paths []string
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "GET /compute/v1/projects/proj/global/firewalls":
+			if r.URL.Query().Get("pageToken") == "" {
+				writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "a"}}, NextPageToken: "next"})
+			} else {
+				writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "b"}}})
+			}
+		case "DELETE /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+
+	key := meta.GlobalKey("fw")
+	if fw, err := gce.Firewalls().Get(ctx, *key); err != nil || fw.Name != "fw" {
+		t.Errorf("Firewalls().Get(%v) = %+v, %v; want fw, nil", key, fw, err)
+	}
+	fws, err := gce.Firewalls().List(ctx, filter.None)
+	if err != nil || len(fws) != 2 {
+		t.Errorf("Firewalls().List() = %+v, %v; want 2 items, nil", fws, err)
+	}
+	if err := gce.Firewalls().Delete(ctx, *key); err != nil {
+		t.Errorf("Firewalls().Delete(%v) = %v; want nil", key, err)
+	}
+	if _, err := gce.Firewalls().Get(ctx, *meta.GlobalKey("missing")); err == nil {
+		t.Errorf("Firewalls().Get(missing) = _, nil; want error")
+	}
+	// The alpha client is not configured.
+	if _, err := gce.AlphaAddresses().Get(ctx, *meta.RegionalKey("addr", "us-central1")); err == nil {
+		t.Errorf("AlphaAddresses().Get() = _, nil; want error")
+	}
+	if len(paths) != 6 {
+		t.Errorf("got requests %v, want 6 requests", paths)
+	}
+}
+
+func TestUpdateLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var req ga.ZoneSetLabelsRequest
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/zones/us-central1-b/disks/disk":
+			writeJSON(t, w, &ga.Disk{Name: "disk", LabelFingerprint: "fp"})
+		case "POST /compute/v1/projects/proj/zones/us-central1-b/disks/disk/setLabels":
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("json.Decode() = %v", err)
+			}
+			writeJSON(t, w, &ga.Operation{Name: "op", Zone: "us-central1-b", SelfLink: "projects/proj/zones/us-central1-b/operations/op"})
+		case "GET /compute/v1/projects/proj/zones/us-central1-b/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+
+	key := meta.ZonalKey("disk", "us-central1-b")
+	labels := map[string]string{"k": "v"}
+	if err := gce.Disks().UpdateLabels(ctx, *key, labels); err != nil {
+		t.Fatalf("Disks().UpdateLabels(%v, %v) = %v; want nil", key, labels, err)
+	}
+	if req.LabelFingerprint != "fp" || req.Labels["k"] != "v" {
+		t.Errorf("SetLabels request = %+v; want labels %v and the label fingerprint of the disk", req, labels)
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var paths []string
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+
+	for _, key := range []*meta.Key{
+		meta.GlobalKey("Bad_Name"),
+		meta.ZonalKey("fw", "us-central1-b"),
+	} {
+		if _, err := gce.Firewalls().Get(ctx, *key); err == nil {
+			t.Errorf("Firewalls().Get(%v) = _, nil; want error", key)
+		}
+		if err := gce.Firewalls().Delete(ctx, *key); err == nil {
+			t.Errorf("Firewalls().Delete(%v) = nil; want error", key)
+		}
+		if err := gce.Firewalls().Insert(ctx, *key, &ga.Firewall{}); err == nil {
+			t.Errorf("Firewalls().Insert(%v) = nil; want error", key)
+		}
+	}
+	if len(paths) != 0 {
+		t.Errorf("got requests %v, want none", paths)
+	}
+}
+
+func TestKeyID(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key    *meta.Key
+		want   uint64
+		wantOK bool
+	}{
+		{meta.GlobalKey("1234567890"), 1234567890, true},
+		{meta.ZonalKey("18446744073709551615", "us-central1-b"), 18446744073709551615, true},
+		{meta.GlobalKey("fw"), 0, false},
+		{meta.GlobalKey("18446744073709551616"), 0, false},
+	} {
+		got, err := keyID(*tc.key)
+		if got != tc.want || (err == nil) != tc.wantOK {
+			t.Errorf("keyID(%v) = %d, %v; want %d, ok = %t", tc.key, got, err, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestWithVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var paths []string
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		writeJSON(t, w, &alpha.Firewall{Name: "fw"})
+	})
+	svc, err := alpha.New(http.DefaultClient)
+	if err != nil {
+		t.Fatalf("alpha.New() = _, %v", err)
+	}
+	svc.BasePath = strings.Replace(s.GA.BasePath, "/compute/v1/", "/compute/alpha/", 1)
+	s.Alpha = svc
+
+	rc := withVersion(NewGCE(s).gceFirewalls.c, meta.VersionAlpha, s.alphaService)
+	if rc.version != meta.VersionAlpha || rc.service != "Firewalls" || rc.keyType != meta.Global {
+		t.Errorf("withVersion() = {%q, %q, %q}; want {%q, %q, %q}", rc.version, rc.service, rc.keyType, meta.VersionAlpha, "Firewalls", meta.Global)
+	}
+	obj, err := invoke(ctx, rc, "Get", meta.GlobalKey("fw"), func(ctx context.Context, svc *alpha.Service, projectID string) (*alpha.Firewall, error) {
+		return svc.Firewalls.Get(projectID, "fw").Context(ctx).Do()
+	})
+	if err != nil || obj.Name != "fw" {
+		t.Fatalf("invoke() = %+v, %v; want fw, nil", obj, err)
+	}
+	if want := []string{"/compute/alpha/projects/proj/global/firewalls/fw"}; len(paths) != 1 || paths[0] != want[0] {
+		t.Errorf("got requests %v, want %v", paths, want)
+	}
+}
+
+func TestAggregatedList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/compute/v1/projects/proj/aggregated/addresses" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(t, w, &ga.AddressAggregatedList{
+			Items: map[string]ga.AddressesScopedList{
+				"regions/us-central1": {Addresses: []*ga.Address{{Name: "a"}, {Name: "b"}}},
+				"regions/us-east1":    {Warning: &ga.AddressesScopedListWarning{Code: "NO_RESULTS_ON_PAGE"}},
+			},
+		})
+	}))
+
+	got, err := gce.Addresses().AggregatedList(ctx, filter.None)
+	if err != nil {
+		t.Fatalf("Addresses().AggregatedList() = _, %v; want _, nil", err)
+	}
+	if len(got) != 1 || len(got["us-central1"]) != 2 {
+		t.Errorf("Addresses().AggregatedList() = %+v; want 2 addresses in us-central1", got)
+	}
+}
+
+func TestAggregatedListLocation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		scope string
+		want  string
+	}{
+		{"zones/us-central1-b", "us-central1-b"},
+		{"regions/us-central1", "us-central1"},
+		{"global", "global"},
+	} {
+		if got := aggregatedListLocation(tc.scope); got != tc.want {
+			t.Errorf("aggregatedListLocation(%q) = %q; want %q", tc.scope, got, tc.want)
+		}
+	}
+}
+
+func TestInsertOp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var (
+		lock  sync.Mutex
+		polls int
+	)
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "RUNNING", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			polls++
+			status := "RUNNING"
+			if polls > 1 {
+				status = "DONE"
+			}
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: status})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	numPolls := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return polls
+	}
+	s.RateLimiter = NewDefaultRateLimiter()
+	buf := &bytes.Buffer{}
+	s.ChangeSink = &WriterChangeSink{W: buf}
+
+	key := meta.GlobalKey("fw")
+	op, err := NewGCE(s).Firewalls().InsertOp(ctx, *key, &ga.Firewall{})
+	if err != nil {
+		t.Fatalf("Firewalls().InsertOp(%v) = _, %v; want nil", key, err)
+	}
+	if n := numPolls(); n != 0 {
+		t.Errorf("InsertOp() polled the operation %d times; want 0", n)
+	}
+	if got, want := op.Key(), meta.GlobalKey("op"); got == nil || *got != *want {
+		t.Errorf("Key() = %v; want %v", got, want)
+	}
+	if done, err := op.Done(ctx); done || err != nil {
+		t.Errorf("Done() = %t, %v; want false, nil", done, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("the change was published before the operation completed: %q", buf.String())
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Errorf("Wait() = %v; want nil", err)
+	}
+	if done, err := op.Done(ctx); !done || err != nil || op.Error() != nil {
+		t.Errorf("Done() = %t, %v, Error() = %v; want true, nil, nil", done, err, op.Error())
+	}
+	if n := numPolls(); n != 2 {
+		t.Errorf("got %d polls, want 2", n)
+	}
+	if !strings.Contains(buf.String(), `"Insert"`) || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("published %q; want a single Insert change", buf.String())
+	}
+
+	// An error starting the operation is returned by DeleteOp.
+	if _, err := NewGCE(s).Firewalls().DeleteOp(ctx, *key); !IsNotFound(err) {
+		t.Errorf("Firewalls().DeleteOp(%v) = _, %v; want http.StatusNotFound", key, err)
+	}
+}
+
+func TestDoneOp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errOp := errors.New("error")
+	op := DoneOp(errOp)
+	if done, err := op.Done(ctx); !done || err != nil {
+		t.Errorf("Done() = %t, %v; want true, nil", done, err)
+	}
+	if err := op.Wait(ctx); err != errOp {
+		t.Errorf("Wait() = %v; want %v", err, errOp)
+	}
+	if err := op.Error(); err != errOp {
+		t.Errorf("Error() = %v; want %v", err, errOp)
+	}
+	if key := op.Key(); key != nil {
+		t.Errorf("Key() = %v; want nil", key)
+	}
+}
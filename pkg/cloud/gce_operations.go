@@ -0,0 +1,78 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// GlobalOperationsOps is the manually implemented methods for the
+// GlobalOperations service. It is defined in package interfaces.
+type GlobalOperationsOps = interfaces.GlobalOperationsOps
+
+// RegionOperationsOps is the manually implemented methods for the
+// RegionOperations service. It is defined in package interfaces.
+type RegionOperationsOps = interfaces.RegionOperationsOps
+
+// ZoneOperationsOps is the manually implemented methods for the
+// ZoneOperations service. It is defined in package interfaces.
+type ZoneOperationsOps = interfaces.ZoneOperationsOps
+
+// Wait polls the global operation referenced by key according to the
+// PollPolicy until it has completed.
+func (g *GCEGlobalOperations) Wait(ctx context.Context, key meta.Key) error {
+	if err := g.c.checkKey(key); err != nil {
+		return err
+	}
+	return g.s.waitForOperationKey(ctx, "GlobalOperations", key)
+}
+
+// Wait polls the regional operation referenced by key according to the
+// PollPolicy until it has completed.
+func (g *GCERegionOperations) Wait(ctx context.Context, key meta.Key) error {
+	if err := g.c.checkKey(key); err != nil {
+		return err
+	}
+	return g.s.waitForOperationKey(ctx, "RegionOperations", key)
+}
+
+// Wait polls the zonal operation referenced by key according to the
+// PollPolicy until it has completed.
+func (g *GCEZoneOperations) Wait(ctx context.Context, key meta.Key) error {
+	if err := g.c.checkKey(key); err != nil {
+		return err
+	}
+	return g.s.waitForOperationKey(ctx, "ZoneOperations", key)
+}
+
+// waitForOperationKey waits for the operation of service referenced by key.
+// The operation is polled at the GA version: the operations are the same for
+// all of the API versions.
+func (g *Service) waitForOperationKey(ctx context.Context, service string, key meta.Key) error {
+	op := &gaOperation{
+		s:         g,
+		op:        &ga.Operation{Name: key.Name, Region: key.Region, Zone: key.Zone},
+		projectID: g.ProjectRouter.ProjectID(ctx, meta.VersionGA, service),
+		opKey:     &key,
+	}
+	return g.waitForOperation(ctx, op, g.PollPolicy)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestOperations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var (
+		lock     sync.Mutex
+		requests []string
+	)
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		req := r.Method + " " + r.URL.Path
+		requests = append(requests, req)
+		switch req {
+		case "GET /compute/v1/projects/proj/zones/us-central1-b/operations/op":
+			status := "RUNNING"
+			if len(requests) > 2 {
+				status = "DONE"
+			}
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: status})
+		case "DELETE /compute/v1/projects/proj/zones/us-central1-b/operations/op":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /compute/v1/projects/proj/regions/us-central1/operations":
+			writeJSON(t, w, &ga.OperationList{Items: []*ga.Operation{{Name: "op"}}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	s.RateLimiter = NewDefaultRateLimiter()
+	gce := NewGCE(s)
+
+	key := meta.ZonalKey("op", "us-central1-b")
+	if err := gce.ZoneOperations().Wait(ctx, *key); err != nil {
+		t.Errorf("ZoneOperations().Wait(%v) = %v; want nil", key, err)
+	}
+	if err := gce.ZoneOperations().Delete(ctx, *key); err != nil {
+		t.Errorf("ZoneOperations().Delete(%v) = %v; want nil", key, err)
+	}
+	if ops, err := gce.RegionOperations().List(ctx, "us-central1", filter.None); err != nil || len(ops) != 1 {
+		t.Errorf("RegionOperations().List() = %+v, %v; want 1 operation, nil", ops, err)
+	}
+	missing := meta.GlobalKey("missing")
+	if err := gce.GlobalOperations().Wait(ctx, *missing); !IsNotFound(err) {
+		t.Errorf("GlobalOperations().Wait(%v) = %v; want http.StatusNotFound", missing, err)
+	}
+	if err := gce.GlobalOperations().Wait(ctx, *key); err == nil {
+		t.Errorf("GlobalOperations().Wait(%v) = nil; want an error for a zonal key", key)
+	}
+	if len(requests) != 6 {
+		t.Errorf("got requests %v, want 6 requests", requests)
+	}
+}
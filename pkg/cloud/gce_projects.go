@@ -18,37 +18,15 @@ package cloud
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
 	"github.com/bowei/gce-gen/pkg/cloud/meta"
 	compute "google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
-// ProjectsOps is the manually implemented methods for the Projects service.
-type ProjectsOps interface {
-	Get(ctx context.Context, projectID string) (*compute.Project, error)
-	SetCommonInstanceMetadata(ctx context.Context, projectID string, m *compute.Metadata) error
-}
-
-// MockProjectOpsState is stored in the mock.X field.
-type MockProjectOpsState struct {
-	metadata map[string]*compute.Metadata
-}
-
-func (m *MockProjects) Get(ctx context.Context, projectID string) (*compute.Project, error) {
-	m.Lock.Lock()
-	defer m.Lock.Unlock()
-
-	if p, ok := m.Objects[*meta.GlobalKey(projectID)]; ok {
-		return p.ToGA(), nil
-	}
-	return nil, &googleapi.Error{
-		Code:    http.StatusNotFound,
-		Message: fmt.Sprintf("MockProjects %v not found", projectID),
-	}
-}
+// ProjectsOps is the manually implemented methods for the Projects service. It
+// is defined in package interfaces.
+type ProjectsOps = interfaces.ProjectsOps
 
 func (g *GCEProjects) Get(ctx context.Context, projectID string) (*compute.Project, error) {
 	rk := &RateLimitKey{
@@ -57,21 +35,21 @@ func (g *GCEProjects) Get(ctx context.Context, projectID string) (*compute.Proje
 		Version:   meta.Version("ga"),
 		Service:   "Projects",
 	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-		return nil, err
-	}
-	call := g.s.GA.Projects.Get(projectID)
-	call.Context(ctx)
-	return call.Do()
-}
-
-func (m *MockProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, meta *compute.Metadata) error {
-	if m.X == nil {
-		m.X = &MockProjectOpsState{metadata: map[string]*compute.Metadata{}}
-	}
-	state := m.X.(*MockProjectOpsState)
-	state.metadata[projectID] = meta
-	return nil
+	p, err := withRetries(ctx, g.s.retryPolicy(), rk, false, func() (*compute.Project, error) {
+		if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
+			return nil, err
+		}
+		svc, err := g.s.gaService("")
+		if err != nil {
+			return nil, err
+		}
+		call := svc.Projects.Get(projectID)
+		call.Context(ctx)
+		p, err := do(g.s, call)
+		g.s.observeRateLimit(ctx, rk, err)
+		return p, err
+	})
+	return p, wrapError(rk, nil, err)
 }
 
 func (g *GCEProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, m *compute.Metadata) error {
@@ -81,15 +59,26 @@ func (g *GCEProjects) SetCommonInstanceMetadata(ctx context.Context, projectID s
 		Version:   meta.Version("ga"),
 		Service:   "Projects",
 	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-		return err
-	}
-	call := g.s.GA.Projects.SetCommonInstanceMetadata(projectID, m)
-	call.Context(ctx)
-
-	op, err := call.Do()
+	op, err := withRetries(ctx, g.s.retryPolicy(), rk, true, func() (*compute.Operation, error) {
+		if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
+			return nil, err
+		}
+		svc, err := g.s.gaService("")
+		if err != nil {
+			return nil, err
+		}
+		call := svc.Projects.SetCommonInstanceMetadata(projectID, m)
+		call.Context(ctx)
+		op, err := do(g.s, call)
+		g.s.observeRateLimit(ctx, rk, err)
+		return op, err
+	})
 	if err != nil {
-		return err
+		return wrapError(rk, nil, err)
 	}
-	return g.s.WaitForCompletion(ctx, op)
+	if err := g.s.WaitForCompletionWithPolicy(ctx, op, g.s.pollPolicy(rk)); err != nil {
+		return wrapError(rk, nil, err)
+	}
+	g.s.recordChange(ctx, rk, *meta.GlobalKey(projectID), op, m)
+	return nil
 }
@@ -18,15 +18,90 @@ limitations under the License.
 // modifying this file:
 //
 //   $ go run gen/main.go > gen.go
+//   $ go run gen/main.go -mode interfaces > interfaces/gen.go
+//   $ go run gen/main.go -mode mock > mock/gen.go
+//   $ go run gen/main.go -mode test > mock/gen_test.go
+//   $ go run gen/main.go -mode fuzz > mock/gen_fuzz_test.go
+//   $ go run gen/main.go -mode bench > mock/gen_bench_test.go
+//
+// The interfaces are generated into the separate package "interfaces" so that
+// libraries can depend on the contract without the GCE adapters or mocks.
+// Package cloud defines aliases for them.
+//
+// The mocks are generated into the separate package "mock" so that binaries
+// using the GCE adapters do not link them in.
+//
+// The output can also be written directly to a file (-out) or all of the
+// generated files can be written to a directory (-dir):
+//
+//   $ go run gen/main.go -out gen.go
+//   $ go run gen/main.go -dir .
+//
+// Files are written atomically, so a failed run does not clobber existing
+// generated code.
+//
+// By default, the services are taken from meta.AllServices. With
+// -services=discovery they are derived from the compute API discovery
+// documents vendored with the client libraries instead; -resources limits the
+// resources that are emitted:
+//
+//   $ go run gen/main.go -services discovery -resources addresses,instances
+//
+// The services can also be read from a JSON configuration file (see
+// meta.Config) so that a set of resources can be maintained without editing
+// meta.AllServices:
+//
+//   $ go run gen/main.go -config services.json
+//
+// The client packages imported by the generated code are taken from the API
+// group of the services (see meta.APIGroup). -api-group selects the group
+// whose discovery documents are used with -services=discovery.
+//
+// -gomock additionally writes mocks in the style of mockgen (with a
+// gomock.Controller and EXPECT()) for Cloud and each service interface to
+// "gomocks/gen.go". These require github.com/golang/mock, which is not vendored
+// by default. Services with CustomOps are not mocked as their Ops interface
+// is written by hand:
+//
+//   $ go run gen/main.go -dir . -gomock
+//
+// -only and -exclude select a subset of the services by name (e.g.
+// "Firewalls"), for consumers that need a slim wrapper for a handful of
+// resources. All of the versions of a selected service are generated, as are
+// the services used by the hand-written code of the package (Projects, Regions,
+// Zones and the operations), which cannot be excluded:
+//
+//   $ go run gen/main.go -only Firewalls,Addresses
+//
+// The code is generated from the templates in "gen/templates", which are
+// embedded in the generator. Templates in -template-dir replace the built-in
+// template with the same file name (e.g. "types.tmpl") and may define
+// additional templates:
+//
+//   $ go run gen/main.go -template-dir ./my-templates
+//
+// -check verifies that the generated files are up to date without modifying
+// them. It exits with a non-zero status and a summary of the differences if
+// regeneration is needed. The copyright year is ignored:
+//
+//   $ go run gen/main.go -check -dir .
 package main
 
 import (
 	"bytes"
+	"embed"
 	"flag"
 	"fmt"
+	"go/build"
+	"go/format"
 	"io"
+	"io/ioutil"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -34,830 +109,1062 @@ import (
 	"github.com/golang/glog"
 )
 
-const (
-	gofmt       = "gofmt"
-	packageRoot = "github.com/bowei/gce-gen/pkg/cloud"
-
-	// readOnly specifies that the given resource is read-only and should not
-	// have insert() or delete() methods generated for the wrapper.
-	readOnly = iota
-)
+const packageRoot = "github.com/bowei/gce-gen/pkg/cloud"
 
 var flags = struct {
-	gofmt bool
-	mode  string
+	gofmt       bool
+	mode        string
+	out         string
+	dir         string
+	services    string
+	config      string
+	resources   string
+	only        string
+	exclude     string
+	templateDir string
+	check       bool
+	gomock      bool
+	apiGroup    string
+	docs        bool
 }{}
 
 func init() {
-	flag.BoolVar(&flags.gofmt, "gofmt", true, "run output through gofmt")
-	flag.StringVar(&flags.mode, "mode", "src", "content to generate: src, test, dummy")
+	flag.BoolVar(&flags.gofmt, "gofmt", true, "format the output (as gofmt)")
+	flag.StringVar(&flags.mode, "mode", "src", "content to generate: src, interfaces, mock, test, fuzz, bench, gomock")
+	flag.BoolVar(&flags.gomock, "gomock", false, "with -dir, also write gomock style mocks to gomocks/gen.go (requires github.com/golang/mock)")
+	flag.StringVar(&flags.out, "out", "", "file to write the output to (default: stdout)")
+	flag.StringVar(&flags.dir, "dir", "", "directory to write all of the generated files to (ignores -mode)")
+	flag.StringVar(&flags.services, "services", "meta", "source of the list of services: meta (meta.AllServices) or discovery (the compute API discovery documents)")
+	flag.BoolVar(&flags.check, "check", false, "check that the generated files (-out, -dir or the default file for -mode) are up to date instead of writing them")
+	flag.StringVar(&flags.templateDir, "template-dir", "", "directory containing templates (*.tmpl) that override or extend the built-in templates")
+	flag.StringVar(&flags.config, "config", "", "JSON file containing the list of services to generate (see meta.Config); replaces meta.AllServices")
+	flag.StringVar(&flags.apiGroup, "api-group", "compute", "API group (see meta.APIGroups) whose discovery documents are used with -services=discovery")
+	flag.StringVar(&flags.resources, "resources", "", "comma separated allowlist of discovery resources to generate (e.g. addresses,backendServices); defaults to the resources in meta.AllServices")
+	flag.StringVar(&flags.only, "only", "", "comma separated list of the services to generate (e.g. Firewalls,Addresses); defaults to all services")
+	flag.StringVar(&flags.exclude, "exclude", "", "comma separated list of the services to omit (e.g. Routes)")
+	flag.BoolVar(&flags.docs, "docs", true, "comment the generated code with the descriptions from the discovery documents")
 }
 
-// gofmtContent runs "gofmt" on the given contents.
-func gofmtContent(r io.Reader) string {
-	cmd := exec.Command(gofmt, "-s")
-	out := &bytes.Buffer{}
-	cmd.Stdin = r
-	cmd.Stdout = out
-	cmdErr := &bytes.Buffer{}
-	cmd.Stderr = cmdErr
+// templateFS contains the default templates for the generated code.
+//
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// templates are the templates used to generate the code, see loadTemplates().
+var templates *template.Template
+
+// templateFuncs are the functions available to the templates, in addition to
+// the methods of the template data.
+var templateFuncs = template.FuncMap{
+	"objectDoc": func(s *meta.ServiceInfo) string {
+		return withDeprecation(docs[s.Version()].Object(s), s.Deprecated())
+	},
+	"methodDoc": func(s *meta.ServiceInfo, method string) string {
+		return withDeprecation(docs[s.Version()].Method(s, method), s.MethodDeprecated(method))
+	},
+	"deprecation": func(notice string) string {
+		return withDeprecation("", notice)
+	},
+	"paramDocs": func(m *meta.Method) []string {
+		return docs[m.Version()].Params(m)
+	},
+	"comment":          comment,
+	"commentParagraph": commentParagraph,
+}
 
-	if err := cmd.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, cmdErr.String())
-		panic(err)
+// withDeprecation returns doc followed by a "Deprecated:" paragraph with
+// notice, which is recognized by the linters, or doc if notice is empty.
+func withDeprecation(doc, notice string) string {
+	if notice == "" {
+		return doc
 	}
-	return out.String()
+	return doc + "\n\nDeprecated: " + notice
 }
 
-// genHeader generate the header for the file.
-func genHeader(wr io.Writer) {
-	fmt.Fprintf(wr, `/*
-Copyright %d The Kubernetes Authors.
+// commentWidth is the width to which the comments generated from text are
+// wrapped, not counting the indentation.
+const commentWidth = 80
+
+// comment returns text as a comment indented by indent, wrapped to
+// commentWidth. Blank lines in text separate paragraphs. The result starts
+// with a newline so that it can follow a line trimmed with "{{-". It is empty
+// if text is empty.
+func comment(indent, text string) string {
+	var b strings.Builder
+	for i, para := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		words := strings.Fields(para)
+		if len(words) == 0 {
+			continue
+		}
+		if i > 0 {
+			b.WriteString("\n" + indent + "//")
+		}
+		line := "//"
+		for _, w := range words {
+			if len(line) > len("//") && len(line)+1+len(w) > commentWidth {
+				b.WriteString("\n" + indent + line)
+				line = "//"
+			}
+			line += " " + w
+		}
+		b.WriteString("\n" + indent + line)
+	}
+	return b.String()
+}
 
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
+// commentParagraph is comment with an empty comment line first, for text that
+// continues an existing comment.
+func commentParagraph(indent, text string) string {
+	c := comment(indent, text)
+	if c == "" {
+		return ""
+	}
+	return "\n" + indent + "//" + c
+}
 
-    http://www.apache.org/licenses/LICENSE-2.0
+// loadTemplates parses the embedded templates followed by the templates in
+// dir, if set. A template file in dir replaces the embedded template with the
+// same file name (e.g. "types.tmpl"); other files add to the set of
+// templates and can be referenced from the overrides.
+func loadTemplates(dir string) (*template.Template, error) {
+	tmpl, err := template.New("").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return tmpl, nil
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no templates (*.tmpl) found in %q", dir)
+	}
+	return tmpl.ParseFiles(files...)
+}
 
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
+// allServices and allServicesByGroup are the services to generate code for.
+// They are meta.AllServices unless -services=discovery.
+var (
+	allServices        = meta.AllServices
+	allServicesByGroup = meta.AllServicesByGroup
+)
 
-// This file was generated by "go run gen/main.go > gen.go". Do not edit
-// directly.
+// apiGroup is the API group of the services. All of the services generated
+// together must be in the same group.
+var apiGroup = meta.ComputeAPI
 
-package cloud
+// servicesAPIGroup returns the API group of services, which must all be in the
+// same group.
+func servicesAPIGroup(services []*meta.ServiceInfo) (*meta.APIGroup, error) {
+	if len(services) == 0 {
+		return meta.ComputeAPI, nil
+	}
+	group := services[0].APIGroup()
+	for _, s := range services[1:] {
+		if s.APIGroup() != group {
+			return nil, fmt.Errorf("services %q and %q are in different API groups (%q and %q)", services[0].Service, s.Service, group.Name, s.APIGroup().Name)
+		}
+	}
+	return group, nil
+}
 
-import (
-	"context"
-	"fmt"
-	"net/http"
-	"sync"
+// resourceName is the name of the resource in the discovery document (e.g.
+// "backendServices").
+func resourceName(s *meta.ServiceInfo) string {
+	return strings.ToLower(s.Service[:1]) + s.Service[1:]
+}
 
-	"google.golang.org/api/googleapi"
-	"github.com/golang/glog"
+// discoveryDocument returns the discovery document vendored with the client
+// package of the version of the API group.
+func discoveryDocument(group *meta.APIGroup, v meta.Version) ([]byte, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	pkgPath, err := group.Package(v)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := build.Import(pkgPath, wd, build.FindOnly)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(filepath.Join(pkg.Dir, "compute-api.json"))
+}
 
-	"%v/filter"
-	"%v/meta"
+// docs are the descriptions of the objects and methods of each version of the
+// API, used to comment the generated code. They are empty with -docs=false.
+var docs = map[meta.Version]*meta.Docs{}
 
-`, time.Now().Year(), packageRoot, packageRoot)
+// loadDocs loads the docs for the versions of services.
+func loadDocs(services []*meta.ServiceInfo) error {
+	group, err := servicesAPIGroup(services)
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		if _, ok := docs[s.Version()]; ok {
+			continue
+		}
+		doc, err := discoveryDocument(group, s.Version())
+		if err != nil {
+			return err
+		}
+		if docs[s.Version()], err = meta.DocsFromDiscovery(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	var hasGA, hasAlpha, hasBeta bool
-	for _, s := range meta.AllServices {
-		switch s.Version() {
-		case meta.VersionGA:
-			hasGA = true
-		case meta.VersionAlpha:
-			hasAlpha = true
-		case meta.VersionBeta:
-			hasBeta = true
+// loadDiscoveryServices derives the services from the discovery documents of
+// the compute API client packages.
+func loadDiscoveryServices() ([]*meta.ServiceInfo, error) {
+	// The allowlist for each version and the order in which the services
+	// are generated.
+	allow := map[meta.Version][]string{}
+	order := map[string]int{}
+	if flags.resources != "" {
+		for i, r := range strings.Split(flags.resources, ",") {
+			for _, v := range meta.AllVersions {
+				allow[v] = append(allow[v], r)
+			}
+			order[r] = i
+		}
+	} else {
+		for i, s := range meta.AllServices {
+			if s.APIGroup() != apiGroup {
+				continue
+			}
+			r := resourceName(s)
+			allow[s.Version()] = append(allow[s.Version()], r)
+			if _, ok := order[r]; !ok {
+				order[r] = i
+			}
 		}
 	}
-	if hasAlpha {
-		fmt.Fprintln(wr, `	alpha "google.golang.org/api/compute/v0.alpha"`)
+
+	var ret []*meta.ServiceInfo
+	for _, v := range meta.AllVersions {
+		if len(allow[v]) == 0 {
+			continue
+		}
+		doc, err := discoveryDocument(apiGroup, v)
+		if err != nil {
+			return nil, err
+		}
+		services, err := meta.ServicesFromDiscovery(apiGroup, v, doc, allow[v])
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, services...)
 	}
-	if hasBeta {
-		fmt.Fprintln(wr, `	beta "google.golang.org/api/compute/v0.beta"`)
+	// Order by the allowlist, with the versions of a service together.
+	sort.SliceStable(ret, func(i, j int) bool {
+		return order[resourceName(ret[i])] < order[resourceName(ret[j])]
+	})
+	return ret, nil
+}
+
+// splitList splits a comma separated flag value, ignoring empty elements.
+func splitList(s string) []string {
+	var ret []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			ret = append(ret, e)
+		}
 	}
-	if hasGA {
-		fmt.Fprintln(wr, `	ga "google.golang.org/api/compute/v1"`)
+	return ret
+}
+
+// requiredServices are the services used by the hand-written code of package
+// cloud and the mocks (e.g. the operations waited for by the GCE adapters, the
+// quotas of the projects and regions and the scopes of the aggregated lists).
+// They are generated whatever the services named with -only.
+var requiredServices = []string{"Projects", "Regions", "Zones", "GlobalOperations", "RegionOperations", "ZoneOperations"}
+
+// filterServices returns the services named in only (all services if only is
+// empty) and the requiredServices, minus the services named in exclude.
+// Services are named by ServiceInfo.Service (e.g. "Firewalls"); all versions
+// of a service are selected together. An error is returned for names that do
+// not match any service and for the exclusion of a required service.
+func filterServices(services []*meta.ServiceInfo, only, exclude []string) ([]*meta.ServiceInfo, error) {
+	known := map[string]bool{}
+	for _, s := range services {
+		known[s.Service] = true
 	}
-	fmt.Fprintf(wr, ")\n\n")
+	toSet := func(names []string) (map[string]bool, error) {
+		set := map[string]bool{}
+		for _, n := range names {
+			if !known[n] {
+				return nil, fmt.Errorf("unknown service %q", n)
+			}
+			set[n] = true
+		}
+		return set, nil
+	}
+	onlySet, err := toSet(only)
+	if err != nil {
+		return nil, err
+	}
+	excludeSet, err := toSet(exclude)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range requiredServices {
+		if excludeSet[n] {
+			return nil, fmt.Errorf("service %q cannot be excluded: it is used by the hand-written code of package cloud", n)
+		}
+		if len(onlySet) > 0 && known[n] {
+			onlySet[n] = true
+		}
+	}
+
+	var ret []*meta.ServiceInfo
+	for _, s := range services {
+		if (len(onlySet) > 0 && !onlySet[s.Service]) || excludeSet[s.Service] {
+			continue
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
 }
 
-// genStubs generates the interface and wrapper stubs.
-func genStubs(wr io.Writer) {
-	const text = `// Cloud is an interface for the GCE compute API.
-type Cloud interface {
-{{- range .All}}
-	{{.WrapType}}() {{.WrapType}}
-{{- end}}
+type output struct {
+	mode string
+	file string
 }
 
-// NewGCE returns a GCE.
-func NewGCE(s *Service) *GCE {
-	g := &GCE{
-	{{- range .All}}
-		{{.Field}}: &{{.GCEWrapType}}{s},
-	{{- end}}
-	}
-	return g
+// outputs is the list of files written by -dir and the mode used to generate
+// each of them.
+var outputs = []output{
+	{"src", "gen.go"},
+	{"interfaces", "interfaces/gen.go"},
+	{"mock", "mock/gen.go"},
+	{"test", "mock/gen_test.go"},
+	{"fuzz", "mock/gen_fuzz_test.go"},
+	{"bench", "mock/gen_bench_test.go"},
 }
 
-// GCE implements Cloud.
-var _ Cloud = (*GCE)(nil)
+// gomockOutput is also written by -dir if -gomock is set. It is optional as
+// the gomock mocks depend on github.com/golang/mock.
+var gomockOutput = output{"gomock", "gomocks/gen.go"}
 
-// GCE is the golang adapter for the compute APIs.
-type GCE struct {
-{{- range .All}}
-	{{.Field}} *{{.GCEWrapType}}
-{{- end}}
+// formatSource formats the generated code in-process, so the gofmt binary
+// does not need to be installed. The templates emit code that "gofmt -s"
+// leaves unchanged.
+func formatSource(src []byte) ([]byte, error) {
+	b, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting the generated code (use -gofmt=false to see the unformatted output): %v", err)
+	}
+	return b, nil
 }
 
-{{range .All}}
-func (gce *GCE) {{.WrapType}}() {{.WrapType}} {
-	return gce.{{.Field}}
+// headerData is the data for the file header templates.
+type headerData struct {
+	Year        int
+	PackageRoot string
+	// Versions are the API versions used by any of the services, sorted.
+	Versions []string
+	// Packages are the import paths of the client packages of the API group
+	// by version (e.g. "ga").
+	Packages map[string]string
+	// Fmt is true if the code of the file uses package fmt.
+	Fmt bool
 }
-{{- end}}
 
-// NewMockGCE returns a new mock for GCE.
-func NewMockGCE() *MockGCE {
-	{{- range .Groups}}
-	mock{{.Service}}Objs := map[meta.Key]*Mock{{.Service}}Obj{}
-	{{- end}}
+func newHeaderData() *headerData {
+	d := &headerData{Year: time.Now().Year(), PackageRoot: packageRoot, Packages: map[string]string{}}
+	for v, pkg := range apiGroup.Packages {
+		d.Packages[string(v)] = pkg
+	}
+	used := map[string]bool{}
+	for _, s := range allServices {
+		used[string(s.Version())] = true
+		// Methods with a version override use the types of their version.
+		for _, m := range s.Methods() {
+			used[string(m.Version())] = true
+		}
+	}
+	for v := range used {
+		d.Versions = append(d.Versions, v)
+	}
+	sort.Strings(d.Versions)
+	return d
+}
 
-	mock := &MockGCE{
-	{{- range .All}}
-		{{.MockField}}: New{{.MockWrapType}}(mock{{.Service}}Objs),
-	{{- end}}
+// execTemplate executes the template with the given name.
+func execTemplate(wr io.Writer, name string, data interface{}) {
+	if err := templates.ExecuteTemplate(wr, name, data); err != nil {
+		panic(err)
 	}
-	return mock
 }
 
-// MockGCE implements Cloud.
-var _ Cloud = (*MockGCE)(nil)
+// genHeader generate the header for the file.
+func genHeader(wr io.Writer) {
+	execTemplate(wr, "header.tmpl", newHeaderData())
+}
 
-// MockGCE is the mock for the compute API.
-type MockGCE struct {
-{{- range .All}}
-	{{.MockField}} *{{.MockWrapType}}
-{{- end}}
+// genStubs generates the interface and wrapper stubs.
+func genStubs(wr io.Writer) {
+	data := struct {
+		All      []*meta.ServiceInfo
+		Groups   map[string]*meta.ServiceGroup
+		Versions []*versionCloud
+		Tags     []*tagCloud
+	}{allServices, allServicesByGroup, versionClouds(), tagClouds()}
+	execTemplate(wr, "stubs.tmpl", data)
 }
-{{range .All}}
-func (mock *MockGCE) {{.WrapType}}() {{.WrapType}} {
-	return mock.{{.MockField}}
+
+// genRegistry generates the registry of the resources.
+func genRegistry(wr io.Writer) {
+	execTemplate(wr, "registry.tmpl", allServices)
 }
-{{end}}
 
-{{range .Groups}}
-// Mock{{.Service}}Obj is used to store the various object versions in the shared
-// map of mocked objects. This allows for multiple API versions to co-exist and
-// share the same "view" of the objects in the backend.
-type Mock{{.Service}}Obj struct {
-	Obj interface{}
+// genVersioned generates the accessors of VersionedCloud for the services
+// with more than one version.
+func genVersioned(wr io.Writer) {
+	var keys []string
+	for k := range allServicesByGroup {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		execTemplate(wr, "versioned.tmpl", allServicesByGroup[k])
+	}
 }
-{{- if .HasAlpha}}
-// ToAlpha retrieves the given version of the object.
-func (m *Mock{{.Service}}Obj) ToAlpha() *{{.Alpha.FQObjectType}} {
-	if ret, ok := m.Obj.(*{{.Alpha.FQObjectType}}); ok {
-		return ret
+
+// genScoped generates the combined interfaces of the meta.ScopedPairs whose
+// services are generated.
+func genScoped(wr io.Writer) {
+	pairs, err := meta.ScopedPairsFor(allServices)
+	if err != nil {
+		panic(err)
 	}
-	// Convert the object via JSON copying to the type that was requested.
-	ret := &{{.Alpha.FQObjectType}}{}
-	if err := copyViaJSON(ret, m.Obj); err != nil {
-		glog.Errorf("Could not convert %T to *{{.Alpha.FQObjectType}} via JSON: %v", m.Obj, err)
+	for _, p := range pairs {
+		execTemplate(wr, "scoped.tmpl", p)
 	}
-	return ret
 }
-{{- end}}
-{{- if .HasBeta}}
-// ToBeta retrieves the given version of the object.
-func (m *Mock{{.Service}}Obj) ToBeta() *{{.Beta.FQObjectType}} {
-	if ret, ok := m.Obj.(*{{.Beta.FQObjectType}}); ok {
-		return ret
+
+// regionalVersion is a version of the golang client as copied for the
+// regional endpoints (see Service.RegionalEndpoints).
+type regionalVersion struct {
+	Version meta.Version
+	// Title is the golang CamelCase name of the version (e.g. "GA").
+	Title string
+	// Services are the per-resource services of the client, whose pointer
+	// to the client is changed in the copies.
+	Services []regionalService
+}
+
+// regionalService is a field of the Service of the golang client holding a
+// per-resource service.
+type regionalService struct {
+	// Field is the name of the field (e.g. "Addresses").
+	Field string
+	// Type is the type of the per-resource service (e.g.
+	// "AddressesService"), made by New<Type>().
+	Type string
+}
+
+// genRegional generates the functions copying the clients of the versions
+// used by the services for the regional endpoints.
+func genRegional(wr io.Writer) {
+	var data []*regionalVersion
+	for _, v := range newHeaderData().Versions {
+		vi, ok := meta.Version(v).Info()
+		if !ok {
+			panic(fmt.Sprintf("unknown version %q", v))
+		}
+		rv := &regionalVersion{Version: vi.Version, Title: vi.Title}
+		st := apiGroup.ServiceTypes[rv.Version]
+		for i := 0; i < st.NumField(); i++ {
+			f := st.Field(i)
+			if f.PkgPath != "" || f.Type.Kind() != reflect.Ptr || f.Type.Elem().Kind() != reflect.Struct {
+				continue
+			}
+			rv.Services = append(rv.Services, regionalService{f.Name, f.Type.Elem().Name()})
+		}
+		data = append(data, rv)
 	}
-	// Convert the object via JSON copying to the type that was requested.
-	ret := &{{.Beta.FQObjectType}}{}
-	if err := copyViaJSON(ret, m.Obj); err != nil {
-		glog.Errorf("Could not convert %T to *{{.Beta.FQObjectType}} via JSON: %v", m.Obj, err)
+	execTemplate(wr, "regional.tmpl", data)
+}
+
+// genCached generates the wrappers of CachedCloud for the services.
+func genCached(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "cached.tmpl", s)
+	}
+}
+
+// genDryRun generates the wrappers of DryRunCloud for the services.
+func genDryRun(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "dryrun.tmpl", s)
 	}
-	return ret
 }
-{{- end}}
-{{- if .HasGA}}
-// ToGA retrieves the given version of the object.
-func (m *Mock{{.Service}}Obj) ToGA() *{{.GA.FQObjectType}} {
-	if ret, ok := m.Obj.(*{{.GA.FQObjectType}}); ok {
-		return ret
+
+// genReadOnly generates the wrappers of ReadOnlyCloud for the services.
+func genReadOnly(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "readonly.tmpl", s)
 	}
-		// Convert the object via JSON copying to the type that was requested.
-	ret := &{{.GA.FQObjectType}}{}
-	if err := copyViaJSON(ret, m.Obj); err != nil {
-		glog.Errorf("Could not convert %T to *{{.GA.FQObjectType}} via JSON: %v", m.Obj, err)
+}
+
+// genBreaker generates the wrappers of CircuitBreakerCloud for the services.
+func genBreaker(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "breaker.tmpl", s)
 	}
-	return ret
 }
-{{- end}}
-{{- end}}
-`
-	data := struct {
-		All    []*meta.ServiceInfo
-		Groups map[string]*meta.ServiceGroup
-	}{meta.AllServices, meta.AllServicesByGroup}
 
-	tmpl := template.Must(template.New("interface").Parse(text))
-	if err := tmpl.Execute(wr, data); err != nil {
-		panic(err)
+// genRecording generates the wrappers of RecordingCloud for the services.
+func genRecording(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "recording.tmpl", s)
+	}
+}
+
+// genReplay generates the services of ReplayCloud.
+func genReplay(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "replay.tmpl", s)
 	}
 }
 
 // genTypes generates the type wrappers.
 func genTypes(wr io.Writer) {
-	const text = `// {{.WrapType}} is an interface that allows for mocking of {{.Service}}.
-type {{.WrapType}} interface {
-{{- if .GenerateCustomOps}}
-	// {{.WrapTypeOps}} is an interface with additional non-CRUD type methods.
-	// This interface is expected to be implemented by hand (non-autogenerated).
-	{{.WrapTypeOps}}
-{{- end}}
-{{- if .GenerateGet}}
-	Get(ctx context.Context, key meta.Key) (*{{.FQObjectType}}, error)
-{{- end -}}
-{{- if .GenerateList}}
-{{- if .KeyIsGlobal}}
-	List(ctx context.Context, fl *filter.F) ([]*{{.FQObjectType}}, error)
-{{- end -}}
-{{- if .KeyIsRegional}}
-	List(ctx context.Context, region string, fl *filter.F) ([]*{{.FQObjectType}}, error)
-{{- end -}}
-{{- if .KeyIsZonal}}
-	List(ctx context.Context, zone string, fl *filter.F) ([]*{{.FQObjectType}}, error)
-{{- end -}}
-{{- end -}}
-{{- if .GenerateInsert}}
-	Insert(ctx context.Context, key meta.Key, obj *{{.FQObjectType}}) error
-{{- end -}}
-{{- if .GenerateDelete}}
-	Delete(ctx context.Context, key meta.Key) error
-{{- end -}}
-{{- if .AggregatedList}}
-	AggregatedList(ctx context.Context, fl *filter.F) (map[string][]*{{.FQObjectType}}, error)
-{{- end}}
-{{- with .Methods -}}
-{{- range .}}
-	{{.InterfaceFunc}}
-{{- end -}}
-{{- end}}
-}
-
-// New{{.MockWrapType}} returns a new mock for {{.Service}}.
-func New{{.MockWrapType}}(objs map[meta.Key]*Mock{{.Service}}Obj) *{{.MockWrapType}} {
-	mock := &{{.MockWrapType}}{
-		Objects: objs,
-		{{- if .GenerateGet}}
-		GetError:    map[meta.Key]error{},
-		{{- end -}}
-		{{- if .GenerateInsert}}
-		InsertError: map[meta.Key]error{},
-		{{- end -}}
-		{{- if .GenerateDelete}}
-		DeleteError: map[meta.Key]error{},
-		{{- end}}
-	}
-	return mock
-}
-
-// {{.MockWrapType}} is the mock for {{.Service}}.
-type {{.MockWrapType}} struct {
-	Lock sync.Mutex
-
-	// Objects maintained by the mock.
-	Objects map[meta.Key]*Mock{{.Service}}Obj
-
-	// If an entry exists for the given key and operation, then the error
-	// will be returned instead of the operation.
-	{{- if .GenerateGet}}
-	GetError map[meta.Key]error
-	{{- end -}}
-	{{- if .GenerateList}}
-	ListError *error
-	{{- end -}}
-	{{- if .GenerateInsert}}
-	InsertError map[meta.Key]error
-	{{- end -}}
-	{{- if .GenerateDelete}}
-	DeleteError map[meta.Key]error
-	{{- end -}}
-	{{- if .AggregatedList}}
-	AggregatedListError *error
-	{{- end}}
-
-	// xxxHook allow you to intercept the standard processing of the mock in
-	// order to add your own logic. Return (true, _, _) to prevent the normal
-	// execution flow of the mock. Return (false, nil, nil) to continue with
-	// normal mock behavior/ after the hook function executes.
-	{{- if .GenerateGet}}
-	GetHook    func(m *{{.MockWrapType}}, ctx context.Context, key meta.Key) (bool, *{{.FQObjectType}}, error)
-	{{- end -}}
-	{{- if .GenerateList}}
-	{{- if .KeyIsGlobal}}
-	ListHook   func(m *{{.MockWrapType}}, ctx context.Context, fl *filter.F) (bool, []*{{.FQObjectType}}, error)
-	{{- end -}}
-	{{- if .KeyIsRegional}}
-	ListHook   func(m *{{.MockWrapType}}, ctx context.Context, region string, fl *filter.F) (bool, []*{{.FQObjectType}}, error)
-	{{- end -}}
-	{{- if .KeyIsZonal}}
-	ListHook   func(m *{{.MockWrapType}}, ctx context.Context, zone string, fl *filter.F) (bool, []*{{.FQObjectType}}, error)
-	{{- end}}
-	{{- end -}}
-	{{- if .GenerateInsert}}
-	InsertHook func(m *{{.MockWrapType}}, ctx context.Context, key meta.Key, obj *{{.FQObjectType}}) (bool, error)
-	{{- end -}}
-	{{- if .GenerateDelete}}
-	DeleteHook func(m *{{.MockWrapType}}, ctx context.Context, key meta.Key) (bool, error)
-	{{- end -}}
-	{{- if .AggregatedList}}
-	AggregatedListHook func(m *{{.MockWrapType}}, ctx context.Context, fl *filter.F) (bool, map[string][]*{{.FQObjectType}}, error)
-	{{- end}}
-
-{{- with .Methods -}}
-{{- range .}}
-	{{.MockHook}}
-{{- end -}}
-{{- end}}
-
-	// X is extra state that can be used as part of the mock. Generated code
-	// will not use this field.
-	X interface{}
-}
-
-{{- if .GenerateGet}}
-// Get returns the object from the mock.
-func (m *{{.MockWrapType}}) Get(ctx context.Context, key meta.Key) (*{{.FQObjectType}}, error) {
-	if m.GetHook != nil {
-		if intercept, obj, err := m.GetHook(m, ctx, key);  intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = %v, %v", ctx, key, obj ,err)
-			return obj, err
-		}
-	}
-
-	m.Lock.Lock()
-	defer m.Lock.Unlock()
-
-	if err, ok := m.GetError[key]; ok {
-		glog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = nil, %v", ctx, key, err)
-		return nil, err
+	for _, s := range allServices {
+		execTemplate(wr, "types.tmpl", s)
 	}
-	if obj, ok := m.Objects[key]; ok {
-		typedObj := obj.To{{.VersionTitle}}()
-		glog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = %v, nil", ctx, key, typedObj)
-		return typedObj, nil
-	}
-
-	err := &googleapi.Error{
-		Code: http.StatusNotFound,
-		Message: fmt.Sprintf("{{.MockWrapType}} %v not found", key),
-	}
-	glog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = nil, %v", ctx, key, err)
-	return nil, err
-}
-{{- end}}
-
-{{- if .GenerateList}}
-{{if .KeyIsGlobal -}}
-// List all of the objects in the mock.
-func (m *{{.MockWrapType}}) List(ctx context.Context, fl *filter.F) ([]*{{.FQObjectType}}, error) {
-{{- end -}}
-{{- if .KeyIsRegional -}}
-// List all of the objects in the mock in the given region.
-func (m *{{.MockWrapType}}) List(ctx context.Context, region string, fl *filter.F) ([]*{{.FQObjectType}}, error) {
-{{- end -}}
-{{- if .KeyIsZonal -}}
-// List all of the objects in the mock in the given zone.
-func (m *{{.MockWrapType}}) List(ctx context.Context, zone string, fl *filter.F) ([]*{{.FQObjectType}}, error) {
-{{- end}}
-	if m.ListHook != nil {
-		{{if .KeyIsGlobal -}}
-		if intercept, objs, err := m.ListHook(m, ctx, fl);  intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.List(%v, %v) = %v, %v", ctx, fl, objs, err)
-		{{- end -}}
-		{{- if .KeyIsRegional -}}
-		if intercept, objs, err := m.ListHook(m, ctx, region, fl);  intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
-		{{- end -}}
-		{{- if .KeyIsZonal -}}
-		if intercept, objs, err := m.ListHook(m, ctx, zone, fl);  intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
-		{{- end}}
-			return objs, err
-		}
-	}
-
-	m.Lock.Lock()
-	defer m.Lock.Unlock()
-
-	if m.ListError != nil {
-		err := *m.ListError
-		{{if .KeyIsGlobal -}}
-		glog.V(5).Infof("{{.MockWrapType}}.List(%v, %v) = nil, %v", ctx, fl, err)
-		{{- end -}}
-		{{- if .KeyIsRegional -}}
-		glog.V(5).Infof("{{.MockWrapType}}.List(%v, %q, %v) = nil, %v", ctx, region, fl, err)
-		{{- end -}}
-		{{- if .KeyIsZonal -}}
-		glog.V(5).Infof("{{.MockWrapType}}.List(%v, %q, %v) = nil, %v", ctx, zone, fl, err)
-		{{- end}}
-
-		return nil, *m.ListError
-	}
-
-	var objs []*{{.FQObjectType}}
-{{- if .KeyIsGlobal}}
-	for _, obj := range m.Objects {
-{{- else}}
-	for key, obj := range m.Objects {
-{{- end -}}
-{{- if .KeyIsRegional}}
-		if key.Region != region {
-			continue
-		}
-{{- end -}}
-{{- if .KeyIsZonal}}
-		if key.Zone != zone {
-			continue
-		}
-{{- end}}
-		if ! fl.Match(obj.To{{.VersionTitle}}()) {
-			continue
-		}
-		objs = append(objs, obj.To{{.VersionTitle}}())
+}
+
+// genKeys generates the typed key for each service group.
+func genKeys(wr io.Writer) {
+	// Sort by service name so the output is stable.
+	var keys []string
+	for k := range allServicesByGroup {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		execTemplate(wr, "keys.tmpl", allServicesByGroup[k])
+	}
+}
 
-	{{if .KeyIsGlobal -}}
-		glog.V(5).Infof("{{.MockWrapType}}.List(%v, %v) = %v, nil", ctx, fl, objs)
-	{{- end -}}
-	{{- if .KeyIsRegional -}}
-		glog.V(5).Infof("{{.MockWrapType}}.List(%v, %q, %v) = %v, nil", ctx, region, fl, objs)
-	{{- end -}}
-	{{- if .KeyIsZonal -}}
-		glog.V(5).Infof("{{.MockWrapType}}.List(%v, %q, %v) = %v, nil", ctx, zone, fl, objs)
-	{{- end}}
-	return objs, nil
+// genConverters generates the conversions between the API versions of each
+// object.
+func genConverters(wr io.Writer) {
+	conversions, err := meta.Conversions(allServices)
+	if err != nil {
+		panic(err)
+	}
+	for _, c := range conversions {
+		execTemplate(wr, "converters.tmpl", c)
+	}
 }
-{{- end}}
 
-{{- if .GenerateInsert}}
-// Insert is a mock for inserting/creating a new object.
-func (m *{{.MockWrapType}}) Insert(ctx context.Context, key meta.Key, obj *{{.FQObjectType}}) error {
-	if m.InsertHook != nil {
-		if intercept, err := m.InsertHook(m, ctx, key, obj);  intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
-			return err
+// genDeepCopies generates the deep copies of the objects and of the types
+// nested in them.
+func genDeepCopies(wr io.Writer) {
+	copies, err := meta.DeepCopies(allServices)
+	if err != nil {
+		panic(err)
+	}
+	for _, d := range copies {
+		execTemplate(wr, "deepcopy.tmpl", d)
+	}
+}
+
+// genGomockHeader generates the header for the gomock mocks.
+func genGomockHeader(wr io.Writer) {
+	execTemplate(wr, "gomock_header.tmpl", newHeaderData())
+}
+
+// genGomocks generates the gomock mocks for Cloud and the service interfaces.
+func genGomocks(wr io.Writer) {
+	execTemplate(wr, "gomock.tmpl", struct{ All []*meta.ServiceInfo }{allServices})
+}
+
+// genInterfacesHeader generates the header for the interfaces package.
+func genInterfacesHeader(wr io.Writer) {
+	execTemplate(wr, "interfaces_header.tmpl", newHeaderData())
+}
+
+// versionCloud is the Cloud interface restricted to the services of a single
+// API version (e.g. GACloud).
+type versionCloud struct {
+	Name     string
+	Version  meta.Version
+	Services []*meta.ServiceInfo
+}
+
+// versionClouds returns the versionCloud for each API version used by the
+// services.
+func versionClouds() []*versionCloud {
+	var ret []*versionCloud
+	for _, v := range meta.AllVersions {
+		var vc *versionCloud
+		for _, s := range allServices {
+			if s.Version() != v {
+				continue
+			}
+			if vc == nil {
+				vc = &versionCloud{Name: s.VersionTitle() + "Cloud", Version: v}
+				ret = append(ret, vc)
+			}
+			vc.Services = append(vc.Services, s)
 		}
 	}
+	return ret
+}
 
-	m.Lock.Lock()
-	defer m.Lock.Unlock()
+// tagCloud is the interface with the services of a meta.Tag at all of their
+// versions (e.g. LoadBalancingCloud).
+type tagCloud struct {
+	Name     string
+	Tag      meta.Tag
+	Services []*meta.ServiceInfo
+}
 
-	if err, ok := m.InsertError[key]; ok {
-		glog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
-		return err
+// tagClouds returns the tagCloud for each tag used by the services.
+func tagClouds() []*tagCloud {
+	byTag := meta.ServicesByTag(allServices)
+	var ret []*tagCloud
+	for _, t := range meta.SortedTags(byTag) {
+		ret = append(ret, &tagCloud{Name: t.CloudName(), Tag: t, Services: byTag[t]})
 	}
-	if _, ok := m.Objects[key]; ok {
-		err := &googleapi.Error{
-			Code: http.StatusConflict,
-			Message: fmt.Sprintf("{{.MockWrapType}} %v exists", key),
+	return ret
+}
+
+// genInterfaces generates the Cloud interface and the service interfaces.
+func genInterfaces(wr io.Writer) {
+	data := struct {
+		All      []*meta.ServiceInfo
+		Versions []*versionCloud
+		Tags     []*tagCloud
+	}{allServices, versionClouds(), tagClouds()}
+	execTemplate(wr, "interfaces.tmpl", data)
+}
+
+// genMockHeader generates the header for the mock package.
+func genMockHeader(wr io.Writer) {
+	d := newHeaderData()
+	// The mocks of the calls other than the standard List() and of the
+	// methods that do not return an operation need a hook.
+	for _, s := range allServices {
+		for _, lc := range s.ListCalls() {
+			d.Fmt = d.Fmt || !lc.Standard()
+		}
+		for _, m := range s.Methods() {
+			d.Fmt = d.Fmt || m.ReturnType != "Operation"
 		}
-		glog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
-		return err
 	}
+	execTemplate(wr, "mock_header.tmpl", d)
+}
 
-	m.Objects[key] = &Mock{{.Service}}Obj{obj}
-	glog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %v) = nil", ctx, key, obj)
-	return nil
+// genMockStubs generates MockGCE and the shared mock objects.
+func genMockStubs(wr io.Writer) {
+	data := struct {
+		All    []*meta.ServiceInfo
+		Groups map[string]*meta.ServiceGroup
+	}{allServices, allServicesByGroup}
+	execTemplate(wr, "mock_stubs.tmpl", data)
 }
-{{- end}}
 
-{{- if .GenerateDelete}}
-// Delete is a mock for deleting the object.
-func (m *{{.MockWrapType}}) Delete(ctx context.Context, key meta.Key) error {
-	if m.DeleteHook != nil {
-		if intercept, err := m.DeleteHook(m, ctx, key);  intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
-			return err
-		}
+// genMockTypes generates the mocks for each service.
+func genMockTypes(wr io.Writer) {
+	for _, s := range allServices {
+		execTemplate(wr, "mock_types.tmpl", s)
 	}
+}
 
-	m.Lock.Lock()
-	defer m.Lock.Unlock()
+// genUnitTestHeader generates the header for the unit test file.
+func genUnitTestHeader(wr io.Writer) {
+	execTemplate(wr, "test_header.tmpl", newHeaderData())
+}
 
-	if err, ok := m.DeleteError[key]; ok {
-		glog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
-		return err
+// genUnitTestAssertions generates the compile-time checks that the GCE and
+// mock types implement the service interfaces.
+func genUnitTestAssertions(wr io.Writer) {
+	execTemplate(wr, "test_assertions.tmpl", allServices)
+}
+
+// genUnitTestServices generates a test for each service group that exercises
+// the mock across all of the API versions.
+func genUnitTestServices(wr io.Writer) {
+	// Sort by service name so the output is stable.
+	var keys []string
+	for k := range allServicesByGroup {
+		keys = append(keys, k)
 	}
-	if _, ok := m.Objects[key]; !ok {
-		err := &googleapi.Error{
-			Code: http.StatusNotFound,
-			Message: fmt.Sprintf("{{.MockWrapType}} %v not found", key),
-		}
-		glog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
-		return err
+	sort.Strings(keys)
+	for _, k := range keys {
+		execTemplate(wr, "test_services.tmpl", allServicesByGroup[k])
 	}
+}
 
-	delete(m.Objects, key)
-	glog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = nil", ctx, key)
-	return nil
+// genUnitTestConverters generates a test that checks the conversions between
+// the API versions do not drop fields.
+func genUnitTestConverters(wr io.Writer) {
+	conversions, err := meta.Conversions(allServices)
+	if err != nil {
+		panic(err)
+	}
+	execTemplate(wr, "test_converters.tmpl", conversions)
 }
-{{- end}}
 
-{{- if .AggregatedList}}
-// AggregatedList is a mock for AggregatedList.
-func (m *{{.MockWrapType}}) AggregatedList(ctx context.Context, fl *filter.F) (map[string][]*{{.FQObjectType}}, error) {
-	if m.AggregatedListHook != nil {
-		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
-			glog.V(5).Infof("{{.MockWrapType}}.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
-			return objs, err
-		}
+// genUnitTestDeepCopies generates a test that checks the deep copies of the
+// objects copy every field and share no references with the original.
+func genUnitTestDeepCopies(wr io.Writer) {
+	copies, err := meta.DeepCopies(allServices)
+	if err != nil {
+		panic(err)
 	}
+	execTemplate(wr, "test_deepcopy.tmpl", copies)
+}
 
-	m.Lock.Lock()
-	defer m.Lock.Unlock()
+// genFuzzHeader generates the header for the fuzz tests.
+func genFuzzHeader(wr io.Writer) {
+	execTemplate(wr, "fuzz_header.tmpl", newHeaderData())
+}
 
-	if m.AggregatedListError != nil {
-		err := *m.AggregatedListError
-		glog.V(5).Infof("{{.MockWrapType}}.AggregatedList(%v, %v) = nil, %v", ctx, fl, err)
-		return nil, err
+// genFuzz generates the fuzz targets for ParseResourceURL and the typed keys
+// and mock CRUD paths of each service group.
+func genFuzz(wr io.Writer) {
+	var keys []string
+	for k := range allServicesByGroup {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	objs := map[string][]*{{.FQObjectType}}{}
-	for _, obj := range m.Objects {
-		res, err := ParseResourceURL(obj.To{{.VersionTitle}}().SelfLink)
-		{{- if .KeyIsRegional}}
-		location := res.Key.Region
-		{{- end -}}
-		{{- if .KeyIsZonal}}
-		location := res.Key.Zone
-		{{- end}}
-		if err != nil {
-			glog.V(5).Infof("{{.MockWrapType}}.AggregatedList(%v, %v) = nil, %v", ctx, fl, err)
-			return nil, err
-		}
-		if ! fl.Match(obj.To{{.VersionTitle}}()) {
-			continue
+	data := struct {
+		URLs   []string
+		Groups []*meta.ServiceGroup
+	}{
+		// Malformed URLs.
+		URLs: []string{
+			"",
+			"projects",
+			"projects/",
+			"projects//global//",
+			"projects/project/zones/zone/instances/name/extra",
+			"https://www.googleapis.com/compute/v1/",
+			"https://www.googleapis.com/compute/alpha/projects/project/regions",
+		},
+	}
+	// A valid URL for each service.
+	for _, k := range keys {
+		sg := allServicesByGroup[k]
+		s := sg.Versions()[0]
+		scope := "global"
+		switch {
+		case s.KeyIsRegional():
+			scope = "regions/region"
+		case s.KeyIsZonal():
+			scope = "zones/zone"
 		}
-		objs[location] = append(objs[location], obj.To{{.VersionTitle}}())
+		data.URLs = append(data.URLs, fmt.Sprintf("projects/project/%s/%s/name", scope, resourceName(s)))
+		data.Groups = append(data.Groups, sg)
 	}
-	glog.V(5).Infof("{{.MockWrapType}}.AggregatedList(%v, %v) = %+v, nil", ctx, fl, objs)
-	return objs, nil
+	execTemplate(wr, "fuzz.tmpl", data)
+}
+
+// genBenchHeader generates the header for the benchmarks.
+func genBenchHeader(wr io.Writer) {
+	execTemplate(wr, "bench_header.tmpl", newHeaderData())
 }
-{{- end}}
 
-{{with .Methods -}}
-{{- range .}}
-// {{.Name}} is a mock for the corresponding method.
-func (m *{{.MockWrapType}}) {{.FcnArgs}} {
-{{- if eq .ReturnType "Operation"}}
-	if m.{{.MockHookName}} != nil {
-		return m.{{.MockHookName}}(m, ctx, key {{.CallArgs}})
+// genBench generates the benchmarks of the mocks and the conversions
+// between API versions.
+func genBench(wr io.Writer) {
+	var keys []string
+	for k := range allServicesByGroup {
+		keys = append(keys, k)
 	}
-	return nil
-{{- else}}
-	if m.{{.MockHookName}} != nil {
-		return m.{{.MockHookName}}(m, ctx, key {{.CallArgs}})
-	}
-	return nil, fmt.Errorf("{{.MockHookName}} must be set")
-{{- end}}
-}
-{{end -}}
-{{- end}}
-// {{.GCEWrapType}} is a simplifying adapter for the GCE {{.Service}}.
-type {{.GCEWrapType}} struct {
-	s *Service
-}
-
-{{- if .GenerateGet}}
-// Get the {{.Object}} named by key.
-func (g *{{.GCEWrapType}}) Get(ctx context.Context, key meta.Key) (*{{.FQObjectType}}, error) {
-	projectID := g.s.ProjectRouter.ProjectID(ctx, "{{.Version}}", "{{.Service}}")
-	rk := &RateLimitKey{
-		ProjectID: projectID,
-		Operation: "Get",
-		Version: meta.Version("{{.Version}}"),
-		Service: "{{.Service}}",
-	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-		return nil, err
+	sort.Strings(keys)
+	for _, k := range keys {
+		execTemplate(wr, "bench.tmpl", allServicesByGroup[k])
 	}
-{{- if .KeyIsGlobal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Get(projectID, key.Name)
-{{- end -}}
-{{- if .KeyIsRegional}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Get(projectID, key.Region, key.Name)
-{{- end -}}
-{{- if .KeyIsZonal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Get(projectID, key.Zone, key.Name)
-{{- end}}
-	call.Context(ctx)
-	return call.Do()
-}
-{{- end}}
-
-{{- if .GenerateList}}
-// List all {{.Object}} objects.
-{{- if .KeyIsGlobal}}
-func (g *{{.GCEWrapType}}) List(ctx context.Context, fl *filter.F) ([]*{{.FQObjectType}}, error) {
-{{- end -}}
-{{- if .KeyIsRegional}}
-func (g *{{.GCEWrapType}}) List(ctx context.Context, region string, fl *filter.F) ([]*{{.FQObjectType}}, error) {
-{{- end -}}
-{{- if .KeyIsZonal}}
-func (g *{{.GCEWrapType}}) List(ctx context.Context, zone string, fl *filter.F) ([]*{{.FQObjectType}}, error) {
-{{- end}}
-projectID := g.s.ProjectRouter.ProjectID(ctx, "{{.Version}}", "{{.Service}}")
-rk := &RateLimitKey{
-		ProjectID: projectID,
-		Operation: "List",
-		Version: meta.Version("{{.Version}}"),
-		Service: "{{.Service}}",
-	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-		return nil, err
+
+	conversions, err := meta.Conversions(allServices)
+	if err != nil {
+		panic(err)
 	}
-{{- if .KeyIsGlobal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.List(projectID)
-{{- end -}}
-{{- if .KeyIsRegional}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.List(projectID, region)
-{{- end -}}
-{{- if .KeyIsZonal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.List(projectID, zone)
-{{- end}}
-	if fl != filter.None {
-		call.Filter(fl.String())
-	}
-	var all []*{{.FQObjectType}}
-	f := func(l *{{.ObjectListType}}) error {
-		all = append(all, l.Items...)
-		return nil
-	}
-	if err := call.Pages(ctx, f); err != nil {
-		return nil, err
+	execTemplate(wr, "bench_conversions.tmpl", conversions)
+
+	copies, err := meta.DeepCopies(allServices)
+	if err != nil {
+		panic(err)
 	}
-	return all, nil
+	execTemplate(wr, "bench_deepcopy.tmpl", copies)
 }
-{{- end}}
 
-{{- if .GenerateInsert}}
-// Insert {{.Object}} with key of value obj.
-func (g *{{.GCEWrapType}}) Insert(ctx context.Context, key meta.Key, obj *{{.FQObjectType}}) error {
-	projectID := g.s.ProjectRouter.ProjectID(ctx, "{{.Version}}", "{{.Service}}")
-	rk := &RateLimitKey{
-		ProjectID: projectID,
-		Operation: "Insert",
-		Version: meta.Version("{{.Version}}"),
-		Service: "{{.Service}}",
+// generate returns the generated content for mode.
+func generate(mode string) ([]byte, error) {
+	out := &bytes.Buffer{}
+
+	switch mode {
+	case "src":
+		genHeader(out)
+		genStubs(out)
+		genRegistry(out)
+		genTypes(out)
+		genKeys(out)
+		genVersioned(out)
+		genScoped(out)
+		genRegional(out)
+		genCached(out)
+		genDryRun(out)
+		genReadOnly(out)
+		genBreaker(out)
+		genRecording(out)
+		genReplay(out)
+		genConverters(out)
+		genDeepCopies(out)
+	case "interfaces":
+		genInterfacesHeader(out)
+		genInterfaces(out)
+	case "mock":
+		genMockHeader(out)
+		genMockStubs(out)
+		genMockTypes(out)
+	case "fuzz":
+		genFuzzHeader(out)
+		genFuzz(out)
+	case "bench":
+		genBenchHeader(out)
+		genBench(out)
+	case "gomock":
+		genGomockHeader(out)
+		genGomocks(out)
+	case "test":
+		genUnitTestHeader(out)
+		genUnitTestAssertions(out)
+		genUnitTestServices(out)
+		genUnitTestConverters(out)
+		genUnitTestDeepCopies(out)
+	default:
+		return nil, fmt.Errorf("invalid -mode: %q", mode)
 	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-		return err
+
+	if flags.gofmt {
+		return formatSource(out.Bytes())
 	}
-	obj.Name = key.Name
-{{- if .KeyIsGlobal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Insert(projectID, obj)
-{{- end -}}
-{{- if .KeyIsRegional}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Insert(projectID, key.Region, obj)
-{{- end -}}
-{{- if .KeyIsZonal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Insert(projectID, key.Zone, obj)
-{{- end}}
-	call.Context(ctx)
-
-	op, err := call.Do()
+	return out.Bytes(), nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory as
+// path and renames it to path once complete.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
 	if err != nil {
 		return err
 	}
-	return g.s.WaitForCompletion(ctx, op)
-}
-{{- end}}
+	defer os.Remove(f.Name())
 
-{{- if .GenerateDelete}}
-// Delete the {{.Object}} referenced by key.
-func (g *{{.GCEWrapType}}) Delete(ctx context.Context, key meta.Key) error {
-	projectID := g.s.ProjectRouter.ProjectID(ctx, "{{.Version}}", "{{.Service}}")
-	rk := &RateLimitKey{
-		ProjectID: projectID,
-		Operation: "Delete",
-		Version: meta.Version("{{.Version}}"),
-		Service: "{{.Service}}",
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
 	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
 		return err
 	}
-{{- if .KeyIsGlobal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Delete(projectID, key.Name)
-{{end -}}
-{{- if .KeyIsRegional}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Delete(projectID, key.Region, key.Name)
-{{- end -}}
-{{- if .KeyIsZonal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.Delete(projectID, key.Zone, key.Name)
-{{- end}}
-	call.Context(ctx)
-
-	op, err := call.Do()
-	if err != nil {
+	if err := f.Close(); err != nil {
 		return err
 	}
-	return g.s.WaitForCompletion(ctx, op)
+	return os.Rename(f.Name(), path)
 }
-{{end -}}
 
-{{- if .AggregatedList}}
-// AggregatedList lists all resources of the given type across all locations.
-func (g *{{.GCEWrapType}}) AggregatedList(ctx context.Context, fl *filter.F) (map[string][]*{{.FQObjectType}}, error) {
-	projectID := g.s.ProjectRouter.ProjectID(ctx, "{{.Version}}", "{{.Service}}")
-	rk := &RateLimitKey{
-		ProjectID: projectID,
-		Operation: "AggregatedList",
-		Version: meta.Version("{{.Version}}"),
-		Service: "{{.Service}}",
-	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-		return nil, err
-	}
-
-	call := g.s.{{.VersionTitle}}.{{.Service}}.AggregatedList(projectID)
-	call.Context(ctx)
-	if fl != filter.None {
-		call.Filter(fl.String())
-	}
+// target is a generated file.
+type target struct {
+	path    string
+	content []byte
+}
 
-	all := map[string][]*{{.FQObjectType}}{}
-	f := func(l *{{.ObjectAggregatedListType}}) error {
-		for k, v := range l.Items {
-			all[k] = append(all[k], v.{{.AggregatedListField}}...)
+// outputFile returns the file name for the given mode.
+func outputFile(mode string) string {
+	for _, o := range append(outputs, gomockOutput) {
+		if o.mode == mode {
+			return o.file
 		}
-		return nil
-	}
-	if err := call.Pages(ctx, f); err != nil {
-		return nil, err
 	}
-	return all, nil
+	return ""
 }
-{{- end}}
 
-{{- with .Methods -}}
-{{- range .}}
-// {{.Name}} is a method on {{.GCEWrapType}}.
-func (g *{{.GCEWrapType}}) {{.FcnArgs}} {
-	projectID := g.s.ProjectRouter.ProjectID(ctx, "{{.Version}}", "{{.Service}}")
-	rk := &RateLimitKey{
-		ProjectID: projectID,
-		Operation: "{{.Name}}",
-		Version: meta.Version("{{.Version}}"),
-		Service: "{{.Service}}",
+// copyrightRE matches the year in the license header. The year is ignored
+// when checking if a file is up to date.
+var copyrightRE = regexp.MustCompile(`(?m)^Copyright [0-9]{4} `)
+
+// checkFile compares the file at path with want. It returns a summary of the
+// differences or "" if the file is up to date.
+func checkFile(path string, want []byte) (string, error) {
+	got, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "does not exist", nil
 	}
-	if err := g.s.RateLimiter.Accept(ctx, rk); err != nil {
-	{{- if eq .ReturnType "Operation"}}
-		return err
-	{{- else}}
-		return nil, err
-	{{- end}}
-	}
-{{- if .KeyIsGlobal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.{{.Name}}(projectID, key.Name {{.CallArgs}})
-{{- end -}}
-{{- if .KeyIsRegional}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.{{.Name}}(projectID, key.Region, key.Name {{.CallArgs}})
-{{- end -}}
-{{- if .KeyIsZonal}}
-	call := g.s.{{.VersionTitle}}.{{.Service}}.{{.Name}}(projectID, key.Zone, key.Name {{.CallArgs}})
-{{- end}}
-	call.Context(ctx)
-{{- if eq .ReturnType "Operation"}}
-	op, err := call.Do()
 	if err != nil {
-		return err
+		return "", err
 	}
-	return g.s.WaitForCompletion(ctx, op)
-{{- else}}
-	return call.Do()
-{{- end}}
+	return diffSummary(got, want), nil
 }
-{{end -}}
-{{- end}}
-`
-	tmpl := template.Must(template.New("interface").Parse(text))
-	for _, s := range meta.AllServices {
-		if err := tmpl.Execute(wr, s); err != nil {
-			panic(err)
+
+// diffSummary returns a short description of the lines that differ between
+// got and want or "" if they are the same.
+func diffSummary(got, want []byte) string {
+	split := func(b []byte) []string {
+		return strings.Split(copyrightRE.ReplaceAllString(string(b), "Copyright YYYY "), "\n")
+	}
+	g, w := split(got), split(want)
+
+	// Trim the common prefix and suffix; what is left is the changed region.
+	start := 0
+	for start < len(g) && start < len(w) && g[start] == w[start] {
+		start++
+	}
+	if start == len(g) && start == len(w) {
+		return ""
+	}
+	gEnd, wEnd := len(g), len(w)
+	for gEnd > start && wEnd > start && g[gEnd-1] == w[wEnd-1] {
+		gEnd--
+		wEnd--
+	}
+
+	line := func(lines []string, i, end int) string {
+		if i >= end {
+			return "<none>"
 		}
+		return strings.TrimSpace(lines[i])
 	}
+	return fmt.Sprintf("differs starting at line %d (%d existing lines, %d generated lines):\n- %s\n+ %s",
+		start+1, gEnd-start, wEnd-start, line(g, start, gEnd), line(w, start, wEnd))
 }
 
 func main() {
 	flag.Parse()
 
-	out := &bytes.Buffer{}
+	if flags.out != "" && flags.dir != "" {
+		glog.Fatalf("-out and -dir cannot be used together")
+	}
 
-	switch flags.mode {
-	case "src":
-		genHeader(out)
-		genStubs(out)
-		genTypes(out)
-	case "test":
-		panic(fmt.Errorf("not implemented"))
+	var err error
+	if templates, err = loadTemplates(flags.templateDir); err != nil {
+		glog.Fatalf("Error loading templates: %v", err)
+	}
+
+	switch flags.services {
+	case "meta":
+		if flags.config == "" {
+			break
+		}
+		data, err := ioutil.ReadFile(flags.config)
+		if err != nil {
+			glog.Fatalf("Error reading -config: %v", err)
+		}
+		services, err := meta.ServicesFromConfig(data)
+		if err != nil {
+			glog.Fatalf("Error loading services from %q: %v", flags.config, err)
+		}
+		allServices = services
+		allServicesByGroup = meta.GroupServices(services)
+	case "discovery":
+		if flags.config != "" {
+			glog.Fatalf("-config cannot be used with -services=discovery")
+		}
+		var ok bool
+		if apiGroup, ok = meta.APIGroups[flags.apiGroup]; !ok {
+			glog.Fatalf("unknown -api-group: %q", flags.apiGroup)
+		}
+		services, err := loadDiscoveryServices()
+		if err != nil {
+			glog.Fatalf("Error loading services from the discovery documents: %v", err)
+		}
+		allServices = services
+		allServicesByGroup = meta.GroupServices(services)
 	default:
-		glog.Fatalf("Invalid -mode: %q", flags.mode)
+		glog.Fatalf("invalid -services: %q", flags.services)
 	}
 
-	if flags.gofmt {
-		fmt.Print(gofmtContent(out))
+	if flags.only != "" || flags.exclude != "" {
+		services, err := filterServices(allServices, splitList(flags.only), splitList(flags.exclude))
+		if err != nil {
+			glog.Fatalf("Error selecting services: %v", err)
+		}
+		allServices = services
+		allServicesByGroup = meta.GroupServices(services)
+	}
+	if apiGroup, err = servicesAPIGroup(allServices); err != nil {
+		glog.Fatalf("Error: %v", err)
+	}
+	if err := meta.Check(allServices); err != nil {
+		glog.Fatalf("Error: %v", err)
+	}
+	if flags.docs {
+		if err := loadDocs(allServices); err != nil {
+			glog.Fatalf("Error loading the docs from the discovery documents (use -docs=false to generate without them): %v", err)
+		}
+	}
+
+	// Generate everything before writing anything so that a failure leaves
+	// the existing files untouched.
+	var targets []target
+	if flags.dir != "" {
+		dirOutputs := outputs
+		if flags.gomock {
+			dirOutputs = append(dirOutputs, gomockOutput)
+		}
+		for _, o := range dirOutputs {
+			b, err := generate(o.mode)
+			if err != nil {
+				glog.Fatalf("Error generating %q: %v", o.file, err)
+			}
+			targets = append(targets, target{filepath.Join(flags.dir, o.file), b})
+		}
 	} else {
-		fmt.Print(out.String())
+		b, err := generate(flags.mode)
+		if err != nil {
+			glog.Fatal(err)
+		}
+		path := flags.out
+		if path == "" && flags.check {
+			path = outputFile(flags.mode)
+		}
+		if path == "" {
+			os.Stdout.Write(b)
+			return
+		}
+		targets = append(targets, target{path, b})
+	}
+
+	if flags.check {
+		upToDate := true
+		for _, t := range targets {
+			summary, err := checkFile(t.path, t.content)
+			if err != nil {
+				glog.Fatalf("Error checking %q: %v", t.path, err)
+			}
+			if summary != "" {
+				fmt.Fprintf(os.Stderr, "%s is out of date and must be regenerated; it %s\n", t.path, summary)
+				upToDate = false
+			}
+		}
+		if !upToDate {
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, t := range targets {
+		if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+			glog.Fatalf("Error creating the directory for %q: %v", t.path, err)
+		}
+		if err := writeFileAtomic(t.path, t.content); err != nil {
+			glog.Fatalf("Error writing %q: %v", t.path, err)
+		}
 	}
 }
@@ -0,0 +1,233 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestDiffSummary(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc      string
+		got, want string
+		wantDiff  string
+	}{
+		{"same", "a\nb\nc\n", "a\nb\nc\n", ""},
+		{"copyright year", "/*\nCopyright 2017 X\n*/\n", "/*\nCopyright 2018 X\n*/\n", ""},
+		{"changed line", "a\nb\nc\n", "a\nB\nc\n", "line 2 (1 existing lines, 1 generated lines)"},
+		{"added line", "a\nc\n", "a\nb\nc\n", "line 2 (0 existing lines, 1 generated lines)"},
+		{"removed lines", "a\nb\nb\nc\n", "a\nc\n", "line 2 (2 existing lines, 0 generated lines)"},
+	} {
+		got := diffSummary([]byte(tc.got), []byte(tc.want))
+		if tc.wantDiff == "" && got != "" || !strings.Contains(got, tc.wantDiff) {
+			t.Errorf("%s: diffSummary(%q, %q) = %q; want %q", tc.desc, tc.got, tc.want, got, tc.wantDiff)
+		}
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "gen")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = _, %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gen.go")
+	for _, content := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(content)); err != nil {
+			t.Fatalf("writeFileAtomic(%q, %q) = %v; want nil", path, content, err)
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil || string(b) != content {
+			t.Errorf("ioutil.ReadFile(%q) = %q, %v; want %q, nil", path, b, err, content)
+		}
+	}
+	// No temporary files are left behind.
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("ioutil.ReadDir(%q) = %d files; want 1", dir, len(files))
+	}
+
+	if err := writeFileAtomic(filepath.Join(dir, "missing", "gen.go"), nil); err == nil {
+		t.Errorf("writeFileAtomic() to a missing directory = nil; want error")
+	}
+}
+
+func TestFilterServices(t *testing.T) {
+	t.Parallel()
+
+	services := []*meta.ServiceInfo{
+		{Service: "Addresses"},
+		{Service: "Addresses"},
+		{Service: "Firewalls"},
+		{Service: "Zones"},
+	}
+	names := func(l []*meta.ServiceInfo) []string {
+		var ret []string
+		for _, s := range l {
+			ret = append(ret, s.Service)
+		}
+		return ret
+	}
+
+	for _, tc := range []struct {
+		desc          string
+		only, exclude string
+		want          []string
+		wantErr       bool
+	}{
+		{desc: "all", want: []string{"Addresses", "Addresses", "Firewalls", "Zones"}},
+		{desc: "only", only: "Zones, Addresses", want: []string{"Addresses", "Addresses", "Zones"}},
+		{desc: "exclude", exclude: "Addresses", want: []string{"Firewalls", "Zones"}},
+		{desc: "only and exclude", only: "Addresses,Zones", exclude: "Addresses", want: []string{"Zones"}},
+		{desc: "only keeps required", only: "Addresses", want: []string{"Addresses", "Addresses", "Zones"}},
+		{desc: "exclude required", exclude: "Zones", wantErr: true},
+		{desc: "unknown only", only: "Addresss", wantErr: true},
+		{desc: "unknown exclude", exclude: "firewalls", wantErr: true},
+	} {
+		got, err := filterServices(services, splitList(tc.only), splitList(tc.exclude))
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: filterServices(_, %q, %q) = _, %v; gotErr = %t, want %t", tc.desc, tc.only, tc.exclude, err, gotErr, tc.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(names(got), tc.want) {
+			t.Errorf("%s: filterServices(_, %q, %q) = %v, _; want %v", tc.desc, tc.only, tc.exclude, names(got), tc.want)
+		}
+	}
+}
+
+// TestOnlyBuilds generates the package with -only in a copy of the
+// repository and builds it.
+func TestOnlyBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates and builds the package")
+	}
+	t.Parallel()
+
+	root, err := filepath.Abs(filepath.Join("..", "..", ".."))
+	if err != nil {
+		t.Fatalf("filepath.Abs() = _, %v", err)
+	}
+	gopath, err := ioutil.TempDir("", "gen")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = _, %v", err)
+	}
+	defer os.RemoveAll(gopath)
+
+	repo := filepath.Join(gopath, "src", filepath.Dir(filepath.Dir(packageRoot)))
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("os.MkdirAll(%q) = %v", repo, err)
+	}
+	if err := os.Symlink(filepath.Join(root, "vendor"), filepath.Join(repo, "vendor")); err != nil {
+		t.Fatalf("os.Symlink() = %v", err)
+	}
+	pkg := filepath.Join(gopath, "src", packageRoot)
+	err = filepath.Walk(filepath.Join(root, "pkg", "cloud"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filepath.Join(root, "pkg", "cloud"), path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(pkg, rel), 0755)
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(pkg, rel), b, 0644)
+	})
+	if err != nil {
+		t.Fatalf("copying the package to %q: %v", pkg, err)
+	}
+
+	for _, args := range [][]string{
+		{"run", "./gen", "-dir", ".", "-only", "Firewalls,Addresses", "-docs=false"},
+		{"build", "./..."},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = pkg
+		cmd.Env = append(os.Environ(), "GOPATH="+gopath, "GO111MODULE=off")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %s = %v; output:\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+func TestFormatSource(t *testing.T) {
+	t.Parallel()
+
+	got, err := formatSource([]byte("package p\nfunc f( ) {\nreturn}\n"))
+	if want := "package p\n\nfunc f() {\n\treturn\n}\n"; err != nil || string(got) != want {
+		t.Errorf("formatSource() = %q, %v; want %q, nil", got, err, want)
+	}
+	if _, err := formatSource([]byte("package p\nfunc {")); err == nil {
+		t.Errorf("formatSource(invalid) = _, nil; want error")
+	}
+}
+
+func TestComment(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("word ", 20)
+	for _, tc := range []struct {
+		indent, text string
+		want         string
+	}{
+		{"", "", ""},
+		{"\t", "Gets a thing.", "\n\t// Gets a thing."},
+		{"", "First.\n\nSecond.", "\n// First.\n//\n// Second."},
+		{"", long, "\n//" + strings.Repeat(" word", 15) + "\n//" + strings.Repeat(" word", 5)},
+	} {
+		if got := comment(tc.indent, tc.text); got != tc.want {
+			t.Errorf("comment(%q, %q) = %q; want %q", tc.indent, tc.text, got, tc.want)
+		}
+	}
+	if got, want := commentParagraph("", "More."), "\n//\n// More."; got != want {
+		t.Errorf("commentParagraph(\"\", \"More.\") = %q; want %q", got, want)
+	}
+}
+
+func TestWithDeprecation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		doc, notice string
+		want        string
+	}{
+		{"A thing.", "", "\n// A thing."},
+		{"A thing.", "Use other.", "\n// A thing.\n//\n// Deprecated: Use other."},
+		{"", "Use other.", "\n// Deprecated: Use other."},
+	} {
+		if got := comment("", withDeprecation(tc.doc, tc.notice)); got != tc.want {
+			t.Errorf("comment(\"\", withDeprecation(%q, %q)) = %q; want %q", tc.doc, tc.notice, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// HealthReport is the result of Service.HealthCheck().
+type HealthReport struct {
+	// Versions are the API versions checked, in the order of
+	// meta.AllVersions.
+	Versions []*VersionHealth
+}
+
+// VersionHealth is the result of the check of an API version.
+type VersionHealth struct {
+	Version meta.Version
+	// ProjectID is the project read by the check (see ProjectRouter).
+	ProjectID string
+	// Err is nil if the version is usable.
+	Err error
+	// Hint is an actionable description of Err, e.g. "enable the Compute
+	// Engine API (compute.googleapis.com) in project p".
+	Hint string
+}
+
+// Usable is true if version was checked successfully.
+func (r *HealthReport) Usable(version meta.Version) bool {
+	for _, v := range r.Versions {
+		if v.Version == version {
+			return v.Err == nil
+		}
+	}
+	return false
+}
+
+// Err returns the errors of the versions that are not usable, with their
+// Hint, or nil if all of them are.
+func (r *HealthReport) Err() error {
+	var errs []error
+	for _, v := range r.Versions {
+		if v.Err == nil {
+			continue
+		}
+		if v.Hint == "" {
+			errs = append(errs, fmt.Errorf("API version %q: %w", v.Version, v.Err))
+			continue
+		}
+		errs = append(errs, fmt.Errorf("API version %q: %s: %w", v.Version, v.Hint, v.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// HealthCheck validates the credentials, scopes and connectivity of each API
+// version with a client (given or constructed by NewGA, NewAlpha or NewBeta)
+// by reading the project the calls are routed to, a single cheap call per
+// version. Controllers call it at startup to fail fast with an actionable
+// error instead of failing later with a 401 or a 403:
+//
+//	if err := s.HealthCheck(ctx).Err(); err != nil {
+//		glog.Exitf("Compute API unusable: %v", err)
+//	}
+func (g *Service) HealthCheck(ctx context.Context) *HealthReport {
+	r := &HealthReport{}
+	for _, version := range g.clientVersions() {
+		v := &VersionHealth{
+			Version:   version,
+			ProjectID: g.ProjectRouter.ProjectID(ctx, version, "Projects"),
+		}
+		rk := &RateLimitKey{
+			ProjectID: v.ProjectID,
+			Operation: "Get",
+			Version:   version,
+			Service:   "Projects",
+		}
+		if err := g.RateLimiter.Accept(ctx, rk); err != nil {
+			v.Err = err
+		} else {
+			v.Err = wrapError(rk, nil, g.getProject(ctx, version, v.ProjectID))
+		}
+		v.Hint = healthHint(v)
+		r.Versions = append(r.Versions, v)
+	}
+	return r
+}
+
+// clientVersions returns the API versions with a client, given or
+// constructed on first use.
+func (g *Service) clientVersions() []meta.Version {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var ret []meta.Version
+	if g.GA != nil || g.NewGA != nil {
+		ret = append(ret, meta.VersionGA)
+	}
+	if g.Alpha != nil || g.NewAlpha != nil {
+		ret = append(ret, meta.VersionAlpha)
+	}
+	if g.Beta != nil || g.NewBeta != nil {
+		ret = append(ret, meta.VersionBeta)
+	}
+	return ret
+}
+
+// getProject reads the name of the project with the client of version.
+func (g *Service) getProject(ctx context.Context, version meta.Version, projectID string) error {
+	switch version {
+	case meta.VersionAlpha:
+		svc, err := g.alphaService("")
+		if err != nil {
+			return err
+		}
+		_, err = do(g, svc.Projects.Get(projectID).Context(ctx), Fields("name"))
+		return err
+	case meta.VersionBeta:
+		svc, err := g.betaService("")
+		if err != nil {
+			return err
+		}
+		_, err = do(g, svc.Projects.Get(projectID).Context(ctx), Fields("name"))
+		return err
+	default:
+		svc, err := g.gaService("")
+		if err != nil {
+			return err
+		}
+		_, err = do(g, svc.Projects.Get(projectID).Context(ctx), Fields("name"))
+		return err
+	}
+}
+
+// healthHint returns the Hint for the error of v.
+func healthHint(v *VersionHealth) string {
+	apiErr, ok := apiError(v.Err)
+	switch {
+	case v.Err == nil:
+		return ""
+	case !ok:
+		return "check the client configuration and the connectivity to the endpoint"
+	case apiErr.Code == http.StatusUnauthorized:
+		return "the credentials are invalid or expired"
+	case apiErr.Code == http.StatusNotFound:
+		return fmt.Sprintf("project %q does not exist", v.ProjectID)
+	case apiErr.Code != http.StatusForbidden || IsQuotaExceeded(v.Err):
+		return ""
+	case hasReason(apiErr, "accessNotConfigured"):
+		return fmt.Sprintf("enable the Compute Engine API (compute.googleapis.com) in project %q", v.ProjectID)
+	case strings.Contains(apiErr.Message, "scopes"):
+		return "request the compute scopes of the services or the cloud-platform scope for the credentials (see meta.RequiredScopes())"
+	}
+	return fmt.Sprintf("grant the compute.projects.get permission on project %q to the credentials", v.ProjectID)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	apiError := func(code int, reason, message string) string {
+		return fmt.Sprintf(`{"error": {"code": %d, "message": %q, "errors": [{"reason": %q, "message": %q}]}}`, code, message, reason, message)
+	}
+	for _, tc := range []struct {
+		desc     string
+		code     int
+		body     string
+		wantHint string
+	}{
+		{desc: "usable", code: http.StatusOK, body: `{"name": "proj"}`},
+		{
+			desc:     "unauthenticated",
+			code:     http.StatusUnauthorized,
+			body:     apiError(http.StatusUnauthorized, "authError", "Invalid Credentials"),
+			wantHint: "the credentials are invalid or expired",
+		},
+		{
+			desc:     "API disabled",
+			code:     http.StatusForbidden,
+			body:     apiError(http.StatusForbidden, "accessNotConfigured", "Compute Engine API has not been used in project proj"),
+			wantHint: `enable the Compute Engine API (compute.googleapis.com) in project "proj"`,
+		},
+		{
+			desc:     "missing scope",
+			code:     http.StatusForbidden,
+			body:     apiError(http.StatusForbidden, "insufficientPermissions", "Request had insufficient authentication scopes."),
+			wantHint: "request the compute scopes of the services or the cloud-platform scope for the credentials (see meta.RequiredScopes())",
+		},
+		{
+			desc:     "missing permission",
+			code:     http.StatusForbidden,
+			body:     apiError(http.StatusForbidden, "forbidden", "Required 'compute.projects.get' permission for 'projects/proj'"),
+			wantHint: `grant the compute.projects.get permission on project "proj" to the credentials`,
+		},
+		{
+			desc:     "rate limited",
+			code:     http.StatusForbidden,
+			body:     apiError(http.StatusForbidden, "rateLimitExceeded", "Rate Limit Exceeded"),
+			wantHint: "",
+		},
+		{
+			desc:     "no project",
+			code:     http.StatusNotFound,
+			body:     apiError(http.StatusNotFound, "notFound", "The resource 'projects/proj' was not found"),
+			wantHint: `project "proj" does not exist`,
+		},
+	} {
+		var paths []string
+		s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tc.code)
+			fmt.Fprint(w, tc.body)
+		})
+		r := s.HealthCheck(context.Background())
+		if len(r.Versions) != 1 {
+			t.Fatalf("%s: HealthCheck() = %+v; want the ga version only", tc.desc, r.Versions)
+		}
+		v := r.Versions[0]
+		if v.Version != meta.VersionGA || v.ProjectID != "proj" {
+			t.Errorf("%s: Version, ProjectID = %q, %q; want ga, proj", tc.desc, v.Version, v.ProjectID)
+		}
+		if len(paths) != 1 || !strings.HasPrefix(paths[0], "/compute/v1/projects/proj?") || !strings.Contains(paths[0], "fields=name") {
+			t.Errorf("%s: calls = %v; want a Get of projects/proj with fields=name", tc.desc, paths)
+		}
+		if usable := tc.code == http.StatusOK; r.Usable(meta.VersionGA) != usable || (r.Err() == nil) != usable {
+			t.Errorf("%s: Usable(ga), Err() = %t, %v; want %t", tc.desc, r.Usable(meta.VersionGA), r.Err(), usable)
+		}
+		if v.Hint != tc.wantHint {
+			t.Errorf("%s: Hint = %q; want %q", tc.desc, v.Hint, tc.wantHint)
+		}
+		if err := r.Err(); err != nil && !strings.Contains(err.Error(), tc.wantHint) {
+			t.Errorf("%s: Err() = %v; want the hint", tc.desc, err)
+		}
+	}
+}
+
+func TestHealthCheckVersions(t *testing.T) {
+	t.Parallel()
+
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, &ga.Project{Name: "proj"})
+	})
+	errClient := errors.New("no credentials")
+	s.NewAlpha = func() (*alpha.Service, error) { return nil, errClient }
+
+	r := s.HealthCheck(context.Background())
+	if len(r.Versions) != 2 || r.Versions[0].Version != meta.VersionGA || r.Versions[1].Version != meta.VersionAlpha {
+		t.Fatalf("HealthCheck() = %+v; want ga and alpha", r.Versions)
+	}
+	if !r.Usable(meta.VersionGA) || r.Usable(meta.VersionAlpha) || r.Usable(meta.VersionBeta) {
+		t.Errorf("Usable(ga, alpha, beta) = %t, %t, %t; want true, false, false", r.Usable(meta.VersionGA), r.Usable(meta.VersionAlpha), r.Usable(meta.VersionBeta))
+	}
+	if err := r.Err(); err == nil || !strings.Contains(err.Error(), `API version "alpha"`) || !strings.Contains(err.Error(), errClient.Error()) {
+		t.Errorf("Err() = %v; want the error of alpha", err)
+	}
+}
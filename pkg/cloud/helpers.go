@@ -0,0 +1,176 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/golang/glog"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// The Is* helpers below classify the errors returned by the generated
+// services. They look for a *googleapi.Error in the chain of err with
+// errors.As(), so they also apply to errors wrapped with fmt.Errorf("%w") and
+// to the errors of the mocks. For a *BatchError, they are true if any of the
+// failed calls has such an error.
+
+// IsNotFound is true if err is a googleapi.Error with the status code
+// http.StatusNotFound.
+func IsNotFound(err error) bool {
+	return isHTTPErrorCode(err, http.StatusNotFound)
+}
+
+// IsConflict is true if err is a googleapi.Error with the status code
+// http.StatusConflict, which is returned when inserting an object that
+// already exists.
+func IsConflict(err error) bool {
+	return isHTTPErrorCode(err, http.StatusConflict)
+}
+
+// IsForbidden is true if err is a googleapi.Error with the status code
+// http.StatusForbidden that is not a quota error (see IsQuotaExceeded), e.g.
+// when the caller lacks a permission.
+func IsForbidden(err error) bool {
+	return isHTTPErrorCode(err, http.StatusForbidden) && !IsQuotaExceeded(err)
+}
+
+// IsPreconditionFailed is true if err is a googleapi.Error with the status
+// code http.StatusPreconditionFailed, which is returned when the fingerprint
+// sent with a modification is not the one of the current object. The object
+// should be read again before retrying.
+func IsPreconditionFailed(err error) bool {
+	return isHTTPErrorCode(err, http.StatusPreconditionFailed)
+}
+
+// IsBadRequest is true if err is a googleapi.Error with the status code
+// http.StatusBadRequest, which is returned for an invalid request (e.g. an
+// Insert of an object without one of its required fields).
+func IsBadRequest(err error) bool {
+	return isHTTPErrorCode(err, http.StatusBadRequest)
+}
+
+// IsQuotaExceeded is true if err is a googleapi.Error reporting that a rate
+// limit or a quota was exceeded: the status code http.StatusTooManyRequests or
+// an error with the reason "rateLimitExceeded", "userRateLimitExceeded" or
+// "quotaExceeded". The call can be retried after backing off (see
+// AdaptiveRateLimiter).
+func IsQuotaExceeded(err error) bool {
+	apiErr, ok := apiError(err)
+	if !ok {
+		return false
+	}
+	return apiErr.Code == http.StatusTooManyRequests || hasReason(apiErr, "rateLimitExceeded", "userRateLimitExceeded", "quotaExceeded")
+}
+
+// IsOperationInProgress is true if err is a googleapi.Error reporting that
+// the resource is being modified by another operation (the reason
+// "resourceNotReady"). The call can be retried once the operation is done.
+func IsOperationInProgress(err error) bool {
+	apiErr, ok := apiError(err)
+	return ok && hasReason(apiErr, "resourceNotReady")
+}
+
+// apiError returns the *googleapi.Error in the chain of err.
+func apiError(err error) (*googleapi.Error, bool) {
+	var apiErr *googleapi.Error
+	if err == nil || !errors.As(err, &apiErr) {
+		return nil, false
+	}
+	return apiErr, true
+}
+
+// hasReason is true if one of the items of apiErr has one of reasons.
+func hasReason(apiErr *googleapi.Error, reasons ...string) bool {
+	for _, item := range apiErr.Errors {
+		for _, r := range reasons {
+			if item.Reason == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isHTTPErrorCode(err error, code int) bool {
+	apiErr, ok := apiError(err)
+	return ok && apiErr.Code == code
+}
+
+// Exists returns true if get returns the object for key and false if it is
+// not found. Other errors are returned as-is. This implements the generated
+// Exists() methods.
+func Exists[T any](ctx context.Context, key meta.Key, get func(context.Context, meta.Key, ...Option) (*T, error)) (bool, error) {
+	_, err := get(ctx, key)
+	switch {
+	case err == nil:
+		return true, nil
+	case IsNotFound(err):
+		return false, nil
+	}
+	return false, err
+}
+
+// GetOrCreate returns the object for key, inserting desired if it does not
+// exist. If the insert conflicts with a concurrent creation of the object,
+// the object is fetched again. This implements the generated GetOrCreate()
+// methods.
+func GetOrCreate[T any](ctx context.Context, key meta.Key, desired *T, get func(context.Context, meta.Key, ...Option) (*T, error), insert func(context.Context, meta.Key, *T, ...Option) error) (*T, error) {
+	obj, err := get(ctx, key)
+	if err == nil || !IsNotFound(err) {
+		return obj, err
+	}
+	if err := insert(ctx, key, desired); err != nil && !IsConflict(err) {
+		return nil, err
+	}
+	return get(ctx, key)
+}
+
+// MaxUpdateAttempts is the number of times UpdateWithRetry() reads and
+// updates an object before giving up on the fingerprint conflicts.
+const MaxUpdateAttempts = 5
+
+// UpdateWithRetry reads the object for key with get, modifies it with update
+// and writes it back with put. As the object carries the fingerprint it was
+// read with, put fails with http.StatusPreconditionFailed if the object was
+// modified concurrently; the object is then read and updated again, up to
+// MaxUpdateAttempts times. An error returned by update aborts the update and
+// is returned as-is. This implements the generated UpdateWithRetry() methods.
+func UpdateWithRetry[T any](ctx context.Context, key meta.Key, update func(*T) error, get func(context.Context, meta.Key, ...Option) (*T, error), put func(context.Context, meta.Key, *T, ...Option) error) error {
+	var err error
+	for attempt := 1; attempt <= MaxUpdateAttempts; attempt++ {
+		var obj *T
+		if obj, err = get(ctx, key); err != nil {
+			return err
+		}
+		if err := update(obj); err != nil {
+			return err
+		}
+		if err = put(ctx, key, obj); !IsPreconditionFailed(err) {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		glog.V(4).Infof("Retrying the update of %s after attempt %d: %v", key, attempt, err)
+	}
+	return err
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+type testObj struct {
+	Name string
+}
+
+// testStore is a map of objects with the signatures of the generated Get()
+// and Insert().
+type testStore struct {
+	objs      map[meta.Key]*testObj
+	getErr    error
+	insertErr error
+	inserts   int
+	// concurrent, if set, is created by someone else when insert is
+	// called, which then fails with a conflict.
+	concurrent *testObj
+}
+
+func (s *testStore) get(ctx context.Context, key meta.Key, _ ...Option) (*testObj, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	if obj, ok := s.objs[key]; ok {
+		return obj, nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func (s *testStore) insert(ctx context.Context, key meta.Key, obj *testObj, _ ...Option) error {
+	s.inserts++
+	if s.concurrent != nil {
+		s.objs[key] = s.concurrent
+		return &googleapi.Error{Code: http.StatusConflict}
+	}
+	if s.insertErr != nil {
+		return s.insertErr
+	}
+	s.objs[key] = obj
+	return nil
+}
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("error"), false},
+		{&googleapi.Error{Code: http.StatusNotFound}, true},
+		{&googleapi.Error{Code: http.StatusConflict}, false},
+		{fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusNotFound}), true},
+		{&BatchError{Errors: []error{nil, &googleapi.Error{Code: http.StatusNotFound}}}, true},
+		{&BatchError{Errors: []error{errors.New("error")}}, false},
+	} {
+		if got := IsNotFound(tc.err); got != tc.want {
+			t.Errorf("IsNotFound(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("error"), false},
+		{&googleapi.Error{Code: http.StatusConflict}, true},
+		{&googleapi.Error{Code: http.StatusNotFound}, false},
+		{fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusConflict}), true},
+	} {
+		if got := IsConflict(tc.err); got != tc.want {
+			t.Errorf("IsConflict(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsForbidden(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("error"), false},
+		{&googleapi.Error{Code: http.StatusForbidden}, true},
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}, true},
+		// A quota error is not a missing permission.
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, false},
+		{&googleapi.Error{Code: http.StatusNotFound}, false},
+		{fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusForbidden}), true},
+	} {
+		if got := IsForbidden(tc.err); got != tc.want {
+			t.Errorf("IsForbidden(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsOperationInProgress(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("resourceNotReady"), false},
+		{&googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "resourceNotReady"}}}, true},
+		{&googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}}, false},
+		{fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "resourceNotReady"}}}), true},
+	} {
+		if got := IsOperationInProgress(tc.err); got != tc.want {
+			t.Errorf("IsOperationInProgress(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsPreconditionFailed(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("error"), false},
+		{&googleapi.Error{Code: http.StatusPreconditionFailed}, true},
+		{&googleapi.Error{Code: http.StatusConflict}, false},
+	} {
+		if got := IsPreconditionFailed(tc.err); got != tc.want {
+			t.Errorf("IsPreconditionFailed(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsBadRequest(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("error"), false},
+		{&googleapi.Error{Code: http.StatusBadRequest}, true},
+		{&googleapi.Error{Code: http.StatusNotFound}, false},
+	} {
+		if got := IsBadRequest(tc.err); got != tc.want {
+			t.Errorf("IsBadRequest(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsQuotaExceeded(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("rateLimitExceeded"), false},
+		{&googleapi.Error{Code: http.StatusTooManyRequests}, true},
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, true},
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}}}, true},
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}}, true},
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}, false},
+		{&googleapi.Error{Code: http.StatusNotFound}, false},
+		{fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusTooManyRequests}), true},
+	} {
+		if got := IsQuotaExceeded(tc.err); got != tc.want {
+			t.Errorf("IsQuotaExceeded(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestExists(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := *meta.GlobalKey("obj")
+	s := &testStore{objs: map[meta.Key]*testObj{}}
+
+	if got, err := Exists(ctx, key, s.get); got || err != nil {
+		t.Errorf("Exists(_, %v, _) = %t, %v; want false, nil", key, got, err)
+	}
+	s.objs[key] = &testObj{Name: "obj"}
+	if got, err := Exists(ctx, key, s.get); !got || err != nil {
+		t.Errorf("Exists(_, %v, _) = %t, %v; want true, nil", key, got, err)
+	}
+	s.getErr = errors.New("injected")
+	if got, err := Exists(ctx, key, s.get); got || err != s.getErr {
+		t.Errorf("Exists(_, %v, _) = %t, %v; want false, %v", key, got, err, s.getErr)
+	}
+}
+
+func TestGetOrCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := *meta.GlobalKey("obj")
+	existing := &testObj{Name: "existing"}
+	desired := &testObj{Name: "desired"}
+	injected := errors.New("injected")
+
+	for _, tc := range []struct {
+		desc        string
+		existing    bool
+		concurrent  bool
+		getErr      error
+		insertErr   error
+		want        *testObj
+		wantInserts int
+		wantErr     bool
+	}{
+		{desc: "exists", existing: true, want: existing},
+		{desc: "created", want: desired, wantInserts: 1},
+		{desc: "get error", getErr: injected, wantErr: true},
+		{desc: "insert error", insertErr: injected, wantInserts: 1, wantErr: true},
+		{desc: "created concurrently", concurrent: true, want: existing, wantInserts: 1},
+	} {
+		s := &testStore{objs: map[meta.Key]*testObj{}, getErr: tc.getErr, insertErr: tc.insertErr}
+		if tc.existing {
+			s.objs[key] = existing
+		}
+		if tc.concurrent {
+			s.concurrent = existing
+		}
+		got, err := GetOrCreate(ctx, key, desired, s.get, s.insert)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: GetOrCreate() = _, %v; gotErr = %t, want %t", tc.desc, err, gotErr, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("%s: GetOrCreate() = %s, _; want %s", tc.desc, fmt.Sprint(got), fmt.Sprint(tc.want))
+		}
+		if s.inserts != tc.wantInserts {
+			t.Errorf("%s: GetOrCreate() inserted %d times; want %d", tc.desc, s.inserts, tc.wantInserts)
+		}
+	}
+}
+
+func TestUpdateWithRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := *meta.GlobalKey("obj")
+	injected := errors.New("injected")
+
+	type fpObj struct {
+		Name        string
+		Fingerprint string
+	}
+	for _, tc := range []struct {
+		desc      string
+		conflicts int
+		updateErr error
+		wantPuts  int
+		wantErr   bool
+	}{
+		{desc: "no conflict", wantPuts: 1},
+		{desc: "conflicts", conflicts: 2, wantPuts: 3},
+		{desc: "too many conflicts", conflicts: MaxUpdateAttempts, wantPuts: MaxUpdateAttempts, wantErr: true},
+		{desc: "update error", updateErr: injected, wantErr: true},
+	} {
+		current := fpObj{Name: "v0", Fingerprint: "0"}
+		puts := 0
+		get := func(context.Context, meta.Key, ...Option) (*fpObj, error) {
+			obj := current
+			return &obj, nil
+		}
+		put := func(ctx context.Context, key meta.Key, obj *fpObj, _ ...Option) error {
+			puts++
+			if puts <= tc.conflicts {
+				// Modified concurrently after obj was read.
+				current.Fingerprint += "x"
+			}
+			if obj.Fingerprint != current.Fingerprint {
+				return &googleapi.Error{Code: http.StatusPreconditionFailed}
+			}
+			current = *obj
+			current.Fingerprint += "+"
+			return nil
+		}
+		update := func(obj *fpObj) error {
+			obj.Name = "v1"
+			return tc.updateErr
+		}
+		err := UpdateWithRetry(ctx, key, update, get, put)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: UpdateWithRetry() = %v; gotErr = %t, want %t", tc.desc, err, gotErr, tc.wantErr)
+		}
+		if tc.updateErr != nil && err != tc.updateErr {
+			t.Errorf("%s: UpdateWithRetry() = %v; want %v", tc.desc, err, tc.updateErr)
+		}
+		if puts != tc.wantPuts {
+			t.Errorf("%s: UpdateWithRetry() updated %d times; want %d", tc.desc, puts, tc.wantPuts)
+		}
+		if wantName := map[bool]string{true: "v0", false: "v1"}[tc.wantErr]; current.Name != wantName {
+			t.Errorf("%s: object name = %q; want %q", tc.desc, current.Name, wantName)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// CallInfo describes a call made by the GCE adapters to the Interceptors.
+type CallInfo struct {
+	// ProjectID the call is made in.
+	ProjectID string
+	// Version of the API used.
+	Version meta.Version
+	// Service is the service called (e.g. "Firewalls").
+	Service string
+	// Operation is the method invoked (e.g. "Get", "Insert", "SetTarget").
+	Operation string
+	// Key of the object, nil for the calls on a collection (e.g. List).
+	Key *meta.Key
+	// Request is the request payload of a mutation (e.g. the object
+	// inserted), if any.
+	Request interface{}
+	// Response is the result of a call that is not a mutation (e.g. the
+	// object returned by Get), once it succeeded. It is only set for After.
+	Response interface{}
+	// Header are the headers added to the HTTP requests of the call, e.g. by
+	// Before to stamp the calls with an audit tag. It is nil until a Before
+	// sets it.
+	Header http.Header
+}
+
+// Interceptor is called around every call made by the GCE adapters when
+// registered in Service.Interceptors, so that auditing, fault injection or
+// header stamping is layered on all of the generated methods without
+// wrapping each interface. It is called once per call, not per retry; for a
+// mutation, the call includes waiting for the operation.
+type Interceptor struct {
+	// Before, if set, is called before the call. If it returns an error,
+	// the call fails with it without being made.
+	Before func(ctx context.Context, call *CallInfo) error
+	// After, if set, is called after the call, with its error, if the
+	// Before of the interceptor was called and succeeded.
+	After func(ctx context.Context, call *CallInfo, err error)
+}
+
+// beforeCall calls the Before of the Interceptors in order until one fails.
+// It returns the number of Interceptors whose Before succeeded and the
+// context of the call, which carries the Header of call.
+func (g *Service) beforeCall(ctx context.Context, call *CallInfo) (context.Context, int, error) {
+	for i, ic := range g.Interceptors {
+		if ic.Before == nil {
+			continue
+		}
+		if err := ic.Before(ctx, call); err != nil {
+			return ctx, i, err
+		}
+	}
+	if len(call.Header) > 0 {
+		ctx = context.WithValue(ctx, callHeaderKey{}, call.Header)
+	}
+	return ctx, len(g.Interceptors), nil
+}
+
+// afterCall calls the After of the first n Interceptors in reverse order.
+func (g *Service) afterCall(ctx context.Context, call *CallInfo, n int, err error) {
+	for i := n - 1; i >= 0; i-- {
+		if after := g.Interceptors[i].After; after != nil {
+			after(ctx, call, err)
+		}
+	}
+}
+
+// newCallInfo returns the CallInfo of the call described by rk on the object
+// referenced by key with the payload req.
+func newCallInfo(rk *RateLimitKey, key *meta.Key, req interface{}) *CallInfo {
+	return &CallInfo{
+		ProjectID: rk.ProjectID,
+		Version:   rk.Version,
+		Service:   rk.Service,
+		Operation: rk.Operation,
+		Key:       key,
+		Request:   req,
+	}
+}
+
+// callHeaderKey is the context key of the headers set by the Interceptors.
+type callHeaderKey struct{}
+
+// headerOption is the CallOption carrying the headers set by the
+// Interceptors to do(), which sets them on the call instead of passing it to
+// the client (see Service.callOptions()).
+type headerOption http.Header
+
+func (headerOption) Get() (string, string) { return "", "" }
+
+// headerOptions returns the CallOptions carrying the headers of the call of
+// ctx, if any.
+func headerOptions(ctx context.Context, opts []googleapi.CallOption) []googleapi.CallOption {
+	h, ok := ctx.Value(callHeaderKey{}).(http.Header)
+	if !ok {
+		return opts
+	}
+	return append(opts, headerOption(h))
+}
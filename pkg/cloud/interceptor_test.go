@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lock    sync.Mutex
+		headers []string
+	)
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		headers = append(headers, r.Header.Get("X-Audit"))
+		lock.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path == "/compute/v1/projects/proj/global/operations/op" {
+				writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+				return
+			}
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		default:
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE", SelfLink: "projects/proj/global/operations/op"})
+		}
+	})
+
+	var calls []string
+	record := func(name string) Interceptor {
+		return Interceptor{
+			Before: func(ctx context.Context, call *CallInfo) error {
+				calls = append(calls, name+" before "+call.Operation)
+				return nil
+			},
+			After: func(ctx context.Context, call *CallInfo, err error) {
+				calls = append(calls, name+" after "+call.Operation)
+			},
+		}
+	}
+	var infos []CallInfo
+	s.Interceptors = []Interceptor{
+		record("first"),
+		{
+			Before: func(ctx context.Context, call *CallInfo) error {
+				call.Header = http.Header{"X-Audit": {"tag"}}
+				return nil
+			},
+			After: func(ctx context.Context, call *CallInfo, err error) {
+				infos = append(infos, *call)
+			},
+		},
+		record("last"),
+	}
+
+	ctx := context.Background()
+	gce := NewGCE(s)
+	key := meta.GlobalKey("fw")
+	if _, err := gce.Firewalls().Get(ctx, *key); err != nil {
+		t.Fatalf("Firewalls().Get(%v) = _, %v; want nil", key, err)
+	}
+	fw := &ga.Firewall{}
+	if err := gce.Firewalls().Insert(ctx, *key, fw); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+
+	want := []string{
+		"first before Get", "last before Get", "last after Get", "first after Get",
+		"first before Insert", "last before Insert", "last after Insert", "first after Insert",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v; want %v", calls, want)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("got %d CallInfos; want 2", len(infos))
+	}
+	if got := infos[0]; got.Operation != "Get" || got.ProjectID != "proj" || *got.Key != *key || got.Response.(*ga.Firewall).Name != "fw" {
+		t.Errorf("CallInfo of Get = %+v; want the Get of %v returning the firewall", got, key)
+	}
+	if got := infos[1]; got.Operation != "Insert" || got.Request != fw || got.Response != nil {
+		t.Errorf("CallInfo of Insert = %+v; want the Insert of the firewall", got)
+	}
+	// The header is stamped on the calls, but not on the polls of the
+	// operation.
+	if want := []string{"tag", "tag", ""}; !reflect.DeepEqual(headers, want) {
+		t.Errorf("X-Audit headers = %q; want %q", headers, want)
+	}
+}
+
+func TestInterceptorBeforeError(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	})
+	errInjected := errors.New("injected")
+	var afters []error
+	s.Interceptors = []Interceptor{
+		{After: func(ctx context.Context, call *CallInfo, err error) { afters = append(afters, err) }},
+		{
+			Before: func(ctx context.Context, call *CallInfo) error { return errInjected },
+			After:  func(ctx context.Context, call *CallInfo, err error) { t.Errorf("After() called for a failed Before") },
+		},
+	}
+
+	key := meta.GlobalKey("fw")
+	_, err := NewGCE(s).Firewalls().Get(context.Background(), *key)
+	var e *Error
+	if !errors.Is(err, errInjected) || !errors.As(err, &e) || e.Operation != "Get" {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want an *Error wrapping %v", key, err, errInjected)
+	}
+	if requests != 0 {
+		t.Errorf("got %d requests; want none", requests)
+	}
+	if want := []error{errInjected}; !reflect.DeepEqual(afters, want) {
+		t.Errorf("After() got %v; want %v", afters, want)
+	}
+}
@@ -0,0 +1,1592 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode interfaces >
+// interfaces/gen.go". Do not edit directly.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+// Cloud is an interface for the GCE compute API. It is composed of the
+// interfaces for each API version (e.g. GACloud).
+type Cloud interface {
+	GACloud
+	AlphaCloud
+	BetaCloud
+}
+
+// GACloud is the subset of Cloud with the ga services. A component
+// that accepts a GACloud cannot call the services of the other API versions.
+type GACloud interface {
+	Addresses() Addresses
+	GlobalAddresses() GlobalAddresses
+	BackendServices() BackendServices
+	Disks() Disks
+	DiskTypes() DiskTypes
+	Firewalls() Firewalls
+	ForwardingRules() ForwardingRules
+	GlobalForwardingRules() GlobalForwardingRules
+	HealthChecks() HealthChecks
+	// Deprecated: HttpHealthChecks are legacy health checks. Use HealthChecks
+	// instead.
+	HttpHealthChecks() HttpHealthChecks
+	// Deprecated: HttpsHealthChecks are legacy health checks. Use HealthChecks
+	// instead.
+	HttpsHealthChecks() HttpsHealthChecks
+	InstanceGroups() InstanceGroups
+	Instances() Instances
+	MachineTypes() MachineTypes
+	GlobalOperations() GlobalOperations
+	RegionOperations() RegionOperations
+	ZoneOperations() ZoneOperations
+	Projects() Projects
+	Regions() Regions
+	Routes() Routes
+	SslCertificates() SslCertificates
+	TargetHttpProxies() TargetHttpProxies
+	TargetHttpsProxies() TargetHttpsProxies
+	TargetPools() TargetPools
+	UrlMaps() UrlMaps
+	Zones() Zones
+}
+
+// AlphaCloud is the subset of Cloud with the alpha services. A component
+// that accepts a AlphaCloud cannot call the services of the other API versions.
+type AlphaCloud interface {
+	AlphaAddresses() AlphaAddresses
+	AlphaBackendServices() AlphaBackendServices
+	AlphaRegionBackendServices() AlphaRegionBackendServices
+	AlphaDisks() AlphaDisks
+	AlphaRegionDisks() AlphaRegionDisks
+	AlphaForwardingRules() AlphaForwardingRules
+	AlphaHealthChecks() AlphaHealthChecks
+	AlphaInstances() AlphaInstances
+	AlphaNetworkEndpointGroups() AlphaNetworkEndpointGroups
+}
+
+// BetaCloud is the subset of Cloud with the beta services. A component
+// that accepts a BetaCloud cannot call the services of the other API versions.
+type BetaCloud interface {
+	BetaAddresses() BetaAddresses
+	BetaInstances() BetaInstances
+}
+
+// InstancesCloud is the subset of Cloud with the services tagged "instances",
+// at all of their versions. A component that accepts a InstancesCloud cannot
+// call the other services.
+type InstancesCloud interface {
+	InstanceGroups() InstanceGroups
+	Instances() Instances
+	BetaInstances() BetaInstances
+	AlphaInstances() AlphaInstances
+	MachineTypes() MachineTypes
+}
+
+// LoadBalancingCloud is the subset of Cloud with the services tagged
+// "loadbalancing", at all of their versions. A component that accepts a
+// LoadBalancingCloud cannot call the other services.
+type LoadBalancingCloud interface {
+	BackendServices() BackendServices
+	AlphaBackendServices() AlphaBackendServices
+	AlphaRegionBackendServices() AlphaRegionBackendServices
+	ForwardingRules() ForwardingRules
+	AlphaForwardingRules() AlphaForwardingRules
+	GlobalForwardingRules() GlobalForwardingRules
+	HealthChecks() HealthChecks
+	AlphaHealthChecks() AlphaHealthChecks
+	// Deprecated: HttpHealthChecks are legacy health checks. Use HealthChecks
+	// instead.
+	HttpHealthChecks() HttpHealthChecks
+	// Deprecated: HttpsHealthChecks are legacy health checks. Use HealthChecks
+	// instead.
+	HttpsHealthChecks() HttpsHealthChecks
+	AlphaNetworkEndpointGroups() AlphaNetworkEndpointGroups
+	SslCertificates() SslCertificates
+	TargetHttpProxies() TargetHttpProxies
+	TargetHttpsProxies() TargetHttpsProxies
+	TargetPools() TargetPools
+	UrlMaps() UrlMaps
+}
+
+// NetworkingCloud is the subset of Cloud with the services tagged "networking",
+// at all of their versions. A component that accepts a NetworkingCloud cannot
+// call the other services.
+type NetworkingCloud interface {
+	Addresses() Addresses
+	AlphaAddresses() AlphaAddresses
+	BetaAddresses() BetaAddresses
+	GlobalAddresses() GlobalAddresses
+	Firewalls() Firewalls
+	Routes() Routes
+}
+
+// StorageCloud is the subset of Cloud with the services tagged "storage", at
+// all of their versions. A component that accepts a StorageCloud cannot call
+// the other services.
+type StorageCloud interface {
+	Disks() Disks
+	AlphaDisks() AlphaDisks
+	AlphaRegionDisks() AlphaRegionDisks
+	DiskTypes() DiskTypes
+}
+
+// Addresses is an interface that allows for mocking of Addresses.
+//
+// A reserved address resource.
+type Addresses interface {
+	// Returns the specified address resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Address, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Address, error)
+	// Retrieves a list of addresses contained within the specified region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*ga.Address, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Address, string, error)
+	// ListAcrossProjects lists the objects of List in each of projects (e.g.
+	// the host and service projects of a Shared VPC) with concurrent calls.
+	// See cloud.ListAcrossProjects().
+	ListAcrossProjects(ctx context.Context, projects []string, region string, fl *filter.F, opts ...Option) (map[string][]*ga.Address, error)
+	// Creates an address resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.Address, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.Address, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Address) (*ga.Address, error)
+	// Deletes the specified address resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of addresses.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*ga.Address, error)
+}
+
+// AlphaAddresses is an interface that allows for mocking of Addresses.
+//
+// A reserved address resource.
+type AlphaAddresses interface {
+	// Returns the specified address resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.Address, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Address, error)
+	// Retrieves a list of addresses contained within the specified region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*alpha.Address, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.Address, string, error)
+	// ListAcrossProjects lists the objects of List in each of projects (e.g.
+	// the host and service projects of a Shared VPC) with concurrent calls.
+	// See cloud.ListAcrossProjects().
+	ListAcrossProjects(ctx context.Context, projects []string, region string, fl *filter.F, opts ...Option) (map[string][]*alpha.Address, error)
+	// Creates an address resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.Address, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.Address, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Address) (*alpha.Address, error)
+	// Deletes the specified address resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of addresses.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*alpha.Address, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+}
+
+// BetaAddresses is an interface that allows for mocking of Addresses.
+//
+// A reserved address resource.
+type BetaAddresses interface {
+	// Returns the specified address resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*beta.Address, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*beta.Address, error)
+	// Retrieves a list of addresses contained within the specified region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*beta.Address, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*beta.Address, string, error)
+	// ListAcrossProjects lists the objects of List in each of projects (e.g.
+	// the host and service projects of a Shared VPC) with concurrent calls.
+	// See cloud.ListAcrossProjects().
+	ListAcrossProjects(ctx context.Context, projects []string, region string, fl *filter.F, opts ...Option) (map[string][]*beta.Address, error)
+	// Creates an address resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *beta.Address, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *beta.Address, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *beta.Address) (*beta.Address, error)
+	// Deletes the specified address resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of addresses.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*beta.Address, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+}
+
+// GlobalAddresses is an interface that allows for mocking of GlobalAddresses.
+//
+// A reserved address resource.
+type GlobalAddresses interface {
+	// Returns the specified address resource. Get a list of available addresses by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Address, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Address, error)
+	// Retrieves a list of global addresses.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.Address, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Address, string, error)
+	// ListAcrossProjects lists the objects of List in each of projects (e.g.
+	// the host and service projects of a Shared VPC) with concurrent calls.
+	// See cloud.ListAcrossProjects().
+	ListAcrossProjects(ctx context.Context, projects []string, fl *filter.F, opts ...Option) (map[string][]*ga.Address, error)
+	// Creates an address resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.Address, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.Address, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Address) (*ga.Address, error)
+	// Deletes the specified address resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+}
+
+// BackendServices is an interface that allows for mocking of BackendServices.
+//
+// A BackendService resource. This resource defines a group of backend virtual
+// machines and their serving capacity.
+type BackendServices interface {
+	// Returns the specified BackendService resource. Get a list of available
+	// backend services by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.BackendService, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.BackendService, error)
+	// Retrieves the list of BackendService resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.BackendService, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.BackendService, string, error)
+	// Creates a BackendService resource in the specified project using the data
+	// included in the request. There are several restrictions and guidelines to
+	// keep in mind when creating a backend service. Read Restrictions and
+	// Guidelines for more information.
+	Insert(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.BackendService) (*ga.BackendService, error)
+	// Deletes the specified BackendService resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates the specified BackendService resource with the data included in the
+	// request. There are several restrictions and guidelines to keep in mind when
+	// updating a backend service. Read Restrictions and Guidelines for more
+	// information.
+	Update(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...Option) error
+	// UpdateWithRetry updates the current object with update, reading it
+	// again and retrying if the fingerprint changed concurrently. See
+	// cloud.UpdateWithRetry().
+	UpdateWithRetry(ctx context.Context, key meta.Key, update func(*ga.BackendService) error) error
+	// Patches the specified BackendService resource with the data included in the
+	// request. There are several restrictions and guidelines to keep in mind when
+	// updating a backend service. Read Restrictions and Guidelines for more
+	// information. This method supports PATCH semantics and uses the JSON merge
+	// patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...Option) error
+	// Gets the most recent health check results for this BackendService.
+	GetHealth(context.Context, meta.Key, *ga.ResourceGroupReference, ...Option) (*ga.BackendServiceGroupHealth, error)
+}
+
+// AlphaBackendServices is an interface that allows for mocking of BackendServices.
+//
+// A BackendService resource. This resource defines a group of backend virtual
+// machines and their serving capacity.
+type AlphaBackendServices interface {
+	// Returns the specified BackendService resource. Get a list of available
+	// backend services by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.BackendService, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.BackendService, error)
+	// Retrieves the list of BackendService resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*alpha.BackendService, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.BackendService, string, error)
+	// Creates a BackendService resource in the specified project using the data
+	// included in the request. There are several restrictions and guidelines to
+	// keep in mind when creating a backend service. Read Restrictions and
+	// Guidelines for more information.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.BackendService) (*alpha.BackendService, error)
+	// Deletes the specified BackendService resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates the specified BackendService resource with the data included in the
+	// request. There are several restrictions and guidelines to keep in mind when
+	// updating a backend service. Read Restrictions and Guidelines for more
+	// information.
+	Update(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) error
+	// UpdateWithRetry updates the current object with update, reading it
+	// again and retrying if the fingerprint changed concurrently. See
+	// cloud.UpdateWithRetry().
+	UpdateWithRetry(ctx context.Context, key meta.Key, update func(*alpha.BackendService) error) error
+	// Patches the specified BackendService resource with the data included in the
+	// request. There are several restrictions and guidelines to keep in mind when
+	// updating a backend service. Read Restrictions and Guidelines for more
+	// information. This method supports PATCH semantics and uses the JSON merge
+	// patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) error
+}
+
+// AlphaRegionBackendServices is an interface that allows for mocking of RegionBackendServices.
+//
+// A BackendService resource. This resource defines a group of backend virtual
+// machines and their serving capacity.
+type AlphaRegionBackendServices interface {
+	// Returns the specified regional BackendService resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.BackendService, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.BackendService, error)
+	// Retrieves the list of regional BackendService resources available to the
+	// specified project in the given region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*alpha.BackendService, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.BackendService, string, error)
+	// Creates a regional BackendService resource in the specified project using the
+	// data included in the request. There are several restrictions and guidelines
+	// to keep in mind when creating a regional backend service. Read Restrictions
+	// and Guidelines for more information.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.BackendService) (*alpha.BackendService, error)
+	// Deletes the specified regional BackendService resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates the specified regional BackendService resource with the data included
+	// in the request. There are several restrictions and guidelines to keep in mind
+	// when updating a backend service. Read Restrictions and Guidelines for more
+	// information.
+	Update(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) error
+	// UpdateWithRetry updates the current object with update, reading it
+	// again and retrying if the fingerprint changed concurrently. See
+	// cloud.UpdateWithRetry().
+	UpdateWithRetry(ctx context.Context, key meta.Key, update func(*alpha.BackendService) error) error
+	// Updates the specified regional BackendService resource with the data included
+	// in the request. There are several restrictions and guidelines to keep in mind
+	// when updating a backend service. Read Restrictions and Guidelines for more
+	// information. This method supports PATCH semantics and uses the JSON merge
+	// patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...Option) error
+	// Gets the most recent health check results for this regional BackendService.
+	GetHealth(context.Context, meta.Key, *alpha.ResourceGroupReference, ...Option) (*alpha.BackendServiceGroupHealth, error)
+}
+
+// Disks is an interface that allows for mocking of Disks.
+//
+// A Disk resource.
+type Disks interface {
+	// Returns a specified persistent disk. Get a list of available persistent disks
+	// by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Disk, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Disk, error)
+	// Retrieves a list of persistent disks contained within the specified zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*ga.Disk, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Disk, string, error)
+	// Creates a persistent disk in the specified project using the data in the
+	// request. You can create a disk with a sourceImage, a sourceSnapshot, or
+	// create an empty 500 GB data disk by omitting all properties. You can also
+	// create a disk that is larger than the default size by specifying the sizeGb
+	// property.
+	Insert(ctx context.Context, key meta.Key, obj *ga.Disk, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.Disk, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Disk) (*ga.Disk, error)
+	// Deletes the specified persistent disk. Deleting a disk removes its data
+	// permanently and is irreversible. However, deleting a disk does not delete any
+	// snapshots previously made from the disk. You must separately delete
+	// snapshots.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of persistent disks.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*ga.Disk, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+}
+
+// AlphaDisks is an interface that allows for mocking of Disks.
+//
+// A Disk resource.
+type AlphaDisks interface {
+	// Returns a specified persistent disk. Get a list of available persistent disks
+	// by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.Disk, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Disk, error)
+	// Retrieves a list of persistent disks contained within the specified zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*alpha.Disk, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.Disk, string, error)
+	// Creates a persistent disk in the specified project using the data in the
+	// request. You can create a disk with a sourceImage, a sourceSnapshot, or
+	// create an empty 500 GB data disk by omitting all properties. You can also
+	// create a disk that is larger than the default size by specifying the sizeGb
+	// property.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Disk) (*alpha.Disk, error)
+	// Deletes the specified persistent disk. Deleting a disk removes its data
+	// permanently and is irreversible. However, deleting a disk does not delete any
+	// snapshots previously made from the disk. You must separately delete
+	// snapshots.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of persistent disks.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*alpha.Disk, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+}
+
+// AlphaRegionDisks is an interface that allows for mocking of RegionDisks.
+//
+// A Disk resource.
+type AlphaRegionDisks interface {
+	// Returns a specified regional persistent disk.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.Disk, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Disk, error)
+	// Retrieves the list of persistent disks contained within the specified region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*alpha.Disk, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.Disk, string, error)
+	// Creates a persistent regional disk in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Disk) (*alpha.Disk, error)
+	// Deletes the specified regional persistent disk. Deleting a regional disk
+	// removes all the replicas of its data permanently and is irreversible.
+	// However, deleting a disk does not delete any snapshots previously made from
+	// the disk. You must separately delete snapshots.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+}
+
+// DiskTypes is an interface that allows for mocking of DiskTypes.
+//
+// A DiskType resource.
+type DiskTypes interface {
+	// Returns the specified disk type. Get a list of available disk types by making
+	// a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.DiskType, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.DiskType, error)
+	// Retrieves a list of disk types available to the specified project.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*ga.DiskType, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.DiskType, string, error)
+}
+
+// Firewalls is an interface that allows for mocking of Firewalls.
+//
+// Represents a Firewall resource.
+type Firewalls interface {
+	// Returns the specified firewall.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Firewall, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Firewall, error)
+	// Retrieves the list of firewall rules available to the specified project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.Firewall, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Firewall, string, error)
+	// ListAcrossProjects lists the objects of List in each of projects (e.g.
+	// the host and service projects of a Shared VPC) with concurrent calls.
+	// See cloud.ListAcrossProjects().
+	ListAcrossProjects(ctx context.Context, projects []string, fl *filter.F, opts ...Option) (map[string][]*ga.Firewall, error)
+	// Creates a firewall rule in the specified project using the data included in
+	// the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Firewall) (*ga.Firewall, error)
+	// Deletes the specified firewall.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates the specified firewall rule with the data included in the request.
+	// Using PUT method, can only update following fields of firewall rule: allowed,
+	// description, sourceRanges, sourceTags, targetTags.
+	Update(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...Option) error
+	// Updates the specified firewall rule with the data included in the request.
+	// This method supports PATCH semantics and uses the JSON merge patch format and
+	// processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...Option) error
+}
+
+// ForwardingRules is an interface that allows for mocking of ForwardingRules.
+//
+// A ForwardingRule resource. A ForwardingRule resource specifies which pool of
+// target virtual machines to forward a packet to if it matches the given
+// [IPAddress, IPProtocol, ports] tuple.
+type ForwardingRules interface {
+	// Returns the specified ForwardingRule resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.ForwardingRule, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.ForwardingRule, error)
+	// Retrieves a list of ForwardingRule resources available to the specified
+	// project and region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*ga.ForwardingRule, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.ForwardingRule, string, error)
+	// Creates a ForwardingRule resource in the specified project and region using
+	// the data included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.ForwardingRule) (*ga.ForwardingRule, error)
+	// Deletes the specified ForwardingRule resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of forwarding rules.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*ga.ForwardingRule, error)
+}
+
+// AlphaForwardingRules is an interface that allows for mocking of ForwardingRules.
+//
+// A ForwardingRule resource. A ForwardingRule resource specifies which pool of
+// target virtual machines to forward a packet to if it matches the given
+// [IPAddress, IPProtocol, ports] tuple.
+type AlphaForwardingRules interface {
+	// Returns the specified ForwardingRule resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.ForwardingRule, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.ForwardingRule, error)
+	// Retrieves a list of ForwardingRule resources available to the specified
+	// project and region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*alpha.ForwardingRule, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.ForwardingRule, string, error)
+	// Creates a ForwardingRule resource in the specified project and region using
+	// the data included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.ForwardingRule, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.ForwardingRule, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.ForwardingRule) (*alpha.ForwardingRule, error)
+	// Deletes the specified ForwardingRule resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves an aggregated list of forwarding rules.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*alpha.ForwardingRule, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+}
+
+// GlobalForwardingRules is an interface that allows for mocking of GlobalForwardingRules.
+//
+// A ForwardingRule resource. A ForwardingRule resource specifies which pool of
+// target virtual machines to forward a packet to if it matches the given
+// [IPAddress, IPProtocol, ports] tuple.
+type GlobalForwardingRules interface {
+	// Returns the specified GlobalForwardingRule resource. Get a list of available
+	// forwarding rules by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.ForwardingRule, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.ForwardingRule, error)
+	// Retrieves a list of GlobalForwardingRule resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.ForwardingRule, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.ForwardingRule, string, error)
+	// Creates a GlobalForwardingRule resource in the specified project using the
+	// data included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.ForwardingRule) (*ga.ForwardingRule, error)
+	// Deletes the specified GlobalForwardingRule resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Changes target URL for the GlobalForwardingRule resource. The new target
+	// should be of the same type as the old target.
+	SetTarget(context.Context, meta.Key, *ga.TargetReference, ...Option) error
+}
+
+// HealthChecks is an interface that allows for mocking of HealthChecks.
+//
+// An HealthCheck resource. This resource defines a template for how individual
+// virtual machines should be checked for health, via one of the supported
+// protocols.
+type HealthChecks interface {
+	// Returns the specified HealthCheck resource. Get a list of available health
+	// checks by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.HealthCheck, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.HealthCheck, error)
+	// Retrieves the list of HealthCheck resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.HealthCheck, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.HealthCheck, string, error)
+	// Creates a HealthCheck resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.HealthCheck) (*ga.HealthCheck, error)
+	// Deletes the specified HealthCheck resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates a HealthCheck resource in the specified project using the data
+	// included in the request.
+	Update(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...Option) error
+	// Updates a HealthCheck resource in the specified project using the data
+	// included in the request. This method supports PATCH semantics and uses the
+	// JSON merge patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...Option) error
+}
+
+// AlphaHealthChecks is an interface that allows for mocking of HealthChecks.
+//
+// An HealthCheck resource. This resource defines a template for how individual
+// virtual machines should be checked for health, via one of the supported
+// protocols.
+type AlphaHealthChecks interface {
+	// Returns the specified HealthCheck resource. Get a list of available health
+	// checks by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.HealthCheck, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.HealthCheck, error)
+	// Retrieves the list of HealthCheck resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*alpha.HealthCheck, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.HealthCheck, string, error)
+	// Creates a HealthCheck resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.HealthCheck) (*alpha.HealthCheck, error)
+	// Deletes the specified HealthCheck resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates a HealthCheck resource in the specified project using the data
+	// included in the request.
+	Update(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...Option) error
+	// Updates a HealthCheck resource in the specified project using the data
+	// included in the request. This method supports PATCH semantics and uses the
+	// JSON merge patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...Option) error
+}
+
+// HttpHealthChecks is an interface that allows for mocking of HttpHealthChecks.
+//
+// An HttpHealthCheck resource. This resource defines a template for how
+// individual instances should be checked for health, via HTTP.
+//
+// Deprecated: HttpHealthChecks are legacy health checks. Use HealthChecks
+// instead.
+type HttpHealthChecks interface {
+	// Returns the specified HttpHealthCheck resource. Get a list of available HTTP
+	// health checks by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.HttpHealthCheck, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.HttpHealthCheck, error)
+	// Retrieves the list of HttpHealthCheck resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.HttpHealthCheck, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.HttpHealthCheck, string, error)
+	// Creates a HttpHealthCheck resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.HttpHealthCheck) (*ga.HttpHealthCheck, error)
+	// Deletes the specified HttpHealthCheck resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates a HttpHealthCheck resource in the specified project using the data
+	// included in the request.
+	Update(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...Option) error
+	// Updates a HttpHealthCheck resource in the specified project using the data
+	// included in the request. This method supports PATCH semantics and uses the
+	// JSON merge patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...Option) error
+}
+
+// HttpsHealthChecks is an interface that allows for mocking of HttpsHealthChecks.
+//
+// An HttpsHealthCheck resource. This resource defines a template for how
+// individual instances should be checked for health, via HTTPS.
+//
+// Deprecated: HttpsHealthChecks are legacy health checks. Use HealthChecks
+// instead.
+type HttpsHealthChecks interface {
+	// Returns the specified HttpsHealthCheck resource. Get a list of available
+	// HTTPS health checks by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.HttpsHealthCheck, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.HttpsHealthCheck, error)
+	// Retrieves the list of HttpsHealthCheck resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.HttpsHealthCheck, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.HttpsHealthCheck, string, error)
+	// Creates a HttpsHealthCheck resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.HttpsHealthCheck) (*ga.HttpsHealthCheck, error)
+	// Deletes the specified HttpsHealthCheck resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates a HttpsHealthCheck resource in the specified project using the data
+	// included in the request.
+	Update(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...Option) error
+	// Updates a HttpsHealthCheck resource in the specified project using the data
+	// included in the request. This method supports PATCH semantics and uses the
+	// JSON merge patch format and processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...Option) error
+}
+
+// InstanceGroups is an interface that allows for mocking of InstanceGroups.
+//
+// InstanceGroups
+type InstanceGroups interface {
+	// Returns the specified instance group. Get a list of available instance groups
+	// by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.InstanceGroup, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.InstanceGroup, error)
+	// Retrieves the list of instance groups that are located in the specified
+	// project and zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*ga.InstanceGroup, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.InstanceGroup, string, error)
+	// Creates an instance group in the specified project using the parameters that
+	// are included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.InstanceGroup, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.InstanceGroup, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.InstanceGroup) (*ga.InstanceGroup, error)
+	// Deletes the specified instance group. The instances in the group are not
+	// deleted. Note that instance group must not belong to a backend service. Read
+	// Deleting an instance group for more information.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Adds a list of instances to the specified instance group. All of the
+	// instances in the instance group must be in the same network/subnetwork. Read
+	// Adding instances for more information.
+	AddInstances(context.Context, meta.Key, *ga.InstanceGroupsAddInstancesRequest, ...Option) error
+	// Lists the instances in the specified instance group.
+	ListInstances(context.Context, meta.Key, *ga.InstanceGroupsListInstancesRequest, ...Option) (*ga.InstanceGroupsListInstances, error)
+	// Removes one or more instances from the specified instance group, but does not
+	// delete those instances.
+	//
+	// If the group is part of a backend service that has enabled connection
+	// draining, it can take up to 60 seconds after the connection draining duration
+	// before the VM instance is removed or deleted.
+	RemoveInstances(context.Context, meta.Key, *ga.InstanceGroupsRemoveInstancesRequest, ...Option) error
+	// Sets the named ports for the specified instance group.
+	SetNamedPorts(context.Context, meta.Key, *ga.InstanceGroupsSetNamedPortsRequest, ...Option) error
+}
+
+// Instances is an interface that allows for mocking of Instances.
+//
+// An Instance resource.
+type Instances interface {
+	// Returns the specified Instance resource. Get a list of available instances by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Instance, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Instance, error)
+	// Retrieves the list of instances contained within the specified zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*ga.Instance, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Instance, string, error)
+	// Creates an instance resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.Instance, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.Instance, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Instance) (*ga.Instance, error)
+	// Deletes the specified Instance resource. For more information, see Stopping
+	// or Deleting an Instance.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves aggregated list of instances.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*ga.Instance, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+	// Attaches an existing Disk resource to an instance. You must first create the
+	// disk before you can attach it. It is not possible to create and attach a disk
+	// at the same time. For more information, read Adding a persistent disk to your
+	// instance.
+	AttachDisk(context.Context, meta.Key, *ga.AttachedDisk, ...Option) error
+	// Detaches a disk from an instance.
+	DetachDisk(context.Context, meta.Key, string, ...Option) error
+}
+
+// BetaInstances is an interface that allows for mocking of Instances.
+//
+// An Instance resource.
+type BetaInstances interface {
+	// Returns the specified Instance resource. Get a list of available instances by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*beta.Instance, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*beta.Instance, error)
+	// Retrieves the list of instances contained within the specified zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*beta.Instance, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*beta.Instance, string, error)
+	// Creates an instance resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *beta.Instance, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *beta.Instance, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *beta.Instance) (*beta.Instance, error)
+	// Deletes the specified Instance resource. For more information, see Stopping
+	// or Deleting an Instance.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves aggregated list of instances.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*beta.Instance, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+	// Attaches an existing Disk resource to an instance. You must first create the
+	// disk before you can attach it. It is not possible to create and attach a disk
+	// at the same time. For more information, read Adding a persistent disk to your
+	// instance.
+	AttachDisk(context.Context, meta.Key, *beta.AttachedDisk, ...Option) error
+	// Detaches a disk from an instance.
+	DetachDisk(context.Context, meta.Key, string, ...Option) error
+}
+
+// AlphaInstances is an interface that allows for mocking of Instances.
+//
+// An Instance resource.
+type AlphaInstances interface {
+	// Returns the specified Instance resource. Get a list of available instances by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.Instance, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Instance, error)
+	// Retrieves the list of instances contained within the specified zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*alpha.Instance, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.Instance, string, error)
+	// Creates an instance resource in the specified project using the data included
+	// in the request.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.Instance, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.Instance, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Instance) (*alpha.Instance, error)
+	// Deletes the specified Instance resource. For more information, see Stopping
+	// or Deleting an Instance.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves aggregated list of instances.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*alpha.Instance, error)
+	// UpdateLabels sets the labels of the object, guarded by the label
+	// fingerprint of the current object.
+	UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) error
+	// Attaches an existing Disk resource to an instance. You must first create the
+	// disk before you can attach it. It is not possible to create and attach a disk
+	// at the same time. For more information, read Adding a persistent disk to your
+	// instance.
+	AttachDisk(context.Context, meta.Key, *alpha.AttachedDisk, ...Option) error
+	// Detaches a disk from an instance.
+	DetachDisk(context.Context, meta.Key, string, ...Option) error
+	// Updates an instance's network interface. This method follows PATCH semantics.
+	UpdateNetworkInterface(context.Context, meta.Key, string, *alpha.NetworkInterface, ...Option) error
+}
+
+// MachineTypes is an interface that allows for mocking of MachineTypes.
+//
+// A Machine Type resource.
+type MachineTypes interface {
+	// Returns the specified machine type. Get a list of available machine types by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.MachineType, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.MachineType, error)
+	// Retrieves a list of machine types available to the specified project.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*ga.MachineType, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.MachineType, string, error)
+}
+
+// AlphaNetworkEndpointGroups is an interface that allows for mocking of NetworkEndpointGroups.
+//
+// Represents a collection of network endpoints.
+type AlphaNetworkEndpointGroups interface {
+	// Returns the specified network endpoint group. Get a list of available network
+	// endpoint groups by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*alpha.NetworkEndpointGroup, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.NetworkEndpointGroup, error)
+	// Retrieves the list of network endpoint groups that are located in the
+	// specified project and zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*alpha.NetworkEndpointGroup, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*alpha.NetworkEndpointGroup, string, error)
+	// Creates a network endpoint group in the specified project using the
+	// parameters that are included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *alpha.NetworkEndpointGroup, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *alpha.NetworkEndpointGroup, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.NetworkEndpointGroup) (*alpha.NetworkEndpointGroup, error)
+	// Deletes the specified network endpoint group. The network endpoints in the
+	// NEG and the VM instances they belong to are not terminated when the NEG is
+	// deleted. Note that the NEG cannot be deleted if there are backend services
+	// referencing it.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Retrieves the list of network endpoint groups and sorts them by zone.
+	AggregatedList(ctx context.Context, fl *filter.F, opts ...Option) (map[string][]*alpha.NetworkEndpointGroup, error)
+	// Attach a list of network endpoints to the specified network endpoint group.
+	AttachNetworkEndpoints(context.Context, meta.Key, *alpha.NetworkEndpointGroupsAttachEndpointsRequest, ...Option) error
+	// Detach a list of network endpoints from the specified network endpoint group.
+	DetachNetworkEndpoints(context.Context, meta.Key, *alpha.NetworkEndpointGroupsDetachEndpointsRequest, ...Option) error
+}
+
+// GlobalOperations is an interface that allows for mocking of GlobalOperations.
+//
+// An Operation resource, used to manage asynchronous API requests.
+type GlobalOperations interface {
+	// GlobalOperationsOps is an interface with additional non-CRUD type methods.
+	// This interface is expected to be implemented by hand (non-autogenerated).
+	GlobalOperationsOps
+	// Retrieves the specified Operations resource. Get a list of operations by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Operation, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Operation, error)
+	// Retrieves a list of Operation resources contained within the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.Operation, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Operation, string, error)
+	// Deletes the specified Operations resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+}
+
+// RegionOperations is an interface that allows for mocking of RegionOperations.
+//
+// An Operation resource, used to manage asynchronous API requests.
+type RegionOperations interface {
+	// RegionOperationsOps is an interface with additional non-CRUD type methods.
+	// This interface is expected to be implemented by hand (non-autogenerated).
+	RegionOperationsOps
+	// Retrieves the specified region-specific Operations resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Operation, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Operation, error)
+	// Retrieves a list of Operation resources contained within the specified
+	// region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*ga.Operation, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Operation, string, error)
+	// Deletes the specified region-specific Operations resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+}
+
+// ZoneOperations is an interface that allows for mocking of ZoneOperations.
+//
+// An Operation resource, used to manage asynchronous API requests.
+type ZoneOperations interface {
+	// ZoneOperationsOps is an interface with additional non-CRUD type methods.
+	// This interface is expected to be implemented by hand (non-autogenerated).
+	ZoneOperationsOps
+	// Retrieves the specified zone-specific Operations resource.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Operation, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Operation, error)
+	// Retrieves a list of Operation resources contained within the specified zone.
+	List(ctx context.Context, zone string, fl *filter.F, opts ...Option) ([]*ga.Operation, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Operation, string, error)
+	// Deletes the specified zone-specific Operations resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+}
+
+// Projects is an interface that allows for mocking of Projects.
+//
+// A Project resource. Projects can only be created in the Google Cloud Platform
+// Console. Unless marked otherwise, values can only be modified in the console.
+type Projects interface {
+	// ProjectsOps is an interface with additional non-CRUD type methods.
+	// This interface is expected to be implemented by hand (non-autogenerated).
+	ProjectsOps
+}
+
+// Regions is an interface that allows for mocking of Regions.
+//
+// Region resource.
+type Regions interface {
+	// Returns the specified Region resource. Get a list of available regions by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Region, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Region, error)
+	// Retrieves the list of region resources available to the specified project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.Region, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Region, string, error)
+}
+
+// Routes is an interface that allows for mocking of Routes.
+//
+// Represents a Route resource. A route specifies how certain packets should be
+// handled by the network. Routes are associated with instances by tags and the
+// set of routes for a particular instance is called its routing table.
+//
+// For each packet leaving an instance, the system searches that instance's
+// routing table for a single best matching route. Routes match packets by
+// destination IP address, preferring smaller or more specific ranges over
+// larger ones. If there is a tie, the system selects the route with the
+// smallest priority value. If there is still a tie, it uses the layer three and
+// four packet headers to select just one of the remaining matching routes. The
+// packet is then forwarded as specified by the nextHop field of the winning
+// route - either to another instance destination, an instance gateway, or a
+// Google Compute Engine-operated gateway.
+//
+// Packets that do not match any route in the sending instance's routing table
+// are dropped.
+type Routes interface {
+	// Returns the specified Route resource. Get a list of available routes by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Route, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Route, error)
+	// Retrieves the list of Route resources available to the specified project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.Route, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Route, string, error)
+	// ListAcrossProjects lists the objects of List in each of projects (e.g.
+	// the host and service projects of a Shared VPC) with concurrent calls.
+	// See cloud.ListAcrossProjects().
+	ListAcrossProjects(ctx context.Context, projects []string, fl *filter.F, opts ...Option) (map[string][]*ga.Route, error)
+	// Creates a Route resource in the specified project using the data included in
+	// the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.Route, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.Route, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Route) (*ga.Route, error)
+	// Deletes the specified Route resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+}
+
+// SslCertificates is an interface that allows for mocking of SslCertificates.
+//
+// An SslCertificate resource. This resource provides a mechanism to upload an
+// SSL key and certificate to the load balancer to serve secure connections from
+// the user.
+type SslCertificates interface {
+	// Returns the specified SslCertificate resource. Get a list of available SSL
+	// certificates by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.SslCertificate, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.SslCertificate, error)
+	// Retrieves the list of SslCertificate resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.SslCertificate, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.SslCertificate, string, error)
+	// Creates a SslCertificate resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.SslCertificate, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.SslCertificate, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.SslCertificate) (*ga.SslCertificate, error)
+	// Deletes the specified SslCertificate resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+}
+
+// TargetHttpProxies is an interface that allows for mocking of TargetHttpProxies.
+//
+// A TargetHttpProxy resource. This resource defines an HTTP proxy.
+type TargetHttpProxies interface {
+	// Returns the specified TargetHttpProxy resource. Get a list of available
+	// target HTTP proxies by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.TargetHttpProxy, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.TargetHttpProxy, error)
+	// Retrieves the list of TargetHttpProxy resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.TargetHttpProxy, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.TargetHttpProxy, string, error)
+	// Creates a TargetHttpProxy resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.TargetHttpProxy, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.TargetHttpProxy, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.TargetHttpProxy) (*ga.TargetHttpProxy, error)
+	// Deletes the specified TargetHttpProxy resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Changes the URL map for TargetHttpProxy.
+	SetUrlMap(context.Context, meta.Key, *ga.UrlMapReference, ...Option) error
+}
+
+// TargetHttpsProxies is an interface that allows for mocking of TargetHttpsProxies.
+//
+// A TargetHttpsProxy resource. This resource defines an HTTPS proxy.
+type TargetHttpsProxies interface {
+	// Returns the specified TargetHttpsProxy resource. Get a list of available
+	// target HTTPS proxies by making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.TargetHttpsProxy, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.TargetHttpsProxy, error)
+	// Retrieves the list of TargetHttpsProxy resources available to the specified
+	// project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.TargetHttpsProxy, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.TargetHttpsProxy, string, error)
+	// Creates a TargetHttpsProxy resource in the specified project using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.TargetHttpsProxy, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.TargetHttpsProxy, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.TargetHttpsProxy) (*ga.TargetHttpsProxy, error)
+	// Deletes the specified TargetHttpsProxy resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Replaces SslCertificates for TargetHttpsProxy.
+	SetSslCertificates(context.Context, meta.Key, *ga.TargetHttpsProxiesSetSslCertificatesRequest, ...Option) error
+	// Changes the URL map for TargetHttpsProxy.
+	SetUrlMap(context.Context, meta.Key, *ga.UrlMapReference, ...Option) error
+}
+
+// TargetPools is an interface that allows for mocking of TargetPools.
+//
+// A TargetPool resource. This resource defines a pool of instances, an
+// associated HttpHealthCheck resource, and the fallback target pool.
+type TargetPools interface {
+	// Returns the specified target pool. Get a list of available target pools by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.TargetPool, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.TargetPool, error)
+	// Retrieves a list of target pools available to the specified project and
+	// region.
+	List(ctx context.Context, region string, fl *filter.F, opts ...Option) ([]*ga.TargetPool, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.TargetPool, string, error)
+	// Creates a target pool in the specified project and region using the data
+	// included in the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.TargetPool, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.TargetPool, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.TargetPool) (*ga.TargetPool, error)
+	// Deletes the specified target pool.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Adds an instance to a target pool.
+	AddInstance(context.Context, meta.Key, *ga.TargetPoolsAddInstanceRequest, ...Option) error
+	// Removes instance URL from a target pool.
+	RemoveInstance(context.Context, meta.Key, *ga.TargetPoolsRemoveInstanceRequest, ...Option) error
+}
+
+// UrlMaps is an interface that allows for mocking of UrlMaps.
+//
+// A UrlMap resource. This resource defines the mapping from URL to the
+// BackendService resource, based on the "longest-match" of the URL's host and
+// path.
+type UrlMaps interface {
+	// Returns the specified UrlMap resource. Get a list of available URL maps by
+	// making a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.UrlMap, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.UrlMap, error)
+	// Retrieves the list of UrlMap resources available to the specified project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.UrlMap, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.UrlMap, string, error)
+	// Creates a UrlMap resource in the specified project using the data included in
+	// the request.
+	Insert(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...Option) error
+	// InsertOp is Insert returning as soon as the operation is started.
+	InsertOp(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...Option) (Op, error)
+	GetOrCreate(ctx context.Context, key meta.Key, desired *ga.UrlMap) (*ga.UrlMap, error)
+	// Deletes the specified UrlMap resource.
+	Delete(ctx context.Context, key meta.Key, opts ...Option) error
+	// DeleteOp is Delete returning as soon as the operation is started.
+	DeleteOp(ctx context.Context, key meta.Key, opts ...Option) (Op, error)
+	// BatchDelete deletes the objects of keys with concurrent calls to
+	// Delete. See cloud.BatchDelete().
+	BatchDelete(ctx context.Context, keys []meta.Key) error
+	// Updates the specified UrlMap resource with the data included in the request.
+	Update(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...Option) error
+	// UpdateWithRetry updates the current object with update, reading it
+	// again and retrying if the fingerprint changed concurrently. See
+	// cloud.UpdateWithRetry().
+	UpdateWithRetry(ctx context.Context, key meta.Key, update func(*ga.UrlMap) error) error
+	// Patches the specified UrlMap resource with the data included in the request.
+	// This method supports PATCH semantics and uses the JSON merge patch format and
+	// processing rules.
+	Patch(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...Option) error
+}
+
+// Zones is an interface that allows for mocking of Zones.
+//
+// A Zone resource.
+type Zones interface {
+	// Returns the specified Zone resource. Get a list of available zones by making
+	// a list() request.
+	Get(ctx context.Context, key meta.Key, opts ...Option) (*ga.Zone, error)
+	Exists(ctx context.Context, key meta.Key) (bool, error)
+	// BatchGet gets the objects of keys with concurrent calls to Get. See
+	// cloud.BatchGet().
+	BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Zone, error)
+	// Retrieves the list of Zone resources available to the specified project.
+	List(ctx context.Context, fl *filter.F, opts ...Option) ([]*ga.Zone, error)
+	// ListPage lists a page of the objects of List, starting at pageToken
+	// ("" for the first page) with at most maxResults objects (0 for the
+	// default of the API), and returns the token of the next page ("" after
+	// the last page).
+	ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...Option) ([]*ga.Zone, string, error)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Op is a pending mutation returned by the InsertOp and DeleteOp methods,
+// which return as soon as GCE has accepted the mutation. This allows starting
+// many mutations concurrently and waiting for all of them afterwards.
+type Op interface {
+	// Done polls the operation once and returns true if it has completed,
+	// successfully or not (see Error()).
+	Done(ctx context.Context) (bool, error)
+	// Wait blocks until the operation has completed.
+	Wait(ctx context.Context) error
+	// Key returns the key of the operation in the GlobalOperations,
+	// RegionOperations or ZoneOperations service, e.g. to resume waiting for
+	// it with their Wait() after a restart. It is nil if there is no GCE
+	// operation (e.g. in the mocks).
+	Key() *meta.Key
+	// Error returns the error of the operation once it has completed, nil
+	// if it succeeded or is still pending. The errors reported by GCE are
+	// returned as a *cloud.OperationError.
+	Error() error
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// GlobalOperationsOps is the manually implemented methods for the
+// GlobalOperations service.
+type GlobalOperationsOps interface {
+	// Wait blocks until the operation referenced by key has completed. It
+	// allows resuming the wait for an operation by name, e.g. after a
+	// restart.
+	Wait(ctx context.Context, key meta.Key) error
+}
+
+// RegionOperationsOps is the manually implemented methods for the
+// RegionOperations service.
+type RegionOperationsOps interface {
+	// Wait blocks until the operation referenced by key has completed.
+	Wait(ctx context.Context, key meta.Key) error
+}
+
+// ZoneOperationsOps is the manually implemented methods for the
+// ZoneOperations service.
+type ZoneOperationsOps interface {
+	// Wait blocks until the operation referenced by key has completed.
+	Wait(ctx context.Context, key meta.Key) error
+}
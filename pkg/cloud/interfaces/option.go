@@ -0,0 +1,55 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interfaces
+
+import "time"
+
+// Option is an option of a single call of a method of a service, e.g.
+// cloud.Timeout(). The methods making a single API call (Get, the list
+// methods, Insert, Delete, Update, Patch and the custom methods) take
+// Options; the methods made of several calls (e.g. BatchGet or GetOrCreate)
+// do not.
+type Option func(*CallConfig)
+
+// CallConfig is the configuration of a call, as set by its Options.
+type CallConfig struct {
+	// Timeout, if > 0, bounds the call, including waiting for the operation
+	// of a mutation.
+	Timeout time.Duration
+	// Fields, if set, restricts the fields of the response of a read (see
+	// https://cloud.google.com/compute/docs/api/how-tos/performance). It is
+	// not applied to mutations, whose response is the operation.
+	Fields []string
+	// RequestID, if set, is the idempotency token of a mutation: GCE ignores
+	// a request with the RequestID of a request it has already accepted.
+	RequestID string
+	// SkipWait makes a mutation return as soon as its operation is
+	// started, without waiting for it to complete.
+	SkipWait bool
+	// ProjectID, if set, is the project of the call instead of the one
+	// given by the ProjectRouter of the service.
+	ProjectID string
+}
+
+// NewCallConfig returns the configuration set by opts.
+func NewCallConfig(opts ...Option) CallConfig {
+	var c CallConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	return c
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+)
+
+// IteratorDone is returned by Iterator when there are no more objects.
+var IteratorDone = errors.New("no more objects in iterator")
+
+// PageFunc lists the page of objects starting at pageToken and returns the
+// token of the next page, "" after the last page. It is the ListPage method of
+// a service with the other arguments bound.
+type PageFunc[T any] func(ctx context.Context, pageToken string) ([]*T, string, error)
+
+// Iterator lists the objects of a PageFunc one page at a time, so that the
+// objects of a large collection are not all held in memory:
+//
+//	it := cloud.NewIterator(func(ctx context.Context, token string) ([]*ga.Instance, string, error) {
+//		return c.Instances().ListPage(ctx, "us-central1-b", filter.None, token, 500)
+//	})
+//	for {
+//		obj, err := it.Next(ctx)
+//		if err == cloud.IteratorDone {
+//			break
+//		}
+//		...
+//	}
+type Iterator[T any] struct {
+	page  PageFunc[T]
+	token string
+	last  bool
+	items []*T
+}
+
+// NewIterator returns an Iterator listing the pages of page from the first
+// one.
+func NewIterator[T any](page PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{page: page}
+}
+
+// NextPage returns the next page of objects, or IteratorDone after the last
+// page. The objects of the current page not returned by Next() are skipped.
+// A page can be empty (e.g. when the objects of a page are filtered out).
+func (it *Iterator[T]) NextPage(ctx context.Context) ([]*T, error) {
+	it.items = nil
+	if it.last {
+		return nil, IteratorDone
+	}
+	items, next, err := it.page(ctx, it.token)
+	if err != nil {
+		return nil, err
+	}
+	it.token, it.last = next, next == ""
+	return items, nil
+}
+
+// Next returns the next object, or IteratorDone after the last one.
+func (it *Iterator[T]) Next(ctx context.Context) (*T, error) {
+	for len(it.items) == 0 {
+		items, err := it.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		it.items = items
+	}
+	obj := it.items[0]
+	it.items = it.items[1:]
+	return obj, nil
+}
+
+// PageToken returns the token of the next page, which resumes the listing
+// with ListPage: "" before the first page and after the last one.
+func (it *Iterator[T]) PageToken() string {
+	return it.token
+}
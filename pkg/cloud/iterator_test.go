@@ -0,0 +1,114 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+)
+
+func TestListPage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pages := map[string]*ga.FirewallList{
+		"":   {Items: []*ga.Firewall{{Name: "fw-1"}, {Name: "fw-2"}}, NextPageToken: "p2"},
+		"p2": {NextPageToken: "p3"},
+		"p3": {Items: []*ga.Firewall{{Name: "fw-3"}}},
+	}
+	var queries []string
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/compute/v1/projects/proj/global/firewalls" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		queries = append(queries, q.Get("pageToken")+"/"+q.Get("maxResults")+"/"+q.Get("filter"))
+		writeJSON(t, w, pages[q.Get("pageToken")])
+	})
+	fws := NewGCE(s).Firewalls()
+
+	items, next, err := fws.ListPage(ctx, filter.Regexp("name", "fw-.*"), "", 2)
+	if err != nil || len(items) != 2 || next != "p2" {
+		t.Errorf("ListPage(\"\") = %v, %q, %v; want 2 items, \"p2\", nil", items, next, err)
+	}
+	if got, want := queries, []string{"/2/name eq fw-.*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("queries = %q; want %q", got, want)
+	}
+
+	queries = nil
+	it := NewIterator(func(ctx context.Context, token string) ([]*ga.Firewall, string, error) {
+		return fws.ListPage(ctx, filter.None, token, 0)
+	})
+	var names []string
+	for {
+		obj, err := it.Next(ctx)
+		if err == IteratorDone {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() = _, %v; want nil", err)
+		}
+		names = append(names, obj.Name)
+	}
+	if want := []string{"fw-1", "fw-2", "fw-3"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Next() returned %v; want %v", names, want)
+	}
+	if got, want := queries, []string{"//", "p2//", "p3//"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("queries = %q; want %q", got, want)
+	}
+	if _, err := it.NextPage(ctx); err != IteratorDone {
+		t.Errorf("NextPage() = _, %v; want IteratorDone", err)
+	}
+}
+
+func TestIteratorPages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a, b := &ga.Zone{Name: "a"}, &ga.Zone{Name: "b"}
+	pages := map[string]struct {
+		items []*ga.Zone
+		next  string
+	}{
+		"":  {[]*ga.Zone{a}, "1"},
+		"1": {[]*ga.Zone{b}, ""},
+	}
+	it := NewIterator(func(ctx context.Context, token string) ([]*ga.Zone, string, error) {
+		return pages[token].items, pages[token].next, nil
+	})
+	for i, want := range []struct {
+		items []*ga.Zone
+		token string
+		err   error
+	}{
+		{[]*ga.Zone{a}, "1", nil},
+		{[]*ga.Zone{b}, "", nil},
+		{nil, "", IteratorDone},
+	} {
+		items, err := it.NextPage(ctx)
+		if !reflect.DeepEqual(items, want.items) || err != want.err || it.PageToken() != want.token {
+			t.Errorf("NextPage() #%d = %v, %v, PageToken() = %q; want %v, %v, %q", i, items, err, it.PageToken(), want.items, want.err, want.token)
+		}
+	}
+}
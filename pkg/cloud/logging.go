@@ -0,0 +1,176 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// CallLog describes a call made by the GCE adapters for a CallLogger.
+type CallLog struct {
+	// ProjectID the call was made in.
+	ProjectID string
+	// Version of the API used.
+	Version meta.Version
+	// Service is the service called (e.g. "Firewalls").
+	Service string
+	// Operation is the method invoked (e.g. "Get", "Insert", "SetTarget").
+	Operation string
+	// Key of the object, nil for the calls on a collection (e.g. List).
+	Key *meta.Key
+	// Request is the request payload of a mutation (e.g. the object
+	// inserted), if any.
+	Request interface{}
+	// Response is the result of a call that is not a mutation (e.g. the
+	// object returned by Get), nil if it failed.
+	Response interface{}
+	// Duration of the call. For mutations, this includes waiting for the
+	// operation to complete.
+	Duration time.Duration
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// CallLogger receives a CallLog for every call made by the GCE adapters when
+// configured as Service.CallLogger (see GlogCallLogger).
+type CallLogger interface {
+	LogCall(ctx context.Context, l *CallLog)
+}
+
+// logCall logs the call described by rk on the object referenced by key
+// that started at start to the configured CallLogger, if any.
+func (g *Service) logCall(ctx context.Context, rk *RateLimitKey, key *meta.Key, req, resp interface{}, start time.Time, err error) {
+	if g.CallLogger == nil {
+		return
+	}
+	g.CallLogger.LogCall(ctx, &CallLog{
+		ProjectID: rk.ProjectID,
+		Version:   rk.Version,
+		Service:   rk.Service,
+		Operation: rk.Operation,
+		Key:       key,
+		Request:   req,
+		Response:  resp,
+		Duration:  time.Since(start),
+		Err:       err,
+	})
+}
+
+// DefaultRedactedFields are the JSON fields of the payloads that are
+// redacted by GlogCallLogger: the metadata of the instances and projects
+// (e.g. startup scripts and SSH keys), the private keys of the certificates
+// and the disk encryption keys.
+var DefaultRedactedFields = []string{"metadata", "commonInstanceMetadata", "privateKey", "rawKey", "rsaEncryptedKey"}
+
+// GlogCallLogger is a CallLogger logging a line per call with glog.
+type GlogCallLogger struct {
+	// Verbosity is the glog verbosity of the calls.
+	Verbosity glog.Level
+	// PayloadVerbosity is the glog verbosity at which the request and
+	// response payloads are logged as well. The payloads are not logged if
+	// it is 0.
+	PayloadVerbosity glog.Level
+	// RedactedFields are the JSON fields of the payloads whose values are
+	// replaced by "REDACTED", at any depth. DefaultRedactedFields is used
+	// if nil.
+	RedactedFields []string
+}
+
+// LogCall implements CallLogger.
+func (l *GlogCallLogger) LogCall(ctx context.Context, c *CallLog) {
+	if !glog.V(l.Verbosity) {
+		return
+	}
+	payloads := l.PayloadVerbosity > 0 && bool(glog.V(l.PayloadVerbosity))
+	glog.InfoDepth(1, l.format(c, payloads))
+}
+
+// format returns the log line of the call, with the payloads if payloads is
+// true.
+func (l *GlogCallLogger) format(c *CallLog, payloads bool) string {
+	key := "-"
+	if c.Key != nil {
+		key = c.Key.String()
+	}
+	s := fmt.Sprintf("GCE call: project=%s version=%s service=%s operation=%s key=%s duration=%v", c.ProjectID, c.Version, c.Service, c.Operation, key, c.Duration)
+	if c.Err != nil {
+		s += fmt.Sprintf(" err=%q", c.Err.Error())
+	}
+	if !payloads {
+		return s
+	}
+	fields := l.RedactedFields
+	if fields == nil {
+		fields = DefaultRedactedFields
+	}
+	if c.Request != nil {
+		s += " request=" + redactedJSON(c.Request, fields)
+	}
+	if c.Response != nil {
+		s += " response=" + redactedJSON(c.Response, fields)
+	}
+	return s
+}
+
+// redactedJSON returns obj as JSON, with the values of the given fields
+// replaced by "REDACTED" at any depth.
+func redactedJSON(obj interface{}, fields []string) string {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Sprintf("<%T: %v>", obj, err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Sprintf("<%T: %v>", obj, err)
+	}
+	redacted := map[string]bool{}
+	for _, f := range fields {
+		redacted[f] = true
+	}
+	b, err = json.Marshal(redact(v, redacted))
+	if err != nil {
+		return fmt.Sprintf("<%T: %v>", obj, err)
+	}
+	return string(b)
+}
+
+// redact replaces the values of the redacted fields of the decoded JSON
+// value v.
+func redact(v interface{}, redacted map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			if redacted[k] {
+				v[k] = "REDACTED"
+			} else {
+				v[k] = redact(fv, redacted)
+			}
+		}
+	case []interface{}:
+		for i, iv := range v {
+			v[i] = redact(iv, redacted)
+		}
+	}
+	return v
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+type fakeCallLogger struct {
+	logs []*CallLog
+}
+
+func (l *fakeCallLogger) LogCall(ctx context.Context, c *CallLog) {
+	l.logs = append(l.logs, c)
+}
+
+func TestCallLogger(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	logger := &fakeCallLogger{}
+	s.CallLogger = logger
+	gce := NewGCE(s)
+	key := meta.GlobalKey("fw")
+	obj := &ga.Firewall{Name: "fw"}
+
+	if _, err := gce.Firewalls().Get(ctx, *key); err != nil {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want nil", key, err)
+	}
+	if err := gce.Firewalls().Insert(ctx, *key, obj); err != nil {
+		t.Errorf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	if err := gce.Firewalls().Delete(ctx, *meta.GlobalKey("other")); !IsNotFound(err) {
+		t.Errorf("Firewalls().Delete() = %v; want http.StatusNotFound", err)
+	}
+
+	if len(logger.logs) != 3 {
+		t.Fatalf("got %d logs; want 3", len(logger.logs))
+	}
+	for i, tc := range []struct {
+		operation string
+		key       string
+		req, resp bool
+		err       bool
+	}{
+		{operation: "Get", key: "Key{\"fw\"}", resp: true},
+		{operation: "Insert", key: "Key{\"fw\"}", req: true},
+		{operation: "Delete", key: "Key{\"other\"}", err: true},
+	} {
+		got := logger.logs[i]
+		if got.ProjectID != "proj" || got.Version != meta.VersionGA || got.Service != "Firewalls" || got.Operation != tc.operation {
+			t.Errorf("logs[%d] = %+v; want a GA Firewalls.%s call in proj", i, got, tc.operation)
+		}
+		if got.Key == nil || got.Key.String() != tc.key {
+			t.Errorf("logs[%d].Key = %v; want %s", i, got.Key, tc.key)
+		}
+		if (got.Request != nil) != tc.req || (got.Response != nil) != tc.resp || (got.Err != nil) != tc.err {
+			t.Errorf("logs[%d] = %+v; want request %t, response %t, error %t", i, got, tc.req, tc.resp, tc.err)
+		}
+	}
+	if logger.logs[1].Request != obj {
+		t.Errorf("logs[1].Request = %v; want %v", logger.logs[1].Request, obj)
+	}
+}
+
+func TestGlogCallLoggerFormat(t *testing.T) {
+	t.Parallel()
+
+	c := &CallLog{
+		ProjectID: "proj",
+		Version:   meta.VersionGA,
+		Service:   "Instances",
+		Operation: "Insert",
+		Key:       meta.ZonalKey("vm", "us-central1-b"),
+		Request: &ga.Instance{
+			Name: "vm",
+			Metadata: &ga.Metadata{
+				Items: []*ga.MetadataItems{{Key: "startup-script"}},
+			},
+			Disks: []*ga.AttachedDisk{{
+				DiskEncryptionKey: &ga.CustomerEncryptionKey{RawKey: "secret"},
+			}},
+		},
+		Duration: time.Second,
+		Err:      errors.New("boom"),
+	}
+	for _, tc := range []struct {
+		desc     string
+		logger   *GlogCallLogger
+		payloads bool
+		want     string
+	}{
+		{
+			desc:   "no payloads",
+			logger: &GlogCallLogger{},
+			want:   `GCE call: project=proj version=ga service=Instances operation=Insert key=Key{"vm", zone: "us-central1-b"} duration=1s err="boom"`,
+		},
+		{
+			desc:     "default redaction",
+			logger:   &GlogCallLogger{},
+			payloads: true,
+			want:     `GCE call: project=proj version=ga service=Instances operation=Insert key=Key{"vm", zone: "us-central1-b"} duration=1s err="boom" request={"disks":[{"diskEncryptionKey":{"rawKey":"REDACTED"}}],"metadata":"REDACTED","name":"vm"}`,
+		},
+		{
+			desc:     "custom redaction",
+			logger:   &GlogCallLogger{RedactedFields: []string{"name"}},
+			payloads: true,
+			want:     `GCE call: project=proj version=ga service=Instances operation=Insert key=Key{"vm", zone: "us-central1-b"} duration=1s err="boom" request={"disks":[{"diskEncryptionKey":{"rawKey":"secret"}}],"metadata":{"items":[{"key":"startup-script"}]},"name":"REDACTED"}`,
+		},
+	} {
+		if got := tc.logger.format(c, tc.payloads); got != tc.want {
+			t.Errorf("%s: format() = %s; want %s", tc.desc, got, tc.want)
+		}
+	}
+	if got := (&GlogCallLogger{}).format(&CallLog{Version: meta.VersionGA}, true); !strings.Contains(got, "key=-") {
+		t.Errorf("format() = %s; want it to contain key=-", got)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+
+	ga "google.golang.org/api/compute/v1"
+)
+
+// APIGroup is a Google API (e.g. compute, container) that code can be
+// generated for. It gives the golang client package and the root Service type
+// for each version of the API.
+type APIGroup struct {
+	// Name of the API group (e.g. "compute").
+	Name string
+	// Packages are the import paths of the golang client for each version
+	// (e.g. "google.golang.org/api/compute/v1").
+	Packages map[Version]string
+	// ServiceTypes are the root Service types of the golang client for each
+	// version. The fields of the Service are the per-resource services (e.g.
+	// Addresses).
+	ServiceTypes map[Version]reflect.Type
+	// ReadOnlyScope and ReadWriteScope are the OAuth scopes that allow the
+	// read-only and all of the methods of the API (e.g.
+	// "https://www.googleapis.com/auth/compute.readonly"). If empty,
+	// CloudPlatformScope is used.
+	ReadOnlyScope  string
+	ReadWriteScope string
+}
+
+// Package returns the import path of the golang client for version.
+func (g *APIGroup) Package(version Version) (string, error) {
+	pkg, ok := g.Packages[version]
+	if !ok {
+		return "", fmt.Errorf("API group %q has no version %q", g.Name, version)
+	}
+	return pkg, nil
+}
+
+// serviceType returns the type of the per-resource service of the golang
+// client for version (e.g. *ga.AddressesService for "Addresses").
+func (g *APIGroup) serviceType(version Version, service string) (reflect.Type, error) {
+	apiType, ok := g.ServiceTypes[version]
+	if !ok {
+		return nil, fmt.Errorf("API group %q has no version %q", g.Name, version)
+	}
+	field, ok := apiType.FieldByName(service)
+	if !ok {
+		return nil, fmt.Errorf("%v %v.Service has no field %q", g.Name, version, service)
+	}
+	return field.Type, nil
+}
+
+// ComputeAPI is the GCE compute API. It is the API group of the services
+// that do not specify one. Its versions are the Versions.
+var ComputeAPI = &APIGroup{
+	Name:           "compute",
+	Packages:       versionPackages(),
+	ServiceTypes:   versionServiceTypes(),
+	ReadOnlyScope:  ga.ComputeReadonlyScope,
+	ReadWriteScope: ga.ComputeScope,
+}
+
+// APIGroups are the API groups known to the generator by name. To generate
+// code for another API, add an entry with the client packages of the API and
+// vendor the packages.
+var APIGroups = map[string]*APIGroup{
+	ComputeAPI.Name: ComputeAPI,
+}
+
+// versionForPackage returns the version of the golang client package pkg in
+// any of the APIGroups.
+func versionForPackage(pkg string) (Version, bool) {
+	for _, g := range APIGroups {
+		for v, p := range g.Packages {
+			if p == pkg {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
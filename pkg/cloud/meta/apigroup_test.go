@@ -0,0 +1,61 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestAPIGroup(t *testing.T) {
+	t.Parallel()
+
+	if pkg, err := ComputeAPI.Package(VersionGA); err != nil || pkg != "google.golang.org/api/compute/v1" {
+		t.Errorf("ComputeAPI.Package(%v) = %q, %v; want google.golang.org/api/compute/v1, nil", VersionGA, pkg, err)
+	}
+	if _, err := ComputeAPI.Package("v2"); err == nil {
+		t.Errorf("ComputeAPI.Package(v2) = _, nil; want error")
+	}
+
+	want := reflect.TypeOf(&ga.FirewallsService{})
+	if got, err := ComputeAPI.serviceType(VersionGA, "Firewalls"); err != nil || got != want {
+		t.Errorf("ComputeAPI.serviceType(%v, Firewalls) = %v, %v; want %v, nil", VersionGA, got, err, want)
+	}
+	if _, err := ComputeAPI.serviceType(VersionGA, "Widgets"); err == nil {
+		t.Errorf("ComputeAPI.serviceType(%v, Widgets) = _, nil; want error", VersionGA)
+	}
+
+	for _, tc := range []struct {
+		pkg  string
+		want Version
+		ok   bool
+	}{
+		{"google.golang.org/api/compute/v1", VersionGA, true},
+		{"google.golang.org/api/compute/v0.beta", VersionBeta, true},
+		{"google.golang.org/api/dns/v1", "", false},
+	} {
+		if got, ok := versionForPackage(tc.pkg); got != tc.want || ok != tc.ok {
+			t.Errorf("versionForPackage(%q) = %v, %t; want %v, %t", tc.pkg, got, ok, tc.want, tc.ok)
+		}
+	}
+
+	if got := (&ServiceInfo{}).APIGroup(); got != ComputeAPI {
+		t.Errorf("ServiceInfo{}.APIGroup() = %v; want ComputeAPI", got.Name)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check returns an error if any of the services do not match the golang
+// client of their API group, e.g. if the service, object or a method does not
+// exist at the version of the service. The generator calls Check before
+// generating code, as the generated code would not compile. The error lists
+// all of the invalid services.
+func Check(services []*ServiceInfo) error {
+	var errs []string
+	for _, s := range services {
+		if err := s.checkClient(); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if err := s.validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d invalid service(s):\n  %s", len(errs), strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// checkClient returns an error if the service does not match the golang
+// client.
+func (i *ServiceInfo) checkClient() (err error) {
+	name := fmt.Sprintf("service %q (%v)", i.Service, i.Version())
+
+	serviceType, err := i.APIGroup().serviceType(i.Version(), i.Service)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	switch {
+	case i.serviceType == nil:
+		return fmt.Errorf("%s: serviceType is not set", name)
+	case i.serviceType != serviceType:
+		return fmt.Errorf("%s: serviceType is %v; want %v", name, i.serviceType, serviceType)
+	}
+	if _, err := i.objectType(); err != nil {
+		return fmt.Errorf("%s: object: %v", name, err)
+	}
+
+	standard := []struct {
+		method   string
+		generate bool
+		hint     string
+	}{
+		{"Get", i.GenerateGet(), "use the NoGet option"},
+		{"Insert", i.GenerateInsert(), "use the NoInsert option"},
+		{"Delete", i.GenerateDelete(), "use the NoDelete option"},
+		{"Update", i.GenerateUpdate(), "remove the Update option"},
+		{"Patch", i.GeneratePatch(), "remove the Patch option"},
+		{"AggregatedList", i.AggregatedList(), "remove the AggregatedList option"},
+	}
+	for _, m := range standard {
+		if !m.generate {
+			continue
+		}
+		if _, ok := i.serviceType.MethodByName(m.method); !ok {
+			return fmt.Errorf("%s: method %q was not found in %v (%s)", name, m.method, i.serviceType, m.hint)
+		}
+	}
+
+	var lists []string
+	if i.GenerateList() {
+		lists = append(lists, "List")
+	}
+	for _, m := range append(lists, i.listMethods...) {
+		if _, err := newListCall(i, m); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	for _, m := range i.additionalMethods {
+		st, err := i.methodServiceType(m)
+		if err != nil {
+			return fmt.Errorf("%s: method %q: %v", name, m, err)
+		}
+		if _, ok := st.MethodByName(m); !ok {
+			return fmt.Errorf("%s: method %q was not found in %v", name, m, st)
+		}
+	}
+	// Methods() panics if a method is not supported by the generator.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %v", name, r)
+		}
+	}()
+	i.Methods()
+	return nil
+}
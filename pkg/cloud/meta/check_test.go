@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	if err := Check(AllServices); err != nil {
+		t.Errorf("Check(AllServices) = %v; want nil", err)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		si   *ServiceInfo
+		want string
+	}{
+		{
+			"unknown service",
+			&ServiceInfo{Object: "Firewall", Service: "Firewallz", serviceType: reflect.TypeOf(&ga.FirewallsService{})},
+			`has no field "Firewallz"`,
+		},
+		{
+			"serviceType of another service",
+			&ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.RoutesService{})},
+			"serviceType is *compute.RoutesService",
+		},
+		{
+			"serviceType of another version",
+			&ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&alpha.FirewallsService{})},
+			"serviceType is *compute.FirewallsService",
+		},
+		{
+			"unknown object",
+			&ServiceInfo{Object: "Firewal", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{})},
+			`type "Firewal" not found`,
+		},
+		{
+			"missing standard method",
+			&ServiceInfo{Object: "Zone", Service: "Zones", serviceType: reflect.TypeOf(&ga.ZonesService{})},
+			`method "Insert" was not found`,
+		},
+		{
+			"missing additional method",
+			&ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), additionalMethods: []string{"Frob"}},
+			`method "Frob" was not found`,
+		},
+		{
+			"unsupported additional method",
+			&ServiceInfo{Object: "InstanceGroup", Service: "InstanceGroups", keyType: Zonal, serviceType: reflect.TypeOf(&ga.InstanceGroupsService{}), additionalMethods: []string{"AggregatedList"}},
+			"arity",
+		},
+		{
+			"inconsistent options",
+			&ServiceInfo{Object: "Zone", Service: "Zones", options: ReadOnly | Update, serviceType: reflect.TypeOf(&ga.ZonesService{})},
+			`method "Update" was not found`,
+		},
+	} {
+		err := Check([]*ServiceInfo{tc.si})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: Check() = %v; want error containing %q", tc.desc, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Config is the list of services read from a configuration file. It allows
+// for a set of services to be maintained outside of AllServices. Example:
+//
+//	{
+//	  "services": [
+//	    {
+//	      "object": "Address",
+//	      "service": "Addresses",
+//	      "version": "alpha",
+//	      "keyType": "regional",
+//	      "options": ["AggregatedList"]
+//	    },
+//	    {
+//	      "object": "BackendService",
+//	      "service": "BackendServices",
+//	      "versions": ["ga", "beta", "alpha"]
+//	    },
+//	    {
+//	      "object": "InstanceGroup",
+//	      "service": "InstanceGroups",
+//	      "keyType": "zonal",
+//	      "additionalMethods": ["SetNamedPorts"],
+//	      "tags": ["instances"]
+//	    },
+//	    {
+//	      "object": "Instance",
+//	      "service": "Instances",
+//	      "keyType": "zonal",
+//	      "additionalMethods": ["AttachDisk", "SetLabels"],
+//	      "methodVersions": {"SetLabels": "alpha"}
+//	    }
+//	  ]
+//	}
+type Config struct {
+	Services []*ServiceConfig `json:"services"`
+}
+
+// ServiceConfig is the configuration for a single ServiceInfo. The fields
+// correspond to the fields of ServiceInfo.
+type ServiceConfig struct {
+	Object  string `json:"object"`
+	Service string `json:"service"`
+	// APIGroup is the name of one of the APIGroups. Defaults to "compute".
+	APIGroup string `json:"apiGroup,omitempty"`
+	// Version defaults to "ga".
+	Version Version `json:"version,omitempty"`
+	// Versions declares the service at each of the versions with the same
+	// configuration, in place of Version.
+	Versions []Version `json:"versions,omitempty"`
+	// KeyType is one of "global", "regional" or "zonal". Defaults to
+	// "global".
+	KeyType           KeyType  `json:"keyType,omitempty"`
+	AdditionalMethods []string `json:"additionalMethods,omitempty"`
+	// MethodVersions are the API versions of the additional methods that
+	// are called at a different version than the service (e.g.
+	// {"SetLabels": "alpha"}).
+	MethodVersions map[string]Version `json:"methodVersions,omitempty"`
+	// ListMethods are additional List style calls (e.g. "ListUsable"),
+	// see ListCall.
+	ListMethods []string `json:"listMethods,omitempty"`
+	// Options are the names of the options (e.g. "ReadOnly", "CustomOps").
+	Options []string `json:"options,omitempty"`
+	// AggregatedListField is the field of the scoped list containing the
+	// objects if it differs from the service name.
+	AggregatedListField string `json:"aggregatedListField,omitempty"`
+	// Snippets is custom code merged into the generated methods, keyed by
+	// injection point (e.g. "gce.Insert"). See ServiceInfo.Snippet.
+	Snippets map[string]string `json:"snippets,omitempty"`
+	// RateLimits are the rate limit hints of the operations of the service,
+	// keyed by operation (e.g. {"Get": {"qps": 50, "burst": 100}}) or "*" for
+	// the other operations.
+	RateLimits map[string]RateLimit `json:"rateLimits,omitempty"`
+	// Tags group the service with others for the generated per-tag
+	// interfaces (e.g. ["loadbalancing"]).
+	Tags []Tag `json:"tags,omitempty"`
+	// RequiredFields are the fields of the object that must be set on Insert
+	// (e.g. ["Network"]). See ServiceInfo.RequiredFields.
+	RequiredFields []string `json:"requiredFields,omitempty"`
+	// Deprecated is the deprecation notice of the service (e.g. "Use
+	// HealthChecks."). See ServiceInfo.Deprecated.
+	Deprecated string `json:"deprecated,omitempty"`
+	// DeprecatedMethods are the deprecation notices of methods of the
+	// service, keyed by method name.
+	DeprecatedMethods map[string]string `json:"deprecatedMethods,omitempty"`
+	// IdentityField is the field of the object identified by the name of the
+	// key, if it is not "Name" (e.g. "Id"), or "-" if the identity is
+	// assigned by the API. See ServiceInfo.IdentityField.
+	IdentityField string `json:"identityField,omitempty"`
+}
+
+// optionsByName maps the names used in the configuration to the options.
+var optionsByName = map[string]int{
+	"NoGet":          NoGet,
+	"NoList":         NoList,
+	"NoDelete":       NoDelete,
+	"NoInsert":       NoInsert,
+	"CustomOps":      CustomOps,
+	"AggregatedList": AggregatedList,
+	"Update":         Update,
+	"Patch":          Patch,
+	"ReadOnly":       ReadOnly,
+}
+
+// ServicesFromConfig returns the ServiceInfo for each of the services in the
+// JSON configuration data (see Config).
+func ServicesFromConfig(data []byte) ([]*ServiceInfo, error) {
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error parsing config: %v", err)
+	}
+	if len(c.Services) == 0 {
+		return nil, fmt.Errorf("config has no services")
+	}
+
+	var ret []*ServiceInfo
+	for i, sc := range c.Services {
+		services, err := sc.serviceInfos()
+		if err != nil {
+			return nil, fmt.Errorf("services[%d]: %v", i, err)
+		}
+		ret = append(ret, services...)
+	}
+	return ret, nil
+}
+
+// serviceInfos returns the ServiceInfo for each of the versions of the
+// service.
+func (sc *ServiceConfig) serviceInfos() ([]*ServiceInfo, error) {
+	if len(sc.Versions) == 0 {
+		si, err := sc.serviceInfo()
+		if err != nil {
+			return nil, err
+		}
+		return []*ServiceInfo{si}, nil
+	}
+	if sc.Version != "" {
+		return nil, fmt.Errorf("service %q: version and versions cannot both be set", sc.Service)
+	}
+	var ret []*ServiceInfo
+	seen := map[Version]bool{}
+	for _, v := range sc.Versions {
+		if seen[v] {
+			return nil, fmt.Errorf("service %q: duplicate version %q", sc.Service, v)
+		}
+		seen[v] = true
+		vc := *sc
+		vc.Version, vc.Versions = v, nil
+		si, err := vc.serviceInfo()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, si)
+	}
+	return ret, nil
+}
+
+// serviceInfo validates the configuration and returns the ServiceInfo.
+func (sc *ServiceConfig) serviceInfo() (*ServiceInfo, error) {
+	if sc.Object == "" || sc.Service == "" {
+		return nil, fmt.Errorf("object and service must be set")
+	}
+	si := &ServiceInfo{
+		Object:              sc.Object,
+		Service:             sc.Service,
+		version:             sc.Version,
+		keyType:             sc.KeyType,
+		additionalMethods:   sc.AdditionalMethods,
+		methodVersions:      sc.MethodVersions,
+		listMethods:         sc.ListMethods,
+		aggregatedListField: sc.AggregatedListField,
+		snippets:            sc.Snippets,
+		rateLimits:          sc.RateLimits,
+		tags:                sc.Tags,
+		requiredFields:      sc.RequiredFields,
+		deprecated:          sc.Deprecated,
+		deprecatedMethods:   sc.DeprecatedMethods,
+		identityField:       sc.IdentityField,
+	}
+	if si.keyType == "" {
+		si.keyType = Global
+	}
+	switch si.keyType {
+	case Global, Regional, Zonal:
+	default:
+		return nil, fmt.Errorf("service %q: invalid keyType %q", sc.Service, sc.KeyType)
+	}
+
+	group := ComputeAPI
+	if sc.APIGroup != "" {
+		var ok bool
+		if group, ok = APIGroups[sc.APIGroup]; !ok {
+			return nil, fmt.Errorf("service %q: unknown API group %q", sc.Service, sc.APIGroup)
+		}
+		si.apiGroup = group
+	}
+	if _, ok := group.ServiceTypes[si.Version()]; !ok {
+		return nil, fmt.Errorf("service %q: invalid version %q", sc.Service, sc.Version)
+	}
+	serviceType, err := group.serviceType(si.Version(), sc.Service)
+	if err != nil {
+		return nil, fmt.Errorf("service %q: %v", sc.Service, err)
+	}
+	si.serviceType = serviceType
+	for m, v := range sc.MethodVersions {
+		if !contains(sc.AdditionalMethods, m) {
+			return nil, fmt.Errorf("service %q: methodVersions: %q is not one of the additionalMethods", sc.Service, m)
+		}
+		if _, ok := group.ServiceTypes[v]; !ok {
+			return nil, fmt.Errorf("service %q: methodVersions: invalid version %q for method %q", sc.Service, v, m)
+		}
+	}
+	for _, m := range sc.AdditionalMethods {
+		st, err := si.methodServiceType(m)
+		if err != nil {
+			return nil, fmt.Errorf("service %q: method %q: %v", sc.Service, m, err)
+		}
+		if _, ok := st.MethodByName(m); !ok {
+			return nil, fmt.Errorf("service %q: method %q was not found in %v", sc.Service, m, st)
+		}
+	}
+
+	for _, m := range sc.ListMethods {
+		if _, err := newListCall(si, m); err != nil {
+			return nil, fmt.Errorf("service %q: %v", sc.Service, err)
+		}
+	}
+
+	for _, name := range sc.Options {
+		opt, ok := optionsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("service %q: invalid option %q", sc.Service, name)
+		}
+		si.options |= opt
+	}
+	if err := si.validate(); err != nil {
+		return nil, err
+	}
+	return si, nil
+}
+
+// contains is true if list contains s.
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
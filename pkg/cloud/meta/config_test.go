@@ -0,0 +1,116 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServicesFromConfig(t *testing.T) {
+	t.Parallel()
+
+	const config = `{
+	  "services": [
+	    {"object": "Address", "service": "Addresses", "version": "alpha", "keyType": "regional", "options": ["AggregatedList"]},
+	    {"object": "InstanceGroup", "service": "InstanceGroups", "apiGroup": "compute", "keyType": "zonal", "additionalMethods": ["SetNamedPorts"], "methodVersions": {"SetNamedPorts": "alpha"}},
+	    {"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "snippets": {"gce.Get": "validate(key)"}, "deprecated": "Use Regions.", "deprecatedMethods": {"List": "Use Get."}},
+	    {"object": "BackendService", "service": "BackendServices", "versions": ["ga", "alpha"], "options": ["Update"]}
+	  ]
+	}`
+	got, err := ServicesFromConfig([]byte(config))
+	if err != nil {
+		t.Fatalf("ServicesFromConfig() = _, %v; want _, nil", err)
+	}
+
+	type result struct {
+		Object, Service   string
+		Version           Version
+		KeyType           KeyType
+		Options           int
+		AdditionalMethods []string
+	}
+	want := []result{
+		{"Address", "Addresses", VersionAlpha, Regional, AggregatedList, nil},
+		{"InstanceGroup", "InstanceGroups", VersionGA, Zonal, 0, []string{"SetNamedPorts"}},
+		{"Zone", "Zones", VersionGA, Global, ReadOnly, nil},
+		{"BackendService", "BackendServices", VersionGA, Global, Update, nil},
+		{"BackendService", "BackendServices", VersionAlpha, Global, Update, nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len(ServicesFromConfig()) = %d; want %d", len(got), len(want))
+	}
+	for i, si := range got {
+		r := result{si.Object, si.Service, si.Version(), si.keyType, si.options, si.additionalMethods}
+		if !reflect.DeepEqual(r, want[i]) {
+			t.Errorf("ServicesFromConfig()[%d] = %+v; want %+v", i, r, want[i])
+		}
+	}
+	if m := got[1].Methods(); len(m) != 1 || m[0].Name() != "SetNamedPorts" {
+		t.Errorf("ServicesFromConfig()[1].Methods() = %v; want [SetNamedPorts]", m)
+	} else {
+		if v := m[0].Version(); v != VersionAlpha || !m[0].VersionOverridden() {
+			t.Errorf("SetNamedPorts.Version() = %q (overridden %t); want %q (overridden true)", v, m[0].VersionOverridden(), VersionAlpha)
+		}
+		const wantFunc = "SetNamedPorts(context.Context, meta.Key, *alpha.InstanceGroupsSetNamedPortsRequest, ...Option) error"
+		if f := m[0].InterfaceFunc(); f != wantFunc {
+			t.Errorf("SetNamedPorts.InterfaceFunc() = %q; want %q", f, wantFunc)
+		}
+	}
+	if got := got[2].Snippet("gce.Get"); got != "validate(key)" {
+		t.Errorf("ServicesFromConfig()[2].Snippet(gce.Get) = %q; want %q", got, "validate(key)")
+	}
+	if got := got[2].Deprecated(); got != "Use Regions." {
+		t.Errorf("ServicesFromConfig()[2].Deprecated() = %q; want %q", got, "Use Regions.")
+	}
+	if got := got[2].MethodDeprecated("List"); got != "Use Get." {
+		t.Errorf("ServicesFromConfig()[2].MethodDeprecated(List) = %q; want %q", got, "Use Get.")
+	}
+}
+
+func TestServicesFromConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc   string
+		config string
+	}{
+		{"invalid JSON", `{`},
+		{"no services", `{"services": []}`},
+		{"missing object", `{"services": [{"service": "Zones"}]}`},
+		{"invalid version", `{"services": [{"object": "Zone", "service": "Zones", "version": "v2"}]}`},
+		{"unknown API group", `{"services": [{"object": "Zone", "service": "Zones", "apiGroup": "dns"}]}`},
+		{"invalid key type", `{"services": [{"object": "Zone", "service": "Zones", "keyType": "local"}]}`},
+		{"unknown service", `{"services": [{"object": "Zone", "service": "Zonez"}]}`},
+		{"invalid list method", `{"services": [{"object": "Zone", "service": "Zones", "listMethods": ["Get"]}]}`},
+		{"unknown method", `{"services": [{"object": "Zone", "service": "Zones", "additionalMethods": ["Frob"]}]}`},
+		{"read-only with Update", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly", "Update"]}]}`},
+		{"invalid snippet", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "snippets": {"mock.Delete": "x"}}]}`},
+		{"deprecated method that is not generated", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "deprecatedMethods": {"Delete": "x"}}]}`},
+		{"invalid identity field", `{"services": [{"object": "Firewall", "service": "Firewalls", "identityField": "Allowed"}]}`},
+		{"invalid option", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadWrite"]}]}`},
+		{"version and versions", `{"services": [{"object": "Zone", "service": "Zones", "version": "ga", "versions": ["alpha"]}]}`},
+		{"duplicate versions", `{"services": [{"object": "Zone", "service": "Zones", "versions": ["ga", "ga"]}]}`},
+		{"invalid versions", `{"services": [{"object": "Zone", "service": "Zones", "versions": ["ga", "v2"]}]}`},
+		{"method version without method", `{"services": [{"object": "InstanceGroup", "service": "InstanceGroups", "keyType": "zonal", "methodVersions": {"SetNamedPorts": "alpha"}}]}`},
+		{"invalid method version", `{"services": [{"object": "InstanceGroup", "service": "InstanceGroups", "keyType": "zonal", "additionalMethods": ["SetNamedPorts"], "methodVersions": {"SetNamedPorts": "v2"}}]}`},
+	} {
+		if _, err := ServicesFromConfig([]byte(tc.config)); err == nil {
+			t.Errorf("%s: ServicesFromConfig(%q) = _, nil; want error", tc.desc, tc.config)
+		}
+	}
+}
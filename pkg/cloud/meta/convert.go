@@ -0,0 +1,182 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Conversion describes the generated conversion of an object between two
+// API versions (e.g. alpha.BackendService to ga.BackendService).
+type Conversion struct {
+	// From and To are services with the object at the source and
+	// destination versions.
+	From, To *ServiceInfo
+	// Fields are the fields present in both versions.
+	Fields []*ConversionField
+	// Dropped are the fields of From that do not exist in To.
+	Dropped []string
+}
+
+// ConversionField is a field copied by a Conversion.
+type ConversionField struct {
+	Name string
+	// Kind is how the field is copied:
+	//  "value" -- a basic type that is assigned directly.
+	//  "slice" -- a slice of a basic type, copied element by element.
+	//  "map"   -- a map between basic types, copied entry by entry.
+	//  "json"  -- any other type. The field types differ between the
+	//             versions, so the field is converted via JSON.
+	Kind string
+	// GoType is the golang type of a "slice" or "map" field (e.g.
+	// "[]string").
+	GoType string
+	// Nilable is true if the field can be nil (pointer, slice or map).
+	Nilable bool
+}
+
+// FuncName is the name of the generated function (e.g.
+// "AlphaBackendServiceToGA").
+func (c *Conversion) FuncName() string {
+	return c.From.VersionTitle() + c.From.Object + "To" + c.To.VersionTitle()
+}
+
+// Conversions returns the conversions between all of the versions of each
+// object type used by services. Objects shared by more than one service
+// (e.g. Address for Addresses and GlobalAddresses) are converted once.
+func Conversions(services []*ServiceInfo) ([]*Conversion, error) {
+	// Object => version => a service with that version.
+	byObject := map[string]map[Version]*ServiceInfo{}
+	for _, s := range services {
+		if byObject[s.Object] == nil {
+			byObject[s.Object] = map[Version]*ServiceInfo{}
+		}
+		if _, ok := byObject[s.Object][s.Version()]; !ok {
+			byObject[s.Object][s.Version()] = s
+		}
+	}
+	var objects []string
+	for o := range byObject {
+		objects = append(objects, o)
+	}
+	sort.Strings(objects)
+
+	var ret []*Conversion
+	for _, o := range objects {
+		for _, from := range AllVersions {
+			for _, to := range AllVersions {
+				f, t := byObject[o][from], byObject[o][to]
+				if from == to || f == nil || t == nil {
+					continue
+				}
+				c, err := newConversion(f, t)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, c)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func newConversion(from, to *ServiceInfo) (*Conversion, error) {
+	ft, err := from.objectType()
+	if err != nil {
+		return nil, err
+	}
+	tt, err := to.objectType()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Conversion{From: from, To: to}
+	for i := 0; i < ft.NumField(); i++ {
+		f := ft.Field(i)
+		// ServerResponse is the HTTP response metadata, not part of the
+		// object.
+		if f.Name == "ServerResponse" {
+			continue
+		}
+		tf, ok := tt.FieldByName(f.Name)
+		if !ok {
+			c.Dropped = append(c.Dropped, f.Name)
+			continue
+		}
+		field := &ConversionField{Name: f.Name, Kind: "json"}
+		switch f.Type.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			field.Nilable = true
+		}
+		switch {
+		case f.Type == tf.Type && isBasic(f.Type):
+			field.Kind = "value"
+		case f.Type == tf.Type && f.Type.Kind() == reflect.Slice && isBasic(f.Type.Elem()):
+			field.Kind = "slice"
+			field.GoType = f.Type.String()
+		case f.Type == tf.Type && f.Type.Kind() == reflect.Map && isBasic(f.Type.Key()) && isBasic(f.Type.Elem()):
+			field.Kind = "map"
+			field.GoType = f.Type.String()
+		}
+		c.Fields = append(c.Fields, field)
+	}
+	return c, nil
+}
+
+// isBasic is true if t is a predeclared boolean, numeric or string type.
+func isBasic(t reflect.Type) bool {
+	if t.PkgPath() != "" {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// objectType returns the golang type of the object (e.g. ga.Address). It
+// is found in the arguments or results of the calls of the service.
+func (i *ServiceInfo) objectType() (reflect.Type, error) {
+	isObject := func(t reflect.Type) bool {
+		return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && t.Elem().Name() == i.Object
+	}
+	for j := 0; j < i.serviceType.NumMethod(); j++ {
+		m := i.serviceType.Method(j)
+		for k := 0; k < m.Type.NumIn(); k++ {
+			if isObject(m.Type.In(k)) {
+				return m.Type.In(k).Elem(), nil
+			}
+		}
+		if m.Type.NumOut() != 1 {
+			continue
+		}
+		do, ok := m.Type.Out(0).MethodByName("Do")
+		if !ok || do.Type.NumOut() == 0 {
+			continue
+		}
+		if isObject(do.Type.Out(0)) {
+			return do.Type.Out(0).Elem(), nil
+		}
+	}
+	return nil, fmt.Errorf("type %q not found in the calls of %v", i.Object, i.serviceType)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConversions(t *testing.T) {
+	t.Parallel()
+
+	conversions, err := Conversions(AllServices)
+	if err != nil {
+		t.Fatalf("Conversions(AllServices) = _, %v; want _, nil", err)
+	}
+	byName := map[string]*Conversion{}
+	for _, c := range conversions {
+		if _, ok := byName[c.FuncName()]; ok {
+			t.Errorf("Conversions(AllServices) has duplicate conversion %q", c.FuncName())
+		}
+		byName[c.FuncName()] = c
+	}
+
+	c, ok := byName["AlphaAddressToGA"]
+	if !ok {
+		t.Fatalf("Conversions(AllServices) does not contain AlphaAddressToGA")
+	}
+	kinds := map[string]string{}
+	for _, f := range c.Fields {
+		kinds[f.Name] = f.Kind
+	}
+	for name, want := range map[string]string{
+		"Name":            "value",
+		"Id":              "value",
+		"Users":           "slice",
+		"ForceSendFields": "slice",
+	} {
+		if kinds[name] != want {
+			t.Errorf("AlphaAddressToGA field %q kind = %q; want %q", name, kinds[name], want)
+		}
+	}
+	if _, ok := kinds["ServerResponse"]; ok {
+		t.Errorf("AlphaAddressToGA converts ServerResponse; want skipped")
+	}
+	if want := []string{"LabelFingerprint", "Labels", "NetworkTier"}; !reflect.DeepEqual(c.Dropped, want) {
+		t.Errorf("AlphaAddressToGA.Dropped = %v; want %v", c.Dropped, want)
+	}
+
+	// Zones only exist at GA, so there is nothing to convert.
+	for name := range byName {
+		if byName[name].From.Object == "Zone" {
+			t.Errorf("Conversions(AllServices) has conversion %q for GA-only object Zone", name)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DeepCopy describes the generated deep copy of a struct type used by the
+// objects of the services. This is the object itself (e.g. ga.UrlMap) or a
+// type nested in the object (e.g. ga.UrlMapTest).
+type DeepCopy struct {
+	// Type is the name of the struct type (e.g. "UrlMap").
+	Type string
+	// Object is true if the type is the object of a service. Only the copy
+	// functions of the objects are exported.
+	Object bool
+	// Fields are the fields that are copied.
+	Fields []*DeepCopyField
+	// Skipped are the fields that are not copied (ServerResponse).
+	Skipped []string
+
+	// s is a service with the type in its API version.
+	s *ServiceInfo
+}
+
+// DeepCopyField is a field copied by a DeepCopy.
+type DeepCopyField struct {
+	Name string
+	// Kind is how the field is copied:
+	//  "value"       -- a basic type that is assigned directly.
+	//  "pointer"     -- a pointer to a basic type.
+	//  "slice"       -- a slice of a basic type, copied element by element.
+	//  "map"         -- a map between basic types, copied entry by entry.
+	//  "struct"      -- a pointer to a struct, copied with Func.
+	//  "structSlice" -- a slice of pointers to structs, copied with Func.
+	Kind string
+	// GoType is the golang type of a "map" or "structSlice" field (e.g.
+	// "map[string]string").
+	GoType string
+	// Func is the copy function of the struct of a "struct" or "structSlice"
+	// field.
+	Func string
+}
+
+// FuncName is the name of the generated function (e.g. "CopyGAUrlMap" or
+// "copyGAUrlMapTest").
+func (d *DeepCopy) FuncName() string {
+	return deepCopyFuncName(d.s, d.Type, d.Object)
+}
+
+// FQType is the fully qualified name of the type (e.g. "ga.UrlMap").
+func (d *DeepCopy) FQType() string {
+	return fmt.Sprintf("%v.%v", d.s.Version(), d.Type)
+}
+
+// CopyFunc is the name of the generated deep copy function of the object of
+// the service (e.g. "CopyGAAddress").
+func (i *ServiceInfo) CopyFunc() string {
+	return deepCopyFuncName(i, i.Object, true)
+}
+
+func deepCopyFuncName(s *ServiceInfo, typ string, object bool) string {
+	if object {
+		return "Copy" + s.VersionTitle() + typ
+	}
+	return "copy" + s.VersionTitle() + typ
+}
+
+// DeepCopies returns the deep copies of the object types used by services
+// and of the struct types nested in them. Each type is copied once, even if
+// it is used by more than one service or object.
+func DeepCopies(services []*ServiceInfo) ([]*DeepCopy, error) {
+	// Sort by object and version so the output is stable.
+	sorted := append([]*ServiceInfo{}, services...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Object != sorted[j].Object {
+			return sorted[i].Object < sorted[j].Object
+		}
+		return versionIndex(sorted[i].Version()) < versionIndex(sorted[j].Version())
+	})
+
+	type pending struct {
+		t reflect.Type
+		s *ServiceInfo
+	}
+	objects := map[reflect.Type]bool{}
+	var queue []pending
+	for _, s := range sorted {
+		t, err := s.objectType()
+		if err != nil {
+			return nil, err
+		}
+		if !objects[t] {
+			objects[t] = true
+			queue = append(queue, pending{t, s})
+		}
+	}
+
+	var ret []*DeepCopy
+	seen := map[reflect.Type]bool{}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if seen[p.t] {
+			continue
+		}
+		seen[p.t] = true
+
+		d, nested, err := newDeepCopy(p.s, p.t, objects)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, d)
+		for _, t := range nested {
+			queue = append(queue, pending{t, p.s})
+		}
+	}
+	return ret, nil
+}
+
+// newDeepCopy returns the DeepCopy of the struct type t from the API version
+// of s and the struct types of its fields.
+func newDeepCopy(s *ServiceInfo, t reflect.Type, objects map[reflect.Type]bool) (*DeepCopy, []reflect.Type, error) {
+	d := &DeepCopy{Type: t.Name(), Object: objects[t], s: s}
+	var nested []reflect.Type
+	structFunc := func(st reflect.Type) (string, error) {
+		if st.PkgPath() != t.PkgPath() {
+			return "", fmt.Errorf("%v: type %v is not in the package of the object", t, st)
+		}
+		nested = append(nested, st)
+		return deepCopyFuncName(s, st.Name(), objects[st]), nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		// ServerResponse is the HTTP response metadata, not part of the
+		// object.
+		if f.Name == "ServerResponse" {
+			d.Skipped = append(d.Skipped, f.Name)
+			continue
+		}
+		field := &DeepCopyField{Name: f.Name}
+		ft := f.Type
+		switch {
+		case f.PkgPath != "":
+			return nil, nil, fmt.Errorf("%v: unexported field %q cannot be copied", t, f.Name)
+		case isBasic(ft):
+			field.Kind = "value"
+		case ft.Kind() == reflect.Ptr && isBasic(ft.Elem()):
+			field.Kind = "pointer"
+		case ft.Kind() == reflect.Slice && isBasic(ft.Elem()):
+			field.Kind = "slice"
+		case ft.Kind() == reflect.Map && ft.Name() == "" && isBasic(ft.Key()) && isBasic(ft.Elem()):
+			field.Kind = "map"
+			field.GoType = ft.String()
+		case ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct:
+			fn, err := structFunc(ft.Elem())
+			if err != nil {
+				return nil, nil, err
+			}
+			field.Kind = "struct"
+			field.Func = fn
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Ptr && ft.Elem().Elem().Kind() == reflect.Struct:
+			fn, err := structFunc(ft.Elem().Elem())
+			if err != nil {
+				return nil, nil, err
+			}
+			field.Kind = "structSlice"
+			field.GoType = fmt.Sprintf("[]*%v.%v", s.Version(), ft.Elem().Elem().Name())
+			field.Func = fn
+		default:
+			return nil, nil, fmt.Errorf("%v: field %q of type %v is not supported", t, f.Name, ft)
+		}
+		d.Fields = append(d.Fields, field)
+	}
+	return d, nested, nil
+}
+
+// versionIndex returns the position of v in AllVersions.
+func versionIndex(v Version) int {
+	for i, av := range AllVersions {
+		if av == v {
+			return i
+		}
+	}
+	return len(AllVersions)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeepCopies(t *testing.T) {
+	t.Parallel()
+
+	copies, err := DeepCopies(AllServices)
+	if err != nil {
+		t.Fatalf("DeepCopies(AllServices) = _, %v; want _, nil", err)
+	}
+	byName := map[string]*DeepCopy{}
+	for _, d := range copies {
+		if _, ok := byName[d.FuncName()]; ok {
+			t.Errorf("DeepCopies(AllServices) has duplicate copy %q", d.FuncName())
+		}
+		byName[d.FuncName()] = d
+	}
+
+	// Each version of an object and the types nested in it are copied.
+	for _, name := range []string{"CopyGAAddress", "CopyAlphaAddress", "CopyBetaAddress", "copyGAUrlMapTest"} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("DeepCopies(AllServices) does not contain %q", name)
+		}
+	}
+
+	d, ok := byName["CopyGAUrlMap"]
+	if !ok {
+		t.Fatalf("DeepCopies(AllServices) does not contain CopyGAUrlMap")
+	}
+	if d.FQType() != "ga.UrlMap" || !d.Object {
+		t.Errorf("CopyGAUrlMap: FQType() = %q, Object = %t; want %q, true", d.FQType(), d.Object, "ga.UrlMap")
+	}
+	fields := map[string]*DeepCopyField{}
+	for _, f := range d.Fields {
+		fields[f.Name] = f
+	}
+	for _, tc := range []DeepCopyField{
+		{Name: "Name", Kind: "value"},
+		{Name: "ForceSendFields", Kind: "slice"},
+		{Name: "Tests", Kind: "structSlice", GoType: "[]*ga.UrlMapTest", Func: "copyGAUrlMapTest"},
+	} {
+		f, ok := fields[tc.Name]
+		if !ok {
+			t.Errorf("CopyGAUrlMap does not copy field %q", tc.Name)
+			continue
+		}
+		if !reflect.DeepEqual(*f, tc) {
+			t.Errorf("CopyGAUrlMap field %q = %+v; want %+v", tc.Name, *f, tc)
+		}
+	}
+	if want := []string{"ServerResponse"}; !reflect.DeepEqual(d.Skipped, want) {
+		t.Errorf("CopyGAUrlMap.Skipped = %v; want %v", d.Skipped, want)
+	}
+}
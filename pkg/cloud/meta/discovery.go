@@ -0,0 +1,304 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// standardMethods are generated from the options rather than as additional
+// methods.
+var standardMethods = map[string]bool{
+	"get":            true,
+	"list":           true,
+	"insert":         true,
+	"delete":         true,
+	"aggregatedList": true,
+}
+
+// discoveryDoc is the subset of a Google API discovery document used to
+// derive the ServiceInfo for a resource.
+type discoveryDoc struct {
+	Resources map[string]*discoveryResource `json:"resources"`
+	Schemas   map[string]*discoverySchema   `json:"schemas"`
+}
+
+type discoveryResource struct {
+	Methods map[string]*discoveryMethod `json:"methods"`
+}
+
+type discoveryMethod struct {
+	Description    string                         `json:"description"`
+	Parameters     map[string]*discoveryParameter `json:"parameters"`
+	ParameterOrder []string                       `json:"parameterOrder"`
+	Request        *discoverySchema               `json:"request"`
+	Response       *discoverySchema               `json:"response"`
+}
+
+type discoveryParameter struct {
+	Description string `json:"description"`
+}
+
+type discoverySchema struct {
+	Description          string                      `json:"description"`
+	Ref                  string                      `json:"$ref"`
+	Properties           map[string]*discoverySchema `json:"properties"`
+	Items                *discoverySchema            `json:"items"`
+	AdditionalProperties *discoverySchema            `json:"additionalProperties"`
+}
+
+// ref returns the name of the referenced schema or "" if s is nil.
+func (s *discoverySchema) ref() string {
+	if s == nil {
+		return ""
+	}
+	return s.Ref
+}
+
+// ServicesFromDiscovery returns the ServiceInfo for the resources named in
+// allow (e.g. "addresses", "backendServices"), derived from the discovery
+// document doc of the API group for the given version. The service names, object
+// types, key type and methods are taken from the document. Options that
+// cannot be derived from the document (CustomOps) are copied from the
+// matching entry in AllServices, if any.
+func ServicesFromDiscovery(group *APIGroup, version Version, doc []byte, allow []string) ([]*ServiceInfo, error) {
+	var dd discoveryDoc
+	if err := json.Unmarshal(doc, &dd); err != nil {
+		return nil, fmt.Errorf("error parsing discovery document for %q: %v", version, err)
+	}
+	if _, ok := group.ServiceTypes[version]; !ok {
+		return nil, fmt.Errorf("invalid version %q for API group %q", version, group.Name)
+	}
+
+	var ret []*ServiceInfo
+	for _, name := range allow {
+		res, ok := dd.Resources[name]
+		if !ok {
+			return nil, fmt.Errorf("resource %q not found in the %q discovery document", name, version)
+		}
+		si, err := serviceFromDiscovery(&dd, group, version, name, res)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, si)
+	}
+	return ret, nil
+}
+
+func serviceFromDiscovery(dd *discoveryDoc, group *APIGroup, version Version, name string, res *discoveryResource) (*ServiceInfo, error) {
+	si := &ServiceInfo{
+		Service: upperFirst(name),
+		version: version,
+	}
+	if group != ComputeAPI {
+		si.apiGroup = group
+	}
+	serviceType, err := group.serviceType(version, si.Service)
+	if err != nil {
+		return nil, fmt.Errorf("resource %q: %v", name, err)
+	}
+	si.serviceType = serviceType
+
+	// The object type is the response of Get(), falling back to the request
+	// of Insert().
+	switch {
+	case res.Methods["get"] != nil && res.Methods["get"].Response.ref() != "":
+		si.Object = res.Methods["get"].Response.ref()
+	case res.Methods["insert"] != nil && res.Methods["insert"].Request.ref() != "":
+		si.Object = res.Methods["insert"].Request.ref()
+	default:
+		return nil, fmt.Errorf("resource %q: cannot determine the object type", name)
+	}
+
+	// The key is given by the parameters of the calls that take the
+	// resource name: project, [zone|region], name.
+	var keyParams []string
+	for _, m := range []string{"get", "delete"} {
+		if dm := res.Methods[m]; dm != nil {
+			keyParams = dm.ParameterOrder
+			break
+		}
+	}
+	si.keyType = keyTypeFromParams(keyParams, res)
+
+	hasKey := func(dm *discoveryMethod) bool {
+		return dm != nil && isKeyed(dm.ParameterOrder, si.keyType)
+	}
+	hasScope := func(dm *discoveryMethod) bool {
+		// Insert and List take the location without the name.
+		return dm != nil && len(dm.ParameterOrder) == len(keyParamNames(si.keyType))
+	}
+	if !hasKey(res.Methods["get"]) {
+		si.options |= NoGet
+	}
+	if !hasKey(res.Methods["delete"]) {
+		si.options |= NoDelete
+	}
+	if !hasScope(res.Methods["insert"]) || res.Methods["insert"].Request.ref() != si.Object {
+		si.options |= NoInsert
+	}
+	if !hasScope(res.Methods["list"]) || res.Methods["list"].Response.ref() != si.Object+"List" {
+		si.options |= NoList
+	}
+	// Update and Patch are generated as standard methods if they take the
+	// key and the object.
+	standard := map[string]bool{}
+	for m, opt := range map[string]int{"update": Update, "patch": Patch} {
+		dm := res.Methods[m]
+		if hasKey(dm) && len(dm.ParameterOrder) == len(keyParamNames(si.keyType))+1 && dm.Request.ref() == si.Object {
+			si.options |= opt
+			standard[m] = true
+		}
+	}
+	if field, ok := aggregatedListField(dd, res, si.Object); ok {
+		si.options |= AggregatedList
+		if field != si.Service {
+			si.aggregatedListField = field
+		}
+	}
+
+	// Additional methods are the remaining methods that operate on a single
+	// resource and are present in the golang client.
+	var methods []string
+	for m, dm := range res.Methods {
+		if standardMethods[m] || standard[m] || !hasKey(dm) {
+			continue
+		}
+		if _, ok := si.serviceType.MethodByName(upperFirst(m)); !ok {
+			continue
+		}
+		methods = append(methods, upperFirst(m))
+	}
+	sort.Strings(methods)
+	si.additionalMethods = methods
+
+	// Resources without any calls returning an operation cannot be mutated.
+	mutable := false
+	for _, dm := range res.Methods {
+		if dm.Response.ref() == "Operation" {
+			mutable = true
+		}
+	}
+	if !mutable {
+		si.options |= ReadOnly
+	}
+
+	for _, s := range AllServices {
+		if s.APIGroup() == group && s.Service == si.Service && s.Version() == version {
+			si.options |= s.options & CustomOps
+		}
+	}
+
+	return si, nil
+}
+
+// keyTypeFromParams returns the KeyType for the parameters of a keyed call.
+// If there are no keyed calls, the key type is derived from the parameters
+// of Insert() or List().
+func keyTypeFromParams(params []string, res *discoveryResource) KeyType {
+	if params == nil {
+		for _, m := range []string{"insert", "list"} {
+			if dm := res.Methods[m]; dm != nil {
+				params = append(append([]string{}, dm.ParameterOrder...), "name")
+				break
+			}
+		}
+	}
+	if len(params) == 3 {
+		switch params[1] {
+		case "zone":
+			return Zonal
+		case "region":
+			return Regional
+		}
+	}
+	return Global
+}
+
+// keyParamNames returns the leading parameters (project and location) used
+// by calls for the given key type.
+func keyParamNames(kt KeyType) []string {
+	switch kt {
+	case Zonal:
+		return []string{"project", "zone"}
+	case Regional:
+		return []string{"project", "region"}
+	}
+	return []string{"project"}
+}
+
+// isKeyed returns true if params start with the parameters for a key of the
+// given type (project, [location], name).
+func isKeyed(params []string, kt KeyType) bool {
+	prefix := keyParamNames(kt)
+	if len(params) < len(prefix)+1 {
+		return false
+	}
+	for i, p := range prefix {
+		if params[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregatedListField returns the name of the field of the scoped list that
+// contains the objects. ok is false if the aggregated list is not in the
+// standard form.
+func aggregatedListField(dd *discoveryDoc, res *discoveryResource, object string) (string, bool) {
+	dm := res.Methods["aggregatedList"]
+	if dm == nil || len(dm.ParameterOrder) != 1 || dm.Response.ref() != object+"AggregatedList" {
+		return "", false
+	}
+	list := dd.Schemas[dm.Response.ref()]
+	if list == nil || list.Properties["items"] == nil || list.Properties["items"].AdditionalProperties == nil {
+		return "", false
+	}
+	scoped := dd.Schemas[list.Properties["items"].AdditionalProperties.ref()]
+	if scoped == nil {
+		return "", false
+	}
+	for name, p := range scoped.Properties {
+		if name == "warning" || p.Items.ref() != object {
+			continue
+		}
+		return upperFirst(name), true
+	}
+	return "", false
+}
+
+// lowerFirst returns s with the first letter in lower case, which is how the
+// discovery document names resources and methods (e.g. "SetTarget" =>
+// "setTarget").
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// upperFirst returns s with the first letter capitalized, which is how the
+// golang client names resources and methods (e.g. "setTarget" => "SetTarget").
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
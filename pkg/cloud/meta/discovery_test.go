@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testDiscoveryDoc = `{
+ "resources": {
+  "addresses": {
+   "methods": {
+    "aggregatedList": {"parameterOrder": ["project"], "response": {"$ref": "AddressAggregatedList"}},
+    "delete": {"parameterOrder": ["project", "region", "address"], "response": {"$ref": "Operation"}},
+    "get": {"parameterOrder": ["project", "region", "address"], "response": {"$ref": "Address"}},
+    "insert": {"parameterOrder": ["project", "region"], "request": {"$ref": "Address"}, "response": {"$ref": "Operation"}},
+    "list": {"parameterOrder": ["project", "region"], "response": {"$ref": "AddressList"}},
+    "patch": {"parameterOrder": ["project", "region", "address"], "request": {"$ref": "Address"}, "response": {"$ref": "Operation"}}
+   }
+  },
+  "instances": {
+   "methods": {
+    "attachDisk": {"parameterOrder": ["project", "zone", "instance"], "request": {"$ref": "AttachedDisk"}, "response": {"$ref": "Operation"}},
+    "get": {"parameterOrder": ["project", "zone", "instance"], "response": {"$ref": "Instance"}},
+    "reset": {"parameterOrder": ["project", "zone", "instance"], "response": {"$ref": "Operation"}},
+    "notInClient": {"parameterOrder": ["project", "zone", "instance"], "response": {"$ref": "Operation"}}
+   }
+  },
+  "projects": {
+   "methods": {
+    "get": {"parameterOrder": ["project"], "response": {"$ref": "Project"}},
+    "setCommonInstanceMetadata": {"parameterOrder": ["project"], "request": {"$ref": "Metadata"}, "response": {"$ref": "Operation"}}
+   }
+  },
+  "zones": {
+   "methods": {
+    "get": {"parameterOrder": ["project", "zone"], "response": {"$ref": "Zone"}},
+    "list": {"parameterOrder": ["project"], "response": {"$ref": "ZoneList"}}
+   }
+  }
+ },
+ "schemas": {
+  "AddressAggregatedList": {"properties": {"items": {"additionalProperties": {"$ref": "AddressesScopedList"}}}},
+  "AddressesScopedList": {"properties": {"addresses": {"items": {"$ref": "Address"}}, "warning": {}}}
+ }
+}`
+
+func TestServicesFromDiscovery(t *testing.T) {
+	t.Parallel()
+
+	got, err := ServicesFromDiscovery(ComputeAPI, VersionGA, []byte(testDiscoveryDoc), []string{"addresses", "instances", "projects", "zones"})
+	if err != nil {
+		t.Fatalf("ServicesFromDiscovery() = _, %v; want _, nil", err)
+	}
+
+	type result struct {
+		Object, Service   string
+		KeyType           KeyType
+		Options           int
+		AdditionalMethods []string
+	}
+	want := []result{
+		{"Address", "Addresses", Regional, AggregatedList | Patch, nil},
+		{"Instance", "Instances", Zonal, NoList | NoInsert | NoDelete, []string{"AttachDisk", "Reset"}},
+		{"Project", "Projects", Global, NoGet | NoList | NoInsert | NoDelete | CustomOps, nil},
+		{"Zone", "Zones", Global, ReadOnly, nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len(ServicesFromDiscovery()) = %d; want %d", len(got), len(want))
+	}
+	for i, si := range got {
+		r := result{si.Object, si.Service, si.keyType, si.options, si.additionalMethods}
+		if !reflect.DeepEqual(r, want[i]) {
+			t.Errorf("ServicesFromDiscovery()[%d] = %+v; want %+v", i, r, want[i])
+		}
+		if si.Version() != VersionGA {
+			t.Errorf("ServicesFromDiscovery()[%d].Version() = %v; want %v", i, si.Version(), VersionGA)
+		}
+	}
+
+	if _, err := ServicesFromDiscovery(ComputeAPI, VersionGA, []byte(testDiscoveryDoc), []string{"networks"}); err == nil {
+		t.Errorf("ServicesFromDiscovery(_, _, _, [networks]) = _, nil; want error")
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Docs are the descriptions of the objects, methods and parameters of an API
+// version, taken from its discovery document. They are used to comment the
+// generated code. The methods of a nil *Docs return no descriptions.
+type Docs struct {
+	dd discoveryDoc
+}
+
+// DocsFromDiscovery returns the Docs of the discovery document doc.
+func DocsFromDiscovery(doc []byte) (*Docs, error) {
+	d := &Docs{}
+	if err := json.Unmarshal(doc, &d.dd); err != nil {
+		return nil, fmt.Errorf("error parsing discovery document: %v", err)
+	}
+	return d, nil
+}
+
+// Object returns the description of the object of the service (e.g.
+// "Represents a Firewall resource.").
+func (d *Docs) Object(s *ServiceInfo) string {
+	if d == nil || d.dd.Schemas[s.Object] == nil {
+		return ""
+	}
+	return cleanDescription(d.dd.Schemas[s.Object].Description)
+}
+
+// Method returns the description of the method of the service (e.g. "Get",
+// "SetNamedPorts").
+func (d *Docs) Method(s *ServiceInfo, method string) string {
+	if dm := d.method(s, method); dm != nil {
+		return cleanDescription(dm.Description)
+	}
+	return ""
+}
+
+// Params returns the descriptions of the parameters of the additional method
+// m that follow the key, i.e. the descriptions of arg0, arg1, ... A parameter
+// without a description is "".
+func (d *Docs) Params(m *Method) []string {
+	n := m.m.Type.NumIn() - m.argsSkip()
+	dm := d.method(m.ServiceInfo, m.Name())
+	if n <= 0 || dm == nil {
+		return nil
+	}
+	// The golang client takes the parameters in parameterOrder followed by
+	// the request body, if any.
+	var extra []string
+	if keyed := len(keyParamNames(m.keyType)) + 1; len(dm.ParameterOrder) > keyed {
+		extra = dm.ParameterOrder[keyed:]
+	}
+	ret := make([]string, n)
+	for i := range ret {
+		switch {
+		case i < len(extra):
+			if p := dm.Parameters[extra[i]]; p != nil {
+				ret[i] = cleanDescription(p.Description)
+			}
+		case dm.Request.ref() != "" && d.dd.Schemas[dm.Request.ref()] != nil:
+			ret[i] = cleanDescription(d.dd.Schemas[dm.Request.ref()].Description)
+		}
+	}
+	return ret
+}
+
+// method returns the method of the resource of the service in the discovery
+// document, or nil if it is not found.
+func (d *Docs) method(s *ServiceInfo, method string) *discoveryMethod {
+	if d == nil {
+		return nil
+	}
+	res := d.dd.Resources[lowerFirst(s.Service)]
+	if res == nil {
+		return nil
+	}
+	return res.Methods[lowerFirst(method)]
+}
+
+// annotationRE matches the annotations in the descriptions that are meant for
+// the client generators, e.g. "(== resource_for v1.addresses ==)".
+var annotationRE = regexp.MustCompile(`\s*\(==[^=]*==\)`)
+
+// cleanDescription removes the annotations from the description d.
+func cleanDescription(d string) string {
+	return strings.TrimSpace(annotationRE.ReplaceAllString(d, ""))
+}
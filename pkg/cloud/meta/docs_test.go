@@ -0,0 +1,93 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testDocsDiscoveryDoc = `{
+ "resources": {
+  "instances": {
+   "methods": {
+    "get": {"description": "Returns the specified Instance resource."},
+    "attachDisk": {"description": "Attaches a disk.", "parameterOrder": ["project", "zone", "instance"], "request": {"$ref": "AttachedDisk"}},
+    "detachDisk": {
+     "description": "Detaches a disk.",
+     "parameterOrder": ["project", "zone", "instance", "deviceName"],
+     "parameters": {"deviceName": {"description": "Disk device name to detach."}}
+    }
+   }
+  }
+ },
+ "schemas": {
+  "AttachedDisk": {"description": "An instance-attached disk resource."},
+  "Instance": {"description": "An Instance resource. (== resource_for v1.instances ==)"}
+ }
+}`
+
+func TestDocs(t *testing.T) {
+	t.Parallel()
+
+	d, err := DocsFromDiscovery([]byte(testDocsDiscoveryDoc))
+	if err != nil {
+		t.Fatalf("DocsFromDiscovery() = _, %v; want _, nil", err)
+	}
+	var s *ServiceInfo
+	for _, si := range AllServices {
+		if si.Service == "Instances" && si.Version() == VersionGA {
+			s = si
+		}
+	}
+	methods := map[string]*Method{}
+	for _, m := range s.Methods() {
+		methods[m.Name()] = m
+	}
+
+	if got, want := d.Object(s), "An Instance resource."; got != want {
+		t.Errorf("Object() = %q; want %q", got, want)
+	}
+	for _, tc := range []struct {
+		method string
+		want   string
+	}{
+		{"Get", "Returns the specified Instance resource."},
+		{"DetachDisk", "Detaches a disk."},
+		{"Delete", ""},
+	} {
+		if got := d.Method(s, tc.method); got != tc.want {
+			t.Errorf("Method(_, %q) = %q; want %q", tc.method, got, tc.want)
+		}
+	}
+	for _, tc := range []struct {
+		method string
+		want   []string
+	}{
+		{"AttachDisk", []string{"An instance-attached disk resource."}},
+		{"DetachDisk", []string{"Disk device name to detach."}},
+	} {
+		if got := d.Params(methods[tc.method]); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Params(%s) = %q; want %q", tc.method, got, tc.want)
+		}
+	}
+
+	var nilDocs *Docs
+	if got := nilDocs.Method(s, "Get"); got != "" {
+		t.Errorf("(*Docs)(nil).Method(_, Get) = %q; want \"\"", got)
+	}
+}
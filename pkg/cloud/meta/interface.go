@@ -0,0 +1,273 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterfaceMethod is the signature of a method of the generated service
+// interface. It is used to generate code that implements the interface
+// without spelling out each method in the template (e.g. the gomock mocks).
+type InterfaceMethod struct {
+	Name string
+	// Params and Results are the golang types of the parameters and
+	// results (e.g. "context.Context", "*ga.Address").
+	Params  []string
+	Results []string
+	// Options is true if the method takes Options after Params (opts
+	// ...interfaces.Option). The methods making a single API call take
+	// Options.
+	Options bool
+}
+
+// ParamNames returns the names of the parameters: arg0, arg1, ...
+func (m *InterfaceMethod) ParamNames() []string {
+	var ret []string
+	for i := range m.Params {
+		ret = append(ret, fmt.Sprintf("arg%d", i))
+	}
+	return ret
+}
+
+// ParamList is the parameter list of the method with names (e.g. "arg0
+// context.Context, arg1 meta.Key, opts ...interfaces.Option").
+func (m *InterfaceMethod) ParamList() string {
+	var ret []string
+	for i, n := range m.ParamNames() {
+		ret = append(ret, n+" "+m.Params[i])
+	}
+	if m.Options {
+		ret = append(ret, "opts ...interfaces.Option")
+	}
+	return strings.Join(ret, ", ")
+}
+
+// Args is the argument list passing the parameters of the method on (e.g.
+// "arg0, arg1, opts...").
+func (m *InterfaceMethod) Args() string {
+	names := m.ParamNames()
+	if m.Options {
+		names = append(names, "opts...")
+	}
+	return strings.Join(names, ", ")
+}
+
+// ErrorResults is the list of results returning the error err with the zero
+// value of the other results (e.g. "nil, err").
+func (m *InterfaceMethod) ErrorResults(err string) string {
+	var ret []string
+	for _, r := range m.Results {
+		ret = append(ret, zeroValue(r, err))
+	}
+	return strings.Join(ret, ", ")
+}
+
+// zeroValue returns the zero value of the golang type typ, or err if typ is
+// error.
+func zeroValue(typ, err string) string {
+	switch {
+	case typ == "error":
+		return err
+	case typ == "bool":
+		return "false"
+	case typ == "string":
+		return `""`
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		return "nil"
+	default:
+		return "*new(" + typ + ")"
+	}
+}
+
+// ArgsFrom is the argument list passing the parameters of the method from
+// the i-th on (e.g. "arg2" for ArgsFrom(2) with three parameters), without
+// the Options.
+func (m *InterfaceMethod) ArgsFrom(i int) string {
+	names := m.ParamNames()
+	if i >= len(names) {
+		return ""
+	}
+	return strings.Join(names[i:], ", ")
+}
+
+// Keyed is true if the second parameter of the method is the key of an
+// object.
+func (m *InterfaceMethod) Keyed() bool {
+	return len(m.Params) > 1 && m.Params[1] == "meta.Key"
+}
+
+// MethodKind is what a method of the service interface does, as seen by the
+// generated decorators of the interface (e.g. cloud.CachedCloud).
+type MethodKind string
+
+const (
+	// MethodGet is Get.
+	MethodGet MethodKind = "get"
+	// MethodExists is Exists, which is answered with Get.
+	MethodExists MethodKind = "exists"
+	// MethodList methods are the list calls and AggregatedList.
+	MethodList MethodKind = "list"
+	// MethodMutation methods modify the object of the key and return once
+	// done.
+	MethodMutation MethodKind = "mutation"
+	// MethodOp methods start an operation on the object of the key and
+	// return it (InsertOp and DeleteOp).
+	MethodOp MethodKind = "op"
+	// MethodBatch methods call another method for each of a list of keys
+	// (BatchGet and BatchDelete).
+	MethodBatch MethodKind = "batch"
+	// MethodRead methods read something other than the object (e.g.
+	// GetHealth), or a page of a list (ListPage).
+	MethodRead MethodKind = "read"
+)
+
+// Kind returns what the method does.
+func (m *InterfaceMethod) Kind() MethodKind {
+	switch {
+	case m.Name == "Get":
+		return MethodGet
+	case m.Name == "Exists":
+		return MethodExists
+	case m.Name == "BatchGet", m.Name == "BatchDelete":
+		return MethodBatch
+	case len(m.Results) == 0:
+		return MethodRead
+	case len(m.Results) == 2 && (strings.HasPrefix(m.Results[0], "[]") || strings.HasPrefix(m.Results[0], "map[")):
+		return MethodList
+	case m.Results[0] == "interfaces.Op":
+		return MethodOp
+	case m.Results[0] == "error", m.Name == "GetOrCreate":
+		return MethodMutation
+	}
+	return MethodRead
+}
+
+// ResultVars are the names of variables for the results of the method, the
+// last one (the error) being err (e.g. "ret0, err").
+func (m *InterfaceMethod) ResultVars() string {
+	var ret []string
+	for i := range m.Results[:len(m.Results)-1] {
+		ret = append(ret, fmt.Sprintf("ret%d", i))
+	}
+	return strings.Join(append(ret, "err"), ", ")
+}
+
+// ResultList is the result list of the method (e.g. "(*ga.Address, error)").
+func (m *InterfaceMethod) ResultList() string {
+	if len(m.Results) == 1 {
+		return m.Results[0]
+	}
+	return "(" + strings.Join(m.Results, ", ") + ")"
+}
+
+// InterfaceMethods returns the methods of the generated service interface,
+// excluding the hand written methods of CustomOps.
+func (i *ServiceInfo) InterfaceMethods() []*InterfaceMethod {
+	obj := "*" + i.FQObjectType()
+	keyed := func(name string, params []string, results ...string) *InterfaceMethod {
+		return &InterfaceMethod{
+			Name:    name,
+			Params:  append([]string{"context.Context", "meta.Key"}, params...),
+			Results: results,
+		}
+	}
+	// call is keyed() for the methods making a single API call.
+	call := func(name string, params []string, results ...string) *InterfaceMethod {
+		m := keyed(name, params, results...)
+		m.Options = true
+		return m
+	}
+
+	var ret []*InterfaceMethod
+	if i.GenerateGet() {
+		ret = append(ret, call("Get", nil, obj, "error"), keyed("Exists", nil, "bool", "error"))
+		ret = append(ret, &InterfaceMethod{
+			Name:    "BatchGet",
+			Params:  []string{"context.Context", "[]meta.Key"},
+			Results: []string{"[]" + obj, "error"},
+		})
+	}
+	for _, lc := range i.ListCalls() {
+		ret = append(ret, &InterfaceMethod{
+			Name:    lc.Name,
+			Params:  lc.interfaceParams(),
+			Results: []string{"[]*" + lc.FQItemType(), "error"},
+			Options: true,
+		})
+	}
+	if lc := i.ListPageCall(); lc != nil {
+		ret = append(ret, &InterfaceMethod{
+			Name:    "ListPage",
+			Params:  append(lc.interfaceParams(), "string", "int64"),
+			Results: []string{"[]*" + lc.FQItemType(), "string", "error"},
+			Options: true,
+		})
+	}
+	if lc := i.CrossProjectListCall(); lc != nil {
+		params := lc.interfaceParams()
+		ret = append(ret, &InterfaceMethod{
+			Name:    "ListAcrossProjects",
+			Params:  append([]string{params[0], "[]string"}, params[1:]...),
+			Results: []string{"map[string][]*" + lc.FQItemType(), "error"},
+			Options: true,
+		})
+	}
+	if i.GenerateInsert() {
+		ret = append(ret, call("Insert", []string{obj}, "error"), call("InsertOp", []string{obj}, "interfaces.Op", "error"))
+		if i.GenerateGet() {
+			ret = append(ret, keyed("GetOrCreate", []string{obj}, obj, "error"))
+		}
+	}
+	if i.GenerateDelete() {
+		ret = append(ret, call("Delete", nil, "error"), call("DeleteOp", nil, "interfaces.Op", "error"))
+		ret = append(ret, &InterfaceMethod{
+			Name:    "BatchDelete",
+			Params:  []string{"context.Context", "[]meta.Key"},
+			Results: []string{"error"},
+		})
+	}
+	if i.AggregatedList() {
+		ret = append(ret, &InterfaceMethod{
+			Name:    "AggregatedList",
+			Params:  []string{"context.Context", "*filter.F"},
+			Results: []string{"map[string][]" + obj, "error"},
+			Options: true,
+		})
+	}
+	if i.GenerateUpdate() {
+		ret = append(ret, call("Update", []string{obj}, "error"))
+		if i.SupportsUpdateWithRetry() {
+			ret = append(ret, keyed("UpdateWithRetry", []string{"func(" + obj + ") error"}, "error"))
+		}
+	}
+	if i.GeneratePatch() {
+		ret = append(ret, call("Patch", []string{obj}, "error"))
+	}
+	if i.SupportsLabels() {
+		ret = append(ret, keyed("UpdateLabels", []string{"map[string]string"}, "error"))
+	}
+	for _, m := range i.Methods() {
+		results := []string{"error"}
+		if m.ReturnType != "Operation" {
+			results = []string{fmt.Sprintf("*%v.%v", m.Version(), m.ReturnType), "error"}
+		}
+		ret = append(ret, call(m.Name(), m.args(m.argsSkip(), false, nil), results...))
+	}
+	return ret
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInterfaceMethods(t *testing.T) {
+	t.Parallel()
+
+	type sig struct {
+		Name    string
+		Params  string
+		Results string
+	}
+	find := func(service string, version Version) *ServiceInfo {
+		for _, s := range AllServices {
+			if s.Service == service && s.Version() == version {
+				return s
+			}
+		}
+		t.Fatalf("service %v/%v not found", service, version)
+		return nil
+	}
+
+	for _, tc := range []struct {
+		si   *ServiceInfo
+		want []sig
+	}{
+		{
+			si: find("Zones", VersionGA),
+			want: []sig{
+				{"Get", "arg0 context.Context, arg1 meta.Key, opts ...interfaces.Option", "(*ga.Zone, error)"},
+				{"Exists", "arg0 context.Context, arg1 meta.Key", "(bool, error)"},
+				{"BatchGet", "arg0 context.Context, arg1 []meta.Key", "([]*ga.Zone, error)"},
+				{"List", "arg0 context.Context, arg1 *filter.F, opts ...interfaces.Option", "([]*ga.Zone, error)"},
+				{"ListPage", "arg0 context.Context, arg1 *filter.F, arg2 string, arg3 int64, opts ...interfaces.Option", "([]*ga.Zone, string, error)"},
+			},
+		},
+		{
+			si: find("InstanceGroups", VersionGA),
+			want: []sig{
+				{"Get", "arg0 context.Context, arg1 meta.Key, opts ...interfaces.Option", "(*ga.InstanceGroup, error)"},
+				{"Exists", "arg0 context.Context, arg1 meta.Key", "(bool, error)"},
+				{"BatchGet", "arg0 context.Context, arg1 []meta.Key", "([]*ga.InstanceGroup, error)"},
+				{"List", "arg0 context.Context, arg1 string, arg2 *filter.F, opts ...interfaces.Option", "([]*ga.InstanceGroup, error)"},
+				{"ListPage", "arg0 context.Context, arg1 string, arg2 *filter.F, arg3 string, arg4 int64, opts ...interfaces.Option", "([]*ga.InstanceGroup, string, error)"},
+				{"Insert", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroup, opts ...interfaces.Option", "error"},
+				{"InsertOp", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroup, opts ...interfaces.Option", "(interfaces.Op, error)"},
+				{"GetOrCreate", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroup", "(*ga.InstanceGroup, error)"},
+				{"Delete", "arg0 context.Context, arg1 meta.Key, opts ...interfaces.Option", "error"},
+				{"DeleteOp", "arg0 context.Context, arg1 meta.Key, opts ...interfaces.Option", "(interfaces.Op, error)"},
+				{"BatchDelete", "arg0 context.Context, arg1 []meta.Key", "error"},
+				{"AddInstances", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroupsAddInstancesRequest, opts ...interfaces.Option", "error"},
+				{"ListInstances", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroupsListInstancesRequest, opts ...interfaces.Option", "(*ga.InstanceGroupsListInstances, error)"},
+				{"RemoveInstances", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroupsRemoveInstancesRequest, opts ...interfaces.Option", "error"},
+				{"SetNamedPorts", "arg0 context.Context, arg1 meta.Key, arg2 *ga.InstanceGroupsSetNamedPortsRequest, opts ...interfaces.Option", "error"},
+			},
+		},
+	} {
+		var got []sig
+		for _, m := range tc.si.InterfaceMethods() {
+			got = append(got, sig{m.Name, m.ParamList(), m.ResultList()})
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v.InterfaceMethods() = %+v; want %+v", tc.si.WrapType(), got, tc.want)
+		}
+	}
+}
+
+func TestErrorResults(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		results []string
+		want    string
+	}{
+		{[]string{"error"}, "err"},
+		{[]string{"*ga.Zone", "error"}, "nil, err"},
+		{[]string{"bool", "error"}, "false, err"},
+		{[]string{"[]*ga.Zone", "error"}, "nil, err"},
+		{[]string{"map[string][]*ga.Zone", "error"}, "nil, err"},
+		{[]string{"string", "error"}, `"", err`},
+		{[]string{"int64", "error"}, "*new(int64), err"},
+	} {
+		m := &InterfaceMethod{Name: "M", Results: tc.results}
+		if got := m.ErrorResults("err"); got != tc.want {
+			t.Errorf("ErrorResults(%q) with results %v = %q; want %q", "err", tc.results, got, tc.want)
+		}
+	}
+}
+
+func TestResultVars(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		results []string
+		want    string
+	}{
+		{[]string{"error"}, "err"},
+		{[]string{"*ga.Zone", "error"}, "ret0, err"},
+		{[]string{"[]*ga.Zone", "string", "error"}, "ret0, ret1, err"},
+	} {
+		m := &InterfaceMethod{Name: "M", Results: tc.results}
+		if got := m.ResultVars(); got != tc.want {
+			t.Errorf("ResultVars() with results %v = %q; want %q", tc.results, got, tc.want)
+		}
+	}
+}
+
+func TestKind(t *testing.T) {
+	t.Parallel()
+
+	var si *ServiceInfo
+	for _, s := range AllServices {
+		if s.Service == "InstanceGroups" && s.Version() == VersionGA {
+			si = s
+		}
+	}
+	want := map[string]MethodKind{
+		"Get":             MethodGet,
+		"Exists":          MethodExists,
+		"BatchGet":        MethodBatch,
+		"BatchDelete":     MethodBatch,
+		"List":            MethodList,
+		"ListPage":        MethodRead,
+		"Insert":          MethodMutation,
+		"InsertOp":        MethodOp,
+		"GetOrCreate":     MethodMutation,
+		"Delete":          MethodMutation,
+		"DeleteOp":        MethodOp,
+		"AggregatedList":  MethodList,
+		"AddInstances":    MethodMutation,
+		"ListInstances":   MethodRead,
+		"RemoveInstances": MethodMutation,
+		"SetNamedPorts":   MethodMutation,
+	}
+	for _, m := range si.InterfaceMethods() {
+		if got, ok := want[m.Name]; !ok || m.Kind() != got {
+			t.Errorf("%s.Kind() = %q; want %q", m.Name, m.Kind(), want[m.Name])
+		}
+	}
+}
+
+func TestArgsFrom(t *testing.T) {
+	t.Parallel()
+
+	m := &InterfaceMethod{Name: "M", Params: []string{"context.Context", "meta.Key", "*filter.F"}}
+	for _, tc := range []struct {
+		i    int
+		want string
+	}{
+		{0, "arg0, arg1, arg2"},
+		{2, "arg2"},
+		{3, ""},
+	} {
+		if got := m.ArgsFrom(tc.i); got != tc.want {
+			t.Errorf("ArgsFrom(%d) = %q; want %q", tc.i, got, tc.want)
+		}
+	}
+	if !m.Keyed() {
+		t.Errorf("Keyed() = false; want true")
+	}
+}
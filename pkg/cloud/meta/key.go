@@ -17,7 +17,10 @@ limitations under the License.
 package meta
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 )
 
 // Key for a GCP resource.
@@ -27,16 +30,16 @@ type Key struct {
 	Region string
 }
 
-// KeyType is the type of the key.
+// KeyType is the type of the key: the scope of the resource named by the key.
 type KeyType string
 
 const (
 	// Zonal key type.
-	Zonal = "zonal"
+	Zonal KeyType = "zonal"
 	// Regional key type.
-	Regional = "regional"
+	Regional KeyType = "regional"
 	// Global key type.
-	Global = "global"
+	Global KeyType = "global"
 )
 
 // ZonalKey returns the key for a zonal resource.
@@ -66,24 +69,185 @@ func (k *Key) Type() KeyType {
 	}
 }
 
-// String returns a string representation of the key.
-func (k Key) String() string {
+// Location returns the zone or region of the key, depending on its type, or
+// "" for a global key.
+func (k *Key) Location() string {
 	switch k.Type() {
 	case Zonal:
-		return fmt.Sprintf("Key{%q, zone: %q}", k.Name, k.Zone)
+		return k.Zone
 	case Regional:
+		return k.Region
+	default:
+		return ""
+	}
+}
+
+// String returns a string representation of the key.
+func (k Key) String() string {
+	switch {
+	case k.Zone != "" && k.Region != "":
+		return fmt.Sprintf("Key{%q, zone: %q, region: %q}", k.Name, k.Zone, k.Region)
+	case k.Type() == Zonal:
+		return fmt.Sprintf("Key{%q, zone: %q}", k.Name, k.Zone)
+	case k.Type() == Regional:
 		return fmt.Sprintf("Key{%q, region: %q}", k.Name, k.Region)
 	default:
 		return fmt.Sprintf("Key{%q}", k.Name)
 	}
 }
 
-// Valid is true if the key is valid.
+// MarshalText encodes the key as the scope part of the resource path:
+// "global/<name>", "regions/<region>/<name>" or "zones/<zone>/<name>". It is
+// the form of keys used as JSON map keys.
+func (k Key) MarshalText() ([]byte, error) {
+	switch {
+	case k.Zone != "" && k.Region != "":
+		return nil, fmt.Errorf("%v has both a zone and a region", k)
+	case k.Zone != "":
+		return []byte("zones/" + k.Zone + "/" + k.Name), nil
+	case k.Region != "":
+		return []byte("regions/" + k.Region + "/" + k.Name), nil
+	}
+	return []byte("global/" + k.Name), nil
+}
+
+// UnmarshalText decodes a key encoded by MarshalText.
+func (k *Key) UnmarshalText(text []byte) error {
+	s := string(text)
+	parts := strings.SplitN(s, "/", 3)
+	switch {
+	case len(parts) >= 2 && parts[0] == "global":
+		*k = Key{Name: strings.TrimPrefix(s, "global/")}
+	case len(parts) == 3 && parts[0] == "zones" && parts[1] != "":
+		*k = Key{Name: parts[2], Zone: parts[1]}
+	case len(parts) == 3 && parts[0] == "regions" && parts[1] != "":
+		*k = Key{Name: parts[2], Region: parts[1]}
+	default:
+		return fmt.Errorf("%q is not a valid key, want global/<name>, regions/<region>/<name> or zones/<zone>/<name>", s)
+	}
+	return nil
+}
+
+// jsonKey is the JSON encoding of a Key.
+type jsonKey struct {
+	Name   string `json:"name"`
+	Zone   string `json:"zone,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// MarshalJSON encodes the key as an object with the fields "name", "zone" and
+// "region", leaving out the empty location.
+func (k Key) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonKey(k))
+}
+
+// UnmarshalJSON decodes a key encoded by MarshalJSON, or a string encoded by
+// MarshalText.
+func (k *Key) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return k.UnmarshalText([]byte(s))
+	}
+	var jk jsonKey
+	if err := json.Unmarshal(data, &jk); err != nil {
+		return err
+	}
+	*k = Key(jk)
+	return nil
+}
+
+// Path returns the path of the resource named by the key in project, where
+// resource is the name of the resource collection (e.g. "instances"):
+//
+//	projects/<project>/global/<resource>/<name>
+//	projects/<project>/regions/<region>/<resource>/<name>
+//	projects/<project>/zones/<zone>/<resource>/<name>
+//
+// If version is not empty, the path is the full URL of the resource in that
+// version of the compute API (e.g.
+// "https://www.googleapis.com/compute/v1/projects/..."), as used in the
+// SelfLink of the objects.
+func (k Key) Path(project, resource string, version Version) string {
+	var p string
+	switch k.Type() {
+	case Zonal:
+		p = fmt.Sprintf("projects/%s/zones/%s/%s/%s", project, k.Zone, resource, k.Name)
+	case Regional:
+		p = fmt.Sprintf("projects/%s/regions/%s/%s/%s", project, k.Region, resource, k.Name)
+	default:
+		p = fmt.Sprintf("projects/%s/global/%s/%s", project, resource, k.Name)
+	}
+	if version == "" {
+		return p
+	}
+	vi, ok := version.Info()
+	if !ok {
+		return "https://www.googleapis.com/compute/" + string(version) + "/" + p
+	}
+	return vi.URLPrefix + p
+}
+
+var (
+	// nameRE matches the name of a resource: a RFC1035 label or the numeric
+	// id of the resource, which the API accepts in place of the name.
+	nameRE = regexp.MustCompile(`^([a-z]([-a-z0-9]{0,61}[a-z0-9])?|[1-9][0-9]{0,19})$`)
+	// regionRE matches the name of a region (e.g. "us-central1").
+	regionRE = regexp.MustCompile(`^[a-z]+(-[a-z]+)+[0-9]+$`)
+	// zoneRE matches the name of a zone (e.g. "us-central1-b").
+	zoneRE = regexp.MustCompile(`^[a-z]+(-[a-z]+)+[0-9]+-[a-z][a-z0-9]*$`)
+)
+
+// Valid is true if the key is valid (see Validate()). typeName is not used.
 func (k *Key) Valid(typeName string) bool {
+	return k.Validate() == nil
+}
+
+// Validate returns an error if the key does not follow the GCE naming rules:
+// the name must be a RFC1035 label (or a numeric resource id), the zone and
+// region must be well formed and at most one of them can be set.
+func (k *Key) Validate() error {
 	if k.Zone != "" && k.Region != "" {
-		return false
+		return fmt.Errorf("invalid key %v: zone and region cannot both be set", k)
+	}
+	if !nameRE.MatchString(k.Name) {
+		return fmt.Errorf("invalid key %v: %q is not a valid resource name (RFC1035)", k, k.Name)
+	}
+	if k.Zone != "" && !zoneRE.MatchString(k.Zone) {
+		return fmt.Errorf("invalid key %v: %q is not a valid zone", k, k.Zone)
+	}
+	if k.Region != "" && !regionRE.MatchString(k.Region) {
+		return fmt.Errorf("invalid key %v: %q is not a valid region", k, k.Region)
+	}
+	return nil
+}
+
+// NewZonalKey returns the key for a zonal resource, or an error if the key is
+// not valid (see Validate()).
+func NewZonalKey(name, zone string) (*Key, error) {
+	return checkedKey(ZonalKey(name, zone), Zonal)
+}
+
+// NewRegionalKey returns the key for a regional resource, or an error if the
+// key is not valid (see Validate()).
+func NewRegionalKey(name, region string) (*Key, error) {
+	return checkedKey(RegionalKey(name, region), Regional)
+}
+
+// NewGlobalKey returns the key for a global resource, or an error if the key
+// is not valid (see Validate()).
+func NewGlobalKey(name string) (*Key, error) {
+	return checkedKey(GlobalKey(name), Global)
+}
+
+// checkedKey returns k if it is valid and of type t.
+func checkedKey(k *Key, t KeyType) (*Key, error) {
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+	if k.Type() != t {
+		return nil, fmt.Errorf("invalid key %v: not a %s key", k, t)
 	}
-	return true
+	return k, nil
 }
 
 // KeysToMap creates a map[Key]bool from a list of keys.
@@ -17,6 +17,8 @@ limitations under the License.
 package meta
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +39,23 @@ func TestKeyType(t *testing.T) {
 	}
 }
 
+func TestKeyLocation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key  *Key
+		want string
+	}{
+		{GlobalKey("abc"), ""},
+		{ZonalKey("abc", "us-central1-b"), "us-central1-b"},
+		{RegionalKey("abc", "us-central1"), "us-central1"},
+	} {
+		if got := tc.key.Location(); got != tc.want {
+			t.Errorf("%v.Location() = %q, want %q", tc.key, got, tc.want)
+		}
+	}
+}
+
 func TestKeyString(t *testing.T) {
 	t.Parallel()
 
@@ -49,6 +68,85 @@ func TestKeyString(t *testing.T) {
 			t.Errorf(`k.String() = "", want non-empty`)
 		}
 	}
+	if got, want := (Key{"abc", "us-central1-b", "us-central1"}).String(), `Key{"abc", zone: "us-central1-b", region: "us-central1"}`; got != want {
+		t.Errorf("k.String() = %q, want %q", got, want)
+	}
+}
+
+func TestKeyPath(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key      *Key
+		resource string
+		version  Version
+		want     string
+	}{
+		{GlobalKey("fw"), "firewalls", "", "projects/p/global/firewalls/fw"},
+		{RegionalKey("addr", "us-central1"), "addresses", "", "projects/p/regions/us-central1/addresses/addr"},
+		{ZonalKey("vm", "us-central1-b"), "instances", "", "projects/p/zones/us-central1-b/instances/vm"},
+		{GlobalKey("fw"), "firewalls", VersionGA, "https://www.googleapis.com/compute/v1/projects/p/global/firewalls/fw"},
+		{ZonalKey("vm", "us-central1-b"), "instances", VersionAlpha, "https://www.googleapis.com/compute/alpha/projects/p/zones/us-central1-b/instances/vm"},
+	} {
+		if got := tc.key.Path("p", tc.resource, tc.version); got != tc.want {
+			t.Errorf("%v.Path(p, %q, %q) = %q; want %q", tc.key, tc.resource, tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestKeyEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key      Key
+		wantText string
+		wantJSON string
+	}{
+		{*GlobalKey("fw"), "global/fw", `{"name":"fw"}`},
+		{*RegionalKey("addr", "us-central1"), "regions/us-central1/addr", `{"name":"addr","region":"us-central1"}`},
+		{*ZonalKey("vm", "us-central1-b"), "zones/us-central1-b/vm", `{"name":"vm","zone":"us-central1-b"}`},
+	} {
+		text, err := tc.key.MarshalText()
+		if err != nil || string(text) != tc.wantText {
+			t.Errorf("%v.MarshalText() = %q, %v; want %q, nil", tc.key, text, err, tc.wantText)
+		}
+		var k Key
+		if err := k.UnmarshalText(text); err != nil || k != tc.key {
+			t.Errorf("UnmarshalText(%q) = %v (key %v); want nil (key %v)", text, err, k, tc.key)
+		}
+
+		data, err := json.Marshal(tc.key)
+		if err != nil || string(data) != tc.wantJSON {
+			t.Errorf("json.Marshal(%v) = %s, %v; want %s, nil", tc.key, data, err, tc.wantJSON)
+		}
+		for _, in := range []string{string(data), `"` + tc.wantText + `"`} {
+			var k Key
+			if err := json.Unmarshal([]byte(in), &k); err != nil || k != tc.key {
+				t.Errorf("json.Unmarshal(%s) = %v (key %v); want nil (key %v)", in, err, k, tc.key)
+			}
+		}
+	}
+
+	// Keys as JSON map keys use the text encoding.
+	m := map[Key]int{*GlobalKey("fw"): 1, *ZonalKey("vm", "us-central1-b"): 2}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) = _, %v; want _, nil", m, err)
+	}
+	var got map[Key]int
+	if err := json.Unmarshal(data, &got); err != nil || len(got) != 2 || got[*GlobalKey("fw")] != 1 || got[*ZonalKey("vm", "us-central1-b")] != 2 {
+		t.Errorf("json.Unmarshal(%s) = %v (map %v); want nil (map %v)", data, err, got, m)
+	}
+
+	if _, err := (Key{"abc", "us-central1-b", "us-central1"}).MarshalText(); err == nil {
+		t.Errorf("MarshalText() of a key with a zone and a region = _, nil; want error")
+	}
+	for _, in := range []string{"", "fw", "zones/vm", "zones//vm", "regions/us-central1", "projects/p/global/fw"} {
+		var k Key
+		if err := k.UnmarshalText([]byte(in)); err == nil {
+			t.Errorf("UnmarshalText(%q) = nil (key %v); want error", in, k)
+		}
+	}
 }
 
 func TestKeyValid(t *testing.T) {
@@ -66,6 +164,7 @@ func TestKeyValid(t *testing.T) {
 		// actual settings for each type.
 		{GlobalKey("abc"), "UrlMap", true},
 		{&Key{"abc", zone, region}, "UrlMap", false},
+		{GlobalKey("Abc"), "UrlMap", false},
 	} {
 		valid := tc.key.Valid(tc.typeName)
 		if valid != tc.want {
@@ -73,3 +172,67 @@ func TestKeyValid(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyValidate(t *testing.T) {
+	t.Parallel()
+
+	region := "us-central1"
+	zone := "us-central1-b"
+
+	for _, tc := range []struct {
+		key  *Key
+		want bool
+	}{
+		{GlobalKey("abc"), true},
+		{GlobalKey("a-b-1"), true},
+		{GlobalKey("1234567890"), true},
+		{ZonalKey("abc", zone), true},
+		{ZonalKey("abc", "europe-west4-a"), true},
+		{RegionalKey("abc", region), true},
+		{RegionalKey("abc", "northamerica-northeast1"), true},
+		{&Key{"abc", zone, region}, false},
+		{GlobalKey(""), false},
+		{GlobalKey("Abc"), false},
+		{GlobalKey("abc-"), false},
+		{GlobalKey("1abc"), false},
+		{GlobalKey("a_b"), false},
+		{GlobalKey(strings.Repeat("a", 64)), false},
+		{ZonalKey("abc", region), false},
+		{ZonalKey("abc", "us-central1-"), false},
+		{RegionalKey("abc", zone), false},
+		{RegionalKey("abc", "us central1"), false},
+	} {
+		err := tc.key.Validate()
+		if valid := err == nil; valid != tc.want {
+			t.Errorf("key %+v; key.Validate() = %v, want valid = %v", tc.key, err, tc.want)
+		}
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc    string
+		new     func() (*Key, error)
+		want    *Key
+		wantErr bool
+	}{
+		{"global", func() (*Key, error) { return NewGlobalKey("abc") }, GlobalKey("abc"), false},
+		{"regional", func() (*Key, error) { return NewRegionalKey("abc", "us-central1") }, RegionalKey("abc", "us-central1"), false},
+		{"zonal", func() (*Key, error) { return NewZonalKey("abc", "us-central1-b") }, ZonalKey("abc", "us-central1-b"), false},
+		{"bad name", func() (*Key, error) { return NewGlobalKey("ABC") }, nil, true},
+		{"bad region", func() (*Key, error) { return NewRegionalKey("abc", "us-central1-b") }, nil, true},
+		{"no zone", func() (*Key, error) { return NewZonalKey("abc", "") }, nil, true},
+		{"no region", func() (*Key, error) { return NewRegionalKey("abc", "") }, nil, true},
+	} {
+		got, err := tc.new()
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: New...Key() = %v, %v; want error = %v", tc.desc, got, err, tc.wantErr)
+			continue
+		}
+		if tc.want != nil && *got != *tc.want {
+			t.Errorf("%s: New...Key() = %v, nil; want %v, nil", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ListScope is the scope of a List style call, given by its parameters.
+type ListScope string
+
+const (
+	// ListProject calls take the project (e.g. List(project) of a global
+	// resource, Subnetworks.ListUsable(project)).
+	ListProject ListScope = "project"
+	// ListRegion calls take the project and a region.
+	ListRegion ListScope = "region"
+	// ListZone calls take the project and a zone.
+	ListZone ListScope = "zone"
+	// ListKey calls take the key of a resource of the service and list the
+	// items belonging to the resource (e.g.
+	// InstanceGroupManagers.ListManagedInstances).
+	ListKey ListScope = "key"
+)
+
+// ListCall is a List style call of a service: a call that returns a list of
+// items. The shape of the call (parameters, response type, items field and
+// paging) is derived from the golang client.
+type ListCall struct {
+	// Name of the method (e.g. "List", "ListManagedInstances").
+	Name  string
+	Scope ListScope
+	// ResponseType is the type returned by Do() (e.g. "AddressList").
+	ResponseType string
+	// ItemsField is the field of ResponseType containing the items (e.g.
+	// "Items", "ManagedInstances").
+	ItemsField string
+	// ItemType is the type of the items (e.g. "Address").
+	ItemType string
+	// Paged is true if the call supports Pages().
+	Paged bool
+
+	s *ServiceInfo
+}
+
+// newListCall derives the ListCall for the method name of the golang client.
+func newListCall(s *ServiceInfo, name string) (*ListCall, error) {
+	m, ok := s.serviceType.MethodByName(name)
+	if !ok {
+		return nil, fmt.Errorf("method %q was not found in service %q", name, s.Service)
+	}
+	lc := &ListCall{Name: name, s: s}
+
+	// The parameters of the method, excluding the receiver, must be
+	// strings: project, [location] or the key of a resource.
+	fType := m.Func.Type()
+	for i := 1; i < fType.NumIn(); i++ {
+		if fType.In(i).Kind() != reflect.String {
+			return nil, fmt.Errorf("method %q.%q: list calls can only have string parameters", s.Service, name)
+		}
+	}
+	keyParams := 2
+	if !s.KeyIsGlobal() {
+		keyParams = 3
+	}
+	switch params := fType.NumIn() - 1; {
+	case params == 1:
+		lc.Scope = ListProject
+	case params == keyParams:
+		lc.Scope = ListKey
+	case params == 2 && s.KeyIsRegional():
+		lc.Scope = ListRegion
+	case params == 2 && s.KeyIsZonal():
+		lc.Scope = ListZone
+	default:
+		return nil, fmt.Errorf("method %q.%q: unsupported parameters for a list call", s.Service, name)
+	}
+
+	if fType.NumOut() != 1 || fType.Out(0).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("method %q.%q: list calls must return an *xxxCall object", s.Service, name)
+	}
+	callType := fType.Out(0)
+	if _, ok := callType.MethodByName("Filter"); !ok {
+		return nil, fmt.Errorf("method %q.%q: %v does not have a Filter() method", s.Service, name, callType)
+	}
+	_, lc.Paged = callType.MethodByName("Pages")
+	do, ok := callType.MethodByName("Do")
+	if !ok || do.Type.NumOut() != 2 || do.Type.Out(0).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("method %q.%q: Do() must return (*T, error)", s.Service, name)
+	}
+	resp := do.Type.Out(0).Elem()
+	lc.ResponseType = resp.Name()
+
+	// The items are the only field of the response that is a slice of
+	// pointers to structs.
+	for i := 0; i < resp.NumField(); i++ {
+		f := resp.Field(i)
+		if f.Type.Kind() != reflect.Slice || f.Type.Elem().Kind() != reflect.Ptr || f.Type.Elem().Elem().Kind() != reflect.Struct {
+			continue
+		}
+		if lc.ItemsField != "" {
+			return nil, fmt.Errorf("method %q.%q: %v has more than one list of items", s.Service, name, resp.Name())
+		}
+		lc.ItemsField = f.Name
+		lc.ItemType = f.Type.Elem().Elem().Name()
+	}
+	if lc.ItemsField == "" {
+		return nil, fmt.Errorf("method %q.%q: %v has no list of items", s.Service, name, resp.Name())
+	}
+	return lc, nil
+}
+
+// Standard is true for the standard List() call of the service, which is
+// also implemented by the mocks.
+func (lc *ListCall) Standard() bool {
+	return lc.Name == "List"
+}
+
+// FQItemType is the fully qualified type of the items (e.g. "ga.Address").
+func (lc *ListCall) FQItemType() string {
+	return fmt.Sprintf("%v.%v", lc.s.Version(), lc.ItemType)
+}
+
+// FQResponseType is the fully qualified type of the response (e.g.
+// "ga.AddressList").
+func (lc *ListCall) FQResponseType() string {
+	return fmt.Sprintf("%v.%v", lc.s.Version(), lc.ResponseType)
+}
+
+// scopeParam is the name and type of the scope parameter of the generated
+// method, if any.
+func (lc *ListCall) scopeParam() (string, string) {
+	switch lc.Scope {
+	case ListRegion:
+		return "region", "string"
+	case ListZone:
+		return "zone", "string"
+	case ListKey:
+		return "key", "meta.Key"
+	}
+	return "", ""
+}
+
+// Params is the parameter list of the generated method (e.g. "ctx
+// context.Context, region string, fl *filter.F").
+func (lc *ListCall) Params() string {
+	params := []string{"ctx context.Context"}
+	if name, typ := lc.scopeParam(); name != "" {
+		params = append(params, name+" "+typ)
+	}
+	return strings.Join(append(params, "fl *filter.F"), ", ")
+}
+
+// Args are the arguments of the generated method (e.g. "ctx, region, fl").
+func (lc *ListCall) Args() string {
+	args := []string{"ctx"}
+	if name, _ := lc.scopeParam(); name != "" {
+		args = append(args, name)
+	}
+	return strings.Join(append(args, "fl"), ", ")
+}
+
+// RecordArgs are the arguments of the generated method recorded by the
+// Recorder of the mock: the arguments other than the context and the key
+// (e.g. "region, fl").
+func (lc *ListCall) RecordArgs() string {
+	if lc.Scope == ListRegion || lc.Scope == ListZone {
+		return lc.Location() + ", fl"
+	}
+	return "fl"
+}
+
+// CrossProjectParams is the parameter list of the generated
+// ListAcrossProjects() (e.g. "ctx context.Context, projects []string, region
+// string, fl *filter.F").
+func (lc *ListCall) CrossProjectParams() string {
+	return strings.Replace(lc.Params(), "ctx context.Context", "ctx context.Context, projects []string", 1)
+}
+
+// ArgsFormat is the format for logging Args() (e.g. "%v, %q, %v").
+func (lc *ListCall) ArgsFormat() string {
+	switch lc.Scope {
+	case ListRegion, ListZone:
+		return "%v, %q, %v"
+	case ListKey:
+		return "%v, %s, %v"
+	}
+	return "%v, %v"
+}
+
+// CallArgs are the arguments of the call to the golang client (e.g.
+// "projectID, region").
+func (lc *ListCall) CallArgs() string {
+	switch lc.Scope {
+	case ListRegion:
+		return "projectID, region"
+	case ListZone:
+		return "projectID, zone"
+	case ListKey:
+		switch lc.s.keyType {
+		case Regional:
+			return "projectID, key.Region, key.Name"
+		case Zonal:
+			return "projectID, key.Zone, key.Name"
+		}
+		return "projectID, key.Name"
+	}
+	return "projectID"
+}
+
+// Location is the expression for the region or zone the call lists in (e.g.
+// "region", "key.Zone"), empty for the calls on a project or on a global
+// resource.
+func (lc *ListCall) Location() string {
+	switch lc.Scope {
+	case ListRegion:
+		return "region"
+	case ListZone:
+		return "zone"
+	case ListKey:
+		switch lc.s.keyType {
+		case Regional:
+			return "key.Region"
+		case Zonal:
+			return "key.Zone"
+		}
+	}
+	return ""
+}
+
+// MockInScope is the expression for the function selecting the keys of the
+// objects returned by the standard List() of the mock.
+func (lc *ListCall) MockInScope() string {
+	switch lc.Scope {
+	case ListRegion:
+		return "func(key meta.Key) bool { return key.Region == region }"
+	case ListZone:
+		return "func(key meta.Key) bool { return key.Zone == zone }"
+	}
+	return "nil"
+}
+
+// MockScenarioKey is the key argument for the Scenario of the mock: the key
+// for ListKey calls and nil otherwise.
+func (lc *ListCall) MockScenarioKey() string {
+	if lc.Scope == ListKey {
+		return "&key"
+	}
+	return "nil"
+}
+
+// interfaceParams are the types of the parameters of the generated method.
+func (lc *ListCall) interfaceParams() []string {
+	params := []string{"context.Context"}
+	if _, typ := lc.scopeParam(); typ != "" {
+		params = append(params, typ)
+	}
+	return append(params, "*filter.F")
+}
+
+// ListPageCall returns the standard List() call if it is paged, nil
+// otherwise. A ListPage method returning a single page of the call is
+// generated for it.
+func (i *ServiceInfo) ListPageCall() *ListCall {
+	for _, lc := range i.ListCalls() {
+		if lc.Standard() && lc.Paged {
+			return lc
+		}
+	}
+	return nil
+}
+
+// CrossProjectListCall returns the standard List() call of the services of
+// the networks, which are shared across projects by a Shared VPC
+// (TagNetworking), for which ListAcrossProjects() is generated, or nil.
+func (i *ServiceInfo) CrossProjectListCall() *ListCall {
+	if !i.HasTag(TagNetworking) {
+		return nil
+	}
+	for _, lc := range i.ListCalls() {
+		if lc.Standard() {
+			return lc
+		}
+	}
+	return nil
+}
+
+// ListCalls returns the List style calls of the service: the standard List()
+// call, if generated, followed by the calls named in listMethods.
+func (i *ServiceInfo) ListCalls() []*ListCall {
+	var names []string
+	if i.GenerateList() {
+		names = append(names, "List")
+	}
+	var ret []*ListCall
+	for _, name := range append(names, i.listMethods...) {
+		lc, err := newListCall(i, name)
+		if err != nil {
+			panic(err)
+		}
+		ret = append(ret, lc)
+	}
+	return ret
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestNewListCall(t *testing.T) {
+	t.Parallel()
+
+	addresses := &ServiceInfo{Object: "Address", Service: "Addresses", keyType: Regional, serviceType: reflect.TypeOf(&ga.AddressesService{})}
+	firewalls := &ServiceInfo{Object: "Firewall", Service: "Firewalls", keyType: Global, serviceType: reflect.TypeOf(&ga.FirewallsService{})}
+	igms := &ServiceInfo{Object: "InstanceGroupManager", Service: "InstanceGroupManagers", keyType: Zonal, serviceType: reflect.TypeOf(&ga.InstanceGroupManagersService{})}
+	subnets := &ServiceInfo{Object: "Subnetwork", Service: "Subnetworks", version: VersionAlpha, keyType: Regional, serviceType: reflect.TypeOf(&alpha.SubnetworksService{})}
+
+	type result struct {
+		Scope                                      ListScope
+		ResponseType, ItemsField, ItemType, Params string
+		Paged                                      bool
+	}
+	for _, tc := range []struct {
+		s    *ServiceInfo
+		name string
+		want *result
+	}{
+		{addresses, "List", &result{ListRegion, "AddressList", "Items", "Address", "ctx context.Context, region string, fl *filter.F", true}},
+		{firewalls, "List", &result{ListProject, "FirewallList", "Items", "Firewall", "ctx context.Context, fl *filter.F", true}},
+		{igms, "ListManagedInstances", &result{ListKey, "InstanceGroupManagersListManagedInstancesResponse", "ManagedInstances", "ManagedInstance", "ctx context.Context, key meta.Key, fl *filter.F", false}},
+		{subnets, "ListUsable", &result{ListProject, "UsableSubnetworksAggregatedList", "Items", "UsableSubnetwork", "ctx context.Context, fl *filter.F", true}},
+		// Not a list call.
+		{addresses, "Insert", nil},
+		{addresses, "Frob", nil},
+	} {
+		lc, err := newListCall(tc.s, tc.name)
+		if tc.want == nil {
+			if err == nil {
+				t.Errorf("newListCall(%s, %q) = %+v, nil; want error", tc.s.Service, tc.name, lc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newListCall(%s, %q) = _, %v; want _, nil", tc.s.Service, tc.name, err)
+			continue
+		}
+		got := &result{lc.Scope, lc.ResponseType, lc.ItemsField, lc.ItemType, lc.Params(), lc.Paged}
+		if *got != *tc.want {
+			t.Errorf("newListCall(%s, %q) = %+v; want %+v", tc.s.Service, tc.name, got, tc.want)
+		}
+	}
+
+	if got := igms.ListCalls(); len(got) != 1 || got[0].Name != "List" {
+		t.Errorf("ListCalls() = %+v; want [List]", got)
+	}
+	igms.listMethods = []string{"ListManagedInstances"}
+	if got := igms.ListCalls(); len(got) != 2 || got[1].CallArgs() != "projectID, key.Zone, key.Name" {
+		t.Errorf("ListCalls() = %+v; want [List ListManagedInstances(projectID, key.Zone, key.Name)]", got)
+	}
+	if got := igms.ListCalls(); got[0].Location() != "zone" || got[1].Location() != "key.Zone" {
+		t.Errorf("Location() = %q, %q; want zone, key.Zone", got[0].Location(), got[1].Location())
+	}
+	if got := igms.ListCalls(); got[0].RecordArgs() != "zone, fl" || got[1].RecordArgs() != "fl" {
+		t.Errorf("RecordArgs() = %q, %q; want \"zone, fl\", fl", got[0].RecordArgs(), got[1].RecordArgs())
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+// ServiceForResource returns the service in AllServices for the REST resource
+// collection (e.g. "firewalls", the Resource of a cloud.ResourceID) at version.
+// ok is false if there is no such service, or if the collection is served by
+// more than one service (e.g. "addresses" is both Addresses and
+// GlobalAddresses); use ServiceForResourceKey() to select by the key type.
+func ServiceForResource(resource string, version Version) (s *ServiceInfo, ok bool) {
+	for _, si := range AllServices {
+		if si.ResourcePath() != resource || si.Version() != version {
+			continue
+		}
+		if s != nil {
+			return nil, false
+		}
+		s = si
+	}
+	return s, s != nil
+}
+
+// ServiceForResourceKey returns the service in AllServices for the REST
+// resource collection at version whose keys are of type keyType.
+func ServiceForResourceKey(resource string, version Version, keyType KeyType) (*ServiceInfo, bool) {
+	for _, si := range AllServices {
+		if si.ResourcePath() == resource && si.Version() == version && si.KeyType() == keyType {
+			return si, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"testing"
+)
+
+func TestServiceForResource(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		resource string
+		version  Version
+		want     string
+	}{
+		{"firewalls", VersionGA, "Firewalls"},
+		{"instances", VersionBeta, "Instances"},
+		{"networkEndpointGroups", VersionAlpha, "NetworkEndpointGroups"},
+		// Served by Addresses and GlobalAddresses.
+		{"addresses", VersionGA, ""},
+		{"networkEndpointGroups", VersionGA, ""},
+		{"foos", VersionGA, ""},
+	} {
+		s, ok := ServiceForResource(tc.resource, tc.version)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("ServiceForResource(%q, %v) = %v, true; want _, false", tc.resource, tc.version, s.Service)
+			}
+			continue
+		}
+		if !ok || s.Service != tc.want || s.Version() != tc.version {
+			t.Errorf("ServiceForResource(%q, %v) = %v, %t; want %s, true", tc.resource, tc.version, s, ok, tc.want)
+		}
+	}
+}
+
+func TestServiceForResourceKey(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		resource string
+		version  Version
+		keyType  KeyType
+		want     string
+	}{
+		{"addresses", VersionGA, Global, "GlobalAddresses"},
+		{"addresses", VersionGA, Regional, "Addresses"},
+		{"backendServices", VersionAlpha, Regional, "RegionBackendServices"},
+		{"addresses", VersionGA, Zonal, ""},
+	} {
+		s, ok := ServiceForResourceKey(tc.resource, tc.version, tc.keyType)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("ServiceForResourceKey(%q, %v, %v) = %v, true; want _, false", tc.resource, tc.version, tc.keyType, s.Service)
+			}
+			continue
+		}
+		if !ok || s.Service != tc.want {
+			t.Errorf("ServiceForResourceKey(%q, %v, %v) = %v, %t; want %s, true", tc.resource, tc.version, tc.keyType, s, ok, tc.want)
+		}
+	}
+}
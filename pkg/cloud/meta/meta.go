@@ -42,10 +42,18 @@ const (
 	CustomOps = 1 << iota
 	// AggregatedList will generated a method for AggregatedList().
 	AggregatedList = 1 << iota
+	// Update will generate a method for Update().
+	Update = 1 << iota
+	// Patch will generate a method for Patch().
+	Patch = 1 << iota
+	// readOnly marks the resource as read-only, see ReadOnly.
+	readOnly = 1 << iota
 
-	// ReadOnly specifies that the given resource is read-only and should not
-	// have insert() or delete() methods generated for the wrapper.
-	ReadOnly = NoDelete | NoInsert
+	// ReadOnly specifies that the given resource is read-only: only the
+	// Get() and List() methods are generated for the wrapper. Update, Patch
+	// and additional methods that mutate the resource cannot be combined
+	// with ReadOnly.
+	ReadOnly = NoDelete | NoInsert | readOnly
 
 	// VersionGA is the API version in compute.v1.
 	VersionGA Version = "ga"
@@ -55,12 +63,8 @@ const (
 	VersionBeta Version = "beta"
 )
 
-// AllVersions is a list of all versions of the GCE API.
-var AllVersions = []Version{
-	VersionGA,
-	VersionAlpha,
-	VersionBeta,
-}
+// AllVersions is a list of all versions of the GCE API (see Versions).
+var AllVersions = versionNames()
 
 // AllServices are a list of all the services to generate code for. Keep
 // this list in lexiographical order by object type.
@@ -70,6 +74,8 @@ var AllServices = []*ServiceInfo{
 		Service:     "Addresses",
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.AddressesService{}),
+		tags:        []Tag{TagNetworking},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "Address",
@@ -77,6 +83,8 @@ var AllServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&alpha.AddressesService{}),
+		tags:        []Tag{TagNetworking},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "Address",
@@ -84,30 +92,35 @@ var AllServices = []*ServiceInfo{
 		version:     VersionBeta,
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&beta.AddressesService{}),
+		tags:        []Tag{TagNetworking},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "Address",
 		Service:     "GlobalAddresses",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.GlobalAddressesService{}),
+		tags:        []Tag{TagNetworking},
 	},
 	&ServiceInfo{
 		Object:      "BackendService",
 		Service:     "BackendServices",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.BackendServicesService{}),
+		tags:        []Tag{TagLoadBalancing},
 		additionalMethods: []string{
 			"GetHealth",
-			"Update",
 		},
+		options: Update | Patch,
 	},
 	&ServiceInfo{
-		Object:            "BackendService",
-		Service:           "BackendServices",
-		version:           VersionAlpha,
-		keyType:           Global,
-		serviceType:       reflect.TypeOf(&alpha.BackendServicesService{}),
-		additionalMethods: []string{"Update"},
+		Object:      "BackendService",
+		Service:     "BackendServices",
+		version:     VersionAlpha,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&alpha.BackendServicesService{}),
+		tags:        []Tag{TagLoadBalancing},
+		options:     Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "BackendService",
@@ -115,16 +128,19 @@ var AllServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&alpha.RegionBackendServicesService{}),
+		tags:        []Tag{TagLoadBalancing},
 		additionalMethods: []string{
 			"GetHealth",
-			"Update",
 		},
+		options: Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "Disk",
 		Service:     "Disks",
 		keyType:     Zonal,
 		serviceType: reflect.TypeOf(&ga.DisksService{}),
+		tags:        []Tag{TagStorage},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "Disk",
@@ -132,28 +148,41 @@ var AllServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Zonal,
 		serviceType: reflect.TypeOf(&alpha.DisksService{}),
+		tags:        []Tag{TagStorage},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "Disk",
 		Service:     "RegionDisks",
 		version:     VersionAlpha,
 		keyType:     Regional,
-		serviceType: reflect.TypeOf(&alpha.DisksService{}),
+		serviceType: reflect.TypeOf(&alpha.RegionDisksService{}),
+		tags:        []Tag{TagStorage},
 	},
 	&ServiceInfo{
-		Object:      "Firewall",
-		Service:     "Firewalls",
-		keyType:     Global,
-		serviceType: reflect.TypeOf(&ga.FirewallsService{}),
-		additionalMethods: []string{
-			"Update",
-		},
+		Object:      "DiskType",
+		Service:     "DiskTypes",
+		keyType:     Zonal,
+		options:     ReadOnly,
+		serviceType: reflect.TypeOf(&ga.DiskTypesService{}),
+		tags:        []Tag{TagStorage},
+	},
+	&ServiceInfo{
+		Object:         "Firewall",
+		Service:        "Firewalls",
+		keyType:        Global,
+		serviceType:    reflect.TypeOf(&ga.FirewallsService{}),
+		tags:           []Tag{TagNetworking},
+		requiredFields: []string{"Network"},
+		options:        Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "ForwardingRule",
 		Service:     "ForwardingRules",
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.ForwardingRulesService{}),
+		tags:        []Tag{TagLoadBalancing},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "ForwardingRule",
@@ -161,12 +190,15 @@ var AllServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&alpha.ForwardingRulesService{}),
+		tags:        []Tag{TagLoadBalancing},
+		options:     AggregatedList,
 	},
 	&ServiceInfo{
 		Object:      "ForwardingRule",
 		Service:     "GlobalForwardingRules",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.GlobalForwardingRulesService{}),
+		tags:        []Tag{TagLoadBalancing},
 		additionalMethods: []string{
 			"SetTarget",
 		},
@@ -176,9 +208,8 @@ var AllServices = []*ServiceInfo{
 		Service:     "HealthChecks",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.HealthChecksService{}),
-		additionalMethods: []string{
-			"Update",
-		},
+		tags:        []Tag{TagLoadBalancing},
+		options:     Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "HealthCheck",
@@ -186,33 +217,33 @@ var AllServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&alpha.HealthChecksService{}),
-		additionalMethods: []string{
-			"Update",
-		},
+		tags:        []Tag{TagLoadBalancing},
+		options:     Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "HttpHealthCheck",
 		Service:     "HttpHealthChecks",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.HttpHealthChecksService{}),
-		additionalMethods: []string{
-			"Update",
-		},
+		tags:        []Tag{TagLoadBalancing},
+		deprecated:  "HttpHealthChecks are legacy health checks. Use HealthChecks instead.",
+		options:     Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "HttpsHealthCheck",
 		Service:     "HttpsHealthChecks",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.HttpsHealthChecksService{}),
-		additionalMethods: []string{
-			"Update",
-		},
+		tags:        []Tag{TagLoadBalancing},
+		deprecated:  "HttpsHealthChecks are legacy health checks. Use HealthChecks instead.",
+		options:     Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "InstanceGroup",
 		Service:     "InstanceGroups",
 		keyType:     Zonal,
 		serviceType: reflect.TypeOf(&ga.InstanceGroupsService{}),
+		tags:        []Tag{TagInstances},
 		additionalMethods: []string{
 			"AddInstances",
 			"ListInstances",
@@ -221,37 +252,59 @@ var AllServices = []*ServiceInfo{
 		},
 	},
 	&ServiceInfo{
-		Object:      "Instance",
-		Service:     "Instances",
-		keyType:     Zonal,
-		serviceType: reflect.TypeOf(&ga.InstancesService{}),
+		Object:         "Instance",
+		Service:        "Instances",
+		keyType:        Zonal,
+		serviceType:    reflect.TypeOf(&ga.InstancesService{}),
+		tags:           []Tag{TagInstances},
+		requiredFields: []string{"MachineType"},
 		additionalMethods: []string{
 			"AttachDisk",
 			"DetachDisk",
 		},
+		options: AggregatedList,
+		// Instances are read far more often than the other resources and
+		// have a larger read quota.
+		rateLimits: map[string]RateLimit{
+			"Get": {QPS: 50, Burst: 100},
+		},
 	},
 	&ServiceInfo{
-		Object:      "Instance",
-		Service:     "Instances",
-		version:     VersionBeta,
-		keyType:     Zonal,
-		serviceType: reflect.TypeOf(&beta.InstancesService{}),
+		Object:         "Instance",
+		Service:        "Instances",
+		version:        VersionBeta,
+		keyType:        Zonal,
+		serviceType:    reflect.TypeOf(&beta.InstancesService{}),
+		tags:           []Tag{TagInstances},
+		requiredFields: []string{"MachineType"},
 		additionalMethods: []string{
 			"AttachDisk",
 			"DetachDisk",
 		},
+		options: AggregatedList,
 	},
 	&ServiceInfo{
-		Object:      "Instance",
-		Service:     "Instances",
-		version:     VersionAlpha,
-		keyType:     Zonal,
-		serviceType: reflect.TypeOf(&alpha.InstancesService{}),
+		Object:         "Instance",
+		Service:        "Instances",
+		version:        VersionAlpha,
+		keyType:        Zonal,
+		serviceType:    reflect.TypeOf(&alpha.InstancesService{}),
+		tags:           []Tag{TagInstances},
+		requiredFields: []string{"MachineType"},
 		additionalMethods: []string{
 			"AttachDisk",
 			"DetachDisk",
 			"UpdateNetworkInterface",
 		},
+		options: AggregatedList,
+	},
+	&ServiceInfo{
+		Object:      "MachineType",
+		Service:     "MachineTypes",
+		keyType:     Zonal,
+		options:     ReadOnly,
+		serviceType: reflect.TypeOf(&ga.MachineTypesService{}),
+		tags:        []Tag{TagInstances},
 	},
 	&ServiceInfo{
 		Object:      "NetworkEndpointGroup",
@@ -259,12 +312,34 @@ var AllServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Zonal,
 		serviceType: reflect.TypeOf(&alpha.NetworkEndpointGroupsService{}),
+		tags:        []Tag{TagLoadBalancing},
 		additionalMethods: []string{
 			"AttachNetworkEndpoints",
 			"DetachNetworkEndpoints",
 		},
 		options: AggregatedList,
 	},
+	&ServiceInfo{
+		Object:      "Operation",
+		Service:     "GlobalOperations",
+		keyType:     Global,
+		options:     NoInsert | CustomOps,
+		serviceType: reflect.TypeOf(&ga.GlobalOperationsService{}),
+	},
+	&ServiceInfo{
+		Object:      "Operation",
+		Service:     "RegionOperations",
+		keyType:     Regional,
+		options:     NoInsert | CustomOps,
+		serviceType: reflect.TypeOf(&ga.RegionOperationsService{}),
+	},
+	&ServiceInfo{
+		Object:      "Operation",
+		Service:     "ZoneOperations",
+		keyType:     Zonal,
+		options:     NoInsert | CustomOps,
+		serviceType: reflect.TypeOf(&ga.ZoneOperationsService{}),
+	},
 	&ServiceInfo{
 		Object:  "Project",
 		Service: "Projects",
@@ -281,31 +356,38 @@ var AllServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.RegionsService{}),
 	},
 	&ServiceInfo{
-		Object:      "Route",
-		Service:     "Routes",
-		keyType:     Global,
-		serviceType: reflect.TypeOf(&ga.RoutesService{}),
+		Object:         "Route",
+		Service:        "Routes",
+		keyType:        Global,
+		serviceType:    reflect.TypeOf(&ga.RoutesService{}),
+		tags:           []Tag{TagNetworking},
+		requiredFields: []string{"Network", "DestRange"},
 	},
 	&ServiceInfo{
 		Object:      "SslCertificate",
 		Service:     "SslCertificates",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.SslCertificatesService{}),
+		tags:        []Tag{TagLoadBalancing},
 	},
 	&ServiceInfo{
-		Object:      "TargetHttpProxy",
-		Service:     "TargetHttpProxies",
-		keyType:     Global,
-		serviceType: reflect.TypeOf(&ga.TargetHttpProxiesService{}),
+		Object:         "TargetHttpProxy",
+		Service:        "TargetHttpProxies",
+		keyType:        Global,
+		serviceType:    reflect.TypeOf(&ga.TargetHttpProxiesService{}),
+		tags:           []Tag{TagLoadBalancing},
+		requiredFields: []string{"UrlMap"},
 		additionalMethods: []string{
 			"SetUrlMap",
 		},
 	},
 	&ServiceInfo{
-		Object:      "TargetHttpsProxy",
-		Service:     "TargetHttpsProxies",
-		keyType:     Global,
-		serviceType: reflect.TypeOf(&ga.TargetHttpsProxiesService{}),
+		Object:         "TargetHttpsProxy",
+		Service:        "TargetHttpsProxies",
+		keyType:        Global,
+		serviceType:    reflect.TypeOf(&ga.TargetHttpsProxiesService{}),
+		tags:           []Tag{TagLoadBalancing},
+		requiredFields: []string{"UrlMap", "SslCertificates"},
 		additionalMethods: []string{
 			"SetSslCertificates",
 			"SetUrlMap",
@@ -316,6 +398,7 @@ var AllServices = []*ServiceInfo{
 		Service:     "TargetPools",
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.TargetPoolsService{}),
+		tags:        []Tag{TagLoadBalancing},
 		additionalMethods: []string{
 			"AddInstance",
 			"RemoveInstance",
@@ -326,9 +409,8 @@ var AllServices = []*ServiceInfo{
 		Service:     "UrlMaps",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.UrlMapsService{}),
-		additionalMethods: []string{
-			"Update",
-		},
+		tags:        []Tag{TagLoadBalancing},
+		options:     Update | Patch,
 	},
 	&ServiceInfo{
 		Object:      "Zone",
@@ -62,16 +62,11 @@ func (a *arg) normalizedPkg() string {
 			break
 		}
 	}
-	switch strings.Join(parts, "/") {
-	case "google.golang.org/api/compute/v1":
-		return "ga."
-	case "google.golang.org/api/compute/v0.alpha":
-		return "alpha."
-	case "google.golang.org/api/compute/v0.beta":
-		return "beta."
-	default:
+	v, ok := versionForPackage(strings.Join(parts, "/"))
+	if !ok {
 		panic(fmt.Errorf("unhandled package %q", a.pkg))
 	}
+	return string(v) + "."
 }
 
 func (a *arg) String() string {
@@ -84,9 +79,13 @@ func (a *arg) String() string {
 	return ret
 }
 
-// newMethod returns a newly initialized method.
-func newMethod(s *ServiceInfo, m reflect.Method) *Method {
-	ret := &Method{s, m, ""}
+// newMethod returns a newly initialized method. version is the API version
+// of the method if it differs from the version of the service, "" otherwise.
+func newMethod(s *ServiceInfo, m reflect.Method, version Version) *Method {
+	if version == s.Version() {
+		version = ""
+	}
+	ret := &Method{ServiceInfo: s, m: m, version: version}
 	ret.init()
 	return ret
 }
@@ -95,10 +94,28 @@ func newMethod(s *ServiceInfo, m reflect.Method) *Method {
 type Method struct {
 	*ServiceInfo
 	m reflect.Method
+	// version overrides the version of the service if set.
+	version Version
 
 	ReturnType string
 }
 
+// Version returns the API version of the method, which is the version of the
+// service unless overridden (see VersionOverridden).
+func (mr *Method) Version() Version {
+	if mr.version != "" {
+		return mr.version
+	}
+	return mr.ServiceInfo.Version()
+}
+
+// VersionOverridden is true if the method is called at a different API
+// version than the rest of the service. Its parameters and result are then
+// the types of that version.
+func (mr *Method) VersionOverridden() bool {
+	return mr.version != ""
+}
+
 // argsSkip is the number of arguments to skip when generating the
 // synthesized method.
 func (mr *Method) argsSkip() int {
@@ -204,6 +221,24 @@ func (mr *Method) CallArgs() string {
 	return fmt.Sprintf(", %s", strings.Join(args, ", "))
 }
 
+// Request is the expression for the request payload of the call: nil if
+// there are no arguments, the argument itself if there is one and a slice of
+// the arguments otherwise.
+func (mr *Method) Request() string {
+	var args []string
+	for i := mr.argsSkip(); i < mr.m.Func.Type().NumIn(); i++ {
+		args = append(args, fmt.Sprintf("arg%d", i-mr.argsSkip()))
+	}
+	switch len(args) {
+	case 0:
+		return "nil"
+	case 1:
+		return args[0]
+	default:
+		return fmt.Sprintf("[]interface{}{%s}", strings.Join(args, ", "))
+	}
+}
+
 func (mr *Method) MockHookName() string {
 	return mr.m.Name + "Hook"
 }
@@ -225,6 +260,7 @@ func (mr *Method) FcnArgs() string {
 		"ctx context.Context",
 		"key meta.Key",
 	})
+	args = append(args, "opts ...interfaces.Option")
 
 	if mr.ReturnType == "Operation" {
 		return fmt.Sprintf("%v(%v) error", mr.m.Name, strings.Join(args, ", "))
@@ -232,8 +268,24 @@ func (mr *Method) FcnArgs() string {
 	return fmt.Sprintf("%v(%v) (*%v.%v, error)", mr.m.Name, strings.Join(args, ", "), mr.Version(), mr.ReturnType)
 }
 
+// MockFcnArgs is FcnArgs with the results named, as the mock records the
+// returned error.
+func (mr *Method) MockFcnArgs() string {
+	args := mr.args(mr.argsSkip(), true, []string{
+		"ctx context.Context",
+		"key meta.Key",
+	})
+	args = append(args, "opts ...interfaces.Option")
+
+	if mr.ReturnType == "Operation" {
+		return fmt.Sprintf("%v(%v) (err error)", mr.m.Name, strings.Join(args, ", "))
+	}
+	return fmt.Sprintf("%v(%v) (_ *%v.%v, err error)", mr.m.Name, strings.Join(args, ", "), mr.Version(), mr.ReturnType)
+}
+
 func (mr *Method) InterfaceFunc() string {
 	args := mr.args(mr.argsSkip(), false, []string{"context.Context", "meta.Key"})
+	args = append(args, "...Option")
 	if mr.ReturnType == "Operation" {
 		return fmt.Sprintf("%v(%v) error", mr.m.Name, strings.Join(args, ", "))
 	}
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import "fmt"
+
+// RateLimit is a hint for the rate of the calls of an operation, for use by
+// the rate limiters (see cloud.RateLimitHint()). QPS is the sustained number
+// of calls per second and Burst the number of calls that can be made at once.
+type RateLimit struct {
+	QPS   float64 `json:"qps"`
+	Burst int     `json:"burst"`
+}
+
+// AnyOperation is the key of the rate limit hint of a service for the
+// operations without a hint of their own.
+const AnyOperation = "*"
+
+// DefaultRateLimits are the rate limit hints of the operations of the
+// services that do not declare them, keyed by operation. The reads are
+// cheaper than the mutations, which are under AnyOperation.
+var DefaultRateLimits = map[string]RateLimit{
+	"Get":            {QPS: 20, Burst: 40},
+	"List":           {QPS: 5, Burst: 10},
+	"AggregatedList": {QPS: 2, Burst: 5},
+	AnyOperation:     {QPS: 5, Burst: 10},
+}
+
+// OperationsRateLimit is the rate limit hint for polling the status of the
+// long running operations ("Get" of the "Operations" service).
+var OperationsRateLimit = RateLimit{QPS: 5, Burst: 5}
+
+// LookupRateLimit returns the hint for operation in limits, falling back to
+// the AnyOperation entry of limits and then to DefaultRateLimits.
+func LookupRateLimit(limits map[string]RateLimit, operation string) RateLimit {
+	for _, m := range []map[string]RateLimit{limits, DefaultRateLimits} {
+		if rl, ok := m[operation]; ok {
+			return rl
+		}
+		if rl, ok := m[AnyOperation]; ok {
+			return rl
+		}
+	}
+	return DefaultRateLimits[AnyOperation]
+}
+
+// validate returns an error if the hint cannot be used.
+func (rl RateLimit) validate() error {
+	if rl.QPS <= 0 || rl.Burst < 1 {
+		return fmt.Errorf("invalid rate limit %+v: QPS must be > 0 and Burst >= 1", rl)
+	}
+	return nil
+}
+
+// RateLimits returns the rate limit hints declared for the operations of the
+// service (see RateLimit()).
+func (i *ServiceInfo) RateLimits() map[string]RateLimit {
+	return i.rateLimits
+}
+
+// RateLimit returns the rate limit hint for operation (e.g. "Get"): the one
+// declared by the service, or the default (see LookupRateLimit()).
+func (i *ServiceInfo) RateLimit(operation string) RateLimit {
+	return LookupRateLimit(i.rateLimits, operation)
+}
+
+// checkRateLimits returns an error if the rate limit hints of the service are
+// invalid or are for operations the service does not have.
+func (i *ServiceInfo) checkRateLimits() error {
+	if len(i.rateLimits) == 0 {
+		return nil
+	}
+	ops := map[string]bool{AnyOperation: true}
+	for _, op := range i.Operations() {
+		ops[op] = true
+	}
+	// UpdateLabels makes a SetLabels call.
+	if i.SupportsLabels() {
+		ops["SetLabels"] = true
+	}
+	for op, rl := range i.rateLimits {
+		if !ops[op] {
+			return fmt.Errorf("rate limit for %q, which is not an operation of the service", op)
+		}
+		if err := rl.validate(); err != nil {
+			return fmt.Errorf("rate limit for %q: %v", op, err)
+		}
+	}
+	return nil
+}
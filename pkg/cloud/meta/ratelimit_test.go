@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"testing"
+)
+
+func TestLookupRateLimit(t *testing.T) {
+	t.Parallel()
+
+	limits := map[string]RateLimit{
+		"Get":        {QPS: 50, Burst: 100},
+		AnyOperation: {QPS: 1, Burst: 1},
+	}
+	for _, tc := range []struct {
+		limits map[string]RateLimit
+		op     string
+		want   RateLimit
+	}{
+		{limits, "Get", RateLimit{QPS: 50, Burst: 100}},
+		{limits, "List", RateLimit{QPS: 1, Burst: 1}},
+		{nil, "Get", DefaultRateLimits["Get"]},
+		{nil, "List", DefaultRateLimits["List"]},
+		{nil, "Insert", DefaultRateLimits[AnyOperation]},
+		{map[string]RateLimit{"Get": {QPS: 50, Burst: 100}}, "List", DefaultRateLimits["List"]},
+	} {
+		if got := LookupRateLimit(tc.limits, tc.op); got != tc.want {
+			t.Errorf("LookupRateLimit(%v, %q) = %+v; want %+v", tc.limits, tc.op, got, tc.want)
+		}
+	}
+
+	si := AllServicesByGroup["Instances"].GA
+	if got, want := si.RateLimit("Get"), (RateLimit{QPS: 50, Burst: 100}); got != want {
+		t.Errorf("Instances.RateLimit(Get) = %+v; want %+v", got, want)
+	}
+	if got, want := si.RateLimit("Insert"), DefaultRateLimits[AnyOperation]; got != want {
+		t.Errorf("Instances.RateLimit(Insert) = %+v; want %+v", got, want)
+	}
+}
+
+func TestRateLimitsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	const config = `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "rateLimits": {"Get": {"qps": 100, "burst": 200}, "*": {"qps": 0.5, "burst": 1}}}]}`
+	services, err := ServicesFromConfig([]byte(config))
+	if err != nil {
+		t.Fatalf("ServicesFromConfig() = _, %v; want _, nil", err)
+	}
+	if got, want := services[0].RateLimit("Get"), (RateLimit{QPS: 100, Burst: 200}); got != want {
+		t.Errorf("Zones.RateLimit(Get) = %+v; want %+v", got, want)
+	}
+	if got, want := services[0].RateLimit("List"), (RateLimit{QPS: 0.5, Burst: 1}); got != want {
+		t.Errorf("Zones.RateLimit(List) = %+v; want %+v", got, want)
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		config string
+	}{
+		{"unknown operation", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "rateLimits": {"Insert": {"qps": 1, "burst": 1}}}]}`},
+		{"zero QPS", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "rateLimits": {"Get": {"qps": 0, "burst": 1}}}]}`},
+		{"zero burst", `{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "rateLimits": {"Get": {"qps": 1}}}]}`},
+	} {
+		if _, err := ServicesFromConfig([]byte(tc.config)); err == nil {
+			t.Errorf("%s: ServicesFromConfig(%q) = _, nil; want error", tc.desc, tc.config)
+		}
+	}
+}
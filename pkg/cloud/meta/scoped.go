@@ -0,0 +1,167 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ScopedPair declares a resource that exists in two scopes as two services
+// with the same object type, e.g. Disks (zonal) and RegionDisks (regional).
+// A combined interface is generated for the pair (see WrapType()) whose
+// methods call the service for the scope of the key, so that callers do not
+// have to pick between the two services.
+type ScopedPair struct {
+	// Name of the resource, e.g. "Disks".
+	Name string
+	// Version of the services.
+	Version Version
+	// Services are the names of the two services.
+	Services [2]string
+
+	// services are the ServiceInfos of Services, set by ScopedPairsFor().
+	services []*ServiceInfo
+}
+
+// ScopedPairs are the resources with a service for each of two scopes at the
+// same version. HealthChecks has no regional service in AllServices and is
+// not a pair.
+var ScopedPairs = []*ScopedPair{
+	{Name: "Addresses", Version: VersionGA, Services: [2]string{"Addresses", "GlobalAddresses"}},
+	{Name: "BackendServices", Version: VersionAlpha, Services: [2]string{"BackendServices", "RegionBackendServices"}},
+	{Name: "Disks", Version: VersionAlpha, Services: [2]string{"Disks", "RegionDisks"}},
+	{Name: "ForwardingRules", Version: VersionGA, Services: [2]string{"ForwardingRules", "GlobalForwardingRules"}},
+}
+
+// ScopedPairsFor returns the ScopedPairs for which both services are in
+// services, bound to the services. It returns an error if the services of a
+// pair are not of the same object type or have the same key type.
+func ScopedPairsFor(services []*ServiceInfo) ([]*ScopedPair, error) {
+	var ret []*ScopedPair
+	for _, p := range ScopedPairs {
+		bound, err := p.bind(services)
+		if err != nil {
+			return nil, err
+		}
+		if bound != nil {
+			ret = append(ret, bound)
+		}
+	}
+	return ret, nil
+}
+
+// bind returns a copy of p with the services set, or nil if any of them is
+// not in services.
+func (p *ScopedPair) bind(services []*ServiceInfo) (*ScopedPair, error) {
+	var found []*ServiceInfo
+	for _, name := range p.Services {
+		for _, s := range services {
+			if s.Service == name && s.Version() == p.Version {
+				found = append(found, s)
+			}
+		}
+	}
+	if len(found) != len(p.Services) {
+		return nil, nil
+	}
+	a, b := found[0], found[1]
+	switch {
+	case a.Object != b.Object:
+		return nil, fmt.Errorf("scoped pair %q: services %q and %q have different objects (%q and %q)", p.Name, a.Service, b.Service, a.Object, b.Object)
+	case a.KeyType() == b.KeyType():
+		return nil, fmt.Errorf("scoped pair %q: services %q and %q are both %s", p.Name, a.Service, b.Service, a.KeyType())
+	}
+	ret := *p
+	ret.services = found
+	return &ret, nil
+}
+
+// ServiceInfos returns the services of the pair. It is empty unless the pair
+// was returned by ScopedPairsFor().
+func (p *ScopedPair) ServiceInfos() []*ServiceInfo {
+	return p.services
+}
+
+// WrapType is the name of the combined interface (e.g. "AlphaScopedDisks").
+func (p *ScopedPair) WrapType() string {
+	vi, ok := p.Version.Info()
+	if !ok {
+		return "Invalid"
+	}
+	return vi.WrapPrefix + "Scoped" + p.Name
+}
+
+// Accessor is the name of the method of cloud.ScopedCloud that returns the
+// combined interface (e.g. "AlphaDisks").
+func (p *ScopedPair) Accessor() string {
+	vi, ok := p.Version.Info()
+	if !ok {
+		return "Invalid"
+	}
+	return vi.WrapPrefix + p.Name
+}
+
+// ImplType is the name of the unexported type that implements the combined
+// interface (e.g. "alphaScopedDisks").
+func (p *ScopedPair) ImplType() string {
+	w := p.WrapType()
+	return strings.ToLower(w[:1]) + w[1:]
+}
+
+// ServiceList lists the wrapper types of the services of the pair (e.g.
+// "AlphaDisks or AlphaRegionDisks").
+func (p *ScopedPair) ServiceList() string {
+	var ret []string
+	for _, s := range p.services {
+		ret = append(ret, s.WrapType())
+	}
+	return strings.Join(ret, " or ")
+}
+
+// KeyTypes describes the key types accepted by the pair (e.g. "zonal or
+// regional").
+func (p *ScopedPair) KeyTypes() string {
+	var ret []string
+	for _, s := range p.services {
+		ret = append(ret, string(s.KeyType()))
+	}
+	return strings.Join(ret, " or ")
+}
+
+// InterfaceMethods returns the methods of the combined interface: the methods
+// that take a key and that both services have with the same signature.
+func (p *ScopedPair) InterfaceMethods() []*InterfaceMethod {
+	if len(p.services) == 0 {
+		return nil
+	}
+	others := map[string]*InterfaceMethod{}
+	for _, m := range p.services[1].InterfaceMethods() {
+		others[m.Name] = m
+	}
+	var ret []*InterfaceMethod
+	for _, m := range p.services[0].InterfaceMethods() {
+		if len(m.Params) < 2 || m.Params[1] != "meta.Key" {
+			continue
+		}
+		if o, ok := others[m.Name]; ok && reflect.DeepEqual(m, o) {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScopedPairsFor(t *testing.T) {
+	t.Parallel()
+
+	pairs, err := ScopedPairsFor(AllServices)
+	if err != nil {
+		t.Fatalf("ScopedPairsFor(AllServices) = _, %v; want _, nil", err)
+	}
+	if len(pairs) != len(ScopedPairs) {
+		t.Errorf("len(ScopedPairsFor(AllServices)) = %d; want %d", len(pairs), len(ScopedPairs))
+	}
+
+	var disks *ScopedPair
+	for _, p := range pairs {
+		if p.Name == "Disks" {
+			disks = p
+		}
+	}
+	if disks == nil {
+		t.Fatalf("ScopedPairsFor(AllServices) has no pair %q", "Disks")
+	}
+	for _, tc := range []struct {
+		desc string
+		got  string
+		want string
+	}{
+		{"WrapType()", disks.WrapType(), "AlphaScopedDisks"},
+		{"Accessor()", disks.Accessor(), "AlphaDisks"},
+		{"ImplType()", disks.ImplType(), "alphaScopedDisks"},
+		{"ServiceList()", disks.ServiceList(), "AlphaDisks or AlphaRegionDisks"},
+		{"KeyTypes()", disks.KeyTypes(), "zonal or regional"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("disks.%s = %q; want %q", tc.desc, tc.got, tc.want)
+		}
+	}
+	var names []string
+	for _, m := range disks.InterfaceMethods() {
+		names = append(names, m.Name)
+	}
+	// The List calls do not take a key and are not part of the interface.
+	if want := []string{"Get", "Exists", "Insert", "InsertOp", "GetOrCreate", "Delete", "DeleteOp", "UpdateLabels"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("disks.InterfaceMethods() = %v; want %v", names, want)
+	}
+
+	// A pair is only generated if both of its services are.
+	var zonal []*ServiceInfo
+	for _, s := range AllServices {
+		if s.Service != "RegionDisks" {
+			zonal = append(zonal, s)
+		}
+	}
+	pairs, err = ScopedPairsFor(zonal)
+	if err != nil {
+		t.Fatalf("ScopedPairsFor() = _, %v; want _, nil", err)
+	}
+	for _, p := range pairs {
+		if p.Name == "Disks" {
+			t.Errorf("ScopedPairsFor() without RegionDisks has pair %q", p.Name)
+		}
+	}
+}
+
+func TestScopedPairBind(t *testing.T) {
+	t.Parallel()
+
+	services := []*ServiceInfo{
+		{Object: "Disk", Service: "Disks", keyType: Zonal},
+		{Object: "Disk", Service: "ZonalDisks", keyType: Zonal},
+		{Object: "Snapshot", Service: "RegionDisks", keyType: Regional},
+	}
+	for _, tc := range []struct {
+		desc string
+		pair *ScopedPair
+	}{
+		{"same key type", &ScopedPair{Name: "Disks", Version: VersionGA, Services: [2]string{"Disks", "ZonalDisks"}}},
+		{"different objects", &ScopedPair{Name: "Disks", Version: VersionGA, Services: [2]string{"Disks", "RegionDisks"}}},
+	} {
+		if _, err := tc.pair.bind(services); err == nil {
+			t.Errorf("%s: bind() = _, nil; want error", tc.desc)
+		}
+	}
+}
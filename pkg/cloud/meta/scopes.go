@@ -0,0 +1,63 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"sort"
+)
+
+// CloudPlatformScope is the OAuth scope that allows all of the Google Cloud
+// APIs. It can be used in place of any of the scopes returned by Scopes().
+const CloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// Scopes returns the OAuth scopes needed to call all of the methods of the
+// service: the read-write scope of the API group if the service has methods
+// that mutate resources, the read-only scope otherwise.
+func (i *ServiceInfo) Scopes() []string {
+	g := i.APIGroup()
+	scope := g.ReadOnlyScope
+	if i.MutationsEnabled() {
+		scope = g.ReadWriteScope
+	}
+	if scope == "" {
+		scope = CloudPlatformScope
+	}
+	return []string{scope}
+}
+
+// RequiredScopes returns the minimal set of OAuth scopes that allows all of
+// the methods of services, sorted. The read-only scope of an API group is
+// omitted if its read-write scope is needed.
+func RequiredScopes(services ...*ServiceInfo) []string {
+	needed := map[string]bool{}
+	for _, s := range services {
+		for _, scope := range s.Scopes() {
+			needed[scope] = true
+		}
+	}
+	for _, s := range services {
+		if g := s.APIGroup(); g.ReadWriteScope != "" && needed[g.ReadWriteScope] {
+			delete(needed, g.ReadOnlyScope)
+		}
+	}
+	var ret []string
+	for scope := range needed {
+		ret = append(ret, scope)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	t.Parallel()
+
+	const (
+		ro = "https://www.googleapis.com/auth/compute.readonly"
+		rw = "https://www.googleapis.com/auth/compute"
+	)
+	zones := AllServicesByGroup["Zones"].GA
+	firewalls := AllServicesByGroup["Firewalls"].GA
+	projects := AllServicesByGroup["Projects"].GA
+	other := &ServiceInfo{Service: "Zones", options: ReadOnly, apiGroup: &APIGroup{Name: "other"}, serviceType: reflect.TypeOf(&ga.ZonesService{})}
+
+	for _, tc := range []struct {
+		desc     string
+		services []*ServiceInfo
+		want     []string
+	}{
+		{"none", nil, nil},
+		{"read-only", []*ServiceInfo{zones}, []string{ro}},
+		{"read-write", []*ServiceInfo{firewalls}, []string{rw}},
+		{"custom ops", []*ServiceInfo{projects}, []string{rw}},
+		{"mixed", []*ServiceInfo{zones, firewalls}, []string{rw}},
+		{"no scopes in the API group", []*ServiceInfo{other, zones}, []string{CloudPlatformScope, ro}},
+	} {
+		if got := RequiredScopes(tc.services...); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: RequiredScopes() = %v; want %v", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -20,20 +20,64 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // ServiceInfo defines the entry for a Service that code will be generated for.
 type ServiceInfo struct {
 	Object  string
 	Service string
+	// apiGroup if unspecified will be assumed to be ComputeAPI.
+	apiGroup *APIGroup
 	// version if unspecified will be assumed to be VersionGA.
 	version     Version
 	keyType     KeyType
 	serviceType reflect.Type
 
-	additionalMethods   []string
+	additionalMethods []string
+	// methodVersions are the API versions of the additional methods that are
+	// called at a version other than the version of the service (e.g. a
+	// setter only available in alpha on a GA service).
+	methodVersions map[string]Version
+	// listMethods are additional List style calls (see ListCall).
+	listMethods         []string
 	options             int
 	aggregatedListField string
+	// snippets is custom code merged into the generated methods, keyed by
+	// injection point (see Snippet).
+	snippets map[string]string
+	// rateLimits are the rate limit hints of the operations of the service
+	// (see RateLimit()).
+	rateLimits map[string]RateLimit
+	// tags group the service with others for the generated per-tag
+	// interfaces (see Tag).
+	tags []Tag
+	// requiredFields are the fields of the object that must be set on Insert
+	// (see RequiredFields()).
+	requiredFields []string
+	// deprecated, if set, is the deprecation notice of the service (e.g.
+	// "Use HealthChecks.").
+	deprecated string
+	// deprecatedMethods are the deprecation notices of the methods of the
+	// service, keyed by method name.
+	deprecatedMethods map[string]string
+	// identityField, if set, is the field of the object identified by the
+	// name of the key, if it is not "Name" (see IdentityField()).
+	identityField string
+}
+
+// NoIdentityField is the IdentityField of the services whose objects are
+// identified by a value assigned by the API, for which Insert does not set
+// any field of the object from the key.
+const NoIdentityField = "-"
+
+// APIGroup returns the API group of the Service, defaulting to ComputeAPI.
+func (i *ServiceInfo) APIGroup() *APIGroup {
+	if i.apiGroup == nil {
+		return ComputeAPI
+	}
+	return i.apiGroup
 }
 
 // Version returns the version of the Service, defaulting to GA if APIVersion
@@ -47,28 +91,20 @@ func (i *ServiceInfo) Version() Version {
 
 // VersionTitle returns the capitalized golang CamelCase name for the version.
 func (i *ServiceInfo) VersionTitle() string {
-	switch i.Version() {
-	case VersionGA:
-		return "GA"
-	case VersionAlpha:
-		return "Alpha"
-	case VersionBeta:
-		return "Beta"
+	vi, ok := i.Version().Info()
+	if !ok {
+		panic(fmt.Errorf("invalid version %q", i.Version()))
 	}
-	panic(fmt.Errorf("invalid version %q", i.Version()))
+	return vi.Title
 }
 
 // WrapType is the name of the wrapper service type.
 func (i *ServiceInfo) WrapType() string {
-	switch i.Version() {
-	case VersionGA:
-		return i.Service
-	case VersionAlpha:
-		return "Alpha" + i.Service
-	case VersionBeta:
-		return "Beta" + i.Service
+	vi, ok := i.Version().Info()
+	if !ok {
+		return "Invalid"
 	}
-	return "Invalid"
+	return vi.WrapPrefix + i.Service
 }
 
 // WrapTypeOps is the name of the additional operations type.
@@ -111,30 +147,103 @@ func (i *ServiceInfo) Field() string {
 	return "gce" + i.WrapType()
 }
 
-// Methods returns a list of additional methods to generate code for.
-func (i *ServiceInfo) Methods() []*Method {
-	methods := map[string]bool{}
-	for _, m := range i.additionalMethods {
-		methods[m] = true
+// MakeKey returns the call used to create the appropriate key type for the
+// service (e.g. `RegionalKey("name", "location")`).
+func (i *ServiceInfo) MakeKey(name, location string) string {
+	switch i.keyType {
+	case Global:
+		return fmt.Sprintf("GlobalKey(%q)", name)
+	case Regional:
+		return fmt.Sprintf("RegionalKey(%q, %q)", name, location)
+	case Zonal:
+		return fmt.Sprintf("ZonalKey(%q, %q)", name, location)
 	}
+	return "Invalid"
+}
+
+// TypedKey is the name of the generated key type for the service. It is the
+// object name with the prefix of the service, if any (e.g. "AddressKey" for
+// Addresses and "GlobalAddressKey" for GlobalAddresses).
+func (i *ServiceInfo) TypedKey() string {
+	if i.Object == "" {
+		return i.Service + "Key"
+	}
+	// The service is the plural of the object, e.g. "TargetHttpProxies".
+	idx := strings.LastIndex(i.Service, i.Object[:len(i.Object)-1])
+	if idx < 0 {
+		return i.Service + "Key"
+	}
+	return i.Service[:idx] + i.Object + "Key"
+}
 
+// Methods returns a list of additional methods to generate code for, sorted
+// by name.
+func (i *ServiceInfo) Methods() []*Method {
+	seen := map[string]bool{}
 	var ret []*Method
-	for j := 0; j < i.serviceType.NumMethod(); j++ {
-		m := i.serviceType.Method(j)
-		if _, ok := methods[m.Name]; !ok {
+	for _, name := range i.additionalMethods {
+		if seen[name] {
 			continue
 		}
-		ret = append(ret, newMethod(i, m))
-		methods[m.Name] = false
+		seen[name] = true
+		st, err := i.methodServiceType(name)
+		if err != nil {
+			panic(err)
+		}
+		m, ok := st.MethodByName(name)
+		if !ok {
+			panic(fmt.Errorf("method %q was not found in service %q", name, i.Service))
+		}
+		ret = append(ret, newMethod(i, m, i.methodVersions[name]))
 	}
+	sort.Slice(ret, func(a, b int) bool { return ret[a].Name() < ret[b].Name() })
+	return ret
+}
 
-	for k, b := range methods {
-		if b {
-			panic(fmt.Errorf("method %q was not found in service %q", k, i.Service))
-		}
+// methodServiceType returns the type of the client service that has the
+// additional method name, which depends on the version of the method.
+func (i *ServiceInfo) methodServiceType(name string) (reflect.Type, error) {
+	v, ok := i.methodVersions[name]
+	if !ok || v == i.Version() {
+		return i.serviceType, nil
 	}
+	return i.APIGroup().serviceType(v, i.Service)
+}
 
-	return ret
+// KeyType returns the type of the key of the service.
+func (i *ServiceInfo) KeyType() KeyType {
+	return i.keyType
+}
+
+// KeyTypeConst returns the name of the constant in this package for the
+// KeyType of the service (e.g. "Zonal"), for use in generated code.
+func (i *ServiceInfo) KeyTypeConst() string {
+	switch i.keyType {
+	case Zonal:
+		return "Zonal"
+	case Regional:
+		return "Regional"
+	default:
+		return "Global"
+	}
+}
+
+// ResourcePath is the name of the resource collection in the REST API URLs
+// (e.g. "addresses" in "projects/<proj>/regions/<region>/addresses"). The
+// "Global" and "Region" prefixes of the service name are given by the scope
+// of the URL and are not part of the collection name.
+func (i *ServiceInfo) ResourcePath() string {
+	name := i.Service
+	switch {
+	case i.keyType == Global && strings.HasPrefix(name, "Global"):
+		name = strings.TrimPrefix(name, "Global")
+	case i.keyType == Regional && strings.HasPrefix(name, "Region"):
+		name = strings.TrimPrefix(name, "Region")
+	}
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
 }
 
 // KeyIsGlobal is true if the key is global.
@@ -167,6 +276,17 @@ func (i *ServiceInfo) GenerateDelete() bool {
 	return i.options&NoDelete == 0
 }
 
+// DeleteReturnsOperation is true if the Delete call of the service returns
+// an operation to wait for. The Delete of an Operation does not.
+func (i *ServiceInfo) DeleteReturnsOperation() bool {
+	m, ok := i.serviceType.MethodByName("Delete")
+	if !ok || m.Type.NumOut() != 1 {
+		return false
+	}
+	do, ok := m.Type.Out(0).MethodByName("Do")
+	return ok && do.Type.NumOut() == 2
+}
+
 // GenerateInsert is true if the method is to be generated.
 func (i *ServiceInfo) GenerateInsert() bool {
 	return i.options&NoInsert == 0
@@ -178,11 +298,222 @@ func (i *ServiceInfo) GenerateCustomOps() bool {
 	return i.options&CustomOps != 0
 }
 
+// GenerateUpdate is true if the method is to be generated.
+func (i *ServiceInfo) GenerateUpdate() bool {
+	return i.options&Update != 0
+}
+
+// GeneratePatch is true if the method is to be generated.
+func (i *ServiceInfo) GeneratePatch() bool {
+	return i.options&Patch != 0
+}
+
+// ReadOnly is true if the resource is read-only (see meta.ReadOnly).
+func (i *ServiceInfo) ReadOnly() bool {
+	return i.options&readOnly != 0
+}
+
 // AggregatedList is true if the method is to be generated.
 func (i *ServiceInfo) AggregatedList() bool {
 	return i.options&AggregatedList != 0
 }
 
+// The Supports* methods, MutationsEnabled(), ExtraMethods() and Operations()
+// describe the operations of the generated service for generic tooling (see
+// the registry in package cloud).
+
+// SupportsUpdate is true if the service has an Update method.
+func (i *ServiceInfo) SupportsUpdate() bool {
+	return i.GenerateUpdate()
+}
+
+// SupportsPatch is true if the service has a Patch method.
+func (i *ServiceInfo) SupportsPatch() bool {
+	return i.GeneratePatch()
+}
+
+// SupportsAggregatedList is true if the service has an AggregatedList method.
+func (i *ServiceInfo) SupportsAggregatedList() bool {
+	return i.AggregatedList()
+}
+
+// MutationsEnabled is true if the service has methods that modify resources.
+// The custom (hand written) methods of a CustomOps service are assumed to.
+func (i *ServiceInfo) MutationsEnabled() bool {
+	if i.GenerateInsert() || i.GenerateDelete() || i.GenerateUpdate() || i.GeneratePatch() || i.GenerateCustomOps() {
+		return true
+	}
+	for _, m := range i.Methods() {
+		if m.ReturnType == "Operation" {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtraMethods returns the names of the additional methods generated for the
+// service (its additionalMethods), in order.
+func (i *ServiceInfo) ExtraMethods() []string {
+	var ret []string
+	for _, m := range i.Methods() {
+		ret = append(ret, m.Name())
+	}
+	return ret
+}
+
+// Operations returns the names of all of the methods of the generated
+// service interface (e.g. "Get", "List", "Insert", "SetLabels"), excluding the
+// hand written methods of CustomOps.
+func (i *ServiceInfo) Operations() []string {
+	var ret []string
+	for _, m := range i.InterfaceMethods() {
+		ret = append(ret, m.Name)
+	}
+	return ret
+}
+
+// SupportsLabels is true if the objects of the service have labels that are
+// set with a SetLabels call guarded by a label fingerprint (e.g. Disks). An
+// UpdateLabels method is generated for these services.
+func (i *ServiceInfo) SupportsLabels() bool {
+	if !i.GenerateGet() || i.ReadOnly() {
+		return false
+	}
+	if _, ok := i.serviceType.MethodByName("SetLabels"); !ok {
+		return false
+	}
+	return i.hasField("Labels", reflect.Map) && i.hasField("LabelFingerprint", reflect.String)
+}
+
+// SetLabelsRequestType is the type of the request of the SetLabels call (e.g.
+// "ga.ZoneSetLabelsRequest"). It is only valid if SupportsLabels() is true.
+func (i *ServiceInfo) SetLabelsRequestType() string {
+	m, ok := i.serviceType.MethodByName("SetLabels")
+	if !ok {
+		return ""
+	}
+	req := m.Type.In(m.Type.NumIn() - 1)
+	return fmt.Sprintf("%v.%v", i.Version(), req.Elem().Name())
+}
+
+// UsesFingerprint is true if the objects of the service have a fingerprint
+// that is used for optimistic concurrency by Update and Patch: the call fails
+// with http.StatusPreconditionFailed if the fingerprint of obj is set and is
+// not the one of the current object.
+func (i *ServiceInfo) UsesFingerprint() bool {
+	return (i.GenerateUpdate() || i.GeneratePatch()) && i.hasField("Fingerprint", reflect.String)
+}
+
+// SupportsUpdateWithRetry is true if an UpdateWithRetry method is generated
+// for the service: it has Get and an Update guarded by the fingerprint of the
+// object (e.g. BackendServices, UrlMaps).
+func (i *ServiceInfo) SupportsUpdateWithRetry() bool {
+	return i.GenerateGet() && i.GenerateUpdate() && i.UsesFingerprint()
+}
+
+// IdentityField returns the field of the object that is identified by the
+// name of the key, which Insert sets from the key: "Name" unless the service
+// declares another field (e.g. "Id"), or NoIdentityField.
+func (i *ServiceInfo) IdentityField() string {
+	if i.identityField == "" {
+		return "Name"
+	}
+	return i.identityField
+}
+
+// IdentityKind returns the golang type of the IdentityField, "string" or
+// "uint64", or "" for NoIdentityField.
+func (i *ServiceInfo) IdentityKind() string {
+	switch {
+	case i.identityField == "":
+		return "string"
+	case i.identityField == NoIdentityField:
+		return ""
+	case i.hasField(i.identityField, reflect.Uint64):
+		return "uint64"
+	}
+	return "string"
+}
+
+// checkIdentityField returns an error if the identity field is not a string
+// or uint64 field of the object.
+func (i *ServiceInfo) checkIdentityField() error {
+	if i.identityField == "" || i.identityField == NoIdentityField {
+		return nil
+	}
+	if !i.hasField(i.identityField, reflect.String) && !i.hasField(i.identityField, reflect.Uint64) {
+		return fmt.Errorf("identity field %q is not a string or uint64 field of %s", i.identityField, i.Object)
+	}
+	return nil
+}
+
+// RequiredFields returns the fields of the object that must be set on Insert
+// (e.g. "Network" for a Firewall). The mocks reject an Insert of an object
+// without them with http.StatusBadRequest, like the compute API.
+func (i *ServiceInfo) RequiredFields() []string {
+	return i.requiredFields
+}
+
+// RequiredFieldsInit returns the required fields set to a placeholder value
+// as elements of a composite literal of the object, each preceded by a comma
+// (e.g. `, Network: "network"`). It is used by the generated code that
+// inserts objects into the mocks.
+func (i *ServiceInfo) RequiredFieldsInit() string {
+	t, err := i.objectType()
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, name := range i.requiredFields {
+		f, ok := t.FieldByName(name)
+		if !ok {
+			continue
+		}
+		value := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Type.Kind() == reflect.Slice {
+			fmt.Fprintf(&b, ", %s: []string{%q}", name, value)
+		} else {
+			fmt.Fprintf(&b, ", %s: %q", name, value)
+		}
+	}
+	return b.String()
+}
+
+// checkRequiredFields returns an error if a required field is not a string or
+// []string field of the object.
+func (i *ServiceInfo) checkRequiredFields() error {
+	if len(i.requiredFields) == 0 {
+		return nil
+	}
+	t, err := i.objectType()
+	if err != nil {
+		return err
+	}
+	for _, name := range i.requiredFields {
+		f, ok := t.FieldByName(name)
+		switch {
+		case !ok:
+			return fmt.Errorf("required field %q is not a field of %v", name, t)
+		case f.Type.Kind() == reflect.String:
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String:
+		default:
+			return fmt.Errorf("required field %q is a %v; want string or []string", name, f.Type)
+		}
+	}
+	return nil
+}
+
+// hasField is true if the object of the service has a field name of the given
+// kind.
+func (i *ServiceInfo) hasField(name string, kind reflect.Kind) bool {
+	t, err := i.objectType()
+	if err != nil {
+		return false
+	}
+	f, ok := t.FieldByName(name)
+	return ok && f.Type.Kind() == kind
+}
+
 // AggregatedListField is the name of the field used for the aggregated list
 // call. This is typically the same as the name of the service, but can be
 // customized by setting the aggregatedListField field.
@@ -193,24 +524,107 @@ func (i *ServiceInfo) AggregatedListField() string {
 	return i.aggregatedListField
 }
 
+// Snippet returns the custom code for the injection point, or "" if there is
+// none. The injection points are "gce.<Method>" and "mock.<Method>" (e.g.
+// "gce.Insert"), which are the start of the body of the method of the GCE
+// adapter and of the mock. The code can use the parameters of the method
+// (e.g. ctx, key and obj) and may return early.
+func (i *ServiceInfo) Snippet(point string) string {
+	return i.snippets[point]
+}
+
+// Deprecated returns the deprecation notice of the service, or "" if it is
+// not deprecated. The generator adds it to the comments of the generated
+// types as a "Deprecated:" paragraph so that linters flag their use.
+func (i *ServiceInfo) Deprecated() string {
+	return i.deprecated
+}
+
+// MethodDeprecated returns the deprecation notice of the method name (e.g.
+// "Get", "SetNamedPorts"), or "" if it is not deprecated.
+func (i *ServiceInfo) MethodDeprecated(name string) string {
+	return i.deprecatedMethods[name]
+}
+
+// snippetPoints returns the valid injection points of the service.
+func (i *ServiceInfo) snippetPoints() map[string]bool {
+	ret := map[string]bool{}
+	for _, m := range i.InterfaceMethods() {
+		// Exists, GetOrCreate, InsertOp, DeleteOp, BatchGet, BatchDelete
+		// and UpdateWithRetry are implemented with the other methods.
+		// ListPage has no injection point.
+		switch m.Name {
+		case "Exists", "GetOrCreate", "InsertOp", "DeleteOp", "BatchGet", "BatchDelete", "UpdateWithRetry", "ListPage":
+			continue
+		}
+		ret["gce."+m.Name] = true
+		ret["mock."+m.Name] = true
+	}
+	return ret
+}
+
+// validate returns an error if the options of the service are inconsistent.
+func (i *ServiceInfo) validate() error {
+	if len(i.snippets) > 0 {
+		points := i.snippetPoints()
+		for p := range i.snippets {
+			if !points[p] {
+				return fmt.Errorf("service %q: invalid snippet injection point %q", i.Service, p)
+			}
+		}
+	}
+	if err := i.checkRateLimits(); err != nil {
+		return fmt.Errorf("service %q: %v", i.Service, err)
+	}
+	if err := i.checkTags(); err != nil {
+		return fmt.Errorf("service %q: %v", i.Service, err)
+	}
+	if err := i.checkRequiredFields(); err != nil {
+		return fmt.Errorf("service %q: %v", i.Service, err)
+	}
+	if err := i.checkIdentityField(); err != nil {
+		return fmt.Errorf("service %q: %v", i.Service, err)
+	}
+	if len(i.deprecatedMethods) > 0 {
+		methods := map[string]bool{}
+		for _, m := range i.InterfaceMethods() {
+			methods[m.Name] = true
+		}
+		for m := range i.deprecatedMethods {
+			if !methods[m] {
+				return fmt.Errorf("service %q: deprecated method %q is not a method of the service", i.Service, m)
+			}
+		}
+	}
+	if !i.ReadOnly() {
+		return nil
+	}
+	if i.options&(NoInsert|NoDelete) != NoInsert|NoDelete || i.options&(Update|Patch) != 0 {
+		return fmt.Errorf("service %q: ReadOnly cannot be combined with Insert, Delete, Update or Patch", i.Service)
+	}
+	for _, m := range i.Methods() {
+		if m.ReturnType == "Operation" {
+			return fmt.Errorf("service %q: ReadOnly cannot be combined with method %q, which mutates the resource", i.Service, m.Name())
+		}
+	}
+	return nil
+}
+
 // ServiceGroup is a grouping of the same service but at different API versions.
 type ServiceGroup struct {
 	Alpha *ServiceInfo
 	Beta  *ServiceInfo
 	GA    *ServiceInfo
+	// others are the services at the other Versions.
+	others map[Version]*ServiceInfo
 }
 
 func (sg *ServiceGroup) Service() string {
-	switch {
-	case sg.GA != nil:
-		return sg.GA.Service
-	case sg.Alpha != nil:
-		return sg.Alpha.Service
-	case sg.Beta != nil:
-		return sg.Beta.Service
-	default:
+	vs := sg.Versions()
+	if len(vs) == 0 {
 		panic(errors.New("service group is empty"))
 	}
+	return vs[0].Service
 }
 
 func (sg *ServiceGroup) HasGA() bool {
@@ -225,8 +639,44 @@ func (sg *ServiceGroup) HasBeta() bool {
 	return sg.Beta != nil
 }
 
-// groupServices together by version.
-func groupServices(services []*ServiceInfo) map[string]*ServiceGroup {
+// Version returns the service at version v, or nil if the service is not
+// generated at v.
+func (sg *ServiceGroup) Version(v Version) *ServiceInfo {
+	switch v {
+	case VersionAlpha:
+		return sg.Alpha
+	case VersionBeta:
+		return sg.Beta
+	case VersionGA:
+		return sg.GA
+	}
+	return sg.others[v]
+}
+
+// Versions returns the ServiceInfo for each version in the group, ordered
+// alpha, beta, GA and then the other versions in the order of Versions.
+func (sg *ServiceGroup) Versions() []*ServiceInfo {
+	var ret []*ServiceInfo
+	for _, si := range []*ServiceInfo{sg.Alpha, sg.Beta, sg.GA} {
+		if si != nil {
+			ret = append(ret, si)
+		}
+	}
+	for _, vi := range Versions {
+		if si, ok := sg.others[vi.Version]; ok {
+			ret = append(ret, si)
+		}
+	}
+	return ret
+}
+
+// MultiVersion is true if the service is generated at more than one version.
+func (sg *ServiceGroup) MultiVersion() bool {
+	return len(sg.Versions()) > 1
+}
+
+// GroupServices groups services together by version.
+func GroupServices(services []*ServiceInfo) map[string]*ServiceGroup {
 	ret := map[string]*ServiceGroup{}
 	for _, si := range services {
 		if _, ok := ret[si.Service]; !ok {
@@ -240,6 +690,11 @@ func groupServices(services []*ServiceInfo) map[string]*ServiceGroup {
 			group.Beta = si
 		case VersionGA:
 			group.GA = si
+		default:
+			if group.others == nil {
+				group.others = map[Version]*ServiceInfo{}
+			}
+			group.others[si.Version()] = si
 		}
 	}
 	return ret
@@ -249,5 +704,5 @@ func groupServices(services []*ServiceInfo) map[string]*ServiceGroup {
 var AllServicesByGroup map[string]*ServiceGroup
 
 func init() {
-	AllServicesByGroup = groupServices(AllServices)
+	AllServicesByGroup = GroupServices(AllServices)
 }
@@ -0,0 +1,240 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestTypedKey(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		object, service string
+		want            string
+	}{
+		{"Address", "Addresses", "AddressKey"},
+		{"Address", "GlobalAddresses", "GlobalAddressKey"},
+		{"TargetHttpProxy", "TargetHttpProxies", "TargetHttpProxyKey"},
+		{"BackendService", "RegionBackendServices", "RegionBackendServiceKey"},
+		{"Thing", "Widgets", "WidgetsKey"},
+		{"", "Widgets", "WidgetsKey"},
+	} {
+		si := &ServiceInfo{Object: tc.object, Service: tc.service}
+		if got := si.TypedKey(); got != tc.want {
+			t.Errorf("ServiceInfo{%q, %q}.TypedKey() = %q; want %q", tc.object, tc.service, got, tc.want)
+		}
+	}
+}
+
+func TestResourcePath(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		service string
+		keyType KeyType
+		want    string
+	}{
+		{"Addresses", Regional, "addresses"},
+		{"GlobalAddresses", Global, "addresses"},
+		{"RegionBackendServices", Regional, "backendServices"},
+		{"Regions", Global, "regions"},
+		{"TargetHttpProxies", Global, "targetHttpProxies"},
+		{"Instances", Zonal, "instances"},
+	} {
+		si := &ServiceInfo{Service: tc.service, keyType: tc.keyType}
+		if got := si.ResourcePath(); got != tc.want {
+			t.Errorf("ServiceInfo{%q, %v}.ResourcePath() = %q; want %q", tc.service, tc.keyType, got, tc.want)
+		}
+	}
+}
+
+func TestKeyTypeConst(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		keyType KeyType
+		want    string
+	}{
+		{Global, "Global"},
+		{Regional, "Regional"},
+		{Zonal, "Zonal"},
+	} {
+		si := &ServiceInfo{keyType: tc.keyType}
+		if got := si.KeyTypeConst(); got != tc.want {
+			t.Errorf("ServiceInfo{keyType: %v}.KeyTypeConst() = %q; want %q", tc.keyType, got, tc.want)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	t.Parallel()
+
+	type caps struct {
+		Update, Patch, AggregatedList, Mutations bool
+		Extra                                    []string
+	}
+	for _, tc := range []struct {
+		service string
+		version Version
+		want    caps
+	}{
+		{"BackendServices", VersionGA, caps{true, true, false, true, []string{"GetHealth"}}},
+		{"Addresses", VersionGA, caps{false, false, true, true, nil}},
+		{"Zones", VersionGA, caps{false, false, false, false, nil}},
+		{"Projects", VersionGA, caps{false, false, false, true, nil}},
+	} {
+		var si *ServiceInfo
+		for _, s := range AllServices {
+			if s.Service == tc.service && s.Version() == tc.version {
+				si = s
+			}
+		}
+		got := caps{si.SupportsUpdate(), si.SupportsPatch(), si.SupportsAggregatedList(), si.MutationsEnabled(), si.ExtraMethods()}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s %s: capabilities = %+v; want %+v", tc.service, tc.version, got, tc.want)
+		}
+	}
+
+	si := AllServicesByGroup["Zones"].GA
+	if got, want := si.Operations(), []string{"Get", "Exists", "BatchGet", "List", "ListPage"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Zones.Operations() = %v; want %v", got, want)
+	}
+}
+
+func TestLabelsAndFingerprint(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		si              *ServiceInfo
+		wantLabels      bool
+		wantRequest     string
+		wantFingerprint bool
+	}{
+		{AllServicesByGroup["Disks"].GA, true, "ga.ZoneSetLabelsRequest", false},
+		{AllServicesByGroup["Addresses"].Alpha, true, "alpha.RegionSetLabelsRequest", false},
+		{AllServicesByGroup["Instances"].Beta, true, "beta.InstancesSetLabelsRequest", false},
+		// GA addresses have no labels.
+		{AllServicesByGroup["Addresses"].GA, false, "", false},
+		// Forwarding rules have a fingerprint but no Update or Patch.
+		{AllServicesByGroup["ForwardingRules"].Alpha, true, "alpha.RegionSetLabelsRequest", false},
+		{AllServicesByGroup["BackendServices"].GA, false, "", true},
+		{AllServicesByGroup["UrlMaps"].GA, false, "", true},
+		{AllServicesByGroup["Firewalls"].GA, false, "", false},
+	} {
+		if got := tc.si.SupportsLabels(); got != tc.wantLabels {
+			t.Errorf("%s %s: SupportsLabels() = %t; want %t", tc.si.Version(), tc.si.Service, got, tc.wantLabels)
+		}
+		if tc.wantLabels {
+			if got := tc.si.SetLabelsRequestType(); got != tc.wantRequest {
+				t.Errorf("%s %s: SetLabelsRequestType() = %q; want %q", tc.si.Version(), tc.si.Service, got, tc.wantRequest)
+			}
+		}
+		if got := tc.si.UsesFingerprint(); got != tc.wantFingerprint {
+			t.Errorf("%s %s: UsesFingerprint() = %t; want %t", tc.si.Version(), tc.si.Service, got, tc.wantFingerprint)
+		}
+		if got := tc.si.SupportsUpdateWithRetry(); got != tc.wantFingerprint {
+			t.Errorf("%s %s: SupportsUpdateWithRetry() = %t; want %t", tc.si.Version(), tc.si.Service, got, tc.wantFingerprint)
+		}
+	}
+}
+
+func TestRequiredFieldsInit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		si   *ServiceInfo
+		want string
+	}{
+		{AllServicesByGroup["Firewalls"].GA, `, Network: "network"`},
+		{AllServicesByGroup["TargetHttpsProxies"].GA, `, UrlMap: "urlMap", SslCertificates: []string{"sslCertificates"}`},
+		{AllServicesByGroup["Zones"].GA, ""},
+	} {
+		if got := tc.si.RequiredFieldsInit(); got != tc.want {
+			t.Errorf("%s %s: RequiredFieldsInit() = %q; want %q", tc.si.Version(), tc.si.Service, got, tc.want)
+		}
+	}
+}
+
+func TestIdentityField(t *testing.T) {
+	t.Parallel()
+
+	fw := func(field string) *ServiceInfo {
+		return &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), identityField: field}
+	}
+	for _, tc := range []struct {
+		si        *ServiceInfo
+		wantField string
+		wantKind  string
+	}{
+		{AllServicesByGroup["Firewalls"].GA, "Name", "string"},
+		{fw("Network"), "Network", "string"},
+		{fw("Id"), "Id", "uint64"},
+		{fw(NoIdentityField), NoIdentityField, ""},
+	} {
+		if got := tc.si.IdentityField(); got != tc.wantField {
+			t.Errorf("IdentityField() = %q; want %q", got, tc.wantField)
+		}
+		if got := tc.si.IdentityKind(); got != tc.wantKind {
+			t.Errorf("%s: IdentityKind() = %q; want %q", tc.wantField, got, tc.wantKind)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range AllServices {
+		if err := s.validate(); err != nil {
+			t.Errorf("AllServices: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		desc string
+		si   *ServiceInfo
+		ok   bool
+	}{
+		{"read-only", &ServiceInfo{Service: "Zones", options: ReadOnly, serviceType: reflect.TypeOf(&ga.ZonesService{})}, true},
+		{"read-only with AggregatedList", &ServiceInfo{Service: "DiskTypes", options: ReadOnly | AggregatedList, serviceType: reflect.TypeOf(&ga.DiskTypesService{})}, true},
+		{"read-only with Update", &ServiceInfo{Service: "UrlMaps", options: ReadOnly | Update, serviceType: reflect.TypeOf(&ga.UrlMapsService{})}, false},
+		{"read-only with a mutating method", &ServiceInfo{Service: "TargetPools", keyType: Regional, options: ReadOnly, serviceType: reflect.TypeOf(&ga.TargetPoolsService{}), additionalMethods: []string{"AddInstance"}}, false},
+		{"read-only with a non-mutating method", &ServiceInfo{Service: "BackendServices", keyType: Global, options: ReadOnly, serviceType: reflect.TypeOf(&ga.BackendServicesService{}), additionalMethods: []string{"GetHealth"}}, true},
+		{"snippets", &ServiceInfo{Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), snippets: map[string]string{"gce.Insert": "x", "mock.List": "x"}}, true},
+		{"snippet for an additional method", &ServiceInfo{Service: "InstanceGroups", keyType: Zonal, serviceType: reflect.TypeOf(&ga.InstanceGroupsService{}), additionalMethods: []string{"SetNamedPorts"}, snippets: map[string]string{"mock.SetNamedPorts": "x"}}, true},
+		{"snippet for Exists", &ServiceInfo{Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), snippets: map[string]string{"gce.Exists": "x"}}, false},
+		{"snippet for a method that is not generated", &ServiceInfo{Service: "Zones", options: ReadOnly, serviceType: reflect.TypeOf(&ga.ZonesService{}), snippets: map[string]string{"gce.Insert": "x"}}, false},
+		{"snippet with an invalid target", &ServiceInfo{Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), snippets: map[string]string{"adapter.Get": "x"}}, false},
+		{"required fields", &ServiceInfo{Object: "TargetHttpsProxy", Service: "TargetHttpsProxies", serviceType: reflect.TypeOf(&ga.TargetHttpsProxiesService{}), requiredFields: []string{"UrlMap", "SslCertificates"}}, true},
+		{"required field that does not exist", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), requiredFields: []string{"Netwrok"}}, false},
+		{"deprecated method", &ServiceInfo{Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), deprecatedMethods: map[string]string{"Get": "x"}}, true},
+		{"deprecated method that is not generated", &ServiceInfo{Service: "Zones", options: ReadOnly, serviceType: reflect.TypeOf(&ga.ZonesService{}), deprecatedMethods: map[string]string{"Insert": "x"}}, false},
+		{"string identity field", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), identityField: "Network"}, true},
+		{"numeric identity field", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), identityField: "Id"}, true},
+		{"no identity field", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), identityField: NoIdentityField}, true},
+		{"identity field that does not exist", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), identityField: "Code"}, false},
+		{"identity field that is not a string or uint64", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), identityField: "Priority"}, false},
+		{"required field that is not a string", &ServiceInfo{Object: "Firewall", Service: "Firewalls", serviceType: reflect.TypeOf(&ga.FirewallsService{}), requiredFields: []string{"Allowed"}}, false},
+	} {
+		if err := tc.si.validate(); (err == nil) != tc.ok {
+			t.Errorf("%s: validate() = %v; want ok = %t", tc.desc, err, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Tag groups services by area (e.g. "loadbalancing"). An interface is
+// generated for each tag with the services that have it at all of their
+// versions (e.g. LoadBalancingCloud), so that a component can depend on a
+// narrow slice of Cloud.
+type Tag string
+
+const (
+	// TagInstances are the VM instances and their groups.
+	TagInstances Tag = "instances"
+	// TagLoadBalancing are the resources used to configure load balancers.
+	TagLoadBalancing Tag = "loadbalancing"
+	// TagNetworking are the addresses, firewalls and routes.
+	TagNetworking Tag = "networking"
+	// TagStorage are the disks.
+	TagStorage Tag = "storage"
+)
+
+// tagTitles are the titles of the tags that are not the tag with the first
+// letter in upper case.
+var tagTitles = map[Tag]string{
+	TagLoadBalancing: "LoadBalancing",
+}
+
+// tagRE matches the valid tags.
+var tagRE = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// Title is the tag as used in golang identifiers (e.g. "LoadBalancing").
+func (t Tag) Title() string {
+	if title, ok := tagTitles[t]; ok {
+		return title
+	}
+	return strings.ToUpper(string(t[:1])) + string(t[1:])
+}
+
+// CloudName is the name of the interface generated for the tag (e.g.
+// "LoadBalancingCloud").
+func (t Tag) CloudName() string {
+	return t.Title() + "Cloud"
+}
+
+// validate returns an error if t is not a valid tag.
+func (t Tag) validate() error {
+	if !tagRE.MatchString(string(t)) {
+		return fmt.Errorf("invalid tag %q (want lower case letters and digits)", t)
+	}
+	// The interfaces of the versions are named in the same way.
+	if _, ok := Version(t).Info(); ok {
+		return fmt.Errorf("invalid tag %q: the name of an API version", t)
+	}
+	return nil
+}
+
+// Tags returns the tags of the service.
+func (i *ServiceInfo) Tags() []Tag {
+	return i.tags
+}
+
+// HasTag is true if the service has tag t.
+func (i *ServiceInfo) HasTag(t Tag) bool {
+	for _, tag := range i.tags {
+		if tag == t {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTags returns an error if the tags of the service are invalid.
+func (i *ServiceInfo) checkTags() error {
+	seen := map[Tag]bool{}
+	for _, t := range i.tags {
+		if err := t.validate(); err != nil {
+			return err
+		}
+		if seen[t] {
+			return fmt.Errorf("duplicate tag %q", t)
+		}
+		seen[t] = true
+	}
+	return nil
+}
+
+// ServicesByTag returns the services with each of the tags used by services,
+// in the order of services.
+func ServicesByTag(services []*ServiceInfo) map[Tag][]*ServiceInfo {
+	ret := map[Tag][]*ServiceInfo{}
+	for _, s := range services {
+		for _, t := range s.tags {
+			ret[t] = append(ret[t], s)
+		}
+	}
+	return ret
+}
+
+// SortedTags returns the tags of m in lexicographical order.
+func SortedTags(m map[Tag][]*ServiceInfo) []Tag {
+	var ret []Tag
+	for t := range m {
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"testing"
+)
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		tag   Tag
+		title string
+		ok    bool
+	}{
+		{TagLoadBalancing, "LoadBalancing", true},
+		{TagNetworking, "Networking", true},
+		{"dns2", "Dns2", true},
+		{"", "", false},
+		{"Storage", "Storage", false},
+		{"load-balancing", "Load-balancing", false},
+		{"alpha", "Alpha", false},
+	} {
+		if err := tc.tag.validate(); (err == nil) != tc.ok {
+			t.Errorf("Tag(%q).validate() = %v; want ok = %t", tc.tag, err, tc.ok)
+		}
+		if !tc.ok {
+			continue
+		}
+		if got := tc.tag.Title(); got != tc.title {
+			t.Errorf("Tag(%q).Title() = %q; want %q", tc.tag, got, tc.title)
+		}
+		if got, want := tc.tag.CloudName(), tc.title+"Cloud"; got != want {
+			t.Errorf("Tag(%q).CloudName() = %q; want %q", tc.tag, got, want)
+		}
+	}
+}
+
+func TestServicesByTag(t *testing.T) {
+	t.Parallel()
+
+	byTag := ServicesByTag(AllServices)
+	for _, tag := range SortedTags(byTag) {
+		for _, s := range byTag[tag] {
+			if !s.HasTag(tag) {
+				t.Errorf("ServicesByTag()[%q] has %v %v, which does not have the tag", tag, s.Version(), s.Service)
+			}
+		}
+	}
+	// A service has its tags at all of its versions.
+	for name, sg := range AllServicesByGroup {
+		want := sg.Versions()[0].Tags()
+		for _, s := range sg.Versions() {
+			if got := s.Tags(); len(got) != len(want) || (len(got) > 0 && got[0] != want[0]) {
+				t.Errorf("%v %v has tags %v; want %v (the tags at the other versions)", s.Version(), name, got, want)
+			}
+		}
+	}
+
+	services, err := ServicesFromConfig([]byte(`{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "tags": ["zones"]}]}`))
+	if err != nil {
+		t.Fatalf("ServicesFromConfig() = _, %v; want _, nil", err)
+	}
+	if !services[0].HasTag("zones") {
+		t.Errorf("Zones.Tags() = %v; want [zones]", services[0].Tags())
+	}
+	for _, config := range []string{
+		`{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "tags": ["Zones"]}]}`,
+		`{"services": [{"object": "Zone", "service": "Zones", "options": ["ReadOnly"], "tags": ["zones", "zones"]}]}`,
+	} {
+		if _, err := ServicesFromConfig([]byte(config)); err == nil {
+			t.Errorf("ServicesFromConfig(%q) = _, nil; want error", config)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"path"
+	"reflect"
+	"strings"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+// VersionInfo describes a version of the compute API. The generator, the
+// resource URL parsing and the mocks are driven by Versions, so a version
+// (e.g. a staging endpoint) is added by declaring it there, along with its
+// Version constant.
+type VersionInfo struct {
+	Version Version
+	// Title is the golang CamelCase name of the version (e.g. "Alpha"), used
+	// in the generated names (e.g. AlphaCloud, ToAlpha()).
+	Title string
+	// WrapPrefix is the prefix of the names of the generated services of the
+	// version (e.g. "Alpha" for AlphaAddresses). It is empty for GA.
+	WrapPrefix string
+	// Package is the import path of the golang client. The generated code
+	// imports it with the version as the package name.
+	Package string
+	// ServiceType is the root Service type of the golang client.
+	ServiceType reflect.Type
+	// URLPrefix is the prefix of the URLs of the resources of the version
+	// (e.g. "https://www.googleapis.com/compute/v1/"), as found in SelfLinks.
+	URLPrefix string
+}
+
+// URLName is the name of the version in the resource URLs (e.g. "v1").
+func (vi *VersionInfo) URLName() string {
+	return path.Base(strings.TrimSuffix(vi.URLPrefix, "/"))
+}
+
+// Versions are the versions of the compute API known to the generator, in
+// the order of AllVersions.
+var Versions = []*VersionInfo{
+	{
+		Version:     VersionGA,
+		Title:       "GA",
+		Package:     "google.golang.org/api/compute/v1",
+		ServiceType: reflect.TypeOf(ga.Service{}),
+		URLPrefix:   "https://www.googleapis.com/compute/v1/",
+	},
+	{
+		Version:     VersionAlpha,
+		Title:       "Alpha",
+		WrapPrefix:  "Alpha",
+		Package:     "google.golang.org/api/compute/v0.alpha",
+		ServiceType: reflect.TypeOf(alpha.Service{}),
+		URLPrefix:   "https://www.googleapis.com/compute/alpha/",
+	},
+	{
+		Version:     VersionBeta,
+		Title:       "Beta",
+		WrapPrefix:  "Beta",
+		Package:     "google.golang.org/api/compute/v0.beta",
+		ServiceType: reflect.TypeOf(beta.Service{}),
+		URLPrefix:   "https://www.googleapis.com/compute/beta/",
+	},
+}
+
+// Info returns the VersionInfo of v in Versions.
+func (v Version) Info() (*VersionInfo, bool) {
+	for _, vi := range Versions {
+		if vi.Version == v {
+			return vi, true
+		}
+	}
+	return nil, false
+}
+
+// VersionForURLName returns the version whose name in the resource URLs is
+// name (e.g. "v1" for VersionGA).
+func VersionForURLName(name string) (Version, bool) {
+	for _, vi := range Versions {
+		if vi.URLName() == name {
+			return vi.Version, true
+		}
+	}
+	return "", false
+}
+
+// versionNames returns the versions in Versions.
+func versionNames() []Version {
+	var ret []Version
+	for _, vi := range Versions {
+		ret = append(ret, vi.Version)
+	}
+	return ret
+}
+
+// versionPackages returns the golang client packages of Versions.
+func versionPackages() map[Version]string {
+	ret := map[Version]string{}
+	for _, vi := range Versions {
+		ret[vi.Version] = vi.Package
+	}
+	return ret
+}
+
+// versionServiceTypes returns the root Service types of Versions.
+func versionServiceTypes() map[Version]reflect.Type {
+	ret := map[Version]reflect.Type{}
+	for _, vi := range Versions {
+		ret[vi.Version] = vi.ServiceType
+	}
+	return ret
+}
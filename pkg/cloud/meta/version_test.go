@@ -0,0 +1,75 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersions(t *testing.T) {
+	t.Parallel()
+
+	if got := versionNames(); !reflect.DeepEqual(got, AllVersions) {
+		t.Errorf("versionNames() = %v; want AllVersions %v", got, AllVersions)
+	}
+	for _, tc := range []struct {
+		v       Version
+		urlName string
+		pkg     string
+	}{
+		{VersionGA, "v1", "google.golang.org/api/compute/v1"},
+		{VersionAlpha, "alpha", "google.golang.org/api/compute/v0.alpha"},
+		{VersionBeta, "beta", "google.golang.org/api/compute/v0.beta"},
+	} {
+		vi, ok := tc.v.Info()
+		if !ok {
+			t.Errorf("Version(%q).Info() = _, false; want true", tc.v)
+			continue
+		}
+		if got := vi.URLName(); got != tc.urlName {
+			t.Errorf("Version(%q).Info().URLName() = %q; want %q", tc.v, got, tc.urlName)
+		}
+		if got, ok := VersionForURLName(tc.urlName); !ok || got != tc.v {
+			t.Errorf("VersionForURLName(%q) = %q, %t; want %q, true", tc.urlName, got, ok, tc.v)
+		}
+		if got, err := ComputeAPI.Package(tc.v); err != nil || got != tc.pkg {
+			t.Errorf("ComputeAPI.Package(%q) = %q, %v; want %q, nil", tc.v, got, err, tc.pkg)
+		}
+	}
+	if _, ok := Version("v2").Info(); ok {
+		t.Errorf("Version(%q).Info() = _, true; want false", "v2")
+	}
+	if _, ok := VersionForURLName("v2"); ok {
+		t.Errorf("VersionForURLName(%q) = _, true; want false", "v2")
+	}
+}
+
+func TestServiceGroupVersion(t *testing.T) {
+	t.Parallel()
+
+	sg := AllServicesByGroup["Addresses"]
+	for _, v := range AllVersions {
+		si := sg.Version(v)
+		if si == nil || si.Version() != v {
+			t.Errorf("Addresses.Version(%q) = %v; want the %s service", v, si, v)
+		}
+	}
+	if si := AllServicesByGroup["Zones"].Version(VersionAlpha); si != nil {
+		t.Errorf("Zones.Version(%q) = %v; want nil", VersionAlpha, si)
+	}
+}
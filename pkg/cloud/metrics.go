@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// CallMetrics describes a call made by the GCE adapters.
+type CallMetrics struct {
+	// ProjectID the call was made in.
+	ProjectID string
+	// Version of the API used.
+	Version meta.Version
+	// Service is the service called (e.g. "Firewalls").
+	Service string
+	// Operation is the method invoked (e.g. "Get", "Insert", "SetTarget").
+	Operation string
+	// Latency of the call. For mutations, this includes waiting for the
+	// operation to complete.
+	Latency time.Duration
+	// Err is the error returned by the call, if any.
+	Err error
+	// Code is the HTTP status code of the call: http.StatusOK if it
+	// succeeded, the code of the googleapi.Error if it failed with one, and
+	// 0 otherwise (e.g. if the call was canceled).
+	Code int
+}
+
+// MetricsRecorder receives the CallMetrics of every call made by the GCE
+// adapters when configured as Service.MetricsRecorder. This allows for the
+// latency and error rates by service, operation and version to be exported
+// (e.g. to Prometheus) without decorating each method by hand.
+type MetricsRecorder interface {
+	RecordCall(ctx context.Context, m *CallMetrics)
+}
+
+// CallStartRecorder is implemented by the MetricsRecorders that also record
+// the start of the calls (e.g. to track the calls in flight).
+type CallStartRecorder interface {
+	// RecordCallStart is called before each call with the CallMetrics
+	// describing it. Latency, Err and Code are not set.
+	RecordCallStart(ctx context.Context, m *CallMetrics)
+}
+
+// recordCallStart records the start of the call described by rk to the
+// configured MetricsRecorder, if it is a CallStartRecorder.
+func (g *Service) recordCallStart(ctx context.Context, rk *RateLimitKey) {
+	if r, ok := g.MetricsRecorder.(CallStartRecorder); ok {
+		r.RecordCallStart(ctx, &CallMetrics{
+			ProjectID: rk.ProjectID,
+			Version:   rk.Version,
+			Service:   rk.Service,
+			Operation: rk.Operation,
+		})
+	}
+}
+
+// statusCode returns the HTTP status code of a call that returned err (see
+// CallMetrics.Code).
+func statusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if apiErr, ok := apiError(err); ok {
+		return apiErr.Code
+	}
+	return 0
+}
+
+// recordCall records the call described by rk that started at start to the
+// configured MetricsRecorder, if any.
+func (g *Service) recordCall(ctx context.Context, rk *RateLimitKey, start time.Time, err error) {
+	if g.MetricsRecorder == nil {
+		return
+	}
+	g.MetricsRecorder.RecordCall(ctx, &CallMetrics{
+		ProjectID: rk.ProjectID,
+		Version:   rk.Version,
+		Service:   rk.Service,
+		Operation: rk.Operation,
+		Latency:   time.Since(start),
+		Err:       err,
+		Code:      statusCode(err),
+	})
+}
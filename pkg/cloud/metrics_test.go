@@ -0,0 +1,94 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+type fakeMetricsRecorder struct {
+	started []*CallMetrics
+	calls   []*CallMetrics
+}
+
+func (r *fakeMetricsRecorder) RecordCallStart(ctx context.Context, m *CallMetrics) {
+	r.started = append(r.started, m)
+}
+
+func (r *fakeMetricsRecorder) RecordCall(ctx context.Context, m *CallMetrics) {
+	r.calls = append(r.calls, m)
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op-insert", SelfLink: "projects/proj/global/operations/op-insert"})
+		case "GET /compute/v1/projects/proj/global/operations/op-insert":
+			writeJSON(t, w, &ga.Operation{Name: "op-insert", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	rec := &fakeMetricsRecorder{}
+	s.MetricsRecorder = rec
+	gce := NewGCE(s)
+	key := meta.GlobalKey("fw")
+
+	if _, err := gce.Firewalls().Get(ctx, *key); err != nil {
+		t.Fatalf("Firewalls().Get(%v) = _, %v; want _, nil", key, err)
+	}
+	if err := gce.Firewalls().Insert(ctx, *key, &ga.Firewall{}); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	if err := gce.Firewalls().Delete(ctx, *key); err == nil {
+		t.Fatalf("Firewalls().Delete(%v) = nil; want error", key)
+	}
+
+	want := []struct {
+		operation string
+		err       bool
+		code      int
+	}{{"Get", false, http.StatusOK}, {"Insert", false, http.StatusOK}, {"Delete", true, http.StatusNotFound}}
+	if len(rec.calls) != len(want) {
+		t.Fatalf("recorded %d calls; want %d", len(rec.calls), len(want))
+	}
+	for i, c := range rec.calls {
+		if c.ProjectID != "proj" || c.Version != meta.VersionGA || c.Service != "Firewalls" || c.Operation != want[i].operation || (c.Err != nil) != want[i].err || c.Code != want[i].code || c.Latency <= 0 {
+			t.Errorf("calls[%d] = %+v; want Firewalls %s with err = %t, code %d", i, c, want[i].operation, want[i].err, want[i].code)
+		}
+	}
+	if len(rec.started) != len(want) {
+		t.Fatalf("recorded the start of %d calls; want %d", len(rec.started), len(want))
+	}
+	for i, c := range rec.started {
+		if c.Operation != want[i].operation || c.Latency != 0 || c.Code != 0 {
+			t.Errorf("started[%d] = %+v; want Firewalls %s", i, c, want[i].operation)
+		}
+	}
+}
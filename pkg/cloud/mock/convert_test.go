@@ -0,0 +1,201 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fillDepth limits the recursion of fillObject for recursive types.
+const fillDepth = 5
+
+// fillObject sets every serialized field of v (recursively) to a non-zero
+// value.
+func fillObject(v reflect.Value, depth int) {
+	if depth > fillDepth {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillObject(v.Elem(), depth+1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillObject(v.Index(0), depth+1)
+	case reflect.Map:
+		k := reflect.New(v.Type().Key()).Elem()
+		e := reflect.New(v.Type().Elem()).Elem()
+		fillObject(k, depth+1)
+		fillObject(e, depth+1)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(k, e)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			// Fields that are not serialized (e.g. ForceSendFields) are
+			// not converted by copyViaJSON.
+			if f.PkgPath != "" || strings.Split(f.Tag.Get("json"), ",")[0] == "-" {
+				continue
+			}
+			fillObject(v.Field(i), depth+1)
+		}
+	}
+}
+
+// testConversion checks that convert copies every field of a fully populated
+// object that exists in both versions, using the conversion via JSON as the
+// reference.
+func testConversion[F, T any](t *testing.T, name string, convert func(*F) (*T, error)) {
+	t.Helper()
+
+	obj := new(F)
+	fillObject(reflect.ValueOf(obj).Elem(), 0)
+
+	got, err := convert(obj)
+	if err != nil {
+		t.Errorf("%s(_) = _, %v; want _, nil", name, err)
+		return
+	}
+	want := new(T)
+	if err := copyViaJSON(want, obj); err != nil {
+		t.Fatalf("copyViaJSON(_, _) = %v; want nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s(%+v) = %+v; want %+v", name, obj, got, want)
+	}
+
+	if got, err := convert(nil); got != nil || err != nil {
+		t.Errorf("%s(nil) = %v, %v; want nil, nil", name, got, err)
+	}
+}
+
+// benchmarkConversion benchmarks convert against the conversion via JSON for
+// a fully populated object.
+func benchmarkConversion[F, T any](b *testing.B, name string, convert func(*F) (*T, error)) {
+	obj := new(F)
+	fillObject(reflect.ValueOf(obj).Elem(), 0)
+
+	b.Run(name, func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := convert(obj); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run(name+"/JSON", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := copyViaJSON(new(T), obj); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// sharedRef returns the path of the first pointer, slice or map that is
+// shared between a and b, or "" if there is none.
+func sharedRef(a, b reflect.Value, path string) string {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		return sharedRef(a.Elem(), b.Elem(), path)
+	case reflect.Slice:
+		if a.Len() == 0 || b.Len() == 0 {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			if p := sharedRef(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i)); p != "" {
+				return p
+			}
+		}
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if p := sharedRef(a.Field(i), b.Field(i), path+"."+a.Type().Field(i).Name); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// testDeepCopy checks that copyFn copies every field of a fully populated
+// object and that the copy shares no pointers, slices or maps with it.
+func testDeepCopy[T any](t *testing.T, name string, copyFn func(*T) *T) {
+	t.Helper()
+
+	obj := new(T)
+	fillObject(reflect.ValueOf(obj).Elem(), 0)
+
+	got := copyFn(obj)
+	if !reflect.DeepEqual(got, obj) {
+		t.Errorf("%s(%+v) = %+v; want %+v", name, obj, got, obj)
+	}
+	if p := sharedRef(reflect.ValueOf(got), reflect.ValueOf(obj), "obj"); p != "" {
+		t.Errorf("%s(_) shares %s with the original; want a deep copy", name, p)
+	}
+
+	if got := copyFn(nil); got != nil {
+		t.Errorf("%s(nil) = %v; want nil", name, got)
+	}
+}
+
+// benchmarkDeepCopy benchmarks copyFn against the copy via JSON for a fully
+// populated object.
+func benchmarkDeepCopy[T any](b *testing.B, name string, copyFn func(*T) *T) {
+	obj := new(T)
+	fillObject(reflect.ValueOf(obj).Elem(), 0)
+
+	b.Run(name, func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copyFn(obj)
+		}
+	})
+	b.Run(name+"/JSON", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := copyViaJSON(new(T), obj); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,6582 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode mock > mock/gen.go".
+// Do not edit directly.
+
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+// NewMockGCE returns a new mock for GCE.
+func NewMockGCE() *MockGCE {
+	mockAddressesObjs := map[meta.Key]*MockAddressesObj{}
+	mockBackendServicesObjs := map[meta.Key]*MockBackendServicesObj{}
+	mockDiskTypesObjs := map[meta.Key]*MockDiskTypesObj{}
+	mockDisksObjs := map[meta.Key]*MockDisksObj{}
+	mockFirewallsObjs := map[meta.Key]*MockFirewallsObj{}
+	mockForwardingRulesObjs := map[meta.Key]*MockForwardingRulesObj{}
+	mockGlobalAddressesObjs := map[meta.Key]*MockGlobalAddressesObj{}
+	mockGlobalForwardingRulesObjs := map[meta.Key]*MockGlobalForwardingRulesObj{}
+	mockGlobalOperationsObjs := map[meta.Key]*MockGlobalOperationsObj{}
+	mockHealthChecksObjs := map[meta.Key]*MockHealthChecksObj{}
+	mockHttpHealthChecksObjs := map[meta.Key]*MockHttpHealthChecksObj{}
+	mockHttpsHealthChecksObjs := map[meta.Key]*MockHttpsHealthChecksObj{}
+	mockInstanceGroupsObjs := map[meta.Key]*MockInstanceGroupsObj{}
+	mockInstancesObjs := map[meta.Key]*MockInstancesObj{}
+	mockMachineTypesObjs := map[meta.Key]*MockMachineTypesObj{}
+	mockNetworkEndpointGroupsObjs := map[meta.Key]*MockNetworkEndpointGroupsObj{}
+	mockProjectsObjs := map[meta.Key]*MockProjectsObj{}
+	mockRegionBackendServicesObjs := map[meta.Key]*MockRegionBackendServicesObj{}
+	mockRegionDisksObjs := map[meta.Key]*MockRegionDisksObj{}
+	mockRegionOperationsObjs := map[meta.Key]*MockRegionOperationsObj{}
+	mockRegionsObjs := map[meta.Key]*MockRegionsObj{}
+	mockRoutesObjs := map[meta.Key]*MockRoutesObj{}
+	mockSslCertificatesObjs := map[meta.Key]*MockSslCertificatesObj{}
+	mockTargetHttpProxiesObjs := map[meta.Key]*MockTargetHttpProxiesObj{}
+	mockTargetHttpsProxiesObjs := map[meta.Key]*MockTargetHttpsProxiesObj{}
+	mockTargetPoolsObjs := map[meta.Key]*MockTargetPoolsObj{}
+	mockUrlMapsObjs := map[meta.Key]*MockUrlMapsObj{}
+	mockZoneOperationsObjs := map[meta.Key]*MockZoneOperationsObj{}
+	mockZonesObjs := map[meta.Key]*MockZonesObj{}
+
+	mock := &MockGCE{
+		MockAddresses:                  NewMockAddresses(mockAddressesObjs),
+		MockAlphaAddresses:             NewMockAlphaAddresses(mockAddressesObjs),
+		MockBetaAddresses:              NewMockBetaAddresses(mockAddressesObjs),
+		MockGlobalAddresses:            NewMockGlobalAddresses(mockGlobalAddressesObjs),
+		MockBackendServices:            NewMockBackendServices(mockBackendServicesObjs),
+		MockAlphaBackendServices:       NewMockAlphaBackendServices(mockBackendServicesObjs),
+		MockAlphaRegionBackendServices: NewMockAlphaRegionBackendServices(mockRegionBackendServicesObjs),
+		MockDisks:                      NewMockDisks(mockDisksObjs),
+		MockAlphaDisks:                 NewMockAlphaDisks(mockDisksObjs),
+		MockAlphaRegionDisks:           NewMockAlphaRegionDisks(mockRegionDisksObjs),
+		MockDiskTypes:                  NewMockDiskTypes(mockDiskTypesObjs),
+		MockFirewalls:                  NewMockFirewalls(mockFirewallsObjs),
+		MockForwardingRules:            NewMockForwardingRules(mockForwardingRulesObjs),
+		MockAlphaForwardingRules:       NewMockAlphaForwardingRules(mockForwardingRulesObjs),
+		MockGlobalForwardingRules:      NewMockGlobalForwardingRules(mockGlobalForwardingRulesObjs),
+		MockHealthChecks:               NewMockHealthChecks(mockHealthChecksObjs),
+		MockAlphaHealthChecks:          NewMockAlphaHealthChecks(mockHealthChecksObjs),
+		MockHttpHealthChecks:           NewMockHttpHealthChecks(mockHttpHealthChecksObjs),
+		MockHttpsHealthChecks:          NewMockHttpsHealthChecks(mockHttpsHealthChecksObjs),
+		MockInstanceGroups:             NewMockInstanceGroups(mockInstanceGroupsObjs),
+		MockInstances:                  NewMockInstances(mockInstancesObjs),
+		MockBetaInstances:              NewMockBetaInstances(mockInstancesObjs),
+		MockAlphaInstances:             NewMockAlphaInstances(mockInstancesObjs),
+		MockMachineTypes:               NewMockMachineTypes(mockMachineTypesObjs),
+		MockAlphaNetworkEndpointGroups: NewMockAlphaNetworkEndpointGroups(mockNetworkEndpointGroupsObjs),
+		MockGlobalOperations:           NewMockGlobalOperations(mockGlobalOperationsObjs),
+		MockRegionOperations:           NewMockRegionOperations(mockRegionOperationsObjs),
+		MockZoneOperations:             NewMockZoneOperations(mockZoneOperationsObjs),
+		MockProjects:                   NewMockProjects(mockProjectsObjs),
+		MockRegions:                    NewMockRegions(mockRegionsObjs),
+		MockRoutes:                     NewMockRoutes(mockRoutesObjs),
+		MockSslCertificates:            NewMockSslCertificates(mockSslCertificatesObjs),
+		MockTargetHttpProxies:          NewMockTargetHttpProxies(mockTargetHttpProxiesObjs),
+		MockTargetHttpsProxies:         NewMockTargetHttpsProxies(mockTargetHttpsProxiesObjs),
+		MockTargetPools:                NewMockTargetPools(mockTargetPoolsObjs),
+		MockUrlMaps:                    NewMockUrlMaps(mockUrlMapsObjs),
+		MockZones:                      NewMockZones(mockZonesObjs),
+	}
+	mock.MockOperations = newMockOperations(mock.MockGlobalOperations, mock.MockRegionOperations, mock.MockZoneOperations)
+	mock.MockQuotas = newMockQuotas()
+	mock.MockAddresses.ops = mock.MockOperations
+	mock.MockAddresses.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Addresses"] = mock.MockAddresses.count
+	mock.MockAlphaAddresses.ops = mock.MockOperations
+	mock.MockAlphaAddresses.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Addresses"] = mock.MockAlphaAddresses.count
+	mock.MockBetaAddresses.ops = mock.MockOperations
+	mock.MockBetaAddresses.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Addresses"] = mock.MockBetaAddresses.count
+	mock.MockGlobalAddresses.ops = mock.MockOperations
+	mock.MockGlobalAddresses.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["GlobalAddresses"] = mock.MockGlobalAddresses.count
+	mock.MockBackendServices.ops = mock.MockOperations
+	mock.MockBackendServices.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["BackendServices"] = mock.MockBackendServices.count
+	mock.MockAlphaBackendServices.ops = mock.MockOperations
+	mock.MockAlphaBackendServices.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["BackendServices"] = mock.MockAlphaBackendServices.count
+	mock.MockAlphaRegionBackendServices.ops = mock.MockOperations
+	mock.MockAlphaRegionBackendServices.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["RegionBackendServices"] = mock.MockAlphaRegionBackendServices.count
+	mock.MockDisks.ops = mock.MockOperations
+	mock.MockDisks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Disks"] = mock.MockDisks.count
+	mock.MockAlphaDisks.ops = mock.MockOperations
+	mock.MockAlphaDisks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Disks"] = mock.MockAlphaDisks.count
+	mock.MockAlphaRegionDisks.ops = mock.MockOperations
+	mock.MockAlphaRegionDisks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["RegionDisks"] = mock.MockAlphaRegionDisks.count
+	mock.MockDiskTypes.ops = mock.MockOperations
+	mock.MockDiskTypes.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["DiskTypes"] = mock.MockDiskTypes.count
+	mock.MockFirewalls.ops = mock.MockOperations
+	mock.MockFirewalls.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Firewalls"] = mock.MockFirewalls.count
+	mock.MockForwardingRules.ops = mock.MockOperations
+	mock.MockForwardingRules.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["ForwardingRules"] = mock.MockForwardingRules.count
+	mock.MockAlphaForwardingRules.ops = mock.MockOperations
+	mock.MockAlphaForwardingRules.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["ForwardingRules"] = mock.MockAlphaForwardingRules.count
+	mock.MockGlobalForwardingRules.ops = mock.MockOperations
+	mock.MockGlobalForwardingRules.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["GlobalForwardingRules"] = mock.MockGlobalForwardingRules.count
+	mock.MockHealthChecks.ops = mock.MockOperations
+	mock.MockHealthChecks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["HealthChecks"] = mock.MockHealthChecks.count
+	mock.MockAlphaHealthChecks.ops = mock.MockOperations
+	mock.MockAlphaHealthChecks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["HealthChecks"] = mock.MockAlphaHealthChecks.count
+	mock.MockHttpHealthChecks.ops = mock.MockOperations
+	mock.MockHttpHealthChecks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["HttpHealthChecks"] = mock.MockHttpHealthChecks.count
+	mock.MockHttpsHealthChecks.ops = mock.MockOperations
+	mock.MockHttpsHealthChecks.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["HttpsHealthChecks"] = mock.MockHttpsHealthChecks.count
+	mock.MockInstanceGroups.ops = mock.MockOperations
+	mock.MockInstanceGroups.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["InstanceGroups"] = mock.MockInstanceGroups.count
+	mock.MockInstances.ops = mock.MockOperations
+	mock.MockInstances.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Instances"] = mock.MockInstances.count
+	mock.MockBetaInstances.ops = mock.MockOperations
+	mock.MockBetaInstances.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Instances"] = mock.MockBetaInstances.count
+	mock.MockAlphaInstances.ops = mock.MockOperations
+	mock.MockAlphaInstances.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Instances"] = mock.MockAlphaInstances.count
+	mock.MockMachineTypes.ops = mock.MockOperations
+	mock.MockMachineTypes.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["MachineTypes"] = mock.MockMachineTypes.count
+	mock.MockAlphaNetworkEndpointGroups.ops = mock.MockOperations
+	mock.MockAlphaNetworkEndpointGroups.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["NetworkEndpointGroups"] = mock.MockAlphaNetworkEndpointGroups.count
+	mock.MockGlobalOperations.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["GlobalOperations"] = mock.MockGlobalOperations.count
+	mock.MockRegionOperations.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["RegionOperations"] = mock.MockRegionOperations.count
+	mock.MockZoneOperations.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["ZoneOperations"] = mock.MockZoneOperations.count
+	mock.MockProjects.ops = mock.MockOperations
+	mock.MockProjects.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Projects"] = mock.MockProjects.count
+	mock.MockRegions.ops = mock.MockOperations
+	mock.MockRegions.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Regions"] = mock.MockRegions.count
+	mock.MockRoutes.ops = mock.MockOperations
+	mock.MockRoutes.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Routes"] = mock.MockRoutes.count
+	mock.MockSslCertificates.ops = mock.MockOperations
+	mock.MockSslCertificates.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["SslCertificates"] = mock.MockSslCertificates.count
+	mock.MockTargetHttpProxies.ops = mock.MockOperations
+	mock.MockTargetHttpProxies.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["TargetHttpProxies"] = mock.MockTargetHttpProxies.count
+	mock.MockTargetHttpsProxies.ops = mock.MockOperations
+	mock.MockTargetHttpsProxies.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["TargetHttpsProxies"] = mock.MockTargetHttpsProxies.count
+	mock.MockTargetPools.ops = mock.MockOperations
+	mock.MockTargetPools.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["TargetPools"] = mock.MockTargetPools.count
+	mock.MockUrlMaps.ops = mock.MockOperations
+	mock.MockUrlMaps.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["UrlMaps"] = mock.MockUrlMaps.count
+	mock.MockZones.ops = mock.MockOperations
+	mock.MockZones.quotas = mock.MockQuotas
+	mock.MockQuotas.counters["Zones"] = mock.MockZones.count
+	return mock
+}
+
+// MockGCE implements cloud.Cloud.
+var _ cloud.Cloud = (*MockGCE)(nil)
+
+// MockGCE is the mock for the compute API.
+type MockGCE struct {
+	MockAddresses                  *MockAddresses
+	MockAlphaAddresses             *MockAlphaAddresses
+	MockBetaAddresses              *MockBetaAddresses
+	MockGlobalAddresses            *MockGlobalAddresses
+	MockBackendServices            *MockBackendServices
+	MockAlphaBackendServices       *MockAlphaBackendServices
+	MockAlphaRegionBackendServices *MockAlphaRegionBackendServices
+	MockDisks                      *MockDisks
+	MockAlphaDisks                 *MockAlphaDisks
+	MockAlphaRegionDisks           *MockAlphaRegionDisks
+	MockDiskTypes                  *MockDiskTypes
+	MockFirewalls                  *MockFirewalls
+	MockForwardingRules            *MockForwardingRules
+	MockAlphaForwardingRules       *MockAlphaForwardingRules
+	MockGlobalForwardingRules      *MockGlobalForwardingRules
+	MockHealthChecks               *MockHealthChecks
+	MockAlphaHealthChecks          *MockAlphaHealthChecks
+	MockHttpHealthChecks           *MockHttpHealthChecks
+	MockHttpsHealthChecks          *MockHttpsHealthChecks
+	MockInstanceGroups             *MockInstanceGroups
+	MockInstances                  *MockInstances
+	MockBetaInstances              *MockBetaInstances
+	MockAlphaInstances             *MockAlphaInstances
+	MockMachineTypes               *MockMachineTypes
+	MockAlphaNetworkEndpointGroups *MockAlphaNetworkEndpointGroups
+	MockGlobalOperations           *MockGlobalOperations
+	MockRegionOperations           *MockRegionOperations
+	MockZoneOperations             *MockZoneOperations
+	MockProjects                   *MockProjects
+	MockRegions                    *MockRegions
+	MockRoutes                     *MockRoutes
+	MockSslCertificates            *MockSslCertificates
+	MockTargetHttpProxies          *MockTargetHttpProxies
+	MockTargetHttpsProxies         *MockTargetHttpsProxies
+	MockTargetPools                *MockTargetPools
+	MockUrlMaps                    *MockUrlMaps
+	MockZones                      *MockZones
+
+	// MockOperations simulates the operations of the mutations of the
+	// mocks. See MockOperations.
+	MockOperations *MockOperations
+	// MockQuotas simulates the quotas of the project and of its regions.
+	// See MockQuotas.
+	MockQuotas *MockQuotas
+}
+
+func (mock *MockGCE) Addresses() cloud.Addresses {
+	return mock.MockAddresses
+}
+
+func (mock *MockGCE) AlphaAddresses() cloud.AlphaAddresses {
+	return mock.MockAlphaAddresses
+}
+
+func (mock *MockGCE) BetaAddresses() cloud.BetaAddresses {
+	return mock.MockBetaAddresses
+}
+
+func (mock *MockGCE) GlobalAddresses() cloud.GlobalAddresses {
+	return mock.MockGlobalAddresses
+}
+
+func (mock *MockGCE) BackendServices() cloud.BackendServices {
+	return mock.MockBackendServices
+}
+
+func (mock *MockGCE) AlphaBackendServices() cloud.AlphaBackendServices {
+	return mock.MockAlphaBackendServices
+}
+
+func (mock *MockGCE) AlphaRegionBackendServices() cloud.AlphaRegionBackendServices {
+	return mock.MockAlphaRegionBackendServices
+}
+
+func (mock *MockGCE) Disks() cloud.Disks {
+	return mock.MockDisks
+}
+
+func (mock *MockGCE) AlphaDisks() cloud.AlphaDisks {
+	return mock.MockAlphaDisks
+}
+
+func (mock *MockGCE) AlphaRegionDisks() cloud.AlphaRegionDisks {
+	return mock.MockAlphaRegionDisks
+}
+
+func (mock *MockGCE) DiskTypes() cloud.DiskTypes {
+	return mock.MockDiskTypes
+}
+
+func (mock *MockGCE) Firewalls() cloud.Firewalls {
+	return mock.MockFirewalls
+}
+
+func (mock *MockGCE) ForwardingRules() cloud.ForwardingRules {
+	return mock.MockForwardingRules
+}
+
+func (mock *MockGCE) AlphaForwardingRules() cloud.AlphaForwardingRules {
+	return mock.MockAlphaForwardingRules
+}
+
+func (mock *MockGCE) GlobalForwardingRules() cloud.GlobalForwardingRules {
+	return mock.MockGlobalForwardingRules
+}
+
+func (mock *MockGCE) HealthChecks() cloud.HealthChecks {
+	return mock.MockHealthChecks
+}
+
+func (mock *MockGCE) AlphaHealthChecks() cloud.AlphaHealthChecks {
+	return mock.MockAlphaHealthChecks
+}
+
+func (mock *MockGCE) HttpHealthChecks() cloud.HttpHealthChecks {
+	return mock.MockHttpHealthChecks
+}
+
+func (mock *MockGCE) HttpsHealthChecks() cloud.HttpsHealthChecks {
+	return mock.MockHttpsHealthChecks
+}
+
+func (mock *MockGCE) InstanceGroups() cloud.InstanceGroups {
+	return mock.MockInstanceGroups
+}
+
+func (mock *MockGCE) Instances() cloud.Instances {
+	return mock.MockInstances
+}
+
+func (mock *MockGCE) BetaInstances() cloud.BetaInstances {
+	return mock.MockBetaInstances
+}
+
+func (mock *MockGCE) AlphaInstances() cloud.AlphaInstances {
+	return mock.MockAlphaInstances
+}
+
+func (mock *MockGCE) MachineTypes() cloud.MachineTypes {
+	return mock.MockMachineTypes
+}
+
+func (mock *MockGCE) AlphaNetworkEndpointGroups() cloud.AlphaNetworkEndpointGroups {
+	return mock.MockAlphaNetworkEndpointGroups
+}
+
+func (mock *MockGCE) GlobalOperations() cloud.GlobalOperations {
+	return mock.MockGlobalOperations
+}
+
+func (mock *MockGCE) RegionOperations() cloud.RegionOperations {
+	return mock.MockRegionOperations
+}
+
+func (mock *MockGCE) ZoneOperations() cloud.ZoneOperations {
+	return mock.MockZoneOperations
+}
+
+func (mock *MockGCE) Projects() cloud.Projects {
+	return mock.MockProjects
+}
+
+func (mock *MockGCE) Regions() cloud.Regions {
+	return mock.MockRegions
+}
+
+func (mock *MockGCE) Routes() cloud.Routes {
+	return mock.MockRoutes
+}
+
+func (mock *MockGCE) SslCertificates() cloud.SslCertificates {
+	return mock.MockSslCertificates
+}
+
+func (mock *MockGCE) TargetHttpProxies() cloud.TargetHttpProxies {
+	return mock.MockTargetHttpProxies
+}
+
+func (mock *MockGCE) TargetHttpsProxies() cloud.TargetHttpsProxies {
+	return mock.MockTargetHttpsProxies
+}
+
+func (mock *MockGCE) TargetPools() cloud.TargetPools {
+	return mock.MockTargetPools
+}
+
+func (mock *MockGCE) UrlMaps() cloud.UrlMaps {
+	return mock.MockUrlMaps
+}
+
+func (mock *MockGCE) Zones() cloud.Zones {
+	return mock.MockZones
+}
+
+// UseScenario sets the Scenario for all of the mocks.
+func (mock *MockGCE) UseScenario(s *Scenario) {
+	mock.MockAddresses.Scenario = s
+	mock.MockAlphaAddresses.Scenario = s
+	mock.MockBetaAddresses.Scenario = s
+	mock.MockGlobalAddresses.Scenario = s
+	mock.MockBackendServices.Scenario = s
+	mock.MockAlphaBackendServices.Scenario = s
+	mock.MockAlphaRegionBackendServices.Scenario = s
+	mock.MockDisks.Scenario = s
+	mock.MockAlphaDisks.Scenario = s
+	mock.MockAlphaRegionDisks.Scenario = s
+	mock.MockDiskTypes.Scenario = s
+	mock.MockFirewalls.Scenario = s
+	mock.MockForwardingRules.Scenario = s
+	mock.MockAlphaForwardingRules.Scenario = s
+	mock.MockGlobalForwardingRules.Scenario = s
+	mock.MockHealthChecks.Scenario = s
+	mock.MockAlphaHealthChecks.Scenario = s
+	mock.MockHttpHealthChecks.Scenario = s
+	mock.MockHttpsHealthChecks.Scenario = s
+	mock.MockInstanceGroups.Scenario = s
+	mock.MockInstances.Scenario = s
+	mock.MockBetaInstances.Scenario = s
+	mock.MockAlphaInstances.Scenario = s
+	mock.MockMachineTypes.Scenario = s
+	mock.MockAlphaNetworkEndpointGroups.Scenario = s
+	mock.MockGlobalOperations.Scenario = s
+	mock.MockRegionOperations.Scenario = s
+	mock.MockZoneOperations.Scenario = s
+	mock.MockProjects.Scenario = s
+	mock.MockRegions.Scenario = s
+	mock.MockRoutes.Scenario = s
+	mock.MockSslCertificates.Scenario = s
+	mock.MockTargetHttpProxies.Scenario = s
+	mock.MockTargetHttpsProxies.Scenario = s
+	mock.MockTargetPools.Scenario = s
+	mock.MockUrlMaps.Scenario = s
+	mock.MockZones.Scenario = s
+}
+
+// UseRecorder sets the Recorder for all of the mocks.
+func (mock *MockGCE) UseRecorder(r *Recorder) {
+	mock.MockAddresses.Recorder = r
+	mock.MockAlphaAddresses.Recorder = r
+	mock.MockBetaAddresses.Recorder = r
+	mock.MockGlobalAddresses.Recorder = r
+	mock.MockBackendServices.Recorder = r
+	mock.MockAlphaBackendServices.Recorder = r
+	mock.MockAlphaRegionBackendServices.Recorder = r
+	mock.MockDisks.Recorder = r
+	mock.MockAlphaDisks.Recorder = r
+	mock.MockAlphaRegionDisks.Recorder = r
+	mock.MockDiskTypes.Recorder = r
+	mock.MockFirewalls.Recorder = r
+	mock.MockForwardingRules.Recorder = r
+	mock.MockAlphaForwardingRules.Recorder = r
+	mock.MockGlobalForwardingRules.Recorder = r
+	mock.MockHealthChecks.Recorder = r
+	mock.MockAlphaHealthChecks.Recorder = r
+	mock.MockHttpHealthChecks.Recorder = r
+	mock.MockHttpsHealthChecks.Recorder = r
+	mock.MockInstanceGroups.Recorder = r
+	mock.MockInstances.Recorder = r
+	mock.MockBetaInstances.Recorder = r
+	mock.MockAlphaInstances.Recorder = r
+	mock.MockMachineTypes.Recorder = r
+	mock.MockAlphaNetworkEndpointGroups.Recorder = r
+	mock.MockGlobalOperations.Recorder = r
+	mock.MockRegionOperations.Recorder = r
+	mock.MockZoneOperations.Recorder = r
+	mock.MockProjects.Recorder = r
+	mock.MockRegions.Recorder = r
+	mock.MockRoutes.Recorder = r
+	mock.MockSslCertificates.Recorder = r
+	mock.MockTargetHttpProxies.Recorder = r
+	mock.MockTargetHttpsProxies.Recorder = r
+	mock.MockTargetPools.Recorder = r
+	mock.MockUrlMaps.Recorder = r
+	mock.MockZones.Recorder = r
+}
+
+// SetProjectID sets the project of the SelfLinks given by all of the mocks
+// to the inserted objects.
+func (mock *MockGCE) SetProjectID(id string) {
+	mock.MockAddresses.ProjectID = id
+	mock.MockAlphaAddresses.ProjectID = id
+	mock.MockBetaAddresses.ProjectID = id
+	mock.MockGlobalAddresses.ProjectID = id
+	mock.MockBackendServices.ProjectID = id
+	mock.MockAlphaBackendServices.ProjectID = id
+	mock.MockAlphaRegionBackendServices.ProjectID = id
+	mock.MockDisks.ProjectID = id
+	mock.MockAlphaDisks.ProjectID = id
+	mock.MockAlphaRegionDisks.ProjectID = id
+	mock.MockDiskTypes.ProjectID = id
+	mock.MockFirewalls.ProjectID = id
+	mock.MockForwardingRules.ProjectID = id
+	mock.MockAlphaForwardingRules.ProjectID = id
+	mock.MockGlobalForwardingRules.ProjectID = id
+	mock.MockHealthChecks.ProjectID = id
+	mock.MockAlphaHealthChecks.ProjectID = id
+	mock.MockHttpHealthChecks.ProjectID = id
+	mock.MockHttpsHealthChecks.ProjectID = id
+	mock.MockInstanceGroups.ProjectID = id
+	mock.MockInstances.ProjectID = id
+	mock.MockBetaInstances.ProjectID = id
+	mock.MockAlphaInstances.ProjectID = id
+	mock.MockMachineTypes.ProjectID = id
+	mock.MockAlphaNetworkEndpointGroups.ProjectID = id
+	mock.MockGlobalOperations.ProjectID = id
+	mock.MockRegionOperations.ProjectID = id
+	mock.MockZoneOperations.ProjectID = id
+	mock.MockProjects.ProjectID = id
+	mock.MockRegions.ProjectID = id
+	mock.MockRoutes.ProjectID = id
+	mock.MockSslCertificates.ProjectID = id
+	mock.MockTargetHttpProxies.ProjectID = id
+	mock.MockTargetHttpsProxies.ProjectID = id
+	mock.MockTargetPools.ProjectID = id
+	mock.MockUrlMaps.ProjectID = id
+	mock.MockZones.ProjectID = id
+}
+
+// stateStores returns the store of the objects of each service for Save and
+// Load: the store of the most complete API version of the service.
+func (mock *MockGCE) stateStores() map[string]stateStore {
+	return map[string]stateStore{
+		"Addresses":             mock.MockAlphaAddresses.mockStore,
+		"BackendServices":       mock.MockAlphaBackendServices.mockStore,
+		"DiskTypes":             mock.MockDiskTypes.mockStore,
+		"Disks":                 mock.MockAlphaDisks.mockStore,
+		"Firewalls":             mock.MockFirewalls.mockStore,
+		"ForwardingRules":       mock.MockAlphaForwardingRules.mockStore,
+		"GlobalAddresses":       mock.MockGlobalAddresses.mockStore,
+		"GlobalForwardingRules": mock.MockGlobalForwardingRules.mockStore,
+		"GlobalOperations":      mock.MockGlobalOperations.mockStore,
+		"HealthChecks":          mock.MockAlphaHealthChecks.mockStore,
+		"HttpHealthChecks":      mock.MockHttpHealthChecks.mockStore,
+		"HttpsHealthChecks":     mock.MockHttpsHealthChecks.mockStore,
+		"InstanceGroups":        mock.MockInstanceGroups.mockStore,
+		"Instances":             mock.MockAlphaInstances.mockStore,
+		"MachineTypes":          mock.MockMachineTypes.mockStore,
+		"NetworkEndpointGroups": mock.MockAlphaNetworkEndpointGroups.mockStore,
+		"Projects":              mock.MockProjects.mockStore,
+		"RegionBackendServices": mock.MockAlphaRegionBackendServices.mockStore,
+		"RegionDisks":           mock.MockAlphaRegionDisks.mockStore,
+		"RegionOperations":      mock.MockRegionOperations.mockStore,
+		"Regions":               mock.MockRegions.mockStore,
+		"Routes":                mock.MockRoutes.mockStore,
+		"SslCertificates":       mock.MockSslCertificates.mockStore,
+		"TargetHttpProxies":     mock.MockTargetHttpProxies.mockStore,
+		"TargetHttpsProxies":    mock.MockTargetHttpsProxies.mockStore,
+		"TargetPools":           mock.MockTargetPools.mockStore,
+		"UrlMaps":               mock.MockUrlMaps.mockStore,
+		"ZoneOperations":        mock.MockZoneOperations.mockStore,
+		"Zones":                 mock.MockZones.mockStore,
+	}
+}
+
+// seedKinds are the kinds of the resources of Seed.
+var seedKinds = []seedKind{
+	{"Address", "Addresses", meta.KeyType("regional"), meta.Version("ga")},
+	{"BackendService", "BackendServices", meta.KeyType("global"), meta.Version("ga")},
+	{"DiskType", "DiskTypes", meta.KeyType("zonal"), meta.Version("ga")},
+	{"Disk", "Disks", meta.KeyType("zonal"), meta.Version("ga")},
+	{"Firewall", "Firewalls", meta.KeyType("global"), meta.Version("ga")},
+	{"ForwardingRule", "ForwardingRules", meta.KeyType("regional"), meta.Version("ga")},
+	{"Address", "GlobalAddresses", meta.KeyType("global"), meta.Version("ga")},
+	{"ForwardingRule", "GlobalForwardingRules", meta.KeyType("global"), meta.Version("ga")},
+	{"Operation", "GlobalOperations", meta.KeyType("global"), meta.Version("ga")},
+	{"HealthCheck", "HealthChecks", meta.KeyType("global"), meta.Version("ga")},
+	{"HttpHealthCheck", "HttpHealthChecks", meta.KeyType("global"), meta.Version("ga")},
+	{"HttpsHealthCheck", "HttpsHealthChecks", meta.KeyType("global"), meta.Version("ga")},
+	{"InstanceGroup", "InstanceGroups", meta.KeyType("zonal"), meta.Version("ga")},
+	{"Instance", "Instances", meta.KeyType("zonal"), meta.Version("ga")},
+	{"MachineType", "MachineTypes", meta.KeyType("zonal"), meta.Version("ga")},
+	{"NetworkEndpointGroup", "NetworkEndpointGroups", meta.KeyType("zonal"), meta.Version("alpha")},
+	{"Project", "Projects", meta.KeyType("global"), meta.Version("ga")},
+	{"BackendService", "RegionBackendServices", meta.KeyType("regional"), meta.Version("alpha")},
+	{"Disk", "RegionDisks", meta.KeyType("regional"), meta.Version("alpha")},
+	{"Operation", "RegionOperations", meta.KeyType("regional"), meta.Version("ga")},
+	{"Region", "Regions", meta.KeyType("global"), meta.Version("ga")},
+	{"Route", "Routes", meta.KeyType("global"), meta.Version("ga")},
+	{"SslCertificate", "SslCertificates", meta.KeyType("global"), meta.Version("ga")},
+	{"TargetHttpProxy", "TargetHttpProxies", meta.KeyType("global"), meta.Version("ga")},
+	{"TargetHttpsProxy", "TargetHttpsProxies", meta.KeyType("global"), meta.Version("ga")},
+	{"TargetPool", "TargetPools", meta.KeyType("regional"), meta.Version("ga")},
+	{"UrlMap", "UrlMaps", meta.KeyType("global"), meta.Version("ga")},
+	{"Operation", "ZoneOperations", meta.KeyType("zonal"), meta.Version("ga")},
+	{"Zone", "Zones", meta.KeyType("global"), meta.Version("ga")},
+}
+
+// serverRoutes returns the REST API routes served by NewHTTPHandler.
+func (mock *MockGCE) serverRoutes() map[serverRouteKey]*serverRoute {
+	return map[serverRouteKey]*serverRoute{
+		{"ga", "regional", "addresses"}: {
+			get:             getRoute(mock.MockAddresses.Get),
+			list:            listRoute(mock.MockAddresses.List),
+			insert:          insertRoute(mock.MockAddresses.InsertOp),
+			delete:          mock.MockAddresses.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockAddresses.AggregatedList),
+			aggregatedField: "Addresses",
+		},
+		{"alpha", "regional", "addresses"}: {
+			get:             getRoute(mock.MockAlphaAddresses.Get),
+			list:            listRoute(mock.MockAlphaAddresses.List),
+			insert:          insertRoute(mock.MockAlphaAddresses.InsertOp),
+			delete:          mock.MockAlphaAddresses.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockAlphaAddresses.AggregatedList),
+			aggregatedField: "Addresses",
+		},
+		{"beta", "regional", "addresses"}: {
+			get:             getRoute(mock.MockBetaAddresses.Get),
+			list:            listRoute(mock.MockBetaAddresses.List),
+			insert:          insertRoute(mock.MockBetaAddresses.InsertOp),
+			delete:          mock.MockBetaAddresses.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockBetaAddresses.AggregatedList),
+			aggregatedField: "Addresses",
+		},
+		{"ga", "global", "addresses"}: {
+			get:    getRoute(mock.MockGlobalAddresses.Get),
+			list:   globalListRoute(mock.MockGlobalAddresses.List),
+			insert: insertRoute(mock.MockGlobalAddresses.InsertOp),
+			delete: mock.MockGlobalAddresses.DeleteOp,
+		},
+		{"ga", "global", "backendServices"}: {
+			get:    getRoute(mock.MockBackendServices.Get),
+			list:   globalListRoute(mock.MockBackendServices.List),
+			insert: insertRoute(mock.MockBackendServices.InsertOp),
+			delete: mock.MockBackendServices.DeleteOp,
+		},
+		{"alpha", "global", "backendServices"}: {
+			get:    getRoute(mock.MockAlphaBackendServices.Get),
+			list:   globalListRoute(mock.MockAlphaBackendServices.List),
+			insert: insertRoute(mock.MockAlphaBackendServices.InsertOp),
+			delete: mock.MockAlphaBackendServices.DeleteOp,
+		},
+		{"alpha", "regional", "backendServices"}: {
+			get:    getRoute(mock.MockAlphaRegionBackendServices.Get),
+			list:   listRoute(mock.MockAlphaRegionBackendServices.List),
+			insert: insertRoute(mock.MockAlphaRegionBackendServices.InsertOp),
+			delete: mock.MockAlphaRegionBackendServices.DeleteOp,
+		},
+		{"ga", "zonal", "disks"}: {
+			get:             getRoute(mock.MockDisks.Get),
+			list:            listRoute(mock.MockDisks.List),
+			insert:          insertRoute(mock.MockDisks.InsertOp),
+			delete:          mock.MockDisks.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockDisks.AggregatedList),
+			aggregatedField: "Disks",
+		},
+		{"alpha", "zonal", "disks"}: {
+			get:             getRoute(mock.MockAlphaDisks.Get),
+			list:            listRoute(mock.MockAlphaDisks.List),
+			insert:          insertRoute(mock.MockAlphaDisks.InsertOp),
+			delete:          mock.MockAlphaDisks.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockAlphaDisks.AggregatedList),
+			aggregatedField: "Disks",
+		},
+		{"alpha", "regional", "disks"}: {
+			get:    getRoute(mock.MockAlphaRegionDisks.Get),
+			list:   listRoute(mock.MockAlphaRegionDisks.List),
+			insert: insertRoute(mock.MockAlphaRegionDisks.InsertOp),
+			delete: mock.MockAlphaRegionDisks.DeleteOp,
+		},
+		{"ga", "zonal", "diskTypes"}: {
+			get:      getRoute(mock.MockDiskTypes.Get),
+			list:     listRoute(mock.MockDiskTypes.List),
+			readOnly: true,
+		},
+		{"ga", "global", "firewalls"}: {
+			get:    getRoute(mock.MockFirewalls.Get),
+			list:   globalListRoute(mock.MockFirewalls.List),
+			insert: insertRoute(mock.MockFirewalls.InsertOp),
+			delete: mock.MockFirewalls.DeleteOp,
+		},
+		{"ga", "regional", "forwardingRules"}: {
+			get:             getRoute(mock.MockForwardingRules.Get),
+			list:            listRoute(mock.MockForwardingRules.List),
+			insert:          insertRoute(mock.MockForwardingRules.InsertOp),
+			delete:          mock.MockForwardingRules.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockForwardingRules.AggregatedList),
+			aggregatedField: "ForwardingRules",
+		},
+		{"alpha", "regional", "forwardingRules"}: {
+			get:             getRoute(mock.MockAlphaForwardingRules.Get),
+			list:            listRoute(mock.MockAlphaForwardingRules.List),
+			insert:          insertRoute(mock.MockAlphaForwardingRules.InsertOp),
+			delete:          mock.MockAlphaForwardingRules.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockAlphaForwardingRules.AggregatedList),
+			aggregatedField: "ForwardingRules",
+		},
+		{"ga", "global", "forwardingRules"}: {
+			get:    getRoute(mock.MockGlobalForwardingRules.Get),
+			list:   globalListRoute(mock.MockGlobalForwardingRules.List),
+			insert: insertRoute(mock.MockGlobalForwardingRules.InsertOp),
+			delete: mock.MockGlobalForwardingRules.DeleteOp,
+		},
+		{"ga", "global", "healthChecks"}: {
+			get:    getRoute(mock.MockHealthChecks.Get),
+			list:   globalListRoute(mock.MockHealthChecks.List),
+			insert: insertRoute(mock.MockHealthChecks.InsertOp),
+			delete: mock.MockHealthChecks.DeleteOp,
+		},
+		{"alpha", "global", "healthChecks"}: {
+			get:    getRoute(mock.MockAlphaHealthChecks.Get),
+			list:   globalListRoute(mock.MockAlphaHealthChecks.List),
+			insert: insertRoute(mock.MockAlphaHealthChecks.InsertOp),
+			delete: mock.MockAlphaHealthChecks.DeleteOp,
+		},
+		{"ga", "global", "httpHealthChecks"}: {
+			get:    getRoute(mock.MockHttpHealthChecks.Get),
+			list:   globalListRoute(mock.MockHttpHealthChecks.List),
+			insert: insertRoute(mock.MockHttpHealthChecks.InsertOp),
+			delete: mock.MockHttpHealthChecks.DeleteOp,
+		},
+		{"ga", "global", "httpsHealthChecks"}: {
+			get:    getRoute(mock.MockHttpsHealthChecks.Get),
+			list:   globalListRoute(mock.MockHttpsHealthChecks.List),
+			insert: insertRoute(mock.MockHttpsHealthChecks.InsertOp),
+			delete: mock.MockHttpsHealthChecks.DeleteOp,
+		},
+		{"ga", "zonal", "instanceGroups"}: {
+			get:    getRoute(mock.MockInstanceGroups.Get),
+			list:   listRoute(mock.MockInstanceGroups.List),
+			insert: insertRoute(mock.MockInstanceGroups.InsertOp),
+			delete: mock.MockInstanceGroups.DeleteOp,
+		},
+		{"ga", "zonal", "instances"}: {
+			get:             getRoute(mock.MockInstances.Get),
+			list:            listRoute(mock.MockInstances.List),
+			insert:          insertRoute(mock.MockInstances.InsertOp),
+			delete:          mock.MockInstances.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockInstances.AggregatedList),
+			aggregatedField: "Instances",
+		},
+		{"beta", "zonal", "instances"}: {
+			get:             getRoute(mock.MockBetaInstances.Get),
+			list:            listRoute(mock.MockBetaInstances.List),
+			insert:          insertRoute(mock.MockBetaInstances.InsertOp),
+			delete:          mock.MockBetaInstances.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockBetaInstances.AggregatedList),
+			aggregatedField: "Instances",
+		},
+		{"alpha", "zonal", "instances"}: {
+			get:             getRoute(mock.MockAlphaInstances.Get),
+			list:            listRoute(mock.MockAlphaInstances.List),
+			insert:          insertRoute(mock.MockAlphaInstances.InsertOp),
+			delete:          mock.MockAlphaInstances.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockAlphaInstances.AggregatedList),
+			aggregatedField: "Instances",
+		},
+		{"ga", "zonal", "machineTypes"}: {
+			get:      getRoute(mock.MockMachineTypes.Get),
+			list:     listRoute(mock.MockMachineTypes.List),
+			readOnly: true,
+		},
+		{"alpha", "zonal", "networkEndpointGroups"}: {
+			get:             getRoute(mock.MockAlphaNetworkEndpointGroups.Get),
+			list:            listRoute(mock.MockAlphaNetworkEndpointGroups.List),
+			insert:          insertRoute(mock.MockAlphaNetworkEndpointGroups.InsertOp),
+			delete:          mock.MockAlphaNetworkEndpointGroups.DeleteOp,
+			aggregatedList:  aggregatedListRoute(mock.MockAlphaNetworkEndpointGroups.AggregatedList),
+			aggregatedField: "NetworkEndpointGroups",
+		},
+		{"ga", "global", "operations"}: {
+			get:    getRoute(mock.MockGlobalOperations.Get),
+			list:   globalListRoute(mock.MockGlobalOperations.List),
+			delete: mock.MockGlobalOperations.DeleteOp,
+		},
+		{"ga", "regional", "operations"}: {
+			get:    getRoute(mock.MockRegionOperations.Get),
+			list:   listRoute(mock.MockRegionOperations.List),
+			delete: mock.MockRegionOperations.DeleteOp,
+		},
+		{"ga", "zonal", "zoneOperations"}: {
+			get:    getRoute(mock.MockZoneOperations.Get),
+			list:   listRoute(mock.MockZoneOperations.List),
+			delete: mock.MockZoneOperations.DeleteOp,
+		},
+		{"ga", "global", "regions"}: {
+			get:      getRoute(mock.MockRegions.Get),
+			list:     globalListRoute(mock.MockRegions.List),
+			readOnly: true,
+		},
+		{"ga", "global", "routes"}: {
+			get:    getRoute(mock.MockRoutes.Get),
+			list:   globalListRoute(mock.MockRoutes.List),
+			insert: insertRoute(mock.MockRoutes.InsertOp),
+			delete: mock.MockRoutes.DeleteOp,
+		},
+		{"ga", "global", "sslCertificates"}: {
+			get:    getRoute(mock.MockSslCertificates.Get),
+			list:   globalListRoute(mock.MockSslCertificates.List),
+			insert: insertRoute(mock.MockSslCertificates.InsertOp),
+			delete: mock.MockSslCertificates.DeleteOp,
+		},
+		{"ga", "global", "targetHttpProxies"}: {
+			get:    getRoute(mock.MockTargetHttpProxies.Get),
+			list:   globalListRoute(mock.MockTargetHttpProxies.List),
+			insert: insertRoute(mock.MockTargetHttpProxies.InsertOp),
+			delete: mock.MockTargetHttpProxies.DeleteOp,
+		},
+		{"ga", "global", "targetHttpsProxies"}: {
+			get:    getRoute(mock.MockTargetHttpsProxies.Get),
+			list:   globalListRoute(mock.MockTargetHttpsProxies.List),
+			insert: insertRoute(mock.MockTargetHttpsProxies.InsertOp),
+			delete: mock.MockTargetHttpsProxies.DeleteOp,
+		},
+		{"ga", "regional", "targetPools"}: {
+			get:    getRoute(mock.MockTargetPools.Get),
+			list:   listRoute(mock.MockTargetPools.List),
+			insert: insertRoute(mock.MockTargetPools.InsertOp),
+			delete: mock.MockTargetPools.DeleteOp,
+		},
+		{"ga", "global", "urlMaps"}: {
+			get:    getRoute(mock.MockUrlMaps.Get),
+			list:   globalListRoute(mock.MockUrlMaps.List),
+			insert: insertRoute(mock.MockUrlMaps.InsertOp),
+			delete: mock.MockUrlMaps.DeleteOp,
+		},
+		{"ga", "global", "zones"}: {
+			get:      getRoute(mock.MockZones.Get),
+			list:     globalListRoute(mock.MockZones.List),
+			readOnly: true,
+		},
+	}
+}
+
+// MockAddressesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockAddressesObj struct {
+	Obj interface{}
+}
+
+func newMockAddressesObj(obj interface{}) *MockAddressesObj {
+	return &MockAddressesObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockAddressesObj) ToAlpha() *alpha.Address {
+	return convertMockObj(m.Obj, cloud.CopyAlphaAddress)
+}
+
+// ToBeta retrieves a copy of the given version of the object.
+func (m *MockAddressesObj) ToBeta() *beta.Address {
+	return convertMockObj(m.Obj, cloud.CopyBetaAddress)
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockAddressesObj) ToGA() *ga.Address {
+	return convertMockObj(m.Obj, cloud.CopyGAAddress)
+}
+
+// MockBackendServicesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockBackendServicesObj struct {
+	Obj interface{}
+}
+
+func newMockBackendServicesObj(obj interface{}) *MockBackendServicesObj {
+	return &MockBackendServicesObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockBackendServicesObj) ToAlpha() *alpha.BackendService {
+	return convertMockObj(m.Obj, cloud.CopyAlphaBackendService)
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockBackendServicesObj) ToGA() *ga.BackendService {
+	return convertMockObj(m.Obj, cloud.CopyGABackendService)
+}
+
+// MockDiskTypesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockDiskTypesObj struct {
+	Obj interface{}
+}
+
+func newMockDiskTypesObj(obj interface{}) *MockDiskTypesObj {
+	return &MockDiskTypesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockDiskTypesObj) ToGA() *ga.DiskType {
+	return convertMockObj(m.Obj, cloud.CopyGADiskType)
+}
+
+// MockDisksObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockDisksObj struct {
+	Obj interface{}
+}
+
+func newMockDisksObj(obj interface{}) *MockDisksObj {
+	return &MockDisksObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockDisksObj) ToAlpha() *alpha.Disk {
+	return convertMockObj(m.Obj, cloud.CopyAlphaDisk)
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockDisksObj) ToGA() *ga.Disk {
+	return convertMockObj(m.Obj, cloud.CopyGADisk)
+}
+
+// MockFirewallsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockFirewallsObj struct {
+	Obj interface{}
+}
+
+func newMockFirewallsObj(obj interface{}) *MockFirewallsObj {
+	return &MockFirewallsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockFirewallsObj) ToGA() *ga.Firewall {
+	return convertMockObj(m.Obj, cloud.CopyGAFirewall)
+}
+
+// MockForwardingRulesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockForwardingRulesObj struct {
+	Obj interface{}
+}
+
+func newMockForwardingRulesObj(obj interface{}) *MockForwardingRulesObj {
+	return &MockForwardingRulesObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockForwardingRulesObj) ToAlpha() *alpha.ForwardingRule {
+	return convertMockObj(m.Obj, cloud.CopyAlphaForwardingRule)
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockForwardingRulesObj) ToGA() *ga.ForwardingRule {
+	return convertMockObj(m.Obj, cloud.CopyGAForwardingRule)
+}
+
+// MockGlobalAddressesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockGlobalAddressesObj struct {
+	Obj interface{}
+}
+
+func newMockGlobalAddressesObj(obj interface{}) *MockGlobalAddressesObj {
+	return &MockGlobalAddressesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockGlobalAddressesObj) ToGA() *ga.Address {
+	return convertMockObj(m.Obj, cloud.CopyGAAddress)
+}
+
+// MockGlobalForwardingRulesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockGlobalForwardingRulesObj struct {
+	Obj interface{}
+}
+
+func newMockGlobalForwardingRulesObj(obj interface{}) *MockGlobalForwardingRulesObj {
+	return &MockGlobalForwardingRulesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockGlobalForwardingRulesObj) ToGA() *ga.ForwardingRule {
+	return convertMockObj(m.Obj, cloud.CopyGAForwardingRule)
+}
+
+// MockGlobalOperationsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockGlobalOperationsObj struct {
+	Obj interface{}
+}
+
+func newMockGlobalOperationsObj(obj interface{}) *MockGlobalOperationsObj {
+	return &MockGlobalOperationsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockGlobalOperationsObj) ToGA() *ga.Operation {
+	return convertMockObj(m.Obj, cloud.CopyGAOperation)
+}
+
+// MockHealthChecksObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockHealthChecksObj struct {
+	Obj interface{}
+}
+
+func newMockHealthChecksObj(obj interface{}) *MockHealthChecksObj {
+	return &MockHealthChecksObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockHealthChecksObj) ToAlpha() *alpha.HealthCheck {
+	return convertMockObj(m.Obj, cloud.CopyAlphaHealthCheck)
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockHealthChecksObj) ToGA() *ga.HealthCheck {
+	return convertMockObj(m.Obj, cloud.CopyGAHealthCheck)
+}
+
+// MockHttpHealthChecksObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockHttpHealthChecksObj struct {
+	Obj interface{}
+}
+
+func newMockHttpHealthChecksObj(obj interface{}) *MockHttpHealthChecksObj {
+	return &MockHttpHealthChecksObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockHttpHealthChecksObj) ToGA() *ga.HttpHealthCheck {
+	return convertMockObj(m.Obj, cloud.CopyGAHttpHealthCheck)
+}
+
+// MockHttpsHealthChecksObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockHttpsHealthChecksObj struct {
+	Obj interface{}
+}
+
+func newMockHttpsHealthChecksObj(obj interface{}) *MockHttpsHealthChecksObj {
+	return &MockHttpsHealthChecksObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockHttpsHealthChecksObj) ToGA() *ga.HttpsHealthCheck {
+	return convertMockObj(m.Obj, cloud.CopyGAHttpsHealthCheck)
+}
+
+// MockInstanceGroupsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockInstanceGroupsObj struct {
+	Obj interface{}
+}
+
+func newMockInstanceGroupsObj(obj interface{}) *MockInstanceGroupsObj {
+	return &MockInstanceGroupsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockInstanceGroupsObj) ToGA() *ga.InstanceGroup {
+	return convertMockObj(m.Obj, cloud.CopyGAInstanceGroup)
+}
+
+// MockInstancesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockInstancesObj struct {
+	Obj interface{}
+}
+
+func newMockInstancesObj(obj interface{}) *MockInstancesObj {
+	return &MockInstancesObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockInstancesObj) ToAlpha() *alpha.Instance {
+	return convertMockObj(m.Obj, cloud.CopyAlphaInstance)
+}
+
+// ToBeta retrieves a copy of the given version of the object.
+func (m *MockInstancesObj) ToBeta() *beta.Instance {
+	return convertMockObj(m.Obj, cloud.CopyBetaInstance)
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockInstancesObj) ToGA() *ga.Instance {
+	return convertMockObj(m.Obj, cloud.CopyGAInstance)
+}
+
+// MockMachineTypesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockMachineTypesObj struct {
+	Obj interface{}
+}
+
+func newMockMachineTypesObj(obj interface{}) *MockMachineTypesObj {
+	return &MockMachineTypesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockMachineTypesObj) ToGA() *ga.MachineType {
+	return convertMockObj(m.Obj, cloud.CopyGAMachineType)
+}
+
+// MockNetworkEndpointGroupsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockNetworkEndpointGroupsObj struct {
+	Obj interface{}
+}
+
+func newMockNetworkEndpointGroupsObj(obj interface{}) *MockNetworkEndpointGroupsObj {
+	return &MockNetworkEndpointGroupsObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockNetworkEndpointGroupsObj) ToAlpha() *alpha.NetworkEndpointGroup {
+	return convertMockObj(m.Obj, cloud.CopyAlphaNetworkEndpointGroup)
+}
+
+// MockProjectsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockProjectsObj struct {
+	Obj interface{}
+}
+
+func newMockProjectsObj(obj interface{}) *MockProjectsObj {
+	return &MockProjectsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockProjectsObj) ToGA() *ga.Project {
+	return convertMockObj(m.Obj, cloud.CopyGAProject)
+}
+
+// MockRegionBackendServicesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockRegionBackendServicesObj struct {
+	Obj interface{}
+}
+
+func newMockRegionBackendServicesObj(obj interface{}) *MockRegionBackendServicesObj {
+	return &MockRegionBackendServicesObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockRegionBackendServicesObj) ToAlpha() *alpha.BackendService {
+	return convertMockObj(m.Obj, cloud.CopyAlphaBackendService)
+}
+
+// MockRegionDisksObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockRegionDisksObj struct {
+	Obj interface{}
+}
+
+func newMockRegionDisksObj(obj interface{}) *MockRegionDisksObj {
+	return &MockRegionDisksObj{obj}
+}
+
+// ToAlpha retrieves a copy of the given version of the object.
+func (m *MockRegionDisksObj) ToAlpha() *alpha.Disk {
+	return convertMockObj(m.Obj, cloud.CopyAlphaDisk)
+}
+
+// MockRegionOperationsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockRegionOperationsObj struct {
+	Obj interface{}
+}
+
+func newMockRegionOperationsObj(obj interface{}) *MockRegionOperationsObj {
+	return &MockRegionOperationsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockRegionOperationsObj) ToGA() *ga.Operation {
+	return convertMockObj(m.Obj, cloud.CopyGAOperation)
+}
+
+// MockRegionsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockRegionsObj struct {
+	Obj interface{}
+}
+
+func newMockRegionsObj(obj interface{}) *MockRegionsObj {
+	return &MockRegionsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockRegionsObj) ToGA() *ga.Region {
+	return convertMockObj(m.Obj, cloud.CopyGARegion)
+}
+
+// MockRoutesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockRoutesObj struct {
+	Obj interface{}
+}
+
+func newMockRoutesObj(obj interface{}) *MockRoutesObj {
+	return &MockRoutesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockRoutesObj) ToGA() *ga.Route {
+	return convertMockObj(m.Obj, cloud.CopyGARoute)
+}
+
+// MockSslCertificatesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockSslCertificatesObj struct {
+	Obj interface{}
+}
+
+func newMockSslCertificatesObj(obj interface{}) *MockSslCertificatesObj {
+	return &MockSslCertificatesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockSslCertificatesObj) ToGA() *ga.SslCertificate {
+	return convertMockObj(m.Obj, cloud.CopyGASslCertificate)
+}
+
+// MockTargetHttpProxiesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockTargetHttpProxiesObj struct {
+	Obj interface{}
+}
+
+func newMockTargetHttpProxiesObj(obj interface{}) *MockTargetHttpProxiesObj {
+	return &MockTargetHttpProxiesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockTargetHttpProxiesObj) ToGA() *ga.TargetHttpProxy {
+	return convertMockObj(m.Obj, cloud.CopyGATargetHttpProxy)
+}
+
+// MockTargetHttpsProxiesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockTargetHttpsProxiesObj struct {
+	Obj interface{}
+}
+
+func newMockTargetHttpsProxiesObj(obj interface{}) *MockTargetHttpsProxiesObj {
+	return &MockTargetHttpsProxiesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockTargetHttpsProxiesObj) ToGA() *ga.TargetHttpsProxy {
+	return convertMockObj(m.Obj, cloud.CopyGATargetHttpsProxy)
+}
+
+// MockTargetPoolsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockTargetPoolsObj struct {
+	Obj interface{}
+}
+
+func newMockTargetPoolsObj(obj interface{}) *MockTargetPoolsObj {
+	return &MockTargetPoolsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockTargetPoolsObj) ToGA() *ga.TargetPool {
+	return convertMockObj(m.Obj, cloud.CopyGATargetPool)
+}
+
+// MockUrlMapsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockUrlMapsObj struct {
+	Obj interface{}
+}
+
+func newMockUrlMapsObj(obj interface{}) *MockUrlMapsObj {
+	return &MockUrlMapsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockUrlMapsObj) ToGA() *ga.UrlMap {
+	return convertMockObj(m.Obj, cloud.CopyGAUrlMap)
+}
+
+// MockZoneOperationsObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockZoneOperationsObj struct {
+	Obj interface{}
+}
+
+func newMockZoneOperationsObj(obj interface{}) *MockZoneOperationsObj {
+	return &MockZoneOperationsObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockZoneOperationsObj) ToGA() *ga.Operation {
+	return convertMockObj(m.Obj, cloud.CopyGAOperation)
+}
+
+// MockZonesObj is used to store the various object versions in the shared
+// map of mocked objects. This allows for multiple API versions to co-exist and
+// share the same "view" of the objects in the backend.
+type MockZonesObj struct {
+	Obj interface{}
+}
+
+func newMockZonesObj(obj interface{}) *MockZonesObj {
+	return &MockZonesObj{obj}
+}
+
+// ToGA retrieves a copy of the given version of the object.
+func (m *MockZonesObj) ToGA() *ga.Zone {
+	return convertMockObj(m.Obj, cloud.CopyGAZone)
+}
+
+// NewMockAddresses returns a new mock for Addresses.
+func NewMockAddresses(objs map[meta.Key]*MockAddressesObj) *MockAddresses {
+	return &MockAddresses{
+		mockStore: newMockStore("MockAddresses", meta.Version("ga"), "Addresses", objs, newMockAddressesObj, (*MockAddressesObj).ToGA).withIdentity(func(obj *ga.Address, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAddresses is the mock for Addresses. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAddresses struct {
+	*mockStore[ga.Address, MockAddressesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockAddresses, ctx context.Context, key meta.Key) (bool, *ga.Address, error)
+	ListHook           func(m *MockAddresses, ctx context.Context, region string, fl *filter.F) (bool, []*ga.Address, error)
+	InsertHook         func(m *MockAddresses, ctx context.Context, key meta.Key, obj *ga.Address) (bool, error)
+	DeleteHook         func(m *MockAddresses, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockAddresses, ctx context.Context, fl *filter.F) (bool, map[string][]*ga.Address, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAddresses) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Address, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAddresses.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAddresses) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAddresses) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Address, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAddresses) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Address) (*ga.Address, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockAddresses) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Address, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockAddresses.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAddresses) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Address, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// ListAcrossProjects returns the objects of List for each of projects, as
+// the mock is not project aware.
+func (m *MockAddresses) ListAcrossProjects(ctx context.Context, projects []string, region string, fl *filter.F, opts ...interfaces.Option) (map[string][]*ga.Address, error) {
+	return cloud.ListAcrossProjects(ctx, projects, 0, func(ctx context.Context, projectID string) ([]*ga.Address, error) {
+		return m.List(ctx, region, fl, opts...)
+	})
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAddresses) Insert(ctx context.Context, key meta.Key, obj *ga.Address, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAddresses.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAddresses) InsertOp(ctx context.Context, key meta.Key, obj *ga.Address, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAddresses) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAddresses.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAddresses) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAddresses) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockAddresses) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*ga.Address, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAddresses.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// NewMockAlphaAddresses returns a new mock for Addresses.
+func NewMockAlphaAddresses(objs map[meta.Key]*MockAddressesObj) *MockAlphaAddresses {
+	return &MockAlphaAddresses{
+		mockStore: newMockStore("MockAlphaAddresses", meta.Version("alpha"), "Addresses", objs, newMockAddressesObj, (*MockAddressesObj).ToAlpha).withIdentity(func(obj *alpha.Address, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaAddresses is the mock for Addresses. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaAddresses struct {
+	*mockStore[alpha.Address, MockAddressesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockAlphaAddresses, ctx context.Context, key meta.Key) (bool, *alpha.Address, error)
+	ListHook           func(m *MockAlphaAddresses, ctx context.Context, region string, fl *filter.F) (bool, []*alpha.Address, error)
+	InsertHook         func(m *MockAlphaAddresses, ctx context.Context, key meta.Key, obj *alpha.Address) (bool, error)
+	DeleteHook         func(m *MockAlphaAddresses, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockAlphaAddresses, ctx context.Context, fl *filter.F) (bool, map[string][]*alpha.Address, error)
+	UpdateLabelsHook   func(m *MockAlphaAddresses, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaAddresses) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.Address, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaAddresses.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaAddresses) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaAddresses) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Address, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaAddresses) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Address) (*alpha.Address, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockAlphaAddresses) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.Address, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockAlphaAddresses.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaAddresses) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.Address, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// ListAcrossProjects returns the objects of List for each of projects, as
+// the mock is not project aware.
+func (m *MockAlphaAddresses) ListAcrossProjects(ctx context.Context, projects []string, region string, fl *filter.F, opts ...interfaces.Option) (map[string][]*alpha.Address, error) {
+	return cloud.ListAcrossProjects(ctx, projects, 0, func(ctx context.Context, projectID string) ([]*alpha.Address, error) {
+		return m.List(ctx, region, fl, opts...)
+	})
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaAddresses) Insert(ctx context.Context, key meta.Key, obj *alpha.Address, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaAddresses.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaAddresses) InsertOp(ctx context.Context, key meta.Key, obj *alpha.Address, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaAddresses) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaAddresses.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaAddresses) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaAddresses) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockAlphaAddresses) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*alpha.Address, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaAddresses.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockAlphaAddresses) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockAlphaAddresses.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *alpha.Address, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// NewMockBetaAddresses returns a new mock for Addresses.
+func NewMockBetaAddresses(objs map[meta.Key]*MockAddressesObj) *MockBetaAddresses {
+	return &MockBetaAddresses{
+		mockStore: newMockStore("MockBetaAddresses", meta.Version("beta"), "Addresses", objs, newMockAddressesObj, (*MockAddressesObj).ToBeta).withIdentity(func(obj *beta.Address, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockBetaAddresses is the mock for Addresses. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockBetaAddresses struct {
+	*mockStore[beta.Address, MockAddressesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockBetaAddresses, ctx context.Context, key meta.Key) (bool, *beta.Address, error)
+	ListHook           func(m *MockBetaAddresses, ctx context.Context, region string, fl *filter.F) (bool, []*beta.Address, error)
+	InsertHook         func(m *MockBetaAddresses, ctx context.Context, key meta.Key, obj *beta.Address) (bool, error)
+	DeleteHook         func(m *MockBetaAddresses, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockBetaAddresses, ctx context.Context, fl *filter.F) (bool, map[string][]*beta.Address, error)
+	UpdateLabelsHook   func(m *MockBetaAddresses, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockBetaAddresses) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *beta.Address, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockBetaAddresses.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockBetaAddresses) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockBetaAddresses) BatchGet(ctx context.Context, keys []meta.Key) ([]*beta.Address, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockBetaAddresses) GetOrCreate(ctx context.Context, key meta.Key, desired *beta.Address) (*beta.Address, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockBetaAddresses) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*beta.Address, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockBetaAddresses.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockBetaAddresses) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*beta.Address, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// ListAcrossProjects returns the objects of List for each of projects, as
+// the mock is not project aware.
+func (m *MockBetaAddresses) ListAcrossProjects(ctx context.Context, projects []string, region string, fl *filter.F, opts ...interfaces.Option) (map[string][]*beta.Address, error) {
+	return cloud.ListAcrossProjects(ctx, projects, 0, func(ctx context.Context, projectID string) ([]*beta.Address, error) {
+		return m.List(ctx, region, fl, opts...)
+	})
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockBetaAddresses) Insert(ctx context.Context, key meta.Key, obj *beta.Address, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockBetaAddresses.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockBetaAddresses) InsertOp(ctx context.Context, key meta.Key, obj *beta.Address, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockBetaAddresses) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockBetaAddresses.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockBetaAddresses) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockBetaAddresses) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockBetaAddresses) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*beta.Address, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockBetaAddresses.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockBetaAddresses) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockBetaAddresses.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *beta.Address, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// NewMockGlobalAddresses returns a new mock for GlobalAddresses.
+func NewMockGlobalAddresses(objs map[meta.Key]*MockGlobalAddressesObj) *MockGlobalAddresses {
+	return &MockGlobalAddresses{
+		mockStore: newMockStore("MockGlobalAddresses", meta.Version("ga"), "GlobalAddresses", objs, newMockGlobalAddressesObj, (*MockGlobalAddressesObj).ToGA).withIdentity(func(obj *ga.Address, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockGlobalAddresses is the mock for GlobalAddresses. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockGlobalAddresses struct {
+	*mockStore[ga.Address, MockGlobalAddressesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockGlobalAddresses, ctx context.Context, key meta.Key) (bool, *ga.Address, error)
+	ListHook   func(m *MockGlobalAddresses, ctx context.Context, fl *filter.F) (bool, []*ga.Address, error)
+	InsertHook func(m *MockGlobalAddresses, ctx context.Context, key meta.Key, obj *ga.Address) (bool, error)
+	DeleteHook func(m *MockGlobalAddresses, ctx context.Context, key meta.Key) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockGlobalAddresses) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Address, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockGlobalAddresses.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockGlobalAddresses) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockGlobalAddresses) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Address, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockGlobalAddresses) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Address) (*ga.Address, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockGlobalAddresses) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Address, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockGlobalAddresses.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockGlobalAddresses) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Address, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// ListAcrossProjects returns the objects of List for each of projects, as
+// the mock is not project aware.
+func (m *MockGlobalAddresses) ListAcrossProjects(ctx context.Context, projects []string, fl *filter.F, opts ...interfaces.Option) (map[string][]*ga.Address, error) {
+	return cloud.ListAcrossProjects(ctx, projects, 0, func(ctx context.Context, projectID string) ([]*ga.Address, error) {
+		return m.List(ctx, fl, opts...)
+	})
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockGlobalAddresses) Insert(ctx context.Context, key meta.Key, obj *ga.Address, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockGlobalAddresses.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockGlobalAddresses) InsertOp(ctx context.Context, key meta.Key, obj *ga.Address, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockGlobalAddresses) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockGlobalAddresses.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockGlobalAddresses) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockGlobalAddresses) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// NewMockBackendServices returns a new mock for BackendServices.
+func NewMockBackendServices(objs map[meta.Key]*MockBackendServicesObj) *MockBackendServices {
+	return &MockBackendServices{
+		mockStore: newMockStore("MockBackendServices", meta.Version("ga"), "BackendServices", objs, newMockBackendServicesObj, (*MockBackendServicesObj).ToGA).withFingerprint(func(obj *ga.BackendService) *string { return &obj.Fingerprint }).withIdentity(func(obj *ga.BackendService, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockBackendServices is the mock for BackendServices. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockBackendServices struct {
+	*mockStore[ga.BackendService, MockBackendServicesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook       func(m *MockBackendServices, ctx context.Context, key meta.Key) (bool, *ga.BackendService, error)
+	ListHook      func(m *MockBackendServices, ctx context.Context, fl *filter.F) (bool, []*ga.BackendService, error)
+	InsertHook    func(m *MockBackendServices, ctx context.Context, key meta.Key, obj *ga.BackendService) (bool, error)
+	DeleteHook    func(m *MockBackendServices, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook    func(m *MockBackendServices, ctx context.Context, key meta.Key, obj *ga.BackendService) (bool, error)
+	PatchHook     func(m *MockBackendServices, ctx context.Context, key meta.Key, obj *ga.BackendService) (bool, error)
+	GetHealthHook func(*MockBackendServices, context.Context, meta.Key, *ga.ResourceGroupReference) (*ga.BackendServiceGroupHealth, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockBackendServices) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.BackendService, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockBackendServices.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockBackendServices) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockBackendServices) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.BackendService, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockBackendServices) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.BackendService) (*ga.BackendService, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockBackendServices) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.BackendService, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockBackendServices.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockBackendServices) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.BackendService, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockBackendServices) Insert(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockBackendServices.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockBackendServices) InsertOp(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockBackendServices) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockBackendServices.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockBackendServices) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockBackendServices) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockBackendServices) Update(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockBackendServices.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// UpdateWithRetry updates the object with update using Get and Update of the
+// mock, which fails with http.StatusPreconditionFailed on a stale fingerprint.
+func (m *MockBackendServices) UpdateWithRetry(ctx context.Context, key meta.Key, update func(*ga.BackendService) error) error {
+	return cloud.UpdateWithRetry(ctx, key, update, m.Get, m.Update)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockBackendServices) Patch(ctx context.Context, key meta.Key, obj *ga.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockBackendServices.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// GetHealth is a mock for the corresponding method.
+func (m *MockBackendServices) GetHealth(ctx context.Context, key meta.Key, arg0 *ga.ResourceGroupReference, opts ...interfaces.Option) (_ *ga.BackendServiceGroupHealth, err error) {
+	defer m.record("GetHealth", &key, &err, arg0)
+	if m.GetHealthHook != nil {
+		return m.GetHealthHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("BackendServices", "GetHealth", &key); ok && o.Err != nil {
+		return nil, o.Err
+	}
+	return nil, fmt.Errorf("GetHealthHook must be set")
+}
+
+// NewMockAlphaBackendServices returns a new mock for BackendServices.
+func NewMockAlphaBackendServices(objs map[meta.Key]*MockBackendServicesObj) *MockAlphaBackendServices {
+	return &MockAlphaBackendServices{
+		mockStore: newMockStore("MockAlphaBackendServices", meta.Version("alpha"), "BackendServices", objs, newMockBackendServicesObj, (*MockBackendServicesObj).ToAlpha).withFingerprint(func(obj *alpha.BackendService) *string { return &obj.Fingerprint }).withIdentity(func(obj *alpha.BackendService, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaBackendServices is the mock for BackendServices. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaBackendServices struct {
+	*mockStore[alpha.BackendService, MockBackendServicesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockAlphaBackendServices, ctx context.Context, key meta.Key) (bool, *alpha.BackendService, error)
+	ListHook   func(m *MockAlphaBackendServices, ctx context.Context, fl *filter.F) (bool, []*alpha.BackendService, error)
+	InsertHook func(m *MockAlphaBackendServices, ctx context.Context, key meta.Key, obj *alpha.BackendService) (bool, error)
+	DeleteHook func(m *MockAlphaBackendServices, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockAlphaBackendServices, ctx context.Context, key meta.Key, obj *alpha.BackendService) (bool, error)
+	PatchHook  func(m *MockAlphaBackendServices, ctx context.Context, key meta.Key, obj *alpha.BackendService) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaBackendServices) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.BackendService, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaBackendServices.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaBackendServices) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaBackendServices) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.BackendService, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaBackendServices) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.BackendService) (*alpha.BackendService, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockAlphaBackendServices) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.BackendService, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaBackendServices.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaBackendServices) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.BackendService, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaBackendServices) Insert(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaBackendServices.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaBackendServices) InsertOp(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaBackendServices) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaBackendServices.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaBackendServices) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaBackendServices) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockAlphaBackendServices) Update(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaBackendServices.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// UpdateWithRetry updates the object with update using Get and Update of the
+// mock, which fails with http.StatusPreconditionFailed on a stale fingerprint.
+func (m *MockAlphaBackendServices) UpdateWithRetry(ctx context.Context, key meta.Key, update func(*alpha.BackendService) error) error {
+	return cloud.UpdateWithRetry(ctx, key, update, m.Get, m.Update)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockAlphaBackendServices) Patch(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaBackendServices.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockAlphaRegionBackendServices returns a new mock for RegionBackendServices.
+func NewMockAlphaRegionBackendServices(objs map[meta.Key]*MockRegionBackendServicesObj) *MockAlphaRegionBackendServices {
+	return &MockAlphaRegionBackendServices{
+		mockStore: newMockStore("MockAlphaRegionBackendServices", meta.Version("alpha"), "RegionBackendServices", objs, newMockRegionBackendServicesObj, (*MockRegionBackendServicesObj).ToAlpha).withFingerprint(func(obj *alpha.BackendService) *string { return &obj.Fingerprint }).withIdentity(func(obj *alpha.BackendService, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaRegionBackendServices is the mock for RegionBackendServices. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaRegionBackendServices struct {
+	*mockStore[alpha.BackendService, MockRegionBackendServicesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook       func(m *MockAlphaRegionBackendServices, ctx context.Context, key meta.Key) (bool, *alpha.BackendService, error)
+	ListHook      func(m *MockAlphaRegionBackendServices, ctx context.Context, region string, fl *filter.F) (bool, []*alpha.BackendService, error)
+	InsertHook    func(m *MockAlphaRegionBackendServices, ctx context.Context, key meta.Key, obj *alpha.BackendService) (bool, error)
+	DeleteHook    func(m *MockAlphaRegionBackendServices, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook    func(m *MockAlphaRegionBackendServices, ctx context.Context, key meta.Key, obj *alpha.BackendService) (bool, error)
+	PatchHook     func(m *MockAlphaRegionBackendServices, ctx context.Context, key meta.Key, obj *alpha.BackendService) (bool, error)
+	GetHealthHook func(*MockAlphaRegionBackendServices, context.Context, meta.Key, *alpha.ResourceGroupReference) (*alpha.BackendServiceGroupHealth, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaRegionBackendServices) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.BackendService, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaRegionBackendServices.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaRegionBackendServices) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaRegionBackendServices) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.BackendService, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaRegionBackendServices) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.BackendService) (*alpha.BackendService, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockAlphaRegionBackendServices) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.BackendService, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockAlphaRegionBackendServices.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaRegionBackendServices) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.BackendService, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaRegionBackendServices) Insert(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaRegionBackendServices.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaRegionBackendServices) InsertOp(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaRegionBackendServices) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaRegionBackendServices.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaRegionBackendServices) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaRegionBackendServices) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockAlphaRegionBackendServices) Update(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaRegionBackendServices.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// UpdateWithRetry updates the object with update using Get and Update of the
+// mock, which fails with http.StatusPreconditionFailed on a stale fingerprint.
+func (m *MockAlphaRegionBackendServices) UpdateWithRetry(ctx context.Context, key meta.Key, update func(*alpha.BackendService) error) error {
+	return cloud.UpdateWithRetry(ctx, key, update, m.Get, m.Update)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockAlphaRegionBackendServices) Patch(ctx context.Context, key meta.Key, obj *alpha.BackendService, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaRegionBackendServices.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// GetHealth is a mock for the corresponding method.
+func (m *MockAlphaRegionBackendServices) GetHealth(ctx context.Context, key meta.Key, arg0 *alpha.ResourceGroupReference, opts ...interfaces.Option) (_ *alpha.BackendServiceGroupHealth, err error) {
+	defer m.record("GetHealth", &key, &err, arg0)
+	if m.GetHealthHook != nil {
+		return m.GetHealthHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("RegionBackendServices", "GetHealth", &key); ok && o.Err != nil {
+		return nil, o.Err
+	}
+	return nil, fmt.Errorf("GetHealthHook must be set")
+}
+
+// NewMockDisks returns a new mock for Disks.
+func NewMockDisks(objs map[meta.Key]*MockDisksObj) *MockDisks {
+	return &MockDisks{
+		mockStore: newMockStore("MockDisks", meta.Version("ga"), "Disks", objs, newMockDisksObj, (*MockDisksObj).ToGA).withIdentity(func(obj *ga.Disk, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockDisks is the mock for Disks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockDisks struct {
+	*mockStore[ga.Disk, MockDisksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockDisks, ctx context.Context, key meta.Key) (bool, *ga.Disk, error)
+	ListHook           func(m *MockDisks, ctx context.Context, zone string, fl *filter.F) (bool, []*ga.Disk, error)
+	InsertHook         func(m *MockDisks, ctx context.Context, key meta.Key, obj *ga.Disk) (bool, error)
+	DeleteHook         func(m *MockDisks, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockDisks, ctx context.Context, fl *filter.F) (bool, map[string][]*ga.Disk, error)
+	UpdateLabelsHook   func(m *MockDisks, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockDisks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Disk, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockDisks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockDisks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockDisks) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Disk, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockDisks) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Disk) (*ga.Disk, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockDisks) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Disk, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockDisks.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockDisks) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Disk, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockDisks) Insert(ctx context.Context, key meta.Key, obj *ga.Disk, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockDisks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockDisks) InsertOp(ctx context.Context, key meta.Key, obj *ga.Disk, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockDisks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockDisks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockDisks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockDisks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockDisks) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*ga.Disk, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockDisks.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockDisks) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockDisks.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *ga.Disk, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// NewMockAlphaDisks returns a new mock for Disks.
+func NewMockAlphaDisks(objs map[meta.Key]*MockDisksObj) *MockAlphaDisks {
+	return &MockAlphaDisks{
+		mockStore: newMockStore("MockAlphaDisks", meta.Version("alpha"), "Disks", objs, newMockDisksObj, (*MockDisksObj).ToAlpha).withIdentity(func(obj *alpha.Disk, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaDisks is the mock for Disks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaDisks struct {
+	*mockStore[alpha.Disk, MockDisksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockAlphaDisks, ctx context.Context, key meta.Key) (bool, *alpha.Disk, error)
+	ListHook           func(m *MockAlphaDisks, ctx context.Context, zone string, fl *filter.F) (bool, []*alpha.Disk, error)
+	InsertHook         func(m *MockAlphaDisks, ctx context.Context, key meta.Key, obj *alpha.Disk) (bool, error)
+	DeleteHook         func(m *MockAlphaDisks, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockAlphaDisks, ctx context.Context, fl *filter.F) (bool, map[string][]*alpha.Disk, error)
+	UpdateLabelsHook   func(m *MockAlphaDisks, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaDisks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.Disk, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaDisks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaDisks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaDisks) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Disk, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaDisks) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Disk) (*alpha.Disk, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockAlphaDisks) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.Disk, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockAlphaDisks.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaDisks) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.Disk, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaDisks) Insert(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaDisks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaDisks) InsertOp(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaDisks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaDisks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaDisks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaDisks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockAlphaDisks) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*alpha.Disk, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaDisks.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockAlphaDisks) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockAlphaDisks.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *alpha.Disk, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// NewMockAlphaRegionDisks returns a new mock for RegionDisks.
+func NewMockAlphaRegionDisks(objs map[meta.Key]*MockRegionDisksObj) *MockAlphaRegionDisks {
+	return &MockAlphaRegionDisks{
+		mockStore: newMockStore("MockAlphaRegionDisks", meta.Version("alpha"), "RegionDisks", objs, newMockRegionDisksObj, (*MockRegionDisksObj).ToAlpha).withIdentity(func(obj *alpha.Disk, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaRegionDisks is the mock for RegionDisks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaRegionDisks struct {
+	*mockStore[alpha.Disk, MockRegionDisksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook          func(m *MockAlphaRegionDisks, ctx context.Context, key meta.Key) (bool, *alpha.Disk, error)
+	ListHook         func(m *MockAlphaRegionDisks, ctx context.Context, region string, fl *filter.F) (bool, []*alpha.Disk, error)
+	InsertHook       func(m *MockAlphaRegionDisks, ctx context.Context, key meta.Key, obj *alpha.Disk) (bool, error)
+	DeleteHook       func(m *MockAlphaRegionDisks, ctx context.Context, key meta.Key) (bool, error)
+	UpdateLabelsHook func(m *MockAlphaRegionDisks, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaRegionDisks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.Disk, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaRegionDisks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaRegionDisks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaRegionDisks) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Disk, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaRegionDisks) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Disk) (*alpha.Disk, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockAlphaRegionDisks) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.Disk, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockAlphaRegionDisks.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaRegionDisks) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.Disk, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaRegionDisks) Insert(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaRegionDisks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaRegionDisks) InsertOp(ctx context.Context, key meta.Key, obj *alpha.Disk, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaRegionDisks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaRegionDisks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaRegionDisks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaRegionDisks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockAlphaRegionDisks) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockAlphaRegionDisks.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *alpha.Disk, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// NewMockDiskTypes returns a new mock for DiskTypes.
+func NewMockDiskTypes(objs map[meta.Key]*MockDiskTypesObj) *MockDiskTypes {
+	return &MockDiskTypes{
+		mockStore: newMockStore("MockDiskTypes", meta.Version("ga"), "DiskTypes", objs, newMockDiskTypesObj, (*MockDiskTypesObj).ToGA).withIdentity(func(obj *ga.DiskType, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockDiskTypes is the mock for DiskTypes. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockDiskTypes struct {
+	*mockStore[ga.DiskType, MockDiskTypesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook  func(m *MockDiskTypes, ctx context.Context, key meta.Key) (bool, *ga.DiskType, error)
+	ListHook func(m *MockDiskTypes, ctx context.Context, zone string, fl *filter.F) (bool, []*ga.DiskType, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockDiskTypes) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.DiskType, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockDiskTypes.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockDiskTypes) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockDiskTypes) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.DiskType, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockDiskTypes) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.DiskType, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockDiskTypes.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockDiskTypes) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.DiskType, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// NewMockFirewalls returns a new mock for Firewalls.
+func NewMockFirewalls(objs map[meta.Key]*MockFirewallsObj) *MockFirewalls {
+	return &MockFirewalls{
+		mockStore: newMockStore("MockFirewalls", meta.Version("ga"), "Firewalls", objs, newMockFirewallsObj, (*MockFirewallsObj).ToGA).withIdentity(func(obj *ga.Firewall, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("Network"),
+	}
+}
+
+// MockFirewalls is the mock for Firewalls. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockFirewalls struct {
+	*mockStore[ga.Firewall, MockFirewallsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockFirewalls, ctx context.Context, key meta.Key) (bool, *ga.Firewall, error)
+	ListHook   func(m *MockFirewalls, ctx context.Context, fl *filter.F) (bool, []*ga.Firewall, error)
+	InsertHook func(m *MockFirewalls, ctx context.Context, key meta.Key, obj *ga.Firewall) (bool, error)
+	DeleteHook func(m *MockFirewalls, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockFirewalls, ctx context.Context, key meta.Key, obj *ga.Firewall) (bool, error)
+	PatchHook  func(m *MockFirewalls, ctx context.Context, key meta.Key, obj *ga.Firewall) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockFirewalls) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Firewall, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockFirewalls.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockFirewalls) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockFirewalls) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Firewall, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockFirewalls) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Firewall) (*ga.Firewall, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockFirewalls) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Firewall, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockFirewalls.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockFirewalls) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Firewall, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// ListAcrossProjects returns the objects of List for each of projects, as
+// the mock is not project aware.
+func (m *MockFirewalls) ListAcrossProjects(ctx context.Context, projects []string, fl *filter.F, opts ...interfaces.Option) (map[string][]*ga.Firewall, error) {
+	return cloud.ListAcrossProjects(ctx, projects, 0, func(ctx context.Context, projectID string) ([]*ga.Firewall, error) {
+		return m.List(ctx, fl, opts...)
+	})
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockFirewalls) Insert(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockFirewalls.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockFirewalls) InsertOp(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockFirewalls) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockFirewalls.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockFirewalls) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockFirewalls) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockFirewalls) Update(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockFirewalls.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockFirewalls) Patch(ctx context.Context, key meta.Key, obj *ga.Firewall, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockFirewalls.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockForwardingRules returns a new mock for ForwardingRules.
+func NewMockForwardingRules(objs map[meta.Key]*MockForwardingRulesObj) *MockForwardingRules {
+	return &MockForwardingRules{
+		mockStore: newMockStore("MockForwardingRules", meta.Version("ga"), "ForwardingRules", objs, newMockForwardingRulesObj, (*MockForwardingRulesObj).ToGA).withIdentity(func(obj *ga.ForwardingRule, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockForwardingRules is the mock for ForwardingRules. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockForwardingRules struct {
+	*mockStore[ga.ForwardingRule, MockForwardingRulesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockForwardingRules, ctx context.Context, key meta.Key) (bool, *ga.ForwardingRule, error)
+	ListHook           func(m *MockForwardingRules, ctx context.Context, region string, fl *filter.F) (bool, []*ga.ForwardingRule, error)
+	InsertHook         func(m *MockForwardingRules, ctx context.Context, key meta.Key, obj *ga.ForwardingRule) (bool, error)
+	DeleteHook         func(m *MockForwardingRules, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockForwardingRules, ctx context.Context, fl *filter.F) (bool, map[string][]*ga.ForwardingRule, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockForwardingRules) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.ForwardingRule, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockForwardingRules.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockForwardingRules) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockForwardingRules) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.ForwardingRule, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockForwardingRules) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.ForwardingRule) (*ga.ForwardingRule, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockForwardingRules) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.ForwardingRule, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockForwardingRules.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockForwardingRules) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.ForwardingRule, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockForwardingRules) Insert(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockForwardingRules.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockForwardingRules) InsertOp(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockForwardingRules) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockForwardingRules.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockForwardingRules) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockForwardingRules) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockForwardingRules) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*ga.ForwardingRule, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockForwardingRules.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// NewMockAlphaForwardingRules returns a new mock for ForwardingRules.
+func NewMockAlphaForwardingRules(objs map[meta.Key]*MockForwardingRulesObj) *MockAlphaForwardingRules {
+	return &MockAlphaForwardingRules{
+		mockStore: newMockStore("MockAlphaForwardingRules", meta.Version("alpha"), "ForwardingRules", objs, newMockForwardingRulesObj, (*MockForwardingRulesObj).ToAlpha).withIdentity(func(obj *alpha.ForwardingRule, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaForwardingRules is the mock for ForwardingRules. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaForwardingRules struct {
+	*mockStore[alpha.ForwardingRule, MockForwardingRulesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockAlphaForwardingRules, ctx context.Context, key meta.Key) (bool, *alpha.ForwardingRule, error)
+	ListHook           func(m *MockAlphaForwardingRules, ctx context.Context, region string, fl *filter.F) (bool, []*alpha.ForwardingRule, error)
+	InsertHook         func(m *MockAlphaForwardingRules, ctx context.Context, key meta.Key, obj *alpha.ForwardingRule) (bool, error)
+	DeleteHook         func(m *MockAlphaForwardingRules, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockAlphaForwardingRules, ctx context.Context, fl *filter.F) (bool, map[string][]*alpha.ForwardingRule, error)
+	UpdateLabelsHook   func(m *MockAlphaForwardingRules, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaForwardingRules) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.ForwardingRule, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaForwardingRules.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaForwardingRules) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaForwardingRules) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.ForwardingRule, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaForwardingRules) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.ForwardingRule) (*alpha.ForwardingRule, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockAlphaForwardingRules) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.ForwardingRule, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockAlphaForwardingRules.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaForwardingRules) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.ForwardingRule, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaForwardingRules) Insert(ctx context.Context, key meta.Key, obj *alpha.ForwardingRule, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaForwardingRules.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaForwardingRules) InsertOp(ctx context.Context, key meta.Key, obj *alpha.ForwardingRule, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaForwardingRules) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaForwardingRules.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaForwardingRules) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaForwardingRules) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockAlphaForwardingRules) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*alpha.ForwardingRule, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaForwardingRules.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockAlphaForwardingRules) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockAlphaForwardingRules.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *alpha.ForwardingRule, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// NewMockGlobalForwardingRules returns a new mock for GlobalForwardingRules.
+func NewMockGlobalForwardingRules(objs map[meta.Key]*MockGlobalForwardingRulesObj) *MockGlobalForwardingRules {
+	return &MockGlobalForwardingRules{
+		mockStore: newMockStore("MockGlobalForwardingRules", meta.Version("ga"), "GlobalForwardingRules", objs, newMockGlobalForwardingRulesObj, (*MockGlobalForwardingRulesObj).ToGA).withIdentity(func(obj *ga.ForwardingRule, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockGlobalForwardingRules is the mock for GlobalForwardingRules. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockGlobalForwardingRules struct {
+	*mockStore[ga.ForwardingRule, MockGlobalForwardingRulesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook       func(m *MockGlobalForwardingRules, ctx context.Context, key meta.Key) (bool, *ga.ForwardingRule, error)
+	ListHook      func(m *MockGlobalForwardingRules, ctx context.Context, fl *filter.F) (bool, []*ga.ForwardingRule, error)
+	InsertHook    func(m *MockGlobalForwardingRules, ctx context.Context, key meta.Key, obj *ga.ForwardingRule) (bool, error)
+	DeleteHook    func(m *MockGlobalForwardingRules, ctx context.Context, key meta.Key) (bool, error)
+	SetTargetHook func(*MockGlobalForwardingRules, context.Context, meta.Key, *ga.TargetReference) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockGlobalForwardingRules) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.ForwardingRule, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockGlobalForwardingRules.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockGlobalForwardingRules) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockGlobalForwardingRules) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.ForwardingRule, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockGlobalForwardingRules) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.ForwardingRule) (*ga.ForwardingRule, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockGlobalForwardingRules) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.ForwardingRule, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockGlobalForwardingRules.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockGlobalForwardingRules) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.ForwardingRule, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockGlobalForwardingRules) Insert(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockGlobalForwardingRules.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockGlobalForwardingRules) InsertOp(ctx context.Context, key meta.Key, obj *ga.ForwardingRule, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockGlobalForwardingRules) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockGlobalForwardingRules.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockGlobalForwardingRules) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockGlobalForwardingRules) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// SetTarget is a mock for the corresponding method.
+func (m *MockGlobalForwardingRules) SetTarget(ctx context.Context, key meta.Key, arg0 *ga.TargetReference, opts ...interfaces.Option) (err error) {
+	defer m.record("SetTarget", &key, &err, arg0)
+	if m.SetTargetHook != nil {
+		return m.SetTargetHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("GlobalForwardingRules", "SetTarget", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockHealthChecks returns a new mock for HealthChecks.
+func NewMockHealthChecks(objs map[meta.Key]*MockHealthChecksObj) *MockHealthChecks {
+	return &MockHealthChecks{
+		mockStore: newMockStore("MockHealthChecks", meta.Version("ga"), "HealthChecks", objs, newMockHealthChecksObj, (*MockHealthChecksObj).ToGA).withIdentity(func(obj *ga.HealthCheck, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockHealthChecks is the mock for HealthChecks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockHealthChecks struct {
+	*mockStore[ga.HealthCheck, MockHealthChecksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockHealthChecks, ctx context.Context, key meta.Key) (bool, *ga.HealthCheck, error)
+	ListHook   func(m *MockHealthChecks, ctx context.Context, fl *filter.F) (bool, []*ga.HealthCheck, error)
+	InsertHook func(m *MockHealthChecks, ctx context.Context, key meta.Key, obj *ga.HealthCheck) (bool, error)
+	DeleteHook func(m *MockHealthChecks, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockHealthChecks, ctx context.Context, key meta.Key, obj *ga.HealthCheck) (bool, error)
+	PatchHook  func(m *MockHealthChecks, ctx context.Context, key meta.Key, obj *ga.HealthCheck) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockHealthChecks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.HealthCheck, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockHealthChecks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockHealthChecks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockHealthChecks) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.HealthCheck, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockHealthChecks) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.HealthCheck) (*ga.HealthCheck, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockHealthChecks) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.HealthCheck, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockHealthChecks.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockHealthChecks) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.HealthCheck, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockHealthChecks) Insert(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHealthChecks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockHealthChecks) InsertOp(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockHealthChecks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockHealthChecks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockHealthChecks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockHealthChecks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockHealthChecks) Update(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHealthChecks.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockHealthChecks) Patch(ctx context.Context, key meta.Key, obj *ga.HealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHealthChecks.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockAlphaHealthChecks returns a new mock for HealthChecks.
+func NewMockAlphaHealthChecks(objs map[meta.Key]*MockHealthChecksObj) *MockAlphaHealthChecks {
+	return &MockAlphaHealthChecks{
+		mockStore: newMockStore("MockAlphaHealthChecks", meta.Version("alpha"), "HealthChecks", objs, newMockHealthChecksObj, (*MockHealthChecksObj).ToAlpha).withIdentity(func(obj *alpha.HealthCheck, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaHealthChecks is the mock for HealthChecks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaHealthChecks struct {
+	*mockStore[alpha.HealthCheck, MockHealthChecksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockAlphaHealthChecks, ctx context.Context, key meta.Key) (bool, *alpha.HealthCheck, error)
+	ListHook   func(m *MockAlphaHealthChecks, ctx context.Context, fl *filter.F) (bool, []*alpha.HealthCheck, error)
+	InsertHook func(m *MockAlphaHealthChecks, ctx context.Context, key meta.Key, obj *alpha.HealthCheck) (bool, error)
+	DeleteHook func(m *MockAlphaHealthChecks, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockAlphaHealthChecks, ctx context.Context, key meta.Key, obj *alpha.HealthCheck) (bool, error)
+	PatchHook  func(m *MockAlphaHealthChecks, ctx context.Context, key meta.Key, obj *alpha.HealthCheck) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaHealthChecks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.HealthCheck, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaHealthChecks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaHealthChecks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaHealthChecks) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.HealthCheck, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaHealthChecks) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.HealthCheck) (*alpha.HealthCheck, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockAlphaHealthChecks) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.HealthCheck, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaHealthChecks.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaHealthChecks) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.HealthCheck, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaHealthChecks) Insert(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaHealthChecks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaHealthChecks) InsertOp(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaHealthChecks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaHealthChecks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaHealthChecks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaHealthChecks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockAlphaHealthChecks) Update(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaHealthChecks.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockAlphaHealthChecks) Patch(ctx context.Context, key meta.Key, obj *alpha.HealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaHealthChecks.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockHttpHealthChecks returns a new mock for HttpHealthChecks.
+func NewMockHttpHealthChecks(objs map[meta.Key]*MockHttpHealthChecksObj) *MockHttpHealthChecks {
+	return &MockHttpHealthChecks{
+		mockStore: newMockStore("MockHttpHealthChecks", meta.Version("ga"), "HttpHealthChecks", objs, newMockHttpHealthChecksObj, (*MockHttpHealthChecksObj).ToGA).withIdentity(func(obj *ga.HttpHealthCheck, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockHttpHealthChecks is the mock for HttpHealthChecks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockHttpHealthChecks struct {
+	*mockStore[ga.HttpHealthCheck, MockHttpHealthChecksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockHttpHealthChecks, ctx context.Context, key meta.Key) (bool, *ga.HttpHealthCheck, error)
+	ListHook   func(m *MockHttpHealthChecks, ctx context.Context, fl *filter.F) (bool, []*ga.HttpHealthCheck, error)
+	InsertHook func(m *MockHttpHealthChecks, ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck) (bool, error)
+	DeleteHook func(m *MockHttpHealthChecks, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockHttpHealthChecks, ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck) (bool, error)
+	PatchHook  func(m *MockHttpHealthChecks, ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockHttpHealthChecks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.HttpHealthCheck, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockHttpHealthChecks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockHttpHealthChecks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockHttpHealthChecks) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.HttpHealthCheck, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockHttpHealthChecks) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.HttpHealthCheck) (*ga.HttpHealthCheck, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockHttpHealthChecks) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.HttpHealthCheck, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockHttpHealthChecks.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockHttpHealthChecks) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.HttpHealthCheck, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockHttpHealthChecks) Insert(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHttpHealthChecks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockHttpHealthChecks) InsertOp(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockHttpHealthChecks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockHttpHealthChecks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockHttpHealthChecks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockHttpHealthChecks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockHttpHealthChecks) Update(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHttpHealthChecks.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockHttpHealthChecks) Patch(ctx context.Context, key meta.Key, obj *ga.HttpHealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHttpHealthChecks.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockHttpsHealthChecks returns a new mock for HttpsHealthChecks.
+func NewMockHttpsHealthChecks(objs map[meta.Key]*MockHttpsHealthChecksObj) *MockHttpsHealthChecks {
+	return &MockHttpsHealthChecks{
+		mockStore: newMockStore("MockHttpsHealthChecks", meta.Version("ga"), "HttpsHealthChecks", objs, newMockHttpsHealthChecksObj, (*MockHttpsHealthChecksObj).ToGA).withIdentity(func(obj *ga.HttpsHealthCheck, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockHttpsHealthChecks is the mock for HttpsHealthChecks. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockHttpsHealthChecks struct {
+	*mockStore[ga.HttpsHealthCheck, MockHttpsHealthChecksObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockHttpsHealthChecks, ctx context.Context, key meta.Key) (bool, *ga.HttpsHealthCheck, error)
+	ListHook   func(m *MockHttpsHealthChecks, ctx context.Context, fl *filter.F) (bool, []*ga.HttpsHealthCheck, error)
+	InsertHook func(m *MockHttpsHealthChecks, ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck) (bool, error)
+	DeleteHook func(m *MockHttpsHealthChecks, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockHttpsHealthChecks, ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck) (bool, error)
+	PatchHook  func(m *MockHttpsHealthChecks, ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockHttpsHealthChecks) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.HttpsHealthCheck, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockHttpsHealthChecks.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockHttpsHealthChecks) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockHttpsHealthChecks) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.HttpsHealthCheck, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockHttpsHealthChecks) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.HttpsHealthCheck) (*ga.HttpsHealthCheck, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockHttpsHealthChecks) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.HttpsHealthCheck, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockHttpsHealthChecks.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockHttpsHealthChecks) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.HttpsHealthCheck, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockHttpsHealthChecks) Insert(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHttpsHealthChecks.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockHttpsHealthChecks) InsertOp(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockHttpsHealthChecks) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockHttpsHealthChecks.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockHttpsHealthChecks) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockHttpsHealthChecks) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockHttpsHealthChecks) Update(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHttpsHealthChecks.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockHttpsHealthChecks) Patch(ctx context.Context, key meta.Key, obj *ga.HttpsHealthCheck, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockHttpsHealthChecks.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockInstanceGroups returns a new mock for InstanceGroups.
+func NewMockInstanceGroups(objs map[meta.Key]*MockInstanceGroupsObj) *MockInstanceGroups {
+	return &MockInstanceGroups{
+		mockStore: newMockStore("MockInstanceGroups", meta.Version("ga"), "InstanceGroups", objs, newMockInstanceGroupsObj, (*MockInstanceGroupsObj).ToGA).withIdentity(func(obj *ga.InstanceGroup, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockInstanceGroups is the mock for InstanceGroups. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockInstanceGroups struct {
+	*mockStore[ga.InstanceGroup, MockInstanceGroupsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook             func(m *MockInstanceGroups, ctx context.Context, key meta.Key) (bool, *ga.InstanceGroup, error)
+	ListHook            func(m *MockInstanceGroups, ctx context.Context, zone string, fl *filter.F) (bool, []*ga.InstanceGroup, error)
+	InsertHook          func(m *MockInstanceGroups, ctx context.Context, key meta.Key, obj *ga.InstanceGroup) (bool, error)
+	DeleteHook          func(m *MockInstanceGroups, ctx context.Context, key meta.Key) (bool, error)
+	AddInstancesHook    func(*MockInstanceGroups, context.Context, meta.Key, *ga.InstanceGroupsAddInstancesRequest) error
+	ListInstancesHook   func(*MockInstanceGroups, context.Context, meta.Key, *ga.InstanceGroupsListInstancesRequest) (*ga.InstanceGroupsListInstances, error)
+	RemoveInstancesHook func(*MockInstanceGroups, context.Context, meta.Key, *ga.InstanceGroupsRemoveInstancesRequest) error
+	SetNamedPortsHook   func(*MockInstanceGroups, context.Context, meta.Key, *ga.InstanceGroupsSetNamedPortsRequest) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockInstanceGroups) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.InstanceGroup, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockInstanceGroups.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockInstanceGroups) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockInstanceGroups) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.InstanceGroup, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockInstanceGroups) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.InstanceGroup) (*ga.InstanceGroup, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockInstanceGroups) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.InstanceGroup, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockInstanceGroups.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockInstanceGroups) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.InstanceGroup, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockInstanceGroups) Insert(ctx context.Context, key meta.Key, obj *ga.InstanceGroup, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockInstanceGroups.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockInstanceGroups) InsertOp(ctx context.Context, key meta.Key, obj *ga.InstanceGroup, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockInstanceGroups) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockInstanceGroups.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockInstanceGroups) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockInstanceGroups) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AddInstances is a mock for the corresponding method.
+func (m *MockInstanceGroups) AddInstances(ctx context.Context, key meta.Key, arg0 *ga.InstanceGroupsAddInstancesRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("AddInstances", &key, &err, arg0)
+	if m.AddInstancesHook != nil {
+		return m.AddInstancesHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("InstanceGroups", "AddInstances", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// ListInstances is a mock for the corresponding method.
+func (m *MockInstanceGroups) ListInstances(ctx context.Context, key meta.Key, arg0 *ga.InstanceGroupsListInstancesRequest, opts ...interfaces.Option) (_ *ga.InstanceGroupsListInstances, err error) {
+	defer m.record("ListInstances", &key, &err, arg0)
+	if m.ListInstancesHook != nil {
+		return m.ListInstancesHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("InstanceGroups", "ListInstances", &key); ok && o.Err != nil {
+		return nil, o.Err
+	}
+	return nil, fmt.Errorf("ListInstancesHook must be set")
+}
+
+// RemoveInstances is a mock for the corresponding method.
+func (m *MockInstanceGroups) RemoveInstances(ctx context.Context, key meta.Key, arg0 *ga.InstanceGroupsRemoveInstancesRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("RemoveInstances", &key, &err, arg0)
+	if m.RemoveInstancesHook != nil {
+		return m.RemoveInstancesHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("InstanceGroups", "RemoveInstances", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// SetNamedPorts is a mock for the corresponding method.
+func (m *MockInstanceGroups) SetNamedPorts(ctx context.Context, key meta.Key, arg0 *ga.InstanceGroupsSetNamedPortsRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("SetNamedPorts", &key, &err, arg0)
+	if m.SetNamedPortsHook != nil {
+		return m.SetNamedPortsHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("InstanceGroups", "SetNamedPorts", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockInstances returns a new mock for Instances.
+func NewMockInstances(objs map[meta.Key]*MockInstancesObj) *MockInstances {
+	return &MockInstances{
+		mockStore: newMockStore("MockInstances", meta.Version("ga"), "Instances", objs, newMockInstancesObj, (*MockInstancesObj).ToGA).withIdentity(func(obj *ga.Instance, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("MachineType"),
+	}
+}
+
+// MockInstances is the mock for Instances. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockInstances struct {
+	*mockStore[ga.Instance, MockInstancesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockInstances, ctx context.Context, key meta.Key) (bool, *ga.Instance, error)
+	ListHook           func(m *MockInstances, ctx context.Context, zone string, fl *filter.F) (bool, []*ga.Instance, error)
+	InsertHook         func(m *MockInstances, ctx context.Context, key meta.Key, obj *ga.Instance) (bool, error)
+	DeleteHook         func(m *MockInstances, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockInstances, ctx context.Context, fl *filter.F) (bool, map[string][]*ga.Instance, error)
+	UpdateLabelsHook   func(m *MockInstances, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+	AttachDiskHook     func(*MockInstances, context.Context, meta.Key, *ga.AttachedDisk) error
+	DetachDiskHook     func(*MockInstances, context.Context, meta.Key, string) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockInstances) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Instance, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockInstances.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockInstances) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockInstances) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Instance, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockInstances) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Instance) (*ga.Instance, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockInstances) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Instance, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockInstances.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockInstances) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Instance, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockInstances) Insert(ctx context.Context, key meta.Key, obj *ga.Instance, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockInstances.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockInstances) InsertOp(ctx context.Context, key meta.Key, obj *ga.Instance, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockInstances) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockInstances.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockInstances) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockInstances) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockInstances) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*ga.Instance, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockInstances.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockInstances) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockInstances.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *ga.Instance, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// AttachDisk is a mock for the corresponding method.
+func (m *MockInstances) AttachDisk(ctx context.Context, key meta.Key, arg0 *ga.AttachedDisk, opts ...interfaces.Option) (err error) {
+	defer m.record("AttachDisk", &key, &err, arg0)
+	if m.AttachDiskHook != nil {
+		return m.AttachDiskHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("Instances", "AttachDisk", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// DetachDisk is a mock for the corresponding method.
+func (m *MockInstances) DetachDisk(ctx context.Context, key meta.Key, arg0 string, opts ...interfaces.Option) (err error) {
+	defer m.record("DetachDisk", &key, &err, arg0)
+	if m.DetachDiskHook != nil {
+		return m.DetachDiskHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("Instances", "DetachDisk", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockBetaInstances returns a new mock for Instances.
+func NewMockBetaInstances(objs map[meta.Key]*MockInstancesObj) *MockBetaInstances {
+	return &MockBetaInstances{
+		mockStore: newMockStore("MockBetaInstances", meta.Version("beta"), "Instances", objs, newMockInstancesObj, (*MockInstancesObj).ToBeta).withIdentity(func(obj *beta.Instance, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("MachineType"),
+	}
+}
+
+// MockBetaInstances is the mock for Instances. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockBetaInstances struct {
+	*mockStore[beta.Instance, MockInstancesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockBetaInstances, ctx context.Context, key meta.Key) (bool, *beta.Instance, error)
+	ListHook           func(m *MockBetaInstances, ctx context.Context, zone string, fl *filter.F) (bool, []*beta.Instance, error)
+	InsertHook         func(m *MockBetaInstances, ctx context.Context, key meta.Key, obj *beta.Instance) (bool, error)
+	DeleteHook         func(m *MockBetaInstances, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook func(m *MockBetaInstances, ctx context.Context, fl *filter.F) (bool, map[string][]*beta.Instance, error)
+	UpdateLabelsHook   func(m *MockBetaInstances, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+	AttachDiskHook     func(*MockBetaInstances, context.Context, meta.Key, *beta.AttachedDisk) error
+	DetachDiskHook     func(*MockBetaInstances, context.Context, meta.Key, string) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockBetaInstances) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *beta.Instance, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockBetaInstances.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockBetaInstances) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockBetaInstances) BatchGet(ctx context.Context, keys []meta.Key) ([]*beta.Instance, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockBetaInstances) GetOrCreate(ctx context.Context, key meta.Key, desired *beta.Instance) (*beta.Instance, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockBetaInstances) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*beta.Instance, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockBetaInstances.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockBetaInstances) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*beta.Instance, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockBetaInstances) Insert(ctx context.Context, key meta.Key, obj *beta.Instance, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockBetaInstances.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockBetaInstances) InsertOp(ctx context.Context, key meta.Key, obj *beta.Instance, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockBetaInstances) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockBetaInstances.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockBetaInstances) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockBetaInstances) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockBetaInstances) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*beta.Instance, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockBetaInstances.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockBetaInstances) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockBetaInstances.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *beta.Instance, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// AttachDisk is a mock for the corresponding method.
+func (m *MockBetaInstances) AttachDisk(ctx context.Context, key meta.Key, arg0 *beta.AttachedDisk, opts ...interfaces.Option) (err error) {
+	defer m.record("AttachDisk", &key, &err, arg0)
+	if m.AttachDiskHook != nil {
+		return m.AttachDiskHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("Instances", "AttachDisk", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// DetachDisk is a mock for the corresponding method.
+func (m *MockBetaInstances) DetachDisk(ctx context.Context, key meta.Key, arg0 string, opts ...interfaces.Option) (err error) {
+	defer m.record("DetachDisk", &key, &err, arg0)
+	if m.DetachDiskHook != nil {
+		return m.DetachDiskHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("Instances", "DetachDisk", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockAlphaInstances returns a new mock for Instances.
+func NewMockAlphaInstances(objs map[meta.Key]*MockInstancesObj) *MockAlphaInstances {
+	return &MockAlphaInstances{
+		mockStore: newMockStore("MockAlphaInstances", meta.Version("alpha"), "Instances", objs, newMockInstancesObj, (*MockInstancesObj).ToAlpha).withIdentity(func(obj *alpha.Instance, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("MachineType"),
+	}
+}
+
+// MockAlphaInstances is the mock for Instances. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaInstances struct {
+	*mockStore[alpha.Instance, MockInstancesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook                    func(m *MockAlphaInstances, ctx context.Context, key meta.Key) (bool, *alpha.Instance, error)
+	ListHook                   func(m *MockAlphaInstances, ctx context.Context, zone string, fl *filter.F) (bool, []*alpha.Instance, error)
+	InsertHook                 func(m *MockAlphaInstances, ctx context.Context, key meta.Key, obj *alpha.Instance) (bool, error)
+	DeleteHook                 func(m *MockAlphaInstances, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook         func(m *MockAlphaInstances, ctx context.Context, fl *filter.F) (bool, map[string][]*alpha.Instance, error)
+	UpdateLabelsHook           func(m *MockAlphaInstances, ctx context.Context, key meta.Key, labels map[string]string) (bool, error)
+	AttachDiskHook             func(*MockAlphaInstances, context.Context, meta.Key, *alpha.AttachedDisk) error
+	DetachDiskHook             func(*MockAlphaInstances, context.Context, meta.Key, string) error
+	UpdateNetworkInterfaceHook func(*MockAlphaInstances, context.Context, meta.Key, string, *alpha.NetworkInterface) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaInstances) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.Instance, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaInstances.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaInstances) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaInstances) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.Instance, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaInstances) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.Instance) (*alpha.Instance, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockAlphaInstances) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.Instance, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockAlphaInstances.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaInstances) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.Instance, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaInstances) Insert(ctx context.Context, key meta.Key, obj *alpha.Instance, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaInstances.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaInstances) InsertOp(ctx context.Context, key meta.Key, obj *alpha.Instance, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaInstances) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaInstances.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaInstances) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaInstances) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockAlphaInstances) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*alpha.Instance, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaInstances.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// UpdateLabels is a mock for setting the labels of the object. The object is
+// given a new label fingerprint.
+func (m *MockAlphaInstances) UpdateLabels(ctx context.Context, key meta.Key, labels map[string]string) (err error) {
+	defer m.record("UpdateLabels", &key, &err, labels)
+	if m.UpdateLabelsHook != nil {
+		if intercept, err := m.UpdateLabelsHook(m, ctx, key, labels); intercept {
+			glog.V(5).Infof("MockAlphaInstances.UpdateLabels(%v, %v, %v) = %v", ctx, key, labels, err)
+			return err
+		}
+	}
+	return m.updateLabels(ctx, key, labels, func(obj *alpha.Instance, labels map[string]string, fingerprint string) {
+		obj.Labels = labels
+		obj.LabelFingerprint = fingerprint
+	})
+}
+
+// AttachDisk is a mock for the corresponding method.
+func (m *MockAlphaInstances) AttachDisk(ctx context.Context, key meta.Key, arg0 *alpha.AttachedDisk, opts ...interfaces.Option) (err error) {
+	defer m.record("AttachDisk", &key, &err, arg0)
+	if m.AttachDiskHook != nil {
+		return m.AttachDiskHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("Instances", "AttachDisk", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// DetachDisk is a mock for the corresponding method.
+func (m *MockAlphaInstances) DetachDisk(ctx context.Context, key meta.Key, arg0 string, opts ...interfaces.Option) (err error) {
+	defer m.record("DetachDisk", &key, &err, arg0)
+	if m.DetachDiskHook != nil {
+		return m.DetachDiskHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("Instances", "DetachDisk", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// UpdateNetworkInterface is a mock for the corresponding method.
+func (m *MockAlphaInstances) UpdateNetworkInterface(ctx context.Context, key meta.Key, arg0 string, arg1 *alpha.NetworkInterface, opts ...interfaces.Option) (err error) {
+	defer m.record("UpdateNetworkInterface", &key, &err, arg0, arg1)
+	if m.UpdateNetworkInterfaceHook != nil {
+		return m.UpdateNetworkInterfaceHook(m, ctx, key, arg0, arg1)
+	}
+	if o, ok := m.Scenario.next("Instances", "UpdateNetworkInterface", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockMachineTypes returns a new mock for MachineTypes.
+func NewMockMachineTypes(objs map[meta.Key]*MockMachineTypesObj) *MockMachineTypes {
+	return &MockMachineTypes{
+		mockStore: newMockStore("MockMachineTypes", meta.Version("ga"), "MachineTypes", objs, newMockMachineTypesObj, (*MockMachineTypesObj).ToGA).withIdentity(func(obj *ga.MachineType, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockMachineTypes is the mock for MachineTypes. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockMachineTypes struct {
+	*mockStore[ga.MachineType, MockMachineTypesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook  func(m *MockMachineTypes, ctx context.Context, key meta.Key) (bool, *ga.MachineType, error)
+	ListHook func(m *MockMachineTypes, ctx context.Context, zone string, fl *filter.F) (bool, []*ga.MachineType, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockMachineTypes) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.MachineType, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockMachineTypes.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockMachineTypes) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockMachineTypes) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.MachineType, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockMachineTypes) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.MachineType, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockMachineTypes.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockMachineTypes) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.MachineType, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// NewMockAlphaNetworkEndpointGroups returns a new mock for NetworkEndpointGroups.
+func NewMockAlphaNetworkEndpointGroups(objs map[meta.Key]*MockNetworkEndpointGroupsObj) *MockAlphaNetworkEndpointGroups {
+	return &MockAlphaNetworkEndpointGroups{
+		mockStore: newMockStore("MockAlphaNetworkEndpointGroups", meta.Version("alpha"), "NetworkEndpointGroups", objs, newMockNetworkEndpointGroupsObj, (*MockNetworkEndpointGroupsObj).ToAlpha).withIdentity(func(obj *alpha.NetworkEndpointGroup, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockAlphaNetworkEndpointGroups is the mock for NetworkEndpointGroups. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockAlphaNetworkEndpointGroups struct {
+	*mockStore[alpha.NetworkEndpointGroup, MockNetworkEndpointGroupsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook                    func(m *MockAlphaNetworkEndpointGroups, ctx context.Context, key meta.Key) (bool, *alpha.NetworkEndpointGroup, error)
+	ListHook                   func(m *MockAlphaNetworkEndpointGroups, ctx context.Context, zone string, fl *filter.F) (bool, []*alpha.NetworkEndpointGroup, error)
+	InsertHook                 func(m *MockAlphaNetworkEndpointGroups, ctx context.Context, key meta.Key, obj *alpha.NetworkEndpointGroup) (bool, error)
+	DeleteHook                 func(m *MockAlphaNetworkEndpointGroups, ctx context.Context, key meta.Key) (bool, error)
+	AggregatedListHook         func(m *MockAlphaNetworkEndpointGroups, ctx context.Context, fl *filter.F) (bool, map[string][]*alpha.NetworkEndpointGroup, error)
+	AttachNetworkEndpointsHook func(*MockAlphaNetworkEndpointGroups, context.Context, meta.Key, *alpha.NetworkEndpointGroupsAttachEndpointsRequest) error
+	DetachNetworkEndpointsHook func(*MockAlphaNetworkEndpointGroups, context.Context, meta.Key, *alpha.NetworkEndpointGroupsDetachEndpointsRequest) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockAlphaNetworkEndpointGroups) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *alpha.NetworkEndpointGroup, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaNetworkEndpointGroups.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockAlphaNetworkEndpointGroups) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockAlphaNetworkEndpointGroups) BatchGet(ctx context.Context, keys []meta.Key) ([]*alpha.NetworkEndpointGroup, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockAlphaNetworkEndpointGroups) GetOrCreate(ctx context.Context, key meta.Key, desired *alpha.NetworkEndpointGroup) (*alpha.NetworkEndpointGroup, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockAlphaNetworkEndpointGroups) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*alpha.NetworkEndpointGroup, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockAlphaNetworkEndpointGroups.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockAlphaNetworkEndpointGroups) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*alpha.NetworkEndpointGroup, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockAlphaNetworkEndpointGroups) Insert(ctx context.Context, key meta.Key, obj *alpha.NetworkEndpointGroup, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockAlphaNetworkEndpointGroups.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaNetworkEndpointGroups) InsertOp(ctx context.Context, key meta.Key, obj *alpha.NetworkEndpointGroup, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockAlphaNetworkEndpointGroups) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockAlphaNetworkEndpointGroups.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockAlphaNetworkEndpointGroups) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockAlphaNetworkEndpointGroups) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AggregatedList is a mock for AggregatedList.
+func (m *MockAlphaNetworkEndpointGroups) AggregatedList(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ map[string][]*alpha.NetworkEndpointGroup, err error) {
+	defer m.record("AggregatedList", nil, &err, fl)
+	if m.AggregatedListHook != nil {
+		if intercept, objs, err := m.AggregatedListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockAlphaNetworkEndpointGroups.AggregatedList(%v, %v) = %+v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.aggregatedList(fl)
+}
+
+// AttachNetworkEndpoints is a mock for the corresponding method.
+func (m *MockAlphaNetworkEndpointGroups) AttachNetworkEndpoints(ctx context.Context, key meta.Key, arg0 *alpha.NetworkEndpointGroupsAttachEndpointsRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("AttachNetworkEndpoints", &key, &err, arg0)
+	if m.AttachNetworkEndpointsHook != nil {
+		return m.AttachNetworkEndpointsHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("NetworkEndpointGroups", "AttachNetworkEndpoints", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// DetachNetworkEndpoints is a mock for the corresponding method.
+func (m *MockAlphaNetworkEndpointGroups) DetachNetworkEndpoints(ctx context.Context, key meta.Key, arg0 *alpha.NetworkEndpointGroupsDetachEndpointsRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("DetachNetworkEndpoints", &key, &err, arg0)
+	if m.DetachNetworkEndpointsHook != nil {
+		return m.DetachNetworkEndpointsHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("NetworkEndpointGroups", "DetachNetworkEndpoints", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockGlobalOperations returns a new mock for GlobalOperations.
+func NewMockGlobalOperations(objs map[meta.Key]*MockGlobalOperationsObj) *MockGlobalOperations {
+	return &MockGlobalOperations{
+		mockStore: newMockStore("MockGlobalOperations", meta.Version("ga"), "GlobalOperations", objs, newMockGlobalOperationsObj, (*MockGlobalOperationsObj).ToGA).withIdentity(func(obj *ga.Operation, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockGlobalOperations is the mock for GlobalOperations. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockGlobalOperations struct {
+	*mockStore[ga.Operation, MockGlobalOperationsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockGlobalOperations, ctx context.Context, key meta.Key) (bool, *ga.Operation, error)
+	ListHook   func(m *MockGlobalOperations, ctx context.Context, fl *filter.F) (bool, []*ga.Operation, error)
+	DeleteHook func(m *MockGlobalOperations, ctx context.Context, key meta.Key) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockGlobalOperations) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Operation, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockGlobalOperations.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockGlobalOperations) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockGlobalOperations) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Operation, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock.
+func (m *MockGlobalOperations) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Operation, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockGlobalOperations.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockGlobalOperations) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Operation, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockGlobalOperations) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockGlobalOperations.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockGlobalOperations) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockGlobalOperations) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// NewMockRegionOperations returns a new mock for RegionOperations.
+func NewMockRegionOperations(objs map[meta.Key]*MockRegionOperationsObj) *MockRegionOperations {
+	return &MockRegionOperations{
+		mockStore: newMockStore("MockRegionOperations", meta.Version("ga"), "RegionOperations", objs, newMockRegionOperationsObj, (*MockRegionOperationsObj).ToGA).withIdentity(func(obj *ga.Operation, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockRegionOperations is the mock for RegionOperations. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockRegionOperations struct {
+	*mockStore[ga.Operation, MockRegionOperationsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockRegionOperations, ctx context.Context, key meta.Key) (bool, *ga.Operation, error)
+	ListHook   func(m *MockRegionOperations, ctx context.Context, region string, fl *filter.F) (bool, []*ga.Operation, error)
+	DeleteHook func(m *MockRegionOperations, ctx context.Context, key meta.Key) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockRegionOperations) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Operation, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockRegionOperations.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockRegionOperations) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockRegionOperations) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Operation, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockRegionOperations) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Operation, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockRegionOperations.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockRegionOperations) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Operation, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockRegionOperations) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockRegionOperations.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockRegionOperations) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockRegionOperations) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// NewMockZoneOperations returns a new mock for ZoneOperations.
+func NewMockZoneOperations(objs map[meta.Key]*MockZoneOperationsObj) *MockZoneOperations {
+	return &MockZoneOperations{
+		mockStore: newMockStore("MockZoneOperations", meta.Version("ga"), "ZoneOperations", objs, newMockZoneOperationsObj, (*MockZoneOperationsObj).ToGA).withIdentity(func(obj *ga.Operation, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockZoneOperations is the mock for ZoneOperations. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockZoneOperations struct {
+	*mockStore[ga.Operation, MockZoneOperationsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockZoneOperations, ctx context.Context, key meta.Key) (bool, *ga.Operation, error)
+	ListHook   func(m *MockZoneOperations, ctx context.Context, zone string, fl *filter.F) (bool, []*ga.Operation, error)
+	DeleteHook func(m *MockZoneOperations, ctx context.Context, key meta.Key) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockZoneOperations) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Operation, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockZoneOperations.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockZoneOperations) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockZoneOperations) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Operation, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock in the given zone.
+func (m *MockZoneOperations) List(ctx context.Context, zone string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Operation, err error) {
+	defer m.record("List", nil, &err, zone, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, zone, fl); intercept {
+			glog.V(5).Infof("MockZoneOperations.List(%v, %q, %v) = %v, %v", ctx, zone, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Zone == zone })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockZoneOperations) ListPage(ctx context.Context, zone string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Operation, string, error) {
+	objs, err := m.List(ctx, zone, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockZoneOperations) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockZoneOperations.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockZoneOperations) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockZoneOperations) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// NewMockProjects returns a new mock for Projects.
+func NewMockProjects(objs map[meta.Key]*MockProjectsObj) *MockProjects {
+	return &MockProjects{
+		mockStore: newMockStore("MockProjects", meta.Version("ga"), "Projects", objs, newMockProjectsObj, (*MockProjectsObj).ToGA).withIdentity(func(obj *ga.Project, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockProjects is the mock for Projects. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockProjects struct {
+	*mockStore[ga.Project, MockProjectsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// NewMockRegions returns a new mock for Regions.
+func NewMockRegions(objs map[meta.Key]*MockRegionsObj) *MockRegions {
+	return &MockRegions{
+		mockStore: newMockStore("MockRegions", meta.Version("ga"), "Regions", objs, newMockRegionsObj, (*MockRegionsObj).ToGA).withIdentity(func(obj *ga.Region, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockRegions is the mock for Regions. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockRegions struct {
+	*mockStore[ga.Region, MockRegionsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook  func(m *MockRegions, ctx context.Context, key meta.Key) (bool, *ga.Region, error)
+	ListHook func(m *MockRegions, ctx context.Context, fl *filter.F) (bool, []*ga.Region, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockRegions) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Region, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockRegions.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockRegions) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockRegions) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Region, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock.
+func (m *MockRegions) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Region, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockRegions.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockRegions) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Region, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// NewMockRoutes returns a new mock for Routes.
+func NewMockRoutes(objs map[meta.Key]*MockRoutesObj) *MockRoutes {
+	return &MockRoutes{
+		mockStore: newMockStore("MockRoutes", meta.Version("ga"), "Routes", objs, newMockRoutesObj, (*MockRoutesObj).ToGA).withIdentity(func(obj *ga.Route, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("Network", "DestRange"),
+	}
+}
+
+// MockRoutes is the mock for Routes. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockRoutes struct {
+	*mockStore[ga.Route, MockRoutesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockRoutes, ctx context.Context, key meta.Key) (bool, *ga.Route, error)
+	ListHook   func(m *MockRoutes, ctx context.Context, fl *filter.F) (bool, []*ga.Route, error)
+	InsertHook func(m *MockRoutes, ctx context.Context, key meta.Key, obj *ga.Route) (bool, error)
+	DeleteHook func(m *MockRoutes, ctx context.Context, key meta.Key) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockRoutes) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Route, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockRoutes.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockRoutes) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockRoutes) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Route, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockRoutes) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.Route) (*ga.Route, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockRoutes) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Route, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockRoutes.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockRoutes) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Route, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// ListAcrossProjects returns the objects of List for each of projects, as
+// the mock is not project aware.
+func (m *MockRoutes) ListAcrossProjects(ctx context.Context, projects []string, fl *filter.F, opts ...interfaces.Option) (map[string][]*ga.Route, error) {
+	return cloud.ListAcrossProjects(ctx, projects, 0, func(ctx context.Context, projectID string) ([]*ga.Route, error) {
+		return m.List(ctx, fl, opts...)
+	})
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockRoutes) Insert(ctx context.Context, key meta.Key, obj *ga.Route, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockRoutes.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockRoutes) InsertOp(ctx context.Context, key meta.Key, obj *ga.Route, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockRoutes) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockRoutes.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockRoutes) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockRoutes) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// NewMockSslCertificates returns a new mock for SslCertificates.
+func NewMockSslCertificates(objs map[meta.Key]*MockSslCertificatesObj) *MockSslCertificates {
+	return &MockSslCertificates{
+		mockStore: newMockStore("MockSslCertificates", meta.Version("ga"), "SslCertificates", objs, newMockSslCertificatesObj, (*MockSslCertificatesObj).ToGA).withIdentity(func(obj *ga.SslCertificate, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockSslCertificates is the mock for SslCertificates. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockSslCertificates struct {
+	*mockStore[ga.SslCertificate, MockSslCertificatesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockSslCertificates, ctx context.Context, key meta.Key) (bool, *ga.SslCertificate, error)
+	ListHook   func(m *MockSslCertificates, ctx context.Context, fl *filter.F) (bool, []*ga.SslCertificate, error)
+	InsertHook func(m *MockSslCertificates, ctx context.Context, key meta.Key, obj *ga.SslCertificate) (bool, error)
+	DeleteHook func(m *MockSslCertificates, ctx context.Context, key meta.Key) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockSslCertificates) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.SslCertificate, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockSslCertificates.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockSslCertificates) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockSslCertificates) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.SslCertificate, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockSslCertificates) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.SslCertificate) (*ga.SslCertificate, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockSslCertificates) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.SslCertificate, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockSslCertificates.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockSslCertificates) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.SslCertificate, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockSslCertificates) Insert(ctx context.Context, key meta.Key, obj *ga.SslCertificate, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockSslCertificates.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockSslCertificates) InsertOp(ctx context.Context, key meta.Key, obj *ga.SslCertificate, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockSslCertificates) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockSslCertificates.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockSslCertificates) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockSslCertificates) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// NewMockTargetHttpProxies returns a new mock for TargetHttpProxies.
+func NewMockTargetHttpProxies(objs map[meta.Key]*MockTargetHttpProxiesObj) *MockTargetHttpProxies {
+	return &MockTargetHttpProxies{
+		mockStore: newMockStore("MockTargetHttpProxies", meta.Version("ga"), "TargetHttpProxies", objs, newMockTargetHttpProxiesObj, (*MockTargetHttpProxiesObj).ToGA).withIdentity(func(obj *ga.TargetHttpProxy, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("UrlMap"),
+	}
+}
+
+// MockTargetHttpProxies is the mock for TargetHttpProxies. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockTargetHttpProxies struct {
+	*mockStore[ga.TargetHttpProxy, MockTargetHttpProxiesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook       func(m *MockTargetHttpProxies, ctx context.Context, key meta.Key) (bool, *ga.TargetHttpProxy, error)
+	ListHook      func(m *MockTargetHttpProxies, ctx context.Context, fl *filter.F) (bool, []*ga.TargetHttpProxy, error)
+	InsertHook    func(m *MockTargetHttpProxies, ctx context.Context, key meta.Key, obj *ga.TargetHttpProxy) (bool, error)
+	DeleteHook    func(m *MockTargetHttpProxies, ctx context.Context, key meta.Key) (bool, error)
+	SetUrlMapHook func(*MockTargetHttpProxies, context.Context, meta.Key, *ga.UrlMapReference) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockTargetHttpProxies) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.TargetHttpProxy, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockTargetHttpProxies.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockTargetHttpProxies) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockTargetHttpProxies) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.TargetHttpProxy, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockTargetHttpProxies) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.TargetHttpProxy) (*ga.TargetHttpProxy, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockTargetHttpProxies) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.TargetHttpProxy, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockTargetHttpProxies.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockTargetHttpProxies) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.TargetHttpProxy, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockTargetHttpProxies) Insert(ctx context.Context, key meta.Key, obj *ga.TargetHttpProxy, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockTargetHttpProxies.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockTargetHttpProxies) InsertOp(ctx context.Context, key meta.Key, obj *ga.TargetHttpProxy, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockTargetHttpProxies) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockTargetHttpProxies.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockTargetHttpProxies) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockTargetHttpProxies) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// SetUrlMap is a mock for the corresponding method.
+func (m *MockTargetHttpProxies) SetUrlMap(ctx context.Context, key meta.Key, arg0 *ga.UrlMapReference, opts ...interfaces.Option) (err error) {
+	defer m.record("SetUrlMap", &key, &err, arg0)
+	if m.SetUrlMapHook != nil {
+		return m.SetUrlMapHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("TargetHttpProxies", "SetUrlMap", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockTargetHttpsProxies returns a new mock for TargetHttpsProxies.
+func NewMockTargetHttpsProxies(objs map[meta.Key]*MockTargetHttpsProxiesObj) *MockTargetHttpsProxies {
+	return &MockTargetHttpsProxies{
+		mockStore: newMockStore("MockTargetHttpsProxies", meta.Version("ga"), "TargetHttpsProxies", objs, newMockTargetHttpsProxiesObj, (*MockTargetHttpsProxiesObj).ToGA).withIdentity(func(obj *ga.TargetHttpsProxy, key meta.Key) error { obj.Name = key.Name; return nil }).withRequiredFields("UrlMap", "SslCertificates"),
+	}
+}
+
+// MockTargetHttpsProxies is the mock for TargetHttpsProxies. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockTargetHttpsProxies struct {
+	*mockStore[ga.TargetHttpsProxy, MockTargetHttpsProxiesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook                func(m *MockTargetHttpsProxies, ctx context.Context, key meta.Key) (bool, *ga.TargetHttpsProxy, error)
+	ListHook               func(m *MockTargetHttpsProxies, ctx context.Context, fl *filter.F) (bool, []*ga.TargetHttpsProxy, error)
+	InsertHook             func(m *MockTargetHttpsProxies, ctx context.Context, key meta.Key, obj *ga.TargetHttpsProxy) (bool, error)
+	DeleteHook             func(m *MockTargetHttpsProxies, ctx context.Context, key meta.Key) (bool, error)
+	SetSslCertificatesHook func(*MockTargetHttpsProxies, context.Context, meta.Key, *ga.TargetHttpsProxiesSetSslCertificatesRequest) error
+	SetUrlMapHook          func(*MockTargetHttpsProxies, context.Context, meta.Key, *ga.UrlMapReference) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockTargetHttpsProxies) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.TargetHttpsProxy, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockTargetHttpsProxies.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockTargetHttpsProxies) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockTargetHttpsProxies) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.TargetHttpsProxy, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockTargetHttpsProxies) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.TargetHttpsProxy) (*ga.TargetHttpsProxy, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockTargetHttpsProxies) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.TargetHttpsProxy, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockTargetHttpsProxies.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockTargetHttpsProxies) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.TargetHttpsProxy, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockTargetHttpsProxies) Insert(ctx context.Context, key meta.Key, obj *ga.TargetHttpsProxy, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockTargetHttpsProxies.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockTargetHttpsProxies) InsertOp(ctx context.Context, key meta.Key, obj *ga.TargetHttpsProxy, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockTargetHttpsProxies) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockTargetHttpsProxies.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockTargetHttpsProxies) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockTargetHttpsProxies) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// SetSslCertificates is a mock for the corresponding method.
+func (m *MockTargetHttpsProxies) SetSslCertificates(ctx context.Context, key meta.Key, arg0 *ga.TargetHttpsProxiesSetSslCertificatesRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("SetSslCertificates", &key, &err, arg0)
+	if m.SetSslCertificatesHook != nil {
+		return m.SetSslCertificatesHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("TargetHttpsProxies", "SetSslCertificates", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// SetUrlMap is a mock for the corresponding method.
+func (m *MockTargetHttpsProxies) SetUrlMap(ctx context.Context, key meta.Key, arg0 *ga.UrlMapReference, opts ...interfaces.Option) (err error) {
+	defer m.record("SetUrlMap", &key, &err, arg0)
+	if m.SetUrlMapHook != nil {
+		return m.SetUrlMapHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("TargetHttpsProxies", "SetUrlMap", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockTargetPools returns a new mock for TargetPools.
+func NewMockTargetPools(objs map[meta.Key]*MockTargetPoolsObj) *MockTargetPools {
+	return &MockTargetPools{
+		mockStore: newMockStore("MockTargetPools", meta.Version("ga"), "TargetPools", objs, newMockTargetPoolsObj, (*MockTargetPoolsObj).ToGA).withIdentity(func(obj *ga.TargetPool, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockTargetPools is the mock for TargetPools. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockTargetPools struct {
+	*mockStore[ga.TargetPool, MockTargetPoolsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook            func(m *MockTargetPools, ctx context.Context, key meta.Key) (bool, *ga.TargetPool, error)
+	ListHook           func(m *MockTargetPools, ctx context.Context, region string, fl *filter.F) (bool, []*ga.TargetPool, error)
+	InsertHook         func(m *MockTargetPools, ctx context.Context, key meta.Key, obj *ga.TargetPool) (bool, error)
+	DeleteHook         func(m *MockTargetPools, ctx context.Context, key meta.Key) (bool, error)
+	AddInstanceHook    func(*MockTargetPools, context.Context, meta.Key, *ga.TargetPoolsAddInstanceRequest) error
+	RemoveInstanceHook func(*MockTargetPools, context.Context, meta.Key, *ga.TargetPoolsRemoveInstanceRequest) error
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockTargetPools) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.TargetPool, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockTargetPools.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockTargetPools) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockTargetPools) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.TargetPool, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockTargetPools) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.TargetPool) (*ga.TargetPool, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock in the given region.
+func (m *MockTargetPools) List(ctx context.Context, region string, fl *filter.F, opts ...interfaces.Option) (_ []*ga.TargetPool, err error) {
+	defer m.record("List", nil, &err, region, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, region, fl); intercept {
+			glog.V(5).Infof("MockTargetPools.List(%v, %q, %v) = %v, %v", ctx, region, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, func(key meta.Key) bool { return key.Region == region })
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockTargetPools) ListPage(ctx context.Context, region string, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.TargetPool, string, error) {
+	objs, err := m.List(ctx, region, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockTargetPools) Insert(ctx context.Context, key meta.Key, obj *ga.TargetPool, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockTargetPools.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockTargetPools) InsertOp(ctx context.Context, key meta.Key, obj *ga.TargetPool, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockTargetPools) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockTargetPools.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockTargetPools) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockTargetPools) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// AddInstance is a mock for the corresponding method.
+func (m *MockTargetPools) AddInstance(ctx context.Context, key meta.Key, arg0 *ga.TargetPoolsAddInstanceRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("AddInstance", &key, &err, arg0)
+	if m.AddInstanceHook != nil {
+		return m.AddInstanceHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("TargetPools", "AddInstance", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// RemoveInstance is a mock for the corresponding method.
+func (m *MockTargetPools) RemoveInstance(ctx context.Context, key meta.Key, arg0 *ga.TargetPoolsRemoveInstanceRequest, opts ...interfaces.Option) (err error) {
+	defer m.record("RemoveInstance", &key, &err, arg0)
+	if m.RemoveInstanceHook != nil {
+		return m.RemoveInstanceHook(m, ctx, key, arg0)
+	}
+	if o, ok := m.Scenario.next("TargetPools", "RemoveInstance", &key); ok && o.Err != nil {
+		return o.Err
+	}
+	return nil
+}
+
+// NewMockUrlMaps returns a new mock for UrlMaps.
+func NewMockUrlMaps(objs map[meta.Key]*MockUrlMapsObj) *MockUrlMaps {
+	return &MockUrlMaps{
+		mockStore: newMockStore("MockUrlMaps", meta.Version("ga"), "UrlMaps", objs, newMockUrlMapsObj, (*MockUrlMapsObj).ToGA).withFingerprint(func(obj *ga.UrlMap) *string { return &obj.Fingerprint }).withIdentity(func(obj *ga.UrlMap, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockUrlMaps is the mock for UrlMaps. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockUrlMaps struct {
+	*mockStore[ga.UrlMap, MockUrlMapsObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook    func(m *MockUrlMaps, ctx context.Context, key meta.Key) (bool, *ga.UrlMap, error)
+	ListHook   func(m *MockUrlMaps, ctx context.Context, fl *filter.F) (bool, []*ga.UrlMap, error)
+	InsertHook func(m *MockUrlMaps, ctx context.Context, key meta.Key, obj *ga.UrlMap) (bool, error)
+	DeleteHook func(m *MockUrlMaps, ctx context.Context, key meta.Key) (bool, error)
+	UpdateHook func(m *MockUrlMaps, ctx context.Context, key meta.Key, obj *ga.UrlMap) (bool, error)
+	PatchHook  func(m *MockUrlMaps, ctx context.Context, key meta.Key, obj *ga.UrlMap) (bool, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockUrlMaps) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.UrlMap, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockUrlMaps.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockUrlMaps) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockUrlMaps) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.UrlMap, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// GetOrCreate returns the object from the mock, inserting desired if it does
+// not exist.
+func (m *MockUrlMaps) GetOrCreate(ctx context.Context, key meta.Key, desired *ga.UrlMap) (*ga.UrlMap, error) {
+	return cloud.GetOrCreate(ctx, key, desired, m.Get, m.Insert)
+}
+
+// List all of the objects in the mock.
+func (m *MockUrlMaps) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.UrlMap, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockUrlMaps.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockUrlMaps) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.UrlMap, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
+
+// Insert is a mock for inserting/creating a new object.
+func (m *MockUrlMaps) Insert(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...interfaces.Option) (err error) {
+	defer m.record("Insert", &key, &err, obj)
+	if m.InsertHook != nil {
+		if intercept, err := m.InsertHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockUrlMaps.Insert(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.insert(ctx, key, obj, opts)
+}
+
+// InsertOp is a mock for InsertOp. The object is inserted with Insert; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockUrlMaps) InsertOp(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Insert(ctx, key, obj, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// Delete is a mock for deleting the object.
+func (m *MockUrlMaps) Delete(ctx context.Context, key meta.Key, opts ...interfaces.Option) (err error) {
+	defer m.record("Delete", &key, &err)
+	if m.DeleteHook != nil {
+		if intercept, err := m.DeleteHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockUrlMaps.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
+	return m.delete(ctx, key, opts)
+}
+
+// DeleteOp is a mock for DeleteOp. The object is deleted with Delete; the
+// returned operation is pending if the MockOperations are Async.
+func (m *MockUrlMaps) DeleteOp(ctx context.Context, key meta.Key, opts ...interfaces.Option) (cloud.Op, error) {
+	ctx, op := withStartedOp(ctx)
+	if err := m.Delete(ctx, key, append(opts[:len(opts):len(opts)], cloud.SkipWait())...); err != nil {
+		return nil, err
+	}
+	if *op == nil {
+		return cloud.DoneOp(nil), nil
+	}
+	return *op, nil
+}
+
+// BatchDelete deletes the objects of keys from the mock with Delete.
+func (m *MockUrlMaps) BatchDelete(ctx context.Context, keys []meta.Key) error {
+	return cloud.BatchDelete(ctx, keys, 0, m.Delete)
+}
+
+// Update is a mock for updating the object.
+func (m *MockUrlMaps) Update(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...interfaces.Option) (err error) {
+	defer m.record("Update", &key, &err, obj)
+	if m.UpdateHook != nil {
+		if intercept, err := m.UpdateHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockUrlMaps.Update(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.update(ctx, key, obj, opts)
+}
+
+// UpdateWithRetry updates the object with update using Get and Update of the
+// mock, which fails with http.StatusPreconditionFailed on a stale fingerprint.
+func (m *MockUrlMaps) UpdateWithRetry(ctx context.Context, key meta.Key, update func(*ga.UrlMap) error) error {
+	return cloud.UpdateWithRetry(ctx, key, update, m.Get, m.Update)
+}
+
+// Patch is a mock for patching the object.
+func (m *MockUrlMaps) Patch(ctx context.Context, key meta.Key, obj *ga.UrlMap, opts ...interfaces.Option) (err error) {
+	defer m.record("Patch", &key, &err, obj)
+	if m.PatchHook != nil {
+		if intercept, err := m.PatchHook(m, ctx, key, obj); intercept {
+			glog.V(5).Infof("MockUrlMaps.Patch(%v, %v, %v) = %v", ctx, key, obj, err)
+			return err
+		}
+	}
+	return m.patch(ctx, key, obj, opts)
+}
+
+// NewMockZones returns a new mock for Zones.
+func NewMockZones(objs map[meta.Key]*MockZonesObj) *MockZones {
+	return &MockZones{
+		mockStore: newMockStore("MockZones", meta.Version("ga"), "Zones", objs, newMockZonesObj, (*MockZonesObj).ToGA).withIdentity(func(obj *ga.Zone, key meta.Key) error { obj.Name = key.Name; return nil }),
+	}
+}
+
+// MockZones is the mock for Zones. The objects, injected errors
+// and Scenario are fields of the embedded mockStore.
+type MockZones struct {
+	*mockStore[ga.Zone, MockZonesObj]
+
+	// xxxHook allow you to intercept the standard processing of the mock in
+	// order to add your own logic. Return (true, _, _) to prevent the normal
+	// execution flow of the mock. Return (false, nil, nil) to continue with
+	// normal mock behavior/ after the hook function executes.
+	GetHook  func(m *MockZones, ctx context.Context, key meta.Key) (bool, *ga.Zone, error)
+	ListHook func(m *MockZones, ctx context.Context, fl *filter.F) (bool, []*ga.Zone, error)
+
+	// X is extra state that can be used as part of the mock. Generated code
+	// will not use this field.
+	X interface{}
+}
+
+// Get returns the object from the mock.
+func (m *MockZones) Get(ctx context.Context, key meta.Key, opts ...interfaces.Option) (_ *ga.Zone, err error) {
+	defer m.record("Get", &key, &err)
+	if m.GetHook != nil {
+		if intercept, obj, err := m.GetHook(m, ctx, key); intercept {
+			glog.V(5).Infof("MockZones.Get(%v, %s) = %v, %v", ctx, key, obj, err)
+			return obj, err
+		}
+	}
+	return m.get(key)
+}
+
+// Exists returns true if the object exists in the mock.
+func (m *MockZones) Exists(ctx context.Context, key meta.Key) (bool, error) {
+	return cloud.Exists(ctx, key, m.Get)
+}
+
+// BatchGet gets the objects of keys from the mock with Get.
+func (m *MockZones) BatchGet(ctx context.Context, keys []meta.Key) ([]*ga.Zone, error) {
+	return cloud.BatchGet(ctx, keys, 0, m.Get)
+}
+
+// List all of the objects in the mock.
+func (m *MockZones) List(ctx context.Context, fl *filter.F, opts ...interfaces.Option) (_ []*ga.Zone, err error) {
+	defer m.record("List", nil, &err, fl)
+	if m.ListHook != nil {
+		if intercept, objs, err := m.ListHook(m, ctx, fl); intercept {
+			glog.V(5).Infof("MockZones.List(%v, %v) = %v, %v", ctx, fl, objs, err)
+			return objs, err
+		}
+	}
+	return m.list(fl, nil)
+}
+
+// ListPage returns a page of the objects returned by List. See mockStore.page().
+func (m *MockZones) ListPage(ctx context.Context, fl *filter.F, pageToken string, maxResults int64, opts ...interfaces.Option) ([]*ga.Zone, string, error) {
+	objs, err := m.List(ctx, fl, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.page(objs, pageToken, maxResults)
+}
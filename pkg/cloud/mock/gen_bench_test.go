@@ -0,0 +1,1223 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode bench > mock/gen_bench_test.go".
+// Do not edit directly.
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+// benchObjectCounts are the number of objects in the mock for the benchmarks.
+var benchObjectCounts = []int{10, 100, 1000}
+
+func BenchmarkAddresses(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.RegionalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaAddresses.Objects[key] = newMockAddressesObj(&alpha.Address{Name: key.Name})
+		}
+		key := *meta.RegionalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaAddresses/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaAddresses().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaAddresses/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaAddresses().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaAddresses/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaAddresses().Insert(ctx, key, &alpha.Address{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaAddresses.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("BetaAddresses/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.BetaAddresses().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("BetaAddresses/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.BetaAddresses().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("BetaAddresses/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.BetaAddresses().Insert(ctx, key, &beta.Address{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockBetaAddresses.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("Addresses/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Addresses().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Addresses/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Addresses().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Addresses/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.Addresses().Insert(ctx, key, &ga.Address{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAddresses.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkBackendServices(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaBackendServices.Objects[key] = newMockBackendServicesObj(&alpha.BackendService{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("AlphaBackendServices/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaBackendServices().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaBackendServices/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaBackendServices().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaBackendServices/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaBackendServices().Insert(ctx, key, &alpha.BackendService{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaBackendServices.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("BackendServices/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.BackendServices().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("BackendServices/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.BackendServices().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("BackendServices/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.BackendServices().Insert(ctx, key, &ga.BackendService{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockBackendServices.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkDiskTypes(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockDiskTypes.Objects[key] = newMockDiskTypesObj(&ga.DiskType{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("DiskTypes/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.DiskTypes().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("DiskTypes/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.DiskTypes().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDisks(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaDisks.Objects[key] = newMockDisksObj(&alpha.Disk{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaDisks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaDisks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaDisks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaDisks().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaDisks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaDisks().Insert(ctx, key, &alpha.Disk{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaDisks.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("Disks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Disks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Disks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Disks().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Disks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.Disks().Insert(ctx, key, &ga.Disk{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockDisks.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkFirewalls(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockFirewalls.Objects[key] = newMockFirewallsObj(&ga.Firewall{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("Firewalls/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Firewalls().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Firewalls/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Firewalls().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Firewalls/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.Firewalls().Insert(ctx, key, &ga.Firewall{Name: key.Name, Network: "network"}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockFirewalls.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkForwardingRules(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.RegionalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaForwardingRules.Objects[key] = newMockForwardingRulesObj(&alpha.ForwardingRule{Name: key.Name})
+		}
+		key := *meta.RegionalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaForwardingRules/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaForwardingRules().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaForwardingRules/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaForwardingRules().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaForwardingRules/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaForwardingRules().Insert(ctx, key, &alpha.ForwardingRule{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaForwardingRules.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("ForwardingRules/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.ForwardingRules().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("ForwardingRules/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.ForwardingRules().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("ForwardingRules/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.ForwardingRules().Insert(ctx, key, &ga.ForwardingRule{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockForwardingRules.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkGlobalAddresses(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockGlobalAddresses.Objects[key] = newMockGlobalAddressesObj(&ga.Address{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("GlobalAddresses/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.GlobalAddresses().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("GlobalAddresses/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.GlobalAddresses().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("GlobalAddresses/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.GlobalAddresses().Insert(ctx, key, &ga.Address{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockGlobalAddresses.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkGlobalForwardingRules(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockGlobalForwardingRules.Objects[key] = newMockGlobalForwardingRulesObj(&ga.ForwardingRule{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("GlobalForwardingRules/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.GlobalForwardingRules().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("GlobalForwardingRules/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.GlobalForwardingRules().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("GlobalForwardingRules/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.GlobalForwardingRules().Insert(ctx, key, &ga.ForwardingRule{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockGlobalForwardingRules.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkGlobalOperations(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockGlobalOperations.Objects[key] = newMockGlobalOperationsObj(&ga.Operation{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("GlobalOperations/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.GlobalOperations().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("GlobalOperations/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.GlobalOperations().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHealthChecks(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaHealthChecks.Objects[key] = newMockHealthChecksObj(&alpha.HealthCheck{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("AlphaHealthChecks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaHealthChecks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaHealthChecks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaHealthChecks().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaHealthChecks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaHealthChecks().Insert(ctx, key, &alpha.HealthCheck{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaHealthChecks.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("HealthChecks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.HealthChecks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("HealthChecks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.HealthChecks().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("HealthChecks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.HealthChecks().Insert(ctx, key, &ga.HealthCheck{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockHealthChecks.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkHttpHealthChecks(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockHttpHealthChecks.Objects[key] = newMockHttpHealthChecksObj(&ga.HttpHealthCheck{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("HttpHealthChecks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.HttpHealthChecks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("HttpHealthChecks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.HttpHealthChecks().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("HttpHealthChecks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.HttpHealthChecks().Insert(ctx, key, &ga.HttpHealthCheck{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockHttpHealthChecks.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkHttpsHealthChecks(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockHttpsHealthChecks.Objects[key] = newMockHttpsHealthChecksObj(&ga.HttpsHealthCheck{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("HttpsHealthChecks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.HttpsHealthChecks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("HttpsHealthChecks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.HttpsHealthChecks().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("HttpsHealthChecks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.HttpsHealthChecks().Insert(ctx, key, &ga.HttpsHealthCheck{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockHttpsHealthChecks.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkInstanceGroups(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockInstanceGroups.Objects[key] = newMockInstanceGroupsObj(&ga.InstanceGroup{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("InstanceGroups/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.InstanceGroups().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("InstanceGroups/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.InstanceGroups().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("InstanceGroups/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.InstanceGroups().Insert(ctx, key, &ga.InstanceGroup{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockInstanceGroups.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkInstances(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaInstances.Objects[key] = newMockInstancesObj(&alpha.Instance{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaInstances/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaInstances().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaInstances/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaInstances().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaInstances/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaInstances().Insert(ctx, key, &alpha.Instance{Name: key.Name, MachineType: "machineType"}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaInstances.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("BetaInstances/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.BetaInstances().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("BetaInstances/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.BetaInstances().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("BetaInstances/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.BetaInstances().Insert(ctx, key, &beta.Instance{Name: key.Name, MachineType: "machineType"}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockBetaInstances.Objects, key)
+			}
+		})
+		b.Run(fmt.Sprintf("Instances/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Instances().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Instances/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Instances().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Instances/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.Instances().Insert(ctx, key, &ga.Instance{Name: key.Name, MachineType: "machineType"}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockInstances.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkMachineTypes(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockMachineTypes.Objects[key] = newMockMachineTypesObj(&ga.MachineType{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("MachineTypes/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.MachineTypes().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("MachineTypes/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.MachineTypes().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNetworkEndpointGroups(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaNetworkEndpointGroups.Objects[key] = newMockNetworkEndpointGroupsObj(&alpha.NetworkEndpointGroup{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaNetworkEndpointGroups/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaNetworkEndpointGroups().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaNetworkEndpointGroups/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaNetworkEndpointGroups().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaNetworkEndpointGroups/Insert/%d", n), func(b *testing.B) {
+			key := *meta.ZonalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaNetworkEndpointGroups().Insert(ctx, key, &alpha.NetworkEndpointGroup{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaNetworkEndpointGroups.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkRegionBackendServices(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.RegionalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaRegionBackendServices.Objects[key] = newMockRegionBackendServicesObj(&alpha.BackendService{Name: key.Name})
+		}
+		key := *meta.RegionalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaRegionBackendServices/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaRegionBackendServices().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaRegionBackendServices/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaRegionBackendServices().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaRegionBackendServices/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaRegionBackendServices().Insert(ctx, key, &alpha.BackendService{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaRegionBackendServices.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkRegionDisks(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.RegionalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockAlphaRegionDisks.Objects[key] = newMockRegionDisksObj(&alpha.Disk{Name: key.Name})
+		}
+		key := *meta.RegionalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("AlphaRegionDisks/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaRegionDisks().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaRegionDisks/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.AlphaRegionDisks().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("AlphaRegionDisks/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.AlphaRegionDisks().Insert(ctx, key, &alpha.Disk{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockAlphaRegionDisks.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkRegionOperations(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.RegionalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockRegionOperations.Objects[key] = newMockRegionOperationsObj(&ga.Operation{Name: key.Name})
+		}
+		key := *meta.RegionalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("RegionOperations/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.RegionOperations().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("RegionOperations/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.RegionOperations().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRegions(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockRegions.Objects[key] = newMockRegionsObj(&ga.Region{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("Regions/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Regions().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Regions/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Regions().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRoutes(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockRoutes.Objects[key] = newMockRoutesObj(&ga.Route{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("Routes/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Routes().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Routes/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Routes().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Routes/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.Routes().Insert(ctx, key, &ga.Route{Name: key.Name, Network: "network", DestRange: "destRange"}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockRoutes.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkSslCertificates(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockSslCertificates.Objects[key] = newMockSslCertificatesObj(&ga.SslCertificate{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("SslCertificates/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.SslCertificates().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("SslCertificates/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.SslCertificates().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("SslCertificates/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.SslCertificates().Insert(ctx, key, &ga.SslCertificate{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockSslCertificates.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkTargetHttpProxies(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockTargetHttpProxies.Objects[key] = newMockTargetHttpProxiesObj(&ga.TargetHttpProxy{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("TargetHttpProxies/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.TargetHttpProxies().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TargetHttpProxies/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.TargetHttpProxies().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TargetHttpProxies/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.TargetHttpProxies().Insert(ctx, key, &ga.TargetHttpProxy{Name: key.Name, UrlMap: "urlMap"}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockTargetHttpProxies.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkTargetHttpsProxies(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockTargetHttpsProxies.Objects[key] = newMockTargetHttpsProxiesObj(&ga.TargetHttpsProxy{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("TargetHttpsProxies/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.TargetHttpsProxies().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TargetHttpsProxies/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.TargetHttpsProxies().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TargetHttpsProxies/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.TargetHttpsProxies().Insert(ctx, key, &ga.TargetHttpsProxy{Name: key.Name, UrlMap: "urlMap", SslCertificates: []string{"sslCertificates"}}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockTargetHttpsProxies.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkTargetPools(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.RegionalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockTargetPools.Objects[key] = newMockTargetPoolsObj(&ga.TargetPool{Name: key.Name})
+		}
+		key := *meta.RegionalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("TargetPools/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.TargetPools().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TargetPools/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.TargetPools().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TargetPools/Insert/%d", n), func(b *testing.B) {
+			key := *meta.RegionalKey("obj-insert", "location")
+			for i := 0; i < b.N; i++ {
+				if err := mock.TargetPools().Insert(ctx, key, &ga.TargetPool{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockTargetPools.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkUrlMaps(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockUrlMaps.Objects[key] = newMockUrlMapsObj(&ga.UrlMap{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("UrlMaps/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.UrlMaps().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("UrlMaps/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.UrlMaps().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("UrlMaps/Insert/%d", n), func(b *testing.B) {
+			key := *meta.GlobalKey("obj-insert")
+			for i := 0; i < b.N; i++ {
+				if err := mock.UrlMaps().Insert(ctx, key, &ga.UrlMap{Name: key.Name}); err != nil {
+					b.Fatal(err)
+				}
+				delete(mock.MockUrlMaps.Objects, key)
+			}
+		})
+	}
+}
+
+func BenchmarkZoneOperations(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.ZonalKey("", "location")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockZoneOperations.Objects[key] = newMockZoneOperationsObj(&ga.Operation{Name: key.Name})
+		}
+		key := *meta.ZonalKey("obj-0", "location")
+		b.Run(fmt.Sprintf("ZoneOperations/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.ZoneOperations().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("ZoneOperations/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.ZoneOperations().List(ctx, "location", filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkZones(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchObjectCounts {
+		mock := NewMockGCE()
+		for i := 0; i < n; i++ {
+			key := *meta.GlobalKey("")
+			key.Name = fmt.Sprintf("obj-%d", i)
+			mock.MockZones.Objects[key] = newMockZonesObj(&ga.Zone{Name: key.Name})
+		}
+		key := *meta.GlobalKey("obj-0")
+		b.Run(fmt.Sprintf("Zones/Get/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Zones().Get(ctx, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Zones/List/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mock.Zones().List(ctx, filter.None); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConversions(b *testing.B) {
+	benchmarkConversion(b, "GAAddressToAlpha", cloud.GAAddressToAlpha)
+	benchmarkConversion(b, "GAAddressToBeta", cloud.GAAddressToBeta)
+	benchmarkConversion(b, "AlphaAddressToGA", cloud.AlphaAddressToGA)
+	benchmarkConversion(b, "AlphaAddressToBeta", cloud.AlphaAddressToBeta)
+	benchmarkConversion(b, "BetaAddressToGA", cloud.BetaAddressToGA)
+	benchmarkConversion(b, "BetaAddressToAlpha", cloud.BetaAddressToAlpha)
+	benchmarkConversion(b, "GABackendServiceToAlpha", cloud.GABackendServiceToAlpha)
+	benchmarkConversion(b, "AlphaBackendServiceToGA", cloud.AlphaBackendServiceToGA)
+	benchmarkConversion(b, "GADiskToAlpha", cloud.GADiskToAlpha)
+	benchmarkConversion(b, "AlphaDiskToGA", cloud.AlphaDiskToGA)
+	benchmarkConversion(b, "GAForwardingRuleToAlpha", cloud.GAForwardingRuleToAlpha)
+	benchmarkConversion(b, "AlphaForwardingRuleToGA", cloud.AlphaForwardingRuleToGA)
+	benchmarkConversion(b, "GAHealthCheckToAlpha", cloud.GAHealthCheckToAlpha)
+	benchmarkConversion(b, "AlphaHealthCheckToGA", cloud.AlphaHealthCheckToGA)
+	benchmarkConversion(b, "GAInstanceToAlpha", cloud.GAInstanceToAlpha)
+	benchmarkConversion(b, "GAInstanceToBeta", cloud.GAInstanceToBeta)
+	benchmarkConversion(b, "AlphaInstanceToGA", cloud.AlphaInstanceToGA)
+	benchmarkConversion(b, "AlphaInstanceToBeta", cloud.AlphaInstanceToBeta)
+	benchmarkConversion(b, "BetaInstanceToGA", cloud.BetaInstanceToGA)
+	benchmarkConversion(b, "BetaInstanceToAlpha", cloud.BetaInstanceToAlpha)
+}
+
+func BenchmarkDeepCopies(b *testing.B) {
+	benchmarkDeepCopy(b, "CopyGAAddress", cloud.CopyGAAddress)
+	benchmarkDeepCopy(b, "CopyAlphaAddress", cloud.CopyAlphaAddress)
+	benchmarkDeepCopy(b, "CopyBetaAddress", cloud.CopyBetaAddress)
+	benchmarkDeepCopy(b, "CopyGABackendService", cloud.CopyGABackendService)
+	benchmarkDeepCopy(b, "CopyAlphaBackendService", cloud.CopyAlphaBackendService)
+	benchmarkDeepCopy(b, "CopyGADisk", cloud.CopyGADisk)
+	benchmarkDeepCopy(b, "CopyAlphaDisk", cloud.CopyAlphaDisk)
+	benchmarkDeepCopy(b, "CopyGADiskType", cloud.CopyGADiskType)
+	benchmarkDeepCopy(b, "CopyGAFirewall", cloud.CopyGAFirewall)
+	benchmarkDeepCopy(b, "CopyGAForwardingRule", cloud.CopyGAForwardingRule)
+	benchmarkDeepCopy(b, "CopyAlphaForwardingRule", cloud.CopyAlphaForwardingRule)
+	benchmarkDeepCopy(b, "CopyGAHealthCheck", cloud.CopyGAHealthCheck)
+	benchmarkDeepCopy(b, "CopyAlphaHealthCheck", cloud.CopyAlphaHealthCheck)
+	benchmarkDeepCopy(b, "CopyGAHttpHealthCheck", cloud.CopyGAHttpHealthCheck)
+	benchmarkDeepCopy(b, "CopyGAHttpsHealthCheck", cloud.CopyGAHttpsHealthCheck)
+	benchmarkDeepCopy(b, "CopyGAInstance", cloud.CopyGAInstance)
+	benchmarkDeepCopy(b, "CopyAlphaInstance", cloud.CopyAlphaInstance)
+	benchmarkDeepCopy(b, "CopyBetaInstance", cloud.CopyBetaInstance)
+	benchmarkDeepCopy(b, "CopyGAInstanceGroup", cloud.CopyGAInstanceGroup)
+	benchmarkDeepCopy(b, "CopyGAMachineType", cloud.CopyGAMachineType)
+	benchmarkDeepCopy(b, "CopyAlphaNetworkEndpointGroup", cloud.CopyAlphaNetworkEndpointGroup)
+	benchmarkDeepCopy(b, "CopyGAOperation", cloud.CopyGAOperation)
+	benchmarkDeepCopy(b, "CopyGAProject", cloud.CopyGAProject)
+	benchmarkDeepCopy(b, "CopyGARegion", cloud.CopyGARegion)
+	benchmarkDeepCopy(b, "CopyGARoute", cloud.CopyGARoute)
+	benchmarkDeepCopy(b, "CopyGASslCertificate", cloud.CopyGASslCertificate)
+	benchmarkDeepCopy(b, "CopyGATargetHttpProxy", cloud.CopyGATargetHttpProxy)
+	benchmarkDeepCopy(b, "CopyGATargetHttpsProxy", cloud.CopyGATargetHttpsProxy)
+	benchmarkDeepCopy(b, "CopyGATargetPool", cloud.CopyGATargetPool)
+	benchmarkDeepCopy(b, "CopyGAUrlMap", cloud.CopyGAUrlMap)
+	benchmarkDeepCopy(b, "CopyGAZone", cloud.CopyGAZone)
+}
@@ -0,0 +1,1150 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode fuzz > mock/gen_fuzz_test.go".
+// Do not edit directly.
+
+package mock
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+func FuzzParseResourceURL(f *testing.F) {
+	for _, url := range []string{
+		"",
+		"projects",
+		"projects/",
+		"projects//global//",
+		"projects/project/zones/zone/instances/name/extra",
+		"https://www.googleapis.com/compute/v1/",
+		"https://www.googleapis.com/compute/alpha/projects/project/regions",
+		"projects/project/regions/region/addresses/name",
+		"projects/project/global/backendServices/name",
+		"projects/project/zones/zone/diskTypes/name",
+		"projects/project/zones/zone/disks/name",
+		"projects/project/global/firewalls/name",
+		"projects/project/regions/region/forwardingRules/name",
+		"projects/project/global/globalAddresses/name",
+		"projects/project/global/globalForwardingRules/name",
+		"projects/project/global/globalOperations/name",
+		"projects/project/global/healthChecks/name",
+		"projects/project/global/httpHealthChecks/name",
+		"projects/project/global/httpsHealthChecks/name",
+		"projects/project/zones/zone/instanceGroups/name",
+		"projects/project/zones/zone/instances/name",
+		"projects/project/zones/zone/machineTypes/name",
+		"projects/project/zones/zone/networkEndpointGroups/name",
+		"projects/project/global/projects/name",
+		"projects/project/regions/region/regionBackendServices/name",
+		"projects/project/regions/region/regionDisks/name",
+		"projects/project/regions/region/regionOperations/name",
+		"projects/project/global/regions/name",
+		"projects/project/global/routes/name",
+		"projects/project/global/sslCertificates/name",
+		"projects/project/global/targetHttpProxies/name",
+		"projects/project/global/targetHttpsProxies/name",
+		"projects/project/regions/region/targetPools/name",
+		"projects/project/global/urlMaps/name",
+		"projects/project/zones/zone/zoneOperations/name",
+		"projects/project/global/zones/name",
+	} {
+		f.Add(url)
+	}
+	f.Fuzz(func(t *testing.T, url string) {
+		id, err := cloud.ParseResourceURL(url)
+		if err != nil || !strings.HasPrefix(url, "projects/") {
+			return
+		}
+		// A relative URL parses the same as the full URL.
+		full := "https://www.googleapis.com/compute/v1/" + url
+		if id2, err := cloud.ParseResourceURL(full); err != nil || !id.Equal(id2) {
+			t.Errorf("ParseResourceURL(%q) = %+v, %v; want %+v, nil", full, id2, err, id)
+		}
+	})
+}
+
+func FuzzAddresses(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewAddressKey(name, location)
+		if location == "" {
+			// The key is not regional without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.AddressKeyFrom(key); err != nil || got != k {
+			t.Errorf("AddressKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaAddresses.
+		if err := mock.AlphaAddresses().Insert(ctx, key, &alpha.Address{Name: name}); err != nil {
+			t.Errorf("AlphaAddresses().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaAddresses().Get(ctx, key); err != nil {
+			t.Errorf("AlphaAddresses().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaAddresses().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaAddresses().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaAddresses().Get(ctx, key); err == nil {
+			t.Errorf("AlphaAddresses().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// BetaAddresses.
+		if err := mock.BetaAddresses().Insert(ctx, key, &beta.Address{Name: name}); err != nil {
+			t.Errorf("BetaAddresses().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.BetaAddresses().Get(ctx, key); err != nil {
+			t.Errorf("BetaAddresses().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.BetaAddresses().Delete(ctx, key); err != nil {
+			t.Errorf("BetaAddresses().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.BetaAddresses().Get(ctx, key); err == nil {
+			t.Errorf("BetaAddresses().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// Addresses.
+		if err := mock.Addresses().Insert(ctx, key, &ga.Address{Name: name}); err != nil {
+			t.Errorf("Addresses().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.Addresses().Get(ctx, key); err != nil {
+			t.Errorf("Addresses().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.Addresses().Delete(ctx, key); err != nil {
+			t.Errorf("Addresses().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.Addresses().Get(ctx, key); err == nil {
+			t.Errorf("Addresses().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzBackendServices(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewBackendServiceKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.BackendServiceKeyFrom(key); err != nil || got != k {
+			t.Errorf("BackendServiceKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaBackendServices.
+		if err := mock.AlphaBackendServices().Insert(ctx, key, &alpha.BackendService{Name: name}); err != nil {
+			t.Errorf("AlphaBackendServices().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaBackendServices().Get(ctx, key); err != nil {
+			t.Errorf("AlphaBackendServices().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaBackendServices().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaBackendServices().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaBackendServices().Get(ctx, key); err == nil {
+			t.Errorf("AlphaBackendServices().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// BackendServices.
+		if err := mock.BackendServices().Insert(ctx, key, &ga.BackendService{Name: name}); err != nil {
+			t.Errorf("BackendServices().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.BackendServices().Get(ctx, key); err != nil {
+			t.Errorf("BackendServices().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.BackendServices().Delete(ctx, key); err != nil {
+			t.Errorf("BackendServices().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.BackendServices().Get(ctx, key); err == nil {
+			t.Errorf("BackendServices().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzDiskTypes(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewDiskTypeKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.DiskTypeKeyFrom(key); err != nil || got != k {
+			t.Errorf("DiskTypeKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// DiskTypes.
+		mock.MockDiskTypes.Objects[key] = newMockDiskTypesObj(&ga.DiskType{Name: name})
+		if _, err := mock.DiskTypes().Get(ctx, key); err != nil {
+			t.Errorf("DiskTypes().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		delete(mock.MockDiskTypes.Objects, key)
+	})
+}
+
+func FuzzDisks(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewDiskKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.DiskKeyFrom(key); err != nil || got != k {
+			t.Errorf("DiskKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaDisks.
+		if err := mock.AlphaDisks().Insert(ctx, key, &alpha.Disk{Name: name}); err != nil {
+			t.Errorf("AlphaDisks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaDisks().Get(ctx, key); err != nil {
+			t.Errorf("AlphaDisks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaDisks().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaDisks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaDisks().Get(ctx, key); err == nil {
+			t.Errorf("AlphaDisks().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// Disks.
+		if err := mock.Disks().Insert(ctx, key, &ga.Disk{Name: name}); err != nil {
+			t.Errorf("Disks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.Disks().Get(ctx, key); err != nil {
+			t.Errorf("Disks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.Disks().Delete(ctx, key); err != nil {
+			t.Errorf("Disks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.Disks().Get(ctx, key); err == nil {
+			t.Errorf("Disks().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzFirewalls(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewFirewallKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.FirewallKeyFrom(key); err != nil || got != k {
+			t.Errorf("FirewallKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// Firewalls.
+		if err := mock.Firewalls().Insert(ctx, key, &ga.Firewall{Name: name, Network: "network"}); err != nil {
+			t.Errorf("Firewalls().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.Firewalls().Get(ctx, key); err != nil {
+			t.Errorf("Firewalls().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.Firewalls().Delete(ctx, key); err != nil {
+			t.Errorf("Firewalls().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.Firewalls().Get(ctx, key); err == nil {
+			t.Errorf("Firewalls().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzForwardingRules(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewForwardingRuleKey(name, location)
+		if location == "" {
+			// The key is not regional without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.ForwardingRuleKeyFrom(key); err != nil || got != k {
+			t.Errorf("ForwardingRuleKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaForwardingRules.
+		if err := mock.AlphaForwardingRules().Insert(ctx, key, &alpha.ForwardingRule{Name: name}); err != nil {
+			t.Errorf("AlphaForwardingRules().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaForwardingRules().Get(ctx, key); err != nil {
+			t.Errorf("AlphaForwardingRules().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaForwardingRules().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaForwardingRules().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaForwardingRules().Get(ctx, key); err == nil {
+			t.Errorf("AlphaForwardingRules().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// ForwardingRules.
+		if err := mock.ForwardingRules().Insert(ctx, key, &ga.ForwardingRule{Name: name}); err != nil {
+			t.Errorf("ForwardingRules().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.ForwardingRules().Get(ctx, key); err != nil {
+			t.Errorf("ForwardingRules().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.ForwardingRules().Delete(ctx, key); err != nil {
+			t.Errorf("ForwardingRules().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.ForwardingRules().Get(ctx, key); err == nil {
+			t.Errorf("ForwardingRules().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzGlobalAddresses(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewGlobalAddressKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.GlobalAddressKeyFrom(key); err != nil || got != k {
+			t.Errorf("GlobalAddressKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// GlobalAddresses.
+		if err := mock.GlobalAddresses().Insert(ctx, key, &ga.Address{Name: name}); err != nil {
+			t.Errorf("GlobalAddresses().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.GlobalAddresses().Get(ctx, key); err != nil {
+			t.Errorf("GlobalAddresses().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.GlobalAddresses().Delete(ctx, key); err != nil {
+			t.Errorf("GlobalAddresses().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.GlobalAddresses().Get(ctx, key); err == nil {
+			t.Errorf("GlobalAddresses().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzGlobalForwardingRules(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewGlobalForwardingRuleKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.GlobalForwardingRuleKeyFrom(key); err != nil || got != k {
+			t.Errorf("GlobalForwardingRuleKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// GlobalForwardingRules.
+		if err := mock.GlobalForwardingRules().Insert(ctx, key, &ga.ForwardingRule{Name: name}); err != nil {
+			t.Errorf("GlobalForwardingRules().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.GlobalForwardingRules().Get(ctx, key); err != nil {
+			t.Errorf("GlobalForwardingRules().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.GlobalForwardingRules().Delete(ctx, key); err != nil {
+			t.Errorf("GlobalForwardingRules().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.GlobalForwardingRules().Get(ctx, key); err == nil {
+			t.Errorf("GlobalForwardingRules().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzGlobalOperations(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewGlobalOperationKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.GlobalOperationKeyFrom(key); err != nil || got != k {
+			t.Errorf("GlobalOperationKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// GlobalOperations.
+		mock.MockGlobalOperations.Objects[key] = newMockGlobalOperationsObj(&ga.Operation{Name: name})
+		if _, err := mock.GlobalOperations().Get(ctx, key); err != nil {
+			t.Errorf("GlobalOperations().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.GlobalOperations().Delete(ctx, key); err != nil {
+			t.Errorf("GlobalOperations().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.GlobalOperations().Get(ctx, key); err == nil {
+			t.Errorf("GlobalOperations().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzHealthChecks(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewHealthCheckKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.HealthCheckKeyFrom(key); err != nil || got != k {
+			t.Errorf("HealthCheckKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaHealthChecks.
+		if err := mock.AlphaHealthChecks().Insert(ctx, key, &alpha.HealthCheck{Name: name}); err != nil {
+			t.Errorf("AlphaHealthChecks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaHealthChecks().Get(ctx, key); err != nil {
+			t.Errorf("AlphaHealthChecks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaHealthChecks().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaHealthChecks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaHealthChecks().Get(ctx, key); err == nil {
+			t.Errorf("AlphaHealthChecks().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// HealthChecks.
+		if err := mock.HealthChecks().Insert(ctx, key, &ga.HealthCheck{Name: name}); err != nil {
+			t.Errorf("HealthChecks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.HealthChecks().Get(ctx, key); err != nil {
+			t.Errorf("HealthChecks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.HealthChecks().Delete(ctx, key); err != nil {
+			t.Errorf("HealthChecks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.HealthChecks().Get(ctx, key); err == nil {
+			t.Errorf("HealthChecks().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzHttpHealthChecks(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewHttpHealthCheckKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.HttpHealthCheckKeyFrom(key); err != nil || got != k {
+			t.Errorf("HttpHealthCheckKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// HttpHealthChecks.
+		if err := mock.HttpHealthChecks().Insert(ctx, key, &ga.HttpHealthCheck{Name: name}); err != nil {
+			t.Errorf("HttpHealthChecks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.HttpHealthChecks().Get(ctx, key); err != nil {
+			t.Errorf("HttpHealthChecks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.HttpHealthChecks().Delete(ctx, key); err != nil {
+			t.Errorf("HttpHealthChecks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.HttpHealthChecks().Get(ctx, key); err == nil {
+			t.Errorf("HttpHealthChecks().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzHttpsHealthChecks(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewHttpsHealthCheckKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.HttpsHealthCheckKeyFrom(key); err != nil || got != k {
+			t.Errorf("HttpsHealthCheckKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// HttpsHealthChecks.
+		if err := mock.HttpsHealthChecks().Insert(ctx, key, &ga.HttpsHealthCheck{Name: name}); err != nil {
+			t.Errorf("HttpsHealthChecks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.HttpsHealthChecks().Get(ctx, key); err != nil {
+			t.Errorf("HttpsHealthChecks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.HttpsHealthChecks().Delete(ctx, key); err != nil {
+			t.Errorf("HttpsHealthChecks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.HttpsHealthChecks().Get(ctx, key); err == nil {
+			t.Errorf("HttpsHealthChecks().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzInstanceGroups(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewInstanceGroupKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.InstanceGroupKeyFrom(key); err != nil || got != k {
+			t.Errorf("InstanceGroupKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// InstanceGroups.
+		if err := mock.InstanceGroups().Insert(ctx, key, &ga.InstanceGroup{Name: name}); err != nil {
+			t.Errorf("InstanceGroups().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.InstanceGroups().Get(ctx, key); err != nil {
+			t.Errorf("InstanceGroups().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.InstanceGroups().Delete(ctx, key); err != nil {
+			t.Errorf("InstanceGroups().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.InstanceGroups().Get(ctx, key); err == nil {
+			t.Errorf("InstanceGroups().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzInstances(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewInstanceKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.InstanceKeyFrom(key); err != nil || got != k {
+			t.Errorf("InstanceKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaInstances.
+		if err := mock.AlphaInstances().Insert(ctx, key, &alpha.Instance{Name: name, MachineType: "machineType"}); err != nil {
+			t.Errorf("AlphaInstances().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaInstances().Get(ctx, key); err != nil {
+			t.Errorf("AlphaInstances().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaInstances().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaInstances().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaInstances().Get(ctx, key); err == nil {
+			t.Errorf("AlphaInstances().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// BetaInstances.
+		if err := mock.BetaInstances().Insert(ctx, key, &beta.Instance{Name: name, MachineType: "machineType"}); err != nil {
+			t.Errorf("BetaInstances().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.BetaInstances().Get(ctx, key); err != nil {
+			t.Errorf("BetaInstances().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.BetaInstances().Delete(ctx, key); err != nil {
+			t.Errorf("BetaInstances().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.BetaInstances().Get(ctx, key); err == nil {
+			t.Errorf("BetaInstances().Get(_, %v) = _, nil; want error", key)
+		}
+
+		// Instances.
+		if err := mock.Instances().Insert(ctx, key, &ga.Instance{Name: name, MachineType: "machineType"}); err != nil {
+			t.Errorf("Instances().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.Instances().Get(ctx, key); err != nil {
+			t.Errorf("Instances().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.Instances().Delete(ctx, key); err != nil {
+			t.Errorf("Instances().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.Instances().Get(ctx, key); err == nil {
+			t.Errorf("Instances().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzMachineTypes(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewMachineTypeKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.MachineTypeKeyFrom(key); err != nil || got != k {
+			t.Errorf("MachineTypeKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// MachineTypes.
+		mock.MockMachineTypes.Objects[key] = newMockMachineTypesObj(&ga.MachineType{Name: name})
+		if _, err := mock.MachineTypes().Get(ctx, key); err != nil {
+			t.Errorf("MachineTypes().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		delete(mock.MockMachineTypes.Objects, key)
+	})
+}
+
+func FuzzNetworkEndpointGroups(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewNetworkEndpointGroupKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.NetworkEndpointGroupKeyFrom(key); err != nil || got != k {
+			t.Errorf("NetworkEndpointGroupKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaNetworkEndpointGroups.
+		if err := mock.AlphaNetworkEndpointGroups().Insert(ctx, key, &alpha.NetworkEndpointGroup{Name: name}); err != nil {
+			t.Errorf("AlphaNetworkEndpointGroups().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaNetworkEndpointGroups().Get(ctx, key); err != nil {
+			t.Errorf("AlphaNetworkEndpointGroups().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaNetworkEndpointGroups().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaNetworkEndpointGroups().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaNetworkEndpointGroups().Get(ctx, key); err == nil {
+			t.Errorf("AlphaNetworkEndpointGroups().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzProjects(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewProjectKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.ProjectKeyFrom(key); err != nil || got != k {
+			t.Errorf("ProjectKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// Projects.
+		mock.MockProjects.Objects[key] = newMockProjectsObj(&ga.Project{Name: name})
+		delete(mock.MockProjects.Objects, key)
+	})
+}
+
+func FuzzRegionBackendServices(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewRegionBackendServiceKey(name, location)
+		if location == "" {
+			// The key is not regional without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.RegionBackendServiceKeyFrom(key); err != nil || got != k {
+			t.Errorf("RegionBackendServiceKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaRegionBackendServices.
+		if err := mock.AlphaRegionBackendServices().Insert(ctx, key, &alpha.BackendService{Name: name}); err != nil {
+			t.Errorf("AlphaRegionBackendServices().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaRegionBackendServices().Get(ctx, key); err != nil {
+			t.Errorf("AlphaRegionBackendServices().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaRegionBackendServices().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaRegionBackendServices().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaRegionBackendServices().Get(ctx, key); err == nil {
+			t.Errorf("AlphaRegionBackendServices().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzRegionDisks(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewRegionDiskKey(name, location)
+		if location == "" {
+			// The key is not regional without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.RegionDiskKeyFrom(key); err != nil || got != k {
+			t.Errorf("RegionDiskKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// AlphaRegionDisks.
+		if err := mock.AlphaRegionDisks().Insert(ctx, key, &alpha.Disk{Name: name}); err != nil {
+			t.Errorf("AlphaRegionDisks().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaRegionDisks().Get(ctx, key); err != nil {
+			t.Errorf("AlphaRegionDisks().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.AlphaRegionDisks().Delete(ctx, key); err != nil {
+			t.Errorf("AlphaRegionDisks().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.AlphaRegionDisks().Get(ctx, key); err == nil {
+			t.Errorf("AlphaRegionDisks().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzRegionOperations(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewRegionOperationKey(name, location)
+		if location == "" {
+			// The key is not regional without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.RegionOperationKeyFrom(key); err != nil || got != k {
+			t.Errorf("RegionOperationKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// RegionOperations.
+		mock.MockRegionOperations.Objects[key] = newMockRegionOperationsObj(&ga.Operation{Name: name})
+		if _, err := mock.RegionOperations().Get(ctx, key); err != nil {
+			t.Errorf("RegionOperations().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.RegionOperations().Delete(ctx, key); err != nil {
+			t.Errorf("RegionOperations().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.RegionOperations().Get(ctx, key); err == nil {
+			t.Errorf("RegionOperations().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzRegions(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewRegionKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.RegionKeyFrom(key); err != nil || got != k {
+			t.Errorf("RegionKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// Regions.
+		mock.MockRegions.Objects[key] = newMockRegionsObj(&ga.Region{Name: name})
+		if _, err := mock.Regions().Get(ctx, key); err != nil {
+			t.Errorf("Regions().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		delete(mock.MockRegions.Objects, key)
+	})
+}
+
+func FuzzRoutes(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewRouteKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.RouteKeyFrom(key); err != nil || got != k {
+			t.Errorf("RouteKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// Routes.
+		if err := mock.Routes().Insert(ctx, key, &ga.Route{Name: name, Network: "network", DestRange: "destRange"}); err != nil {
+			t.Errorf("Routes().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.Routes().Get(ctx, key); err != nil {
+			t.Errorf("Routes().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.Routes().Delete(ctx, key); err != nil {
+			t.Errorf("Routes().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.Routes().Get(ctx, key); err == nil {
+			t.Errorf("Routes().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzSslCertificates(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewSslCertificateKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.SslCertificateKeyFrom(key); err != nil || got != k {
+			t.Errorf("SslCertificateKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// SslCertificates.
+		if err := mock.SslCertificates().Insert(ctx, key, &ga.SslCertificate{Name: name}); err != nil {
+			t.Errorf("SslCertificates().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.SslCertificates().Get(ctx, key); err != nil {
+			t.Errorf("SslCertificates().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.SslCertificates().Delete(ctx, key); err != nil {
+			t.Errorf("SslCertificates().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.SslCertificates().Get(ctx, key); err == nil {
+			t.Errorf("SslCertificates().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzTargetHttpProxies(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewTargetHttpProxyKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.TargetHttpProxyKeyFrom(key); err != nil || got != k {
+			t.Errorf("TargetHttpProxyKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// TargetHttpProxies.
+		if err := mock.TargetHttpProxies().Insert(ctx, key, &ga.TargetHttpProxy{Name: name, UrlMap: "urlMap"}); err != nil {
+			t.Errorf("TargetHttpProxies().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.TargetHttpProxies().Get(ctx, key); err != nil {
+			t.Errorf("TargetHttpProxies().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.TargetHttpProxies().Delete(ctx, key); err != nil {
+			t.Errorf("TargetHttpProxies().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.TargetHttpProxies().Get(ctx, key); err == nil {
+			t.Errorf("TargetHttpProxies().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzTargetHttpsProxies(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewTargetHttpsProxyKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.TargetHttpsProxyKeyFrom(key); err != nil || got != k {
+			t.Errorf("TargetHttpsProxyKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// TargetHttpsProxies.
+		if err := mock.TargetHttpsProxies().Insert(ctx, key, &ga.TargetHttpsProxy{Name: name, UrlMap: "urlMap", SslCertificates: []string{"sslCertificates"}}); err != nil {
+			t.Errorf("TargetHttpsProxies().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.TargetHttpsProxies().Get(ctx, key); err != nil {
+			t.Errorf("TargetHttpsProxies().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.TargetHttpsProxies().Delete(ctx, key); err != nil {
+			t.Errorf("TargetHttpsProxies().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.TargetHttpsProxies().Get(ctx, key); err == nil {
+			t.Errorf("TargetHttpsProxies().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzTargetPools(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewTargetPoolKey(name, location)
+		if location == "" {
+			// The key is not regional without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.TargetPoolKeyFrom(key); err != nil || got != k {
+			t.Errorf("TargetPoolKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// TargetPools.
+		if err := mock.TargetPools().Insert(ctx, key, &ga.TargetPool{Name: name}); err != nil {
+			t.Errorf("TargetPools().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.TargetPools().Get(ctx, key); err != nil {
+			t.Errorf("TargetPools().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.TargetPools().Delete(ctx, key); err != nil {
+			t.Errorf("TargetPools().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.TargetPools().Get(ctx, key); err == nil {
+			t.Errorf("TargetPools().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzUrlMaps(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewUrlMapKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.UrlMapKeyFrom(key); err != nil || got != k {
+			t.Errorf("UrlMapKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// UrlMaps.
+		if err := mock.UrlMaps().Insert(ctx, key, &ga.UrlMap{Name: name}); err != nil {
+			t.Errorf("UrlMaps().Insert(_, %v, _) = %v; want nil", key, err)
+		}
+		if _, err := mock.UrlMaps().Get(ctx, key); err != nil {
+			t.Errorf("UrlMaps().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.UrlMaps().Delete(ctx, key); err != nil {
+			t.Errorf("UrlMaps().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.UrlMaps().Get(ctx, key); err == nil {
+			t.Errorf("UrlMaps().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzZoneOperations(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewZoneOperationKey(name, location)
+		if location == "" {
+			// The key is not zonal without a location.
+			return
+		}
+		key := k.Key()
+		if got, err := cloud.ZoneOperationKeyFrom(key); err != nil || got != k {
+			t.Errorf("ZoneOperationKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// ZoneOperations.
+		mock.MockZoneOperations.Objects[key] = newMockZoneOperationsObj(&ga.Operation{Name: name})
+		if _, err := mock.ZoneOperations().Get(ctx, key); err != nil {
+			t.Errorf("ZoneOperations().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		if err := mock.ZoneOperations().Delete(ctx, key); err != nil {
+			t.Errorf("ZoneOperations().Delete(_, %v) = %v; want nil", key, err)
+		}
+		if _, err := mock.ZoneOperations().Get(ctx, key); err == nil {
+			t.Errorf("ZoneOperations().Get(_, %v) = _, nil; want error", key)
+		}
+	})
+}
+
+func FuzzZones(f *testing.F) {
+	f.Add("name", "location")
+	f.Add("", "")
+	f.Add("a/b", "us-central1-b")
+	f.Fuzz(func(t *testing.T, name, location string) {
+		k := cloud.NewZoneKey(name)
+		_ = location
+		key := k.Key()
+		if got, err := cloud.ZoneKeyFrom(key); err != nil || got != k {
+			t.Errorf("ZoneKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+		}
+
+		ctx := context.Background()
+		mock := NewMockGCE()
+		// Ignore unused variables.
+		_, _ = ctx, mock
+
+		// Zones.
+		mock.MockZones.Objects[key] = newMockZonesObj(&ga.Zone{Name: name})
+		if _, err := mock.Zones().Get(ctx, key); err != nil {
+			t.Errorf("Zones().Get(_, %v) = _, %v; want _, nil", key, err)
+		}
+		delete(mock.MockZones.Objects, key)
+	})
+}
@@ -0,0 +1,4640 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode test > mock/gen_test.go".
+// Do not edit directly.
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+const location = "location"
+
+// errInjected is the error injected into the mocks by the generated tests.
+var errInjected = errors.New("injected error")
+
+// Compile-time checks that the adapters and mocks implement the interfaces.
+var (
+	_ cloud.Addresses                  = (*cloud.GCEAddresses)(nil)
+	_ cloud.Addresses                  = (*MockAddresses)(nil)
+	_ cloud.AlphaAddresses             = (*cloud.GCEAlphaAddresses)(nil)
+	_ cloud.AlphaAddresses             = (*MockAlphaAddresses)(nil)
+	_ cloud.BetaAddresses              = (*cloud.GCEBetaAddresses)(nil)
+	_ cloud.BetaAddresses              = (*MockBetaAddresses)(nil)
+	_ cloud.GlobalAddresses            = (*cloud.GCEGlobalAddresses)(nil)
+	_ cloud.GlobalAddresses            = (*MockGlobalAddresses)(nil)
+	_ cloud.BackendServices            = (*cloud.GCEBackendServices)(nil)
+	_ cloud.BackendServices            = (*MockBackendServices)(nil)
+	_ cloud.AlphaBackendServices       = (*cloud.GCEAlphaBackendServices)(nil)
+	_ cloud.AlphaBackendServices       = (*MockAlphaBackendServices)(nil)
+	_ cloud.AlphaRegionBackendServices = (*cloud.GCEAlphaRegionBackendServices)(nil)
+	_ cloud.AlphaRegionBackendServices = (*MockAlphaRegionBackendServices)(nil)
+	_ cloud.Disks                      = (*cloud.GCEDisks)(nil)
+	_ cloud.Disks                      = (*MockDisks)(nil)
+	_ cloud.AlphaDisks                 = (*cloud.GCEAlphaDisks)(nil)
+	_ cloud.AlphaDisks                 = (*MockAlphaDisks)(nil)
+	_ cloud.AlphaRegionDisks           = (*cloud.GCEAlphaRegionDisks)(nil)
+	_ cloud.AlphaRegionDisks           = (*MockAlphaRegionDisks)(nil)
+	_ cloud.DiskTypes                  = (*cloud.GCEDiskTypes)(nil)
+	_ cloud.DiskTypes                  = (*MockDiskTypes)(nil)
+	_ cloud.Firewalls                  = (*cloud.GCEFirewalls)(nil)
+	_ cloud.Firewalls                  = (*MockFirewalls)(nil)
+	_ cloud.ForwardingRules            = (*cloud.GCEForwardingRules)(nil)
+	_ cloud.ForwardingRules            = (*MockForwardingRules)(nil)
+	_ cloud.AlphaForwardingRules       = (*cloud.GCEAlphaForwardingRules)(nil)
+	_ cloud.AlphaForwardingRules       = (*MockAlphaForwardingRules)(nil)
+	_ cloud.GlobalForwardingRules      = (*cloud.GCEGlobalForwardingRules)(nil)
+	_ cloud.GlobalForwardingRules      = (*MockGlobalForwardingRules)(nil)
+	_ cloud.HealthChecks               = (*cloud.GCEHealthChecks)(nil)
+	_ cloud.HealthChecks               = (*MockHealthChecks)(nil)
+	_ cloud.AlphaHealthChecks          = (*cloud.GCEAlphaHealthChecks)(nil)
+	_ cloud.AlphaHealthChecks          = (*MockAlphaHealthChecks)(nil)
+	_ cloud.HttpHealthChecks           = (*cloud.GCEHttpHealthChecks)(nil)
+	_ cloud.HttpHealthChecks           = (*MockHttpHealthChecks)(nil)
+	_ cloud.HttpsHealthChecks          = (*cloud.GCEHttpsHealthChecks)(nil)
+	_ cloud.HttpsHealthChecks          = (*MockHttpsHealthChecks)(nil)
+	_ cloud.InstanceGroups             = (*cloud.GCEInstanceGroups)(nil)
+	_ cloud.InstanceGroups             = (*MockInstanceGroups)(nil)
+	_ cloud.Instances                  = (*cloud.GCEInstances)(nil)
+	_ cloud.Instances                  = (*MockInstances)(nil)
+	_ cloud.BetaInstances              = (*cloud.GCEBetaInstances)(nil)
+	_ cloud.BetaInstances              = (*MockBetaInstances)(nil)
+	_ cloud.AlphaInstances             = (*cloud.GCEAlphaInstances)(nil)
+	_ cloud.AlphaInstances             = (*MockAlphaInstances)(nil)
+	_ cloud.MachineTypes               = (*cloud.GCEMachineTypes)(nil)
+	_ cloud.MachineTypes               = (*MockMachineTypes)(nil)
+	_ cloud.AlphaNetworkEndpointGroups = (*cloud.GCEAlphaNetworkEndpointGroups)(nil)
+	_ cloud.AlphaNetworkEndpointGroups = (*MockAlphaNetworkEndpointGroups)(nil)
+	_ cloud.GlobalOperations           = (*cloud.GCEGlobalOperations)(nil)
+	_ cloud.GlobalOperations           = (*MockGlobalOperations)(nil)
+	_ cloud.RegionOperations           = (*cloud.GCERegionOperations)(nil)
+	_ cloud.RegionOperations           = (*MockRegionOperations)(nil)
+	_ cloud.ZoneOperations             = (*cloud.GCEZoneOperations)(nil)
+	_ cloud.ZoneOperations             = (*MockZoneOperations)(nil)
+	_ cloud.Projects                   = (*cloud.GCEProjects)(nil)
+	_ cloud.Projects                   = (*MockProjects)(nil)
+	_ cloud.Regions                    = (*cloud.GCERegions)(nil)
+	_ cloud.Regions                    = (*MockRegions)(nil)
+	_ cloud.Routes                     = (*cloud.GCERoutes)(nil)
+	_ cloud.Routes                     = (*MockRoutes)(nil)
+	_ cloud.SslCertificates            = (*cloud.GCESslCertificates)(nil)
+	_ cloud.SslCertificates            = (*MockSslCertificates)(nil)
+	_ cloud.TargetHttpProxies          = (*cloud.GCETargetHttpProxies)(nil)
+	_ cloud.TargetHttpProxies          = (*MockTargetHttpProxies)(nil)
+	_ cloud.TargetHttpsProxies         = (*cloud.GCETargetHttpsProxies)(nil)
+	_ cloud.TargetHttpsProxies         = (*MockTargetHttpsProxies)(nil)
+	_ cloud.TargetPools                = (*cloud.GCETargetPools)(nil)
+	_ cloud.TargetPools                = (*MockTargetPools)(nil)
+	_ cloud.UrlMaps                    = (*cloud.GCEUrlMaps)(nil)
+	_ cloud.UrlMaps                    = (*MockUrlMaps)(nil)
+	_ cloud.Zones                      = (*cloud.GCEZones)(nil)
+	_ cloud.Zones                      = (*MockZones)(nil)
+)
+
+func TestAddressesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.RegionalKey("key-alpha", "location")
+	keyBeta := *meta.RegionalKey("key-beta", "location")
+	keyGA := *meta.RegionalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaAddresses().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaAddresses().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaAddresses().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+	if _, err := mock.BetaAddresses().Get(ctx, keyBeta); err == nil {
+		t.Errorf("BetaAddresses().Get(%v, %v) = _, nil; want error", ctx, keyBeta)
+	}
+	if ok, err := mock.BetaAddresses().Exists(ctx, keyBeta); ok || err != nil {
+		t.Errorf("BetaAddresses().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyBeta, ok, err)
+	}
+	if _, err := mock.Addresses().Get(ctx, keyGA); err == nil {
+		t.Errorf("Addresses().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Addresses().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Addresses().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaAddresses.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaAddresses().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaAddresses.GetError, keyAlpha)
+	mock.MockAlphaAddresses.ListError = &errInjected
+	if _, err := mock.AlphaAddresses().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaAddresses().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaAddresses.ListError = nil
+	mock.MockAlphaAddresses.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaAddresses().Insert(ctx, keyAlpha, &alpha.Address{}); err != errInjected {
+		t.Errorf("AlphaAddresses().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaAddresses.InsertError, keyAlpha)
+	mock.MockAlphaAddresses.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaAddresses().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaAddresses().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaAddresses.DeleteError, keyAlpha)
+	mock.MockAlphaAddresses.AggregatedListError = &errInjected
+	if _, err := mock.AlphaAddresses().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaAddresses().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaAddresses.AggregatedListError = nil
+	mock.MockBetaAddresses.GetError[keyBeta] = errInjected
+	if _, err := mock.BetaAddresses().Get(ctx, keyBeta); err != errInjected {
+		t.Errorf("BetaAddresses().Get(%v, %v) = _, %v; want %v", ctx, keyBeta, err, errInjected)
+	}
+	delete(mock.MockBetaAddresses.GetError, keyBeta)
+	mock.MockBetaAddresses.ListError = &errInjected
+	if _, err := mock.BetaAddresses().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("BetaAddresses().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockBetaAddresses.ListError = nil
+	mock.MockBetaAddresses.InsertError[keyBeta] = errInjected
+	if err := mock.BetaAddresses().Insert(ctx, keyBeta, &beta.Address{}); err != errInjected {
+		t.Errorf("BetaAddresses().Insert(%v, %v, _) = %v; want %v", ctx, keyBeta, err, errInjected)
+	}
+	delete(mock.MockBetaAddresses.InsertError, keyBeta)
+	mock.MockBetaAddresses.DeleteError[keyBeta] = errInjected
+	if err := mock.BetaAddresses().Delete(ctx, keyBeta); err != errInjected {
+		t.Errorf("BetaAddresses().Delete(%v, %v) = %v; want %v", ctx, keyBeta, err, errInjected)
+	}
+	delete(mock.MockBetaAddresses.DeleteError, keyBeta)
+	mock.MockBetaAddresses.AggregatedListError = &errInjected
+	if _, err := mock.BetaAddresses().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("BetaAddresses().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockBetaAddresses.AggregatedListError = nil
+	mock.MockAddresses.GetError[keyGA] = errInjected
+	if _, err := mock.Addresses().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Addresses().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockAddresses.GetError, keyGA)
+	mock.MockAddresses.ListError = &errInjected
+	if _, err := mock.Addresses().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("Addresses().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAddresses.ListError = nil
+	mock.MockAddresses.InsertError[keyGA] = errInjected
+	if err := mock.Addresses().Insert(ctx, keyGA, &ga.Address{}); err != errInjected {
+		t.Errorf("Addresses().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockAddresses.InsertError, keyGA)
+	mock.MockAddresses.DeleteError[keyGA] = errInjected
+	if err := mock.Addresses().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("Addresses().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockAddresses.DeleteError, keyGA)
+	mock.MockAddresses.AggregatedListError = &errInjected
+	if _, err := mock.Addresses().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("Addresses().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAddresses.AggregatedListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaAddresses().Insert(ctx, keyAlpha, &alpha.Address{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaAddresses().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaAddresses().Insert(ctx, keyAlpha, &alpha.Address{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaAddresses().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaAddresses().Insert(ctx, keyBeta, &beta.Address{Name: keyBeta.Name}); err != nil {
+		t.Errorf("BetaAddresses().Insert(%v, %v, _) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.BetaAddresses().Insert(ctx, keyBeta, &beta.Address{Name: keyBeta.Name}); err == nil {
+		t.Errorf("BetaAddresses().Insert(%v, %v, _) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.Addresses().Insert(ctx, keyGA, &ga.Address{Name: keyGA.Name}); err != nil {
+		t.Errorf("Addresses().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.Addresses().Insert(ctx, keyGA, &ga.Address{Name: keyGA.Name}); err == nil {
+		t.Errorf("Addresses().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// UpdateLabels.
+	if err := mock.AlphaAddresses().UpdateLabels(ctx, keyAlpha, map[string]string{"version": "alpha"}); err != nil {
+		t.Errorf("AlphaAddresses().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaAddresses().Get(ctx, keyAlpha); err != nil || obj.Labels["version"] != "alpha" || obj.LabelFingerprint == "" {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyAlpha, obj, err, "alpha")
+	}
+	if err := mock.BetaAddresses().UpdateLabels(ctx, keyBeta, map[string]string{"version": "beta"}); err != nil {
+		t.Errorf("BetaAddresses().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyBeta, err)
+	}
+	if obj, err := mock.BetaAddresses().Get(ctx, keyBeta); err != nil || obj.Labels["version"] != "beta" || obj.LabelFingerprint == "" {
+		t.Errorf("BetaAddresses().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyBeta, obj, err, "beta")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaAddresses().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaAddresses().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaAddresses().GetOrCreate(ctx, keyAlpha, &alpha.Address{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaAddresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.AlphaAddresses().GetOrCreate(ctx, key, &alpha.Address{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaAddresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaAddresses.Objects, key)
+	}
+	if ok, err := mock.BetaAddresses().Exists(ctx, keyBeta); !ok || err != nil {
+		t.Errorf("BetaAddresses().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyBeta, ok, err)
+	}
+	if obj, err := mock.BetaAddresses().GetOrCreate(ctx, keyBeta, &beta.Address{Name: "other"}); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("BetaAddresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.BetaAddresses().GetOrCreate(ctx, key, &beta.Address{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("BetaAddresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockBetaAddresses.Objects, key)
+	}
+	if ok, err := mock.Addresses().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Addresses().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.Addresses().GetOrCreate(ctx, keyGA, &ga.Address{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Addresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.Addresses().GetOrCreate(ctx, key, &ga.Address{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("Addresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAddresses.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaAddresses().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.AlphaAddresses().Get(ctx, keyBeta); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	if obj, err := mock.AlphaAddresses().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.BetaAddresses().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("BetaAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.BetaAddresses().Get(ctx, keyBeta); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("BetaAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	if obj, err := mock.BetaAddresses().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("BetaAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.Addresses().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("Addresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.Addresses().Get(ctx, keyBeta); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("Addresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	if obj, err := mock.Addresses().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Addresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+		keyBeta.Name:  true,
+		keyGA.Name:    true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaAddresses().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaAddresses().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaAddresses().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaAddresses().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("BetaAddresses().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaAddresses().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.Addresses().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("Addresses().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Addresses().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.AlphaAddresses().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaAddresses().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("AlphaAddresses().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaAddresses().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.BetaAddresses().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaAddresses().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("BetaAddresses().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaAddresses().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.Addresses().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Addresses().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("Addresses().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Addresses().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaAddresses().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaAddresses().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaAddresses().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaAddresses().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaAddresses().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaAddresses().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaAddresses().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if _, err := mock.BetaAddresses().Get(ctx, keyBeta); err == nil {
+		t.Errorf("BetaAddresses().Get(%v, %v) = _, nil; want error", ctx, keyBeta)
+	}
+	if err := mock.BetaAddresses().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaAddresses().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.Addresses().Delete(ctx, keyGA); err != nil {
+		t.Errorf("Addresses().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.Addresses().Get(ctx, keyGA); err == nil {
+		t.Errorf("Addresses().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.Addresses().Delete(ctx, keyGA); err == nil {
+		t.Errorf("Addresses().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestAddressKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.RegionalKey("key", "location")
+	k := cloud.NewAddressKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.AddressKeyFrom(key); err != nil || got != k {
+		t.Errorf("AddressKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.AddressKeyFrom(wrongKey); err == nil {
+		t.Errorf("AddressKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestBackendServicesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.GlobalKey("key-alpha")
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaBackendServices().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaBackendServices().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaBackendServices().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+	if _, err := mock.BackendServices().Get(ctx, keyGA); err == nil {
+		t.Errorf("BackendServices().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.BackendServices().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("BackendServices().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaBackendServices.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaBackendServices().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaBackendServices.GetError, keyAlpha)
+	mock.MockAlphaBackendServices.ListError = &errInjected
+	if _, err := mock.AlphaBackendServices().List(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaBackendServices().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaBackendServices.ListError = nil
+	mock.MockAlphaBackendServices.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaBackendServices().Insert(ctx, keyAlpha, &alpha.BackendService{}); err != errInjected {
+		t.Errorf("AlphaBackendServices().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaBackendServices.InsertError, keyAlpha)
+	mock.MockAlphaBackendServices.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaBackendServices().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaBackendServices().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaBackendServices.DeleteError, keyAlpha)
+	mock.MockAlphaBackendServices.UpdateError[keyAlpha] = errInjected
+	if err := mock.AlphaBackendServices().Update(ctx, keyAlpha, &alpha.BackendService{}); err != errInjected {
+		t.Errorf("AlphaBackendServices().Update(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaBackendServices.UpdateError, keyAlpha)
+	mock.MockAlphaBackendServices.PatchError[keyAlpha] = errInjected
+	if err := mock.AlphaBackendServices().Patch(ctx, keyAlpha, &alpha.BackendService{}); err != errInjected {
+		t.Errorf("AlphaBackendServices().Patch(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaBackendServices.PatchError, keyAlpha)
+	mock.MockBackendServices.GetError[keyGA] = errInjected
+	if _, err := mock.BackendServices().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("BackendServices().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockBackendServices.GetError, keyGA)
+	mock.MockBackendServices.ListError = &errInjected
+	if _, err := mock.BackendServices().List(ctx, filter.None); err != errInjected {
+		t.Errorf("BackendServices().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockBackendServices.ListError = nil
+	mock.MockBackendServices.InsertError[keyGA] = errInjected
+	if err := mock.BackendServices().Insert(ctx, keyGA, &ga.BackendService{}); err != errInjected {
+		t.Errorf("BackendServices().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockBackendServices.InsertError, keyGA)
+	mock.MockBackendServices.DeleteError[keyGA] = errInjected
+	if err := mock.BackendServices().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("BackendServices().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockBackendServices.DeleteError, keyGA)
+	mock.MockBackendServices.UpdateError[keyGA] = errInjected
+	if err := mock.BackendServices().Update(ctx, keyGA, &ga.BackendService{}); err != errInjected {
+		t.Errorf("BackendServices().Update(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockBackendServices.UpdateError, keyGA)
+	mock.MockBackendServices.PatchError[keyGA] = errInjected
+	if err := mock.BackendServices().Patch(ctx, keyGA, &ga.BackendService{}); err != errInjected {
+		t.Errorf("BackendServices().Patch(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockBackendServices.PatchError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaBackendServices().Insert(ctx, keyAlpha, &alpha.BackendService{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaBackendServices().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaBackendServices().Insert(ctx, keyAlpha, &alpha.BackendService{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaBackendServices().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BackendServices().Insert(ctx, keyGA, &ga.BackendService{Name: keyGA.Name}); err != nil {
+		t.Errorf("BackendServices().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.BackendServices().Insert(ctx, keyGA, &ga.BackendService{Name: keyGA.Name}); err == nil {
+		t.Errorf("BackendServices().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Update and Patch.
+	if err := mock.AlphaBackendServices().Update(ctx, *meta.GlobalKey("key-missing"), &alpha.BackendService{}); err == nil {
+		t.Errorf("AlphaBackendServices().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.AlphaBackendServices().Update(ctx, keyAlpha, &alpha.BackendService{Name: keyAlpha.Name, Description: "updated"}); err != nil {
+		t.Errorf("AlphaBackendServices().Update(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaBackendServices().Get(ctx, keyAlpha); err != nil || obj.Description != "updated" {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyAlpha, obj, err, "updated")
+	}
+	if err := mock.AlphaBackendServices().Patch(ctx, *meta.GlobalKey("key-missing"), &alpha.BackendService{}); err == nil {
+		t.Errorf("AlphaBackendServices().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.AlphaBackendServices().Patch(ctx, keyAlpha, &alpha.BackendService{Description: "patched"}); err != nil {
+		t.Errorf("AlphaBackendServices().Patch(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaBackendServices().Get(ctx, keyAlpha); err != nil || obj.Description != "patched" || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name, "patched")
+	}
+	if err := mock.BackendServices().Update(ctx, *meta.GlobalKey("key-missing"), &ga.BackendService{}); err == nil {
+		t.Errorf("BackendServices().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.BackendServices().Update(ctx, keyGA, &ga.BackendService{Name: keyGA.Name, Description: "updated"}); err != nil {
+		t.Errorf("BackendServices().Update(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.BackendServices().Get(ctx, keyGA); err != nil || obj.Description != "updated" {
+		t.Errorf("BackendServices().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyGA, obj, err, "updated")
+	}
+	if err := mock.BackendServices().Patch(ctx, *meta.GlobalKey("key-missing"), &ga.BackendService{}); err == nil {
+		t.Errorf("BackendServices().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.BackendServices().Patch(ctx, keyGA, &ga.BackendService{Description: "patched"}); err != nil {
+		t.Errorf("BackendServices().Patch(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.BackendServices().Get(ctx, keyGA); err != nil || obj.Description != "patched" || obj.Name != keyGA.Name {
+		t.Errorf("BackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyGA, obj, err, keyGA.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaBackendServices().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaBackendServices().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaBackendServices().GetOrCreate(ctx, keyAlpha, &alpha.BackendService{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaBackendServices().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.AlphaBackendServices().GetOrCreate(ctx, key, &alpha.BackendService{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaBackendServices().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaBackendServices.Objects, key)
+	}
+	if ok, err := mock.BackendServices().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("BackendServices().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.BackendServices().GetOrCreate(ctx, keyGA, &ga.BackendService{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("BackendServices().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.BackendServices().GetOrCreate(ctx, key, &ga.BackendService{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("BackendServices().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockBackendServices.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaBackendServices().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.AlphaBackendServices().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.BackendServices().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("BackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.BackendServices().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("BackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+		keyGA.Name:    true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaBackendServices().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaBackendServices().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaBackendServices().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BackendServices().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BackendServices().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BackendServices().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaBackendServices().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaBackendServices().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaBackendServices().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaBackendServices().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaBackendServices().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaBackendServices().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BackendServices().Delete(ctx, keyGA); err != nil {
+		t.Errorf("BackendServices().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.BackendServices().Get(ctx, keyGA); err == nil {
+		t.Errorf("BackendServices().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.BackendServices().Delete(ctx, keyGA); err == nil {
+		t.Errorf("BackendServices().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestBackendServiceKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewBackendServiceKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.BackendServiceKeyFrom(key); err != nil || got != k {
+		t.Errorf("BackendServiceKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.BackendServiceKeyFrom(wrongKey); err == nil {
+		t.Errorf("BackendServiceKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestDiskTypesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.ZonalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// DiskTypes is read-only.
+	if _, ok := mock.DiskTypes().(interface {
+		Insert(context.Context, meta.Key, *ga.DiskType) error
+	}); ok {
+		t.Errorf("DiskTypes() has Insert(); want read-only")
+	}
+	if _, ok := mock.DiskTypes().(interface {
+		Delete(context.Context, meta.Key) error
+	}); ok {
+		t.Errorf("DiskTypes() has Delete(); want read-only")
+	}
+
+	// Get not found.
+	if _, err := mock.DiskTypes().Get(ctx, keyGA); err == nil {
+		t.Errorf("DiskTypes().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.DiskTypes().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("DiskTypes().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockDiskTypes.GetError[keyGA] = errInjected
+	if _, err := mock.DiskTypes().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("DiskTypes().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockDiskTypes.GetError, keyGA)
+	mock.MockDiskTypes.ListError = &errInjected
+	if _, err := mock.DiskTypes().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("DiskTypes().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockDiskTypes.ListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockDiskTypes.Objects[keyGA] = newMockDiskTypesObj(&ga.DiskType{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.DiskTypes().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("DiskTypes().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.DiskTypes().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("DiskTypes().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.DiskTypes().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("DiskTypes().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("DiskTypes().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+}
+
+func TestDiskTypeKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewDiskTypeKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.DiskTypeKeyFrom(key); err != nil || got != k {
+		t.Errorf("DiskTypeKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.DiskTypeKeyFrom(wrongKey); err == nil {
+		t.Errorf("DiskTypeKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestDisksGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.ZonalKey("key-alpha", "location")
+	keyGA := *meta.ZonalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaDisks().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaDisks().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaDisks().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaDisks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+	if _, err := mock.Disks().Get(ctx, keyGA); err == nil {
+		t.Errorf("Disks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Disks().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Disks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaDisks.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaDisks().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaDisks().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaDisks.GetError, keyAlpha)
+	mock.MockAlphaDisks.ListError = &errInjected
+	if _, err := mock.AlphaDisks().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaDisks().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaDisks.ListError = nil
+	mock.MockAlphaDisks.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaDisks().Insert(ctx, keyAlpha, &alpha.Disk{}); err != errInjected {
+		t.Errorf("AlphaDisks().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaDisks.InsertError, keyAlpha)
+	mock.MockAlphaDisks.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaDisks().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaDisks().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaDisks.DeleteError, keyAlpha)
+	mock.MockAlphaDisks.AggregatedListError = &errInjected
+	if _, err := mock.AlphaDisks().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaDisks().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaDisks.AggregatedListError = nil
+	mock.MockDisks.GetError[keyGA] = errInjected
+	if _, err := mock.Disks().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Disks().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockDisks.GetError, keyGA)
+	mock.MockDisks.ListError = &errInjected
+	if _, err := mock.Disks().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("Disks().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockDisks.ListError = nil
+	mock.MockDisks.InsertError[keyGA] = errInjected
+	if err := mock.Disks().Insert(ctx, keyGA, &ga.Disk{}); err != errInjected {
+		t.Errorf("Disks().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockDisks.InsertError, keyGA)
+	mock.MockDisks.DeleteError[keyGA] = errInjected
+	if err := mock.Disks().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("Disks().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockDisks.DeleteError, keyGA)
+	mock.MockDisks.AggregatedListError = &errInjected
+	if _, err := mock.Disks().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("Disks().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockDisks.AggregatedListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaDisks().Insert(ctx, keyAlpha, &alpha.Disk{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaDisks().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaDisks().Insert(ctx, keyAlpha, &alpha.Disk{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaDisks().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.Disks().Insert(ctx, keyGA, &ga.Disk{Name: keyGA.Name}); err != nil {
+		t.Errorf("Disks().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.Disks().Insert(ctx, keyGA, &ga.Disk{Name: keyGA.Name}); err == nil {
+		t.Errorf("Disks().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// UpdateLabels.
+	if err := mock.AlphaDisks().UpdateLabels(ctx, keyAlpha, map[string]string{"version": "alpha"}); err != nil {
+		t.Errorf("AlphaDisks().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaDisks().Get(ctx, keyAlpha); err != nil || obj.Labels["version"] != "alpha" || obj.LabelFingerprint == "" {
+		t.Errorf("AlphaDisks().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyAlpha, obj, err, "alpha")
+	}
+	if err := mock.Disks().UpdateLabels(ctx, keyGA, map[string]string{"version": "ga"}); err != nil {
+		t.Errorf("Disks().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.Disks().Get(ctx, keyGA); err != nil || obj.Labels["version"] != "ga" || obj.LabelFingerprint == "" {
+		t.Errorf("Disks().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyGA, obj, err, "ga")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaDisks().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaDisks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaDisks().GetOrCreate(ctx, keyAlpha, &alpha.Disk{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaDisks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.AlphaDisks().GetOrCreate(ctx, key, &alpha.Disk{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaDisks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaDisks.Objects, key)
+	}
+	if ok, err := mock.Disks().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Disks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.Disks().GetOrCreate(ctx, keyGA, &ga.Disk{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Disks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.Disks().GetOrCreate(ctx, key, &ga.Disk{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("Disks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockDisks.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaDisks().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaDisks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.AlphaDisks().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("AlphaDisks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.Disks().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("Disks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.Disks().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Disks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+		keyGA.Name:    true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaDisks().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaDisks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaDisks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.Disks().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("Disks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Disks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.AlphaDisks().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaDisks().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("AlphaDisks().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaDisks().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.Disks().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Disks().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("Disks().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Disks().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaDisks().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaDisks().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaDisks().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaDisks().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaDisks().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaDisks().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.Disks().Delete(ctx, keyGA); err != nil {
+		t.Errorf("Disks().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.Disks().Get(ctx, keyGA); err == nil {
+		t.Errorf("Disks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.Disks().Delete(ctx, keyGA); err == nil {
+		t.Errorf("Disks().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestDiskKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewDiskKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.DiskKeyFrom(key); err != nil || got != k {
+		t.Errorf("DiskKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.DiskKeyFrom(wrongKey); err == nil {
+		t.Errorf("DiskKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestFirewallsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.Firewalls().Get(ctx, keyGA); err == nil {
+		t.Errorf("Firewalls().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Firewalls().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Firewalls().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockFirewalls.GetError[keyGA] = errInjected
+	if _, err := mock.Firewalls().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Firewalls().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockFirewalls.GetError, keyGA)
+	mock.MockFirewalls.ListError = &errInjected
+	if _, err := mock.Firewalls().List(ctx, filter.None); err != errInjected {
+		t.Errorf("Firewalls().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockFirewalls.ListError = nil
+	mock.MockFirewalls.InsertError[keyGA] = errInjected
+	if err := mock.Firewalls().Insert(ctx, keyGA, &ga.Firewall{}); err != errInjected {
+		t.Errorf("Firewalls().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockFirewalls.InsertError, keyGA)
+	mock.MockFirewalls.DeleteError[keyGA] = errInjected
+	if err := mock.Firewalls().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("Firewalls().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockFirewalls.DeleteError, keyGA)
+	mock.MockFirewalls.UpdateError[keyGA] = errInjected
+	if err := mock.Firewalls().Update(ctx, keyGA, &ga.Firewall{}); err != errInjected {
+		t.Errorf("Firewalls().Update(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockFirewalls.UpdateError, keyGA)
+	mock.MockFirewalls.PatchError[keyGA] = errInjected
+	if err := mock.Firewalls().Patch(ctx, keyGA, &ga.Firewall{}); err != errInjected {
+		t.Errorf("Firewalls().Patch(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockFirewalls.PatchError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.Firewalls().Insert(ctx, keyGA, &ga.Firewall{Name: keyGA.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("Firewalls().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyGA, err)
+	}
+	if err := mock.Firewalls().Insert(ctx, keyGA, &ga.Firewall{Name: keyGA.Name, Network: "network"}); err != nil {
+		t.Errorf("Firewalls().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.Firewalls().Insert(ctx, keyGA, &ga.Firewall{Name: keyGA.Name, Network: "network"}); err == nil {
+		t.Errorf("Firewalls().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Update and Patch.
+	if err := mock.Firewalls().Update(ctx, *meta.GlobalKey("key-missing"), &ga.Firewall{}); err == nil {
+		t.Errorf("Firewalls().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.Firewalls().Update(ctx, keyGA, &ga.Firewall{Name: keyGA.Name, Description: "updated"}); err != nil {
+		t.Errorf("Firewalls().Update(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.Firewalls().Get(ctx, keyGA); err != nil || obj.Description != "updated" {
+		t.Errorf("Firewalls().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyGA, obj, err, "updated")
+	}
+	if err := mock.Firewalls().Patch(ctx, *meta.GlobalKey("key-missing"), &ga.Firewall{}); err == nil {
+		t.Errorf("Firewalls().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.Firewalls().Patch(ctx, keyGA, &ga.Firewall{Description: "patched"}); err != nil {
+		t.Errorf("Firewalls().Patch(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.Firewalls().Get(ctx, keyGA); err != nil || obj.Description != "patched" || obj.Name != keyGA.Name {
+		t.Errorf("Firewalls().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyGA, obj, err, keyGA.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.Firewalls().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Firewalls().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.Firewalls().GetOrCreate(ctx, keyGA, &ga.Firewall{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Firewalls().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.Firewalls().GetOrCreate(ctx, key, &ga.Firewall{Name: key.Name, Network: "network"}); err != nil || obj.Name != key.Name {
+			t.Errorf("Firewalls().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockFirewalls.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.Firewalls().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Firewalls().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.Firewalls().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Firewalls().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Firewalls().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.Firewalls().Delete(ctx, keyGA); err != nil {
+		t.Errorf("Firewalls().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.Firewalls().Get(ctx, keyGA); err == nil {
+		t.Errorf("Firewalls().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.Firewalls().Delete(ctx, keyGA); err == nil {
+		t.Errorf("Firewalls().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestFirewallKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewFirewallKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.FirewallKeyFrom(key); err != nil || got != k {
+		t.Errorf("FirewallKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.FirewallKeyFrom(wrongKey); err == nil {
+		t.Errorf("FirewallKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestForwardingRulesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.RegionalKey("key-alpha", "location")
+	keyGA := *meta.RegionalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaForwardingRules().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaForwardingRules().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaForwardingRules().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaForwardingRules().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+	if _, err := mock.ForwardingRules().Get(ctx, keyGA); err == nil {
+		t.Errorf("ForwardingRules().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.ForwardingRules().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("ForwardingRules().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaForwardingRules.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaForwardingRules().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaForwardingRules().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaForwardingRules.GetError, keyAlpha)
+	mock.MockAlphaForwardingRules.ListError = &errInjected
+	if _, err := mock.AlphaForwardingRules().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaForwardingRules().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaForwardingRules.ListError = nil
+	mock.MockAlphaForwardingRules.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaForwardingRules().Insert(ctx, keyAlpha, &alpha.ForwardingRule{}); err != errInjected {
+		t.Errorf("AlphaForwardingRules().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaForwardingRules.InsertError, keyAlpha)
+	mock.MockAlphaForwardingRules.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaForwardingRules().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaForwardingRules().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaForwardingRules.DeleteError, keyAlpha)
+	mock.MockAlphaForwardingRules.AggregatedListError = &errInjected
+	if _, err := mock.AlphaForwardingRules().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaForwardingRules().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaForwardingRules.AggregatedListError = nil
+	mock.MockForwardingRules.GetError[keyGA] = errInjected
+	if _, err := mock.ForwardingRules().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("ForwardingRules().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockForwardingRules.GetError, keyGA)
+	mock.MockForwardingRules.ListError = &errInjected
+	if _, err := mock.ForwardingRules().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("ForwardingRules().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockForwardingRules.ListError = nil
+	mock.MockForwardingRules.InsertError[keyGA] = errInjected
+	if err := mock.ForwardingRules().Insert(ctx, keyGA, &ga.ForwardingRule{}); err != errInjected {
+		t.Errorf("ForwardingRules().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockForwardingRules.InsertError, keyGA)
+	mock.MockForwardingRules.DeleteError[keyGA] = errInjected
+	if err := mock.ForwardingRules().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("ForwardingRules().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockForwardingRules.DeleteError, keyGA)
+	mock.MockForwardingRules.AggregatedListError = &errInjected
+	if _, err := mock.ForwardingRules().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("ForwardingRules().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockForwardingRules.AggregatedListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaForwardingRules().Insert(ctx, keyAlpha, &alpha.ForwardingRule{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaForwardingRules().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaForwardingRules().Insert(ctx, keyAlpha, &alpha.ForwardingRule{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaForwardingRules().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.ForwardingRules().Insert(ctx, keyGA, &ga.ForwardingRule{Name: keyGA.Name}); err != nil {
+		t.Errorf("ForwardingRules().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.ForwardingRules().Insert(ctx, keyGA, &ga.ForwardingRule{Name: keyGA.Name}); err == nil {
+		t.Errorf("ForwardingRules().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// UpdateLabels.
+	if err := mock.AlphaForwardingRules().UpdateLabels(ctx, keyAlpha, map[string]string{"version": "alpha"}); err != nil {
+		t.Errorf("AlphaForwardingRules().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaForwardingRules().Get(ctx, keyAlpha); err != nil || obj.Labels["version"] != "alpha" || obj.LabelFingerprint == "" {
+		t.Errorf("AlphaForwardingRules().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyAlpha, obj, err, "alpha")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaForwardingRules().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaForwardingRules().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaForwardingRules().GetOrCreate(ctx, keyAlpha, &alpha.ForwardingRule{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaForwardingRules().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.AlphaForwardingRules().GetOrCreate(ctx, key, &alpha.ForwardingRule{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaForwardingRules().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaForwardingRules.Objects, key)
+	}
+	if ok, err := mock.ForwardingRules().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("ForwardingRules().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.ForwardingRules().GetOrCreate(ctx, keyGA, &ga.ForwardingRule{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("ForwardingRules().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.ForwardingRules().GetOrCreate(ctx, key, &ga.ForwardingRule{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("ForwardingRules().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockForwardingRules.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaForwardingRules().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaForwardingRules().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.AlphaForwardingRules().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("AlphaForwardingRules().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.ForwardingRules().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("ForwardingRules().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.ForwardingRules().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("ForwardingRules().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+		keyGA.Name:    true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaForwardingRules().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaForwardingRules().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaForwardingRules().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.ForwardingRules().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("ForwardingRules().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ForwardingRules().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.AlphaForwardingRules().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaForwardingRules().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("AlphaForwardingRules().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaForwardingRules().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.ForwardingRules().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("ForwardingRules().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("ForwardingRules().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ForwardingRules().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaForwardingRules().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaForwardingRules().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaForwardingRules().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaForwardingRules().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaForwardingRules().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaForwardingRules().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.ForwardingRules().Delete(ctx, keyGA); err != nil {
+		t.Errorf("ForwardingRules().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.ForwardingRules().Get(ctx, keyGA); err == nil {
+		t.Errorf("ForwardingRules().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.ForwardingRules().Delete(ctx, keyGA); err == nil {
+		t.Errorf("ForwardingRules().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestForwardingRuleKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.RegionalKey("key", "location")
+	k := cloud.NewForwardingRuleKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.ForwardingRuleKeyFrom(key); err != nil || got != k {
+		t.Errorf("ForwardingRuleKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.ForwardingRuleKeyFrom(wrongKey); err == nil {
+		t.Errorf("ForwardingRuleKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestGlobalAddressesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.GlobalAddresses().Get(ctx, keyGA); err == nil {
+		t.Errorf("GlobalAddresses().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.GlobalAddresses().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("GlobalAddresses().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockGlobalAddresses.GetError[keyGA] = errInjected
+	if _, err := mock.GlobalAddresses().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("GlobalAddresses().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalAddresses.GetError, keyGA)
+	mock.MockGlobalAddresses.ListError = &errInjected
+	if _, err := mock.GlobalAddresses().List(ctx, filter.None); err != errInjected {
+		t.Errorf("GlobalAddresses().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockGlobalAddresses.ListError = nil
+	mock.MockGlobalAddresses.InsertError[keyGA] = errInjected
+	if err := mock.GlobalAddresses().Insert(ctx, keyGA, &ga.Address{}); err != errInjected {
+		t.Errorf("GlobalAddresses().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalAddresses.InsertError, keyGA)
+	mock.MockGlobalAddresses.DeleteError[keyGA] = errInjected
+	if err := mock.GlobalAddresses().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("GlobalAddresses().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalAddresses.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.GlobalAddresses().Insert(ctx, keyGA, &ga.Address{Name: keyGA.Name}); err != nil {
+		t.Errorf("GlobalAddresses().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.GlobalAddresses().Insert(ctx, keyGA, &ga.Address{Name: keyGA.Name}); err == nil {
+		t.Errorf("GlobalAddresses().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.GlobalAddresses().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("GlobalAddresses().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.GlobalAddresses().GetOrCreate(ctx, keyGA, &ga.Address{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("GlobalAddresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.GlobalAddresses().GetOrCreate(ctx, key, &ga.Address{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("GlobalAddresses().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockGlobalAddresses.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.GlobalAddresses().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("GlobalAddresses().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.GlobalAddresses().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("GlobalAddresses().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GlobalAddresses().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.GlobalAddresses().Delete(ctx, keyGA); err != nil {
+		t.Errorf("GlobalAddresses().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.GlobalAddresses().Get(ctx, keyGA); err == nil {
+		t.Errorf("GlobalAddresses().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.GlobalAddresses().Delete(ctx, keyGA); err == nil {
+		t.Errorf("GlobalAddresses().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestGlobalAddressKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewGlobalAddressKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.GlobalAddressKeyFrom(key); err != nil || got != k {
+		t.Errorf("GlobalAddressKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.GlobalAddressKeyFrom(wrongKey); err == nil {
+		t.Errorf("GlobalAddressKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestGlobalForwardingRulesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.GlobalForwardingRules().Get(ctx, keyGA); err == nil {
+		t.Errorf("GlobalForwardingRules().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.GlobalForwardingRules().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("GlobalForwardingRules().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockGlobalForwardingRules.GetError[keyGA] = errInjected
+	if _, err := mock.GlobalForwardingRules().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("GlobalForwardingRules().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalForwardingRules.GetError, keyGA)
+	mock.MockGlobalForwardingRules.ListError = &errInjected
+	if _, err := mock.GlobalForwardingRules().List(ctx, filter.None); err != errInjected {
+		t.Errorf("GlobalForwardingRules().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockGlobalForwardingRules.ListError = nil
+	mock.MockGlobalForwardingRules.InsertError[keyGA] = errInjected
+	if err := mock.GlobalForwardingRules().Insert(ctx, keyGA, &ga.ForwardingRule{}); err != errInjected {
+		t.Errorf("GlobalForwardingRules().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalForwardingRules.InsertError, keyGA)
+	mock.MockGlobalForwardingRules.DeleteError[keyGA] = errInjected
+	if err := mock.GlobalForwardingRules().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("GlobalForwardingRules().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalForwardingRules.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.GlobalForwardingRules().Insert(ctx, keyGA, &ga.ForwardingRule{Name: keyGA.Name}); err != nil {
+		t.Errorf("GlobalForwardingRules().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.GlobalForwardingRules().Insert(ctx, keyGA, &ga.ForwardingRule{Name: keyGA.Name}); err == nil {
+		t.Errorf("GlobalForwardingRules().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.GlobalForwardingRules().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("GlobalForwardingRules().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.GlobalForwardingRules().GetOrCreate(ctx, keyGA, &ga.ForwardingRule{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("GlobalForwardingRules().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.GlobalForwardingRules().GetOrCreate(ctx, key, &ga.ForwardingRule{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("GlobalForwardingRules().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockGlobalForwardingRules.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.GlobalForwardingRules().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("GlobalForwardingRules().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.GlobalForwardingRules().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("GlobalForwardingRules().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GlobalForwardingRules().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.GlobalForwardingRules().Delete(ctx, keyGA); err != nil {
+		t.Errorf("GlobalForwardingRules().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.GlobalForwardingRules().Get(ctx, keyGA); err == nil {
+		t.Errorf("GlobalForwardingRules().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.GlobalForwardingRules().Delete(ctx, keyGA); err == nil {
+		t.Errorf("GlobalForwardingRules().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestGlobalForwardingRuleKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewGlobalForwardingRuleKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.GlobalForwardingRuleKeyFrom(key); err != nil || got != k {
+		t.Errorf("GlobalForwardingRuleKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.GlobalForwardingRuleKeyFrom(wrongKey); err == nil {
+		t.Errorf("GlobalForwardingRuleKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestGlobalOperationsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.GlobalOperations().Get(ctx, keyGA); err == nil {
+		t.Errorf("GlobalOperations().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.GlobalOperations().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("GlobalOperations().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockGlobalOperations.GetError[keyGA] = errInjected
+	if _, err := mock.GlobalOperations().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("GlobalOperations().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalOperations.GetError, keyGA)
+	mock.MockGlobalOperations.ListError = &errInjected
+	if _, err := mock.GlobalOperations().List(ctx, filter.None); err != errInjected {
+		t.Errorf("GlobalOperations().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockGlobalOperations.ListError = nil
+	mock.MockGlobalOperations.DeleteError[keyGA] = errInjected
+	if err := mock.GlobalOperations().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("GlobalOperations().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockGlobalOperations.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockGlobalOperations.Objects[keyGA] = newMockGlobalOperationsObj(&ga.Operation{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.GlobalOperations().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("GlobalOperations().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.GlobalOperations().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("GlobalOperations().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.GlobalOperations().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("GlobalOperations().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GlobalOperations().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.GlobalOperations().Delete(ctx, keyGA); err != nil {
+		t.Errorf("GlobalOperations().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.GlobalOperations().Get(ctx, keyGA); err == nil {
+		t.Errorf("GlobalOperations().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.GlobalOperations().Delete(ctx, keyGA); err == nil {
+		t.Errorf("GlobalOperations().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestGlobalOperationKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewGlobalOperationKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.GlobalOperationKeyFrom(key); err != nil || got != k {
+		t.Errorf("GlobalOperationKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.GlobalOperationKeyFrom(wrongKey); err == nil {
+		t.Errorf("GlobalOperationKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestHealthChecksGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.GlobalKey("key-alpha")
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaHealthChecks().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaHealthChecks().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaHealthChecks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+	if _, err := mock.HealthChecks().Get(ctx, keyGA); err == nil {
+		t.Errorf("HealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.HealthChecks().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("HealthChecks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaHealthChecks.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaHealthChecks().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaHealthChecks.GetError, keyAlpha)
+	mock.MockAlphaHealthChecks.ListError = &errInjected
+	if _, err := mock.AlphaHealthChecks().List(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaHealthChecks().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaHealthChecks.ListError = nil
+	mock.MockAlphaHealthChecks.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaHealthChecks().Insert(ctx, keyAlpha, &alpha.HealthCheck{}); err != errInjected {
+		t.Errorf("AlphaHealthChecks().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaHealthChecks.InsertError, keyAlpha)
+	mock.MockAlphaHealthChecks.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaHealthChecks().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaHealthChecks().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaHealthChecks.DeleteError, keyAlpha)
+	mock.MockAlphaHealthChecks.UpdateError[keyAlpha] = errInjected
+	if err := mock.AlphaHealthChecks().Update(ctx, keyAlpha, &alpha.HealthCheck{}); err != errInjected {
+		t.Errorf("AlphaHealthChecks().Update(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaHealthChecks.UpdateError, keyAlpha)
+	mock.MockAlphaHealthChecks.PatchError[keyAlpha] = errInjected
+	if err := mock.AlphaHealthChecks().Patch(ctx, keyAlpha, &alpha.HealthCheck{}); err != errInjected {
+		t.Errorf("AlphaHealthChecks().Patch(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaHealthChecks.PatchError, keyAlpha)
+	mock.MockHealthChecks.GetError[keyGA] = errInjected
+	if _, err := mock.HealthChecks().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("HealthChecks().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHealthChecks.GetError, keyGA)
+	mock.MockHealthChecks.ListError = &errInjected
+	if _, err := mock.HealthChecks().List(ctx, filter.None); err != errInjected {
+		t.Errorf("HealthChecks().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockHealthChecks.ListError = nil
+	mock.MockHealthChecks.InsertError[keyGA] = errInjected
+	if err := mock.HealthChecks().Insert(ctx, keyGA, &ga.HealthCheck{}); err != errInjected {
+		t.Errorf("HealthChecks().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHealthChecks.InsertError, keyGA)
+	mock.MockHealthChecks.DeleteError[keyGA] = errInjected
+	if err := mock.HealthChecks().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("HealthChecks().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHealthChecks.DeleteError, keyGA)
+	mock.MockHealthChecks.UpdateError[keyGA] = errInjected
+	if err := mock.HealthChecks().Update(ctx, keyGA, &ga.HealthCheck{}); err != errInjected {
+		t.Errorf("HealthChecks().Update(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHealthChecks.UpdateError, keyGA)
+	mock.MockHealthChecks.PatchError[keyGA] = errInjected
+	if err := mock.HealthChecks().Patch(ctx, keyGA, &ga.HealthCheck{}); err != errInjected {
+		t.Errorf("HealthChecks().Patch(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHealthChecks.PatchError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaHealthChecks().Insert(ctx, keyAlpha, &alpha.HealthCheck{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaHealthChecks().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaHealthChecks().Insert(ctx, keyAlpha, &alpha.HealthCheck{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaHealthChecks().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.HealthChecks().Insert(ctx, keyGA, &ga.HealthCheck{Name: keyGA.Name}); err != nil {
+		t.Errorf("HealthChecks().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.HealthChecks().Insert(ctx, keyGA, &ga.HealthCheck{Name: keyGA.Name}); err == nil {
+		t.Errorf("HealthChecks().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Update and Patch.
+	if err := mock.AlphaHealthChecks().Update(ctx, *meta.GlobalKey("key-missing"), &alpha.HealthCheck{}); err == nil {
+		t.Errorf("AlphaHealthChecks().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.AlphaHealthChecks().Update(ctx, keyAlpha, &alpha.HealthCheck{Name: keyAlpha.Name, Description: "updated"}); err != nil {
+		t.Errorf("AlphaHealthChecks().Update(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaHealthChecks().Get(ctx, keyAlpha); err != nil || obj.Description != "updated" {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyAlpha, obj, err, "updated")
+	}
+	if err := mock.AlphaHealthChecks().Patch(ctx, *meta.GlobalKey("key-missing"), &alpha.HealthCheck{}); err == nil {
+		t.Errorf("AlphaHealthChecks().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.AlphaHealthChecks().Patch(ctx, keyAlpha, &alpha.HealthCheck{Description: "patched"}); err != nil {
+		t.Errorf("AlphaHealthChecks().Patch(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaHealthChecks().Get(ctx, keyAlpha); err != nil || obj.Description != "patched" || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name, "patched")
+	}
+	if err := mock.HealthChecks().Update(ctx, *meta.GlobalKey("key-missing"), &ga.HealthCheck{}); err == nil {
+		t.Errorf("HealthChecks().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.HealthChecks().Update(ctx, keyGA, &ga.HealthCheck{Name: keyGA.Name, Description: "updated"}); err != nil {
+		t.Errorf("HealthChecks().Update(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.HealthChecks().Get(ctx, keyGA); err != nil || obj.Description != "updated" {
+		t.Errorf("HealthChecks().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyGA, obj, err, "updated")
+	}
+	if err := mock.HealthChecks().Patch(ctx, *meta.GlobalKey("key-missing"), &ga.HealthCheck{}); err == nil {
+		t.Errorf("HealthChecks().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.HealthChecks().Patch(ctx, keyGA, &ga.HealthCheck{Description: "patched"}); err != nil {
+		t.Errorf("HealthChecks().Patch(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.HealthChecks().Get(ctx, keyGA); err != nil || obj.Description != "patched" || obj.Name != keyGA.Name {
+		t.Errorf("HealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyGA, obj, err, keyGA.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaHealthChecks().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaHealthChecks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaHealthChecks().GetOrCreate(ctx, keyAlpha, &alpha.HealthCheck{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaHealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.AlphaHealthChecks().GetOrCreate(ctx, key, &alpha.HealthCheck{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaHealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaHealthChecks.Objects, key)
+	}
+	if ok, err := mock.HealthChecks().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("HealthChecks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.HealthChecks().GetOrCreate(ctx, keyGA, &ga.HealthCheck{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("HealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.HealthChecks().GetOrCreate(ctx, key, &ga.HealthCheck{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("HealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockHealthChecks.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaHealthChecks().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.AlphaHealthChecks().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.HealthChecks().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("HealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.HealthChecks().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("HealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+		keyGA.Name:    true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaHealthChecks().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaHealthChecks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaHealthChecks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.HealthChecks().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("HealthChecks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("HealthChecks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaHealthChecks().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaHealthChecks().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaHealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaHealthChecks().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.HealthChecks().Delete(ctx, keyGA); err != nil {
+		t.Errorf("HealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.HealthChecks().Get(ctx, keyGA); err == nil {
+		t.Errorf("HealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.HealthChecks().Delete(ctx, keyGA); err == nil {
+		t.Errorf("HealthChecks().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestHealthCheckKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewHealthCheckKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.HealthCheckKeyFrom(key); err != nil || got != k {
+		t.Errorf("HealthCheckKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.HealthCheckKeyFrom(wrongKey); err == nil {
+		t.Errorf("HealthCheckKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestHttpHealthChecksGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.HttpHealthChecks().Get(ctx, keyGA); err == nil {
+		t.Errorf("HttpHealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.HttpHealthChecks().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("HttpHealthChecks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockHttpHealthChecks.GetError[keyGA] = errInjected
+	if _, err := mock.HttpHealthChecks().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("HttpHealthChecks().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpHealthChecks.GetError, keyGA)
+	mock.MockHttpHealthChecks.ListError = &errInjected
+	if _, err := mock.HttpHealthChecks().List(ctx, filter.None); err != errInjected {
+		t.Errorf("HttpHealthChecks().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockHttpHealthChecks.ListError = nil
+	mock.MockHttpHealthChecks.InsertError[keyGA] = errInjected
+	if err := mock.HttpHealthChecks().Insert(ctx, keyGA, &ga.HttpHealthCheck{}); err != errInjected {
+		t.Errorf("HttpHealthChecks().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpHealthChecks.InsertError, keyGA)
+	mock.MockHttpHealthChecks.DeleteError[keyGA] = errInjected
+	if err := mock.HttpHealthChecks().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("HttpHealthChecks().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpHealthChecks.DeleteError, keyGA)
+	mock.MockHttpHealthChecks.UpdateError[keyGA] = errInjected
+	if err := mock.HttpHealthChecks().Update(ctx, keyGA, &ga.HttpHealthCheck{}); err != errInjected {
+		t.Errorf("HttpHealthChecks().Update(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpHealthChecks.UpdateError, keyGA)
+	mock.MockHttpHealthChecks.PatchError[keyGA] = errInjected
+	if err := mock.HttpHealthChecks().Patch(ctx, keyGA, &ga.HttpHealthCheck{}); err != errInjected {
+		t.Errorf("HttpHealthChecks().Patch(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpHealthChecks.PatchError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.HttpHealthChecks().Insert(ctx, keyGA, &ga.HttpHealthCheck{Name: keyGA.Name}); err != nil {
+		t.Errorf("HttpHealthChecks().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.HttpHealthChecks().Insert(ctx, keyGA, &ga.HttpHealthCheck{Name: keyGA.Name}); err == nil {
+		t.Errorf("HttpHealthChecks().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Update and Patch.
+	if err := mock.HttpHealthChecks().Update(ctx, *meta.GlobalKey("key-missing"), &ga.HttpHealthCheck{}); err == nil {
+		t.Errorf("HttpHealthChecks().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.HttpHealthChecks().Update(ctx, keyGA, &ga.HttpHealthCheck{Name: keyGA.Name, Description: "updated"}); err != nil {
+		t.Errorf("HttpHealthChecks().Update(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.HttpHealthChecks().Get(ctx, keyGA); err != nil || obj.Description != "updated" {
+		t.Errorf("HttpHealthChecks().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyGA, obj, err, "updated")
+	}
+	if err := mock.HttpHealthChecks().Patch(ctx, *meta.GlobalKey("key-missing"), &ga.HttpHealthCheck{}); err == nil {
+		t.Errorf("HttpHealthChecks().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.HttpHealthChecks().Patch(ctx, keyGA, &ga.HttpHealthCheck{Description: "patched"}); err != nil {
+		t.Errorf("HttpHealthChecks().Patch(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.HttpHealthChecks().Get(ctx, keyGA); err != nil || obj.Description != "patched" || obj.Name != keyGA.Name {
+		t.Errorf("HttpHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyGA, obj, err, keyGA.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.HttpHealthChecks().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("HttpHealthChecks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.HttpHealthChecks().GetOrCreate(ctx, keyGA, &ga.HttpHealthCheck{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("HttpHealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.HttpHealthChecks().GetOrCreate(ctx, key, &ga.HttpHealthCheck{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("HttpHealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockHttpHealthChecks.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.HttpHealthChecks().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("HttpHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.HttpHealthChecks().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("HttpHealthChecks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("HttpHealthChecks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.HttpHealthChecks().Delete(ctx, keyGA); err != nil {
+		t.Errorf("HttpHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.HttpHealthChecks().Get(ctx, keyGA); err == nil {
+		t.Errorf("HttpHealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.HttpHealthChecks().Delete(ctx, keyGA); err == nil {
+		t.Errorf("HttpHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestHttpHealthCheckKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewHttpHealthCheckKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.HttpHealthCheckKeyFrom(key); err != nil || got != k {
+		t.Errorf("HttpHealthCheckKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.HttpHealthCheckKeyFrom(wrongKey); err == nil {
+		t.Errorf("HttpHealthCheckKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestHttpsHealthChecksGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.HttpsHealthChecks().Get(ctx, keyGA); err == nil {
+		t.Errorf("HttpsHealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.HttpsHealthChecks().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("HttpsHealthChecks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockHttpsHealthChecks.GetError[keyGA] = errInjected
+	if _, err := mock.HttpsHealthChecks().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("HttpsHealthChecks().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpsHealthChecks.GetError, keyGA)
+	mock.MockHttpsHealthChecks.ListError = &errInjected
+	if _, err := mock.HttpsHealthChecks().List(ctx, filter.None); err != errInjected {
+		t.Errorf("HttpsHealthChecks().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockHttpsHealthChecks.ListError = nil
+	mock.MockHttpsHealthChecks.InsertError[keyGA] = errInjected
+	if err := mock.HttpsHealthChecks().Insert(ctx, keyGA, &ga.HttpsHealthCheck{}); err != errInjected {
+		t.Errorf("HttpsHealthChecks().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpsHealthChecks.InsertError, keyGA)
+	mock.MockHttpsHealthChecks.DeleteError[keyGA] = errInjected
+	if err := mock.HttpsHealthChecks().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("HttpsHealthChecks().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpsHealthChecks.DeleteError, keyGA)
+	mock.MockHttpsHealthChecks.UpdateError[keyGA] = errInjected
+	if err := mock.HttpsHealthChecks().Update(ctx, keyGA, &ga.HttpsHealthCheck{}); err != errInjected {
+		t.Errorf("HttpsHealthChecks().Update(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpsHealthChecks.UpdateError, keyGA)
+	mock.MockHttpsHealthChecks.PatchError[keyGA] = errInjected
+	if err := mock.HttpsHealthChecks().Patch(ctx, keyGA, &ga.HttpsHealthCheck{}); err != errInjected {
+		t.Errorf("HttpsHealthChecks().Patch(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockHttpsHealthChecks.PatchError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.HttpsHealthChecks().Insert(ctx, keyGA, &ga.HttpsHealthCheck{Name: keyGA.Name}); err != nil {
+		t.Errorf("HttpsHealthChecks().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.HttpsHealthChecks().Insert(ctx, keyGA, &ga.HttpsHealthCheck{Name: keyGA.Name}); err == nil {
+		t.Errorf("HttpsHealthChecks().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Update and Patch.
+	if err := mock.HttpsHealthChecks().Update(ctx, *meta.GlobalKey("key-missing"), &ga.HttpsHealthCheck{}); err == nil {
+		t.Errorf("HttpsHealthChecks().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.HttpsHealthChecks().Update(ctx, keyGA, &ga.HttpsHealthCheck{Name: keyGA.Name, Description: "updated"}); err != nil {
+		t.Errorf("HttpsHealthChecks().Update(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.HttpsHealthChecks().Get(ctx, keyGA); err != nil || obj.Description != "updated" {
+		t.Errorf("HttpsHealthChecks().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyGA, obj, err, "updated")
+	}
+	if err := mock.HttpsHealthChecks().Patch(ctx, *meta.GlobalKey("key-missing"), &ga.HttpsHealthCheck{}); err == nil {
+		t.Errorf("HttpsHealthChecks().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.HttpsHealthChecks().Patch(ctx, keyGA, &ga.HttpsHealthCheck{Description: "patched"}); err != nil {
+		t.Errorf("HttpsHealthChecks().Patch(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.HttpsHealthChecks().Get(ctx, keyGA); err != nil || obj.Description != "patched" || obj.Name != keyGA.Name {
+		t.Errorf("HttpsHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyGA, obj, err, keyGA.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.HttpsHealthChecks().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("HttpsHealthChecks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.HttpsHealthChecks().GetOrCreate(ctx, keyGA, &ga.HttpsHealthCheck{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("HttpsHealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.HttpsHealthChecks().GetOrCreate(ctx, key, &ga.HttpsHealthCheck{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("HttpsHealthChecks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockHttpsHealthChecks.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.HttpsHealthChecks().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("HttpsHealthChecks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.HttpsHealthChecks().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("HttpsHealthChecks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("HttpsHealthChecks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.HttpsHealthChecks().Delete(ctx, keyGA); err != nil {
+		t.Errorf("HttpsHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.HttpsHealthChecks().Get(ctx, keyGA); err == nil {
+		t.Errorf("HttpsHealthChecks().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.HttpsHealthChecks().Delete(ctx, keyGA); err == nil {
+		t.Errorf("HttpsHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestHttpsHealthCheckKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewHttpsHealthCheckKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.HttpsHealthCheckKeyFrom(key); err != nil || got != k {
+		t.Errorf("HttpsHealthCheckKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.HttpsHealthCheckKeyFrom(wrongKey); err == nil {
+		t.Errorf("HttpsHealthCheckKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestInstanceGroupsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.ZonalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.InstanceGroups().Get(ctx, keyGA); err == nil {
+		t.Errorf("InstanceGroups().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.InstanceGroups().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("InstanceGroups().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockInstanceGroups.GetError[keyGA] = errInjected
+	if _, err := mock.InstanceGroups().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("InstanceGroups().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockInstanceGroups.GetError, keyGA)
+	mock.MockInstanceGroups.ListError = &errInjected
+	if _, err := mock.InstanceGroups().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("InstanceGroups().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockInstanceGroups.ListError = nil
+	mock.MockInstanceGroups.InsertError[keyGA] = errInjected
+	if err := mock.InstanceGroups().Insert(ctx, keyGA, &ga.InstanceGroup{}); err != errInjected {
+		t.Errorf("InstanceGroups().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockInstanceGroups.InsertError, keyGA)
+	mock.MockInstanceGroups.DeleteError[keyGA] = errInjected
+	if err := mock.InstanceGroups().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("InstanceGroups().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockInstanceGroups.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.InstanceGroups().Insert(ctx, keyGA, &ga.InstanceGroup{Name: keyGA.Name}); err != nil {
+		t.Errorf("InstanceGroups().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.InstanceGroups().Insert(ctx, keyGA, &ga.InstanceGroup{Name: keyGA.Name}); err == nil {
+		t.Errorf("InstanceGroups().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.InstanceGroups().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("InstanceGroups().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.InstanceGroups().GetOrCreate(ctx, keyGA, &ga.InstanceGroup{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("InstanceGroups().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.InstanceGroups().GetOrCreate(ctx, key, &ga.InstanceGroup{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("InstanceGroups().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockInstanceGroups.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.InstanceGroups().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("InstanceGroups().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.InstanceGroups().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("InstanceGroups().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("InstanceGroups().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.InstanceGroups().Delete(ctx, keyGA); err != nil {
+		t.Errorf("InstanceGroups().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.InstanceGroups().Get(ctx, keyGA); err == nil {
+		t.Errorf("InstanceGroups().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.InstanceGroups().Delete(ctx, keyGA); err == nil {
+		t.Errorf("InstanceGroups().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestInstanceGroupKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewInstanceGroupKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.InstanceGroupKeyFrom(key); err != nil || got != k {
+		t.Errorf("InstanceGroupKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.InstanceGroupKeyFrom(wrongKey); err == nil {
+		t.Errorf("InstanceGroupKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestInstancesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.ZonalKey("key-alpha", "location")
+	keyBeta := *meta.ZonalKey("key-beta", "location")
+	keyGA := *meta.ZonalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaInstances().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaInstances().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaInstances().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaInstances().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+	if _, err := mock.BetaInstances().Get(ctx, keyBeta); err == nil {
+		t.Errorf("BetaInstances().Get(%v, %v) = _, nil; want error", ctx, keyBeta)
+	}
+	if ok, err := mock.BetaInstances().Exists(ctx, keyBeta); ok || err != nil {
+		t.Errorf("BetaInstances().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyBeta, ok, err)
+	}
+	if _, err := mock.Instances().Get(ctx, keyGA); err == nil {
+		t.Errorf("Instances().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Instances().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Instances().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaInstances.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaInstances().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaInstances().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaInstances.GetError, keyAlpha)
+	mock.MockAlphaInstances.ListError = &errInjected
+	if _, err := mock.AlphaInstances().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaInstances().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaInstances.ListError = nil
+	mock.MockAlphaInstances.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaInstances().Insert(ctx, keyAlpha, &alpha.Instance{}); err != errInjected {
+		t.Errorf("AlphaInstances().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaInstances.InsertError, keyAlpha)
+	mock.MockAlphaInstances.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaInstances().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaInstances().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaInstances.DeleteError, keyAlpha)
+	mock.MockAlphaInstances.AggregatedListError = &errInjected
+	if _, err := mock.AlphaInstances().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaInstances().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaInstances.AggregatedListError = nil
+	mock.MockBetaInstances.GetError[keyBeta] = errInjected
+	if _, err := mock.BetaInstances().Get(ctx, keyBeta); err != errInjected {
+		t.Errorf("BetaInstances().Get(%v, %v) = _, %v; want %v", ctx, keyBeta, err, errInjected)
+	}
+	delete(mock.MockBetaInstances.GetError, keyBeta)
+	mock.MockBetaInstances.ListError = &errInjected
+	if _, err := mock.BetaInstances().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("BetaInstances().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockBetaInstances.ListError = nil
+	mock.MockBetaInstances.InsertError[keyBeta] = errInjected
+	if err := mock.BetaInstances().Insert(ctx, keyBeta, &beta.Instance{}); err != errInjected {
+		t.Errorf("BetaInstances().Insert(%v, %v, _) = %v; want %v", ctx, keyBeta, err, errInjected)
+	}
+	delete(mock.MockBetaInstances.InsertError, keyBeta)
+	mock.MockBetaInstances.DeleteError[keyBeta] = errInjected
+	if err := mock.BetaInstances().Delete(ctx, keyBeta); err != errInjected {
+		t.Errorf("BetaInstances().Delete(%v, %v) = %v; want %v", ctx, keyBeta, err, errInjected)
+	}
+	delete(mock.MockBetaInstances.DeleteError, keyBeta)
+	mock.MockBetaInstances.AggregatedListError = &errInjected
+	if _, err := mock.BetaInstances().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("BetaInstances().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockBetaInstances.AggregatedListError = nil
+	mock.MockInstances.GetError[keyGA] = errInjected
+	if _, err := mock.Instances().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Instances().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockInstances.GetError, keyGA)
+	mock.MockInstances.ListError = &errInjected
+	if _, err := mock.Instances().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("Instances().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockInstances.ListError = nil
+	mock.MockInstances.InsertError[keyGA] = errInjected
+	if err := mock.Instances().Insert(ctx, keyGA, &ga.Instance{}); err != errInjected {
+		t.Errorf("Instances().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockInstances.InsertError, keyGA)
+	mock.MockInstances.DeleteError[keyGA] = errInjected
+	if err := mock.Instances().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("Instances().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockInstances.DeleteError, keyGA)
+	mock.MockInstances.AggregatedListError = &errInjected
+	if _, err := mock.Instances().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("Instances().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockInstances.AggregatedListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaInstances().Insert(ctx, keyAlpha, &alpha.Instance{Name: keyAlpha.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("AlphaInstances().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaInstances().Insert(ctx, keyAlpha, &alpha.Instance{Name: keyAlpha.Name, MachineType: "machineType"}); err != nil {
+		t.Errorf("AlphaInstances().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaInstances().Insert(ctx, keyAlpha, &alpha.Instance{Name: keyAlpha.Name, MachineType: "machineType"}); err == nil {
+		t.Errorf("AlphaInstances().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaInstances().Insert(ctx, keyBeta, &beta.Instance{Name: keyBeta.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("BetaInstances().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyBeta, err)
+	}
+	if err := mock.BetaInstances().Insert(ctx, keyBeta, &beta.Instance{Name: keyBeta.Name, MachineType: "machineType"}); err != nil {
+		t.Errorf("BetaInstances().Insert(%v, %v, _) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.BetaInstances().Insert(ctx, keyBeta, &beta.Instance{Name: keyBeta.Name, MachineType: "machineType"}); err == nil {
+		t.Errorf("BetaInstances().Insert(%v, %v, _) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.Instances().Insert(ctx, keyGA, &ga.Instance{Name: keyGA.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("Instances().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyGA, err)
+	}
+	if err := mock.Instances().Insert(ctx, keyGA, &ga.Instance{Name: keyGA.Name, MachineType: "machineType"}); err != nil {
+		t.Errorf("Instances().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.Instances().Insert(ctx, keyGA, &ga.Instance{Name: keyGA.Name, MachineType: "machineType"}); err == nil {
+		t.Errorf("Instances().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// UpdateLabels.
+	if err := mock.AlphaInstances().UpdateLabels(ctx, keyAlpha, map[string]string{"version": "alpha"}); err != nil {
+		t.Errorf("AlphaInstances().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaInstances().Get(ctx, keyAlpha); err != nil || obj.Labels["version"] != "alpha" || obj.LabelFingerprint == "" {
+		t.Errorf("AlphaInstances().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyAlpha, obj, err, "alpha")
+	}
+	if err := mock.BetaInstances().UpdateLabels(ctx, keyBeta, map[string]string{"version": "beta"}); err != nil {
+		t.Errorf("BetaInstances().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyBeta, err)
+	}
+	if obj, err := mock.BetaInstances().Get(ctx, keyBeta); err != nil || obj.Labels["version"] != "beta" || obj.LabelFingerprint == "" {
+		t.Errorf("BetaInstances().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyBeta, obj, err, "beta")
+	}
+	if err := mock.Instances().UpdateLabels(ctx, keyGA, map[string]string{"version": "ga"}); err != nil {
+		t.Errorf("Instances().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.Instances().Get(ctx, keyGA); err != nil || obj.Labels["version"] != "ga" || obj.LabelFingerprint == "" {
+		t.Errorf("Instances().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyGA, obj, err, "ga")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaInstances().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaInstances().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaInstances().GetOrCreate(ctx, keyAlpha, &alpha.Instance{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaInstances().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.AlphaInstances().GetOrCreate(ctx, key, &alpha.Instance{Name: key.Name, MachineType: "machineType"}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaInstances().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaInstances.Objects, key)
+	}
+	if ok, err := mock.BetaInstances().Exists(ctx, keyBeta); !ok || err != nil {
+		t.Errorf("BetaInstances().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyBeta, ok, err)
+	}
+	if obj, err := mock.BetaInstances().GetOrCreate(ctx, keyBeta, &beta.Instance{Name: "other"}); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("BetaInstances().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.BetaInstances().GetOrCreate(ctx, key, &beta.Instance{Name: key.Name, MachineType: "machineType"}); err != nil || obj.Name != key.Name {
+			t.Errorf("BetaInstances().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockBetaInstances.Objects, key)
+	}
+	if ok, err := mock.Instances().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Instances().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.Instances().GetOrCreate(ctx, keyGA, &ga.Instance{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Instances().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.Instances().GetOrCreate(ctx, key, &ga.Instance{Name: key.Name, MachineType: "machineType"}); err != nil || obj.Name != key.Name {
+			t.Errorf("Instances().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockInstances.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaInstances().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaInstances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.AlphaInstances().Get(ctx, keyBeta); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("AlphaInstances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	if obj, err := mock.AlphaInstances().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("AlphaInstances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.BetaInstances().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("BetaInstances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.BetaInstances().Get(ctx, keyBeta); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("BetaInstances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	if obj, err := mock.BetaInstances().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("BetaInstances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	if obj, err := mock.Instances().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("Instances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	if obj, err := mock.Instances().Get(ctx, keyBeta); err != nil || obj.Name != keyBeta.Name {
+		t.Errorf("Instances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyBeta, obj, err, keyBeta.Name)
+	}
+	if obj, err := mock.Instances().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Instances().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+		keyBeta.Name:  true,
+		keyGA.Name:    true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaInstances().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaInstances().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaInstances().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaInstances().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("BetaInstances().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaInstances().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.Instances().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("Instances().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Instances().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.AlphaInstances().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaInstances().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("AlphaInstances().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaInstances().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.BetaInstances().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaInstances().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("BetaInstances().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaInstances().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.Instances().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Instances().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("Instances().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Instances().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaInstances().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaInstances().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaInstances().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaInstances().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaInstances().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaInstances().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaInstances().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaInstances().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if _, err := mock.BetaInstances().Get(ctx, keyBeta); err == nil {
+		t.Errorf("BetaInstances().Get(%v, %v) = _, nil; want error", ctx, keyBeta)
+	}
+	if err := mock.BetaInstances().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaInstances().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.Instances().Delete(ctx, keyGA); err != nil {
+		t.Errorf("Instances().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.Instances().Get(ctx, keyGA); err == nil {
+		t.Errorf("Instances().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.Instances().Delete(ctx, keyGA); err == nil {
+		t.Errorf("Instances().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestInstanceKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewInstanceKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.InstanceKeyFrom(key); err != nil || got != k {
+		t.Errorf("InstanceKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.InstanceKeyFrom(wrongKey); err == nil {
+		t.Errorf("InstanceKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestMachineTypesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.ZonalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// MachineTypes is read-only.
+	if _, ok := mock.MachineTypes().(interface {
+		Insert(context.Context, meta.Key, *ga.MachineType) error
+	}); ok {
+		t.Errorf("MachineTypes() has Insert(); want read-only")
+	}
+	if _, ok := mock.MachineTypes().(interface {
+		Delete(context.Context, meta.Key) error
+	}); ok {
+		t.Errorf("MachineTypes() has Delete(); want read-only")
+	}
+
+	// Get not found.
+	if _, err := mock.MachineTypes().Get(ctx, keyGA); err == nil {
+		t.Errorf("MachineTypes().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.MachineTypes().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("MachineTypes().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockMachineTypes.GetError[keyGA] = errInjected
+	if _, err := mock.MachineTypes().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("MachineTypes().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockMachineTypes.GetError, keyGA)
+	mock.MockMachineTypes.ListError = &errInjected
+	if _, err := mock.MachineTypes().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("MachineTypes().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockMachineTypes.ListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockMachineTypes.Objects[keyGA] = newMockMachineTypesObj(&ga.MachineType{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.MachineTypes().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("MachineTypes().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.MachineTypes().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("MachineTypes().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.MachineTypes().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("MachineTypes().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("MachineTypes().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+}
+
+func TestMachineTypeKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewMachineTypeKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.MachineTypeKeyFrom(key); err != nil || got != k {
+		t.Errorf("MachineTypeKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.MachineTypeKeyFrom(wrongKey); err == nil {
+		t.Errorf("MachineTypeKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestNetworkEndpointGroupsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.ZonalKey("key-alpha", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaNetworkEndpointGroups().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaNetworkEndpointGroups().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaNetworkEndpointGroups.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaNetworkEndpointGroups().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaNetworkEndpointGroups().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaNetworkEndpointGroups.GetError, keyAlpha)
+	mock.MockAlphaNetworkEndpointGroups.ListError = &errInjected
+	if _, err := mock.AlphaNetworkEndpointGroups().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaNetworkEndpointGroups().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaNetworkEndpointGroups.ListError = nil
+	mock.MockAlphaNetworkEndpointGroups.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaNetworkEndpointGroups().Insert(ctx, keyAlpha, &alpha.NetworkEndpointGroup{}); err != errInjected {
+		t.Errorf("AlphaNetworkEndpointGroups().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaNetworkEndpointGroups.InsertError, keyAlpha)
+	mock.MockAlphaNetworkEndpointGroups.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaNetworkEndpointGroups().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaNetworkEndpointGroups().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaNetworkEndpointGroups.DeleteError, keyAlpha)
+	mock.MockAlphaNetworkEndpointGroups.AggregatedListError = &errInjected
+	if _, err := mock.AlphaNetworkEndpointGroups().AggregatedList(ctx, filter.None); err != errInjected {
+		t.Errorf("AlphaNetworkEndpointGroups().AggregatedList(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockAlphaNetworkEndpointGroups.AggregatedListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaNetworkEndpointGroups().Insert(ctx, keyAlpha, &alpha.NetworkEndpointGroup{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaNetworkEndpointGroups().Insert(ctx, keyAlpha, &alpha.NetworkEndpointGroup{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaNetworkEndpointGroups().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaNetworkEndpointGroups().GetOrCreate(ctx, keyAlpha, &alpha.NetworkEndpointGroup{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaNetworkEndpointGroups().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.ZonalKey("key-created", "location")
+		if obj, err := mock.AlphaNetworkEndpointGroups().GetOrCreate(ctx, key, &alpha.NetworkEndpointGroup{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaNetworkEndpointGroups().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaNetworkEndpointGroups.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaNetworkEndpointGroups().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaNetworkEndpointGroups().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaNetworkEndpointGroups().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaNetworkEndpointGroups().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaNetworkEndpointGroups().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+	{
+		aggregated, err := mock.AlphaNetworkEndpointGroups().AggregatedList(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaNetworkEndpointGroups().AggregatedList(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for loc, objs := range aggregated {
+				if loc != location {
+					t.Errorf("AlphaNetworkEndpointGroups().AggregatedList(%v, _) has location %q; want %q", ctx, loc, location)
+				}
+				for _, obj := range objs {
+					got[obj.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaNetworkEndpointGroups().AggregatedList(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaNetworkEndpointGroups().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaNetworkEndpointGroups().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaNetworkEndpointGroups().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaNetworkEndpointGroups().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+}
+
+func TestNetworkEndpointGroupKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewNetworkEndpointGroupKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.NetworkEndpointGroupKeyFrom(key); err != nil || got != k {
+		t.Errorf("NetworkEndpointGroupKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.NetworkEndpointGroupKeyFrom(wrongKey); err == nil {
+		t.Errorf("NetworkEndpointGroupKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestProjectsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+
+	// Injected errors.
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockProjects.Objects[keyGA] = newMockProjectsObj(&ga.Project{Name: keyGA.Name})
+
+	// Get across versions.
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+
+	// Delete.
+}
+
+func TestProjectKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewProjectKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.ProjectKeyFrom(key); err != nil || got != k {
+		t.Errorf("ProjectKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.ProjectKeyFrom(wrongKey); err == nil {
+		t.Errorf("ProjectKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestRegionBackendServicesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.RegionalKey("key-alpha", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaRegionBackendServices().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionBackendServices().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaRegionBackendServices().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaRegionBackendServices().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaRegionBackendServices.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaRegionBackendServices().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaRegionBackendServices().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionBackendServices.GetError, keyAlpha)
+	mock.MockAlphaRegionBackendServices.ListError = &errInjected
+	if _, err := mock.AlphaRegionBackendServices().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaRegionBackendServices().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaRegionBackendServices.ListError = nil
+	mock.MockAlphaRegionBackendServices.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaRegionBackendServices().Insert(ctx, keyAlpha, &alpha.BackendService{}); err != errInjected {
+		t.Errorf("AlphaRegionBackendServices().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionBackendServices.InsertError, keyAlpha)
+	mock.MockAlphaRegionBackendServices.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaRegionBackendServices().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaRegionBackendServices().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionBackendServices.DeleteError, keyAlpha)
+	mock.MockAlphaRegionBackendServices.UpdateError[keyAlpha] = errInjected
+	if err := mock.AlphaRegionBackendServices().Update(ctx, keyAlpha, &alpha.BackendService{}); err != errInjected {
+		t.Errorf("AlphaRegionBackendServices().Update(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionBackendServices.UpdateError, keyAlpha)
+	mock.MockAlphaRegionBackendServices.PatchError[keyAlpha] = errInjected
+	if err := mock.AlphaRegionBackendServices().Patch(ctx, keyAlpha, &alpha.BackendService{}); err != errInjected {
+		t.Errorf("AlphaRegionBackendServices().Patch(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionBackendServices.PatchError, keyAlpha)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaRegionBackendServices().Insert(ctx, keyAlpha, &alpha.BackendService{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaRegionBackendServices().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaRegionBackendServices().Insert(ctx, keyAlpha, &alpha.BackendService{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaRegionBackendServices().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+
+	// Update and Patch.
+	if err := mock.AlphaRegionBackendServices().Update(ctx, *meta.RegionalKey("key-missing", "location"), &alpha.BackendService{}); err == nil {
+		t.Errorf("AlphaRegionBackendServices().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.AlphaRegionBackendServices().Update(ctx, keyAlpha, &alpha.BackendService{Name: keyAlpha.Name, Description: "updated"}); err != nil {
+		t.Errorf("AlphaRegionBackendServices().Update(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaRegionBackendServices().Get(ctx, keyAlpha); err != nil || obj.Description != "updated" {
+		t.Errorf("AlphaRegionBackendServices().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyAlpha, obj, err, "updated")
+	}
+	if err := mock.AlphaRegionBackendServices().Patch(ctx, *meta.RegionalKey("key-missing", "location"), &alpha.BackendService{}); err == nil {
+		t.Errorf("AlphaRegionBackendServices().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.AlphaRegionBackendServices().Patch(ctx, keyAlpha, &alpha.BackendService{Description: "patched"}); err != nil {
+		t.Errorf("AlphaRegionBackendServices().Patch(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaRegionBackendServices().Get(ctx, keyAlpha); err != nil || obj.Description != "patched" || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaRegionBackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaRegionBackendServices().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaRegionBackendServices().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaRegionBackendServices().GetOrCreate(ctx, keyAlpha, &alpha.BackendService{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaRegionBackendServices().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.AlphaRegionBackendServices().GetOrCreate(ctx, key, &alpha.BackendService{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaRegionBackendServices().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaRegionBackendServices.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaRegionBackendServices().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaRegionBackendServices().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaRegionBackendServices().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaRegionBackendServices().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaRegionBackendServices().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaRegionBackendServices().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaRegionBackendServices().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaRegionBackendServices().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionBackendServices().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaRegionBackendServices().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionBackendServices().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+}
+
+func TestRegionBackendServiceKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.RegionalKey("key", "location")
+	k := cloud.NewRegionBackendServiceKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.RegionBackendServiceKeyFrom(key); err != nil || got != k {
+		t.Errorf("RegionBackendServiceKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.RegionBackendServiceKeyFrom(wrongKey); err == nil {
+		t.Errorf("RegionBackendServiceKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestRegionDisksGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := *meta.RegionalKey("key-alpha", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.AlphaRegionDisks().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionDisks().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if ok, err := mock.AlphaRegionDisks().Exists(ctx, keyAlpha); ok || err != nil {
+		t.Errorf("AlphaRegionDisks().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyAlpha, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockAlphaRegionDisks.GetError[keyAlpha] = errInjected
+	if _, err := mock.AlphaRegionDisks().Get(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaRegionDisks().Get(%v, %v) = _, %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionDisks.GetError, keyAlpha)
+	mock.MockAlphaRegionDisks.ListError = &errInjected
+	if _, err := mock.AlphaRegionDisks().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("AlphaRegionDisks().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockAlphaRegionDisks.ListError = nil
+	mock.MockAlphaRegionDisks.InsertError[keyAlpha] = errInjected
+	if err := mock.AlphaRegionDisks().Insert(ctx, keyAlpha, &alpha.Disk{}); err != errInjected {
+		t.Errorf("AlphaRegionDisks().Insert(%v, %v, _) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionDisks.InsertError, keyAlpha)
+	mock.MockAlphaRegionDisks.DeleteError[keyAlpha] = errInjected
+	if err := mock.AlphaRegionDisks().Delete(ctx, keyAlpha); err != errInjected {
+		t.Errorf("AlphaRegionDisks().Delete(%v, %v) = %v; want %v", ctx, keyAlpha, err, errInjected)
+	}
+	delete(mock.MockAlphaRegionDisks.DeleteError, keyAlpha)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.AlphaRegionDisks().Insert(ctx, keyAlpha, &alpha.Disk{Name: keyAlpha.Name}); err != nil {
+		t.Errorf("AlphaRegionDisks().Insert(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.AlphaRegionDisks().Insert(ctx, keyAlpha, &alpha.Disk{Name: keyAlpha.Name}); err == nil {
+		t.Errorf("AlphaRegionDisks().Insert(%v, %v, _) = nil; want error", ctx, keyAlpha)
+	}
+
+	// UpdateLabels.
+	if err := mock.AlphaRegionDisks().UpdateLabels(ctx, keyAlpha, map[string]string{"version": "alpha"}); err != nil {
+		t.Errorf("AlphaRegionDisks().UpdateLabels(%v, %v, _) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if obj, err := mock.AlphaRegionDisks().Get(ctx, keyAlpha); err != nil || obj.Labels["version"] != "alpha" || obj.LabelFingerprint == "" {
+		t.Errorf("AlphaRegionDisks().Get(%v, %v) = %+v, %v; want object with label version=%s and a label fingerprint, nil", ctx, keyAlpha, obj, err, "alpha")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.AlphaRegionDisks().Exists(ctx, keyAlpha); !ok || err != nil {
+		t.Errorf("AlphaRegionDisks().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyAlpha, ok, err)
+	}
+	if obj, err := mock.AlphaRegionDisks().GetOrCreate(ctx, keyAlpha, &alpha.Disk{Name: "other"}); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaRegionDisks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.AlphaRegionDisks().GetOrCreate(ctx, key, &alpha.Disk{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("AlphaRegionDisks().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockAlphaRegionDisks.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaRegionDisks().Get(ctx, keyAlpha); err != nil || obj.Name != keyAlpha.Name {
+		t.Errorf("AlphaRegionDisks().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyAlpha, obj, err, keyAlpha.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyAlpha.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.AlphaRegionDisks().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaRegionDisks().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaRegionDisks().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.AlphaRegionDisks().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaRegionDisks().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if _, err := mock.AlphaRegionDisks().Get(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionDisks().Get(%v, %v) = _, nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.AlphaRegionDisks().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionDisks().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+}
+
+func TestRegionDiskKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.RegionalKey("key", "location")
+	k := cloud.NewRegionDiskKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.RegionDiskKeyFrom(key); err != nil || got != k {
+		t.Errorf("RegionDiskKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.RegionDiskKeyFrom(wrongKey); err == nil {
+		t.Errorf("RegionDiskKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestRegionOperationsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.RegionalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.RegionOperations().Get(ctx, keyGA); err == nil {
+		t.Errorf("RegionOperations().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.RegionOperations().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("RegionOperations().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockRegionOperations.GetError[keyGA] = errInjected
+	if _, err := mock.RegionOperations().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("RegionOperations().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockRegionOperations.GetError, keyGA)
+	mock.MockRegionOperations.ListError = &errInjected
+	if _, err := mock.RegionOperations().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("RegionOperations().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockRegionOperations.ListError = nil
+	mock.MockRegionOperations.DeleteError[keyGA] = errInjected
+	if err := mock.RegionOperations().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("RegionOperations().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockRegionOperations.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockRegionOperations.Objects[keyGA] = newMockRegionOperationsObj(&ga.Operation{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.RegionOperations().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("RegionOperations().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.RegionOperations().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("RegionOperations().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.RegionOperations().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("RegionOperations().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("RegionOperations().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.RegionOperations().Delete(ctx, keyGA); err != nil {
+		t.Errorf("RegionOperations().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.RegionOperations().Get(ctx, keyGA); err == nil {
+		t.Errorf("RegionOperations().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.RegionOperations().Delete(ctx, keyGA); err == nil {
+		t.Errorf("RegionOperations().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestRegionOperationKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.RegionalKey("key", "location")
+	k := cloud.NewRegionOperationKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.RegionOperationKeyFrom(key); err != nil || got != k {
+		t.Errorf("RegionOperationKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.RegionOperationKeyFrom(wrongKey); err == nil {
+		t.Errorf("RegionOperationKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestRegionsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Regions is read-only.
+	if _, ok := mock.Regions().(interface {
+		Insert(context.Context, meta.Key, *ga.Region) error
+	}); ok {
+		t.Errorf("Regions() has Insert(); want read-only")
+	}
+	if _, ok := mock.Regions().(interface {
+		Delete(context.Context, meta.Key) error
+	}); ok {
+		t.Errorf("Regions() has Delete(); want read-only")
+	}
+
+	// Get not found.
+	if _, err := mock.Regions().Get(ctx, keyGA); err == nil {
+		t.Errorf("Regions().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Regions().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Regions().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockRegions.GetError[keyGA] = errInjected
+	if _, err := mock.Regions().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Regions().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockRegions.GetError, keyGA)
+	mock.MockRegions.ListError = &errInjected
+	if _, err := mock.Regions().List(ctx, filter.None); err != errInjected {
+		t.Errorf("Regions().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockRegions.ListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockRegions.Objects[keyGA] = newMockRegionsObj(&ga.Region{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.Regions().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Regions().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.Regions().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Regions().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.Regions().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Regions().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Regions().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+}
+
+func TestRegionKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewRegionKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.RegionKeyFrom(key); err != nil || got != k {
+		t.Errorf("RegionKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.RegionKeyFrom(wrongKey); err == nil {
+		t.Errorf("RegionKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestRoutesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.Routes().Get(ctx, keyGA); err == nil {
+		t.Errorf("Routes().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Routes().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Routes().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockRoutes.GetError[keyGA] = errInjected
+	if _, err := mock.Routes().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Routes().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockRoutes.GetError, keyGA)
+	mock.MockRoutes.ListError = &errInjected
+	if _, err := mock.Routes().List(ctx, filter.None); err != errInjected {
+		t.Errorf("Routes().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockRoutes.ListError = nil
+	mock.MockRoutes.InsertError[keyGA] = errInjected
+	if err := mock.Routes().Insert(ctx, keyGA, &ga.Route{}); err != errInjected {
+		t.Errorf("Routes().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockRoutes.InsertError, keyGA)
+	mock.MockRoutes.DeleteError[keyGA] = errInjected
+	if err := mock.Routes().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("Routes().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockRoutes.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.Routes().Insert(ctx, keyGA, &ga.Route{Name: keyGA.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("Routes().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyGA, err)
+	}
+	if err := mock.Routes().Insert(ctx, keyGA, &ga.Route{Name: keyGA.Name, Network: "network", DestRange: "destRange"}); err != nil {
+		t.Errorf("Routes().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.Routes().Insert(ctx, keyGA, &ga.Route{Name: keyGA.Name, Network: "network", DestRange: "destRange"}); err == nil {
+		t.Errorf("Routes().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.Routes().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Routes().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.Routes().GetOrCreate(ctx, keyGA, &ga.Route{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Routes().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.Routes().GetOrCreate(ctx, key, &ga.Route{Name: key.Name, Network: "network", DestRange: "destRange"}); err != nil || obj.Name != key.Name {
+			t.Errorf("Routes().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockRoutes.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.Routes().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Routes().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.Routes().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Routes().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Routes().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.Routes().Delete(ctx, keyGA); err != nil {
+		t.Errorf("Routes().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.Routes().Get(ctx, keyGA); err == nil {
+		t.Errorf("Routes().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.Routes().Delete(ctx, keyGA); err == nil {
+		t.Errorf("Routes().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestRouteKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewRouteKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.RouteKeyFrom(key); err != nil || got != k {
+		t.Errorf("RouteKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.RouteKeyFrom(wrongKey); err == nil {
+		t.Errorf("RouteKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestSslCertificatesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.SslCertificates().Get(ctx, keyGA); err == nil {
+		t.Errorf("SslCertificates().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.SslCertificates().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("SslCertificates().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockSslCertificates.GetError[keyGA] = errInjected
+	if _, err := mock.SslCertificates().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("SslCertificates().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockSslCertificates.GetError, keyGA)
+	mock.MockSslCertificates.ListError = &errInjected
+	if _, err := mock.SslCertificates().List(ctx, filter.None); err != errInjected {
+		t.Errorf("SslCertificates().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockSslCertificates.ListError = nil
+	mock.MockSslCertificates.InsertError[keyGA] = errInjected
+	if err := mock.SslCertificates().Insert(ctx, keyGA, &ga.SslCertificate{}); err != errInjected {
+		t.Errorf("SslCertificates().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockSslCertificates.InsertError, keyGA)
+	mock.MockSslCertificates.DeleteError[keyGA] = errInjected
+	if err := mock.SslCertificates().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("SslCertificates().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockSslCertificates.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.SslCertificates().Insert(ctx, keyGA, &ga.SslCertificate{Name: keyGA.Name}); err != nil {
+		t.Errorf("SslCertificates().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.SslCertificates().Insert(ctx, keyGA, &ga.SslCertificate{Name: keyGA.Name}); err == nil {
+		t.Errorf("SslCertificates().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.SslCertificates().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("SslCertificates().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.SslCertificates().GetOrCreate(ctx, keyGA, &ga.SslCertificate{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("SslCertificates().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.SslCertificates().GetOrCreate(ctx, key, &ga.SslCertificate{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("SslCertificates().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockSslCertificates.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.SslCertificates().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("SslCertificates().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.SslCertificates().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("SslCertificates().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("SslCertificates().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.SslCertificates().Delete(ctx, keyGA); err != nil {
+		t.Errorf("SslCertificates().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.SslCertificates().Get(ctx, keyGA); err == nil {
+		t.Errorf("SslCertificates().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.SslCertificates().Delete(ctx, keyGA); err == nil {
+		t.Errorf("SslCertificates().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestSslCertificateKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewSslCertificateKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.SslCertificateKeyFrom(key); err != nil || got != k {
+		t.Errorf("SslCertificateKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.SslCertificateKeyFrom(wrongKey); err == nil {
+		t.Errorf("SslCertificateKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestTargetHttpProxiesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.TargetHttpProxies().Get(ctx, keyGA); err == nil {
+		t.Errorf("TargetHttpProxies().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.TargetHttpProxies().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("TargetHttpProxies().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockTargetHttpProxies.GetError[keyGA] = errInjected
+	if _, err := mock.TargetHttpProxies().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("TargetHttpProxies().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetHttpProxies.GetError, keyGA)
+	mock.MockTargetHttpProxies.ListError = &errInjected
+	if _, err := mock.TargetHttpProxies().List(ctx, filter.None); err != errInjected {
+		t.Errorf("TargetHttpProxies().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockTargetHttpProxies.ListError = nil
+	mock.MockTargetHttpProxies.InsertError[keyGA] = errInjected
+	if err := mock.TargetHttpProxies().Insert(ctx, keyGA, &ga.TargetHttpProxy{}); err != errInjected {
+		t.Errorf("TargetHttpProxies().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetHttpProxies.InsertError, keyGA)
+	mock.MockTargetHttpProxies.DeleteError[keyGA] = errInjected
+	if err := mock.TargetHttpProxies().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("TargetHttpProxies().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetHttpProxies.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.TargetHttpProxies().Insert(ctx, keyGA, &ga.TargetHttpProxy{Name: keyGA.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("TargetHttpProxies().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyGA, err)
+	}
+	if err := mock.TargetHttpProxies().Insert(ctx, keyGA, &ga.TargetHttpProxy{Name: keyGA.Name, UrlMap: "urlMap"}); err != nil {
+		t.Errorf("TargetHttpProxies().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.TargetHttpProxies().Insert(ctx, keyGA, &ga.TargetHttpProxy{Name: keyGA.Name, UrlMap: "urlMap"}); err == nil {
+		t.Errorf("TargetHttpProxies().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.TargetHttpProxies().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("TargetHttpProxies().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.TargetHttpProxies().GetOrCreate(ctx, keyGA, &ga.TargetHttpProxy{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("TargetHttpProxies().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.TargetHttpProxies().GetOrCreate(ctx, key, &ga.TargetHttpProxy{Name: key.Name, UrlMap: "urlMap"}); err != nil || obj.Name != key.Name {
+			t.Errorf("TargetHttpProxies().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockTargetHttpProxies.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.TargetHttpProxies().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("TargetHttpProxies().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.TargetHttpProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("TargetHttpProxies().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TargetHttpProxies().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.TargetHttpProxies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("TargetHttpProxies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.TargetHttpProxies().Get(ctx, keyGA); err == nil {
+		t.Errorf("TargetHttpProxies().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.TargetHttpProxies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("TargetHttpProxies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestTargetHttpProxyKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewTargetHttpProxyKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.TargetHttpProxyKeyFrom(key); err != nil || got != k {
+		t.Errorf("TargetHttpProxyKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.TargetHttpProxyKeyFrom(wrongKey); err == nil {
+		t.Errorf("TargetHttpProxyKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestTargetHttpsProxiesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.TargetHttpsProxies().Get(ctx, keyGA); err == nil {
+		t.Errorf("TargetHttpsProxies().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.TargetHttpsProxies().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("TargetHttpsProxies().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockTargetHttpsProxies.GetError[keyGA] = errInjected
+	if _, err := mock.TargetHttpsProxies().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("TargetHttpsProxies().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetHttpsProxies.GetError, keyGA)
+	mock.MockTargetHttpsProxies.ListError = &errInjected
+	if _, err := mock.TargetHttpsProxies().List(ctx, filter.None); err != errInjected {
+		t.Errorf("TargetHttpsProxies().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockTargetHttpsProxies.ListError = nil
+	mock.MockTargetHttpsProxies.InsertError[keyGA] = errInjected
+	if err := mock.TargetHttpsProxies().Insert(ctx, keyGA, &ga.TargetHttpsProxy{}); err != errInjected {
+		t.Errorf("TargetHttpsProxies().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetHttpsProxies.InsertError, keyGA)
+	mock.MockTargetHttpsProxies.DeleteError[keyGA] = errInjected
+	if err := mock.TargetHttpsProxies().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("TargetHttpsProxies().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetHttpsProxies.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.TargetHttpsProxies().Insert(ctx, keyGA, &ga.TargetHttpsProxy{Name: keyGA.Name}); !cloud.IsBadRequest(err) {
+		t.Errorf("TargetHttpsProxies().Insert(%v, %v, <no required fields>) = %v; want http.StatusBadRequest", ctx, keyGA, err)
+	}
+	if err := mock.TargetHttpsProxies().Insert(ctx, keyGA, &ga.TargetHttpsProxy{Name: keyGA.Name, UrlMap: "urlMap", SslCertificates: []string{"sslCertificates"}}); err != nil {
+		t.Errorf("TargetHttpsProxies().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.TargetHttpsProxies().Insert(ctx, keyGA, &ga.TargetHttpsProxy{Name: keyGA.Name, UrlMap: "urlMap", SslCertificates: []string{"sslCertificates"}}); err == nil {
+		t.Errorf("TargetHttpsProxies().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.TargetHttpsProxies().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("TargetHttpsProxies().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.TargetHttpsProxies().GetOrCreate(ctx, keyGA, &ga.TargetHttpsProxy{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("TargetHttpsProxies().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.TargetHttpsProxies().GetOrCreate(ctx, key, &ga.TargetHttpsProxy{Name: key.Name, UrlMap: "urlMap", SslCertificates: []string{"sslCertificates"}}); err != nil || obj.Name != key.Name {
+			t.Errorf("TargetHttpsProxies().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockTargetHttpsProxies.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.TargetHttpsProxies().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("TargetHttpsProxies().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.TargetHttpsProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("TargetHttpsProxies().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TargetHttpsProxies().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.TargetHttpsProxies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("TargetHttpsProxies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.TargetHttpsProxies().Get(ctx, keyGA); err == nil {
+		t.Errorf("TargetHttpsProxies().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.TargetHttpsProxies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("TargetHttpsProxies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestTargetHttpsProxyKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewTargetHttpsProxyKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.TargetHttpsProxyKeyFrom(key); err != nil || got != k {
+		t.Errorf("TargetHttpsProxyKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.TargetHttpsProxyKeyFrom(wrongKey); err == nil {
+		t.Errorf("TargetHttpsProxyKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestTargetPoolsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.RegionalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.TargetPools().Get(ctx, keyGA); err == nil {
+		t.Errorf("TargetPools().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.TargetPools().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("TargetPools().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockTargetPools.GetError[keyGA] = errInjected
+	if _, err := mock.TargetPools().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("TargetPools().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetPools.GetError, keyGA)
+	mock.MockTargetPools.ListError = &errInjected
+	if _, err := mock.TargetPools().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("TargetPools().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockTargetPools.ListError = nil
+	mock.MockTargetPools.InsertError[keyGA] = errInjected
+	if err := mock.TargetPools().Insert(ctx, keyGA, &ga.TargetPool{}); err != errInjected {
+		t.Errorf("TargetPools().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetPools.InsertError, keyGA)
+	mock.MockTargetPools.DeleteError[keyGA] = errInjected
+	if err := mock.TargetPools().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("TargetPools().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockTargetPools.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.TargetPools().Insert(ctx, keyGA, &ga.TargetPool{Name: keyGA.Name}); err != nil {
+		t.Errorf("TargetPools().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.TargetPools().Insert(ctx, keyGA, &ga.TargetPool{Name: keyGA.Name}); err == nil {
+		t.Errorf("TargetPools().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.TargetPools().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("TargetPools().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.TargetPools().GetOrCreate(ctx, keyGA, &ga.TargetPool{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("TargetPools().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.RegionalKey("key-created", "location")
+		if obj, err := mock.TargetPools().GetOrCreate(ctx, key, &ga.TargetPool{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("TargetPools().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockTargetPools.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.TargetPools().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("TargetPools().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.TargetPools().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("TargetPools().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TargetPools().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.TargetPools().Delete(ctx, keyGA); err != nil {
+		t.Errorf("TargetPools().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.TargetPools().Get(ctx, keyGA); err == nil {
+		t.Errorf("TargetPools().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.TargetPools().Delete(ctx, keyGA); err == nil {
+		t.Errorf("TargetPools().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestTargetPoolKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.RegionalKey("key", "location")
+	k := cloud.NewTargetPoolKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.TargetPoolKeyFrom(key); err != nil || got != k {
+		t.Errorf("TargetPoolKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.TargetPoolKeyFrom(wrongKey); err == nil {
+		t.Errorf("TargetPoolKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestUrlMapsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.UrlMaps().Get(ctx, keyGA); err == nil {
+		t.Errorf("UrlMaps().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.UrlMaps().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("UrlMaps().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockUrlMaps.GetError[keyGA] = errInjected
+	if _, err := mock.UrlMaps().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("UrlMaps().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockUrlMaps.GetError, keyGA)
+	mock.MockUrlMaps.ListError = &errInjected
+	if _, err := mock.UrlMaps().List(ctx, filter.None); err != errInjected {
+		t.Errorf("UrlMaps().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockUrlMaps.ListError = nil
+	mock.MockUrlMaps.InsertError[keyGA] = errInjected
+	if err := mock.UrlMaps().Insert(ctx, keyGA, &ga.UrlMap{}); err != errInjected {
+		t.Errorf("UrlMaps().Insert(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockUrlMaps.InsertError, keyGA)
+	mock.MockUrlMaps.DeleteError[keyGA] = errInjected
+	if err := mock.UrlMaps().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("UrlMaps().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockUrlMaps.DeleteError, keyGA)
+	mock.MockUrlMaps.UpdateError[keyGA] = errInjected
+	if err := mock.UrlMaps().Update(ctx, keyGA, &ga.UrlMap{}); err != errInjected {
+		t.Errorf("UrlMaps().Update(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockUrlMaps.UpdateError, keyGA)
+	mock.MockUrlMaps.PatchError[keyGA] = errInjected
+	if err := mock.UrlMaps().Patch(ctx, keyGA, &ga.UrlMap{}); err != errInjected {
+		t.Errorf("UrlMaps().Patch(%v, %v, _) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockUrlMaps.PatchError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	if err := mock.UrlMaps().Insert(ctx, keyGA, &ga.UrlMap{Name: keyGA.Name}); err != nil {
+		t.Errorf("UrlMaps().Insert(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if err := mock.UrlMaps().Insert(ctx, keyGA, &ga.UrlMap{Name: keyGA.Name}); err == nil {
+		t.Errorf("UrlMaps().Insert(%v, %v, _) = nil; want error", ctx, keyGA)
+	}
+
+	// Update and Patch.
+	if err := mock.UrlMaps().Update(ctx, *meta.GlobalKey("key-missing"), &ga.UrlMap{}); err == nil {
+		t.Errorf("UrlMaps().Update(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.UrlMaps().Update(ctx, keyGA, &ga.UrlMap{Name: keyGA.Name, Description: "updated"}); err != nil {
+		t.Errorf("UrlMaps().Update(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.UrlMaps().Get(ctx, keyGA); err != nil || obj.Description != "updated" {
+		t.Errorf("UrlMaps().Get(%v, %v) = %+v, %v; want object with Description %q, nil", ctx, keyGA, obj, err, "updated")
+	}
+	if err := mock.UrlMaps().Patch(ctx, *meta.GlobalKey("key-missing"), &ga.UrlMap{}); err == nil {
+		t.Errorf("UrlMaps().Patch(%v, key-missing, _) = nil; want error", ctx)
+	}
+	if err := mock.UrlMaps().Patch(ctx, keyGA, &ga.UrlMap{Description: "patched"}); err != nil {
+		t.Errorf("UrlMaps().Patch(%v, %v, _) = %v; want nil", ctx, keyGA, err)
+	}
+	if obj, err := mock.UrlMaps().Get(ctx, keyGA); err != nil || obj.Description != "patched" || obj.Name != keyGA.Name {
+		t.Errorf("UrlMaps().Get(%v, %v) = %+v, %v; want object with Name %q, Description %q, nil", ctx, keyGA, obj, err, keyGA.Name, "patched")
+	}
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.UrlMaps().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("UrlMaps().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+	if obj, err := mock.UrlMaps().GetOrCreate(ctx, keyGA, &ga.UrlMap{Name: "other"}); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("UrlMaps().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+	{
+		key := *meta.GlobalKey("key-created")
+		if obj, err := mock.UrlMaps().GetOrCreate(ctx, key, &ga.UrlMap{Name: key.Name}); err != nil || obj.Name != key.Name {
+			t.Errorf("UrlMaps().GetOrCreate(%v, %v, _) = %+v, %v; want object with Name %q, nil", ctx, key, obj, err, key.Name)
+		}
+		delete(mock.MockUrlMaps.Objects, key)
+	}
+
+	// Get across versions.
+	if obj, err := mock.UrlMaps().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("UrlMaps().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.UrlMaps().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("UrlMaps().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("UrlMaps().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.UrlMaps().Delete(ctx, keyGA); err != nil {
+		t.Errorf("UrlMaps().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.UrlMaps().Get(ctx, keyGA); err == nil {
+		t.Errorf("UrlMaps().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.UrlMaps().Delete(ctx, keyGA); err == nil {
+		t.Errorf("UrlMaps().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestUrlMapKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewUrlMapKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.UrlMapKeyFrom(key); err != nil || got != k {
+		t.Errorf("UrlMapKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.UrlMapKeyFrom(wrongKey); err == nil {
+		t.Errorf("UrlMapKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestZoneOperationsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.ZonalKey("key-ga", "location")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Get not found.
+	if _, err := mock.ZoneOperations().Get(ctx, keyGA); err == nil {
+		t.Errorf("ZoneOperations().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.ZoneOperations().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("ZoneOperations().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockZoneOperations.GetError[keyGA] = errInjected
+	if _, err := mock.ZoneOperations().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("ZoneOperations().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockZoneOperations.GetError, keyGA)
+	mock.MockZoneOperations.ListError = &errInjected
+	if _, err := mock.ZoneOperations().List(ctx, location, filter.None); err != errInjected {
+		t.Errorf("ZoneOperations().List(%v, %q, _) = _, %v; want %v", ctx, location, err, errInjected)
+	}
+	mock.MockZoneOperations.ListError = nil
+	mock.MockZoneOperations.DeleteError[keyGA] = errInjected
+	if err := mock.ZoneOperations().Delete(ctx, keyGA); err != errInjected {
+		t.Errorf("ZoneOperations().Delete(%v, %v) = %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockZoneOperations.DeleteError, keyGA)
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockZoneOperations.Objects[keyGA] = newMockZoneOperationsObj(&ga.Operation{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.ZoneOperations().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("ZoneOperations().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.ZoneOperations().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("ZoneOperations().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.ZoneOperations().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("ZoneOperations().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ZoneOperations().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+	if err := mock.ZoneOperations().Delete(ctx, keyGA); err != nil {
+		t.Errorf("ZoneOperations().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+	if _, err := mock.ZoneOperations().Get(ctx, keyGA); err == nil {
+		t.Errorf("ZoneOperations().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if err := mock.ZoneOperations().Delete(ctx, keyGA); err == nil {
+		t.Errorf("ZoneOperations().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestZoneOperationKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.ZonalKey("key", "location")
+	k := cloud.NewZoneOperationKey("key", location)
+	wrongKey := *meta.GlobalKey("key")
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.ZoneOperationKeyFrom(key); err != nil || got != k {
+		t.Errorf("ZoneOperationKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.ZoneOperationKeyFrom(wrongKey); err == nil {
+		t.Errorf("ZoneOperationKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestZonesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyGA := *meta.GlobalKey("key-ga")
+	// Ignore unused variables.
+	_, _ = ctx, mock
+
+	// Zones is read-only.
+	if _, ok := mock.Zones().(interface {
+		Insert(context.Context, meta.Key, *ga.Zone) error
+	}); ok {
+		t.Errorf("Zones() has Insert(); want read-only")
+	}
+	if _, ok := mock.Zones().(interface {
+		Delete(context.Context, meta.Key) error
+	}); ok {
+		t.Errorf("Zones() has Delete(); want read-only")
+	}
+
+	// Get not found.
+	if _, err := mock.Zones().Get(ctx, keyGA); err == nil {
+		t.Errorf("Zones().Get(%v, %v) = _, nil; want error", ctx, keyGA)
+	}
+	if ok, err := mock.Zones().Exists(ctx, keyGA); ok || err != nil {
+		t.Errorf("Zones().Exists(%v, %v) = %t, %v; want false, nil", ctx, keyGA, ok, err)
+	}
+
+	// Injected errors.
+	mock.MockZones.GetError[keyGA] = errInjected
+	if _, err := mock.Zones().Get(ctx, keyGA); err != errInjected {
+		t.Errorf("Zones().Get(%v, %v) = _, %v; want %v", ctx, keyGA, err, errInjected)
+	}
+	delete(mock.MockZones.GetError, keyGA)
+	mock.MockZones.ListError = &errInjected
+	if _, err := mock.Zones().List(ctx, filter.None); err != errInjected {
+		t.Errorf("Zones().List(%v, _) = _, %v; want %v", ctx, err, errInjected)
+	}
+	mock.MockZones.ListError = nil
+
+	// Insert. Objects for services without Insert() are added to the mock
+	// directly.
+	mock.MockZones.Objects[keyGA] = newMockZonesObj(&ga.Zone{Name: keyGA.Name})
+
+	// Exists and GetOrCreate.
+	if ok, err := mock.Zones().Exists(ctx, keyGA); !ok || err != nil {
+		t.Errorf("Zones().Exists(%v, %v) = %t, %v; want true, nil", ctx, keyGA, ok, err)
+	}
+
+	// Get across versions.
+	if obj, err := mock.Zones().Get(ctx, keyGA); err != nil || obj.Name != keyGA.Name {
+		t.Errorf("Zones().Get(%v, %v) = %+v, %v; want object with Name %q, nil", ctx, keyGA, obj, err, keyGA.Name)
+	}
+
+	// List.
+	want := map[string]bool{
+		keyGA.Name: true,
+	}
+	_ = want // Ignore unused variables.
+	{
+		objs, err := mock.Zones().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Zones().List(%v, _) = _, %v; want _, nil", ctx, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Zones().List(%v, _) = %v; want %v", ctx, got, want)
+			}
+		}
+	}
+
+	// Delete.
+}
+
+func TestZoneKey(t *testing.T) {
+	t.Parallel()
+
+	key := *meta.GlobalKey("key")
+	k := cloud.NewZoneKey("key")
+	wrongKey := *meta.ZonalKey("key", location)
+	if got := k.Key(); got != key {
+		t.Errorf("%+v.Key() = %v; want %v", k, got, key)
+	}
+	if got, err := cloud.ZoneKeyFrom(key); err != nil || got != k {
+		t.Errorf("ZoneKeyFrom(%v) = %+v, %v; want %+v, nil", key, got, err, k)
+	}
+	if _, err := cloud.ZoneKeyFrom(wrongKey); err == nil {
+		t.Errorf("ZoneKeyFrom(%v) = _, nil; want error", wrongKey)
+	}
+}
+
+func TestConversions(t *testing.T) {
+	t.Parallel()
+
+	testConversion(t, "GAAddressToAlpha", cloud.GAAddressToAlpha)
+	testConversion(t, "GAAddressToBeta", cloud.GAAddressToBeta)
+	testConversion(t, "AlphaAddressToGA", cloud.AlphaAddressToGA)
+	testConversion(t, "AlphaAddressToBeta", cloud.AlphaAddressToBeta)
+	testConversion(t, "BetaAddressToGA", cloud.BetaAddressToGA)
+	testConversion(t, "BetaAddressToAlpha", cloud.BetaAddressToAlpha)
+	testConversion(t, "GABackendServiceToAlpha", cloud.GABackendServiceToAlpha)
+	testConversion(t, "AlphaBackendServiceToGA", cloud.AlphaBackendServiceToGA)
+	testConversion(t, "GADiskToAlpha", cloud.GADiskToAlpha)
+	testConversion(t, "AlphaDiskToGA", cloud.AlphaDiskToGA)
+	testConversion(t, "GAForwardingRuleToAlpha", cloud.GAForwardingRuleToAlpha)
+	testConversion(t, "AlphaForwardingRuleToGA", cloud.AlphaForwardingRuleToGA)
+	testConversion(t, "GAHealthCheckToAlpha", cloud.GAHealthCheckToAlpha)
+	testConversion(t, "AlphaHealthCheckToGA", cloud.AlphaHealthCheckToGA)
+	testConversion(t, "GAInstanceToAlpha", cloud.GAInstanceToAlpha)
+	testConversion(t, "GAInstanceToBeta", cloud.GAInstanceToBeta)
+	testConversion(t, "AlphaInstanceToGA", cloud.AlphaInstanceToGA)
+	testConversion(t, "AlphaInstanceToBeta", cloud.AlphaInstanceToBeta)
+	testConversion(t, "BetaInstanceToGA", cloud.BetaInstanceToGA)
+	testConversion(t, "BetaInstanceToAlpha", cloud.BetaInstanceToAlpha)
+}
+
+func TestDeepCopies(t *testing.T) {
+	t.Parallel()
+
+	testDeepCopy(t, "CopyGAAddress", cloud.CopyGAAddress)
+	testDeepCopy(t, "CopyAlphaAddress", cloud.CopyAlphaAddress)
+	testDeepCopy(t, "CopyBetaAddress", cloud.CopyBetaAddress)
+	testDeepCopy(t, "CopyGABackendService", cloud.CopyGABackendService)
+	testDeepCopy(t, "CopyAlphaBackendService", cloud.CopyAlphaBackendService)
+	testDeepCopy(t, "CopyGADisk", cloud.CopyGADisk)
+	testDeepCopy(t, "CopyAlphaDisk", cloud.CopyAlphaDisk)
+	testDeepCopy(t, "CopyGADiskType", cloud.CopyGADiskType)
+	testDeepCopy(t, "CopyGAFirewall", cloud.CopyGAFirewall)
+	testDeepCopy(t, "CopyGAForwardingRule", cloud.CopyGAForwardingRule)
+	testDeepCopy(t, "CopyAlphaForwardingRule", cloud.CopyAlphaForwardingRule)
+	testDeepCopy(t, "CopyGAHealthCheck", cloud.CopyGAHealthCheck)
+	testDeepCopy(t, "CopyAlphaHealthCheck", cloud.CopyAlphaHealthCheck)
+	testDeepCopy(t, "CopyGAHttpHealthCheck", cloud.CopyGAHttpHealthCheck)
+	testDeepCopy(t, "CopyGAHttpsHealthCheck", cloud.CopyGAHttpsHealthCheck)
+	testDeepCopy(t, "CopyGAInstance", cloud.CopyGAInstance)
+	testDeepCopy(t, "CopyAlphaInstance", cloud.CopyAlphaInstance)
+	testDeepCopy(t, "CopyBetaInstance", cloud.CopyBetaInstance)
+	testDeepCopy(t, "CopyGAInstanceGroup", cloud.CopyGAInstanceGroup)
+	testDeepCopy(t, "CopyGAMachineType", cloud.CopyGAMachineType)
+	testDeepCopy(t, "CopyAlphaNetworkEndpointGroup", cloud.CopyAlphaNetworkEndpointGroup)
+	testDeepCopy(t, "CopyGAOperation", cloud.CopyGAOperation)
+	testDeepCopy(t, "CopyGAProject", cloud.CopyGAProject)
+	testDeepCopy(t, "CopyGARegion", cloud.CopyGARegion)
+	testDeepCopy(t, "CopyGARoute", cloud.CopyGARoute)
+	testDeepCopy(t, "CopyGASslCertificate", cloud.CopyGASslCertificate)
+	testDeepCopy(t, "CopyGATargetHttpProxy", cloud.CopyGATargetHttpProxy)
+	testDeepCopy(t, "CopyGATargetHttpsProxy", cloud.CopyGATargetHttpsProxy)
+	testDeepCopy(t, "CopyGATargetPool", cloud.CopyGATargetPool)
+	testDeepCopy(t, "CopyGAUrlMap", cloud.CopyGAUrlMap)
+	testDeepCopy(t, "CopyGAZone", cloud.CopyGAZone)
+}
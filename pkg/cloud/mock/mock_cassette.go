@@ -0,0 +1,127 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// LoadCassette stores in the mocks the objects returned by the calls
+// recorded in c by a cloud.RecordingCloud (Get, List, AggregatedList, ...),
+// as last seen in the recording: an object is dropped once a later call
+// deletes it or fails to find it. Like Load, it replaces the objects with the
+// same keys and does not modify the mocks if c is invalid. This turns a
+// recording of the real API into a fixture for the tests that need to modify
+// the objects, which a cloud.ReplayCloud cannot.
+//
+// The objects are told apart by their SelfLink; the objects only seen as the
+// arguments of Insert, without their output only fields, are not stored.
+func (mock *MockGCE) LoadCassette(c *cloud.Cassette) error {
+	stores := mock.stateStores()
+	state := map[string]map[meta.Key]json.RawMessage{}
+	for i, in := range c.Interactions {
+		r, ok := cloud.LookupResource(in.Service, in.Version)
+		if _, known := stores[in.Service]; !ok || !known {
+			return fmt.Errorf("cassette: interaction %d: unknown service %s %q", i, in.Version, in.Service)
+		}
+		objs := state[in.Service]
+		if objs == nil {
+			objs = map[meta.Key]json.RawMessage{}
+			state[in.Service] = objs
+		}
+		for _, res := range in.Results {
+			cassetteObjects(res, r, func(key meta.Key, obj json.RawMessage) {
+				objs[key] = obj
+			})
+		}
+		if in.Key != nil && removes(in) {
+			delete(objs, *in.Key)
+		}
+	}
+
+	var services []string
+	for service := range state {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	var applies []func()
+	for _, service := range services {
+		data, err := json.Marshal(state[service])
+		if err != nil {
+			return fmt.Errorf("cassette: %s: %v", service, err)
+		}
+		apply, err := stores[service].load(data)
+		if err != nil {
+			return fmt.Errorf("cassette: %s: %v", service, err)
+		}
+		applies = append(applies, apply)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	return nil
+}
+
+// removes is true if the recorded call in shows that the object of its key
+// does not exist anymore.
+func removes(in *cloud.Interaction) bool {
+	switch {
+	case in.Error != nil:
+		return in.Error.Code == http.StatusNotFound && (in.Operation == "Get" || in.Operation == "Exists")
+	case in.Operation == "Delete":
+		return true
+	case in.Operation == "DeleteOp":
+		return in.Op != nil && in.Op.Done && in.Op.Error == nil
+	case in.Operation == "Exists":
+		var exists bool
+		return len(in.Results) == 1 && json.Unmarshal(in.Results[0], &exists) == nil && !exists
+	}
+	return false
+}
+
+// cassetteObjects calls add for each object of the resource r in data, the
+// JSON of a recorded result: an object, or a list or map of objects.
+func cassetteObjects(data json.RawMessage, r *cloud.Resource, add func(key meta.Key, obj json.RawMessage)) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) == nil {
+		var selfLink string
+		if json.Unmarshal(fields["selfLink"], &selfLink) == nil && selfLink != "" {
+			resource, key, err := cloud.KeyFromResourceURL(selfLink)
+			if err == nil && resource == r.Resource && key.Type() == r.KeyType {
+				add(*key, data)
+			}
+			return
+		}
+		for _, v := range fields {
+			cassetteObjects(v, r, add)
+		}
+		return
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(data, &items) == nil {
+		for _, item := range items {
+			cassetteObjects(item, r, add)
+		}
+	}
+}
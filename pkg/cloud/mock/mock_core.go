@@ -0,0 +1,652 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// mockStore is the common core shared by the generated mocks. It is embedded
+// in each Mockxxx type and implements the standard mock behavior on top of
+// the shared map of objects.
+//
+// T is the type of the resource object (e.g. ga.Address) and O is the type
+// of the object stored in the shared map (e.g. MockAddressesObj).
+type mockStore[T, O any] struct {
+	Lock sync.Mutex
+
+	// Objects maintained by the mock.
+	Objects map[meta.Key]*O
+
+	// If an entry exists for the given key and operation, then the error
+	// will be returned instead of the operation.
+	GetError            map[meta.Key]error
+	ListError           *error
+	InsertError         map[meta.Key]error
+	DeleteError         map[meta.Key]error
+	UpdateError         map[meta.Key]error
+	PatchError          map[meta.Key]error
+	AggregatedListError *error
+
+	// Scenario, if set, scripts the outcomes of calls to the mock. See
+	// Scenario for details.
+	Scenario *Scenario
+
+	// Recorder, if set, records the calls to the mock. See Recorder for
+	// details.
+	Recorder *Recorder
+
+	// ProjectID is the project of the SelfLinks given to the inserted
+	// objects (DefaultProjectID unless changed).
+	ProjectID string
+
+	// name of the mock type (e.g. "MockAlphaAddresses").
+	name string
+	// version is the API version of the service.
+	version meta.Version
+	// service is the name of the service (e.g. "Addresses").
+	service string
+	// newObj wraps an object of any API version for storage in Objects.
+	newObj func(obj interface{}) *O
+	// toT converts a stored object to T.
+	toT func(*O) *T
+	// fingerprint, if set, returns the fingerprint field of an object. It is
+	// set for the services that use fingerprints for optimistic concurrency
+	// (see withFingerprint).
+	fingerprint func(*T) *string
+	// requiredFields are the fields that must be set on the objects passed to
+	// Insert (see withRequiredFields).
+	requiredFields []string
+	// identity, if set, sets the field of an inserted object identified by
+	// the name of its key (see withIdentity).
+	identity func(obj *T, key meta.Key) error
+	// quotas, if set, limits the objects that can be inserted (see
+	// MockQuotas).
+	quotas *MockQuotas
+	// ops, if set, simulates the operations of the mutations (see
+	// MockOperations).
+	ops *MockOperations
+}
+
+// newMockStore returns a mockStore using objs as the backing store.
+func newMockStore[T, O any](name string, version meta.Version, service string, objs map[meta.Key]*O, newObj func(interface{}) *O, toT func(*O) *T) *mockStore[T, O] {
+	return &mockStore[T, O]{
+		Objects:     objs,
+		GetError:    map[meta.Key]error{},
+		InsertError: map[meta.Key]error{},
+		DeleteError: map[meta.Key]error{},
+		UpdateError: map[meta.Key]error{},
+		PatchError:  map[meta.Key]error{},
+		ProjectID:   DefaultProjectID,
+		name:        name,
+		version:     version,
+		service:     service,
+		newObj:      newObj,
+		toT:         toT,
+	}
+}
+
+// withFingerprint makes the store maintain the fingerprint of the objects
+// like the compute API: inserted and modified objects are given a new
+// fingerprint, and Update and Patch fail with http.StatusPreconditionFailed if
+// the fingerprint of obj is set and is not the one of the current object.
+func (s *mockStore[T, O]) withFingerprint(fingerprint func(*T) *string) *mockStore[T, O] {
+	s.fingerprint = fingerprint
+	return s
+}
+
+// withRequiredFields makes Insert fail with http.StatusBadRequest if any of
+// fields (e.g. "Network") is not set on the object, like the compute API.
+func (s *mockStore[T, O]) withRequiredFields(fields ...string) *mockStore[T, O] {
+	s.requiredFields = fields
+	return s
+}
+
+// withIdentity makes Insert set the field of the object identified by the
+// name of the key (e.g. Name) with identity, like the GCE adapters.
+func (s *mockStore[T, O]) withIdentity(identity func(obj *T, key meta.Key) error) *mockStore[T, O] {
+	s.identity = identity
+	return s
+}
+
+// callError returns an error with the HTTP status code code for the call of
+// operation on the object at key (nil for the calls on a collection). Like
+// the errors of the GCE adapters, it is a *cloud.Error wrapping a
+// *googleapi.Error.
+func (s *mockStore[T, O]) callError(operation string, key *meta.Key, code int, format string, args ...interface{}) error {
+	return mockError(s.version, s.service, operation, key, code, fmt.Sprintf(format, args...))
+}
+
+// mockError returns an error with the HTTP status code code and message for
+// the call of operation of the service at version. See callError().
+func mockError(version meta.Version, service, operation string, key *meta.Key, code int, message string) error {
+	return mockAPIError(version, service, operation, key, &googleapi.Error{Code: code, Message: message})
+}
+
+// mockAPIError returns err as the error of the call of operation of the
+// service at version.
+func mockAPIError(version meta.Version, service, operation string, key *meta.Key, err *googleapi.Error) error {
+	e := &cloud.Error{
+		Resource:  &cloud.ResourceID{Key: key},
+		Version:   version,
+		Service:   service,
+		Operation: operation,
+		Code:      err.Code,
+		Err:       err,
+	}
+	if r, ok := cloud.LookupResource(service, version); ok {
+		e.Resource.Resource = r.Resource
+	}
+	return e
+}
+
+// get returns the object stored at key.
+func (s *mockStore[T, O]) get(key meta.Key) (*T, error) {
+	if o, ok := s.Scenario.next(s.service, "Get", &key); ok {
+		if o.Err != nil {
+			glog.V(5).Infof("%s.Get(%s) = nil, %v", s.name, key, o.Err)
+			return nil, o.Err
+		}
+		if o.Obj != nil {
+			typedObj := s.toT(s.newObj(o.Obj))
+			glog.V(5).Infof("%s.Get(%s) = %v, nil", s.name, key, typedObj)
+			return typedObj, nil
+		}
+	}
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if err, ok := s.GetError[key]; ok {
+		glog.V(5).Infof("%s.Get(%s) = nil, %v", s.name, key, err)
+		return nil, err
+	}
+	if obj, ok := s.Objects[key]; ok {
+		typedObj := s.toT(obj)
+		glog.V(5).Infof("%s.Get(%s) = %v, nil", s.name, key, typedObj)
+		return typedObj, nil
+	}
+
+	err := s.callError("Get", &key, http.StatusNotFound, "%s %v not found", s.name, key)
+	glog.V(5).Infof("%s.Get(%s) = nil, %v", s.name, key, err)
+	return nil, err
+}
+
+// list returns the objects matching fl. If inScope is non-nil, only the
+// objects with keys for which inScope is true are returned.
+func (s *mockStore[T, O]) list(fl *filter.F, inScope func(key meta.Key) bool) ([]*T, error) {
+	if o, ok := s.Scenario.next(s.service, "List", nil); ok && o.Err != nil {
+		glog.V(5).Infof("%s.List(%v) = nil, %v", s.name, fl, o.Err)
+		return nil, o.Err
+	}
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if s.ListError != nil {
+		err := *s.ListError
+		glog.V(5).Infof("%s.List(%v) = nil, %v", s.name, fl, err)
+		return nil, err
+	}
+
+	var keys []meta.Key
+	for key := range s.Objects {
+		if inScope == nil || inScope(key) {
+			keys = append(keys, key)
+		}
+	}
+	// The objects are listed in the order of their keys so that the pages
+	// of ListPage are stable.
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	var objs []*T
+	for _, key := range keys {
+		typedObj := s.toT(s.Objects[key])
+		if !fl.Match(typedObj) {
+			continue
+		}
+		objs = append(objs, typedObj)
+	}
+	glog.V(5).Infof("%s.List(%v) = %v, nil", s.name, fl, objs)
+	return objs, nil
+}
+
+// defaultMaxResults is the size of the pages of ListPage when maxResults is
+// 0, as in the API.
+const defaultMaxResults = 500
+
+// page returns the page of objs starting at the offset pageToken ("" for
+// the first page) with at most maxResults objects, and the token of the next
+// page ("" after the last page).
+func (s *mockStore[T, O]) page(objs []*T, pageToken string, maxResults int64) ([]*T, string, error) {
+	offset := 0
+	if pageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(pageToken); err != nil || offset < 0 || offset > len(objs) {
+			return nil, "", s.callError("List", nil, http.StatusBadRequest, "Invalid value for field 'pageToken': '%s'", pageToken)
+		}
+	}
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+	end := offset + int(maxResults)
+	if end >= len(objs) {
+		return objs[offset:], "", nil
+	}
+	return objs[offset:end], strconv.Itoa(end), nil
+}
+
+// insert stores obj at key.
+func (s *mockStore[T, O]) insert(ctx context.Context, key meta.Key, obj *T, opts []cloud.Option) error {
+	op, err := s.startInsert(key, obj)
+	return s.finish(ctx, op, err, opts)
+}
+
+// startInsert checks the insertion of obj at key and starts its operation.
+// As in the compute API, the quotas are checked last, so that inserting an
+// existing object fails with http.StatusConflict even at the limit.
+func (s *mockStore[T, O]) startInsert(key meta.Key, obj *T) (*mockOperation, error) {
+	if o, ok := s.Scenario.next(s.service, "Insert", &key); ok && o.Err != nil {
+		glog.V(5).Infof("%s.Insert(%v, %v) = %v", s.name, key, obj, o.Err)
+		return nil, o.Err
+	}
+
+	stored, err := s.checkInsert(key, obj)
+	if err != nil {
+		glog.V(5).Infof("%s.Insert(%v, %v) = %v", s.name, key, obj, err)
+		return nil, err
+	}
+	// The usage of the quotas is counted with the locks of the stores, so
+	// the quotas are checked without s.Lock.
+	release, err := s.quotas.check(s.version, s.service, key)
+	if err != nil {
+		glog.V(5).Infof("%s.Insert(%v, %v) = %v", s.name, key, obj, err)
+		return nil, err
+	}
+	defer release()
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	// The object may have been inserted since checkInsert.
+	if _, ok := s.Objects[key]; ok {
+		err := s.callError("Insert", &key, http.StatusConflict, "%s %v exists", s.name, key)
+		glog.V(5).Infof("%s.Insert(%v, %v) = %v", s.name, key, obj, err)
+		return nil, err
+	}
+	s.setOutputFields(key, stored, s.version)
+	if s.fingerprint != nil {
+		*s.fingerprint(stored) = newFingerprint()
+	}
+	op := s.commit("Insert", key, func() { s.Objects[key] = s.newObj(stored) })
+	glog.V(5).Infof("%s.Insert(%v, %v) = nil", s.name, key, obj)
+	return op, nil
+}
+
+// checkInsert returns the copy of obj to store at key, or the error of its
+// Insert other than exceeding a quota.
+func (s *mockStore[T, O]) checkInsert(key meta.Key, obj *T) (*T, error) {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if err, ok := s.InsertError[key]; ok {
+		return nil, err
+	}
+	stored := s.copy(obj)
+	if s.identity != nil {
+		if err := s.identity(stored, key); err != nil {
+			return nil, s.callError("Insert", &key, http.StatusBadRequest, "%v", err)
+		}
+	}
+	if field, ok := missingField(stored, s.requiredFields); ok {
+		return nil, s.callError("Insert", &key, http.StatusBadRequest, "Required field 'resource.%s' not specified", field)
+	}
+	if _, ok := s.Objects[key]; ok {
+		return nil, s.callError("Insert", &key, http.StatusConflict, "%s %v exists", s.name, key)
+	}
+	return stored, nil
+}
+
+// delete removes the object at key.
+func (s *mockStore[T, O]) delete(ctx context.Context, key meta.Key, opts []cloud.Option) error {
+	op, err := s.startDelete(key)
+	return s.finish(ctx, op, err, opts)
+}
+
+// startDelete checks the deletion of the object at key and starts its
+// operation.
+func (s *mockStore[T, O]) startDelete(key meta.Key) (*mockOperation, error) {
+	if o, ok := s.Scenario.next(s.service, "Delete", &key); ok && o.Err != nil {
+		glog.V(5).Infof("%s.Delete(%v) = %v", s.name, key, o.Err)
+		return nil, o.Err
+	}
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if err, ok := s.DeleteError[key]; ok {
+		glog.V(5).Infof("%s.Delete(%v) = %v", s.name, key, err)
+		return nil, err
+	}
+	if _, ok := s.Objects[key]; !ok {
+		err := s.callError("Delete", &key, http.StatusNotFound, "%s %v not found", s.name, key)
+		glog.V(5).Infof("%s.Delete(%v) = %v", s.name, key, err)
+		return nil, err
+	}
+
+	op := s.commit("Delete", key, func() { delete(s.Objects, key) })
+	glog.V(5).Infof("%s.Delete(%v) = nil", s.name, key)
+	return op, nil
+}
+
+// update replaces the object stored at key with obj.
+func (s *mockStore[T, O]) update(ctx context.Context, key meta.Key, obj *T, opts []cloud.Option) error {
+	return s.modify(ctx, "Update", s.UpdateError, key, obj, opts, func(*T) *T { return obj })
+}
+
+// patch merges the fields set in obj into the object stored at key. As with
+// the compute API, fields with empty values in obj are left unchanged.
+func (s *mockStore[T, O]) patch(ctx context.Context, key meta.Key, obj *T, opts []cloud.Option) error {
+	return s.modify(ctx, "Patch", s.PatchError, key, obj, opts, func(current *T) *T {
+		patched := new(T)
+		if err := copyViaJSON(patched, current); err != nil {
+			glog.Errorf("Could not copy %T via JSON: %v", current, err)
+		}
+		if err := copyViaJSON(patched, obj); err != nil {
+			glog.Errorf("Could not patch %T via JSON: %v", current, err)
+		}
+		return patched
+	})
+}
+
+// modify replaces the object stored at key with the result of f. It
+// implements the common logic for update and patch.
+func (s *mockStore[T, O]) modify(ctx context.Context, operation string, errors map[meta.Key]error, key meta.Key, obj *T, opts []cloud.Option, f func(current *T) *T) error {
+	op, err := s.startModify(operation, errors, key, obj, f)
+	return s.finish(ctx, op, err, opts)
+}
+
+// startModify checks the modification of the object at key and starts its
+// operation.
+func (s *mockStore[T, O]) startModify(operation string, errors map[meta.Key]error, key meta.Key, obj *T, f func(current *T) *T) (*mockOperation, error) {
+	if o, ok := s.Scenario.next(s.service, operation, &key); ok && o.Err != nil {
+		glog.V(5).Infof("%s.%s(%v, %v) = %v", s.name, operation, key, obj, o.Err)
+		return nil, o.Err
+	}
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if err, ok := errors[key]; ok {
+		glog.V(5).Infof("%s.%s(%v, %v) = %v", s.name, operation, key, obj, err)
+		return nil, err
+	}
+	current, ok := s.Objects[key]
+	if !ok {
+		err := s.callError(operation, &key, http.StatusNotFound, "%s %v not found", s.name, key)
+		glog.V(5).Infof("%s.%s(%v, %v) = %v", s.name, operation, key, obj, err)
+		return nil, err
+	}
+
+	if s.fingerprint != nil && obj != nil {
+		if fp := *s.fingerprint(obj); fp != "" && fp != *s.fingerprint(s.toT(current)) {
+			err := s.callError(operation, &key, http.StatusPreconditionFailed, "%s %v: fingerprint %q is not the current fingerprint", s.name, key, fp)
+			glog.V(5).Infof("%s.%s(%v, %v) = %v", s.name, operation, key, obj, err)
+			return nil, err
+		}
+	}
+	updated := s.copy(f(s.toT(current)))
+	if s.fingerprint != nil && obj != nil {
+		*s.fingerprint(updated) = newFingerprint()
+	}
+	op := s.commit(operation, key, func() { s.Objects[key] = s.newObj(updated) })
+	glog.V(5).Infof("%s.%s(%v, %v) = nil", s.name, operation, key, obj)
+	return op, nil
+}
+
+// commit applies change, the change of the objects made by the mutation
+// operation, at once or, if the MockOperations are Async, when the operation
+// started for the mutation completes. s.Lock must be held.
+func (s *mockStore[T, O]) commit(operation string, key meta.Key, change func()) *mockOperation {
+	if !s.ops.async() {
+		change()
+		return nil
+	}
+	return s.ops.start(operation, key, func() {
+		s.Lock.Lock()
+		defer s.Lock.Unlock()
+		change()
+	})
+}
+
+// finish returns err if the mutation could not be started. Otherwise, it
+// waits for op (nil if the mutation completed at once) unless opts has
+// SkipWait. op is made available to InsertOp and DeleteOp (see
+// withStartedOp()).
+func (s *mockStore[T, O]) finish(ctx context.Context, op *mockOperation, err error, opts []cloud.Option) error {
+	if err != nil || op == nil {
+		return err
+	}
+	if started, ok := ctx.Value(startedOpKey{}).(*cloud.Op); ok {
+		*started = op
+	}
+	if interfaces.NewCallConfig(opts...).SkipWait {
+		return nil
+	}
+	return op.Wait(ctx)
+}
+
+// updateLabels replaces the labels of the object stored at key and gives it a
+// new label fingerprint, as the SetLabels call does. set sets the labels and
+// the label fingerprint of a copy of the stored object.
+func (s *mockStore[T, O]) updateLabels(ctx context.Context, key meta.Key, labels map[string]string, set func(obj *T, labels map[string]string, fingerprint string)) error {
+	return s.modify(ctx, "SetLabels", nil, key, nil, nil, func(current *T) *T {
+		set(current, labels, newFingerprint())
+		return current
+	})
+}
+
+// count returns the number of objects in the scope of the quotas (see
+// quotaScope()).
+func (s *mockStore[T, O]) count(scope string) int {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	n := 0
+	for key := range s.Objects {
+		if quotaScope(key) == scope {
+			n++
+		}
+	}
+	return n
+}
+
+// record records the call of operation in the Recorder. err points to the
+// error returned by the call as record is deferred by the methods of the mock.
+func (s *mockStore[T, O]) record(operation string, key *meta.Key, err *error, args ...interface{}) {
+	if s.Recorder == nil {
+		return
+	}
+	c := Call{Service: s.service, Version: s.version, Operation: operation, Args: args, Time: time.Now(), Err: *err}
+	if key != nil {
+		k := *key
+		c.Key = &k
+	}
+	s.Recorder.record(c)
+}
+
+// aggregatedList returns the objects matching fl grouped by location (the
+// zone or region of the key, or "global").
+func (s *mockStore[T, O]) aggregatedList(fl *filter.F) (map[string][]*T, error) {
+	if o, ok := s.Scenario.next(s.service, "AggregatedList", nil); ok && o.Err != nil {
+		glog.V(5).Infof("%s.AggregatedList(%v) = nil, %v", s.name, fl, o.Err)
+		return nil, o.Err
+	}
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if s.AggregatedListError != nil {
+		err := *s.AggregatedListError
+		glog.V(5).Infof("%s.AggregatedList(%v) = nil, %v", s.name, fl, err)
+		return nil, err
+	}
+
+	objs := map[string][]*T{}
+	for key, obj := range s.Objects {
+		typedObj := s.toT(obj)
+		if !fl.Match(typedObj) {
+			continue
+		}
+		loc := keyLocation(key)
+		objs[loc] = append(objs[loc], typedObj)
+	}
+	glog.V(5).Infof("%s.AggregatedList(%v) = %+v, nil", s.name, fl, objs)
+	return objs, nil
+}
+
+// convertMockObj returns obj as a *T. obj is converted via JSON if it is a
+// different API version of the object.
+func convertMockObj[T any](obj interface{}, copy func(*T) *T) *T {
+	if ret, ok := obj.(*T); ok {
+		return copy(ret)
+	}
+	ret := new(T)
+	if err := copyViaJSON(ret, obj); err != nil {
+		glog.Errorf("Could not convert %T to %T via JSON: %v", obj, ret, err)
+	}
+	return ret
+}
+
+// copy returns a deep copy of obj, so that the objects stored in the mock do
+// not share memory with the objects of the callers.
+func (s *mockStore[T, O]) copy(obj *T) *T {
+	return s.toT(s.newObj(obj))
+}
+
+// missingField returns the JSON name of the first of fields (e.g. "Network")
+// that is not set on obj, a pointer to a struct.
+func missingField(obj interface{}, fields []string) (string, bool) {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	for _, name := range fields {
+		f := v.FieldByName(name)
+		if f.IsValid() && (f.Kind() == reflect.Slice && f.Len() > 0 || f.Kind() != reflect.Slice && !f.IsZero()) {
+			continue
+		}
+		sf, _ := v.Type().FieldByName(name)
+		return strings.Split(sf.Tag.Get("json"), ",")[0], true
+	}
+	return "", false
+}
+
+// DefaultProjectID is the project of the SelfLinks of the objects inserted
+// in the mocks, unless changed with MockGCE.SetProjectID().
+const DefaultProjectID = "mock-project"
+
+// creationTimestampFormat is the format of the CreationTimestamp of the
+// objects in the compute API (RFC 3339 with milliseconds).
+const creationTimestampFormat = "2006-01-02T15:04:05.000-07:00"
+
+// setOutputFields sets the output only fields that the compute API sets on
+// an inserted object, unless the object has them already: the SelfLink at
+// version in s.ProjectID, a new Id and the CreationTimestamp.
+func (s *mockStore[T, O]) setOutputFields(key meta.Key, obj *T, version meta.Version) {
+	v := reflect.ValueOf(obj).Elem()
+	if f := v.FieldByName("SelfLink"); f.IsValid() && f.Kind() == reflect.String && f.String() == "" {
+		if r, ok := cloud.LookupResource(s.service, s.version); ok {
+			f.SetString(key.Path(s.ProjectID, r.Resource, version))
+		}
+	}
+	if f := v.FieldByName("Id"); f.IsValid() && f.Kind() == reflect.Uint64 && f.Uint() == 0 {
+		f.SetUint(newID())
+	}
+	if f := v.FieldByName("CreationTimestamp"); f.IsValid() && f.Kind() == reflect.String && f.String() == "" {
+		f.SetString(time.Now().Format(creationTimestampFormat))
+	}
+}
+
+// keyID returns the numeric ID in the name of key, for the services whose
+// objects are identified by their Id.
+func keyID(key meta.Key) (uint64, error) {
+	id, err := strconv.ParseUint(key.Name, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: name %q is not a numeric ID", key, key.Name)
+	}
+	return id, nil
+}
+
+// ids is the number of IDs returned by newID.
+var ids uint64
+
+// firstID is the first ID returned by newID. The IDs are as large as those
+// of the compute API so that they do not collide with the IDs of the objects
+// stored by the tests.
+const firstID = 1000000000000000000
+
+// newID returns an ID that has not been returned before.
+func newID() uint64 {
+	return firstID + atomic.AddUint64(&ids, 1) - 1
+}
+
+// fingerprints is the number of fingerprints returned by newFingerprint.
+var fingerprints uint64
+
+// newFingerprint returns a fingerprint that has not been returned before. As
+// in the compute API, fingerprints are base64 encoded.
+func newFingerprint() string {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], atomic.AddUint64(&fingerprints, 1))
+	return base64.StdEncoding.EncodeToString(b[:])
+}
+
+// keyLocation returns the location of key as used by AggregatedList: the zone
+// or region, or "global".
+func keyLocation(key meta.Key) string {
+	if key.Type() == meta.Global {
+		return "global"
+	}
+	return key.Location()
+}
+
+// copyViaJSON copies src to dest by serializing to and from JSON. This is used
+// to convert objects between the API versions.
+func copyViaJSON(dest, src interface{}) error {
+	bytes, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, dest)
+}
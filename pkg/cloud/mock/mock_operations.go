@@ -0,0 +1,271 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// operationPollInterval is the delay between the polls of a mock operation
+// that is not done.
+const operationPollInterval = 10 * time.Millisecond
+
+// Wait returns once the operation referenced by key has the status "DONE".
+func (m *MockGlobalOperations) Wait(ctx context.Context, key meta.Key) error {
+	return waitForOperation(ctx, key, m.Get)
+}
+
+// Wait returns once the operation referenced by key has the status "DONE".
+func (m *MockRegionOperations) Wait(ctx context.Context, key meta.Key) error {
+	return waitForOperation(ctx, key, m.Get)
+}
+
+// Wait returns once the operation referenced by key has the status "DONE".
+func (m *MockZoneOperations) Wait(ctx context.Context, key meta.Key) error {
+	return waitForOperation(ctx, key, m.Get)
+}
+
+// waitForOperation polls the operation referenced by key with get until it
+// is done, returning its *cloud.OperationError if it has errors. The status
+// of a pending operation can be changed by the test.
+func waitForOperation(ctx context.Context, key meta.Key, get func(context.Context, meta.Key, ...cloud.Option) (*ga.Operation, error)) error {
+	for {
+		op, err := get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if op.Status == "DONE" {
+			return cloud.CheckOperation(op)
+		}
+		select {
+		case <-time.After(operationPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// MockOperations simulates the operations of the mutations of the mocks
+// (Insert, Delete, Update, Patch and UpdateLabels). By default, a mutation
+// completes at once. Once Async is set, a mutation starts a pending operation
+// instead: the operation is stored with the status "RUNNING" in the
+// GlobalOperations, RegionOperations or ZoneOperations mock of the scope of
+// the key, and the change to the objects is only made when the operation
+// completes, Delay after it was started or when the test calls Step(). The
+// checks of a mutation (injected errors, conflicts, fingerprints) are made
+// when it starts.
+//
+// Insert and the other mutations wait for their operation, unless given the
+// SkipWait() option; InsertOp and DeleteOp return it pending. A test calling
+// them without a Delay must call Step() from another goroutine.
+type MockOperations struct {
+	// Async makes the mutations start pending operations. It must be set
+	// before the mocks are used.
+	Async bool
+	// Delay, if > 0, is the time after which a pending operation completes
+	// by itself. Otherwise the operations only complete with Step().
+	Delay time.Duration
+
+	lock    sync.Mutex
+	seq     int
+	pending []*mockOperation
+
+	global *MockGlobalOperations
+	region *MockRegionOperations
+	zone   *MockZoneOperations
+}
+
+// newMockOperations returns the MockOperations storing the operations in the
+// given mocks.
+func newMockOperations(global *MockGlobalOperations, region *MockRegionOperations, zone *MockZoneOperations) *MockOperations {
+	return &MockOperations{global: global, region: region, zone: zone}
+}
+
+// async is true if the mutations start pending operations.
+func (o *MockOperations) async() bool {
+	return o != nil && o.Async
+}
+
+// Pending returns the number of operations that have not completed.
+func (o *MockOperations) Pending() int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return len(o.pending)
+}
+
+// Step completes the oldest pending operation. It returns false if there is
+// none.
+func (o *MockOperations) Step() bool {
+	o.lock.Lock()
+	if len(o.pending) == 0 {
+		o.lock.Unlock()
+		return false
+	}
+	op := o.pending[0]
+	o.lock.Unlock()
+	o.complete(op)
+	return true
+}
+
+// start starts the pending operation of the mutation operation on the object
+// at key. apply makes the change of the mutation.
+func (o *MockOperations) start(operation string, key meta.Key, apply func()) *mockOperation {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.seq++
+	op := &mockOperation{
+		ops: o,
+		obj: &ga.Operation{
+			Kind:          "compute#operation",
+			Name:          fmt.Sprintf("operation-%d", o.seq),
+			OperationType: strings.ToLower(operation),
+			Status:        "RUNNING",
+			Region:        key.Region,
+			Zone:          key.Zone,
+		},
+		apply: apply,
+		done:  make(chan struct{}),
+	}
+	switch key.Type() {
+	case meta.Zonal:
+		op.key = *meta.ZonalKey(op.obj.Name, key.Zone)
+	case meta.Regional:
+		op.key = *meta.RegionalKey(op.obj.Name, key.Region)
+	default:
+		op.key = *meta.GlobalKey(op.obj.Name)
+	}
+	o.store(op)
+	o.pending = append(o.pending, op)
+	if o.Delay > 0 {
+		time.AfterFunc(o.Delay, func() { o.complete(op) })
+	}
+	return op
+}
+
+// complete completes op if it is pending: the change of the mutation is made
+// and the status of the operation becomes "DONE".
+func (o *MockOperations) complete(op *mockOperation) {
+	o.lock.Lock()
+	i := 0
+	for i < len(o.pending) && o.pending[i] != op {
+		i++
+	}
+	if i == len(o.pending) {
+		o.lock.Unlock()
+		return
+	}
+	o.pending = append(o.pending[:i], o.pending[i+1:]...)
+	o.lock.Unlock()
+
+	op.apply()
+
+	o.lock.Lock()
+	obj := *op.obj
+	obj.Status = "DONE"
+	obj.Progress = 100
+	op.obj = &obj
+	o.store(op)
+	o.lock.Unlock()
+	close(op.done)
+}
+
+// store stores the current state of op in the mock of its scope. o.lock must
+// be held.
+func (o *MockOperations) store(op *mockOperation) {
+	switch op.key.Type() {
+	case meta.Zonal:
+		storeOperation(o.zone.mockStore, op)
+	case meta.Regional:
+		storeOperation(o.region.mockStore, op)
+	default:
+		storeOperation(o.global.mockStore, op)
+	}
+}
+
+// storeOperation stores a copy of the state of op in s.
+func storeOperation[O any](s *mockStore[ga.Operation, O], op *mockOperation) {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+	obj := *op.obj
+	s.Objects[op.key] = s.newObj(&obj)
+}
+
+// mockOperation is a pending operation of MockOperations. It implements
+// cloud.Op.
+type mockOperation struct {
+	ops *MockOperations
+	key meta.Key
+	// obj is the state of the operation, guarded by ops.lock.
+	obj *ga.Operation
+	// apply makes the change of the mutation.
+	apply func()
+	// done is closed when the operation has completed.
+	done chan struct{}
+}
+
+// Done implements cloud.Op.
+func (op *mockOperation) Done(ctx context.Context) (bool, error) {
+	select {
+	case <-op.done:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// Wait implements cloud.Op.
+func (op *mockOperation) Wait(ctx context.Context) error {
+	select {
+	case <-op.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Key implements cloud.Op.
+func (op *mockOperation) Key() *meta.Key {
+	key := op.key
+	return &key
+}
+
+// Error implements cloud.Op. The mock operations do not fail.
+func (op *mockOperation) Error() error {
+	return nil
+}
+
+// startedOpKey is the context key of the cloud.Op set by the mutations of the
+// mocks (see withStartedOp()).
+type startedOpKey struct{}
+
+// withStartedOp returns a context in which a mutation of the mocks sets
+// *op to its pending operation. *op is left nil if the mutation completed
+// at once. This implements InsertOp and DeleteOp on top of Insert and Delete.
+func withStartedOp(ctx context.Context) (context.Context, *cloud.Op) {
+	op := new(cloud.Op)
+	return context.WithValue(ctx, startedOpKey{}, op), op
+}
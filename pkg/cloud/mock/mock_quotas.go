@@ -0,0 +1,180 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// MockQuotas simulates the quotas of the project and of its regions. A limit
+// is set for a quota metric of the compute API (e.g. "STATIC_ADDRESSES", see
+// cloud.QuotaServices()); the Insert of an object of the services counting
+// against the metric then fails with 403 quotaExceeded (see
+// cloud.IsQuotaExceeded()) once the limit is reached:
+//
+//	m := NewMockGCE()
+//	// At most 8 addresses in us-central1.
+//	m.MockQuotas.SetRegionLimit("us-central1", "STATIC_ADDRESSES", 8)
+//
+// As in the compute API (see cloud.ProjectQuotas() and cloud.RegionQuotas()),
+// the metrics of the global services are quotas of the project and the
+// metrics of the regional and zonal services are quotas of the region. The
+// usage is the number of objects of the services in the project or in the
+// region (each object counts as 1, including for "CPUS" and
+// "DISKS_TOTAL_GB"). The objects of pending operations (see MockOperations)
+// are not counted until the operation completes.
+type MockQuotas struct {
+	lock sync.Mutex
+	// limits by scope (the region, or "" for the project) and metric.
+	limits map[string]map[string]float64
+	// counters count the objects of a service in a scope.
+	counters map[string]func(scope string) int
+}
+
+func newMockQuotas() *MockQuotas {
+	return &MockQuotas{
+		limits:   map[string]map[string]float64{},
+		counters: map[string]func(string) int{},
+	}
+}
+
+// SetProjectLimit sets the limit of the quota metric of the project (e.g.
+// "FIREWALLS").
+func (q *MockQuotas) SetProjectLimit(metric string, limit float64) {
+	q.setLimit("", metric, limit)
+}
+
+// SetRegionLimit sets the limit of the quota metric of region (e.g.
+// "STATIC_ADDRESSES").
+func (q *MockQuotas) SetRegionLimit(region, metric string, limit float64) {
+	q.setLimit(region, metric, limit)
+}
+
+func (q *MockQuotas) setLimit(scope, metric string, limit float64) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.limits[scope] == nil {
+		q.limits[scope] = map[string]float64{}
+	}
+	q.limits[scope][metric] = limit
+}
+
+// ProjectQuotas returns the limits and the usage of the quotas of the
+// project that have a limit.
+func (q *MockQuotas) ProjectQuotas() cloud.Quotas {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.quotas("")
+}
+
+// RegionQuotas returns the limits and the usage of the quotas of region that
+// have a limit.
+func (q *MockQuotas) RegionQuotas(region string) cloud.Quotas {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.quotas(region)
+}
+
+// quotas returns the quotas of scope. q.lock must be held.
+func (q *MockQuotas) quotas(scope string) cloud.Quotas {
+	ret := cloud.Quotas{}
+	for metric, limit := range q.limits[scope] {
+		quota := &cloud.Quota{Metric: metric, Limit: limit}
+		for _, service := range cloud.QuotaServices(metric) {
+			if count, ok := q.counters[service]; ok {
+				quota.Usage += float64(count(scope))
+			}
+		}
+		ret[metric] = quota
+	}
+	return ret
+}
+
+// check returns the quotaExceeded error for the Insert of an object of
+// service at key if a quota of its scope has no room left. Otherwise, q stays
+// locked until release is called so that the usage does not change before
+// the object is inserted. check is safe to call on a nil MockQuotas.
+func (q *MockQuotas) check(version meta.Version, service string, key meta.Key) (release func(), err error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	q.lock.Lock()
+	scope := quotaScope(key)
+	if len(q.limits[scope]) == 0 {
+		return q.lock.Unlock, nil
+	}
+	quotas := q.quotas(scope)
+	for _, metric := range cloud.ServiceQuotaMetrics(service) {
+		if quota, ok := quotas[metric]; ok && quota.Remaining() < 1 {
+			q.lock.Unlock()
+			return nil, quotaError(version, service, key, scope, quota)
+		}
+	}
+	return q.lock.Unlock, nil
+}
+
+// quotaScope returns the scope of the quotas counting the object at key: its
+// region, or "" for the project.
+func quotaScope(key meta.Key) string {
+	return cloud.RegionOf(key.Location())
+}
+
+// quotaError returns the error of the compute API for an Insert exceeding
+// quota, e.g. "Quota 'STATIC_ADDRESSES' exceeded.  Limit: 8.0 in region
+// us-central1.".
+func quotaError(version meta.Version, service string, key meta.Key, scope string, quota *cloud.Quota) error {
+	where := "globally"
+	if scope != "" {
+		where = "in region " + scope
+	}
+	message := fmt.Sprintf("Quota '%s' exceeded.  Limit: %.1f %s.", quota.Metric, quota.Limit, where)
+	type item struct {
+		Message string `json:"message"`
+		Domain  string `json:"domain"`
+		Reason  string `json:"reason"`
+	}
+	var body struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Errors  []item `json:"errors"`
+		} `json:"error"`
+	}
+	body.Error.Code = http.StatusForbidden
+	body.Error.Message = message
+	body.Error.Errors = []item{{Message: message, Domain: "usageLimits", Reason: "quotaExceeded"}}
+	b, _ := json.Marshal(body)
+
+	return mockAPIError(version, service, "Insert", &key, &googleapi.Error{
+		Code:    http.StatusForbidden,
+		Message: message,
+		Body:    string(b),
+		Errors:  []googleapi.ErrorItem{{Reason: "quotaExceeded", Message: message}},
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// SeedResource is a resource of the manifest of Seed.
+type SeedResource struct {
+	// Kind is the object (e.g. "Instance") or the service (e.g.
+	// "GlobalAddresses") of the resource. The objects of several services
+	// (e.g. "Address") are told apart by the type of the key.
+	Kind string `json:"kind"`
+	// Key of the resource, e.g. "zones/us-central1-b/vm" (see
+	// meta.Key.UnmarshalText()).
+	Key meta.Key `json:"key"`
+	// Object are the fields of the object, as in the JSON of the compute
+	// API. The name is the one of the key unless set.
+	Object map[string]interface{} `json:"object,omitempty"`
+}
+
+// seedKind is a kind of resource of Seed.
+type seedKind struct {
+	object  string
+	service string
+	keyType meta.KeyType
+	// linkVersion is the API version of the SelfLinks of the objects.
+	linkVersion meta.Version
+}
+
+// Seed stores the resources of the JSON manifest read from r in the mocks.
+// The manifest is a list of SeedResource, e.g.
+//
+//	[
+//	  {"kind": "Zone", "key": "global/us-central1-b"},
+//	  {"kind": "Firewall", "key": "global/fw", "object": {"network": "default"}},
+//	  {"kind": "Instance", "key": "zones/us-central1-b/vm-1", "object": {"machineType": "n1-standard-1"}},
+//	  {"kind": "Address", "key": "global/lb-ip"}
+//	]
+//
+// Unlike Insert, Seed does not check the required fields of the objects and
+// also stores the objects of the read-only resources (e.g. "Zone"). The
+// output only fields (SelfLink, Id, CreationTimestamp) are set as by Insert.
+// The mocks are not modified if the manifest is invalid or if one of the
+// objects exists.
+func (mock *MockGCE) Seed(r io.Reader) error {
+	var resources []SeedResource
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&resources); err != nil {
+		return fmt.Errorf("mock manifest: %v", err)
+	}
+	return mock.SeedResources(resources...)
+}
+
+// SeedResources stores resources in the mocks. See Seed.
+func (mock *MockGCE) SeedResources(resources ...SeedResource) error {
+	stores := mock.stateStores()
+	seen := map[string]bool{}
+	var applies []func()
+	for i, res := range resources {
+		kind, err := findSeedKind(res.Kind, res.Key)
+		if err != nil {
+			return fmt.Errorf("mock manifest: resource %d: %v", i, err)
+		}
+		id := kind.service + " " + res.Key.String()
+		if seen[id] {
+			return fmt.Errorf("mock manifest: resource %d: duplicate %s", i, id)
+		}
+		seen[id] = true
+
+		obj := map[string]interface{}{"name": res.Key.Name}
+		for field, v := range res.Object {
+			obj[field] = v
+		}
+		if obj["name"] != res.Key.Name {
+			return fmt.Errorf("mock manifest: resource %d: name %v is not the name of %v", i, obj["name"], res.Key)
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("mock manifest: resource %d: %v", i, err)
+		}
+		apply, err := stores[kind.service].seed(res.Key, data, kind.linkVersion)
+		if err != nil {
+			return fmt.Errorf("mock manifest: resource %d: %s: %v", i, kind.service, err)
+		}
+		applies = append(applies, apply)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	return nil
+}
+
+// findSeedKind returns the kind of the resources of kind (an object or a
+// service) with key.
+func findSeedKind(kind string, key meta.Key) (*seedKind, error) {
+	var known bool
+	for i := range seedKinds {
+		k := &seedKinds[i]
+		if k.object != kind && k.service != kind {
+			continue
+		}
+		known = true
+		if k.keyType == key.Type() {
+			return k, nil
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+	return nil, fmt.Errorf("%v is not the key of a %s", key, kind)
+}
+
+// seed implements stateStore.
+func (s *mockStore[T, O]) seed(key meta.Key, data []byte, linkVersion meta.Version) (func(), error) {
+	obj := new(T)
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	s.setOutputFields(key, obj, linkVersion)
+	if s.fingerprint != nil && *s.fingerprint(obj) == "" {
+		*s.fingerprint(obj) = newFingerprint()
+	}
+
+	s.Lock.Lock()
+	_, exists := s.Objects[key]
+	s.Lock.Unlock()
+	if exists {
+		return nil, fmt.Errorf("%v exists", key)
+	}
+	return func() {
+		s.Lock.Lock()
+		defer s.Lock.Unlock()
+
+		s.Objects[key] = s.newObj(obj)
+	}, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// stateStore is the store of the objects of a service for Save and Load.
+type stateStore interface {
+	// save returns the objects by key.
+	save() interface{}
+	// load decodes the objects by key in data. The objects are stored by
+	// apply.
+	load(data []byte) (apply func(), err error)
+	// seed decodes the object at key in data for Seed, with the output only
+	// fields of an object at linkVersion. The object is stored by apply.
+	seed(key meta.Key, data []byte, linkVersion meta.Version) (apply func(), err error)
+}
+
+// Save writes the objects of the mocks to w as a JSON object keyed by
+// service and by key (see meta.Key.MarshalText()), e.g.
+//
+//	{
+//	  "Addresses": {
+//	    "regions/us-central1/addr": {"name": "addr", "address": "10.0.0.1"}
+//	  },
+//	  "Firewalls": {
+//	    "global/fw": {"name": "fw", "network": "default"}
+//	  }
+//	}
+//
+// The objects are saved at the most complete API version of the service
+// (e.g. alpha) so that no field is lost. The output is stable, so that it can
+// be checked in as a test fixture and loaded with Load.
+func (mock *MockGCE) Save(w io.Writer) error {
+	state := map[string]interface{}{}
+	for service, s := range mock.stateStores() {
+		if objs := s.save(); objs != nil {
+			state[service] = objs
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(state)
+}
+
+// Load reads the objects written by Save, or written by hand in the same
+// format, from r and stores them in the mocks, replacing the objects with
+// the same keys. The objects can be of any API version of the service. The
+// mocks are not modified if the state is invalid. As YAML is a superset of
+// JSON, a fixture in JSON is also valid YAML; fixtures in the other YAML
+// syntaxes must be converted to JSON first.
+func (mock *MockGCE) Load(r io.Reader) error {
+	var state map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("mock state: %v", err)
+	}
+	stores := mock.stateStores()
+	var services []string
+	for service := range state {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	var applies []func()
+	for _, service := range services {
+		s, ok := stores[service]
+		if !ok {
+			return fmt.Errorf("mock state: unknown service %q", service)
+		}
+		apply, err := s.load(state[service])
+		if err != nil {
+			return fmt.Errorf("mock state: %s: %v", service, err)
+		}
+		applies = append(applies, apply)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	return nil
+}
+
+// save implements stateStore. It returns nil if there are no objects.
+func (s *mockStore[T, O]) save() interface{} {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if len(s.Objects) == 0 {
+		return nil
+	}
+	objs := map[meta.Key]*T{}
+	for key, obj := range s.Objects {
+		objs[key] = s.toT(obj)
+	}
+	return objs
+}
+
+// load implements stateStore.
+func (s *mockStore[T, O]) load(data []byte) (func(), error) {
+	var objs map[meta.Key]*T
+	if err := json.Unmarshal(data, &objs); err != nil {
+		return nil, err
+	}
+	for key, obj := range objs {
+		if obj == nil {
+			return nil, fmt.Errorf("%v: null object", key)
+		}
+	}
+	return func() {
+		s.Lock.Lock()
+		defer s.Lock.Unlock()
+
+		for key, obj := range objs {
+			s.Objects[key] = s.newObj(obj)
+		}
+	}, nil
+}
@@ -0,0 +1,905 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func addressSelfLink(version, region, name string) string {
+	return fmt.Sprintf("https://www.googleapis.com/compute/%s/projects/my-project/regions/%s/addresses/%s", version, region, name)
+}
+
+func TestMocks(t *testing.T) {
+	t.Parallel()
+
+	// This test uses Addresses, but the logic that is generated is the same for
+	// other basic objects.
+	const region = "us-central1"
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+
+	keyAlpha := meta.RegionalKey("key-alpha", region)
+	keyBeta := meta.RegionalKey("key-beta", region)
+	keyGA := meta.RegionalKey("key-ga", region)
+	key := keyAlpha
+
+	// Get not found.
+	if _, err := mock.AlphaAddresses().Get(ctx, *key); err == nil {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.BetaAddresses().Get(ctx, *key); err == nil {
+		t.Errorf("BetaAddresses().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.Addresses().Get(ctx, *key); err == nil {
+		t.Errorf("Addresses().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	// Insert.
+	{
+		obj := &alpha.Address{
+			Name:     "alpha",
+			SelfLink: addressSelfLink("v0.alpha", region, "alpha"),
+		}
+		if err := mock.AlphaAddresses().Insert(ctx, *keyAlpha, obj); err != nil {
+			t.Errorf("AlphaAddresses().Insert(%v, %v, %v) = %v; want nil", ctx, key, obj, err)
+		}
+	}
+	{
+		obj := &beta.Address{
+			Name:     "beta",
+			SelfLink: addressSelfLink("v0.beta", region, "beta"),
+		}
+		if err := mock.BetaAddresses().Insert(ctx, *keyBeta, obj); err != nil {
+			t.Errorf("BetaAddresses().Insert(%v, %v, %v) = %v; want nil", ctx, key, obj, err)
+		}
+	}
+	{
+		obj := &ga.Address{
+			Name:     "ga",
+			SelfLink: addressSelfLink("v1", region, "ga"),
+		}
+		if err := mock.Addresses().Insert(ctx, *keyGA, &ga.Address{Name: "ga"}); err != nil {
+			t.Errorf("Addresses().Insert(%v, %v, %v) = %v; want nil", ctx, key, obj, err)
+		}
+	}
+	// Get across versions.
+	if obj, err := mock.AlphaAddresses().Get(ctx, *key); err != nil {
+		t.Errorf("AlphaAddresses().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.BetaAddresses().Get(ctx, *key); err != nil {
+		t.Errorf("BetaAddresses().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.Addresses().Get(ctx, *key); err != nil {
+		t.Errorf("Addresses().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	// List across versions. As in GCE, Insert names the objects after their
+	// keys.
+	want := map[string]bool{
+		"key-alpha": true, "key-beta": true, "key-ga": true,
+	}
+	{
+		objs, err := mock.AlphaAddresses().List(ctx, region, filter.None)
+		if err != nil {
+			t.Errorf("AlphaAddresses().List(%v, %v, %v) = %v, %v; want _, nil", ctx, region, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaAddresses().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaAddresses().List(ctx, region, filter.None)
+		if err != nil {
+			t.Errorf("BetaAddresses().List(%v, %v, %v) = %v, %v; want _, nil", ctx, region, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaAddresses().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.Addresses().List(ctx, region, filter.None)
+		if err != nil {
+			t.Errorf("Addresses().List(%v, %v, %v) = %v, %v; want _, nil", ctx, region, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaAddresses().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	// Delete across versions.
+	if err := mock.AlphaAddresses().Delete(ctx, *keyAlpha); err != nil {
+		t.Errorf("AlphaAddresses().Delete(%v, %v) = %v; want nil", ctx, key, err)
+	}
+	if err := mock.BetaAddresses().Delete(ctx, *keyBeta); err != nil {
+		t.Errorf("BetaAddresses().Delete(%v, %v) = %v; want nil", ctx, key, err)
+	}
+	if err := mock.Addresses().Delete(ctx, *keyGA); err != nil {
+		t.Errorf("Addresses().Delete(%v, %v) = %v; want nil", ctx, key, err)
+	}
+	// Delete not found.
+	if err := mock.AlphaAddresses().Delete(ctx, *keyAlpha); err == nil {
+		t.Errorf("AlphaAddresses().Delete(%v, %v) = nil; want error", ctx, key)
+	}
+	if err := mock.BetaAddresses().Delete(ctx, *keyBeta); err == nil {
+		t.Errorf("BetaAddresses().Delete(%v, %v) = nil; want error", ctx, key)
+	}
+	if err := mock.Addresses().Delete(ctx, *keyGA); err == nil {
+		t.Errorf("Addresses().Delete(%v, %v) = nil; want error", ctx, key)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.GlobalKey("bs")
+
+	obj := &ga.BackendService{Name: "bs"}
+	if err := mock.BackendServices().Insert(ctx, key, obj); err != nil {
+		t.Fatalf("BackendServices().Insert(%v, _) = %v; want nil", key, err)
+	}
+	if obj.Fingerprint != "" {
+		t.Errorf("Insert() modified obj.Fingerprint = %q; want it unchanged", obj.Fingerprint)
+	}
+	current, err := mock.BackendServices().Get(ctx, key)
+	if err != nil || current.Fingerprint == "" {
+		t.Fatalf("BackendServices().Get(%v) = %+v, %v; want object with a fingerprint, nil", key, current, err)
+	}
+
+	// The fingerprint of the current object is accepted and then changes.
+	if err := mock.BackendServices().Update(ctx, key, &ga.BackendService{Name: "bs", Fingerprint: current.Fingerprint}); err != nil {
+		t.Errorf("BackendServices().Update(%v, _) = %v; want nil", key, err)
+	}
+	updated, err := mock.BackendServices().Get(ctx, key)
+	if err != nil || updated.Fingerprint == "" || updated.Fingerprint == current.Fingerprint {
+		t.Fatalf("BackendServices().Get(%v) = %+v, %v; want object with a new fingerprint, nil", key, updated, err)
+	}
+	// A stale fingerprint is rejected, at any version.
+	if err := mock.BackendServices().Update(ctx, key, &ga.BackendService{Name: "bs", Fingerprint: current.Fingerprint}); !cloud.IsPreconditionFailed(err) {
+		t.Errorf("BackendServices().Update(%v, _) = %v; want precondition failed", key, err)
+	}
+	if err := mock.AlphaBackendServices().Patch(ctx, key, &alpha.BackendService{Fingerprint: current.Fingerprint}); !cloud.IsPreconditionFailed(err) {
+		t.Errorf("AlphaBackendServices().Patch(%v, _) = %v; want precondition failed", key, err)
+	}
+	// An empty fingerprint is not checked.
+	if err := mock.BackendServices().Patch(ctx, key, &ga.BackendService{Description: "patched"}); err != nil {
+		t.Errorf("BackendServices().Patch(%v, _) = %v; want nil", key, err)
+	}
+
+	// UpdateWithRetry reads the object again after a concurrent
+	// modification.
+	calls := 0
+	err = mock.BackendServices().UpdateWithRetry(ctx, key, func(obj *ga.BackendService) error {
+		calls++
+		if calls == 1 {
+			mock.BackendServices().Patch(ctx, key, &ga.BackendService{Description: "concurrent"})
+		}
+		obj.Protocol = "HTTPS"
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("BackendServices().UpdateWithRetry(%v, _) = %v with %d calls of update; want nil with 2 calls", key, err, calls)
+	}
+	if got, err := mock.BackendServices().Get(ctx, key); err != nil || got.Protocol != "HTTPS" || got.Description != "concurrent" {
+		t.Errorf("BackendServices().Get(%v) = %+v, %v; want the concurrent and retried modifications", key, got, err)
+	}
+
+	// UpdateLabels changes the label fingerprint.
+	dkey := *meta.ZonalKey("disk", "us-central1-b")
+	mock.Disks().Insert(ctx, dkey, &ga.Disk{Name: "disk"})
+	for _, want := range []string{"a", "b"} {
+		before, _ := mock.Disks().Get(ctx, dkey)
+		if err := mock.Disks().UpdateLabels(ctx, dkey, map[string]string{"l": want}); err != nil {
+			t.Fatalf("Disks().UpdateLabels(%v, _) = %v; want nil", dkey, err)
+		}
+		after, err := mock.Disks().Get(ctx, dkey)
+		if err != nil || after.Labels["l"] != want || after.LabelFingerprint == before.LabelFingerprint {
+			t.Errorf("Disks().Get(%v) = %+v, %v; want label l=%s with a new label fingerprint", dkey, after, err, want)
+		}
+	}
+	if err := mock.Disks().UpdateLabels(ctx, *meta.ZonalKey("missing", "us-central1-b"), nil); err == nil {
+		t.Errorf("Disks().UpdateLabels(missing, _) = nil; want error")
+	}
+}
+
+func TestOutputFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.ZonalKey("vm", "us-central1-b")
+	obj := &ga.Instance{Name: "vm", MachineType: "n1-standard-1"}
+	if err := mock.Instances().Insert(ctx, key, obj); err != nil {
+		t.Fatalf("Instances().Insert(%v) = %v; want nil", key, err)
+	}
+	if obj.SelfLink != "" || obj.Id != 0 || obj.CreationTimestamp != "" {
+		t.Errorf("Instances().Insert(%v) modified the object: %+v", key, obj)
+	}
+	got, err := mock.Instances().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Instances().Get(%v) = _, %v; want nil", key, err)
+	}
+	if want := "https://www.googleapis.com/compute/v1/projects/mock-project/zones/us-central1-b/instances/vm"; got.SelfLink != want {
+		t.Errorf("SelfLink = %q; want %q", got.SelfLink, want)
+	}
+	if _, err := time.Parse(time.RFC3339, got.CreationTimestamp); err != nil {
+		t.Errorf("CreationTimestamp = %q; want an RFC 3339 time (%v)", got.CreationTimestamp, err)
+	}
+
+	mock.SetProjectID("proj")
+	key2 := *meta.ZonalKey("vm-2", "us-central1-b")
+	mock.Instances().Insert(ctx, key2, &ga.Instance{Name: "vm-2", MachineType: "n1-standard-1"})
+	got2, _ := mock.Instances().Get(ctx, key2)
+	if got2.Id == 0 || got2.Id == got.Id {
+		t.Errorf("Id = %d, %d; want distinct non-zero IDs", got.Id, got2.Id)
+	}
+	if want := "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-b/instances/vm-2"; got2.SelfLink != want {
+		t.Errorf("SelfLink = %q; want %q after SetProjectID()", got2.SelfLink, want)
+	}
+
+	// The object is named after its key.
+	unnamed := *meta.GlobalKey("unnamed")
+	if err := mock.Firewalls().Insert(ctx, unnamed, &ga.Firewall{Network: "default"}); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", unnamed, err)
+	}
+	if fw, err := mock.Firewalls().Get(ctx, unnamed); err != nil || fw.Name != unnamed.Name {
+		t.Errorf("Firewalls().Get(%v) = %+v, %v; want Name %q", unnamed, fw, err, unnamed.Name)
+	}
+	if fws, err := mock.Firewalls().List(ctx, filter.Regexp("name", "unnamed")); err != nil || len(fws) != 1 {
+		t.Errorf("Firewalls().List(name eq unnamed) = %v, %v; want [unnamed], nil", fws, err)
+	}
+
+	// The fields set by the test are kept.
+	fwKey := *meta.GlobalKey("fw")
+	mock.Firewalls().Insert(ctx, fwKey, &ga.Firewall{Name: "fw", Network: "default", SelfLink: "link", Id: 1})
+	if fw, _ := mock.Firewalls().Get(ctx, fwKey); fw.SelfLink != "link" || fw.Id != 1 {
+		t.Errorf("Firewalls().Get(%v) = %+v; want the SelfLink and Id of the inserted object", fwKey, fw)
+	}
+}
+
+func TestValueSemantics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.GlobalKey("fw")
+	obj := &ga.Firewall{Name: "fw", Network: "default", SourceRanges: []string{"10.0.0.0/8"}}
+	if err := mock.Firewalls().Insert(ctx, key, obj); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	obj.SourceRanges[0] = "inserted"
+
+	got, _ := mock.Firewalls().Get(ctx, key)
+	got.SourceRanges[0] = "got"
+	objs, _ := mock.Firewalls().List(ctx, filter.None)
+	objs[0].SourceRanges[0] = "listed"
+
+	update := &ga.Firewall{Name: "fw", Network: "default", SourceRanges: []string{"10.0.0.0/8"}, Description: "updated"}
+	if err := mock.Firewalls().Update(ctx, key, update); err != nil {
+		t.Fatalf("Firewalls().Update(%v) = %v; want nil", key, err)
+	}
+	update.SourceRanges[0] = "update"
+
+	got, _ = mock.Firewalls().Get(ctx, key)
+	if got.Description != "updated" || !reflect.DeepEqual(got.SourceRanges, []string{"10.0.0.0/8"}) {
+		t.Errorf("Firewalls().Get(%v) = %+v; want the updated object, unchanged by the callers", key, got)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.GlobalKey("proxy")
+
+	for _, tc := range []struct {
+		obj       *ga.TargetHttpsProxy
+		wantField string
+	}{
+		{&ga.TargetHttpsProxy{Name: "proxy"}, "urlMap"},
+		// An empty list is not set.
+		{&ga.TargetHttpsProxy{Name: "proxy", UrlMap: "um", SslCertificates: []string{}}, "sslCertificates"},
+	} {
+		err := mock.TargetHttpsProxies().Insert(ctx, key, tc.obj)
+		if !cloud.IsBadRequest(err) || !strings.Contains(err.Error(), "'resource."+tc.wantField+"'") {
+			t.Errorf("TargetHttpsProxies().Insert(%v, %+v) = %v; want http.StatusBadRequest for %s", key, tc.obj, err, tc.wantField)
+		}
+	}
+	if _, ok := mock.MockTargetHttpsProxies.Objects[key]; ok {
+		t.Errorf("TargetHttpsProxies().Insert() stored an object without its required fields")
+	}
+	obj := &ga.TargetHttpsProxy{Name: "proxy", UrlMap: "um", SslCertificates: []string{"cert"}}
+	if err := mock.TargetHttpsProxies().Insert(ctx, key, obj); err != nil {
+		t.Errorf("TargetHttpsProxies().Insert(%v, %+v) = %v; want nil", key, obj, err)
+	}
+}
+
+func TestInsertOp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.GlobalKey("fw")
+
+	op, err := mock.Firewalls().InsertOp(ctx, key, &ga.Firewall{Name: "fw", Network: "default"})
+	if err != nil {
+		t.Fatalf("Firewalls().InsertOp(%v) = _, %v; want nil", key, err)
+	}
+	if done, err := op.Done(ctx); !done || err != nil {
+		t.Errorf("Done() = %t, %v; want true, nil", done, err)
+	}
+	if _, ok := mock.MockFirewalls.Objects[key]; !ok {
+		t.Errorf("Firewalls().InsertOp(%v) did not insert the object", key)
+	}
+	if _, err := mock.Firewalls().InsertOp(ctx, key, &ga.Firewall{Name: "fw", Network: "default"}); err == nil {
+		t.Errorf("Firewalls().InsertOp(%v) = _, nil; want an error for the existing object", key)
+	}
+	if op, err := mock.Firewalls().DeleteOp(ctx, key); err != nil || op.Wait(ctx) != nil {
+		t.Errorf("Firewalls().DeleteOp(%v) = _, %v; want nil", key, err)
+	}
+	if _, ok := mock.MockFirewalls.Objects[key]; ok {
+		t.Errorf("Firewalls().DeleteOp(%v) did not delete the object", key)
+	}
+}
+
+func TestOperationsWait(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.RegionalKey("op", "us-central1")
+
+	if err := mock.RegionOperations().Wait(ctx, key); !cloud.IsNotFound(err) {
+		t.Errorf("RegionOperations().Wait(%v) = %v; want http.StatusNotFound", key, err)
+	}
+	mock.MockRegionOperations.Objects[key] = &MockRegionOperationsObj{&ga.Operation{Name: "op", Status: "RUNNING"}}
+	ctx, cancel := context.WithTimeout(ctx, 5*operationPollInterval)
+	defer cancel()
+	if err := mock.RegionOperations().Wait(ctx, key); err != context.DeadlineExceeded {
+		t.Errorf("RegionOperations().Wait(%v) = %v; want %v for a pending operation", key, err, context.DeadlineExceeded)
+	}
+	mock.MockRegionOperations.Objects[key] = &MockRegionOperationsObj{&ga.Operation{Name: "op", Status: "DONE"}}
+	if err := mock.RegionOperations().Wait(context.Background(), key); err != nil {
+		t.Errorf("RegionOperations().Wait(%v) = %v; want nil", key, err)
+	}
+	failed := &ga.Operation{Name: "op", Status: "DONE", Error: &ga.OperationError{Errors: []*ga.OperationErrorErrors{{Code: "QUOTA_EXCEEDED"}}}}
+	mock.MockRegionOperations.Objects[key] = &MockRegionOperationsObj{failed}
+	if err := mock.RegionOperations().Wait(context.Background(), key); !cloud.IsOperationError(err, "QUOTA_EXCEEDED") {
+		t.Errorf("RegionOperations().Wait(%v) = %v; want the QUOTA_EXCEEDED operation error", key, err)
+	}
+}
+
+func TestAsyncOperations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	mock.MockOperations.Async = true
+	key := *meta.GlobalKey("fw")
+
+	op, err := mock.Firewalls().InsertOp(ctx, key, &ga.Firewall{Name: "fw", Network: "default"})
+	if err != nil {
+		t.Fatalf("Firewalls().InsertOp(%v) = _, %v; want nil", key, err)
+	}
+	if done, err := op.Done(ctx); done || err != nil {
+		t.Errorf("Done() = %t, %v; want false, nil", done, err)
+	}
+	if _, err := mock.Firewalls().Get(ctx, key); !cloud.IsNotFound(err) {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want http.StatusNotFound while the insertion is pending", key, err)
+	}
+	if obj, err := mock.GlobalOperations().Get(ctx, *op.Key()); err != nil || obj.Status != "RUNNING" || obj.OperationType != "insert" {
+		t.Errorf("GlobalOperations().Get(%v) = %+v, %v; want a RUNNING insert", op.Key(), obj, err)
+	}
+	if got := mock.MockOperations.Pending(); got != 1 {
+		t.Errorf("Pending() = %d; want 1", got)
+	}
+
+	if !mock.MockOperations.Step() {
+		t.Fatalf("Step() = false; want true")
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Errorf("Wait() = %v; want nil", err)
+	}
+	if _, err := mock.Firewalls().Get(ctx, key); err != nil {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want nil once the insertion is done", key, err)
+	}
+	if err := mock.GlobalOperations().Wait(ctx, *op.Key()); err != nil {
+		t.Errorf("GlobalOperations().Wait(%v) = %v; want nil", op.Key(), err)
+	}
+	if mock.MockOperations.Step() {
+		t.Errorf("Step() = true; want false without pending operations")
+	}
+
+	// Delete waits for its operation.
+	go func() {
+		for !mock.MockOperations.Step() {
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	if err := mock.Firewalls().Delete(ctx, key); err != nil {
+		t.Errorf("Firewalls().Delete(%v) = %v; want nil", key, err)
+	}
+	if _, ok := mock.MockFirewalls.Objects[key]; ok {
+		t.Errorf("Firewalls().Delete(%v) did not delete the object", key)
+	}
+
+	// With a Delay, the operations complete by themselves.
+	mock.MockOperations.Delay = time.Millisecond
+	addrKey := *meta.RegionalKey("addr", "us-central1")
+	op, err = mock.Addresses().InsertOp(ctx, addrKey, &ga.Address{Name: "addr"})
+	if err != nil {
+		t.Fatalf("Addresses().InsertOp(%v) = _, %v; want nil", addrKey, err)
+	}
+	if err := mock.RegionOperations().Wait(ctx, *op.Key()); err != nil {
+		t.Errorf("RegionOperations().Wait(%v) = %v; want nil", op.Key(), err)
+	}
+	if _, err := mock.Addresses().Get(ctx, addrKey); err != nil {
+		t.Errorf("Addresses().Get(%v) = _, %v; want nil", addrKey, err)
+	}
+	if err := mock.Addresses().Insert(ctx, addrKey, &ga.Address{Name: "addr"}); err == nil {
+		t.Errorf("Addresses().Insert(%v) = nil; want an error for the existing object", addrKey)
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	const zone = "us-central1-b"
+	for _, obj := range []*ga.Instance{
+		{Name: "vm-1", Labels: map[string]string{"env": "prod"}, Id: 1},
+		{Name: "vm-2", Labels: map[string]string{"env": "dev"}, Id: 2},
+		{Name: "other", Id: 3},
+	} {
+		mock.MockInstances.Objects[*meta.ZonalKey(obj.Name, zone)] = &MockInstancesObj{obj}
+	}
+
+	for _, tc := range []struct {
+		fl   *filter.F
+		want []string
+	}{
+		{filter.None, []string{"other", "vm-1", "vm-2"}},
+		{filter.Regexp("name", "vm-.*"), []string{"vm-1", "vm-2"}},
+		{filter.Regexp("name", "vm"), nil},
+		{filter.Label("env", "prod"), []string{"vm-1"}},
+		{filter.MustParse("(labels.env != prod) (name = vm-2)"), []string{"vm-2"}},
+		{filter.MustParse("id eq 3"), []string{"other"}},
+	} {
+		objs, err := mock.Instances().List(ctx, zone, tc.fl)
+		if err != nil {
+			t.Errorf("Instances().List(%v) = _, %v; want nil", tc.fl, err)
+			continue
+		}
+		var got []string
+		for _, obj := range objs {
+			got = append(got, obj.Name)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Instances().List(%v) = %v; want %v", tc.fl, got, tc.want)
+		}
+	}
+}
+
+func TestListPage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	for _, name := range []string{"fw-3", "fw-1", "fw-2", "other"} {
+		mock.MockFirewalls.Objects[*meta.GlobalKey(name)] = &MockFirewallsObj{&ga.Firewall{Name: name}}
+	}
+
+	fl := filter.Regexp("name", "fw-.*")
+	var pages [][]string
+	token := ""
+	for {
+		objs, next, err := mock.Firewalls().ListPage(ctx, fl, token, 2)
+		if err != nil {
+			t.Fatalf("Firewalls().ListPage(%q) = _, _, %v; want nil", token, err)
+		}
+		var names []string
+		for _, obj := range objs {
+			names = append(names, obj.Name)
+		}
+		pages = append(pages, names)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if want := [][]string{{"fw-1", "fw-2"}, {"fw-3"}}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("Firewalls().ListPage() pages = %v; want %v", pages, want)
+	}
+	if _, _, err := mock.Firewalls().ListPage(ctx, fl, "invalid", 2); err == nil {
+		t.Errorf("Firewalls().ListPage(%q) = _, _, nil; want an error", "invalid")
+	}
+	if objs, next, err := mock.Firewalls().ListPage(ctx, filter.None, "", 0); len(objs) != 4 || next != "" || err != nil {
+		t.Errorf("Firewalls().ListPage() = %d objects, %q, %v; want 4, \"\", nil", len(objs), next, err)
+	}
+}
+
+func TestListAcrossProjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	mock.MockFirewalls.Objects[*meta.GlobalKey("fw")] = &MockFirewallsObj{&ga.Firewall{Name: "fw"}}
+
+	got, err := mock.Firewalls().ListAcrossProjects(ctx, []string{"host", "service"}, filter.None)
+	if err != nil || len(got) != 2 || len(got["host"]) != 1 || len(got["service"]) != 1 {
+		t.Errorf("Firewalls().ListAcrossProjects() = %v, %v; want fw for host and service, nil", got, err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	a, b := *meta.GlobalKey("a"), *meta.GlobalKey("b")
+	mock.MockFirewalls.Objects[a] = &MockFirewallsObj{&ga.Firewall{Name: "a"}}
+
+	objs, err := mock.Firewalls().BatchGet(ctx, []meta.Key{a, b})
+	if len(objs) != 2 || objs[0].Name != "a" || objs[1] != nil {
+		t.Errorf("Firewalls().BatchGet() = %v, _; want [a nil]", objs)
+	}
+	if be, ok := err.(*cloud.BatchError); !ok || be.Errors[0] != nil || !cloud.IsNotFound(be.Errors[1]) {
+		t.Errorf("Firewalls().BatchGet() = _, %v; want a BatchError with http.StatusNotFound for b", err)
+	}
+	if err := mock.Firewalls().BatchDelete(ctx, []meta.Key{a}); err != nil {
+		t.Errorf("Firewalls().BatchDelete() = %v; want nil", err)
+	}
+	if _, ok := mock.MockFirewalls.Objects[a]; ok {
+		t.Errorf("Firewalls().BatchDelete() did not delete %v", a)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	key := *meta.RegionalKey("addr", "us-central1")
+	mock.AlphaAddresses().Insert(ctx, key, &alpha.Address{Name: "addr"})
+	_, getErr := mock.AlphaAddresses().Get(ctx, *meta.RegionalKey("x", "us-central1"))
+
+	for _, tc := range []struct {
+		desc          string
+		err           error
+		wantOperation string
+		wantCode      int
+	}{
+		{"get", getErr, "Get", http.StatusNotFound},
+		{"insert", mock.AlphaAddresses().Insert(ctx, key, &alpha.Address{Name: "addr"}), "Insert", http.StatusConflict},
+		{"delete", mock.AlphaAddresses().Delete(ctx, *meta.RegionalKey("x", "us-central1")), "Delete", http.StatusNotFound},
+		{"labels", mock.AlphaAddresses().UpdateLabels(ctx, *meta.RegionalKey("x", "us-central1"), nil), "SetLabels", http.StatusNotFound},
+	} {
+		// The errors have the shape of the errors of the GCE adapters.
+		e, ok := tc.err.(*cloud.Error)
+		if !ok {
+			t.Errorf("%s: error %v is not a *cloud.Error", tc.desc, tc.err)
+			continue
+		}
+		if e.Operation != tc.wantOperation || e.Version != meta.VersionAlpha || e.Service != "Addresses" || e.Code != tc.wantCode || e.Resource.Resource != "addresses" {
+			t.Errorf("%s: got %+v; want a %s of alpha Addresses with code %d", tc.desc, e, tc.wantOperation, tc.wantCode)
+		}
+		if got, want := cloud.IsNotFound(tc.err), tc.wantCode == http.StatusNotFound; got != want {
+			t.Errorf("%s: IsNotFound(%v) = %t; want %t", tc.desc, tc.err, got, want)
+		}
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	mock.MockQuotas.SetRegionLimit("us-central1", "STATIC_ADDRESSES", 2)
+	mock.MockQuotas.SetRegionLimit("us-central1", "INSTANCES", 1)
+	mock.MockQuotas.SetProjectLimit("FIREWALLS", 1)
+
+	// Objects already in the mock count against the quotas.
+	mock.MockAddresses.Objects[*meta.RegionalKey("a1", "us-central1")] = newMockAddressesObj(&ga.Address{Name: "a1"})
+	if err := mock.Addresses().Insert(ctx, *meta.RegionalKey("a2", "us-central1"), &ga.Address{Name: "a2"}); err != nil {
+		t.Fatalf("Addresses().Insert(a2) = %v; want nil", err)
+	}
+	a3 := meta.RegionalKey("a3", "us-central1")
+	err := mock.AlphaAddresses().Insert(ctx, *a3, &alpha.Address{Name: "a3"})
+	var apiErr *googleapi.Error
+	if !cloud.IsQuotaExceeded(err) || !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden {
+		t.Fatalf("AlphaAddresses().Insert(a3) = %v; want 403 quotaExceeded", err)
+	}
+	if want := "Quota 'STATIC_ADDRESSES' exceeded.  Limit: 2.0 in region us-central1."; !strings.Contains(err.Error(), want) {
+		t.Errorf("AlphaAddresses().Insert(a3) = %v; want %q", err, want)
+	}
+	if !strings.Contains(apiErr.Body, `"reason":"quotaExceeded"`) {
+		t.Errorf("AlphaAddresses().Insert(a3) = %v; want the body of the compute API error", err)
+	}
+	if _, ok := mock.MockAddresses.Objects[*a3]; ok {
+		t.Errorf("MockAddresses.Objects[%v] exists after quotaExceeded", a3)
+	}
+
+	// The quotas are per region and are freed by Delete.
+	if err := mock.Addresses().Insert(ctx, *meta.RegionalKey("a3", "europe-west1"), &ga.Address{Name: "a3"}); err != nil {
+		t.Errorf("Addresses().Insert(a3 in europe-west1) = %v; want nil", err)
+	}
+	if err := mock.Addresses().Delete(ctx, *meta.RegionalKey("a1", "us-central1")); err != nil {
+		t.Fatalf("Addresses().Delete(a1) = %v; want nil", err)
+	}
+	if err := mock.Addresses().Insert(ctx, *a3, &ga.Address{Name: "a3"}); err != nil {
+		t.Errorf("Addresses().Insert(a3) = %v; want nil after Delete(a1)", err)
+	}
+
+	// Zonal objects count against the quotas of their region.
+	if err := mock.Instances().Insert(ctx, *meta.ZonalKey("vm1", "us-central1-a"), &ga.Instance{Name: "vm1", MachineType: "n1-standard-1"}); err != nil {
+		t.Fatalf("Instances().Insert(vm1) = %v; want nil", err)
+	}
+	if err := mock.Instances().Insert(ctx, *meta.ZonalKey("vm2", "us-central1-b"), &ga.Instance{Name: "vm2", MachineType: "n1-standard-1"}); !cloud.IsQuotaExceeded(err) {
+		t.Errorf("Instances().Insert(vm2) = %v; want quotaExceeded", err)
+	}
+
+	// Global objects count against the quotas of the project.
+	if err := mock.Firewalls().Insert(ctx, *meta.GlobalKey("fw1"), &ga.Firewall{Name: "fw1", Network: "default"}); err != nil {
+		t.Fatalf("Firewalls().Insert(fw1) = %v; want nil", err)
+	}
+	err = mock.Firewalls().Insert(ctx, *meta.GlobalKey("fw2"), &ga.Firewall{Name: "fw2", Network: "default"})
+	if want := "Quota 'FIREWALLS' exceeded.  Limit: 1.0 globally."; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Firewalls().Insert(fw2) = %v; want %q", err, want)
+	}
+
+	// Inserting an existing object at the limit is a conflict, as in GCE.
+	err = mock.Firewalls().Insert(ctx, *meta.GlobalKey("fw1"), &ga.Firewall{Name: "fw1", Network: "default"})
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusConflict {
+		t.Errorf("Firewalls().Insert(fw1) = %v; want %d", err, http.StatusConflict)
+	}
+
+	if q := mock.MockQuotas.RegionQuotas("us-central1")["STATIC_ADDRESSES"]; q == nil || q.Limit != 2 || q.Usage != 2 {
+		t.Errorf("RegionQuotas(us-central1)[STATIC_ADDRESSES] = %+v; want limit 2, usage 2", q)
+	}
+	if q := mock.MockQuotas.ProjectQuotas()["FIREWALLS"]; q == nil || q.Remaining() != 0 {
+		t.Errorf("ProjectQuotas()[FIREWALLS] = %+v; want none remaining", q)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	addrKey := meta.RegionalKey("addr", "us-central1")
+	fwKey := meta.GlobalKey("fw")
+	mock.MockAddresses.Objects[*addrKey] = newMockAddressesObj(&ga.Address{Name: "addr", Address: "10.0.0.1"})
+	mock.MockFirewalls.Objects[*fwKey] = newMockFirewallsObj(&ga.Firewall{Name: "fw", Network: "default"})
+
+	var saved strings.Builder
+	if err := mock.Save(&saved); err != nil {
+		t.Fatalf("Save() = %v; want nil", err)
+	}
+	want := `{
+  "Addresses": {
+    "regions/us-central1/addr": {
+      "address": "10.0.0.1",
+      "name": "addr"
+    }
+  },
+  "Firewalls": {
+    "global/fw": {
+      "name": "fw",
+      "network": "default"
+    }
+  }
+}
+`
+	if saved.String() != want {
+		t.Errorf("Save() wrote\n%s\nwant\n%s", saved.String(), want)
+	}
+
+	loaded := NewMockGCE()
+	if err := loaded.Load(strings.NewReader(saved.String())); err != nil {
+		t.Fatalf("Load() = %v; want nil", err)
+	}
+	if addr, err := loaded.BetaAddresses().Get(ctx, *addrKey); err != nil || addr.Address != "10.0.0.1" {
+		t.Errorf("BetaAddresses().Get(%v) = %+v, %v; want 10.0.0.1, nil", addrKey, addr, err)
+	}
+	if fw, err := loaded.Firewalls().Get(ctx, *fwKey); err != nil || fw.Network != "default" {
+		t.Errorf("Firewalls().Get(%v) = %+v, %v; want default, nil", fwKey, fw, err)
+	}
+
+	// Hand-written fixtures are loaded the same way.
+	fixture := `{"Instances": {"zones/us-central1-b/vm": {"name": "vm", "machineType": "n1-standard-1"}}}`
+	if err := loaded.Load(strings.NewReader(fixture)); err != nil {
+		t.Fatalf("Load(%s) = %v; want nil", fixture, err)
+	}
+	if vms, err := loaded.Instances().List(ctx, "us-central1-b", filter.None); err != nil || len(vms) != 1 || vms[0].MachineType != "n1-standard-1" {
+		t.Errorf("Instances().List() = %+v, %v; want vm, nil", vms, err)
+	}
+
+	// An invalid state does not modify the mocks.
+	for _, state := range []string{
+		`{`,
+		`{"Frobs": {}}`,
+		`{"Addresses": {"regions/us-central1/other": {"name": "other"}}, "Firewalls": {"nowhere/fw": {}}}`,
+		`{"Firewalls": {"global/other": null}}`,
+	} {
+		if err := loaded.Load(strings.NewReader(state)); err == nil {
+			t.Errorf("Load(%s) = nil; want error", state)
+		}
+	}
+	if got := len(loaded.MockAddresses.Objects) + len(loaded.MockFirewalls.Objects); got != 2 {
+		t.Errorf("%d addresses and firewalls after the invalid Load() calls; want 2", got)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE()
+	manifest := `[
+		{"kind": "Zone", "key": "global/us-central1-b"},
+		{"kind": "HealthCheck", "key": "global/default"},
+		{"kind": "Instance", "key": "zones/us-central1-b/vm-1", "object": {"machineType": "n1-standard-1", "labels": {"env": "prod"}}},
+		{"kind": "Address", "key": "regions/us-central1/ip"},
+		{"kind": "Address", "key": "global/lb-ip", "object": {"address": "35.1.2.3"}},
+		{"kind": "Firewalls", "key": "global/fw", "object": {"name": "fw", "network": "default"}}
+	]`
+	if err := mock.Seed(strings.NewReader(manifest)); err != nil {
+		t.Fatalf("Seed() = %v; want nil", err)
+	}
+
+	if zones, err := mock.Zones().List(ctx, filter.None); err != nil || len(zones) != 1 || zones[0].Name != "us-central1-b" {
+		t.Errorf("Zones().List() = %+v, %v; want us-central1-b, nil", zones, err)
+	}
+	vm, err := mock.Instances().Get(ctx, *meta.ZonalKey("vm-1", "us-central1-b"))
+	if err != nil {
+		t.Fatalf("Instances().Get(vm-1) = _, %v; want nil", err)
+	}
+	wantLink := "https://www.googleapis.com/compute/v1/projects/mock-project/zones/us-central1-b/instances/vm-1"
+	if vm.Name != "vm-1" || vm.MachineType != "n1-standard-1" || vm.Labels["env"] != "prod" || vm.SelfLink != wantLink || vm.Id == 0 {
+		t.Errorf("Instances().Get(vm-1) = %+v; want the seeded instance with SelfLink %q and an Id", vm, wantLink)
+	}
+	// The kind is resolved by the type of the key.
+	if _, err := mock.Addresses().Get(ctx, *meta.RegionalKey("ip", "us-central1")); err != nil {
+		t.Errorf("Addresses().Get(ip) = _, %v; want nil", err)
+	}
+	if addr, err := mock.GlobalAddresses().Get(ctx, *meta.GlobalKey("lb-ip")); err != nil || addr.Address != "35.1.2.3" {
+		t.Errorf("GlobalAddresses().Get(lb-ip) = %+v, %v; want 35.1.2.3, nil", addr, err)
+	}
+	if fw, err := mock.Firewalls().Get(ctx, *meta.GlobalKey("fw")); err != nil || fw.Network != "default" {
+		t.Errorf("Firewalls().Get(fw) = %+v, %v; want default, nil", fw, err)
+	}
+
+	// An invalid manifest does not modify the mocks.
+	for _, manifest := range []string{
+		`{}`,
+		`[{"kind": "Frob", "key": "global/x"}]`,
+		`[{"kind": "Instance", "key": "global/x"}]`,
+		`[{"kind": "HealthCheck", "key": "global/x", "object": {"name": "y"}}]`,
+		`[{"kind": "HealthCheck", "key": "global/x", "object": {"checkIntervalSec": "many"}}]`,
+		`[{"kind": "HealthCheck", "key": "global/x"}, {"kind": "HealthChecks", "key": "global/x"}]`,
+		`[{"kind": "HealthCheck", "key": "global/x", "fields": {}}]`,
+		// The object exists.
+		`[{"kind": "HealthCheck", "key": "global/x"}, {"kind": "HealthCheck", "key": "global/default"}]`,
+	} {
+		if err := mock.Seed(strings.NewReader(manifest)); err == nil {
+			t.Errorf("Seed(%s) = nil; want error", manifest)
+		}
+	}
+	if _, ok := mock.MockHealthChecks.Objects[*meta.GlobalKey("x")]; ok {
+		t.Errorf("HealthCheck x exists after the invalid Seed() calls")
+	}
+}
+
+func TestLoadCassette(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	recorded := NewMockGCE()
+	rc := cloud.NewRecordingCloud(recorded)
+	for _, name := range []string{"a", "b", "c"} {
+		if err := rc.Firewalls().Insert(ctx, *meta.GlobalKey(name), &ga.Firewall{Name: name, Network: "default"}); err != nil {
+			t.Fatalf("Firewalls().Insert(%s) = %v; want nil", name, err)
+		}
+	}
+	if _, err := rc.Firewalls().List(ctx, filter.None); err != nil {
+		t.Fatalf("Firewalls().List() = _, %v; want nil", err)
+	}
+	if err := rc.Firewalls().Delete(ctx, *meta.GlobalKey("b")); err != nil {
+		t.Fatalf("Firewalls().Delete(b) = %v; want nil", err)
+	}
+	key := *meta.RegionalKey("ip", "us-central1")
+	if err := rc.AlphaAddresses().Insert(ctx, key, &alpha.Address{Name: "ip", Address: "10.0.0.1"}); err != nil {
+		t.Fatalf("AlphaAddresses().Insert(ip) = %v; want nil", err)
+	}
+	if _, err := rc.AlphaAddresses().AggregatedList(ctx, filter.None); err != nil {
+		t.Fatalf("AlphaAddresses().AggregatedList() = _, %v; want nil", err)
+	}
+	// c is deleted behind the back of the recording, which then sees it
+	// missing.
+	if err := recorded.Firewalls().Delete(ctx, *meta.GlobalKey("c")); err != nil {
+		t.Fatalf("Firewalls().Delete(c) = %v; want nil", err)
+	}
+	if _, err := rc.Firewalls().Get(ctx, *meta.GlobalKey("c")); !cloud.IsNotFound(err) {
+		t.Fatalf("Firewalls().Get(c) = _, %v; want not found", err)
+	}
+
+	mock := NewMockGCE()
+	if err := mock.LoadCassette(rc.Cassette()); err != nil {
+		t.Fatalf("LoadCassette() = %v; want nil", err)
+	}
+	var names []string
+	fws, err := mock.Firewalls().List(ctx, filter.None)
+	for _, fw := range fws {
+		names = append(names, fw.Name)
+	}
+	sort.Strings(names)
+	if err != nil || !reflect.DeepEqual(names, []string{"a"}) {
+		t.Errorf("Firewalls().List() = %v, %v; want [a], nil", names, err)
+	}
+	if addr, err := mock.Addresses().Get(ctx, key); err != nil || addr.Address != "10.0.0.1" {
+		t.Errorf("Addresses().Get(ip) = %+v, %v; want 10.0.0.1, nil", addr, err)
+	}
+
+	bad := &cloud.Cassette{Interactions: []*cloud.Interaction{
+		{Version: meta.VersionGA, Service: "Firewalls", Operation: "Get", Key: meta.GlobalKey("x"), Results: []json.RawMessage{
+			[]byte(`{"name": "x", "selfLink": "https://www.googleapis.com/compute/v1/projects/p/global/firewalls/x"}`),
+		}},
+		{Version: meta.VersionGA, Service: "Frobs", Operation: "Get"},
+	}}
+	if err := mock.LoadCassette(bad); err == nil {
+		t.Errorf("LoadCassette(Frobs) = nil; want error")
+	}
+	if _, ok := mock.MockFirewalls.Objects[*meta.GlobalKey("x")]; ok {
+		t.Errorf("Firewall x exists after the invalid LoadCassette()")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Recorder records the calls to the mocks so that tests can assert on the
+// calls made without setting hooks.
+//
+//	rec := NewRecorder()
+//	mock := NewMockGCE()
+//	mock.UseRecorder(rec)
+//	...
+//	// Exactly one Insert happened for key.
+//	if calls := rec.CallsMatching("Firewalls", "Insert", &key); len(calls) != 1 {
+//		t.Errorf("Firewalls.Insert(%v) calls = %v; want 1 call", key, calls)
+//	}
+//
+// A Recorder can also be set on a single mock (e.g.
+// mock.MockFirewalls.Recorder). The calls are recorded for all API versions
+// of the services, including the calls intercepted by hooks. The helpers of
+// the mocks are recorded as the calls they make, e.g. InsertOp as Insert and
+// GetOrCreate as Get and, if the object does not exist, Insert and Get.
+type Recorder struct {
+	lock  sync.Mutex
+	calls []Call
+}
+
+// Call is a call to a mock.
+type Call struct {
+	// Service of the call (e.g. "Firewalls").
+	Service string
+	// Version is the API version of the mock.
+	Version meta.Version
+	// Operation is the method called (e.g. "Insert").
+	Operation string
+	// Key is nil for the calls on a collection (e.g. List).
+	Key *meta.Key
+	// Args are the arguments of the call other than the context, the key
+	// and the options, e.g. the object of an Insert.
+	Args []interface{}
+	// Time when the call returned.
+	Time time.Time
+	// Err returned by the call.
+	Err error
+}
+
+// String returns a description of the call, e.g.
+// "Firewalls.Insert(Key{"fw"}) = nil".
+func (c Call) String() string {
+	var args []string
+	if c.Key != nil {
+		args = append(args, c.Key.String())
+	}
+	for _, arg := range c.Args {
+		args = append(args, fmt.Sprintf("%v", arg))
+	}
+	return fmt.Sprintf("%s.%s(%s) = %v", c.Service, c.Operation, strings.Join(args, ", "), c.Err)
+}
+
+// NewRecorder returns a new, empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Calls returns the calls recorded, in the order in which they returned.
+func (r *Recorder) Calls() []Call {
+	return r.CallsMatching("", "", nil)
+}
+
+// CallsMatching returns the calls for the given service (e.g. "Firewalls"),
+// operation (e.g. "Insert") and key. An empty service or operation and a nil
+// key match any.
+func (r *Recorder) CallsMatching(service, operation string, key *meta.Key) []Call {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var ret []Call
+	for _, c := range r.calls {
+		switch {
+		case service != "" && c.Service != service:
+		case operation != "" && c.Operation != operation:
+		case key != nil && (c.Key == nil || *c.Key != *key):
+		default:
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// CallCount returns the number of calls of operation (e.g. "Insert") to any
+// of the mocks.
+func (r *Recorder) CallCount(operation string) int {
+	return len(r.CallsMatching("", operation, nil))
+}
+
+// Reset forgets the calls recorded.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.calls = nil
+}
+
+// record appends c. record is safe to call on a nil Recorder.
+func (r *Recorder) record(c Call) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.calls = append(r.calls, c)
+}
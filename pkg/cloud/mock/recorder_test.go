@@ -0,0 +1,118 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := meta.GlobalKey("fw")
+	otherKey := meta.GlobalKey("other")
+
+	rec := NewRecorder()
+	mock := NewMockGCE()
+	mock.UseRecorder(rec)
+
+	fw := &ga.Firewall{Name: "fw", Network: "default"}
+	if err := mock.Firewalls().Insert(ctx, *key, fw); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	// Calls that fail are recorded with their error.
+	if err := mock.Firewalls().Insert(ctx, *key, fw); err == nil {
+		t.Fatalf("Firewalls().Insert(%v) = nil; want error for existing object", key)
+	}
+	addrKey := meta.RegionalKey("addr", "us-central1")
+	if _, err := mock.AlphaAddresses().Get(ctx, *addrKey); err == nil {
+		t.Fatalf("AlphaAddresses().Get(%v) = _, nil; want error", addrKey)
+	}
+	if _, err := mock.Addresses().List(ctx, "us-central1", filter.None); err != nil {
+		t.Fatalf("Addresses().List() = _, %v; want nil", err)
+	}
+	// Helpers are recorded as the calls they make.
+	if _, err := mock.Firewalls().GetOrCreate(ctx, *otherKey, &ga.Firewall{Name: "other", Network: "default"}); err != nil {
+		t.Fatalf("Firewalls().GetOrCreate(%v) = _, %v; want nil", otherKey, err)
+	}
+
+	if got := rec.CallCount("Insert"); got != 3 {
+		t.Errorf("CallCount(Insert) = %d; want 3", got)
+	}
+	calls := rec.CallsMatching("Firewalls", "Insert", key)
+	if len(calls) != 2 {
+		t.Fatalf("CallsMatching(Firewalls, Insert, %v) = %v; want 2 calls", key, calls)
+	}
+	if c := calls[0]; c.Err != nil || c.Version != meta.VersionGA || len(c.Args) != 1 || c.Args[0] != fw || c.Time.IsZero() {
+		t.Errorf("calls[0] = %+v; want the successful Insert of fw", c)
+	}
+	if c := calls[1]; c.Err == nil {
+		t.Errorf("calls[1] = %+v; want the failed Insert", c)
+	}
+	if calls := rec.CallsMatching("Firewalls", "Get", otherKey); len(calls) != 2 || !cloud.IsNotFound(calls[0].Err) || calls[1].Err != nil {
+		t.Errorf("CallsMatching(Firewalls, Get, %v) = %v; want 2 calls, the first one not found", otherKey, calls)
+	}
+	calls = rec.CallsMatching("Addresses", "", nil)
+	if len(calls) != 2 || calls[0].Version != meta.VersionAlpha || calls[1].Key != nil || calls[1].Args[0] != "us-central1" {
+		t.Errorf("CallsMatching(Addresses, \"\", nil) = %v; want the alpha Get and the List in us-central1", calls)
+	}
+	if got := len(rec.Calls()); got != 7 {
+		t.Errorf("len(Calls()) = %d; want 7", got)
+	}
+
+	rec.Reset()
+	if got := rec.Calls(); len(got) != 0 {
+		t.Errorf("Calls() = %v after Reset(); want none", got)
+	}
+}
+
+func TestRecorderHooks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := meta.GlobalKey("fw")
+	injected := errors.New("injected")
+
+	// A Recorder set on a single mock records the calls to that mock,
+	// including the calls intercepted by hooks.
+	mock := NewMockGCE()
+	rec := NewRecorder()
+	mock.MockFirewalls.Recorder = rec
+	mock.MockFirewalls.DeleteHook = func(*MockFirewalls, context.Context, meta.Key) (bool, error) {
+		return true, injected
+	}
+
+	mock.Firewalls().Delete(ctx, *key)
+	mock.Addresses().List(ctx, "us-central1", filter.None)
+
+	calls := rec.Calls()
+	if len(calls) != 1 || calls[0].Operation != "Delete" || calls[0].Err != injected {
+		t.Errorf("Calls() = %v; want [Delete = %v]", calls, injected)
+	}
+	if got, want := calls[0].String(), `Firewalls.Delete(Key{"fw"}) = injected`; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Scenario scripts an ordered sequence of outcomes for calls to the mocks.
+// Each script is identified by (service, operation, key) and each matching
+// call consumes the next outcome in the script. Once a script is exhausted,
+// the mock reverts to its normal behavior.
+//
+//	sc := NewScenario()
+//	// Get returns 404 twice, then the given object.
+//	sc.On("Firewalls", "Get", key).Fail(notFound).Times(2).Return(fw)
+//	// Insert fails with quota exceeded once, then proceeds normally.
+//	sc.On("Firewalls", "Insert", key).Fail(quotaExceeded).Pass()
+//	// List calls (which have no key) are scripted with a nil key.
+//	sc.On("Addresses", "List", nil).Fail(internalError)
+//	// Delete fails with the errors in order, then proceeds normally.
+//	sc.On("Firewalls", "Delete", key).Fail(internalError, unavailable)
+//	// A quarter of the Get calls for any key fail, indefinitely.
+//	sc.On("Instances", "Get", nil).FailRandomly(unavailable, 0.25)
+//
+//	mock := NewMockGCE()
+//	mock.UseScenario(sc)
+//
+// Scripts apply to all API versions of the service. Hooks have precedence
+// over the Scenario.
+type Scenario struct {
+	lock    sync.Mutex
+	scripts map[scenarioKey]*Script
+	// rand decides the outcomes of FailRandomly.
+	rand *rand.Rand
+}
+
+type scenarioKey struct {
+	service   string
+	operation string
+	key       meta.Key
+	anyKey    bool
+}
+
+// Outcome is a single scripted result.
+type Outcome struct {
+	// Err, if non-nil, is returned by the call.
+	Err error
+	// Obj, if non-nil, is returned by the call (only meaningful for Get).
+	// Obj can be any API version of the object.
+	Obj interface{}
+	// P, if non-zero, is the probability that the outcome applies to a
+	// call, which otherwise executes normally. Such an outcome is never
+	// consumed.
+	P float64
+}
+
+// Script is the ordered sequence of outcomes for a single (service,
+// operation, key).
+type Script struct {
+	s        *Scenario
+	name     string
+	outcomes []Outcome
+}
+
+// NewScenario returns a new, empty Scenario. The outcomes of FailRandomly are
+// the same from one run to the next unless Seed is called.
+func NewScenario() *Scenario {
+	return &Scenario{
+		scripts: map[scenarioKey]*Script{},
+		rand:    rand.New(rand.NewSource(1)),
+	}
+}
+
+// Seed seeds the random outcomes of FailRandomly.
+func (s *Scenario) Seed(seed int64) *Scenario {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.rand = rand.New(rand.NewSource(seed))
+	return s
+}
+
+// On returns the script for the given service (e.g. "Firewalls"), operation
+// (e.g. "Get") and key. A nil key matches calls for any key; scripts for a
+// specific key take precedence.
+func (s *Scenario) On(service, operation string, key *meta.Key) *Script {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sk := scenarioKey{service: service, operation: operation, anyKey: key == nil}
+	name := fmt.Sprintf("%s.%s(*)", service, operation)
+	if key != nil {
+		sk.key = *key
+		name = fmt.Sprintf("%s.%s(%v)", service, operation, *key)
+	}
+	if sc, ok := s.scripts[sk]; ok {
+		return sc
+	}
+	sc := &Script{s: s, name: name}
+	s.scripts[sk] = sc
+	return sc
+}
+
+func (sc *Script) add(o Outcome) *Script {
+	sc.s.lock.Lock()
+	defer sc.s.lock.Unlock()
+
+	if sc.endsRandomly() {
+		panic(fmt.Errorf("%s: no outcome can follow FailRandomly()", sc.name))
+	}
+	sc.outcomes = append(sc.outcomes, o)
+	return sc
+}
+
+// Fail appends an outcome where the call returns err for each of errs, in
+// order.
+func (sc *Script) Fail(errs ...error) *Script {
+	for _, err := range errs {
+		sc.add(Outcome{Err: err})
+	}
+	return sc
+}
+
+// FailRandomly appends a final outcome where each call fails with err with
+// probability p (between 0 and 1) and executes normally otherwise. The
+// outcome is never consumed, so it applies to all of the following calls.
+func (sc *Script) FailRandomly(err error, p float64) *Script {
+	if p <= 0 || p > 1 {
+		panic(fmt.Errorf("%s: FailRandomly() called with probability %v, not in (0, 1]", sc.name, p))
+	}
+	return sc.add(Outcome{Err: err, P: p})
+}
+
+// Return appends an outcome where the call returns obj.
+func (sc *Script) Return(obj interface{}) *Script {
+	return sc.add(Outcome{Obj: obj})
+}
+
+// Pass appends an outcome where the call executes normally.
+func (sc *Script) Pass() *Script {
+	return sc.add(Outcome{})
+}
+
+// Times repeats the last outcome so that it occurs n times in total. It has
+// no effect on the outcome of FailRandomly.
+func (sc *Script) Times(n int) *Script {
+	sc.s.lock.Lock()
+	defer sc.s.lock.Unlock()
+
+	if len(sc.outcomes) == 0 {
+		panic(fmt.Errorf("%s: Times() called with no outcome", sc.name))
+	}
+	if sc.endsRandomly() {
+		return sc
+	}
+	last := sc.outcomes[len(sc.outcomes)-1]
+	for i := 1; i < n; i++ {
+		sc.outcomes = append(sc.outcomes, last)
+	}
+	return sc
+}
+
+// endsRandomly is true if the last outcome is the one of FailRandomly.
+// sc.s.lock must be held.
+func (sc *Script) endsRandomly() bool {
+	return len(sc.outcomes) > 0 && sc.outcomes[len(sc.outcomes)-1].P != 0
+}
+
+// Pending returns the description of the scripts that have outcomes that
+// were not consumed. This can be used to verify that the test exercised the
+// entire scenario. The outcomes of FailRandomly, which are never consumed,
+// are not pending.
+func (s *Scenario) Pending() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var ret []string
+	for _, sc := range s.scripts {
+		n := len(sc.outcomes)
+		if sc.endsRandomly() {
+			n--
+		}
+		if n > 0 {
+			ret = append(ret, fmt.Sprintf("%s: %d outcome(s) remaining", sc.name, n))
+		}
+	}
+	return ret
+}
+
+// next consumes the next scripted outcome for the call. ok is false if there
+// is no scripted outcome. next is safe to call on a nil Scenario.
+func (s *Scenario) next(service, operation string, key *meta.Key) (Outcome, bool) {
+	if s == nil {
+		return Outcome{}, false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var keys []scenarioKey
+	if key != nil {
+		keys = append(keys, scenarioKey{service: service, operation: operation, key: *key})
+	}
+	keys = append(keys, scenarioKey{service: service, operation: operation, anyKey: true})
+
+	for _, sk := range keys {
+		sc, ok := s.scripts[sk]
+		if !ok || len(sc.outcomes) == 0 {
+			continue
+		}
+		o := sc.outcomes[0]
+		if o.P != 0 {
+			if s.rand.Float64() >= o.P {
+				return Outcome{}, true
+			}
+			return o, true
+		}
+		sc.outcomes = sc.outcomes[1:]
+		return o, true
+	}
+	return Outcome{}, false
+}
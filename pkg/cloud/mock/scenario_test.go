@@ -0,0 +1,186 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestScenario(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := meta.GlobalKey("fw")
+	notFound := &googleapi.Error{Code: http.StatusNotFound}
+	quotaExceeded := &googleapi.Error{Code: http.StatusForbidden, Message: "quotaExceeded"}
+	internal := &googleapi.Error{Code: http.StatusInternalServerError}
+
+	sc := NewScenario()
+	sc.On("Firewalls", "Get", key).Fail(notFound).Times(2).Return(&alpha.Firewall{Name: "scripted"})
+	sc.On("Firewalls", "Insert", key).Fail(quotaExceeded).Pass()
+	sc.On("Firewalls", "List", nil).Fail(internal)
+
+	mock := NewMockGCE()
+	mock.UseScenario(sc)
+
+	for i := 0; i < 2; i++ {
+		if _, err := mock.Firewalls().Get(ctx, *key); err != notFound {
+			t.Errorf("Firewalls().Get(%v) #%d = _, %v; want %v", key, i, err, notFound)
+		}
+	}
+	if fw, err := mock.Firewalls().Get(ctx, *key); err != nil || fw.Name != "scripted" {
+		t.Errorf("Firewalls().Get(%v) = %+v, %v; want scripted object, nil", key, fw, err)
+	}
+	// Script is exhausted, the mock reverts to normal behavior.
+	if _, err := mock.Firewalls().Get(ctx, *key); err == nil {
+		t.Errorf("Firewalls().Get(%v) = _, nil; want error", key)
+	}
+
+	if err := mock.Firewalls().Insert(ctx, *key, &ga.Firewall{}); err != quotaExceeded {
+		t.Errorf("Firewalls().Insert(%v) = %v; want %v", key, err, quotaExceeded)
+	}
+	if err := mock.Firewalls().Insert(ctx, *key, &ga.Firewall{Network: "default"}); err != nil {
+		t.Errorf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+	if _, ok := mock.MockFirewalls.Objects[*key]; !ok {
+		t.Errorf("Firewall %v was not inserted", key)
+	}
+
+	// Scripts for other keys are unaffected.
+	otherKey := meta.GlobalKey("other")
+	if err := mock.Firewalls().Insert(ctx, *otherKey, &ga.Firewall{Network: "default"}); err != nil {
+		t.Errorf("Firewalls().Insert(%v) = %v; want nil", otherKey, err)
+	}
+
+	if pending := sc.Pending(); len(pending) != 1 {
+		t.Errorf("sc.Pending() = %v; want 1 pending script", pending)
+	}
+	if _, err := mock.Firewalls().List(ctx, filter.None); err != internal {
+		t.Errorf("Firewalls().List() = _, %v; want %v", err, internal)
+	}
+	if pending := sc.Pending(); len(pending) != 0 {
+		t.Errorf("sc.Pending() = %v; want none", pending)
+	}
+}
+
+func TestScenarioErrorSequence(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := meta.GlobalKey("fw")
+	errs := []error{
+		&googleapi.Error{Code: http.StatusInternalServerError},
+		&googleapi.Error{Code: http.StatusServiceUnavailable},
+		&googleapi.Error{Code: http.StatusTooManyRequests},
+	}
+
+	sc := NewScenario()
+	sc.On("Firewalls", "Delete", key).Fail(errs...)
+	mock := NewMockGCE()
+	mock.UseScenario(sc)
+	mock.MockFirewalls.Objects[*key] = newMockFirewallsObj(&ga.Firewall{Name: "fw"})
+
+	for i, want := range errs {
+		if err := mock.Firewalls().Delete(ctx, *key); err != want {
+			t.Errorf("Firewalls().Delete(%v) #%d = %v; want %v", key, i, err, want)
+		}
+	}
+	if err := mock.Firewalls().Delete(ctx, *key); err != nil {
+		t.Errorf("Firewalls().Delete(%v) = %v; want nil", key, err)
+	}
+}
+
+func TestScenarioFailRandomly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := meta.GlobalKey("fw")
+	unavailable := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	internal := &googleapi.Error{Code: http.StatusInternalServerError}
+
+	// failures returns the calls to Get that failed out of n for a scenario
+	// with the given seed.
+	failures := func(seed int64, n int) []int {
+		sc := NewScenario().Seed(seed)
+		sc.On("Firewalls", "Get", nil).Fail(internal).FailRandomly(unavailable, 0.25)
+		mock := NewMockGCE()
+		mock.UseScenario(sc)
+		mock.MockFirewalls.Objects[*key] = newMockFirewallsObj(&ga.Firewall{Name: "fw"})
+
+		if _, err := mock.Firewalls().Get(ctx, *key); err != internal {
+			t.Errorf("Firewalls().Get(%v) = _, %v; want %v", key, err, internal)
+		}
+		var ret []int
+		for i := 0; i < n; i++ {
+			switch _, err := mock.Firewalls().Get(ctx, *key); err {
+			case nil:
+			case unavailable:
+				ret = append(ret, i)
+			default:
+				t.Fatalf("Firewalls().Get(%v) #%d = _, %v; want nil or %v", key, i, err, unavailable)
+			}
+		}
+		if pending := sc.Pending(); len(pending) != 0 {
+			t.Errorf("sc.Pending() = %v; want none", pending)
+		}
+		return ret
+	}
+
+	got := failures(1, 1000)
+	if len(got) < 200 || len(got) > 300 {
+		t.Errorf("%d calls out of 1000 failed; want about 250", len(got))
+	}
+	if again := failures(1, 1000); !reflect.DeepEqual(again, got) {
+		t.Errorf("failures differ for the same seed")
+	}
+	if other := failures(2, 1000); reflect.DeepEqual(other, got) {
+		t.Errorf("failures are the same for another seed")
+	}
+}
+
+func TestScenarioFailRandomlyPanics(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc string
+		f    func(sc *Script)
+	}{
+		{"probability 0", func(sc *Script) { sc.FailRandomly(errors.New("x"), 0) }},
+		{"probability 1.5", func(sc *Script) { sc.FailRandomly(errors.New("x"), 1.5) }},
+		{"outcome after FailRandomly", func(sc *Script) { sc.FailRandomly(errors.New("x"), 0.5).Pass() }},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: no panic", tc.desc)
+				}
+			}()
+			tc.f(NewScenario().On("Firewalls", "Get", nil))
+		}()
+	}
+}
@@ -0,0 +1,480 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// serverRouteKey identifies the resource collection served by a route.
+type serverRouteKey struct {
+	version  meta.Version
+	keyType  meta.KeyType
+	resource string
+}
+
+// serverRoute serves the REST API calls for one service at one API version.
+// The calls are made through the mock methods so hooks, injected errors and
+// Scenarios apply. A nil function means that the call is not supported by the
+// service.
+type serverRoute struct {
+	get    func(ctx context.Context, key meta.Key) (interface{}, error)
+	list   func(ctx context.Context, location string, fl *filter.F) (interface{}, error)
+	insert func(ctx context.Context, key meta.Key, body []byte) (cloud.Op, error)
+	delete func(ctx context.Context, key meta.Key, opts ...cloud.Option) (cloud.Op, error)
+	// aggregatedList returns the objects by zone or region.
+	aggregatedList func(ctx context.Context, fl *filter.F) (map[string]interface{}, error)
+	// aggregatedField is the field of the scoped lists of the aggregated
+	// list response containing the objects (e.g. "instances").
+	aggregatedField string
+	// readOnly is true if the resource cannot be mutated.
+	readOnly bool
+}
+
+// getRoute adapts the Get method of a mock for a serverRoute.
+func getRoute[T any](f func(context.Context, meta.Key, ...cloud.Option) (*T, error)) func(context.Context, meta.Key) (interface{}, error) {
+	return func(ctx context.Context, key meta.Key) (interface{}, error) {
+		return f(ctx, key)
+	}
+}
+
+// listRoute adapts the List method of a mock of a zonal or regional service
+// for a serverRoute.
+func listRoute[T any](f func(context.Context, string, *filter.F, ...cloud.Option) ([]*T, error)) func(context.Context, string, *filter.F) (interface{}, error) {
+	return func(ctx context.Context, location string, fl *filter.F) (interface{}, error) {
+		return f(ctx, location, fl)
+	}
+}
+
+// globalListRoute adapts the List method of a mock of a global service for a
+// serverRoute.
+func globalListRoute[T any](f func(context.Context, *filter.F, ...cloud.Option) ([]*T, error)) func(context.Context, string, *filter.F) (interface{}, error) {
+	return func(ctx context.Context, _ string, fl *filter.F) (interface{}, error) {
+		return f(ctx, fl)
+	}
+}
+
+// aggregatedListRoute adapts the AggregatedList method of a mock for a
+// serverRoute.
+func aggregatedListRoute[T any](f func(context.Context, *filter.F, ...cloud.Option) (map[string][]*T, error)) func(context.Context, *filter.F) (map[string]interface{}, error) {
+	return func(ctx context.Context, fl *filter.F) (map[string]interface{}, error) {
+		objs, err := f(ctx, fl)
+		if err != nil {
+			return nil, err
+		}
+		ret := map[string]interface{}{}
+		for loc, l := range objs {
+			ret[loc] = l
+		}
+		return ret, nil
+	}
+}
+
+// insertRoute adapts the InsertOp method of a mock for a serverRoute. The
+// body of the request is decoded into a T.
+func insertRoute[T any](f func(context.Context, meta.Key, *T, ...cloud.Option) (cloud.Op, error)) func(context.Context, meta.Key, []byte) (cloud.Op, error) {
+	return func(ctx context.Context, key meta.Key, body []byte) (cloud.Op, error) {
+		obj := new(T)
+		if err := json.Unmarshal(body, obj); err != nil {
+			return nil, &googleapi.Error{Code: http.StatusBadRequest, Message: err.Error()}
+		}
+		return f(ctx, key, obj)
+	}
+}
+
+// serverRequest is a REST API request parsed from the URL path.
+type serverRequest struct {
+	version  string
+	project  string
+	keyType  meta.KeyType
+	location string
+	resource string
+	// name is empty for calls on the collection (List and Insert).
+	name string
+	// aggregated is true for the aggregated list of the resource.
+	aggregated bool
+}
+
+// parseServerPath parses a URL path of the following formats:
+//
+//	/compute/<ver>/projects/<proj>/global/<res>[/<name>]
+//	/compute/<ver>/projects/<proj>/regions/<region>/<res>[/<name>]
+//	/compute/<ver>/projects/<proj>/zones/<zone>/<res>[/<name>]
+//	/compute/<ver>/projects/<proj>/{regions,zones}[/<name>]
+//	/compute/<ver>/projects/<proj>/aggregated/<res>
+func parseServerPath(path string) (*serverRequest, error) {
+	errNotValid := fmt.Errorf("%q is not a valid compute API path", path)
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 5 || parts[0] != "compute" || parts[2] != "projects" {
+		return nil, errNotValid
+	}
+	req := &serverRequest{version: parts[1], project: parts[3]}
+	parts = parts[4:]
+
+	switch {
+	case parts[0] == "aggregated" && len(parts) == 2:
+		req.resource = parts[1]
+		req.aggregated = true
+		return req, nil
+	case parts[0] == "global" && (len(parts) == 2 || len(parts) == 3):
+		req.keyType = meta.Global
+		req.resource = parts[1]
+		parts = parts[2:]
+	case (parts[0] == "regions" || parts[0] == "zones") && len(parts) <= 2:
+		req.keyType = meta.Global
+		req.resource = parts[0]
+		parts = parts[1:]
+	case parts[0] == "regions" && (len(parts) == 3 || len(parts) == 4):
+		req.keyType = meta.Regional
+		req.location = parts[1]
+		req.resource = parts[2]
+		parts = parts[3:]
+	case parts[0] == "zones" && (len(parts) == 3 || len(parts) == 4):
+		req.keyType = meta.Zonal
+		req.location = parts[1]
+		req.resource = parts[2]
+		parts = parts[3:]
+	default:
+		return nil, errNotValid
+	}
+	if len(parts) == 1 {
+		req.name = parts[0]
+	}
+	return req, nil
+}
+
+// key returns the key of the resource named in the request.
+func (r *serverRequest) key(name string) meta.Key {
+	switch r.keyType {
+	case meta.Zonal:
+		return *meta.ZonalKey(name, r.location)
+	case meta.Regional:
+		return *meta.RegionalKey(name, r.location)
+	}
+	return *meta.GlobalKey(name)
+}
+
+// collectionPath returns the path of the resource collection of the request
+// relative to the project, e.g. "zones/us-central1-b/instances".
+func (r *serverRequest) collectionPath() string {
+	switch r.keyType {
+	case meta.Zonal:
+		return "zones/" + r.location + "/" + r.resource
+	case meta.Regional:
+		return "regions/" + r.location + "/" + r.resource
+	}
+	if r.resource == "regions" || r.resource == "zones" {
+		return r.resource
+	}
+	return "global/" + r.resource
+}
+
+// httpHandler serves the compute REST API from a MockGCE.
+type httpHandler struct {
+	mock   *MockGCE
+	routes map[serverRouteKey]*serverRoute
+
+	lock sync.Mutex
+	// ops are the operations returned by the mutations, by name.
+	ops   map[string]*serverOperation
+	opSeq int
+}
+
+// NewHTTPHandler returns an http.Handler that serves the compute REST API
+// from the state of mock. Get, List, Insert and Delete of the resources are
+// served as GET, POST and DELETE requests; mutations return an Operation that
+// can be polled from the operations endpoints, e.g. by
+// Service.WaitForCompletion(). The operations have the status "DONE" at once,
+// or once the operation of the mock completes if the MockOperations are
+// Async. The handler can be used with httptest.Server to test
+// code using the compute API clients against the same state as code using the
+// Cloud interface:
+//
+//	srv := httptest.NewServer(mock.NewHTTPHandler(m))
+//	svc, err := ga.New(srv.Client())
+//	svc.BasePath = srv.URL + "/compute/v1/projects/"
+//
+// The project in the URL is ignored as the mock is not project aware. The
+// filter parameter of List calls is evaluated with filter.Parse. The
+// aggregated lists of the zonal and regional resources have a scope for each
+// zone or region of the mock (MockZones, MockRegions) and of the objects, as
+// in the compute API the scopes without objects have a NO_RESULTS_ON_PAGE
+// warning.
+func NewHTTPHandler(mock *MockGCE) http.Handler {
+	return &httpHandler{
+		mock:   mock,
+		routes: mock.serverRoutes(),
+		ops:    map[string]*serverOperation{},
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	obj, err := h.serve(r)
+	if err != nil {
+		glog.V(5).Infof("httpHandler: %s %s = %v", r.Method, r.URL.Path, err)
+		writeServerError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		glog.Errorf("Could not encode the response to %s %s: %v", r.Method, r.URL.Path, err)
+	}
+}
+
+func (h *httpHandler) serve(r *http.Request) (interface{}, error) {
+	req, err := parseServerPath(r.URL.Path)
+	if err != nil {
+		return nil, &googleapi.Error{Code: http.StatusNotFound, Message: err.Error()}
+	}
+	version, ok := meta.VersionForURLName(req.version)
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("unknown API version %q", req.version)}
+	}
+
+	if req.resource == "operations" {
+		return h.getOperation(r, req)
+	}
+	if req.aggregated {
+		return h.aggregatedList(r, version, req)
+	}
+	route, ok := h.routes[serverRouteKey{version, req.keyType, req.resource}]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("resource %q is not served for %v", req.resource, version)}
+	}
+	if route.readOnly && r.Method != http.MethodGet {
+		return nil, &googleapi.Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("resource %q is read-only", req.resource)}
+	}
+	ctx := r.Context()
+
+	switch {
+	case r.Method == http.MethodGet && req.name != "" && route.get != nil:
+		return route.get(ctx, req.key(req.name))
+	case r.Method == http.MethodGet && req.name == "" && route.list != nil:
+		fl, err := requestFilter(r)
+		if err != nil {
+			return nil, err
+		}
+		items, err := route.list(ctx, req.location, fl)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"items": items}, nil
+	case r.Method == http.MethodPost && req.name == "" && route.insert != nil:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(body, &named); err != nil || named.Name == "" {
+			return nil, &googleapi.Error{Code: http.StatusBadRequest, Message: "the request body must be an object with a name"}
+		}
+		op, err := route.insert(ctx, req.key(named.Name), body)
+		if err != nil {
+			return nil, err
+		}
+		return h.newOperation(ctx, req, "insert", named.Name, op), nil
+	case r.Method == http.MethodDelete && req.name != "" && route.delete != nil:
+		op, err := route.delete(ctx, req.key(req.name))
+		if err != nil {
+			return nil, err
+		}
+		return h.newOperation(ctx, req, "delete", req.name, op), nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("%s is not supported for %q", r.Method, r.URL.Path)}
+}
+
+// requestFilter returns the filter of the filter parameter of r.
+func requestFilter(r *http.Request) (*filter.F, error) {
+	expr := r.URL.Query().Get("filter")
+	if expr == "" {
+		return filter.None, nil
+	}
+	fl, err := filter.Parse(expr)
+	if err != nil {
+		return nil, &googleapi.Error{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	return fl, nil
+}
+
+// aggregatedList serves the aggregated list of the zonal or regional
+// resource of the request. The items are keyed by scope ("zones/<zone>" or
+// "regions/<region>") as in the compute API.
+func (h *httpHandler) aggregatedList(r *http.Request, version meta.Version, req *serverRequest) (interface{}, error) {
+	if r.Method != http.MethodGet {
+		return nil, &googleapi.Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("%s is not supported for %q", r.Method, r.URL.Path)}
+	}
+	var (
+		route    *serverRoute
+		scopeFmt string
+		scopes   []string
+	)
+	if rt, ok := h.routes[serverRouteKey{version, meta.Zonal, req.resource}]; ok && rt.aggregatedList != nil {
+		route, scopeFmt, scopes = rt, "zones/%s", storedNames(h.mock.MockZones.mockStore)
+	} else if rt, ok := h.routes[serverRouteKey{version, meta.Regional, req.resource}]; ok && rt.aggregatedList != nil {
+		route, scopeFmt, scopes = rt, "regions/%s", storedNames(h.mock.MockRegions.mockStore)
+	} else {
+		return nil, &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("aggregated list of %q is not served for %v", req.resource, version)}
+	}
+	fl, err := requestFilter(r)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := route.aggregatedList(r.Context(), fl)
+	if err != nil {
+		return nil, err
+	}
+
+	field := strings.ToLower(route.aggregatedField[:1]) + route.aggregatedField[1:]
+	items := map[string]interface{}{}
+	for _, loc := range scopes {
+		scope := fmt.Sprintf(scopeFmt, loc)
+		items[scope] = map[string]interface{}{
+			"warning": map[string]interface{}{
+				"code":    "NO_RESULTS_ON_PAGE",
+				"message": fmt.Sprintf("There are no results for scope '%s' on this page.", scope),
+				"data":    []map[string]string{{"key": "scope", "value": scope}},
+			},
+		}
+	}
+	for loc, l := range objs {
+		items[fmt.Sprintf(scopeFmt, loc)] = map[string]interface{}{field: l}
+	}
+	return map[string]interface{}{"items": items}, nil
+}
+
+// storedNames returns the names of the objects of s, e.g. the zones of
+// MockZones.
+func storedNames[T, O any](s *mockStore[T, O]) []string {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	var names []string
+	for key := range s.Objects {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+// serverOperation is an operation returned by the handler.
+type serverOperation struct {
+	obj *ga.Operation
+	// op is the operation of the mock.
+	op cloud.Op
+}
+
+// status returns obj with the status of the operation of the mock. h.lock
+// must be held.
+func (o *serverOperation) status(ctx context.Context) *ga.Operation {
+	if o.obj.Status != "DONE" {
+		if done, _ := o.op.Done(ctx); done {
+			o.obj.Status = "DONE"
+			o.obj.Progress = 100
+		}
+	}
+	obj := *o.obj
+	return &obj
+}
+
+// newOperation records and returns the operation for a mutation of the
+// resource name, tracking op, the operation of the mock.
+func (h *httpHandler) newOperation(ctx context.Context, req *serverRequest, opType, name string, op cloud.Op) *ga.Operation {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.opSeq++
+	// The links use the URL of the compute API, as in the responses of the
+	// actual API, rather than the URL of the handler.
+	base := fmt.Sprintf("https://www.googleapis.com/compute/%s/projects/%s/", req.version, req.project)
+	obj := &ga.Operation{
+		Kind:          "compute#operation",
+		Name:          fmt.Sprintf("operation-%d", h.opSeq),
+		OperationType: opType,
+		Status:        "RUNNING",
+		TargetLink:    base + req.collectionPath() + "/" + name,
+	}
+	switch req.keyType {
+	case meta.Zonal:
+		obj.SelfLink = base + "zones/" + req.location + "/operations/" + obj.Name
+	case meta.Regional:
+		obj.SelfLink = base + "regions/" + req.location + "/operations/" + obj.Name
+	default:
+		obj.SelfLink = base + "global/operations/" + obj.Name
+	}
+	switch req.keyType {
+	case meta.Zonal:
+		obj.Zone = base + "zones/" + req.location
+	case meta.Regional:
+		obj.Region = base + "regions/" + req.location
+	}
+	h.ops[obj.Name] = &serverOperation{obj: obj, op: op}
+	return h.ops[obj.Name].status(ctx)
+}
+
+// getOperation returns the operation named in the request.
+func (h *httpHandler) getOperation(r *http.Request, req *serverRequest) (interface{}, error) {
+	if r.Method != http.MethodGet || req.name == "" {
+		return nil, &googleapi.Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("%s is not supported for %q", r.Method, r.URL.Path)}
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if op, ok := h.ops[req.name]; ok {
+		return op.status(r.Context()), nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("operation %q not found", req.name)}
+}
+
+// writeServerError writes err in the format of the compute API errors. The
+// status code is taken from the *googleapi.Error in the chain of err, if any.
+func writeServerError(w http.ResponseWriter, err error) {
+	code, message := http.StatusInternalServerError, err.Error()
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		code, message = gerr.Code, gerr.Message
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	body := map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	}
+	if encErr := json.NewEncoder(w).Encode(body); encErr != nil {
+		glog.Errorf("Could not encode error %v: %v", err, encErr)
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud"
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// newTestServerGCE returns a GCE adapter that uses the compute API client
+// against NewHTTPHandler(m).
+func newTestServerGCE(t *testing.T, m *MockGCE) *cloud.GCE {
+	t.Helper()
+
+	srv := httptest.NewServer(NewHTTPHandler(m))
+	t.Cleanup(srv.Close)
+
+	svc, err := ga.New(srv.Client())
+	if err != nil {
+		t.Fatalf("ga.New() = _, %v", err)
+	}
+	svc.BasePath = srv.URL + "/compute/v1/projects/"
+	return cloud.NewGCE(&cloud.Service{
+		GA:            svc,
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj"},
+		RateLimiter:   &cloud.NopRateLimiter{},
+	})
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMockGCE()
+	gce := newTestServerGCE(t, m)
+
+	// Objects in the mock are served by the handler.
+	instKey := meta.ZonalKey("vm", "us-central1-b")
+	m.MockInstances.Insert(ctx, *instKey, &ga.Instance{Name: "vm", MachineType: "n1-standard-1"})
+	if inst, err := gce.Instances().Get(ctx, *instKey); err != nil || inst.MachineType != "n1-standard-1" {
+		t.Errorf("Instances().Get(%v) = %+v, %v; want n1-standard-1, nil", instKey, inst, err)
+	}
+	if _, err := gce.Instances().Get(ctx, *meta.ZonalKey("missing", "us-central1-b")); !cloud.IsNotFound(err) {
+		t.Errorf("Instances().Get(missing) = _, %v; want 404", err)
+	}
+
+	// Mutations through the handler are visible in the mock. Insert and
+	// Delete wait on the operations served by the handler.
+	fwKey := meta.GlobalKey("fw")
+	if err := gce.Firewalls().Insert(ctx, *fwKey, &ga.Firewall{Name: "fw", Network: "default"}); err != nil {
+		t.Fatalf("Firewalls().Insert(%v) = %v; want nil", fwKey, err)
+	}
+	if fw, err := m.Firewalls().Get(ctx, *fwKey); err != nil || fw.Network != "default" {
+		t.Errorf("MockFirewalls.Get(%v) = %+v, %v; want default, nil", fwKey, fw, err)
+	}
+	if err := gce.Firewalls().Insert(ctx, *fwKey, &ga.Firewall{Name: "fw"}); err == nil {
+		t.Errorf("Firewalls().Insert(%v) = nil; want error for existing object", fwKey)
+	}
+
+	addrKey := meta.RegionalKey("addr", "us-central1")
+	if err := gce.Addresses().Insert(ctx, *addrKey, &ga.Address{Name: "addr"}); err != nil {
+		t.Fatalf("Addresses().Insert(%v) = %v; want nil", addrKey, err)
+	}
+	if addrs, err := gce.Addresses().List(ctx, "us-central1", filter.None); err != nil || len(addrs) != 1 {
+		t.Errorf("Addresses().List(us-central1) = %+v, %v; want 1 item, nil", addrs, err)
+	}
+	if addrs, err := gce.Addresses().List(ctx, "europe-west1", filter.None); err != nil || len(addrs) != 0 {
+		t.Errorf("Addresses().List(europe-west1) = %+v, %v; want 0 items, nil", addrs, err)
+	}
+	// The object is shared with the other versions of the mock.
+	if _, err := m.AlphaAddresses().Get(ctx, *addrKey); err != nil {
+		t.Errorf("MockAlphaAddresses.Get(%v) = _, %v; want nil", addrKey, err)
+	}
+
+	if err := gce.Instances().Delete(ctx, *instKey); err != nil {
+		t.Errorf("Instances().Delete(%v) = %v; want nil", instKey, err)
+	}
+	if _, ok := m.MockInstances.Objects[*instKey]; ok {
+		t.Errorf("MockInstances.Objects[%v] exists after Delete()", instKey)
+	}
+}
+
+func TestHTTPHandlerAsync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMockGCE()
+	m.MockOperations.Async = true
+	gce := newTestServerGCE(t, m)
+
+	// InsertOp returns the operation served as RUNNING by the handler and
+	// Wait polls it until the operation of the mock completes.
+	key := *meta.ZonalKey("vm", "us-central1-b")
+	op, err := gce.Instances().InsertOp(ctx, key, &ga.Instance{Name: "vm", MachineType: "n1-standard-1"})
+	if err != nil {
+		t.Fatalf("Instances().InsertOp(%v) = _, %v; want nil", key, err)
+	}
+	if done, err := op.Done(ctx); done || err != nil {
+		t.Errorf("Done() = %t, %v; want false, nil", done, err)
+	}
+	if _, ok := m.MockInstances.Objects[key]; ok {
+		t.Errorf("MockInstances.Objects[%v] exists before the operation is done", key)
+	}
+	m.MockOperations.Step()
+	if err := op.Wait(ctx); err != nil {
+		t.Errorf("Wait() = %v; want nil", err)
+	}
+	if _, ok := m.MockInstances.Objects[key]; !ok {
+		t.Errorf("MockInstances.Objects[%v] does not exist after the operation is done", key)
+	}
+}
+
+func TestHTTPHandlerFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMockGCE()
+	gce := newTestServerGCE(t, m)
+
+	for _, fw := range []*ga.Firewall{
+		{Name: "fw-a", Network: "default", Priority: 100},
+		{Name: "fw-b", Network: "default", Priority: 1000},
+		{Name: "other", Network: "vpc", Priority: 1000},
+	} {
+		m.MockFirewalls.Insert(ctx, *meta.GlobalKey(fw.Name), fw)
+	}
+
+	for _, tc := range []struct {
+		expr string
+		want []string
+	}{
+		{expr: "name eq fw-.*", want: []string{"fw-a", "fw-b"}},
+		{expr: "(network = default) (priority > 100)", want: []string{"fw-b"}},
+		{expr: "(name = fw-a) OR (network = vpc)", want: []string{"fw-a", "other"}},
+		{expr: "NOT (network = default)", want: []string{"other"}},
+	} {
+		fws, err := gce.Firewalls().List(ctx, filter.MustParse(tc.expr))
+		if err != nil {
+			t.Errorf("Firewalls().List(%q) = _, %v; want nil", tc.expr, err)
+			continue
+		}
+		var got []string
+		for _, fw := range fws {
+			got = append(got, fw.Name)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Firewalls().List(%q) = %v; want %v", tc.expr, got, tc.want)
+		}
+	}
+
+	srv := httptest.NewServer(NewHTTPHandler(m))
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL + "/compute/v1/projects/proj/global/firewalls?filter=name+lt+3")
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("GET firewalls?filter=name lt 3: status = %d; want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestHTTPHandlerAggregatedList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMockGCE()
+	for _, zone := range []string{"us-central1-a", "us-central1-b"} {
+		m.MockZones.Objects[*meta.GlobalKey(zone)] = newMockZonesObj(&ga.Zone{Name: zone})
+	}
+	m.MockInstances.Insert(ctx, *meta.ZonalKey("vm-1", "us-central1-b"), &ga.Instance{Name: "vm-1", MachineType: "n1-standard-1"})
+	m.MockInstances.Insert(ctx, *meta.ZonalKey("vm-2", "europe-west1-b"), &ga.Instance{Name: "vm-2", MachineType: "n1-standard-1"})
+
+	// The response has a scope for each zone of the mock and of the objects.
+	srv := httptest.NewServer(NewHTTPHandler(m))
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL + "/compute/v1/projects/proj/aggregated/instances")
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	defer resp.Body.Close()
+	var list ga.InstanceAggregatedList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("Decode() = %v; want nil", err)
+	}
+	var scopes []string
+	for scope := range list.Items {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	if want := []string{"zones/europe-west1-b", "zones/us-central1-a", "zones/us-central1-b"}; !reflect.DeepEqual(scopes, want) {
+		t.Errorf("scopes = %v; want %v", scopes, want)
+	}
+	if l := list.Items["zones/us-central1-a"]; len(l.Instances) != 0 || l.Warning == nil || l.Warning.Code != "NO_RESULTS_ON_PAGE" {
+		t.Errorf("Items[zones/us-central1-a] = %+v; want no instances and a NO_RESULTS_ON_PAGE warning", l)
+	}
+	if l := list.Items["zones/us-central1-b"]; len(l.Instances) != 1 || l.Instances[0].Name != "vm-1" || l.Warning != nil {
+		t.Errorf("Items[zones/us-central1-b] = %+v; want vm-1", l)
+	}
+
+	// The adapter groups the objects by zone.
+	gce := newTestServerGCE(t, m)
+	got, err := gce.Instances().AggregatedList(ctx, filter.MustParse("name eq vm-1"))
+	if err != nil || len(got) != 1 || len(got["us-central1-b"]) != 1 {
+		t.Errorf("Instances().AggregatedList(name eq vm-1) = %+v, %v; want vm-1 in us-central1-b, nil", got, err)
+	}
+	addrs, err := gce.Addresses().AggregatedList(ctx, filter.None)
+	if err != nil || len(addrs) != 0 {
+		t.Errorf("Addresses().AggregatedList() = %+v, %v; want no addresses, nil", addrs, err)
+	}
+}
+
+func TestHTTPHandlerReadOnly(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(NewHTTPHandler(NewMockGCE()))
+	defer srv.Close()
+
+	resp, err := srv.Client().Post(srv.URL+"/compute/v1/projects/proj/zones", "application/json", strings.NewReader(`{"name": "zone"}`))
+	if err != nil {
+		t.Fatalf("Post() = _, %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST zones: status = %d; want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+
+	resp, err = srv.Client().Get(srv.URL + "/compute/v1/projects/proj/zones/zone")
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET zones/zone: status = %d; want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestParseServerPath(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		path string
+		want *serverRequest
+	}{
+		{"/compute/v1/projects/p/global/firewalls", &serverRequest{"v1", "p", meta.Global, "", "firewalls", "", false}},
+		{"/compute/v1/projects/p/global/firewalls/fw", &serverRequest{"v1", "p", meta.Global, "", "firewalls", "fw", false}},
+		{"/compute/alpha/projects/p/regions/r/addresses/a", &serverRequest{"alpha", "p", meta.Regional, "r", "addresses", "a", false}},
+		{"/compute/beta/projects/p/zones/z/instances", &serverRequest{"beta", "p", meta.Zonal, "z", "instances", "", false}},
+		{"/compute/v1/projects/p/zones/z", &serverRequest{"v1", "p", meta.Global, "", "zones", "z", false}},
+		{"/compute/v1/projects/p/regions", &serverRequest{"v1", "p", meta.Global, "", "regions", "", false}},
+		{"/compute/v1/projects/p/zones/z/operations/op", &serverRequest{"v1", "p", meta.Zonal, "z", "operations", "op", false}},
+		{"/compute/v1/projects/p/aggregated/instances", &serverRequest{"v1", "p", "", "", "instances", "", true}},
+		{"/compute/v1/projects/p/aggregated/instances/vm", nil},
+		{"/compute/v1/projects/p", nil},
+		{"/compute/v1/projects/p/global", nil},
+		{"/compute/v1/projects/p/global/firewalls/fw/extra", nil},
+		{"/v1/projects/p/global/firewalls", nil},
+	} {
+		got, err := parseServerPath(tc.path)
+		if tc.want == nil {
+			if err == nil {
+				t.Errorf("parseServerPath(%q) = %+v, nil; want error", tc.path, got)
+			}
+			continue
+		}
+		if err != nil || *got != *tc.want {
+			t.Errorf("parseServerPath(%q) = %+v, %v; want %+v, nil", tc.path, got, err, tc.want)
+		}
+	}
+}
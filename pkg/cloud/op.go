@@ -18,28 +18,39 @@ package cloud
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	ga "google.golang.org/api/compute/v1"
 
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
 	"github.com/bowei/gce-gen/pkg/cloud/meta"
 )
 
 // operation is a GCE operation that can be watied on.
 type operation interface {
-	// isDone queries GCE for the done status. This call can block.
+	// isDone queries GCE for the done status. This call can block. An
+	// operation that completed with errors is done with an *OperationError.
 	isDone(ctx context.Context) (bool, error)
 	// rateLimitKey returns the rate limit key to use for the given operation.
 	// This rate limit will govern how fast the server will be polled for
 	// operation completion status.
 	rateLimitKey() *RateLimitKey
+	// key returns the key of the operation in the GlobalOperations,
+	// RegionOperations or ZoneOperations service.
+	key() *meta.Key
+	// delete deletes the operation (see PollPolicy.DeleteOnCancel).
+	delete(ctx context.Context) error
 }
 
 type gaOperation struct {
 	s         *Service
 	op        *ga.Operation
 	projectID string
+	opKey     *meta.Key
 }
 
 func (o *gaOperation) isDone(ctx context.Context) (bool, error) {
@@ -48,18 +59,44 @@ func (o *gaOperation) isDone(ctx context.Context) (bool, error) {
 		err error
 	)
 
-	switch {
-	case o.op.Region != "":
-		op, err = o.s.GA.RegionOperations.Get(o.projectID, o.op.Region, o.op.Name).Context(ctx).Do()
-	case o.op.Zone != "":
-		op, err = o.s.GA.ZoneOperations.Get(o.projectID, o.op.Zone, o.op.Name).Context(ctx).Do()
+	svc, err := o.s.gaService(o.opKey.Location())
+	if err != nil {
+		return false, err
+	}
+	switch k := o.opKey; k.Type() {
+	case meta.Regional:
+		op, err = do(o.s, svc.RegionOperations.Get(o.projectID, k.Region, k.Name).Context(ctx))
+	case meta.Zonal:
+		op, err = do(o.s, svc.ZoneOperations.Get(o.projectID, k.Zone, k.Name).Context(ctx))
 	default:
-		op, err = o.s.GA.GlobalOperations.Get(o.projectID, o.op.Name).Context(ctx).Do()
+		op, err = do(o.s, svc.GlobalOperations.Get(o.projectID, k.Name).Context(ctx))
 	}
 	if err != nil {
 		return false, err
 	}
-	return op != nil && op.Status == "DONE", nil
+	if op == nil || op.Status != "DONE" {
+		return false, nil
+	}
+	return true, CheckOperation(op)
+}
+
+func (o *gaOperation) key() *meta.Key {
+	return o.opKey
+}
+
+func (o *gaOperation) delete(ctx context.Context) error {
+	svc, err := o.s.gaService(o.opKey.Location())
+	if err != nil {
+		return err
+	}
+	switch k := o.opKey; k.Type() {
+	case meta.Regional:
+		return doNoResult(o.s, svc.RegionOperations.Delete(o.projectID, k.Region, k.Name).Context(ctx))
+	case meta.Zonal:
+		return doNoResult(o.s, svc.ZoneOperations.Delete(o.projectID, k.Zone, k.Name).Context(ctx))
+	default:
+		return doNoResult(o.s, svc.GlobalOperations.Delete(o.projectID, k.Name).Context(ctx))
+	}
 }
 
 func (o *gaOperation) rateLimitKey() *RateLimitKey {
@@ -75,6 +112,7 @@ type alphaOperation struct {
 	s         *Service
 	op        *alpha.Operation
 	projectID string
+	opKey     *meta.Key
 }
 
 func (o *alphaOperation) isDone(ctx context.Context) (bool, error) {
@@ -83,18 +121,44 @@ func (o *alphaOperation) isDone(ctx context.Context) (bool, error) {
 		err error
 	)
 
-	switch {
-	case o.op.Region != "":
-		op, err = o.s.Alpha.RegionOperations.Get(o.projectID, o.op.Region, o.op.Name).Context(ctx).Do()
-	case o.op.Zone != "":
-		op, err = o.s.Alpha.ZoneOperations.Get(o.projectID, o.op.Zone, o.op.Name).Context(ctx).Do()
+	svc, err := o.s.alphaService(o.opKey.Location())
+	if err != nil {
+		return false, err
+	}
+	switch k := o.opKey; k.Type() {
+	case meta.Regional:
+		op, err = do(o.s, svc.RegionOperations.Get(o.projectID, k.Region, k.Name).Context(ctx))
+	case meta.Zonal:
+		op, err = do(o.s, svc.ZoneOperations.Get(o.projectID, k.Zone, k.Name).Context(ctx))
 	default:
-		op, err = o.s.Alpha.GlobalOperations.Get(o.projectID, o.op.Name).Context(ctx).Do()
+		op, err = do(o.s, svc.GlobalOperations.Get(o.projectID, k.Name).Context(ctx))
 	}
 	if err != nil {
 		return false, err
 	}
-	return op != nil && op.Status == "DONE", nil
+	if op == nil || op.Status != "DONE" {
+		return false, nil
+	}
+	return true, CheckOperation(op)
+}
+
+func (o *alphaOperation) key() *meta.Key {
+	return o.opKey
+}
+
+func (o *alphaOperation) delete(ctx context.Context) error {
+	svc, err := o.s.alphaService(o.opKey.Location())
+	if err != nil {
+		return err
+	}
+	switch k := o.opKey; k.Type() {
+	case meta.Regional:
+		return doNoResult(o.s, svc.RegionOperations.Delete(o.projectID, k.Region, k.Name).Context(ctx))
+	case meta.Zonal:
+		return doNoResult(o.s, svc.ZoneOperations.Delete(o.projectID, k.Zone, k.Name).Context(ctx))
+	default:
+		return doNoResult(o.s, svc.GlobalOperations.Delete(o.projectID, k.Name).Context(ctx))
+	}
 }
 
 func (o *alphaOperation) rateLimitKey() *RateLimitKey {
@@ -110,6 +174,7 @@ type betaOperation struct {
 	s         *Service
 	op        *beta.Operation
 	projectID string
+	opKey     *meta.Key
 }
 
 func (o *betaOperation) isDone(ctx context.Context) (bool, error) {
@@ -118,18 +183,44 @@ func (o *betaOperation) isDone(ctx context.Context) (bool, error) {
 		err error
 	)
 
-	switch {
-	case o.op.Region != "":
-		op, err = o.s.Beta.RegionOperations.Get(o.projectID, o.op.Region, o.op.Name).Context(ctx).Do()
-	case o.op.Zone != "":
-		op, err = o.s.Beta.ZoneOperations.Get(o.projectID, o.op.Zone, o.op.Name).Context(ctx).Do()
+	svc, err := o.s.betaService(o.opKey.Location())
+	if err != nil {
+		return false, err
+	}
+	switch k := o.opKey; k.Type() {
+	case meta.Regional:
+		op, err = do(o.s, svc.RegionOperations.Get(o.projectID, k.Region, k.Name).Context(ctx))
+	case meta.Zonal:
+		op, err = do(o.s, svc.ZoneOperations.Get(o.projectID, k.Zone, k.Name).Context(ctx))
 	default:
-		op, err = o.s.Beta.GlobalOperations.Get(o.projectID, o.op.Name).Context(ctx).Do()
+		op, err = do(o.s, svc.GlobalOperations.Get(o.projectID, k.Name).Context(ctx))
 	}
 	if err != nil {
 		return false, err
 	}
-	return op != nil && op.Status == "DONE", nil
+	if op == nil || op.Status != "DONE" {
+		return false, nil
+	}
+	return true, CheckOperation(op)
+}
+
+func (o *betaOperation) key() *meta.Key {
+	return o.opKey
+}
+
+func (o *betaOperation) delete(ctx context.Context) error {
+	svc, err := o.s.betaService(o.opKey.Location())
+	if err != nil {
+		return err
+	}
+	switch k := o.opKey; k.Type() {
+	case meta.Regional:
+		return doNoResult(o.s, svc.RegionOperations.Delete(o.projectID, k.Region, k.Name).Context(ctx))
+	case meta.Zonal:
+		return doNoResult(o.s, svc.ZoneOperations.Delete(o.projectID, k.Zone, k.Name).Context(ctx))
+	default:
+		return doNoResult(o.s, svc.GlobalOperations.Delete(o.projectID, k.Name).Context(ctx))
+	}
 }
 
 func (o *betaOperation) rateLimitKey() *RateLimitKey {
@@ -140,3 +231,176 @@ func (o *betaOperation) rateLimitKey() *RateLimitKey {
 		Version:   meta.VersionBeta,
 	}
 }
+
+// operationKey returns the project of an operation and its key in the
+// GlobalOperations, RegionOperations or ZoneOperations collection of its
+// scope. They are parsed from selfLink or, if the operation has none (e.g.
+// it was left out by a Fields() projection), from its name and the URL of its
+// region or zone.
+func operationKey(selfLink, name, region, zone string) (string, *meta.Key, error) {
+	if selfLink != "" {
+		r, err := ParseResourceURL(selfLink)
+		if err != nil {
+			return "", nil, err
+		}
+		if r.Key == nil {
+			return "", nil, fmt.Errorf("%q is not the URL of an operation", selfLink)
+		}
+		return r.ProjectID, r.Key, nil
+	}
+	for _, link := range []string{region, zone} {
+		if link == "" {
+			continue
+		}
+		r, err := ParseResourceURL(link)
+		if err != nil || r.Key == nil {
+			return "", nil, fmt.Errorf("operation %q has no self-link and %q is not the URL of a region or zone", name, link)
+		}
+		if r.Resource == "regions" {
+			return r.ProjectID, meta.RegionalKey(name, r.Key.Name), nil
+		}
+		return r.ProjectID, meta.ZonalKey(name, r.Key.Name), nil
+	}
+	return "", nil, fmt.Errorf("operation %q has neither a self-link nor a region or zone", name)
+}
+
+// PollPolicy is the policy for polling the status of an operation until it
+// completes. The zero value polls as fast as the RateLimiter allows.
+type PollPolicy struct {
+	// Interval is the delay between the first two polls.
+	Interval time.Duration
+	// Multiplier is the factor applied to the delay after each poll. A
+	// value of 1 or less keeps the delay constant.
+	Multiplier float64
+	// MaxInterval is the longest delay between two polls, if non-zero.
+	MaxInterval time.Duration
+	// Backoff, if set, replaces Interval, Multiplier and MaxInterval, e.g.
+	// to add jitter. Service.Backoff is used if nil.
+	Backoff *Backoff
+	// Timeout is the longest time to wait for the operation, if non-zero.
+	Timeout time.Duration
+	// DeleteOnCancel, if set, makes a best-effort Delete of the operation
+	// when the wait is abandoned because the context is done or the
+	// Timeout expired, within operationDeleteTimeout. Whether the work of
+	// the operation stops is up to the compute API.
+	DeleteOnCancel bool
+}
+
+// operationDeleteTimeout bounds the Delete of an abandoned operation (see
+// PollPolicy.DeleteOnCancel), which is made after the context of the wait is
+// done.
+const operationDeleteTimeout = 5 * time.Second
+
+// delays returns the Backoff of the polls: Backoff or the one given by
+// Interval, Multiplier and MaxInterval.
+func (p *PollPolicy) delays() *Backoff {
+	if p.Backoff != nil {
+		return p.Backoff
+	}
+	return &Backoff{Initial: p.Interval, Multiplier: p.Multiplier, Max: p.MaxInterval}
+}
+
+// pollPolicy returns the policy for polling the operation of the mutation
+// described by rk: the one of the most specific selector in PollPolicies
+// that matches rk, or PollPolicy.
+func (g *Service) pollPolicy(rk *RateLimitKey) *PollPolicy {
+	for _, sel := range rateLimitSelectors(rk) {
+		if p, ok := g.PollPolicies[sel]; ok {
+			return p
+		}
+	}
+	return g.PollPolicy
+}
+
+// Op is a pending mutation returned by the InsertOp and DeleteOp methods. It
+// is defined in package interfaces.
+type Op = interfaces.Op
+
+// DoneOp returns an Op that has already completed with the error err (e.g.
+// for the mocks, whose mutations complete immediately).
+func DoneOp(err error) Op {
+	return &pendingOp{done: true, err: err}
+}
+
+// pendingOp implements Op for an operation started by a mutation.
+type pendingOp struct {
+	s  *Service
+	op operation
+	// policy is the PollPolicy of Wait().
+	policy *PollPolicy
+	// onDone is called when the operation has completed successfully.
+	onDone func(ctx context.Context)
+
+	lock sync.Mutex
+	done bool
+	err  error
+}
+
+func (o *pendingOp) Key() *meta.Key {
+	if o.op == nil {
+		return nil
+	}
+	return o.op.key()
+}
+
+// Done polls the operation once, subject to the RateLimiter, unless it is
+// known to have completed.
+func (o *pendingOp) Done(ctx context.Context) (bool, error) {
+	if o.completed() {
+		return true, nil
+	}
+	if err := o.s.RateLimiter.Accept(ctx, o.op.rateLimitKey()); err != nil {
+		return false, err
+	}
+	done, err := o.s.isDone(ctx, o.op)
+	if done {
+		// The error of the operation is returned by Error().
+		o.complete(ctx, err)
+		return true, nil
+	}
+	return false, err
+}
+
+// Wait polls the operation according to the PollPolicy of the mutation until
+// it completes.
+func (o *pendingOp) Wait(ctx context.Context) error {
+	if o.completed() {
+		return o.Error()
+	}
+	err := o.s.waitForOperation(ctx, o.op, o.policy)
+	if err != nil && !IsOperationError(err) {
+		return err
+	}
+	o.complete(ctx, err)
+	return o.Error()
+}
+
+func (o *pendingOp) Error() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.err
+}
+
+func (o *pendingOp) completed() bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.done
+}
+
+// complete records the completion of the operation with err. onDone is
+// called once, even if the completion is observed by concurrent calls.
+func (o *pendingOp) complete(ctx context.Context, err error) {
+	o.lock.Lock()
+	if o.done {
+		o.lock.Unlock()
+		return
+	}
+	o.done, o.err = true, err
+	o.lock.Unlock()
+
+	if err == nil && o.onDone != nil {
+		o.onDone(ctx)
+	}
+}
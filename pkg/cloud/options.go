@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
+)
+
+// Option is an option of a single call of a method of a service. It is
+// defined in package interfaces.
+type Option = interfaces.Option
+
+// CallConfig is the configuration of a call, as set by its Options. It is
+// defined in package interfaces.
+type CallConfig = interfaces.CallConfig
+
+// Timeout bounds the call to d, including waiting for the operation of a
+// mutation.
+func Timeout(d time.Duration) Option {
+	return func(c *CallConfig) { c.Timeout = d }
+}
+
+// FieldMask restricts the fields of the response of a read to fields, in the
+// syntax of the "fields" parameter of the API (e.g. "name,selfLink" for Get,
+// "items(name),nextPageToken" for List). Unlike Fields(), it only applies to
+// the call it is given to.
+func FieldMask(fields ...string) Option {
+	return func(c *CallConfig) { c.Fields = append(c.Fields, fields...) }
+}
+
+// RequestID sets the idempotency token of a mutation, so that retrying it
+// with the same id (e.g. after a restart) does not repeat it.
+func RequestID(id string) Option {
+	return func(c *CallConfig) { c.RequestID = id }
+}
+
+// SkipWait makes a mutation return as soon as its operation is started. The
+// operation is not returned, so its outcome is unknown to the caller: a caller
+// that needs to wait for the operation must use InsertOp or DeleteOp instead.
+// The change is not recorded to the Service.ChangeSink.
+func SkipWait() Option {
+	return func(c *CallConfig) { c.SkipWait = true }
+}
+
+// Project makes the call in the project id instead of the one given by the
+// Service.ProjectRouter (e.g. to list the subnets of the host project of a
+// Shared VPC).
+func Project(id string) Option {
+	return func(c *CallConfig) { c.ProjectID = id }
+}
+
+// callContext returns ctx bounded by the Timeout of cfg.
+func callContext(ctx context.Context, cfg CallConfig) (context.Context, context.CancelFunc) {
+	if cfg.Timeout > 0 {
+		return context.WithTimeout(ctx, cfg.Timeout)
+	}
+	return ctx, func() {}
+}
+
+// readOptions returns the CallOptions of cfg for a read.
+func readOptions(cfg CallConfig) []googleapi.CallOption {
+	if len(cfg.Fields) == 0 {
+		return nil
+	}
+	return []googleapi.CallOption{Fields(cfg.Fields...)}
+}
+
+// mutationOptions returns the CallOptions of cfg for a mutation.
+func mutationOptions(cfg CallConfig) []googleapi.CallOption {
+	if cfg.RequestID == "" {
+		return nil
+	}
+	return []googleapi.CallOption{requestIDOption(cfg.RequestID)}
+}
+
+// requestIDOption is the CallOption of RequestID().
+type requestIDOption string
+
+func (r requestIDOption) Get() (string, string) { return "requestId", string(r) }
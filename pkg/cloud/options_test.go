@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/interfaces"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestNewCallConfig(t *testing.T) {
+	t.Parallel()
+
+	got := interfaces.NewCallConfig(Timeout(time.Second), FieldMask("name"), FieldMask("selfLink"), RequestID("id"), SkipWait(), Project("other"))
+	want := CallConfig{Timeout: time.Second, Fields: []string{"name", "selfLink"}, RequestID: "id", SkipWait: true, ProjectID: "other"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCallConfig() = %+v; want %+v", got, want)
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var got []string
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		got = append(got, r.Method+" "+r.URL.Path+" fields="+q.Get("fields")+" requestId="+q.Get("requestId"))
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "GET /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw"}}})
+		case "GET /compute/v1/projects/proj/global/firewalls/slow":
+			<-r.Context().Done()
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+
+	key := *meta.GlobalKey("fw")
+	if _, err := gce.Firewalls().Get(ctx, key, FieldMask("name", "selfLink")); err != nil {
+		t.Errorf("Firewalls().Get() = _, %v; want nil", err)
+	}
+	if _, err := gce.Firewalls().List(ctx, filter.None, FieldMask("items(name)")); err != nil {
+		t.Errorf("Firewalls().List() = _, %v; want nil", err)
+	}
+	// The fields are not applied to the operation of a mutation.
+	if err := gce.Firewalls().Insert(ctx, key, &ga.Firewall{}, RequestID("req-1"), FieldMask("name")); err != nil {
+		t.Errorf("Firewalls().Insert() = %v; want nil", err)
+	}
+	// The operation is not polled.
+	if err := gce.Firewalls().Insert(ctx, key, &ga.Firewall{}, SkipWait()); err != nil {
+		t.Errorf("Firewalls().Insert() = %v; want nil", err)
+	}
+	want := []string{
+		"GET /compute/v1/projects/proj/global/firewalls/fw fields=name,selfLink requestId=",
+		"GET /compute/v1/projects/proj/global/firewalls fields=items(name) requestId=",
+		"POST /compute/v1/projects/proj/global/firewalls fields= requestId=req-1",
+		"GET /compute/v1/projects/proj/global/operations/op fields= requestId=",
+		"POST /compute/v1/projects/proj/global/firewalls fields= requestId=",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("requested %q; want %q", got, want)
+	}
+
+	_, err := gce.Firewalls().Get(ctx, *meta.GlobalKey("slow"), Timeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Firewalls().Get(slow) = _, %v; want %v", err, context.DeadlineExceeded)
+	}
+}
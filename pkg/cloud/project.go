@@ -42,3 +42,70 @@ type SingleProjectRouter struct {
 func (r *SingleProjectRouter) ProjectID(ctx context.Context, version meta.Version, service string) string {
 	return r.ID
 }
+
+// ServiceProjectRouter routes the calls to a project by service, e.g. the
+// networking services to the host project of a Shared VPC and the other
+// services to a service project:
+//
+//	router := &cloud.ServiceProjectRouter{
+//		Default: "service-project",
+//		Tags:    map[meta.Tag]string{meta.TagNetworking: "host-project"},
+//	}
+type ServiceProjectRouter struct {
+	// Default is the project of the services that are not routed by
+	// Services or Tags.
+	Default string
+	// Services are the projects of the services by name (e.g.
+	// "Firewalls"), at every version.
+	Services map[string]string
+	// Tags are the projects of the services with the tags (see
+	// Resource.Tags). Services takes precedence. A service with several of
+	// the tags is routed by its first one.
+	Tags map[meta.Tag]string
+}
+
+func (r *ServiceProjectRouter) ProjectID(ctx context.Context, version meta.Version, service string) string {
+	if id, ok := r.Services[service]; ok {
+		return id
+	}
+	if len(r.Tags) > 0 {
+		if res, ok := LookupResource(service, version); ok {
+			for _, t := range res.Tags {
+				if id, ok := r.Tags[t]; ok {
+					return id
+				}
+			}
+		}
+	}
+	return r.Default
+}
+
+type projectIDKey struct{}
+
+// WithProjectID returns a context that routes the calls made with it to the
+// project projectID when used with OverrideProjectRouter.
+func WithProjectID(ctx context.Context, projectID string) context.Context {
+	return context.WithValue(ctx, projectIDKey{}, projectID)
+}
+
+// ProjectIDFromContext returns the project set by WithProjectID, if any.
+func ProjectIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(projectIDKey{}).(string)
+	return id, ok
+}
+
+// OverrideProjectRouter routes the calls made with a context returned by
+// WithProjectID to its project and the other calls with Router:
+//
+//	svc.ProjectRouter = &cloud.OverrideProjectRouter{Router: &cloud.SingleProjectRouter{ID: "proj"}}
+//	err := c.Firewalls().Insert(cloud.WithProjectID(ctx, "other-proj"), key, obj)
+type OverrideProjectRouter struct {
+	Router ProjectRouter
+}
+
+func (r *OverrideProjectRouter) ProjectID(ctx context.Context, version meta.Version, service string) string {
+	if id, ok := ProjectIDFromContext(ctx); ok {
+		return id
+	}
+	return r.Router.ProjectID(ctx, version, service)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestServiceProjectRouter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &ServiceProjectRouter{
+		Default:  "service",
+		Services: map[string]string{"Routes": "routes"},
+		Tags:     map[meta.Tag]string{meta.TagNetworking: "host"},
+	}
+	for _, tc := range []struct {
+		version meta.Version
+		service string
+		want    string
+	}{
+		{meta.VersionGA, "Firewalls", "host"},
+		{meta.VersionAlpha, "Addresses", "host"},
+		{meta.VersionGA, "Routes", "routes"},
+		{meta.VersionGA, "Instances", "service"},
+		{meta.VersionGA, "Unknown", "service"},
+	} {
+		if got := r.ProjectID(ctx, tc.version, tc.service); got != tc.want {
+			t.Errorf("ProjectID(%v, %q) = %q; want %q", tc.version, tc.service, got, tc.want)
+		}
+	}
+}
+
+func TestOverrideProjectRouter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &OverrideProjectRouter{Router: &SingleProjectRouter{ID: "proj"}}
+	if got := r.ProjectID(ctx, meta.VersionGA, "Firewalls"); got != "proj" {
+		t.Errorf("ProjectID() = %q; want %q", got, "proj")
+	}
+	octx := WithProjectID(ctx, "other")
+	if got := r.ProjectID(octx, meta.VersionGA, "Firewalls"); got != "other" {
+		t.Errorf("ProjectID() with WithProjectID(ctx, %q) = %q; want %q", "other", got, "other")
+	}
+
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compute/v1/projects/other/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	s.ProjectRouter = r
+	key := meta.GlobalKey("fw")
+	if _, err := NewGCE(s).Firewalls().Get(octx, *key); err != nil {
+		t.Errorf("Firewalls().Get(%v) in project other = _, %v; want nil", key, err)
+	}
+	if _, err := NewGCE(s).Firewalls().Get(ctx, *key); !IsNotFound(err) {
+		t.Errorf("Firewalls().Get(%v) in project proj = _, %v; want http.StatusNotFound", key, err)
+	}
+}
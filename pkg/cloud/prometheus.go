@@ -0,0 +1,193 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// DefaultLatencyBuckets are the upper bounds in seconds of the latency
+// histogram of a PrometheusRecorder. They range from the reads served in
+// milliseconds to the mutations waiting minutes for their operation.
+var DefaultLatencyBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// NewPrometheusRecorder returns a PrometheusRecorder with the latency
+// histogram buckets (upper bounds in seconds, increasing) or
+// DefaultLatencyBuckets if nil.
+func NewPrometheusRecorder(buckets []float64) *PrometheusRecorder {
+	if buckets == nil {
+		buckets = DefaultLatencyBuckets
+	}
+	return &PrometheusRecorder{
+		buckets:   buckets,
+		inFlight:  map[callLabels]int{},
+		calls:     map[callCodeLabels]uint64{},
+		latencies: map[callLabels]*histogram{},
+	}
+}
+
+// PrometheusRecorder is a MetricsRecorder and CallStartRecorder that exports
+// the calls in the Prometheus text format when served over HTTP (e.g. on
+// "/metrics"):
+//
+//	gce_api_calls_in_flight{version,service,operation}: the calls in progress.
+//	gce_api_calls_total{version,service,operation,code}: the calls made,
+//	  by HTTP status code ("0" if the call failed without one).
+//	gce_api_call_duration_seconds{version,service,operation}: a histogram
+//	  of the latency of the calls.
+//
+// It is implemented without the Prometheus client library, which is not part
+// of the vendored dependencies.
+type PrometheusRecorder struct {
+	buckets []float64
+
+	lock      sync.Mutex
+	inFlight  map[callLabels]int
+	calls     map[callCodeLabels]uint64
+	latencies map[callLabels]*histogram
+}
+
+// callLabels are the labels of the metrics of a call.
+type callLabels struct {
+	version   meta.Version
+	service   string
+	operation string
+}
+
+func (l callLabels) String() string {
+	return fmt.Sprintf("version=%q,service=%q,operation=%q", l.version, l.service, l.operation)
+}
+
+// callCodeLabels are the labels of the calls counter.
+type callCodeLabels struct {
+	callLabels
+	code int
+}
+
+// histogram is a Prometheus histogram. counts[i] is the number of
+// observations in the ith bucket (not cumulative).
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func labelsOf(m *CallMetrics) callLabels {
+	return callLabels{version: m.Version, service: m.Service, operation: m.Operation}
+}
+
+// RecordCallStart implements CallStartRecorder.
+func (r *PrometheusRecorder) RecordCallStart(ctx context.Context, m *CallMetrics) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.inFlight[labelsOf(m)]++
+}
+
+// RecordCall implements MetricsRecorder.
+func (r *PrometheusRecorder) RecordCall(ctx context.Context, m *CallMetrics) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	l := labelsOf(m)
+	if r.inFlight[l] > 0 {
+		r.inFlight[l]--
+	}
+	r.calls[callCodeLabels{l, m.Code}]++
+
+	h, ok := r.latencies[l]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.latencies[l] = h
+	}
+	seconds := m.Latency.Seconds()
+	for i, le := range r.buckets {
+		if seconds <= le {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (r *PrometheusRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	b := bufio.NewWriter(w)
+	r.write(b)
+	b.Flush()
+}
+
+// write writes the metrics sorted by labels, so that the output is stable.
+func (r *PrometheusRecorder) write(w *bufio.Writer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	fmt.Fprintln(w, "# HELP gce_api_calls_in_flight GCE API calls in progress.")
+	fmt.Fprintln(w, "# TYPE gce_api_calls_in_flight gauge")
+	for _, l := range sortedLabels(r.inFlight) {
+		fmt.Fprintf(w, "gce_api_calls_in_flight{%s} %d\n", l, r.inFlight[l])
+	}
+
+	fmt.Fprintln(w, "# HELP gce_api_calls_total GCE API calls by HTTP status code.")
+	fmt.Fprintln(w, "# TYPE gce_api_calls_total counter")
+	var codes []callCodeLabels
+	for l := range r.calls {
+		codes = append(codes, l)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].callLabels != codes[j].callLabels {
+			return codes[i].String() < codes[j].String()
+		}
+		return codes[i].code < codes[j].code
+	})
+	for _, l := range codes {
+		fmt.Fprintf(w, "gce_api_calls_total{%s,code=\"%d\"} %d\n", l.callLabels, l.code, r.calls[l])
+	}
+
+	fmt.Fprintln(w, "# HELP gce_api_call_duration_seconds Latency of the GCE API calls.")
+	fmt.Fprintln(w, "# TYPE gce_api_call_duration_seconds histogram")
+	for _, l := range sortedLabels(r.latencies) {
+		h := r.latencies[l]
+		var cumulative uint64
+		for i, le := range r.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "gce_api_call_duration_seconds_bucket{%s,le=%q} %d\n", l, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(w, "gce_api_call_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", l, h.count)
+		fmt.Fprintf(w, "gce_api_call_duration_seconds_sum{%s} %g\n", l, h.sum)
+		fmt.Fprintf(w, "gce_api_call_duration_seconds_count{%s} %d\n", l, h.count)
+	}
+}
+
+func sortedLabels[V any](m map[callLabels]V) []callLabels {
+	var ret []callLabels
+	for l := range m {
+		ret = append(ret, l)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].String() < ret[j].String() })
+	return ret
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestStatusCode(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{&googleapi.Error{Code: http.StatusNotFound}, http.StatusNotFound},
+		{errors.New("error"), 0},
+	} {
+		if got := statusCode(tc.err); got != tc.want {
+			t.Errorf("statusCode(%v) = %d; want %d", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestPrometheusRecorder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewPrometheusRecorder([]float64{0.1, 1})
+	get := &CallMetrics{Version: meta.VersionGA, Service: "Firewalls", Operation: "Get"}
+	insert := &CallMetrics{Version: meta.VersionGA, Service: "Firewalls", Operation: "Insert"}
+
+	r.RecordCallStart(ctx, get)
+	r.RecordCallStart(ctx, get)
+	r.RecordCallStart(ctx, get)
+	r.RecordCallStart(ctx, insert)
+	r.RecordCall(ctx, &CallMetrics{Version: meta.VersionGA, Service: "Firewalls", Operation: "Get", Latency: 50 * time.Millisecond, Code: http.StatusOK})
+	r.RecordCall(ctx, &CallMetrics{Version: meta.VersionGA, Service: "Firewalls", Operation: "Get", Latency: 500 * time.Millisecond, Code: http.StatusNotFound})
+	r.RecordCall(ctx, &CallMetrics{Version: meta.VersionGA, Service: "Firewalls", Operation: "Insert", Latency: 2 * time.Second, Code: http.StatusOK})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	got := w.Body.String()
+	for _, want := range []string{
+		`# TYPE gce_api_calls_in_flight gauge`,
+		`gce_api_calls_in_flight{version="ga",service="Firewalls",operation="Get"} 1`,
+		`gce_api_calls_in_flight{version="ga",service="Firewalls",operation="Insert"} 0`,
+		`# TYPE gce_api_calls_total counter`,
+		`gce_api_calls_total{version="ga",service="Firewalls",operation="Get",code="200"} 1`,
+		`gce_api_calls_total{version="ga",service="Firewalls",operation="Get",code="404"} 1`,
+		`gce_api_calls_total{version="ga",service="Firewalls",operation="Insert",code="200"} 1`,
+		`# TYPE gce_api_call_duration_seconds histogram`,
+		`gce_api_call_duration_seconds_bucket{version="ga",service="Firewalls",operation="Get",le="0.1"} 1`,
+		`gce_api_call_duration_seconds_bucket{version="ga",service="Firewalls",operation="Get",le="1"} 2`,
+		`gce_api_call_duration_seconds_bucket{version="ga",service="Firewalls",operation="Get",le="+Inf"} 2`,
+		`gce_api_call_duration_seconds_sum{version="ga",service="Firewalls",operation="Get"} 0.55`,
+		`gce_api_call_duration_seconds_count{version="ga",service="Firewalls",operation="Get"} 2`,
+		`gce_api_call_duration_seconds_bucket{version="ga",service="Firewalls",operation="Insert",le="1"} 0`,
+		`gce_api_call_duration_seconds_bucket{version="ga",service="Firewalls",operation="Insert",le="+Inf"} 1`,
+	} {
+		if !strings.Contains(got, want+"\n") {
+			t.Errorf("ServeHTTP() = %q; want a line %q", got, want)
+		}
+	}
+}
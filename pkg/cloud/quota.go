@@ -0,0 +1,141 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// quotaServices maps the quota metrics (e.g. "IN_USE_ADDRESSES") to the
+// services whose Insert counts against them. The metrics of the global
+// services are quotas of the project (see ProjectQuotas()) and the ones of
+// the regional and zonal services are quotas of the region (see
+// RegionQuotas()).
+var quotaServices = map[string][]string{
+	"BACKEND_SERVICES":          {"BackendServices", "RegionBackendServices"},
+	"CPUS":                      {"Instances"},
+	"DISKS_TOTAL_GB":            {"Disks", "RegionDisks"},
+	"FIREWALLS":                 {"Firewalls"},
+	"FORWARDING_RULES":          {"ForwardingRules", "GlobalForwardingRules"},
+	"GLOBAL_INTERNAL_ADDRESSES": {"GlobalAddresses"},
+	"HEALTH_CHECKS":             {"HealthChecks", "HttpHealthChecks", "HttpsHealthChecks"},
+	"IN_USE_ADDRESSES":          {"Addresses", "GlobalAddresses"},
+	"INSTANCE_GROUPS":           {"InstanceGroups"},
+	"INSTANCES":                 {"Instances"},
+	"NETWORK_ENDPOINT_GROUPS":   {"NetworkEndpointGroups"},
+	"ROUTES":                    {"Routes"},
+	"SSL_CERTIFICATES":          {"SslCertificates"},
+	"STATIC_ADDRESSES":          {"Addresses", "GlobalAddresses"},
+	"TARGET_HTTP_PROXIES":       {"TargetHttpProxies"},
+	"TARGET_HTTPS_PROXIES":      {"TargetHttpsProxies"},
+	"TARGET_POOLS":              {"TargetPools"},
+	"URL_MAPS":                  {"UrlMaps"},
+}
+
+// QuotaServices returns the services whose Insert counts against the quota
+// metric (e.g. "IN_USE_ADDRESSES" => Addresses, GlobalAddresses), or nil if
+// the metric is not known.
+func QuotaServices(metric string) []string {
+	return quotaServices[metric]
+}
+
+// ServiceQuotaMetrics returns the quota metrics that the Insert of service
+// (e.g. "Instances") counts against, sorted.
+func ServiceQuotaMetrics(service string) []string {
+	var ret []string
+	for metric, services := range quotaServices {
+		for _, s := range services {
+			if s == service {
+				ret = append(ret, metric)
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Quota is the limit and the usage of a quota metric of a project or a
+// region.
+type Quota struct {
+	Metric string
+	Limit  float64
+	Usage  float64
+}
+
+// Remaining is how much of the quota is left.
+func (q *Quota) Remaining() float64 {
+	return q.Limit - q.Usage
+}
+
+// Quotas are the quotas of a project or a region by metric.
+type Quotas map[string]*Quota
+
+// Check returns an error if less than n is left of one of the quotas that
+// the Insert of service counts against (see ServiceQuotaMetrics()), e.g. n =
+// 1 before inserting an Address. The error is classified by
+// IsQuotaExceeded(). The metrics that are not in q are not checked.
+func (q Quotas) Check(service string, n float64) error {
+	for _, metric := range ServiceQuotaMetrics(service) {
+		quota, ok := q[metric]
+		if !ok || quota.Remaining() >= n {
+			continue
+		}
+		return &googleapi.Error{
+			Code:    http.StatusForbidden,
+			Message: fmt.Sprintf("%s: %v of quota %s left, %v needed", service, quota.Remaining(), metric, n),
+			Errors:  []googleapi.ErrorItem{{Reason: "quotaExceeded", Message: metric}},
+		}
+	}
+	return nil
+}
+
+// ProjectQuotas returns the quotas of projectID, as reported by the Get of
+// the project. These are the quotas of the global resources (e.g.
+// "FIREWALLS").
+func ProjectQuotas(ctx context.Context, projects Projects, projectID string) (Quotas, error) {
+	p, err := projects.Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	ret := Quotas{}
+	for _, q := range p.Quotas {
+		ret[q.Metric] = &Quota{Metric: q.Metric, Limit: q.Limit, Usage: q.Usage}
+	}
+	return ret, nil
+}
+
+// RegionQuotas returns the quotas of region in the project of the call, as
+// reported by the Get of the region. These are the quotas of the regional
+// and zonal resources (e.g. "IN_USE_ADDRESSES", "INSTANCES").
+func RegionQuotas(ctx context.Context, regions Regions, region string) (Quotas, error) {
+	r, err := regions.Get(ctx, *meta.GlobalKey(region))
+	if err != nil {
+		return nil, err
+	}
+	ret := Quotas{}
+	for _, q := range r.Quotas {
+		ret[q.Metric] = &Quota{Metric: q.Metric, Limit: q.Limit, Usage: q.Usage}
+	}
+	return ret, nil
+}
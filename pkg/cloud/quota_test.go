@@ -0,0 +1,111 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestQuotaServices(t *testing.T) {
+	t.Parallel()
+
+	// The services of the metrics are generated, at some version.
+	for metric, services := range quotaServices {
+		for _, s := range services {
+			found := false
+			for _, v := range []meta.Version{meta.VersionGA, meta.VersionBeta, meta.VersionAlpha} {
+				_, ok := LookupResource(s, v)
+				found = found || ok
+			}
+			if !found {
+				t.Errorf("quota %s: service %q is not in the registry", metric, s)
+			}
+		}
+	}
+	if got, want := QuotaServices("IN_USE_ADDRESSES"), []string{"Addresses", "GlobalAddresses"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("QuotaServices(%q) = %v; want %v", "IN_USE_ADDRESSES", got, want)
+	}
+	if got := QuotaServices("UNKNOWN"); got != nil {
+		t.Errorf("QuotaServices(%q) = %v; want nil", "UNKNOWN", got)
+	}
+	if got, want := ServiceQuotaMetrics("Instances"), []string{"CPUS", "INSTANCES"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ServiceQuotaMetrics(%q) = %v; want %v", "Instances", got, want)
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gce := NewGCE(newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compute/v1/projects/proj":
+			writeJSON(t, w, &ga.Project{Name: "proj", Quotas: []*ga.Quota{
+				{Metric: "FIREWALLS", Limit: 100, Usage: 100},
+				{Metric: "URL_MAPS", Limit: 10, Usage: 2},
+			}})
+		case "/compute/v1/projects/proj/regions/us-central1":
+			writeJSON(t, w, &ga.Region{Name: "us-central1", Quotas: []*ga.Quota{
+				{Metric: "IN_USE_ADDRESSES", Limit: 8, Usage: 7},
+			}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+
+	pq, err := ProjectQuotas(ctx, gce.Projects(), "proj")
+	if err != nil {
+		t.Fatalf("ProjectQuotas() = _, %v; want nil", err)
+	}
+	if q := pq["URL_MAPS"]; q == nil || q.Remaining() != 8 {
+		t.Errorf("ProjectQuotas()[URL_MAPS] = %+v; want 8 remaining", q)
+	}
+	rq, err := RegionQuotas(ctx, gce.Regions(), "us-central1")
+	if err != nil {
+		t.Fatalf("RegionQuotas() = _, %v; want nil", err)
+	}
+	if _, err := ProjectQuotas(ctx, gce.Projects(), "missing"); !IsNotFound(err) {
+		t.Errorf("ProjectQuotas(missing) = _, %v; want not found", err)
+	}
+
+	for _, tc := range []struct {
+		quotas  Quotas
+		service string
+		n       float64
+		want    bool
+	}{
+		{pq, "UrlMaps", 1, false},
+		{pq, "UrlMaps", 9, true},
+		{pq, "Firewalls", 1, true},
+		// The metrics that are not reported are not checked.
+		{pq, "Routes", 1, false},
+		{rq, "Addresses", 1, false},
+		{rq, "Addresses", 2, true},
+	} {
+		err := tc.quotas.Check(tc.service, tc.n)
+		if got := IsQuotaExceeded(err); got != tc.want {
+			t.Errorf("Check(%q, %v) = %v; want quota exceeded %t", tc.service, tc.n, err, tc.want)
+		}
+	}
+}
@@ -18,8 +18,12 @@ package cloud
 
 import (
 	"context"
+	"math"
+	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/bowei/gce-gen/pkg/cloud/meta"
 )
 
@@ -47,17 +51,59 @@ type RateLimiter interface {
 	Accept(ctx context.Context, key *RateLimitKey) error
 }
 
+// RateLimitObserver is implemented by the RateLimiters that adapt to the
+// outcome of the calls they accepted (see AdaptiveRateLimiter).
+type RateLimitObserver interface {
+	// Observe is called with the error returned by each call accepted for
+	// key, nil if the call succeeded.
+	Observe(ctx context.Context, key *RateLimitKey, err error)
+}
+
+// RateLimitReserver is implemented by the RateLimiters that can take the token
+// of a call without blocking (see ObserveOnlyRateLimiter).
+type RateLimitReserver interface {
+	// Reserve takes the token of a call for key and returns 0 if the call
+	// would be accepted at once. Otherwise, it returns how long Accept would
+	// block the call, without taking a token.
+	Reserve(key *RateLimitKey) time.Duration
+}
+
+// observeRateLimit passes the outcome err of the call for rk to the
+// RateLimiter if it is a RateLimitObserver.
+func (g *Service) observeRateLimit(ctx context.Context, rk *RateLimitKey, err error) {
+	if o, ok := g.RateLimiter.(RateLimitObserver); ok {
+		o.Observe(ctx, rk, err)
+	}
+}
+
+// RateLimitHint returns the rate limit hint for the calls identified by key:
+// the hint of the operation of the service in the registry (see
+// Resource.RateLimit()), meta.OperationsRateLimit for polling operations and
+// the meta.DefaultRateLimits otherwise.
+func RateLimitHint(key *RateLimitKey) meta.RateLimit {
+	if key.Service == "Operations" {
+		return meta.OperationsRateLimit
+	}
+	if r, ok := LookupResource(key.Service, key.Version); ok {
+		return r.RateLimit(key.Operation)
+	}
+	return meta.LookupRateLimit(nil, key.Operation)
+}
+
 // NopRateLimiter is a rate limiter that performs no rate limiting.
 type NopRateLimiter struct {
 }
 
+// nopPollTime is the delay of NopRateLimiter before polling the status of an
+// Operation.
+const nopPollTime = time.Duration(1) * time.Second
+
 func (*NopRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
 	// Rate limit polling of the Operation status to avoid hammering GCE
 	// for the status of an operation.
-	const pollTime = time.Duration(1) * time.Second
 	if key.Operation == "Get" && key.Service == "Operations" {
 		select {
-		case <-time.NewTimer(pollTime).C:
+		case <-time.NewTimer(nopPollTime).C:
 			break
 		case <-ctx.Done():
 			return ctx.Err()
@@ -65,3 +111,576 @@ func (*NopRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
 	}
 	return nil
 }
+
+// Reserve returns the delay of the polling of an Operation.
+func (*NopRateLimiter) Reserve(key *RateLimitKey) time.Duration {
+	if key.Operation == "Get" && key.Service == "Operations" {
+		return nopPollTime
+	}
+	return 0
+}
+
+// RateLimitObservation records the delays that a rate limiting policy would
+// have imposed on the calls for a given RateLimitKey.
+type RateLimitObservation struct {
+	// Calls is the number of calls observed.
+	Calls int
+	// TotalDelay is the sum of all of the delays.
+	TotalDelay time.Duration
+	// MaxDelay is the longest single delay.
+	MaxDelay time.Duration
+}
+
+// NewObserveOnlyRateLimiter returns a rate limiter that observes policy
+// without enforcing it.
+func NewObserveOnlyRateLimiter(policy RateLimiter) *ObserveOnlyRateLimiter {
+	return &ObserveOnlyRateLimiter{
+		Policy:       policy,
+		observations: map[RateLimitKey]*RateLimitObservation{},
+	}
+}
+
+// ObserveOnlyRateLimiter never blocks the caller. Instead, the token of each
+// call is reserved from Policy, and the time Policy would have blocked the
+// call is recorded per RateLimitKey. This can be used to tune a policy
+// against real traffic before enforcing it.
+//
+// A call that would have been blocked does not take a token, as it is not
+// delayed: the delays recorded are the time to the next token of the key,
+// not the time to the end of a queue of calls that were not made. Policy
+// must be a RateLimitReserver, as are the RateLimiters of this package; the
+// calls of another Policy are counted without a delay.
+type ObserveOnlyRateLimiter struct {
+	Policy RateLimiter
+
+	lock         sync.Mutex
+	observations map[RateLimitKey]*RateLimitObservation
+}
+
+// Accept always returns immediately.
+func (l *ObserveOnlyRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	var delay time.Duration
+	if r, ok := l.Policy.(RateLimitReserver); ok {
+		delay = r.Reserve(key)
+	}
+	l.observe(*key, delay)
+	return nil
+}
+
+// Observe passes the outcome of the call to Policy if it is a
+// RateLimitObserver, so that an adaptive Policy sees the real traffic.
+func (l *ObserveOnlyRateLimiter) Observe(ctx context.Context, key *RateLimitKey, err error) {
+	if o, ok := l.Policy.(RateLimitObserver); ok {
+		o.Observe(ctx, key, err)
+	}
+}
+
+func (l *ObserveOnlyRateLimiter) observe(key RateLimitKey, delay time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	o, ok := l.observations[key]
+	if !ok {
+		o = &RateLimitObservation{}
+		l.observations[key] = o
+	}
+	o.Calls++
+	o.TotalDelay += delay
+	if delay > o.MaxDelay {
+		o.MaxDelay = delay
+	}
+}
+
+// Observations returns a snapshot of the delays observed so far.
+func (l *ObserveOnlyRateLimiter) Observations() map[RateLimitKey]RateLimitObservation {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	ret := map[RateLimitKey]RateLimitObservation{}
+	for k, o := range l.observations {
+		ret[k] = *o
+	}
+	return ret
+}
+
+// Reset clears all observations.
+func (l *ObserveOnlyRateLimiter) Reset() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.observations = map[RateLimitKey]*RateLimitObservation{}
+}
+
+// NewDefaultRateLimiter returns a DefaultRateLimiter using RateLimitHint.
+func NewDefaultRateLimiter() *DefaultRateLimiter {
+	return &DefaultRateLimiter{
+		Hint:    RateLimitHint,
+		buckets: map[RateLimitKey]*rate.Limiter{},
+	}
+}
+
+// DefaultRateLimiter throttles the calls of each RateLimitKey with a token
+// bucket sized by the rate limit hint of the key. The hints are declared in
+// package meta, so the calls are throttled without tuning each RateLimitKey.
+type DefaultRateLimiter struct {
+	// Hint returns the rate limit for the calls of a key. It is called once
+	// per key.
+	Hint func(key *RateLimitKey) meta.RateLimit
+
+	lock    sync.Mutex
+	buckets map[RateLimitKey]*rate.Limiter
+}
+
+// Accept blocks until the call is allowed by the rate limit of key.
+func (l *DefaultRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	l.lock.Lock()
+	b, ok := l.buckets[*key]
+	if !ok {
+		b = newLimiter(l.Hint(key))
+		l.buckets[*key] = b
+	}
+	l.lock.Unlock()
+
+	// Unlike b.Wait(), this returns the error of ctx if it is done first.
+	now := time.Now()
+	r := b.ReserveN(now, 1)
+	return waitForToken(ctx, r.DelayFrom(now), r.Cancel)
+}
+
+// Reserve takes the token of a call for key if one is available.
+func (l *DefaultRateLimiter) Reserve(key *RateLimitKey) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b, ok := l.buckets[*key]
+	if !ok {
+		b = newLimiter(l.Hint(key))
+		l.buckets[*key] = b
+	}
+	return reserve(b, time.Now(), 0)
+}
+
+// waitForToken waits for the token reserved in a bucket, calling giveBack if
+// ctx is canceled first.
+func waitForToken(ctx context.Context, wait time.Duration, giveBack func()) error {
+	if err := sleep(ctx, wait); err != nil {
+		giveBack()
+		return err
+	}
+	return nil
+}
+
+// RateLimitSelector selects the calls that a configured rate limit applies
+// to (see NewConfiguredRateLimiter). An empty field matches any value.
+type RateLimitSelector struct {
+	Version   meta.Version
+	Service   string
+	Operation string
+}
+
+// NewConfiguredRateLimiter returns a DefaultRateLimiter with the QPS and burst
+// of each call given by limits: the limit of the most specific selector that
+// matches the call, where the service is more specific than the operation
+// and the operation more specific than the version. The selector with all of
+// the fields empty is the default bucket. The calls that match no selector
+// use RateLimitHint(). A QPS of 0 does not throttle the matching calls.
+//
+//	rl := cloud.NewConfiguredRateLimiter(map[cloud.RateLimitSelector]meta.RateLimit{
+//		{}: {QPS: 10, Burst: 20},
+//		{Service: "Instances", Operation: "Get"}: {QPS: 50, Burst: 100},
+//	})
+//
+// Each project, version, service and operation has its own bucket, as for
+// NewDefaultRateLimiter().
+func NewConfiguredRateLimiter(limits map[RateLimitSelector]meta.RateLimit) *DefaultRateLimiter {
+	l := NewDefaultRateLimiter()
+	l.Hint = func(key *RateLimitKey) meta.RateLimit {
+		for _, sel := range rateLimitSelectors(key) {
+			if rl, ok := limits[sel]; ok {
+				return rl
+			}
+		}
+		return RateLimitHint(key)
+	}
+	return l
+}
+
+// rateLimitSelectors returns the selectors that match key, most specific
+// first.
+func rateLimitSelectors(key *RateLimitKey) []RateLimitSelector {
+	v, s, op := key.Version, key.Service, key.Operation
+	return []RateLimitSelector{
+		{v, s, op},
+		{"", s, op},
+		{v, s, ""},
+		{"", s, ""},
+		{v, "", op},
+		{"", "", op},
+		{v, "", ""},
+		{"", "", ""},
+	}
+}
+
+// NewAdaptiveRateLimiter returns an AdaptiveRateLimiter starting from the
+// rate limits given by RateLimitHint.
+func NewAdaptiveRateLimiter() *AdaptiveRateLimiter {
+	return &AdaptiveRateLimiter{
+		Hint:             RateLimitHint,
+		Backoff:          0.5,
+		MinFactor:        1.0 / 64,
+		RecoveryInterval: 10 * time.Second,
+		buckets:          map[RateLimitKey]*adaptiveBucket{},
+	}
+}
+
+// AdaptiveRateLimiter throttles the calls of each RateLimitKey with a token
+// bucket like DefaultRateLimiter, and adapts the QPS of the bucket to the
+// quota errors returned by the calls (see IsQuotaExceeded()): each error
+// multiplies the QPS by Backoff, down to MinFactor of the hint, and the QPS
+// is doubled after each RecoveryInterval without errors, up to the hint. This
+// settles the calls of each project under its actual quota without tuning
+// the limits per project.
+//
+// The errors are observed through the RateLimitObserver interface, which the
+// generated code calls after each call.
+type AdaptiveRateLimiter struct {
+	// Hint returns the rate limit for the calls of a key, before any
+	// backoff. It is called once per key.
+	Hint func(key *RateLimitKey) meta.RateLimit
+	// Backoff is the factor applied to the QPS of a key on each quota
+	// error, between 0 and 1.
+	Backoff float64
+	// MinFactor is the lowest fraction of the hinted QPS that a key is
+	// backed off to. It must be greater than 0.
+	MinFactor float64
+	// RecoveryInterval is the time without quota errors after which the QPS
+	// of a key that was backed off is doubled.
+	RecoveryInterval time.Duration
+	// QuotaBackoff, if set, also holds the calls of a key after a quota
+	// error for QuotaBackoff.Delay(n), n being the number of consecutive
+	// quota errors of the key, until a call succeeds.
+	QuotaBackoff *Backoff
+
+	lock    sync.Mutex
+	buckets map[RateLimitKey]*adaptiveBucket
+}
+
+// adaptiveBucket is the token bucket of a key, with a QPS of factor times
+// the QPS of the hint.
+type adaptiveBucket struct {
+	*rate.Limiter
+	hint   meta.RateLimit
+	factor float64
+	// changed is when factor was last changed.
+	changed time.Time
+	// quotaErrors is the number of consecutive quota errors and held is
+	// when the calls are let through again (see QuotaBackoff).
+	quotaErrors int
+	held        time.Time
+}
+
+// Accept blocks until the call is allowed by the current rate limit of key.
+func (l *AdaptiveRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	l.lock.Lock()
+	now := time.Now()
+	b := l.bucket(key, now)
+	r := b.ReserveN(now, 1)
+	wait := r.DelayFrom(now)
+	if held := b.held.Sub(now); held > wait {
+		wait = held
+	}
+	l.lock.Unlock()
+
+	return waitForToken(ctx, wait, r.Cancel)
+}
+
+// Reserve takes the token of a call for key if one is available and the
+// calls of key are not held.
+func (l *AdaptiveRateLimiter) Reserve(key *RateLimitKey) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	b := l.bucket(key, now)
+	return reserve(b.Limiter, now, b.held.Sub(now))
+}
+
+// Observe backs off the rate limit of key if err is a quota error.
+func (l *AdaptiveRateLimiter) Observe(ctx context.Context, key *RateLimitKey, err error) {
+	quota := IsQuotaExceeded(err)
+	if !quota && (err != nil || l.QuotaBackoff == nil) {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !quota {
+		l.bucket(key, time.Now()).quotaErrors = 0
+		return
+	}
+	l.backoff(key, time.Now())
+}
+
+// QPS returns the current QPS of the calls of key.
+func (l *AdaptiveRateLimiter) QPS(key *RateLimitKey) float64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b := l.bucket(key, time.Now())
+	return b.hint.QPS * b.factor
+}
+
+// bucket returns the bucket of key, recovering its QPS as of now. l.lock
+// must be held.
+func (l *AdaptiveRateLimiter) bucket(key *RateLimitKey, now time.Time) *adaptiveBucket {
+	b, ok := l.buckets[*key]
+	if !ok {
+		hint := l.Hint(key)
+		b = &adaptiveBucket{Limiter: newLimiter(hint), hint: hint, factor: 1, changed: now}
+		l.buckets[*key] = b
+	}
+	if l.RecoveryInterval > 0 {
+		for b.factor < 1 && now.Sub(b.changed) >= l.RecoveryInterval {
+			b.setFactor(b.factor*2, b.changed.Add(l.RecoveryInterval))
+		}
+	}
+	return b
+}
+
+// backoff reduces the QPS of key after a quota error at now. l.lock must be
+// held.
+func (l *AdaptiveRateLimiter) backoff(key *RateLimitKey, now time.Time) {
+	b := l.bucket(key, now)
+	b.setFactor(math.Max(b.factor*l.Backoff, l.MinFactor), now)
+	if l.QuotaBackoff != nil {
+		b.quotaErrors++
+		b.held = now.Add(l.QuotaBackoff.Delay(b.quotaErrors))
+	}
+	// Do not spend the burst on calls that are likely to fail as well.
+	if n := int(b.TokensAt(now)); n > 0 {
+		b.ReserveN(now, n)
+	}
+}
+
+func (b *adaptiveBucket) setFactor(factor float64, now time.Time) {
+	b.factor = math.Min(factor, 1)
+	if b.hint.QPS > 0 {
+		b.SetLimitAt(now, rate.Limit(b.hint.QPS*b.factor))
+	}
+	b.changed = now
+}
+
+// NewCompositeRateLimiter returns a CompositeRateLimiter applying limiters in
+// order.
+func NewCompositeRateLimiter(limiters ...RateLimiter) *CompositeRateLimiter {
+	return &CompositeRateLimiter{Limiters: limiters}
+}
+
+// CompositeRateLimiter accepts a call once each of Limiters has accepted it,
+// so that a policy is composed of simpler ones, e.g. a global QPS, a QPS per
+// project and the rate limit hint of each operation:
+//
+//	rl := cloud.NewCompositeRateLimiter(
+//		cloud.NewScopedRateLimiter(cloud.GlobalScope, global),
+//		cloud.NewScopedRateLimiter(cloud.ProjectScope, perProject),
+//		cloud.NewDefaultRateLimiter(),
+//	)
+//
+// The tokens taken from the Limiters that accepted the call are not given
+// back if a later one fails.
+type CompositeRateLimiter struct {
+	Limiters []RateLimiter
+}
+
+// Accept blocks until each of the Limiters accepted the call.
+func (l *CompositeRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	for _, rl := range l.Limiters {
+		if err := rl.Accept(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reserve returns the longest delay of the Limiters that are
+// RateLimitReservers. As for Accept, the tokens taken from the others are
+// not given back.
+func (l *CompositeRateLimiter) Reserve(key *RateLimitKey) time.Duration {
+	var delay time.Duration
+	for _, rl := range l.Limiters {
+		if r, ok := rl.(RateLimitReserver); ok {
+			if d := r.Reserve(key); d > delay {
+				delay = d
+			}
+		}
+	}
+	return delay
+}
+
+// Observe passes the outcome of the call to each of the Limiters that is a
+// RateLimitObserver.
+func (l *CompositeRateLimiter) Observe(ctx context.Context, key *RateLimitKey, err error) {
+	for _, rl := range l.Limiters {
+		if o, ok := rl.(RateLimitObserver); ok {
+			o.Observe(ctx, key, err)
+		}
+	}
+}
+
+// RateLimitScope maps the key of a call to the key that it shares its rate
+// limit with (see NewScopedRateLimiter).
+type RateLimitScope func(key *RateLimitKey) RateLimitKey
+
+// GlobalScope rate limits all of the calls together.
+func GlobalScope(key *RateLimitKey) RateLimitKey {
+	return RateLimitKey{}
+}
+
+// ProjectScope rate limits the calls of each project together.
+func ProjectScope(key *RateLimitKey) RateLimitKey {
+	return RateLimitKey{ProjectID: key.ProjectID}
+}
+
+// ServiceScope rate limits the calls of each project and service together,
+// whatever the version and the operation.
+func ServiceScope(key *RateLimitKey) RateLimitKey {
+	return RateLimitKey{ProjectID: key.ProjectID, Service: key.Service}
+}
+
+// OperationScope rate limits the calls of each version, service and
+// operation together, across the projects.
+func OperationScope(key *RateLimitKey) RateLimitKey {
+	return RateLimitKey{Version: key.Version, Service: key.Service, Operation: key.Operation}
+}
+
+// NewScopedRateLimiter returns a ScopedRateLimiter applying l to the keys
+// mapped by scope.
+func NewScopedRateLimiter(scope RateLimitScope, l RateLimiter) *ScopedRateLimiter {
+	return &ScopedRateLimiter{Scope: scope, Limiter: l}
+}
+
+// ScopedRateLimiter applies Limiter to the key returned by Scope instead of
+// the key of the call. As the limiters keep a bucket per key, the calls that
+// map to the same key share a bucket: for instance, a DefaultRateLimiter
+// scoped by ProjectScope throttles all of the calls of a project together.
+// The Hint of the limiter is called with the scoped key, so it is configured
+// with the selector of the scoped fields, e.g.
+//
+//	perProject := cloud.NewConfiguredRateLimiter(map[cloud.RateLimitSelector]meta.RateLimit{
+//		{}: {QPS: 20, Burst: 40},
+//	})
+type ScopedRateLimiter struct {
+	Scope   RateLimitScope
+	Limiter RateLimiter
+}
+
+// Accept blocks until Limiter accepted the scoped key of the call.
+func (l *ScopedRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	k := l.Scope(key)
+	return l.Limiter.Accept(ctx, &k)
+}
+
+// Reserve reserves the token of the scoped key of the call from Limiter if it
+// is a RateLimitReserver.
+func (l *ScopedRateLimiter) Reserve(key *RateLimitKey) time.Duration {
+	if r, ok := l.Limiter.(RateLimitReserver); ok {
+		k := l.Scope(key)
+		return r.Reserve(&k)
+	}
+	return 0
+}
+
+// Observe passes the outcome of the call for the scoped key to Limiter if it
+// is a RateLimitObserver.
+func (l *ScopedRateLimiter) Observe(ctx context.Context, key *RateLimitKey, err error) {
+	if o, ok := l.Limiter.(RateLimitObserver); ok {
+		k := l.Scope(key)
+		o.Observe(ctx, &k, err)
+	}
+}
+
+// NewMinimumRateLimiter returns a MinimumRateLimiter spacing the calls of a
+// key by interval.
+func NewMinimumRateLimiter(interval time.Duration) *MinimumRateLimiter {
+	return &MinimumRateLimiter{
+		Interval: interval,
+		next:     map[RateLimitKey]time.Time{},
+	}
+}
+
+// MinimumRateLimiter enforces a floor of Interval between the calls of the
+// same RateLimitKey, without a burst. It is typically composed with the
+// other limiters (see CompositeRateLimiter) to keep an operation such as the
+// polling of an Operation from being repeated in a tight loop.
+type MinimumRateLimiter struct {
+	Interval time.Duration
+
+	lock sync.Mutex
+	// next is when the next call of each key may start.
+	next map[RateLimitKey]time.Time
+}
+
+// Accept blocks until Interval has passed since the previous call of key.
+func (l *MinimumRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	l.lock.Lock()
+	now := time.Now()
+	start := l.next[*key]
+	if start.Before(now) {
+		start = now
+	}
+	next := start.Add(l.Interval)
+	l.next[*key] = next
+	l.lock.Unlock()
+
+	return waitForToken(ctx, start.Sub(now), func() {
+		l.lock.Lock()
+		// Give the slot back unless a later call was scheduled after it.
+		if l.next[*key].Equal(next) {
+			l.next[*key] = start
+		}
+		l.lock.Unlock()
+	})
+}
+
+// Reserve takes the slot of a call for key if Interval has passed since the
+// previous call of key.
+func (l *MinimumRateLimiter) Reserve(key *RateLimitKey) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if start := l.next[*key]; start.After(now) {
+		return start.Sub(now)
+	}
+	l.next[*key] = now.Add(l.Interval)
+	return 0
+}
+
+// newLimiter returns the token bucket of the rate limit rl. A QPS of 0 does
+// not throttle the calls.
+func newLimiter(rl meta.RateLimit) *rate.Limiter {
+	if rl.QPS <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	// Each call takes a token, which a bucket without a burst never has.
+	burst := rl.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(rl.QPS), burst)
+}
+
+// reserve takes a token from b at now and returns 0 if it is available and
+// hold is not positive. Otherwise, it returns how long the call would wait
+// for it, without taking it.
+func reserve(b *rate.Limiter, now time.Time, hold time.Duration) time.Duration {
+	r := b.ReserveN(now, 1)
+	delay := r.DelayFrom(now)
+	if hold > delay {
+		delay = hold
+	}
+	if delay > 0 {
+		r.CancelAt(now)
+	}
+	return delay
+}
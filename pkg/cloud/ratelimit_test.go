@@ -0,0 +1,494 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// sleepRateLimiter delays every call by the configured amount.
+type sleepRateLimiter struct {
+	delay time.Duration
+}
+
+func (l *sleepRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	time.Sleep(l.delay)
+	return nil
+}
+
+func TestObserveOnlyRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := NewDefaultRateLimiter()
+	policy.Hint = func(*RateLimitKey) meta.RateLimit { return meta.RateLimit{QPS: 10, Burst: 1} }
+	rl := NewObserveOnlyRateLimiter(policy)
+	getKey := RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	listKey := RateLimitKey{ProjectID: "proj", Operation: "List", Version: meta.VersionGA, Service: "Firewalls"}
+
+	start := time.Now()
+	for _, k := range []RateLimitKey{getKey, getKey, listKey} {
+		if err := rl.Accept(ctx, &k); err != nil {
+			t.Errorf("rl.Accept(%+v) = %v; want nil", k, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Errorf("Accept() blocked for %v, want no delay", elapsed)
+	}
+
+	obs := rl.Observations()
+	if len(obs) != 2 {
+		t.Fatalf("len(rl.Observations()) = %d, want 2", len(obs))
+	}
+	if o := obs[getKey]; o.Calls != 2 || o.MaxDelay < 50*time.Millisecond || o.MaxDelay > 100*time.Millisecond {
+		t.Errorf("rl.Observations()[%+v] = %+v; want 2 calls with a delay of about 100ms", getKey, o)
+	}
+	if o := obs[listKey]; o.Calls != 1 || o.MaxDelay != 0 {
+		t.Errorf("rl.Observations()[%+v] = %+v; want 1 call without delay", listKey, o)
+	}
+
+	// The calls that would have been blocked do not queue up behind each
+	// other.
+	for i := 0; i < 100; i++ {
+		rl.Accept(ctx, &getKey)
+	}
+	if o := rl.Observations()[getKey]; o.Calls != 102 || o.MaxDelay > 100*time.Millisecond {
+		t.Errorf("rl.Observations()[%+v] = %+v; want 102 calls with a delay of at most 100ms", getKey, o)
+	}
+
+	rl.Reset()
+	if obs := rl.Observations(); len(obs) != 0 {
+		t.Errorf("rl.Observations() = %+v after Reset(), want empty", obs)
+	}
+
+	// The calls of a Policy that is not a RateLimitReserver are counted.
+	rl = NewObserveOnlyRateLimiter(&sleepRateLimiter{time.Hour})
+	rl.Accept(ctx, &getKey)
+	if o := rl.Observations()[getKey]; o.Calls != 1 || o.MaxDelay != 0 {
+		t.Errorf("rl.Observations()[%+v] = %+v; want 1 call without delay", getKey, o)
+	}
+}
+
+func TestRateLimitReserver(t *testing.T) {
+	t.Parallel()
+
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	hint := func(*RateLimitKey) meta.RateLimit { return meta.RateLimit{QPS: 10, Burst: 1} }
+	dl := NewDefaultRateLimiter()
+	dl.Hint = hint
+	al := NewAdaptiveRateLimiter()
+	al.Hint = hint
+	for _, tc := range []struct {
+		name string
+		rl   RateLimitReserver
+	}{
+		{"DefaultRateLimiter", dl},
+		{"AdaptiveRateLimiter", al},
+		{"MinimumRateLimiter", NewMinimumRateLimiter(100 * time.Millisecond)},
+		{"ScopedRateLimiter", NewScopedRateLimiter(ProjectScope, NewMinimumRateLimiter(100*time.Millisecond))},
+		{"CompositeRateLimiter", NewCompositeRateLimiter(&NopRateLimiter{}, NewMinimumRateLimiter(100*time.Millisecond))},
+	} {
+		if d := tc.rl.Reserve(key); d != 0 {
+			t.Errorf("%s: Reserve() = %v; want 0", tc.name, d)
+		}
+		// The next token is not taken by the calls that would wait for it.
+		for i := 0; i < 3; i++ {
+			if d := tc.rl.Reserve(key); d < 50*time.Millisecond || d > 100*time.Millisecond {
+				t.Errorf("%s: Reserve() #%d = %v; want about 100ms", tc.name, i, d)
+			}
+		}
+	}
+}
+
+func TestRateLimitHint(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key  RateLimitKey
+		want meta.RateLimit
+	}{
+		// Declared by the service.
+		{RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Instances"}, meta.RateLimit{QPS: 50, Burst: 100}},
+		{RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}, meta.DefaultRateLimits["Get"]},
+		{RateLimitKey{Operation: "Insert", Version: meta.VersionGA, Service: "Firewalls"}, meta.DefaultRateLimits[meta.AnyOperation]},
+		{RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Operations"}, meta.OperationsRateLimit},
+		// Not in the registry.
+		{RateLimitKey{Operation: "List", Version: meta.VersionGA, Service: "Unknown"}, meta.DefaultRateLimits["List"]},
+	} {
+		if got := RateLimitHint(&tc.key); got != tc.want {
+			t.Errorf("RateLimitHint(%+v) = %+v; want %+v", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestNewLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	b := newLimiter(meta.RateLimit{QPS: 10, Burst: 2})
+	for i, tc := range []struct {
+		after time.Duration
+		want  time.Duration
+	}{
+		// The burst is available at once.
+		{0, 0},
+		{0, 0},
+		// Then a token every 100ms.
+		{0, 100 * time.Millisecond},
+		{0, 200 * time.Millisecond},
+		// Tokens accumulate up to the burst.
+		{time.Second, 0},
+		{0, 0},
+		{0, 100 * time.Millisecond},
+	} {
+		now = now.Add(tc.after)
+		if got := b.ReserveN(now, 1).DelayFrom(now); got.Round(time.Millisecond) != tc.want {
+			t.Errorf("ReserveN() #%d delay = %v; want %v", i, got, tc.want)
+		}
+	}
+
+	// A QPS of 0 does not throttle, and a burst of 0 lets a call through.
+	for _, rl := range []meta.RateLimit{{QPS: 0, Burst: 0}, {QPS: 10, Burst: 0}} {
+		if r := newLimiter(rl).ReserveN(now, 1); !r.OK() || r.DelayFrom(now) != 0 {
+			t.Errorf("newLimiter(%+v).ReserveN() = %v, %v; want true, 0", rl, r.OK(), r.DelayFrom(now))
+		}
+	}
+}
+
+func TestDefaultRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rl := NewDefaultRateLimiter()
+	rl.Hint = func(*RateLimitKey) meta.RateLimit { return meta.RateLimit{QPS: 20, Burst: 1} }
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	other := &RateLimitKey{ProjectID: "proj", Operation: "List", Version: meta.VersionGA, Service: "Firewalls"}
+
+	start := time.Now()
+	for _, k := range []*RateLimitKey{key, other} {
+		if err := rl.Accept(ctx, k); err != nil {
+			t.Errorf("rl.Accept(%+v) = %v; want nil", k, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Errorf("Accept() of the burst blocked for %v, want no delay", elapsed)
+	}
+	if err := rl.Accept(ctx, key); err != nil {
+		t.Errorf("rl.Accept(%+v) = %v; want nil", key, err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Accept() over the limit blocked for %v, want >= 40ms", elapsed)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	rl.Accept(ctx, other)
+	if err := rl.Accept(cctx, other); err != context.Canceled {
+		t.Errorf("rl.Accept(<canceled>, %+v) = %v; want %v", other, err, context.Canceled)
+	}
+}
+
+func TestConfiguredRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	rl := NewConfiguredRateLimiter(map[RateLimitSelector]meta.RateLimit{
+		{}:                                       {QPS: 1, Burst: 1},
+		{Operation: "List"}:                      {QPS: 2, Burst: 2},
+		{Version: meta.VersionAlpha}:             {QPS: 3, Burst: 3},
+		{Service: "Instances"}:                   {QPS: 4, Burst: 4},
+		{Service: "Instances", Operation: "Get"}: {QPS: 5, Burst: 5},
+		{meta.VersionAlpha, "Instances", "Get"}:  {QPS: 6, Burst: 6},
+		{Version: meta.VersionBeta, Operation: "AggregatedList"}: {QPS: 7, Burst: 7},
+	})
+	for _, tc := range []struct {
+		key  RateLimitKey
+		want float64
+	}{
+		{RateLimitKey{Operation: "Get", Version: meta.VersionAlpha, Service: "Instances"}, 6},
+		{RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Instances"}, 5},
+		// The service is more specific than the operation and the version.
+		{RateLimitKey{Operation: "List", Version: meta.VersionAlpha, Service: "Instances"}, 4},
+		{RateLimitKey{Operation: "List", Version: meta.VersionAlpha, Service: "Firewalls"}, 2},
+		{RateLimitKey{Operation: "Get", Version: meta.VersionAlpha, Service: "Firewalls"}, 3},
+		{RateLimitKey{Operation: "AggregatedList", Version: meta.VersionBeta, Service: "Addresses"}, 7},
+		{RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}, 1},
+	} {
+		if got := rl.Hint(&tc.key); got.QPS != tc.want {
+			t.Errorf("Hint(%+v) = %+v; want QPS %v", tc.key, got, tc.want)
+		}
+	}
+
+	// Without a default bucket, the calls use RateLimitHint().
+	rl = NewConfiguredRateLimiter(map[RateLimitSelector]meta.RateLimit{{Service: "Firewalls"}: {QPS: 1, Burst: 1}})
+	key := &RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Instances"}
+	if got, want := rl.Hint(key), RateLimitHint(key); got != want {
+		t.Errorf("Hint(%+v) = %+v; want %+v", key, got, want)
+	}
+}
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	l := NewAdaptiveRateLimiter()
+	l.Hint = func(*RateLimitKey) meta.RateLimit { return meta.RateLimit{QPS: 8, Burst: 4} }
+	l.MinFactor = 1.0 / 4
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	now := time.Now()
+	qps := func(at time.Duration) float64 { return float64(l.bucket(key, now.Add(at)).Limit()) }
+
+	if got := qps(0); got != 8 {
+		t.Errorf("qps(0) = %v; want 8", got)
+	}
+	l.backoff(key, now)
+	if got := qps(0); got != 4 {
+		t.Errorf("qps(0) = %v after a backoff; want 4", got)
+	}
+	if tokens := l.buckets[*key].TokensAt(now); tokens >= 1 {
+		t.Errorf("TokensAt() = %v after a backoff; want < 1", tokens)
+	}
+	l.backoff(key, now)
+	l.backoff(key, now)
+	if got := qps(0); got != 2 {
+		t.Errorf("qps(0) = %v after 3 backoffs; want 2 (MinFactor)", got)
+	}
+	// The QPS doubles after each RecoveryInterval without errors.
+	if got := qps(l.RecoveryInterval - time.Second); got != 2 {
+		t.Errorf("qps(%v) = %v; want 2", l.RecoveryInterval-time.Second, got)
+	}
+	if got := qps(l.RecoveryInterval); got != 4 {
+		t.Errorf("qps(%v) = %v; want 4", l.RecoveryInterval, got)
+	}
+	if got := qps(10 * l.RecoveryInterval); got != 8 {
+		t.Errorf("qps(%v) = %v; want 8", 10*l.RecoveryInterval, got)
+	}
+
+	// Only quota errors back off.
+	ctx := context.Background()
+	other := &RateLimitKey{ProjectID: "proj", Operation: "List", Version: meta.VersionGA, Service: "Firewalls"}
+	for _, err := range []error{nil, errors.New("error"), &googleapi.Error{Code: http.StatusNotFound}} {
+		l.Observe(ctx, other, err)
+	}
+	if got := l.QPS(other); got != 8 {
+		t.Errorf("QPS(%+v) = %v; want 8", other, got)
+	}
+	l.Observe(ctx, other, &googleapi.Error{Code: http.StatusTooManyRequests})
+	if got := l.QPS(other); got != 4 {
+		t.Errorf("QPS(%+v) = %v after a quota error; want 4", other, got)
+	}
+	if err := l.Accept(ctx, other); err != nil {
+		t.Errorf("Accept(%+v) = %v; want nil", other, err)
+	}
+}
+
+func TestAdaptiveRateLimiterQuotaBackoff(t *testing.T) {
+	t.Parallel()
+
+	l := NewAdaptiveRateLimiter()
+	l.Hint = func(*RateLimitKey) meta.RateLimit { return meta.RateLimit{QPS: 1000, Burst: 1000} }
+	l.QuotaBackoff = &Backoff{Initial: time.Minute, Multiplier: 2}
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	now := time.Now()
+	held := func() time.Duration { return l.buckets[*key].held.Sub(now) }
+
+	l.backoff(key, now)
+	if got := held(); got != time.Minute {
+		t.Errorf("held = %v after a quota error; want 1m", got)
+	}
+	l.backoff(key, now)
+	if got := held(); got != 2*time.Minute {
+		t.Errorf("held = %v after 2 quota errors; want 2m", got)
+	}
+
+	// The calls wait until the key is no longer held.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Accept(ctx, key); err != context.DeadlineExceeded {
+		t.Errorf("Accept() = %v while held; want %v", err, context.DeadlineExceeded)
+	}
+
+	// A successful call resets the number of consecutive errors.
+	l.Observe(context.Background(), key, nil)
+	l.backoff(key, now)
+	if got := held(); got != time.Minute {
+		t.Errorf("held = %v after a success and a quota error; want 1m", got)
+	}
+}
+
+// recordingObserver records the errors passed to Observe.
+type recordingObserver struct {
+	NopRateLimiter
+	errs []error
+}
+
+func (o *recordingObserver) Observe(ctx context.Context, key *RateLimitKey, err error) {
+	o.errs = append(o.errs, err)
+}
+
+func TestRateLimitObserver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+	})
+	o := &recordingObserver{}
+	s.RateLimiter = NewObserveOnlyRateLimiter(o)
+	key := meta.GlobalKey("fw")
+	if _, err := NewGCE(s).Firewalls().Get(ctx, *key); !IsQuotaExceeded(err) {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want a quota error", key, err)
+	}
+	if len(o.errs) != 1 || !IsQuotaExceeded(o.errs[0]) {
+		t.Errorf("observed %v; want the quota error", o.errs)
+	}
+}
+
+// keyRateLimiter records the keys it accepts, failing with err.
+type keyRateLimiter struct {
+	recordingObserver
+	keys []RateLimitKey
+	err  error
+}
+
+func (l *keyRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	l.keys = append(l.keys, *key)
+	return l.err
+}
+
+func TestCompositeRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	first, second := &keyRateLimiter{}, &keyRateLimiter{}
+	rl := NewCompositeRateLimiter(first, NewObserveOnlyRateLimiter(&sleepRateLimiter{}), second)
+	if err := rl.Accept(ctx, key); err != nil {
+		t.Errorf("rl.Accept(%+v) = %v; want nil", key, err)
+	}
+	if len(first.keys) != 1 || len(second.keys) != 1 {
+		t.Errorf("accepted %v, %v; want the key by each limiter", first.keys, second.keys)
+	}
+
+	// The limiters after the one that failed are not called.
+	errLimit := errors.New("limit")
+	first.err = errLimit
+	if err := rl.Accept(ctx, key); err != errLimit {
+		t.Errorf("rl.Accept(%+v) = %v; want %v", key, err, errLimit)
+	}
+	if len(second.keys) != 1 {
+		t.Errorf("second limiter accepted %v; want 1 key", second.keys)
+	}
+
+	errQuota := &googleapi.Error{Code: http.StatusTooManyRequests}
+	rl.Observe(ctx, key, errQuota)
+	if len(first.errs) != 1 || len(second.errs) != 1 {
+		t.Errorf("observed %v, %v; want the error by each observer", first.errs, second.errs)
+	}
+}
+
+func TestScopedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	for _, tc := range []struct {
+		name  string
+		scope RateLimitScope
+		want  RateLimitKey
+	}{
+		{"GlobalScope", GlobalScope, RateLimitKey{}},
+		{"ProjectScope", ProjectScope, RateLimitKey{ProjectID: "proj"}},
+		{"ServiceScope", ServiceScope, RateLimitKey{ProjectID: "proj", Service: "Firewalls"}},
+		{"OperationScope", OperationScope, RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}},
+	} {
+		l := &keyRateLimiter{}
+		rl := NewScopedRateLimiter(tc.scope, l)
+		rl.Accept(context.Background(), key)
+		rl.Observe(context.Background(), key, nil)
+		if len(l.keys) != 1 || l.keys[0] != tc.want {
+			t.Errorf("%s: accepted %+v; want %+v", tc.name, l.keys, tc.want)
+		}
+		if len(l.errs) != 1 {
+			t.Errorf("%s: observed %v; want 1 call", tc.name, l.errs)
+		}
+	}
+
+	// The calls of a project share a bucket.
+	ctx := context.Background()
+	dl := NewDefaultRateLimiter()
+	dl.Hint = func(*RateLimitKey) meta.RateLimit { return meta.RateLimit{QPS: 20, Burst: 1} }
+	rl := NewScopedRateLimiter(ProjectScope, dl)
+	other := &RateLimitKey{ProjectID: "proj", Operation: "List", Version: meta.VersionBeta, Service: "Instances"}
+	start := time.Now()
+	for _, k := range []*RateLimitKey{key, other} {
+		if err := rl.Accept(ctx, k); err != nil {
+			t.Errorf("rl.Accept(%+v) = %v; want nil", k, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Accept() of 2 calls of a project blocked for %v, want >= 40ms", elapsed)
+	}
+	if len(dl.buckets) != 1 {
+		t.Errorf("len(buckets) = %d; want 1", len(dl.buckets))
+	}
+}
+
+func TestMinimumRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	const interval = 50 * time.Millisecond
+
+	ctx := context.Background()
+	rl := NewMinimumRateLimiter(interval)
+	key := &RateLimitKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	other := &RateLimitKey{ProjectID: "proj", Operation: "List", Version: meta.VersionGA, Service: "Firewalls"}
+
+	start := time.Now()
+	for _, k := range []*RateLimitKey{key, other} {
+		if err := rl.Accept(ctx, k); err != nil {
+			t.Errorf("rl.Accept(%+v) = %v; want nil", k, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= interval {
+		t.Errorf("Accept() of the first calls blocked for %v, want no delay", elapsed)
+	}
+
+	// A canceled call gives its slot back.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	next := rl.next[*other]
+	if err := rl.Accept(cctx, other); err != context.Canceled {
+		t.Errorf("rl.Accept(<canceled>, %+v) = %v; want %v", other, err, context.Canceled)
+	}
+	if got := rl.next[*other]; !got.Equal(next) {
+		t.Errorf("next = %v after a canceled call; want %v", got, next)
+	}
+
+	// The calls of the same key are spaced by the interval.
+	if err := rl.Accept(ctx, key); err != nil {
+		t.Errorf("rl.Accept(%+v) = %v; want nil", key, err)
+	}
+	if elapsed := time.Since(start); elapsed < interval-10*time.Millisecond {
+		t.Errorf("Accept() of a repeated call blocked for %v, want >= %v", elapsed, interval)
+	}
+}
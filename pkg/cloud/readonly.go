@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// ErrReadOnly is wrapped by the errors of the mutations refused by a
+// ReadOnlyCloud, so that errors.Is(err, ErrReadOnly) tells them apart.
+var ErrReadOnly = errors.New("the cloud is read-only")
+
+// ReadOnlyError is the error of a mutation refused by a ReadOnlyCloud. It
+// unwraps to ErrReadOnly.
+type ReadOnlyError struct {
+	// Version of the service.
+	Version meta.Version
+	// Service is the name of the service (e.g. "Firewalls").
+	Service string
+	// Operation is the method called (e.g. "Insert", "SetTarget").
+	Operation string
+	// Key of the object, nil for the changes to a project or to a list of
+	// keys.
+	Key *meta.Key
+}
+
+// Error returns the refused call, e.g. `Insert ga Firewalls Key{"fw"}: the
+// cloud is read-only`.
+func (e *ReadOnlyError) Error() string {
+	key := "-"
+	if e.Key != nil {
+		key = e.Key.String()
+	}
+	return fmt.Sprintf("%s %s %s %s: %v", e.Operation, e.Version, e.Service, key, ErrReadOnly)
+}
+
+// Unwrap returns ErrReadOnly.
+func (e *ReadOnlyError) Unwrap() error {
+	return ErrReadOnly
+}
+
+// ReadOnlyCloud is a Cloud that passes the reads (Get, List, ...) through to
+// another Cloud and fails every mutation (Insert, Delete, SetTarget, ...)
+// with a *ReadOnlyError without calling it. It guarantees that an audit or
+// reporting tool never modifies a project, even if it is miswired:
+//
+//	c := cloud.NewReadOnlyCloud(cloud.NewGCE(svc))
+//	err := c.Firewalls().Delete(ctx, key) // errors.Is(err, cloud.ErrReadOnly)
+//
+// GetOrCreate returns the object if it exists. The Wait() of the operations
+// services is passed through, since it does not modify anything.
+type ReadOnlyCloud struct {
+	c Cloud
+}
+
+// ReadOnlyCloud implements Cloud.
+var _ Cloud = (*ReadOnlyCloud)(nil)
+
+// NewReadOnlyCloud returns a ReadOnlyCloud reading from c.
+func NewReadOnlyCloud(c Cloud) *ReadOnlyCloud {
+	return &ReadOnlyCloud{c: c}
+}
+
+// SetCommonInstanceMetadata fails with a *ReadOnlyError. The other methods of
+// ProjectsOps are reads.
+func (s *readOnlyProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, m *compute.Metadata) error {
+	return &ReadOnlyError{meta.VersionGA, "Projects", "SetCommonInstanceMetadata", nil}
+}
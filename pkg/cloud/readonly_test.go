@@ -0,0 +1,134 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestReadOnlyCloud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("%s %s made by a ReadOnlyCloud", r.Method, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw"})
+		case "/compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw"}}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	c := NewReadOnlyCloud(NewGCE(s))
+	key, newKey := meta.GlobalKey("fw"), meta.GlobalKey("new")
+	obj, newObj := &ga.Firewall{Name: "fw"}, &ga.Firewall{Name: "new"}
+
+	if got, err := c.Firewalls().Get(ctx, *key); err != nil || got.Name != "fw" {
+		t.Errorf("Firewalls().Get(%v) = %v, %v; want fw, nil", key, got, err)
+	}
+	if got, err := c.Firewalls().List(ctx, filter.None); err != nil || len(got) != 1 {
+		t.Errorf("Firewalls().List() = %v, %v; want 1 firewall, nil", got, err)
+	}
+	if got, err := c.Firewalls().GetOrCreate(ctx, *key, obj); err != nil || got.Name != "fw" {
+		t.Errorf("Firewalls().GetOrCreate(%v) = %v, %v; want fw, nil", key, got, err)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		op   string
+		key  *meta.Key
+		call func() error
+	}{
+		{
+			desc: "Insert",
+			op:   "Insert",
+			key:  newKey,
+			call: func() error { return c.Firewalls().Insert(ctx, *newKey, newObj) },
+		},
+		{
+			desc: "InsertOp",
+			op:   "InsertOp",
+			key:  newKey,
+			call: func() error {
+				_, err := c.Firewalls().InsertOp(ctx, *newKey, newObj)
+				return err
+			},
+		},
+		{
+			desc: "GetOrCreate of a missing object",
+			op:   "GetOrCreate",
+			key:  newKey,
+			call: func() error {
+				_, err := c.Firewalls().GetOrCreate(ctx, *newKey, newObj)
+				return err
+			},
+		},
+		{
+			desc: "Delete",
+			op:   "Delete",
+			key:  key,
+			call: func() error { return c.Firewalls().Delete(ctx, *key) },
+		},
+		{
+			desc: "BatchDelete",
+			op:   "BatchDelete",
+			call: func() error { return c.Firewalls().BatchDelete(ctx, []meta.Key{*key, *newKey}) },
+		},
+		{
+			desc: "custom method",
+			op:   "AddInstance",
+			key:  meta.RegionalKey("tp", "us-central1"),
+			call: func() error {
+				return c.TargetPools().AddInstance(ctx, *meta.RegionalKey("tp", "us-central1"), &ga.TargetPoolsAddInstanceRequest{})
+			},
+		},
+		{
+			desc: "project metadata",
+			op:   "SetCommonInstanceMetadata",
+			call: func() error { return c.Projects().SetCommonInstanceMetadata(ctx, "proj", &ga.Metadata{}) },
+		},
+	} {
+		err := tc.call()
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: err = %v; want ErrReadOnly", tc.desc, err)
+			continue
+		}
+		var roErr *ReadOnlyError
+		if !errors.As(err, &roErr) {
+			t.Errorf("%s: err = %T; want *ReadOnlyError", tc.desc, err)
+			continue
+		}
+		if roErr.Operation != tc.op {
+			t.Errorf("%s: Operation = %q; want %q", tc.desc, roErr.Operation, tc.op)
+		}
+		if (roErr.Key == nil) != (tc.key == nil) || (tc.key != nil && *roErr.Key != *tc.key) {
+			t.Errorf("%s: Key = %v; want %v", tc.desc, roErr.Key, tc.key)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Cassette is the recording of the calls made through a RecordingCloud,
+// which a ReplayCloud serves without calling GCE. It is saved and loaded as
+// JSON, to be checked in as a test fixture.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a call recorded in a Cassette.
+type Interaction struct {
+	// Version of the service.
+	Version meta.Version `json:"version"`
+	// Service is the name of the service (e.g. "Firewalls").
+	Service string `json:"service"`
+	// Operation is the method called (e.g. "Get", "SetTarget").
+	Operation string `json:"operation"`
+	// Key of the object, nil for the calls on a collection or a project.
+	Key *meta.Key `json:"key,omitempty"`
+	// Args are the arguments of the call after the key, without the
+	// context and the Options, as a JSON list. Filters are recorded as
+	// their String().
+	Args json.RawMessage `json:"args,omitempty"`
+	// Results are the results of the call before the error, in JSON.
+	Results []json.RawMessage `json:"results,omitempty"`
+	// Error is the error of the call, nil if it succeeded.
+	Error *RecordedError `json:"error,omitempty"`
+	// Op is the operation started by InsertOp and DeleteOp.
+	Op *RecordedOp `json:"op,omitempty"`
+}
+
+// String returns a summary of the call, e.g. "Get ga Firewalls Key{"fw"}".
+func (in *Interaction) String() string {
+	key := "-"
+	if in.Key != nil {
+		key = in.Key.String()
+	}
+	return fmt.Sprintf("%s %s %s %s", in.Operation, in.Version, in.Service, key)
+}
+
+// RecordedError is the error of a recorded call or operation.
+type RecordedError struct {
+	// Code is the HTTP status code of the *googleapi.Error of the call, 0
+	// if it did not fail with one.
+	Code int `json:"code,omitempty"`
+	// Message is the message of the *googleapi.Error, or the error of the
+	// call otherwise.
+	Message string `json:"message"`
+	// Reasons are the reasons of the items of the *googleapi.Error (e.g.
+	// "notFound").
+	Reasons []string `json:"reasons,omitempty"`
+	// Operation is set if the call failed with an *OperationError.
+	Operation *OperationError `json:"operation,omitempty"`
+}
+
+// RecordedOp is the operation started by a recorded call.
+type RecordedOp struct {
+	// Key of the operation (see Op.Key()).
+	Key *meta.Key `json:"key,omitempty"`
+	// Done is true if the operation was seen to complete, by Wait() or
+	// Done(), during the recording.
+	Done bool `json:"done"`
+	// Error is the error of the operation once done.
+	Error *RecordedError `json:"error,omitempty"`
+}
+
+// Save writes the cassette to w as indented JSON.
+func (c *Cassette) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// LoadCassette reads a cassette written by Cassette.Save from r.
+func LoadCassette(r io.Reader) (*Cassette, error) {
+	c := &Cassette{}
+	if err := json.NewDecoder(r).Decode(c); err != nil {
+		return nil, fmt.Errorf("cassette: %v", err)
+	}
+	for i, in := range c.Interactions {
+		if in == nil {
+			return nil, fmt.Errorf("cassette: interaction %d: null", i)
+		}
+	}
+	return c, nil
+}
+
+// RecordingCloud is a Cloud that passes the calls through to another Cloud
+// and records each of them, with its results and its error, in a Cassette.
+// Replayed by a ReplayCloud, the cassette lets the tests of a controller run
+// against the responses of the real API without calling it (VCR style):
+//
+//	c := cloud.NewRecordingCloud(cloud.NewGCE(svc))
+//	err := reconcile(ctx, c)
+//	err = c.Cassette().Save(f)
+//
+// The operations started by InsertOp and DeleteOp are recorded with their
+// error once Wait() or Done() reports them done. UpdateWithRetry is recorded
+// as the Get and Update calls it makes.
+type RecordingCloud struct {
+	c Cloud
+
+	lock     sync.Mutex
+	cassette Cassette
+}
+
+// RecordingCloud implements Cloud.
+var _ Cloud = (*RecordingCloud)(nil)
+
+// NewRecordingCloud returns a RecordingCloud recording the calls made to c.
+func NewRecordingCloud(c Cloud) *RecordingCloud {
+	return &RecordingCloud{c: c}
+}
+
+// Cassette returns a copy of the calls recorded so far, in order.
+func (c *RecordingCloud) Cassette() *Cassette {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ret := &Cassette{}
+	for _, in := range c.cassette.Interactions {
+		in := *in
+		if in.Op != nil {
+			op := *in.Op
+			in.Op = &op
+		}
+		ret.Interactions = append(ret.Interactions, &in)
+	}
+	return ret
+}
+
+// Reset forgets the recorded calls.
+func (c *RecordingCloud) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cassette.Interactions = nil
+}
+
+// record adds the call to the cassette and returns it.
+func (c *RecordingCloud) record(version meta.Version, service, operation string, key *meta.Key, args json.RawMessage, err error, results ...interface{}) *Interaction {
+	in := &Interaction{
+		Version:   version,
+		Service:   service,
+		Operation: operation,
+		Key:       key,
+		Args:      args,
+		Error:     recordError(err),
+	}
+	for _, r := range results {
+		in.Results = append(in.Results, toJSON(r))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cassette.Interactions = append(c.cassette.Interactions, in)
+	return in
+}
+
+// recordOp returns op, started by the call in, recording its completion in
+// in.
+func (c *RecordingCloud) recordOp(in *Interaction, op Op) Op {
+	if op == nil {
+		return nil
+	}
+	c.lock.Lock()
+	in.Op = &RecordedOp{Key: op.Key()}
+	c.lock.Unlock()
+	return &recordingOp{Op: op, c: c, in: in}
+}
+
+// recordingOp is an Op whose completion is recorded by a RecordingCloud.
+type recordingOp struct {
+	Op
+	c  *RecordingCloud
+	in *Interaction
+}
+
+func (o *recordingOp) Done(ctx context.Context) (bool, error) {
+	done, err := o.Op.Done(ctx)
+	if done {
+		o.done(o.Op.Error())
+	}
+	return done, err
+}
+
+func (o *recordingOp) Wait(ctx context.Context) error {
+	err := o.Op.Wait(ctx)
+	// The wait was interrupted before the operation completed.
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+	o.done(err)
+	return err
+}
+
+// done records the completion of the operation with the error err.
+func (o *recordingOp) done(err error) {
+	o.c.lock.Lock()
+	defer o.c.lock.Unlock()
+	o.in.Op.Done, o.in.Op.Error = true, recordError(err)
+}
+
+// recordArgs returns the arguments of a call for Interaction.Args.
+func recordArgs(args ...interface{}) json.RawMessage {
+	if len(args) == 0 {
+		return nil
+	}
+	for i, a := range args {
+		if fl, ok := a.(*filter.F); ok {
+			if fl == nil {
+				args[i] = ""
+			} else {
+				args[i] = fl.String()
+			}
+		}
+	}
+	return toJSON(args)
+}
+
+// toJSON returns v in JSON. The arguments and results of the calls are
+// always valid JSON.
+func toJSON(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("cannot encode %T in JSON: %v", v, err))
+	}
+	return b
+}
+
+// recordError returns err, if any, as a RecordedError.
+func recordError(err error) *RecordedError {
+	if err == nil {
+		return nil
+	}
+	re := &RecordedError{Message: err.Error()}
+	var e *Error
+	if errors.As(err, &e) {
+		re.Message = e.Err.Error()
+	}
+	if apiErr, ok := apiError(err); ok {
+		re.Code, re.Message = apiErr.Code, apiErr.Message
+		for _, item := range apiErr.Errors {
+			re.Reasons = append(re.Reasons, item.Reason)
+		}
+	}
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		re.Operation = opErr
+	}
+	return re
+}
+
+// err returns the recorded error as returned by the call: an *OperationError
+// or a *googleapi.Error if the call failed with one, the error of the
+// context if it was cancelled, an error with the message otherwise.
+func (re *RecordedError) err() error {
+	switch {
+	case re.Operation != nil:
+		return re.Operation
+	case re.Code != 0:
+		apiErr := &googleapi.Error{Code: re.Code, Message: re.Message}
+		for _, reason := range re.Reasons {
+			apiErr.Errors = append(apiErr.Errors, googleapi.ErrorItem{Reason: reason, Message: re.Message})
+		}
+		return apiErr
+	case re.Message == context.Canceled.Error():
+		return context.Canceled
+	case re.Message == context.DeadlineExceeded.Error():
+		return context.DeadlineExceeded
+	}
+	return errors.New(re.Message)
+}
+
+// Get records the call.
+func (s *recordingProjects) Get(ctx context.Context, projectID string) (*compute.Project, error) {
+	ret0, err := s.Projects.Get(ctx, projectID)
+	s.c.record(meta.VersionGA, "Projects", "Get", nil, recordArgs(projectID), err, ret0)
+	return ret0, err
+}
+
+// SetCommonInstanceMetadata records the call.
+func (s *recordingProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, m *compute.Metadata) error {
+	err := s.Projects.SetCommonInstanceMetadata(ctx, projectID, m)
+	s.c.record(meta.VersionGA, "Projects", "SetCommonInstanceMetadata", nil, recordArgs(projectID, m), err)
+	return err
+}
+
+// Wait records the call.
+func (s *recordingGlobalOperations) Wait(ctx context.Context, key meta.Key) error {
+	err := s.GlobalOperations.Wait(ctx, key)
+	s.c.record(meta.VersionGA, "GlobalOperations", "Wait", &key, nil, err)
+	return err
+}
+
+// Wait records the call.
+func (s *recordingRegionOperations) Wait(ctx context.Context, key meta.Key) error {
+	err := s.RegionOperations.Wait(ctx, key)
+	s.c.record(meta.VersionGA, "RegionOperations", "Wait", &key, nil, err)
+	return err
+}
+
+// Wait records the call.
+func (s *recordingZoneOperations) Wait(ctx context.Context, key meta.Key) error {
+	err := s.ZoneOperations.Wait(ctx, key)
+	s.c.record(meta.VersionGA, "ZoneOperations", "Wait", &key, nil, err)
+	return err
+}
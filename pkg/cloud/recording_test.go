@@ -0,0 +1,151 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// newRecordingTestService returns a Service whose API has the firewall fw, in
+// which the operations of the inserts fail with QUOTA_EXCEEDED.
+func newRecordingTestService(t *testing.T) *Service {
+	return newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls/fw":
+			writeJSON(t, w, &ga.Firewall{Name: "fw", SelfLink: "https://www.googleapis.com/compute/v1/projects/proj/global/firewalls/fw"})
+		case "GET /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw"}}})
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "RUNNING", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE", SelfLink: "projects/proj/global/operations/op", Error: &ga.OperationError{
+				Errors: []*ga.OperationErrorErrors{{Code: "QUOTA_EXCEEDED", Message: "Quota 'FIREWALLS' exceeded"}},
+			}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+}
+
+func TestRecordingCloud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := NewRecordingCloud(NewGCE(newRecordingTestService(t)))
+	fw, missing := meta.GlobalKey("fw"), meta.GlobalKey("missing")
+
+	if got, err := c.Firewalls().Get(ctx, *fw); err != nil || got.Name != "fw" {
+		t.Errorf("Firewalls().Get(%v) = %v, %v; want fw, nil", fw, got, err)
+	}
+	if _, err := c.Firewalls().Get(ctx, *missing); !IsNotFound(err) {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want not found", missing, err)
+	}
+	if got, err := c.Firewalls().List(ctx, filter.Regexp("name", "f.*")); err != nil || len(got) != 1 {
+		t.Errorf("Firewalls().List() = %v, %v; want [fw], nil", got, err)
+	}
+	op, err := c.Firewalls().InsertOp(ctx, *meta.GlobalKey("new"), &ga.Firewall{Name: "new"})
+	if err != nil {
+		t.Fatalf("Firewalls().InsertOp() = _, %v; want nil", err)
+	}
+	if err := op.Wait(ctx); !IsOperationError(err, "QUOTA_EXCEEDED") {
+		t.Errorf("Wait() = %v; want QUOTA_EXCEEDED", err)
+	}
+
+	cassette := c.Cassette()
+	var got []string
+	for _, in := range cassette.Interactions {
+		got = append(got, in.String())
+	}
+	want := []string{
+		`Get ga Firewalls Key{"fw"}`,
+		`Get ga Firewalls Key{"missing"}`,
+		`List ga Firewalls -`,
+		`InsertOp ga Firewalls Key{"new"}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Cassette() = %q; want %q", got, want)
+	}
+	if in := cassette.Interactions[1]; in.Error == nil || in.Error.Code != http.StatusNotFound || len(in.Results) != 1 {
+		t.Errorf("Interactions[1] = %+v; want a 404 error and a nil result", in)
+	}
+	if in := cassette.Interactions[2]; string(in.Args) != `["name eq f.*"]` {
+		t.Errorf("Interactions[2].Args = %s; want the filter", in.Args)
+	}
+	in := cassette.Interactions[3]
+	if in.Op == nil || !in.Op.Done || in.Op.Key == nil || in.Op.Key.Name != "op" || in.Op.Error == nil || in.Op.Error.Operation == nil {
+		t.Errorf("Interactions[3].Op = %+v; want the done operation op with its error", in.Op)
+	}
+
+	// The cassette round trips through JSON.
+	var buf bytes.Buffer
+	if err := cassette.Save(&buf); err != nil {
+		t.Fatalf("Save() = %v; want nil", err)
+	}
+	loaded, err := LoadCassette(&buf)
+	if err != nil {
+		t.Fatalf("LoadCassette() = _, %v; want nil", err)
+	}
+	if len(loaded.Interactions) != len(cassette.Interactions) || !reflect.DeepEqual(loaded.Interactions[3].Op, cassette.Interactions[3].Op) {
+		t.Errorf("LoadCassette() = %+v; want %+v", loaded, cassette)
+	}
+	for _, bad := range []string{`[]`, `{"interactions": [null]}`} {
+		if _, err := LoadCassette(bytes.NewBufferString(bad)); err == nil {
+			t.Errorf("LoadCassette(%s) = _, nil; want error", bad)
+		}
+	}
+
+	c.Reset()
+	if n := len(c.Cassette().Interactions); n != 0 {
+		t.Errorf("len(Cassette().Interactions) after Reset() = %d; want 0", n)
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	t.Parallel()
+
+	notFound := &googleapi.Error{Code: http.StatusNotFound, Message: "The resource was not found", Errors: []googleapi.ErrorItem{{Reason: "notFound"}}}
+	other := errors.New("connection reset")
+	opErr := &OperationError{SelfLink: "op", Errors: []OperationErrorItem{{Code: "QUOTA_EXCEEDED"}}}
+	for _, tc := range []struct {
+		desc string
+		err  error
+		want func(error) bool
+	}{
+		{"not found", wrapError(&RateLimitKey{Service: "Firewalls"}, nil, notFound), IsNotFound},
+		{"operation", opErr, func(err error) bool { return IsOperationError(err, "QUOTA_EXCEEDED") }},
+		{"cancelled", context.Canceled, func(err error) bool { return err == context.Canceled }},
+		{"other", other, func(err error) bool { return err.Error() == other.Error() }},
+	} {
+		if got := recordError(tc.err).err(); !tc.want(got) {
+			t.Errorf("%s: recordError(%v).err() = %v; want the same error", tc.desc, tc.err, got)
+		}
+	}
+	if re := recordError(nil); re != nil {
+		t.Errorf("recordError(nil) = %+v; want nil", re)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"strings"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// RegionOf returns the region of location, a region (e.g. "us-central1") or
+// a zone (e.g. "us-central1-b").
+func RegionOf(location string) string {
+	if strings.Count(location, "-") < 2 {
+		return location
+	}
+	return location[:strings.LastIndex(location, "-")]
+}
+
+// regionalBasePath returns the BasePath of the clients of version calling the
+// regional endpoint of the region of location, if there is one in
+// RegionalEndpoints.
+func (g *Service) regionalBasePath(version meta.Version, location string) (string, bool) {
+	if location == "" {
+		return "", false
+	}
+	endpoint, ok := g.RegionalEndpoints[RegionOf(location)]
+	if !ok {
+		return "", false
+	}
+	vi, ok := version.Info()
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/compute/" + vi.URLName() + "/projects/", true
+}
+
+// regionalClient returns the client of version for the calls in location:
+// client itself or, if the region of location has a regional endpoint (see
+// RegionalEndpoints), a copy of client calling it. The copies share the
+// http.Client of client and are made once per region. g.lock must be held.
+func (g *Service) regionalClient(version meta.Version, location string, client interface{}) interface{} {
+	basePath, ok := g.regionalBasePath(version, location)
+	if !ok {
+		return client
+	}
+	if c, ok := g.regional[basePath]; ok {
+		return c
+	}
+	copyClient, ok := regionalClients[version]
+	if !ok {
+		return client
+	}
+	if g.regional == nil {
+		g.regional = map[string]interface{}{}
+	}
+	c := copyClient(client, basePath)
+	g.regional[basePath] = c
+	return c
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestRegionOf(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		location string
+		want     string
+	}{
+		{"", ""},
+		{"us-central1", "us-central1"},
+		{"us-central1-b", "us-central1"},
+		{"northamerica-northeast1-a", "northamerica-northeast1"},
+	} {
+		if got := RegionOf(tc.location); got != tc.want {
+			t.Errorf("RegionOf(%q) = %q; want %q", tc.location, got, tc.want)
+		}
+	}
+}
+
+func TestRegionalEndpoints(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lock  sync.Mutex
+		calls []string
+	)
+	handler := func(endpoint string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			calls = append(calls, endpoint+" "+r.Method+" "+r.URL.Path)
+			lock.Unlock()
+			switch r.Method {
+			case http.MethodPost:
+				writeJSON(t, w, &ga.Operation{Name: "op", Region: "https://www.googleapis.com/compute/v1/projects/proj/regions/us-central1"})
+			default:
+				writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+			}
+		}
+	}
+	s := newTestService(t, handler("global"))
+	rep := httptest.NewServer(handler("regional"))
+	t.Cleanup(rep.Close)
+	s.RegionalEndpoints = map[string]string{"us-central1": rep.URL + "/"}
+	c := NewGCE(s)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		desc string
+		call func() error
+		want []string
+	}{
+		{
+			desc: "regional key",
+			call: func() error {
+				_, err := c.Addresses().Get(ctx, *meta.RegionalKey("addr", "us-central1"))
+				return err
+			},
+			want: []string{"regional GET /compute/v1/projects/proj/regions/us-central1/addresses/addr"},
+		},
+		{
+			desc: "zonal key",
+			call: func() error {
+				_, err := c.Instances().Get(ctx, *meta.ZonalKey("vm", "us-central1-b"))
+				return err
+			},
+			want: []string{"regional GET /compute/v1/projects/proj/zones/us-central1-b/instances/vm"},
+		},
+		{
+			desc: "other region",
+			call: func() error {
+				_, err := c.Addresses().Get(ctx, *meta.RegionalKey("addr", "europe-west1"))
+				return err
+			},
+			want: []string{"global GET /compute/v1/projects/proj/regions/europe-west1/addresses/addr"},
+		},
+		{
+			desc: "global key",
+			call: func() error {
+				_, err := c.Firewalls().Get(ctx, *meta.GlobalKey("fw"))
+				return err
+			},
+			want: []string{"global GET /compute/v1/projects/proj/global/firewalls/fw"},
+		},
+		{
+			desc: "list in region",
+			call: func() error {
+				_, err := c.Addresses().List(ctx, "us-central1", filter.None)
+				return err
+			},
+			want: []string{"regional GET /compute/v1/projects/proj/regions/us-central1/addresses"},
+		},
+		{
+			desc: "mutation and its operation",
+			call: func() error {
+				return c.Addresses().Insert(ctx, *meta.RegionalKey("addr", "us-central1"), &ga.Address{})
+			},
+			want: []string{
+				"regional POST /compute/v1/projects/proj/regions/us-central1/addresses",
+				"regional GET /compute/v1/projects/proj/regions/us-central1/operations/op",
+			},
+		},
+	} {
+		lock.Lock()
+		calls = nil
+		lock.Unlock()
+		if err := tc.call(); err != nil {
+			t.Errorf("%s: err = %v; want nil", tc.desc, err)
+		}
+		lock.Lock()
+		if !reflect.DeepEqual(calls, tc.want) {
+			t.Errorf("%s: calls = %v; want %v", tc.desc, calls, tc.want)
+		}
+		lock.Unlock()
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// registryKey identifies a resource in the registry.
+type registryKey struct {
+	service string
+	version meta.Version
+}
+
+// Resource describes a resource known to the generated code. The registry
+// allows generic tooling (e.g. CLIs, garbage collectors) to operate over all of
+// the resources without a hand-maintained list.
+type Resource struct {
+	// Service is the name of the service (e.g. "Addresses",
+	// "GlobalAddresses").
+	Service string
+	// Version is the API version.
+	Version meta.Version
+	// Resource is the name of the resource collection in the REST API (e.g.
+	// "addresses" for both Addresses and GlobalAddresses).
+	Resource string
+	// KeyType is the scope of the key of the resource.
+	KeyType meta.KeyType
+	// ObjectType is the golang type of the object (e.g. ga.Address).
+	ObjectType reflect.Type
+	// Operations are the names of the methods of the service interface (e.g.
+	// "Get", "List", "SetLabels"). See meta.ServiceInfo.Operations().
+	Operations []string
+	// MutationsEnabled is true if the service has methods that modify
+	// resources.
+	MutationsEnabled bool
+	// RateLimits are the rate limit hints declared for the operations of the
+	// service. See RateLimit().
+	RateLimits map[string]meta.RateLimit
+	// Tags are the tags of the service (e.g. "networking"), see meta.Tag.
+	Tags []meta.Tag
+	// Accessor returns the service of the resource from c (e.g.
+	// c.GlobalAddresses()). The result implements the service interface
+	// (e.g. GlobalAddresses).
+	Accessor func(c Cloud) interface{}
+}
+
+// Supports is true if the service has the method op (e.g. "Update").
+func (r *Resource) Supports(op string) bool {
+	for _, o := range r.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimit returns the rate limit hint for the calls of operation (e.g.
+// "Get") of the service. See meta.LookupRateLimit().
+func (r *Resource) RateLimit(operation string) meta.RateLimit {
+	return meta.LookupRateLimit(r.RateLimits, operation)
+}
+
+// Resources returns all of the resources in the registry, sorted by service
+// and version.
+func Resources() []*Resource {
+	var ret []*Resource
+	for _, r := range resourceRegistry {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Service != ret[j].Service {
+			return ret[i].Service < ret[j].Service
+		}
+		return ret[i].Version < ret[j].Version
+	})
+	return ret
+}
+
+// LookupResource returns the resource for the service at the given version.
+// ok is false if the resource is not in the registry.
+func LookupResource(service string, version meta.Version) (r *Resource, ok bool) {
+	r, ok = resourceRegistry[registryKey{service, version}]
+	return r, ok
+}
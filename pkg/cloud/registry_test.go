@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestLookupResource(t *testing.T) {
+	t.Parallel()
+
+	gce := NewGCE(&Service{})
+	r, ok := LookupResource("GlobalAddresses", meta.VersionGA)
+	if !ok {
+		t.Fatalf("LookupResource(GlobalAddresses, ga) = _, false; want _, true")
+	}
+	if r.Resource != "addresses" || r.KeyType != meta.Global || r.ObjectType != reflect.TypeOf(ga.Address{}) {
+		t.Errorf("LookupResource(GlobalAddresses, ga) = %+v; want Resource addresses, KeyType global, ObjectType ga.Address", r)
+	}
+	if got, ok := r.Accessor(gce).(GlobalAddresses); !ok || got != gce.GlobalAddresses() {
+		t.Errorf("Accessor(gce) = %v; want gce.GlobalAddresses()", got)
+	}
+
+	if !r.MutationsEnabled || !r.Supports("Insert") || r.Supports("Update") {
+		t.Errorf("LookupResource(GlobalAddresses, ga) = %+v; want MutationsEnabled with Insert and without Update", r)
+	}
+	if r, _ := LookupResource("Zones", meta.VersionGA); r.MutationsEnabled || !r.Supports("List") || r.Supports("Insert") {
+		t.Errorf("LookupResource(Zones, ga) = %+v; want read-only with List", r)
+	}
+
+	if _, ok := LookupResource("GlobalAddresses", meta.VersionAlpha); ok {
+		t.Errorf("LookupResource(GlobalAddresses, alpha) = _, true; want _, false")
+	}
+	if _, ok := LookupResource("Foos", meta.VersionGA); ok {
+		t.Errorf("LookupResource(Foos, ga) = _, true; want _, false")
+	}
+}
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+
+	gce := NewGCE(&Service{})
+	resources := Resources()
+	if len(resources) != len(meta.AllServices) {
+		t.Errorf("len(Resources()) = %d; want %d", len(resources), len(meta.AllServices))
+	}
+	for i, r := range resources {
+		if i > 0 {
+			prev := resources[i-1]
+			if prev.Service > r.Service || (prev.Service == r.Service && prev.Version >= r.Version) {
+				t.Errorf("Resources()[%d] = %s/%s after %s/%s; want sorted", i, r.Service, r.Version, prev.Service, prev.Version)
+			}
+		}
+		if r.Accessor(gce) == nil {
+			t.Errorf("Resources()[%d].Accessor(gce) = nil for %s/%s", i, r.Service, r.Version)
+		}
+		if r.ObjectType.Kind() != reflect.Struct {
+			t.Errorf("Resources()[%d].ObjectType = %v for %s/%s; want a struct", i, r.ObjectType, r.Service, r.Version)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// ErrNotRecorded is wrapped by the errors of the calls that a ReplayCloud
+// cannot serve from its Cassette.
+var ErrNotRecorded = errors.New("the call was not recorded")
+
+// NotRecordedError is the error of a call that a ReplayCloud cannot serve:
+// the Cassette has no call left that matches it. It unwraps to
+// ErrNotRecorded.
+type NotRecordedError struct {
+	// Call is the call made, without results.
+	Call *Interaction
+}
+
+// Error returns the call, e.g. `Get ga Firewalls Key{"fw"}: the call was not
+// recorded`.
+func (e *NotRecordedError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Call, ErrNotRecorded)
+}
+
+// Unwrap returns ErrNotRecorded.
+func (e *NotRecordedError) Unwrap() error {
+	return ErrNotRecorded
+}
+
+// ReplayCloud is a Cloud serving the calls recorded in a Cassette by a
+// RecordingCloud, without calling GCE. Each call is answered with the
+// results and the error of the first call of the cassette that matches it
+// and has not been served yet, so that a sequence of calls (e.g. a Get
+// failing with 404, an Insert, then a successful Get) is replayed in order:
+//
+//	cassette, err := cloud.LoadCassette(f)
+//	c := cloud.NewReplayCloud(cassette)
+//	err = reconcile(ctx, c)
+//	if unused := c.Unused(); len(unused) > 0 {
+//		// The controller did not make all the calls of the recording.
+//	}
+//
+// The calls that do not match any recorded call fail with a
+// *NotRecordedError wrapping ErrNotRecorded. The errors are replayed wrapped
+// in an *Error, without the project of the call. The Op returned by InsertOp
+// and DeleteOp has completed with the recorded error of the operation.
+type ReplayCloud struct {
+	// Match returns true if call is served by the recorded call. It is
+	// MatchInteraction if nil.
+	Match func(call, recorded *Interaction) bool
+
+	lock         sync.Mutex
+	interactions []*Interaction
+	// served is true for the interactions that have been replayed.
+	served []bool
+}
+
+// ReplayCloud implements Cloud.
+var _ Cloud = (*ReplayCloud)(nil)
+
+// NewReplayCloud returns a ReplayCloud serving the calls of cassette.
+func NewReplayCloud(cassette *Cassette) *ReplayCloud {
+	return &ReplayCloud{
+		interactions: cassette.Interactions,
+		served:       make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// MatchInteraction returns true if call and recorded are calls of the same
+// method with the same key and arguments.
+func MatchInteraction(call, recorded *Interaction) bool {
+	if call.Version != recorded.Version || call.Service != recorded.Service || call.Operation != recorded.Operation {
+		return false
+	}
+	if (call.Key == nil) != (recorded.Key == nil) || call.Key != nil && *call.Key != *recorded.Key {
+		return false
+	}
+	return sameJSON(call.Args, recorded.Args)
+}
+
+// sameJSON is true if a and b are the same JSON, ignoring the white space.
+func sameJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// Unused returns the recorded calls that have not been served, in order.
+func (c *ReplayCloud) Unused() []*Interaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var ret []*Interaction
+	for i, in := range c.interactions {
+		if !c.served[i] {
+			ret = append(ret, in)
+		}
+	}
+	return ret
+}
+
+// next returns the first recorded call matching call that has not been
+// served yet, and marks it served.
+func (c *ReplayCloud) next(call *Interaction) (*Interaction, error) {
+	match := c.Match
+	if match == nil {
+		match = MatchInteraction
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, in := range c.interactions {
+		if !c.served[i] && match(call, in) {
+			c.served[i] = true
+			return in, nil
+		}
+	}
+	return nil, &NotRecordedError{call}
+}
+
+// replay serves the call: it decodes the results of the recorded call into
+// results, pointers to the results of the method, and returns its error.
+func (c *ReplayCloud) replay(version meta.Version, service, operation string, key *meta.Key, args json.RawMessage, results ...interface{}) error {
+	call := &Interaction{Version: version, Service: service, Operation: operation, Key: key, Args: args}
+	in, err := c.next(call)
+	if err != nil {
+		return err
+	}
+	if len(in.Results) != len(results) {
+		return fmt.Errorf("%v: %d results recorded, want %d", in, len(in.Results), len(results))
+	}
+	for i, r := range in.Results {
+		if err := json.Unmarshal(r, results[i]); err != nil {
+			return fmt.Errorf("%v: result %d: %v", in, i, err)
+		}
+	}
+	return replayedError(call, in.Error)
+}
+
+// replayOp serves the call starting an operation.
+func (c *ReplayCloud) replayOp(version meta.Version, service, operation string, key *meta.Key, args json.RawMessage) (Op, error) {
+	call := &Interaction{Version: version, Service: service, Operation: operation, Key: key, Args: args}
+	in, err := c.next(call)
+	if err != nil {
+		return nil, err
+	}
+	if in.Error != nil {
+		return nil, replayedError(call, in.Error)
+	}
+	op := &replayedOp{Op: DoneOp(nil)}
+	if in.Op != nil {
+		op.key = in.Op.Key
+		if in.Op.Error != nil {
+			op.Op = DoneOp(replayedError(call, in.Op.Error))
+		}
+	}
+	return op, nil
+}
+
+// replayedError returns the recorded error re of call, if any, wrapped in an
+// *Error as by the generated services.
+func replayedError(call *Interaction, re *RecordedError) error {
+	if re == nil {
+		return nil
+	}
+	rk := &RateLimitKey{Version: call.Version, Service: call.Service, Operation: call.Operation}
+	return wrapError(rk, call.Key, re.err())
+}
+
+// replayedOp is an Op served by a ReplayCloud, which has completed.
+type replayedOp struct {
+	Op
+	key *meta.Key
+}
+
+func (o *replayedOp) Key() *meta.Key {
+	return o.key
+}
+
+// Get replays the call.
+func (s *replayProjects) Get(ctx context.Context, projectID string) (*compute.Project, error) {
+	var ret0 *compute.Project
+	err := s.c.replay(meta.VersionGA, "Projects", "Get", nil, recordArgs(projectID), &ret0)
+	return ret0, err
+}
+
+// SetCommonInstanceMetadata replays the call.
+func (s *replayProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, m *compute.Metadata) error {
+	return s.c.replay(meta.VersionGA, "Projects", "SetCommonInstanceMetadata", nil, recordArgs(projectID, m))
+}
+
+// Wait replays the call.
+func (s *replayGlobalOperations) Wait(ctx context.Context, key meta.Key) error {
+	return s.c.replay(meta.VersionGA, "GlobalOperations", "Wait", &key, nil)
+}
+
+// Wait replays the call.
+func (s *replayRegionOperations) Wait(ctx context.Context, key meta.Key) error {
+	return s.c.replay(meta.VersionGA, "RegionOperations", "Wait", &key, nil)
+}
+
+// Wait replays the call.
+func (s *replayZoneOperations) Wait(ctx context.Context, key meta.Key) error {
+	return s.c.replay(meta.VersionGA, "ZoneOperations", "Wait", &key, nil)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// reconcileFirewalls makes the calls of a controller keeping the firewalls
+// fw and new.
+func reconcileFirewalls(ctx context.Context, c Cloud) error {
+	if _, err := c.Firewalls().Get(ctx, *meta.GlobalKey("fw")); err != nil {
+		return err
+	}
+	if _, err := c.Firewalls().Get(ctx, *meta.GlobalKey("new")); !IsNotFound(err) {
+		return err
+	}
+	op, err := c.Firewalls().InsertOp(ctx, *meta.GlobalKey("new"), &ga.Firewall{Name: "new"})
+	if err != nil {
+		return err
+	}
+	return op.Wait(ctx)
+}
+
+func TestReplayCloud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rc := NewRecordingCloud(NewGCE(newRecordingTestService(t)))
+	recordedErr := reconcileFirewalls(ctx, rc)
+	if !IsOperationError(recordedErr, "QUOTA_EXCEEDED") {
+		t.Fatalf("reconcileFirewalls() = %v; want QUOTA_EXCEEDED", recordedErr)
+	}
+	var buf bytes.Buffer
+	if err := rc.Cassette().Save(&buf); err != nil {
+		t.Fatalf("Save() = %v; want nil", err)
+	}
+	cassette, err := LoadCassette(&buf)
+	if err != nil {
+		t.Fatalf("LoadCassette() = _, %v; want nil", err)
+	}
+
+	// The replay makes the same calls, served from the cassette.
+	c := NewReplayCloud(cassette)
+	if err := reconcileFirewalls(ctx, c); !IsOperationError(err, "QUOTA_EXCEEDED") {
+		t.Errorf("reconcileFirewalls() = %v; want QUOTA_EXCEEDED", err)
+	}
+	if unused := c.Unused(); len(unused) != 0 {
+		t.Errorf("Unused() = %v; want none", unused)
+	}
+	// Each call is served once.
+	_, err = c.Firewalls().Get(ctx, *meta.GlobalKey("fw"))
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Firewalls().Get(fw) = _, %v; want ErrNotRecorded", err)
+	}
+	var nrErr *NotRecordedError
+	if !errors.As(err, &nrErr) || nrErr.Call.Operation != "Get" || *nrErr.Call.Key != *meta.GlobalKey("fw") {
+		t.Errorf("Firewalls().Get(fw) = _, %v; want a *NotRecordedError for the call", err)
+	}
+
+	// The replayed results and errors are those of the recording.
+	c = NewReplayCloud(cassette)
+	if got, err := c.Firewalls().Get(ctx, *meta.GlobalKey("fw")); err != nil || got.Name != "fw" || got.SelfLink == "" {
+		t.Errorf("Firewalls().Get(fw) = %+v, %v; want fw, nil", got, err)
+	}
+	_, err = c.Firewalls().Get(ctx, *meta.GlobalKey("new"))
+	var cloudErr *Error
+	if !IsNotFound(err) || !errors.As(err, &cloudErr) || cloudErr.Service != "Firewalls" || cloudErr.Operation != "Get" {
+		t.Errorf("Firewalls().Get(new) = _, %v; want a not found *Error", err)
+	}
+	// The arguments of the calls must match those of the recording.
+	if _, err := c.Firewalls().InsertOp(ctx, *meta.GlobalKey("new"), &ga.Firewall{Name: "new", Description: "other"}); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Firewalls().InsertOp(other) = _, %v; want ErrNotRecorded", err)
+	}
+	op, err := c.Firewalls().InsertOp(ctx, *meta.GlobalKey("new"), &ga.Firewall{Name: "new"})
+	if err != nil {
+		t.Fatalf("Firewalls().InsertOp(new) = _, %v; want nil", err)
+	}
+	if key := op.Key(); key == nil || key.Name != "op" {
+		t.Errorf("Key() = %v; want the key of the recorded operation", key)
+	}
+	if done, err := op.Done(ctx); !done || err != nil || !IsOperationError(op.Error(), "QUOTA_EXCEEDED") {
+		t.Errorf("Done() = %v, %v with Error() %v; want true, nil, QUOTA_EXCEEDED", done, err, op.Error())
+	}
+	if _, err := c.Firewalls().List(ctx, filter.None); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Firewalls().List() = _, %v; want ErrNotRecorded", err)
+	}
+}
+
+func TestReplayCloudMatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cassette := &Cassette{Interactions: []*Interaction{
+		{Version: meta.VersionGA, Service: "Firewalls", Operation: "List", Args: []byte(`[ "name eq a.*" ]`), Results: []json.RawMessage{[]byte(`[{"name": "a"}]`)}},
+		{Version: meta.VersionGA, Service: "Projects", Operation: "Get", Args: []byte(`["proj"]`), Results: []json.RawMessage{[]byte(`{"name": "proj"}`)}},
+		{Version: meta.VersionGA, Service: "GlobalOperations", Operation: "Wait", Key: meta.GlobalKey("op")},
+	}}
+
+	c := NewReplayCloud(cassette)
+	if _, err := c.Firewalls().List(ctx, filter.Regexp("name", "b.*")); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("List(name eq b.*) = _, %v; want ErrNotRecorded", err)
+	}
+	// The white space of the recorded JSON does not matter.
+	if got, err := c.Firewalls().List(ctx, filter.Regexp("name", "a.*")); err != nil || len(got) != 1 || got[0].Name != "a" {
+		t.Errorf("List(name eq a.*) = %v, %v; want [a], nil", got, err)
+	}
+	if got, err := c.Projects().Get(ctx, "proj"); err != nil || got.Name != "proj" {
+		t.Errorf("Projects().Get(proj) = %v, %v; want proj, nil", got, err)
+	}
+	if err := c.GlobalOperations().Wait(ctx, *meta.GlobalKey("op")); err != nil {
+		t.Errorf("GlobalOperations().Wait(op) = %v; want nil", err)
+	}
+
+	// Match replaces the matching of the calls, e.g. to ignore the
+	// arguments.
+	c = NewReplayCloud(cassette)
+	c.Match = func(call, recorded *Interaction) bool {
+		return call.Service == recorded.Service && call.Operation == recorded.Operation
+	}
+	if got, err := c.Firewalls().List(ctx, filter.Regexp("name", "b.*")); err != nil || len(got) != 1 {
+		t.Errorf("List(name eq b.*) with Match = %v, %v; want [a], nil", got, err)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// RetryPolicy is the policy for retrying the calls that fail with a
+// transient error. The retries of a call are rate limited like the first
+// attempt. Only the calls that read are retried, unless Mutations is set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call, including
+	// the first one. A value of 1 or less disables the retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It is doubled for
+	// each subsequent retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest delay between two attempts, if non-zero.
+	MaxBackoff time.Duration
+	// Backoff, if set, replaces InitialBackoff and MaxBackoff, e.g. to add
+	// jitter. Service.Backoff is used if nil.
+	Backoff *Backoff
+	// Retryable returns true if a call that failed with err can be retried.
+	// If nil, IsRetryable is used.
+	Retryable func(err error) bool
+	// Mutations, if true, also retries the calls that mutate a resource
+	// (e.g. Insert, Delete). These are not idempotent: if the API applied
+	// the attempt that failed, the retry fails, e.g. with a 409 for an
+	// Insert or a 404 for a Delete.
+	Mutations bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 4 attempts of a call,
+// backing off from 500ms to 8s, for the errors classified by IsRetryable.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+	}
+}
+
+// IsRetryable is true if err is a transient error: a googleapi.Error with a
+// 5xx status code, a quota error (see IsQuotaExceeded()) or a connection
+// reset.
+func IsRetryable(err error) bool {
+	if apiErr, ok := apiError(err); ok && apiErr.Code >= http.StatusInternalServerError {
+		return true
+	}
+	return IsQuotaExceeded(err) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// backoff returns how long to wait after the given failed attempt (1 for
+// the first one) before retrying the call, and false if the call that failed
+// with err must not be retried. mutation is true for a call that mutates a
+// resource. p may be nil, in which case there are no retries.
+func (p *RetryPolicy) backoff(attempt int, mutation bool, err error) (time.Duration, bool) {
+	if p == nil || err == nil || attempt >= p.MaxAttempts || (mutation && !p.Mutations) {
+		return 0, false
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	if !retryable(err) {
+		return 0, false
+	}
+	return p.delays().Delay(attempt), true
+}
+
+// delays returns the Backoff of the retries: Backoff or the one given by
+// InitialBackoff and MaxBackoff.
+func (p *RetryPolicy) delays() *Backoff {
+	if p.Backoff != nil {
+		return p.Backoff
+	}
+	return &Backoff{Initial: p.InitialBackoff, Multiplier: 2, Max: p.MaxBackoff}
+}
+
+// withRetries performs call, retrying it according to p. mutation is true if
+// call mutates a resource. The error of the last attempt is returned, or the
+// error of ctx if it is done while waiting to retry.
+func withRetries[R any](ctx context.Context, p *RetryPolicy, rk *RateLimitKey, mutation bool, call func() (R, error)) (R, error) {
+	for attempt := 1; ; attempt++ {
+		r, err := call()
+		d, ok := p.backoff(attempt, mutation, err)
+		if !ok {
+			return r, err
+		}
+		glog.V(4).Infof("Retrying %+v in %v after attempt %d: %v", *rk, d, attempt, err)
+
+		if err := sleep(ctx, d); err != nil {
+			var zero R
+			return zero, err
+		}
+	}
+}
+
+// sleep waits for d, or returns the error of ctx if it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
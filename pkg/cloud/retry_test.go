@@ -0,0 +1,179 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("error"), false},
+		{&googleapi.Error{Code: http.StatusInternalServerError}, true},
+		{&googleapi.Error{Code: http.StatusServiceUnavailable}, true},
+		{&googleapi.Error{Code: http.StatusTooManyRequests}, true},
+		{&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, true},
+		{&googleapi.Error{Code: http.StatusNotFound}, false},
+		{&googleapi.Error{Code: http.StatusConflict}, false},
+		{fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+	} {
+		if got := IsRetryable(tc.err); got != tc.want {
+			t.Errorf("IsRetryable(%v) = %t; want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	unavailable := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	p := &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second}
+	for _, tc := range []struct {
+		p        *RetryPolicy
+		attempt  int
+		mutation bool
+		err      error
+		want     time.Duration
+		wantOK   bool
+	}{
+		{p, 1, false, unavailable, time.Second, true},
+		{p, 2, false, unavailable, 2 * time.Second, true},
+		{p, 3, false, unavailable, 3 * time.Second, true},
+		{p, 4, false, unavailable, 3 * time.Second, true},
+		{p, 5, false, unavailable, 0, false},
+		{p, 1, false, nil, 0, false},
+		{p, 1, false, &googleapi.Error{Code: http.StatusNotFound}, 0, false},
+		{nil, 1, false, unavailable, 0, false},
+		// Without MaxBackoff, the delay keeps doubling.
+		{&RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second}, 4, false, unavailable, 8 * time.Second, true},
+		{&RetryPolicy{MaxAttempts: 2, Retryable: IsNotFound}, 1, false, &googleapi.Error{Code: http.StatusNotFound}, 0, true},
+		{&RetryPolicy{MaxAttempts: 2, Retryable: IsNotFound}, 1, false, unavailable, 0, false},
+		// The mutations are only retried with Mutations.
+		{p, 1, true, unavailable, 0, false},
+		{&RetryPolicy{MaxAttempts: 2, Mutations: true}, 1, true, unavailable, 0, true},
+	} {
+		got, ok := tc.p.backoff(tc.attempt, tc.mutation, tc.err)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("%+v.backoff(%d, %t, %v) = %v, %t; want %v, %t", tc.p, tc.attempt, tc.mutation, tc.err, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	t.Parallel()
+
+	rk := &RateLimitKey{Operation: "Get", Version: meta.VersionGA, Service: "Firewalls"}
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	unavailable := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	for _, tc := range []struct {
+		desc         string
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"success", []error{nil}, 1, false},
+		{"transient", []error{unavailable, unavailable, nil}, 3, false},
+		{"too many attempts", []error{unavailable, unavailable, unavailable, nil}, 3, true},
+		{"not retryable", []error{&googleapi.Error{Code: http.StatusNotFound}, nil}, 1, true},
+	} {
+		attempts := 0
+		_, err := withRetries(context.Background(), p, rk, false, func() (int, error) {
+			attempts++
+			return attempts, tc.errs[attempts-1]
+		})
+		if attempts != tc.wantAttempts || (err != nil) != tc.wantErr {
+			t.Errorf("%s: withRetries() = %v after %d attempts; want error %t after %d attempts", tc.desc, err, attempts, tc.wantErr, tc.wantAttempts)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}
+	if _, err := withRetries(ctx, p, rk, false, func() (int, error) { return 0, unavailable }); err != context.Canceled {
+		t.Errorf("withRetries() = %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	})
+	s.RetryPolicy = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	key := meta.GlobalKey("fw")
+	if fw, err := NewGCE(s).Firewalls().Get(ctx, *key); err != nil || fw.Name != "fw" {
+		t.Errorf("Firewalls().Get(%v) = %+v, %v; want fw, nil", key, fw, err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d requests, want 3", calls)
+	}
+}
+
+func TestRetryPolicyMutations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var inserted bool
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		// The first Insert is applied, but its response is lost.
+		if inserted {
+			http.Error(w, "alreadyExists", http.StatusConflict)
+			return
+		}
+		inserted = true
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	s.RetryPolicy = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	key := meta.GlobalKey("fw")
+	err := NewGCE(s).Firewalls().Insert(ctx, *key, &ga.Firewall{Name: "fw"})
+	if apiErr, ok := apiError(err); !ok || apiErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Firewalls().Insert(%v) = %v; want the error of the first attempt", key, err)
+	}
+
+	// With Mutations, the retry fails as the first attempt was applied.
+	inserted = false
+	s.RetryPolicy.Mutations = true
+	err = NewGCE(s).Firewalls().Insert(ctx, *key, &ga.Firewall{Name: "fw"})
+	if apiErr, ok := apiError(err); !ok || apiErr.Code != http.StatusConflict {
+		t.Errorf("Firewalls().Insert(%v) with Mutations = %v; want a conflict", key, err)
+	}
+}
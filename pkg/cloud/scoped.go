@@ -0,0 +1,36 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+// ScopedCloud gives access to the resources that exist in two scopes (e.g.
+// Disks and RegionDisks) through a single interface per resource, which calls
+// the service for the scope of the key:
+//
+//	disks := cloud.Scoped(c).AlphaDisks()
+//	obj, err := disks.Get(ctx, meta.RegionalKey("disk-1", "us-central1"))
+//
+// The resources are the meta.ScopedPairs and the interfaces are generated
+// (see meta.ScopedPair.WrapType()).
+type ScopedCloud struct {
+	c Cloud
+}
+
+// Scoped returns the ScopedCloud for c, which can be any implementation of
+// Cloud (e.g. GCE or mock.MockGCE).
+func Scoped(c Cloud) *ScopedCloud {
+	return &ScopedCloud{c}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestScoped(t *testing.T) {
+	t.Parallel()
+
+	gce := NewGCE(&Service{})
+	disks := Scoped(gce).AlphaDisks().(*alphaScopedDisks)
+	for _, tc := range []struct {
+		key  meta.Key
+		want interface{}
+	}{
+		{*meta.ZonalKey("disk-1", "us-central1-b"), gce.AlphaDisks()},
+		{*meta.RegionalKey("disk-1", "us-central1"), gce.AlphaRegionDisks()},
+	} {
+		got, err := disks.service(tc.key)
+		if err != nil || got != tc.want {
+			t.Errorf("disks.service(%v) = %v, %v; want %v, nil", tc.key, got, err, tc.want)
+		}
+	}
+	key := *meta.GlobalKey("disk-1")
+	if _, err := Scoped(gce).AlphaDisks().Get(context.Background(), key); err == nil {
+		t.Errorf("Scoped(gce).AlphaDisks().Get(_, %v) = _, nil; want error", key)
+	}
+
+	// Every pair has an accessor.
+	sc := reflect.TypeOf(&ScopedCloud{})
+	for _, p := range meta.ScopedPairs {
+		if _, ok := sc.MethodByName(p.Accessor()); !ok {
+			t.Errorf("ScopedCloud has no method %s for the pair %q", p.Accessor(), p.Name)
+		}
+	}
+}
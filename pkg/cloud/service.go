@@ -19,61 +19,324 @@ package cloud
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 
+	"github.com/golang/glog"
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
 )
 
 // Service is the top-level adapter for all of the different compute API
 // versions.
+//
+// The per-version clients can either be given directly (GA, Alpha, Beta) or
+// be constructed on first use by setting the corresponding NewGA, NewAlpha
+// or NewBeta function. Lazy construction allows consumers that only use GA
+// resources to skip the credentials, scopes and endpoints that the alpha and
+// beta APIs require.
 type Service struct {
 	GA            *ga.Service
 	Alpha         *alpha.Service
 	Beta          *beta.Service
 	ProjectRouter ProjectRouter
 	RateLimiter   RateLimiter
+	// ConcurrencyLimiter, if set, bounds the number of calls in flight,
+	// including the polls of the operations (see NewConcurrencyLimiter()).
+	ConcurrencyLimiter *ConcurrencyLimiter
+	// RetryPolicy, if set, retries the calls that fail with a transient
+	// error (see DefaultRetryPolicy()). Only the call returning the
+	// operation of a mutation is retried, not the wait for its completion.
+	RetryPolicy *RetryPolicy
+	// Backoff, if set, is the Backoff of the retries and of the polls of
+	// the operations whose RetryPolicy or PollPolicy has no Backoff of its
+	// own, replacing their other delays. A zero Backoff makes the tests
+	// wait no time.
+	Backoff *Backoff
+	// PollPolicy, if set, is the policy for polling the operations until
+	// they complete (see WaitForCompletion()).
+	PollPolicy *PollPolicy
+	// PollPolicies, if set, overrides the PollPolicy for the operations of
+	// the mutations matching a selector, e.g. to poll the creation of
+	// instances less often than the one of firewalls. The most specific
+	// selector matching a mutation is used (see NewConfiguredRateLimiter()).
+	PollPolicies map[RateLimitSelector]*PollPolicy
+	// ChangeSink, if set, receives a record of every successful mutation.
+	ChangeSink ChangeSink
+	// MetricsRecorder, if set, receives the latency and outcome of every
+	// call.
+	MetricsRecorder MetricsRecorder
+	// Tracer, if set, starts a span around every call (see Tracer).
+	Tracer Tracer
+	// CallLogger, if set, receives a CallLog for every call (see
+	// GlogCallLogger).
+	CallLogger CallLogger
+	// Interceptors are called before and after every call, in order before
+	// and in reverse order after (see Interceptor).
+	Interceptors []Interceptor
+	// BatchConcurrency is the number of calls in flight of the batch
+	// methods (e.g. BatchGet). DefaultBatchConcurrency is used if 0.
+	BatchConcurrency int
+	// Endpoints, if set, are the base URLs of the API versions to call
+	// instead of the public endpoint, e.g.
+	// "https://compute.private.googleapis.com/compute/v1/" for
+	// meta.VersionGA, a restricted VIP or a local fake server. They are
+	// set as the BasePath of the clients, given or constructed, the first
+	// time the clients are used.
+	Endpoints map[meta.Version]string
+	// RegionalEndpoints, if set, are the root URLs of the regional endpoints
+	// to call for the resources of a region and of its zones, by region
+	// (e.g. "me-central2": "https://compute.me-central2.rep.googleapis.com/"),
+	// for data residency or latency. The calls on the global resources and
+	// in the other regions use the global endpoint (see Endpoints).
+	RegionalEndpoints map[string]string
+	// UserAgent, if set, is appended to the User-Agent header of the calls
+	// to attribute them to the application (e.g. "my-controller/1.2").
+	UserAgent string
+	// QuotaProject, if set, is the project whose quota and billing are used
+	// for the calls instead of the project of the credentials. It is sent in
+	// the X-Goog-User-Project header.
+	QuotaProject string
+	// CallOptions are added to every call, e.g. googleapi.QuotaUser().
+	// Note that a fields projection (see Fields()) applies to the
+	// operations returned by the mutations as well.
+	CallOptions []googleapi.CallOption
+
+	// NewGA, if set, is called to construct the GA client the first time a
+	// GA resource is used. It is ignored if GA is non-nil.
+	NewGA func() (*ga.Service, error)
+	// NewAlpha, if set, is called to construct the Alpha client the first
+	// time an alpha resource is used. It is ignored if Alpha is non-nil.
+	NewAlpha func() (*alpha.Service, error)
+	// NewBeta, if set, is called to construct the Beta client the first time
+	// a beta resource is used. It is ignored if Beta is non-nil.
+	NewBeta func() (*beta.Service, error)
+
+	// lock guards the lazy initialization of GA, Alpha and Beta.
+	lock sync.Mutex
+	// configured are the versions whose client has been configured with
+	// Endpoints and UserAgent (see configureClient()).
+	configured map[meta.Version]bool
+	// regional are the copies of the clients calling the RegionalEndpoints,
+	// by base path (see regionalClient()).
+	regional map[string]interface{}
+}
+
+// configureClient sets the BasePath and UserAgent of the client of version
+// from Endpoints and UserAgent. It is only done once per version, before the
+// client is used. g.lock must be held.
+func (g *Service) configureClient(version meta.Version, basePath, userAgent *string) {
+	if g.configured[version] {
+		return
+	}
+	if g.configured == nil {
+		g.configured = map[meta.Version]bool{}
+	}
+	if endpoint, ok := g.Endpoints[version]; ok {
+		*basePath = strings.TrimSuffix(endpoint, "/") + "/projects/"
+	}
+	if g.UserAgent != "" {
+		*userAgent = strings.TrimSpace(*userAgent + " " + g.UserAgent)
+	}
+	g.configured[version] = true
+}
+
+// callOptions sets the headers of a call with the header h and returns its
+// CallOptions: Service.CallOptions followed by opts.
+func (g *Service) callOptions(h http.Header, opts []googleapi.CallOption) []googleapi.CallOption {
+	if g.QuotaProject != "" {
+		h.Set("X-Goog-User-Project", g.QuotaProject)
+	}
+	if len(opts) == 0 {
+		return g.CallOptions
+	}
+	ret := append([]googleapi.CallOption(nil), g.CallOptions...)
+	for _, opt := range opts {
+		// The headers set by the Interceptors are not URL parameters.
+		if ho, ok := opt.(headerOption); ok {
+			for k, v := range ho {
+				h[k] = v
+			}
+			continue
+		}
+		ret = append(ret, opt)
+	}
+	return ret
+}
+
+// fieldsOption is the CallOption of Fields().
+type fieldsOption string
+
+func (f fieldsOption) Get() (string, string) { return "fields", string(f) }
+
+// Fields returns a CallOption for Service.CallOptions restricting the fields
+// of the responses to fields (e.g. "items/name", "name,selfLink"). See
+// https://cloud.google.com/compute/docs/api/how-tos/performance.
+func Fields(fields ...string) googleapi.CallOption {
+	return fieldsOption(strings.Join(fields, ","))
+}
+
+// gaService returns the GA client for the calls in location, constructing it
+// with NewGA if needed (see regionalClient()).
+func (g *Service) gaService(location string) (*ga.Service, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.GA == nil {
+		if g.NewGA == nil {
+			return nil, fmt.Errorf("no client configured for API version %q", meta.VersionGA)
+		}
+		s, err := g.NewGA()
+		if err != nil {
+			return nil, fmt.Errorf("error creating client for API version %q: %v", meta.VersionGA, err)
+		}
+		g.GA = s
+	}
+	g.configureClient(meta.VersionGA, &g.GA.BasePath, &g.GA.UserAgent)
+	return g.regionalClient(meta.VersionGA, location, g.GA).(*ga.Service), nil
 }
 
-// wrapOperation wraps a GCE anyOP in a version generic operation type.
+// alphaService returns the Alpha client for the calls in location,
+// constructing it with NewAlpha if needed (see regionalClient()).
+func (g *Service) alphaService(location string) (*alpha.Service, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.Alpha == nil {
+		if g.NewAlpha == nil {
+			return nil, fmt.Errorf("no client configured for API version %q", meta.VersionAlpha)
+		}
+		s, err := g.NewAlpha()
+		if err != nil {
+			return nil, fmt.Errorf("error creating client for API version %q: %v", meta.VersionAlpha, err)
+		}
+		g.Alpha = s
+	}
+	g.configureClient(meta.VersionAlpha, &g.Alpha.BasePath, &g.Alpha.UserAgent)
+	return g.regionalClient(meta.VersionAlpha, location, g.Alpha).(*alpha.Service), nil
+}
+
+// betaService returns the Beta client for the calls in location,
+// constructing it with NewBeta if needed (see regionalClient()).
+func (g *Service) betaService(location string) (*beta.Service, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.Beta == nil {
+		if g.NewBeta == nil {
+			return nil, fmt.Errorf("no client configured for API version %q", meta.VersionBeta)
+		}
+		s, err := g.NewBeta()
+		if err != nil {
+			return nil, fmt.Errorf("error creating client for API version %q: %v", meta.VersionBeta, err)
+		}
+		g.Beta = s
+	}
+	g.configureClient(meta.VersionBeta, &g.Beta.BasePath, &g.Beta.UserAgent)
+	return g.regionalClient(meta.VersionBeta, location, g.Beta).(*beta.Service), nil
+}
+
+// wrapOperation wraps a GCE anyOP in a version generic operation type. The
+// operation is polled in the operations collection of its scope (see
+// operationKey()).
 func (g *Service) wrapOperation(anyOp interface{}) (operation, error) {
 	switch o := anyOp.(type) {
 	case *ga.Operation:
-		r, err := ParseResourceURL(o.SelfLink)
+		projectID, key, err := operationKey(o.SelfLink, o.Name, o.Region, o.Zone)
 		if err != nil {
 			return nil, err
 		}
-		return &gaOperation{g, o, r.ProjectID}, nil
+		return &gaOperation{g, o, projectID, key}, nil
 	case *alpha.Operation:
-		r, err := ParseResourceURL(o.SelfLink)
+		projectID, key, err := operationKey(o.SelfLink, o.Name, o.Region, o.Zone)
 		if err != nil {
 			return nil, err
 		}
-		return &alphaOperation{g, o, r.ProjectID}, nil
+		return &alphaOperation{g, o, projectID, key}, nil
 	case *beta.Operation:
-		r, err := ParseResourceURL(o.SelfLink)
+		projectID, key, err := operationKey(o.SelfLink, o.Name, o.Region, o.Zone)
 		if err != nil {
 			return nil, err
 		}
-		return &betaOperation{g, o, r.ProjectID}, nil
+		return &betaOperation{g, o, projectID, key}, nil
 	default:
 		return nil, fmt.Errorf("invalid type %T", anyOp)
 	}
 }
 
 // WaitForCompletion of a long running operation. This will poll the state of
-// GCE for the completion status of the given operation. genericOp can be one
-// of alpha, beta, ga Operation types.
+// GCE for the completion status of the given operation according to the
+// PollPolicy. genericOp can be one of alpha, beta, ga Operation types. If the
+// operation completes with errors, the error is an *OperationError.
 func (g *Service) WaitForCompletion(ctx context.Context, genericOp interface{}) error {
+	return g.WaitForCompletionWithPolicy(ctx, genericOp, g.PollPolicy)
+}
+
+// WaitForCompletionWithPolicy is WaitForCompletion polling according to p
+// instead of the PollPolicy. p may be nil.
+func (g *Service) WaitForCompletionWithPolicy(ctx context.Context, genericOp interface{}, p *PollPolicy) error {
 	op, err := g.wrapOperation(genericOp)
 	if err != nil {
 		return err
 	}
-	for done, err := op.isDone(ctx); !done; done, err = op.isDone(ctx) {
+	return g.waitForOperation(ctx, op, p)
+}
+
+// waitForOperation polls op according to p until it completes.
+func (g *Service) waitForOperation(ctx context.Context, op operation, p *PollPolicy) (err error) {
+	p = g.withBackoff(p)
+	if p == nil {
+		p = &PollPolicy{}
+	}
+	if p.Timeout > 0 {
+		parent := ctx
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+		defer func() {
+			if err != nil && parent.Err() == nil && ctx.Err() != nil {
+				err = fmt.Errorf("operation not done after %v: %w", p.Timeout, err)
+			}
+		}()
+	}
+
+	delays := p.delays()
+	for attempt := 1; ; attempt++ {
+		done, err := g.isDone(ctx, op)
+		if done {
+			return err
+		}
+		if err == nil {
+			if err = g.RateLimiter.Accept(ctx, op.rateLimitKey()); err == nil {
+				err = sleep(ctx, delays.Delay(attempt))
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// Return the error of ctx rather than the error of the poll it
+			// interrupted.
+			g.abandonOperation(op, p)
+			return ctxErr
+		}
 		if err != nil {
 			return err
 		}
-		g.RateLimiter.Accept(ctx, op.rateLimitKey())
 	}
-	return nil
+}
+
+// abandonOperation deletes op if the PollPolicy p asks for it, once the wait
+// for op was abandoned.
+func (g *Service) abandonOperation(op operation, p *PollPolicy) {
+	if !p.DeleteOnCancel {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), operationDeleteTimeout)
+	defer cancel()
+	if err := op.delete(ctx); err != nil {
+		glog.V(2).Infof("Could not delete the abandoned operation %v: %v", op.key(), err)
+	}
 }
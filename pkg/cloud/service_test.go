@@ -0,0 +1,366 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestServiceLazyInit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lock  sync.Mutex
+		calls int
+	)
+	s := &Service{
+		NewAlpha: func() (*alpha.Service, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			calls++
+			return alpha.New(http.DefaultClient)
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.alphaService(""); err != nil {
+				t.Errorf(`s.alphaService("") = _, %v; want _, nil`, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("NewAlpha called %d times, want 1", calls)
+	}
+	if s.Alpha == nil {
+		t.Errorf("s.Alpha = nil, want non-nil")
+	}
+	// No client or constructor was configured for the beta API.
+	if _, err := s.betaService(""); err == nil {
+		t.Errorf(`s.betaService("") = _, nil; want error`)
+	}
+}
+
+func TestServiceLazyInitError(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	s := &Service{
+		NewGA: func() (*ga.Service, error) {
+			if fail {
+				return nil, errors.New("injected error")
+			}
+			return ga.New(http.DefaultClient)
+		},
+	}
+	if _, err := s.gaService(""); err == nil {
+		t.Errorf(`s.gaService("") = _, nil; want error`)
+	}
+	// A failed construction is retried on the next use.
+	fail = false
+	if _, err := s.gaService(""); err != nil {
+		t.Errorf(`s.gaService("") = _, %v; want _, nil`, err)
+	}
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		writeJSON(t, w, &ga.Firewall{Name: "fw"})
+	}))
+	defer srv.Close()
+
+	client, err := ga.New(srv.Client())
+	if err != nil {
+		t.Fatalf("ga.New() = _, %v", err)
+	}
+	s := &Service{
+		GA:            client,
+		ProjectRouter: &SingleProjectRouter{ID: "proj"},
+		RateLimiter:   &NopRateLimiter{},
+		NewAlpha:      func() (*alpha.Service, error) { return alpha.New(srv.Client()) },
+		Endpoints: map[meta.Version]string{
+			meta.VersionGA:    srv.URL + "/compute/v1/",
+			meta.VersionAlpha: srv.URL + "/private/compute/alpha",
+		},
+	}
+	gce := NewGCE(s)
+	if _, err := gce.Firewalls().Get(ctx, *meta.GlobalKey("fw")); err != nil {
+		t.Errorf("Firewalls().Get() = _, %v; want nil", err)
+	}
+	if _, err := gce.AlphaAddresses().Get(ctx, *meta.RegionalKey("addr", "us-central1")); err != nil {
+		t.Errorf("AlphaAddresses().Get() = _, %v; want nil", err)
+	}
+	want := []string{
+		"/compute/v1/projects/proj/global/firewalls/fw",
+		"/private/compute/alpha/projects/proj/regions/us-central1/addresses/addr",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("requested %v; want %v", paths, want)
+	}
+}
+
+func TestServiceCallOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	type request struct {
+		userAgent, userProject, quotaUser, fields string
+	}
+	var got []request
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, request{
+			userAgent:   r.Header.Get("User-Agent"),
+			userProject: r.Header.Get("X-Goog-User-Project"),
+			quotaUser:   r.URL.Query().Get("quotaUser"),
+			fields:      r.URL.Query().Get("fields"),
+		})
+		if r.URL.Query().Get("pageToken") == "" {
+			writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw1"}}, NextPageToken: "next"})
+			return
+		}
+		writeJSON(t, w, &ga.FirewallList{Items: []*ga.Firewall{{Name: "fw2"}}})
+	})
+	s.UserAgent = "my-controller/1.2"
+	s.QuotaProject = "billing"
+	s.CallOptions = []googleapi.CallOption{googleapi.QuotaUser("user"), Fields("items/name", "nextPageToken")}
+
+	fws, err := NewGCE(s).Firewalls().List(ctx, filter.None)
+	if err != nil || len(fws) != 2 {
+		t.Fatalf("Firewalls().List() = %v, %v; want 2 items, nil", fws, err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d requests; want 2", len(got))
+	}
+	for i, r := range got {
+		if !strings.HasSuffix(r.userAgent, " my-controller/1.2") {
+			t.Errorf("request %d: User-Agent = %q; want suffix %q", i, r.userAgent, " my-controller/1.2")
+		}
+		want := request{userAgent: r.userAgent, userProject: "billing", quotaUser: "user", fields: "items/name,nextPageToken"}
+		if r != want {
+			t.Errorf("request %d = %+v; want %+v", i, r, want)
+		}
+	}
+	// The User-Agent is only suffixed once.
+	if _, err := NewGCE(s).Firewalls().List(ctx, filter.None); err != nil {
+		t.Fatalf("Firewalls().List() = _, %v; want nil", err)
+	}
+	if got[2].userAgent != got[0].userAgent {
+		t.Errorf("User-Agent = %q; want %q", got[2].userAgent, got[0].userAgent)
+	}
+}
+
+func TestPollPolicies(t *testing.T) {
+	t.Parallel()
+
+	def, insert, instances := &PollPolicy{}, &PollPolicy{}, &PollPolicy{}
+	s := &Service{
+		PollPolicy: def,
+		PollPolicies: map[RateLimitSelector]*PollPolicy{
+			{Operation: "Insert"}:                       insert,
+			{Service: "Instances", Operation: "Insert"}: instances,
+		},
+	}
+	for _, tc := range []struct {
+		rk   RateLimitKey
+		want *PollPolicy
+	}{
+		{RateLimitKey{Operation: "Insert", Version: meta.VersionGA, Service: "Instances"}, instances},
+		{RateLimitKey{Operation: "Insert", Version: meta.VersionGA, Service: "Firewalls"}, insert},
+		{RateLimitKey{Operation: "Delete", Version: meta.VersionGA, Service: "Instances"}, def},
+	} {
+		if got := s.pollPolicy(&tc.rk); got != tc.want {
+			t.Errorf("pollPolicy(%+v) = %p; want %p", tc.rk, got, tc.want)
+		}
+	}
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	t.Parallel()
+
+	op := &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"}
+	for _, tc := range []struct {
+		desc      string
+		p         *PollPolicy
+		running   int
+		wantPolls int
+		wantErr   bool
+	}{
+		{"no policy", nil, 2, 3, false},
+		{"interval", &PollPolicy{Interval: time.Millisecond, Multiplier: 2, MaxInterval: 4 * time.Millisecond}, 5, 6, false},
+		{"timeout", &PollPolicy{Interval: 50 * time.Millisecond, Timeout: 10 * time.Millisecond}, 5, 1, true},
+	} {
+		polls := 0
+		s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			status := "DONE"
+			if polls <= tc.running {
+				status = "RUNNING"
+			}
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: status})
+		})
+		s.RateLimiter = NewDefaultRateLimiter()
+		err := s.WaitForCompletionWithPolicy(context.Background(), op, tc.p)
+		if polls != tc.wantPolls || (err != nil) != tc.wantErr {
+			t.Errorf("%s: WaitForCompletionWithPolicy() = %v after %d polls; want error %t after %d polls", tc.desc, err, polls, tc.wantErr, tc.wantPolls)
+		}
+		if tc.wantErr && !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: WaitForCompletionWithPolicy() = %v; want %v", tc.desc, err, context.DeadlineExceeded)
+		}
+	}
+}
+
+func TestOperationKey(t *testing.T) {
+	t.Parallel()
+
+	const base = "https://www.googleapis.com/compute/v1/projects/proj/"
+	for _, tc := range []struct {
+		desc                         string
+		selfLink, name, region, zone string
+		wantKey                      *meta.Key
+		wantErr                      bool
+	}{
+		{desc: "global", selfLink: base + "global/operations/op", name: "op", wantKey: meta.GlobalKey("op")},
+		{desc: "regional", selfLink: base + "regions/us-central1/operations/op", name: "op", region: base + "regions/us-central1", wantKey: meta.RegionalKey("op", "us-central1")},
+		{desc: "zonal", selfLink: base + "zones/us-central1-b/operations/op", name: "op", zone: base + "zones/us-central1-b", wantKey: meta.ZonalKey("op", "us-central1-b")},
+		{desc: "region without self-link", name: "op", region: base + "regions/us-central1", wantKey: meta.RegionalKey("op", "us-central1")},
+		{desc: "zone without self-link", name: "op", zone: "projects/proj/zones/us-central1-b", wantKey: meta.ZonalKey("op", "us-central1-b")},
+		{desc: "zone name without self-link", name: "op", zone: "us-central1-b", wantErr: true},
+		{desc: "global without self-link", name: "op", wantErr: true},
+		{desc: "invalid self-link", selfLink: "op", name: "op", wantErr: true},
+	} {
+		projectID, key, err := operationKey(tc.selfLink, tc.name, tc.region, tc.zone)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: operationKey() = _, _, %v; want error %t", tc.desc, err, tc.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if projectID != "proj" || *key != *tc.wantKey {
+			t.Errorf("%s: operationKey() = %q, %v; want %q, %v", tc.desc, projectID, key, "proj", tc.wantKey)
+		}
+	}
+}
+
+func TestWaitForCompletionScope(t *testing.T) {
+	t.Parallel()
+
+	const base = "https://www.googleapis.com/compute/v1/projects/proj/"
+	for _, tc := range []struct {
+		desc     string
+		op       interface{}
+		wantPath string
+	}{
+		{"global", &ga.Operation{Name: "op", SelfLink: base + "global/operations/op"}, "/compute/v1/projects/proj/global/operations/op"},
+		{"regional", &ga.Operation{Name: "op", Region: base + "regions/us-central1", SelfLink: base + "regions/us-central1/operations/op"}, "/compute/v1/projects/proj/regions/us-central1/operations/op"},
+		{"zonal", &ga.Operation{Name: "op", Zone: base + "zones/us-central1-b", SelfLink: base + "zones/us-central1-b/operations/op"}, "/compute/v1/projects/proj/zones/us-central1-b/operations/op"},
+		{"zonal without self-link", &ga.Operation{Name: "op", Zone: base + "zones/us-central1-b"}, "/compute/v1/projects/proj/zones/us-central1-b/operations/op"},
+	} {
+		var paths []string
+		s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		})
+		if err := s.WaitForCompletion(context.Background(), tc.op); err != nil {
+			t.Errorf("%s: WaitForCompletion() = %v; want nil", tc.desc, err)
+		}
+		if want := []string{tc.wantPath}; !reflect.DeepEqual(paths, want) {
+			t.Errorf("%s: polled %v; want %v", tc.desc, paths, want)
+		}
+	}
+}
+
+func TestWaitForCompletionCancel(t *testing.T) {
+	t.Parallel()
+
+	op := &ga.Operation{Name: "op", SelfLink: "projects/proj/zones/us-central1-b/operations/op"}
+	for _, tc := range []struct {
+		desc       string
+		p          *PollPolicy
+		block      bool
+		wantDelete bool
+	}{
+		{desc: "between polls", p: &PollPolicy{Interval: time.Millisecond}},
+		{desc: "during a poll", p: &PollPolicy{}, block: true},
+		{desc: "delete", p: &PollPolicy{Interval: time.Millisecond, DeleteOnCancel: true}, wantDelete: true},
+		{desc: "delete during a poll", p: &PollPolicy{DeleteOnCancel: true}, block: true, wantDelete: true},
+	} {
+		var (
+			lock    sync.Mutex
+			deletes []string
+		)
+		s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				lock.Lock()
+				deletes = append(deletes, r.URL.Path)
+				lock.Unlock()
+				return
+			}
+			if tc.block {
+				<-r.Context().Done()
+				return
+			}
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "RUNNING"})
+		})
+		s.RateLimiter = NewDefaultRateLimiter()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		start := time.Now()
+		err := s.WaitForCompletionWithPolicy(ctx, op, tc.p)
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Errorf("%s: WaitForCompletionWithPolicy() = %v; want %v", tc.desc, err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: WaitForCompletionWithPolicy() returned after %v; want it to return promptly", tc.desc, elapsed)
+		}
+		lock.Lock()
+		got := deletes
+		lock.Unlock()
+		var want []string
+		if tc.wantDelete {
+			want = []string{"/compute/v1/projects/proj/zones/us-central1-b/operations/op"}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: deleted %v; want %v", tc.desc, got, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+// Tracer starts a span around each call made by the GCE adapters when
+// configured as Service.Tracer. It is modeled on the OpenCensus and
+// OpenTelemetry tracers, so that either can be adapted in a few lines.
+type Tracer interface {
+	// Start starts the span name (e.g. "gce.Firewalls.Insert") as a child of
+	// the span in ctx, if any, and returns the context carrying the new
+	// span. The call is made with the returned context.
+	Start(ctx context.Context, name string, attrs []SpanAttribute) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// End ends the span of a call that returned err.
+	End(err error)
+}
+
+// SpanAttribute is an attribute of a span.
+type SpanAttribute struct {
+	Key   string
+	Value string
+}
+
+// startSpan starts the span of the call described by rk on the object
+// referenced by key (nil for the calls on a collection). The span is named
+// "gce.<Service>.<Operation>" and has the attributes "gce.project",
+// "gce.version", "gce.operation" and "gce.key".
+func (g *Service) startSpan(ctx context.Context, rk *RateLimitKey, key *meta.Key) (context.Context, Span) {
+	if g.Tracer == nil {
+		return ctx, nopSpan{}
+	}
+	attrs := []SpanAttribute{
+		{"gce.project", rk.ProjectID},
+		{"gce.version", string(rk.Version)},
+		{"gce.operation", rk.Operation},
+	}
+	if key != nil {
+		attrs = append(attrs, SpanAttribute{"gce.key", key.String()})
+	}
+	return g.Tracer.Start(ctx, "gce."+rk.Service+"."+rk.Operation, attrs)
+}
+
+// nopSpan is the Span of the calls when there is no Tracer.
+type nopSpan struct{}
+
+func (nopSpan) End(error) {}
@@ -0,0 +1,123 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/bowei/gce-gen/pkg/cloud/filter"
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+type spanKey struct{}
+
+type fakeSpan struct {
+	name  string
+	attrs []SpanAttribute
+	ended bool
+	err   error
+}
+
+func (s *fakeSpan) End(err error) {
+	s.ended, s.err = true, err
+}
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attrs []SpanAttribute) (context.Context, Span) {
+	s := &fakeSpan{name: name, attrs: attrs}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// spanRateLimiter records the span in the context of the calls.
+type spanRateLimiter struct {
+	NopRateLimiter
+	spans []*fakeSpan
+}
+
+func (l *spanRateLimiter) Accept(ctx context.Context, key *RateLimitKey) error {
+	s, _ := ctx.Value(spanKey{}).(*fakeSpan)
+	l.spans = append(l.spans, s)
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.FirewallList{})
+		case "POST /compute/v1/projects/proj/global/firewalls":
+			writeJSON(t, w, &ga.Operation{Name: "op", SelfLink: "projects/proj/global/operations/op"})
+		case "GET /compute/v1/projects/proj/global/operations/op":
+			writeJSON(t, w, &ga.Operation{Name: "op", Status: "DONE"})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	tracer := &fakeTracer{}
+	rl := &spanRateLimiter{}
+	s.Tracer, s.RateLimiter = tracer, rl
+	gce := NewGCE(s)
+	key := meta.GlobalKey("fw")
+
+	if _, err := gce.Firewalls().Get(ctx, *key); !IsNotFound(err) {
+		t.Errorf("Firewalls().Get(%v) = _, %v; want http.StatusNotFound", key, err)
+	}
+	if _, err := gce.Firewalls().List(ctx, filter.None); err != nil {
+		t.Errorf("Firewalls().List() = _, %v; want nil", err)
+	}
+	if err := gce.Firewalls().Insert(ctx, *key, &ga.Firewall{}); err != nil {
+		t.Errorf("Firewalls().Insert(%v) = %v; want nil", key, err)
+	}
+
+	attrs := func(operation string, key *meta.Key) []SpanAttribute {
+		ret := []SpanAttribute{{"gce.project", "proj"}, {"gce.version", "ga"}, {"gce.operation", operation}}
+		if key != nil {
+			ret = append(ret, SpanAttribute{"gce.key", key.String()})
+		}
+		return ret
+	}
+	want := []*fakeSpan{
+		{name: "gce.Firewalls.Get", attrs: attrs("Get", key), ended: true},
+		{name: "gce.Firewalls.List", attrs: attrs("List", nil), ended: true},
+		{name: "gce.Firewalls.Insert", attrs: attrs("Insert", key), ended: true},
+	}
+	if len(tracer.spans) != len(want) {
+		t.Fatalf("got %d spans; want %d", len(tracer.spans), len(want))
+	}
+	for i, got := range tracer.spans {
+		if got.name != want[i].name || !reflect.DeepEqual(got.attrs, want[i].attrs) || !got.ended || (got.err != nil) != (i == 0) {
+			t.Errorf("spans[%d] = %+v; want %+v", i, got, want[i])
+		}
+	}
+	// The calls are made with the context of their span.
+	wantCtx := tracer.spans
+	if !reflect.DeepEqual(rl.spans, wantCtx) {
+		t.Errorf("calls made with the spans %v; want %v", rl.spans, wantCtx)
+	}
+}
@@ -24,16 +24,6 @@ import (
 	"github.com/bowei/gce-gen/pkg/cloud/meta"
 )
 
-const (
-	gaPrefix    = "https://www.googleapis.com/compute/v1/"
-	alphaPrefix = "https://www.googleapis.com/compute/alpha/"
-	betaPrefix  = "https://www.googleapis.com/compute/beta/"
-)
-
-var (
-	allPrefixes = []string{gaPrefix, alphaPrefix, betaPrefix}
-)
-
 // ResourceID identifies a GCE resource as parsed from compute resource URL.
 type ResourceID struct {
 	ProjectID string
@@ -55,6 +45,45 @@ func (r *ResourceID) Equal(other *ResourceID) bool {
 	return false
 }
 
+// Service returns the service that handles the resource at version (see
+// meta.ServiceForResourceKey()). ok is false if no service handles it.
+func (r *ResourceID) Service(version meta.Version) (s *meta.ServiceInfo, ok bool) {
+	if r.Key == nil {
+		return meta.ServiceForResource(r.Resource, version)
+	}
+	return meta.ServiceForResourceKey(r.Resource, version, r.Key.Type())
+}
+
+// RelativeResourceName returns the resource URL of r without the version
+// prefix (e.g. "projects/<proj>/zones/<zone>/instances/<name>"). It is the
+// inverse of ParseResourceURL.
+func (r *ResourceID) RelativeResourceName() string {
+	switch {
+	case r.Key == nil:
+		return "projects/" + r.ProjectID
+	case (r.Resource == "regions" || r.Resource == "zones") && r.Key.Type() == meta.Global:
+		return fmt.Sprintf("projects/%s/%s/%s", r.ProjectID, r.Resource, r.Key.Name)
+	}
+	return r.Key.Path(r.ProjectID, r.Resource, "")
+}
+
+// MarshalText encodes r as its RelativeResourceName. JSON encodes a
+// ResourceID as this string.
+func (r ResourceID) MarshalText() ([]byte, error) {
+	return []byte(r.RelativeResourceName()), nil
+}
+
+// UnmarshalText decodes a resource URL or relative resource name (see
+// ParseResourceURL).
+func (r *ResourceID) UnmarshalText(text []byte) error {
+	id, err := ParseResourceURL(string(text))
+	if err != nil {
+		return err
+	}
+	*r = *id
+	return nil
+}
+
 // ParseResourceURL parses resource URLs of the following formats:
 //
 //   projects/<proj>/global/<res>/<name>
@@ -63,19 +92,15 @@ func (r *ResourceID) Equal(other *ResourceID) bool {
 //   [https://www.googleapis.com/compute/<ver>]/projects/<proj>/global/<res>/<name>
 //   [https://www.googleapis.com/compute/<ver>]/projects/<proj>/regions/<region>/<res>/<name>
 //   [https://www.googleapis.com/compute/<ver>]/projects/<proj>/zones/<zone>/<res>/<name>
+//
+// The URLs of other endpoints (e.g. https://compute.private.googleapis.com,
+// see Service.Endpoints) are accepted if their path ends with the name of a
+// known version (e.g. ".../v1/projects/<proj>/...").
 func ParseResourceURL(url string) (*ResourceID, error) {
 	errNotValid := fmt.Errorf("%q is not a valid resource URL", url)
 
-	// Remove the "https://..." prefix if present
-	for _, prefix := range allPrefixes {
-		if strings.HasPrefix(url, prefix) {
-			if len(url) < len(prefix) {
-				return nil, errNotValid
-			}
-			url = url[len(prefix):]
-			break
-		}
-	}
+	// Remove the "https://..." prefix of the version if present.
+	url = trimEndpoint(url)
 
 	parts := strings.Split(url, "/")
 	if len(parts) < 2 || parts[0] != "projects" {
@@ -133,6 +158,47 @@ func ParseResourceURL(url string) (*ResourceID, error) {
 	return nil, errNotValid
 }
 
+// trimEndpoint returns url without the prefix of the endpoint and version,
+// e.g. "https://www.googleapis.com/compute/v1/" or
+// "http://127.0.0.1:8080/compute/v1/". url is returned as-is if it has no
+// such prefix.
+func trimEndpoint(url string) string {
+	for _, vi := range meta.Versions {
+		if strings.HasPrefix(url, vi.URLPrefix) {
+			return url[len(vi.URLPrefix):]
+		}
+	}
+	if !strings.Contains(url, "://") {
+		return url
+	}
+	i := strings.Index(url, "/projects/")
+	if i < 0 {
+		return url
+	}
+	for _, vi := range meta.Versions {
+		if strings.HasSuffix(url[:i], "/"+vi.URLName()) {
+			return url[i+1:]
+		}
+	}
+	return url
+}
+
+// KeyFromResourceURL returns the resource collection (e.g. "instances") and
+// the key of the resource URL or relative resource name url (see
+// ParseResourceURL()). It converts the SelfLinks and references in objects
+// returned by the API into keys for the service methods. The key of a project
+// URL ("projects/<proj>") is the global key of the project ID.
+func KeyFromResourceURL(url string) (string, *meta.Key, error) {
+	r, err := ParseResourceURL(url)
+	if err != nil {
+		return "", nil, err
+	}
+	if r.Key == nil {
+		return r.Resource, meta.GlobalKey(r.ProjectID), nil
+	}
+	return r.Resource, r.Key, nil
+}
+
 func copyViaJSON(dest, src interface{}) error {
 	bytes, err := json.Marshal(src)
 	if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package cloud
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -54,6 +55,15 @@ func TestParseResourceURL(t *testing.T) {
 			"https://www.googleapis.com/compute/v1/projects/some-gce-project/zones/us-central1-c/instances/instance-1",
 			&ResourceID{"some-gce-project", "instances", meta.ZonalKey("instance-1", "us-central1-c")},
 		},
+		// Self-links of other endpoints.
+		{
+			"https://compute.private.googleapis.com/compute/v1/projects/some-gce-project/global/firewalls/fw",
+			&ResourceID{"some-gce-project", "firewalls", meta.GlobalKey("fw")},
+		},
+		{
+			"http://127.0.0.1:8080/compute/beta/projects/some-gce-project/zones/us-central1-c/instances/instance-1",
+			&ResourceID{"some-gce-project", "instances", meta.ZonalKey("instance-1", "us-central1-c")},
+		},
 		{
 			"projects/some-gce-project",
 			&ResourceID{"some-gce-project", "projects", nil},
@@ -104,6 +114,8 @@ func TestParseResourceURL(t *testing.T) {
 		"projects/some-gce-project/zones/us-central1-c/res",
 		"projects/some-gce-project/zones/us-central1-c/res/name/extra",
 		"https://www.googleapis.com/compute/gamma/projects/some-gce-project/global/addresses/name",
+		"https://compute.private.googleapis.com/compute/gamma/projects/some-gce-project/global/addresses/name",
+		"https://compute.private.googleapis.com/projects/some-gce-project/global/addresses/name",
 	} {
 		r, err := ParseResourceURL(tc)
 		if err == nil {
@@ -112,6 +124,114 @@ func TestParseResourceURL(t *testing.T) {
 	}
 }
 
+func TestResourceIDEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{
+		"projects/p",
+		"projects/p/regions/us-central1",
+		"projects/p/zones/us-central1-b",
+		"projects/p/global/firewalls/fw",
+		"projects/p/regions/us-central1/addresses/addr",
+		"projects/p/zones/us-central1-b/instances/vm",
+	} {
+		r, err := ParseResourceURL(name)
+		if err != nil {
+			t.Fatalf("ParseResourceURL(%q) = _, %v; want _, nil", name, err)
+		}
+		if got := r.RelativeResourceName(); got != name {
+			t.Errorf("%+v.RelativeResourceName() = %q; want %q", r, got, name)
+		}
+
+		data, err := json.Marshal(r)
+		if err != nil || string(data) != `"`+name+`"` {
+			t.Errorf("json.Marshal(%+v) = %s, %v; want %q, nil", r, data, err, name)
+		}
+		var got ResourceID
+		if err := json.Unmarshal(data, &got); err != nil || !got.Equal(r) {
+			t.Errorf("json.Unmarshal(%s) = %v (%+v); want nil (%+v)", data, err, got, r)
+		}
+	}
+
+	// Full URLs are accepted and encoded without the version.
+	var r ResourceID
+	if err := r.UnmarshalText([]byte("https://www.googleapis.com/compute/v1/projects/p/global/firewalls/fw")); err != nil || r.RelativeResourceName() != "projects/p/global/firewalls/fw" {
+		t.Errorf("UnmarshalText(<URL>) = %v (%+v); want nil (projects/p/global/firewalls/fw)", err, r)
+	}
+	if err := json.Unmarshal([]byte(`"projects"`), &r); err == nil {
+		t.Errorf("json.Unmarshal(%q) = nil; want error", "projects")
+	}
+}
+
+func TestParseKeyPath(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []*meta.Key{
+		meta.GlobalKey("fw"),
+		meta.RegionalKey("addr", "us-central1"),
+		meta.ZonalKey("vm", "us-central1-b"),
+	} {
+		for _, v := range []meta.Version{"", meta.VersionGA, meta.VersionAlpha, meta.VersionBeta} {
+			path := key.Path("proj", "res", v)
+			r, err := ParseResourceURL(path)
+			if want := (&ResourceID{"proj", "res", key}); err != nil || !r.Equal(want) {
+				t.Errorf("ParseResourceURL(%q) = %+v, %v; want %+v, nil", path, r, err, want)
+			}
+		}
+	}
+}
+
+func TestKeyFromResourceURL(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		url          string
+		wantResource string
+		wantKey      *meta.Key
+	}{
+		{"https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-b/instances/vm", "instances", meta.ZonalKey("vm", "us-central1-b")},
+		{"projects/proj/regions/us-central1/addresses/addr", "addresses", meta.RegionalKey("addr", "us-central1")},
+		{"https://www.googleapis.com/compute/beta/projects/proj/global/firewalls/fw", "firewalls", meta.GlobalKey("fw")},
+		{"projects/proj/zones/us-central1-b", "zones", meta.GlobalKey("us-central1-b")},
+		{"projects/proj", "projects", meta.GlobalKey("proj")},
+	} {
+		resource, key, err := KeyFromResourceURL(tc.url)
+		if err != nil || resource != tc.wantResource || *key != *tc.wantKey {
+			t.Errorf("KeyFromResourceURL(%q) = %q, %v, %v; want %q, %v, nil", tc.url, resource, key, err, tc.wantResource, tc.wantKey)
+		}
+	}
+	if _, _, err := KeyFromResourceURL("projects/proj/global"); err == nil {
+		t.Errorf("KeyFromResourceURL(invalid) = _, _, nil; want error")
+	}
+}
+
+func TestResourceIDService(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		url  string
+		want string
+	}{
+		{"projects/proj/global/addresses/addr", "GlobalAddresses"},
+		{"projects/proj/regions/us-central1/addresses/addr", "Addresses"},
+		{"projects/proj/zones/us-central1-b/instances/vm", "Instances"},
+		{"projects/proj/zones/us-central1-b", "Zones"},
+		{"projects/proj/global/foos/foo", ""},
+	} {
+		r, err := ParseResourceURL(tc.url)
+		if err != nil {
+			t.Fatalf("ParseResourceURL(%q) = _, %v; want _, nil", tc.url, err)
+		}
+		var got string
+		if s, ok := r.Service(meta.VersionGA); ok {
+			got = s.Service
+		}
+		if got != tc.want {
+			t.Errorf("ParseResourceURL(%q).Service(ga) = %q; want %q", tc.url, got, tc.want)
+		}
+	}
+}
+
 type A struct {
 	A, B, C string
 }
@@ -0,0 +1,35 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+// VersionedCloud gives access to the services that are generated at more
+// than one API version through a single accessor per service, which returns
+// the service at each of its versions:
+//
+//	bs := cloud.Versioned(c).BackendServices()
+//	obj, err := bs.Alpha().Get(ctx, key)
+//
+// The accessors are generated (see <Service>Versions).
+type VersionedCloud struct {
+	c Cloud
+}
+
+// Versioned returns the VersionedCloud for c, which can be any implementation
+// of Cloud (e.g. GCE or mock.MockGCE).
+func Versioned(c Cloud) *VersionedCloud {
+	return &VersionedCloud{c}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bowei/gce-gen/pkg/cloud/meta"
+)
+
+func TestVersioned(t *testing.T) {
+	t.Parallel()
+
+	gce := NewGCE(&Service{})
+	bs := Versioned(gce).BackendServices()
+	if got := bs.GA(); got != gce.BackendServices() {
+		t.Errorf("Versioned(gce).BackendServices().GA() = %v; want gce.BackendServices()", got)
+	}
+	if got := bs.Alpha(); got != gce.AlphaBackendServices() {
+		t.Errorf("Versioned(gce).BackendServices().Alpha() = %v; want gce.AlphaBackendServices()", got)
+	}
+	if got, want := bs.Versions(), []meta.Version{meta.VersionAlpha, meta.VersionGA}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Versioned(gce).BackendServices().Versions() = %v; want %v", got, want)
+	}
+
+	// Every service with more than one version has an accessor.
+	vc := reflect.TypeOf(&VersionedCloud{})
+	for name, sg := range meta.AllServicesByGroup {
+		if _, ok := vc.MethodByName(name); ok != sg.MultiVersion() {
+			t.Errorf("VersionedCloud has method %s = %t; want %t", name, ok, sg.MultiVersion())
+		}
+	}
+}